@@ -4,6 +4,7 @@ package commands
 import (
 	"io"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -31,6 +32,7 @@ type App struct {
 	loadConfig      ConfigLoader
 	createProvider  ProviderFactory
 	newKeystore     KeystoreFactory
+	createRegistry  RegistryFactory
 	stdin           io.Reader
 	stdout          io.Writer
 	stderr          io.Writer
@@ -45,7 +47,11 @@ type App struct {
 	chatTemperature float32
 	chatMaxTokens   int
 	chatStream      bool
+	chatSession     string
+	chatTags        []string
 	initProvider    string
+	toolArgs        string
+	toolTimeout     time.Duration
 }
 
 // WithConfigLoader injects a config loader dependency.
@@ -96,6 +102,7 @@ func NewApp(opts ...AppOption) *App {
 		loadConfig:     config.LoadConfig,
 		createProvider: defaultProviderFactory(),
 		newKeystore:    keystore.NewKeystore,
+		createRegistry: defaultRegistryFactory,
 		stdin:          os.Stdin,
 		stdout:         os.Stdout,
 		stderr:         os.Stderr,
@@ -122,17 +129,25 @@ Use Iris to manage API keys, chat with models, and scaffold projects.`,
 		},
 		SilenceUsage: true,
 	}
+	// Cobra reports command errors on the app's stderr
+	root.SetErr(a.stderr)
 
 	// Global flags available to all commands.
 	root.PersistentFlags().StringVar(&a.cfgFile, "config", "", "config file (default is ~/.iris/config.yaml)")
 	root.PersistentFlags().StringVar(&a.provider, "provider", "", "provider ID (openai, anthropic, ollama)")
-	root.PersistentFlags().StringVar(&a.model, "model", "", "model ID (e.g. gpt-4o)")
+	root.PersistentFlags().StringVarP(&a.model, "model", "m", "", "model ID (e.g. gpt-4o)")
 	root.PersistentFlags().BoolVar(&a.jsonOutput, "json", false, "emit JSON output")
 	root.PersistentFlags().BoolVar(&a.verbose, "verbose", false, "enable debug logging")
 
+	root.AddCommand(a.newAskCommand())
 	root.AddCommand(a.newChatCommand())
+	root.AddCommand(a.newDoctorCommand())
 	root.AddCommand(a.newKeysCommand())
+	root.AddCommand(a.newSessionsCommand())
 	root.AddCommand(a.newInitCommand())
+	root.AddCommand(a.newNewCommand())
+	root.AddCommand(a.newToolsCommand())
+	root.AddCommand(a.newUsageCommand())
 	root.AddCommand(a.newVersionCommand())
 
 	return root
@@ -0,0 +1,176 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// askOptions holds the flags of the ask command.
+type askOptions struct {
+	system      string
+	temperature float32
+	maxTokens   int
+	timeout     time.Duration
+	noRetry     bool
+	tags        []string
+}
+
+func (a *App) newAskCommand() *cobra.Command {
+	var opts askOptions
+
+	cmd := &cobra.Command{
+		Use:   "ask [question]",
+		Short: "Ask a single question, for scripts",
+		Long: `Ask a single question and print only the answer, for use in scripts.
+
+The question is taken from the arguments, or read from stdin when there are
+none. Text output is the raw answer; --json adds the model and token usage.
+Rate-limited and failed requests are retried unless --no-retry is set.
+
+Exit codes tell error classes apart:
+  0  success
+  1  invalid input or setup, such as a missing question or API key
+  2  other provider errors
+  3  network errors and timeouts
+  4  authentication failed
+  5  rate limited
+  6  request rejected as invalid, such as an unknown model
+
+Examples:
+  echo "What is the capital of France?" | iris ask -m gpt-4o-mini
+  git diff | iris ask --system "Review this diff" --json
+  iris ask "Summarize RFC 2119 in one line"`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// runAsk reports its own errors through askError
+			cmd.SilenceErrors = true
+			return a.runAsk(args, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.system, "system", "", "System message")
+	cmd.Flags().Float32Var(&opts.temperature, "temperature", 0, "Temperature (0 = use default)")
+	cmd.Flags().IntVar(&opts.maxTokens, "max-tokens", 0, "Max tokens (0 = use default)")
+	cmd.Flags().DurationVar(&opts.timeout, "timeout", 0, "Maximum time for the request (0 = no limit)")
+	cmd.Flags().BoolVar(&opts.noRetry, "no-retry", false, "Fail at once instead of retrying rate-limited or failed requests")
+	cmd.Flags().StringArrayVar(&opts.tags, "tag", nil, "Tag the request in the usage log (repeatable)")
+	return cmd
+}
+
+func (a *App) runAsk(args []string, opts askOptions) error {
+	question := strings.Join(args, " ")
+	if question == "" {
+		data, err := io.ReadAll(a.stdin)
+		if err != nil {
+			return a.askError(exitWithCode(ExitValidation, fmt.Errorf("failed to read stdin: %w", err)))
+		}
+		question = string(data)
+	}
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return a.askError(exitWithCode(ExitValidation, errors.New("no question: pass it as an argument or on stdin")))
+	}
+
+	provider, err := a.openProvider(a.provider, a.model)
+	if err != nil {
+		return a.askError(err)
+	}
+
+	clientOpts := a.usageLogOptions(opts.tags)
+	if opts.noRetry {
+		clientOpts = append(clientOpts, core.WithRetryPolicy(noRetry{}))
+	}
+	builder := core.NewClient(provider, clientOpts...).Chat(core.ModelID(a.model))
+	if opts.system != "" {
+		builder = builder.System(opts.system)
+	}
+	builder = builder.User(question)
+	if opts.temperature > 0 {
+		builder = builder.Temperature(opts.temperature)
+	}
+	if opts.maxTokens > 0 {
+		builder = builder.MaxTokens(opts.maxTokens)
+	}
+
+	ctx := context.Background()
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
+	resp, err := builder.GetResponse(ctx)
+	if err != nil {
+		return a.askError(err)
+	}
+
+	if a.jsonOutput {
+		if err := a.outputJSON(resp); err != nil {
+			return a.askError(err)
+		}
+		return nil
+	}
+	fmt.Fprintln(a.stdout, resp.Output)
+	return nil
+}
+
+// classifyError returns the exit code and JSON error type for err.
+func classifyError(err error) (int, string) {
+	var exitErr *exitError
+	switch {
+	case errors.As(err, &exitErr):
+		return exitErr.ExitCode(), "validation_error"
+	case errors.Is(err, core.ErrUnauthorized):
+		return ExitAuth, "auth_error"
+	case errors.Is(err, core.ErrRateLimited):
+		return ExitRateLimit, "rate_limited"
+	case errors.Is(err, core.ErrBadRequest), errors.Is(err, core.ErrNotFound),
+		errors.Is(err, core.ErrUnsupportedParameter), errors.Is(err, core.ErrModelRequired),
+		errors.Is(err, core.ErrNoMessages):
+		return ExitBadRequest, "bad_request"
+	case errors.Is(err, core.ErrNetwork), errors.Is(err, context.DeadlineExceeded):
+		return ExitNetwork, "network_error"
+	default:
+		return ExitProvider, "provider_error"
+	}
+}
+
+// askError reports err on stderr, as JSON with --json, and returns it with
+// the exit code for its class.
+func (a *App) askError(err error) error {
+	code, errType := classifyError(err)
+	if !a.jsonOutput {
+		fmt.Fprintf(a.stderr, "Error: %v\n", err)
+		return exitWithCode(code, err)
+	}
+
+	detail := map[string]any{"type": errType, "message": err.Error()}
+	var provErr *core.ProviderError
+	if errors.As(err, &provErr) {
+		if provErr.Message != "" {
+			detail["message"] = provErr.Message
+		}
+		detail["provider"] = provErr.Provider
+		detail["status"] = provErr.Status
+		if provErr.RequestID != "" {
+			detail["request_id"] = provErr.RequestID
+		}
+	}
+	enc := json.NewEncoder(a.stderr)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(map[string]any{"error": detail})
+	return exitWithCode(code, err)
+}
+
+// noRetry is a core.RetryPolicy that never retries.
+type noRetry struct{}
+
+func (noRetry) NextDelay(int, error) (time.Duration, bool) { return 0, false }
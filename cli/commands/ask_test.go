@@ -0,0 +1,161 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/petal-labs/iris/cli/config"
+	"github.com/petal-labs/iris/cli/keystore"
+	"github.com/petal-labs/iris/core"
+	iristest "github.com/petal-labs/iris/testing"
+)
+
+func newAskTestApp(provider *iristest.MockProvider, stdin string) (*App, *bytes.Buffer, *bytes.Buffer) {
+	var stdout, stderr bytes.Buffer
+	app := NewApp(
+		WithConfigLoader(func(string) (*config.Config, error) {
+			return &config.Config{DefaultProvider: "openai"}, nil
+		}),
+		WithKeystoreFactory(func() (keystore.Keystore, error) { return mapKeystore{"openai": "sk-test"}, nil }),
+		WithProviderFactory(func(id, _ string, _ *config.Config) (core.Provider, error) { return provider.WithID(id), nil }),
+		WithIO(strings.NewReader(stdin), &stdout, &stderr),
+	)
+	return app, &stdout, &stderr
+}
+
+func TestAskStdin(t *testing.T) {
+	provider := iristest.NewMockProvider(core.ChatResponse{Output: "Paris"})
+	app, stdout, _ := newAskTestApp(provider, "What is the capital of France?\n")
+
+	if err := runApp(app, "ask", "-m", "gpt-4o-mini"); err != nil {
+		t.Fatalf("ask error = %v", err)
+	}
+	if stdout.String() != "Paris\n" {
+		t.Errorf("output = %q, want raw answer", stdout.String())
+	}
+
+	req := provider.LastCall().Request
+	if req.Model != "gpt-4o-mini" || req.Messages[0].Content != "What is the capital of France?" {
+		t.Errorf("request = %+v", req)
+	}
+}
+
+func TestAskArgsJSON(t *testing.T) {
+	provider := iristest.NewMockProvider(core.ChatResponse{
+		Model:        "gpt-4o-mini",
+		Output:       "4",
+		Usage:        core.TokenUsage{PromptTokens: 5, CompletionTokens: 1, TotalTokens: 6},
+		FinishReason: core.FinishStop,
+	})
+	app, stdout, _ := newAskTestApp(provider, "")
+
+	if err := runApp(app, "--json", "ask", "-m", "gpt-4o-mini", "What", "is", "2+2?"); err != nil {
+		t.Fatalf("ask error = %v", err)
+	}
+	var out struct {
+		Output       string         `json:"output"`
+		Usage        map[string]int `json:"usage"`
+		FinishReason string         `json:"finish_reason"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout.String())
+	}
+	if out.Output != "4" || out.Usage["total_tokens"] != 6 || out.FinishReason != "stop" {
+		t.Errorf("output = %s", stdout.String())
+	}
+	if got := provider.LastCall().Request.Messages[0].Content; got != "What is 2+2?" {
+		t.Errorf("question = %q", got)
+	}
+}
+
+func TestAskExitCodes(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode int
+		wantType string
+	}{
+		{"auth", core.ErrUnauthorized, ExitAuth, "auth_error"},
+		{"rate limit", &core.ProviderError{Provider: "openai", Status: 429, Message: "slow down", Err: core.ErrRateLimited}, ExitRateLimit, "rate_limited"},
+		{"bad request", core.ErrBadRequest, ExitBadRequest, "bad_request"},
+		{"unknown model", core.ErrNotFound, ExitBadRequest, "bad_request"},
+		{"network", fmt.Errorf("dial: %w", core.ErrNetwork), ExitNetwork, "network_error"},
+		{"other", errors.New("boom"), ExitProvider, "provider_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := iristest.NewMockProvider().WithError(tt.err)
+			app, _, stderr := newAskTestApp(provider, "question")
+
+			err := runApp(app, "--json", "ask", "-m", "gpt-4o-mini", "--no-retry")
+			var exitErr *exitError
+			if !errors.As(err, &exitErr) {
+				t.Fatalf("error = %v, want *exitError", err)
+			}
+			if exitErr.ExitCode() != tt.wantCode {
+				t.Errorf("ExitCode() = %d, want %d", exitErr.ExitCode(), tt.wantCode)
+			}
+
+			var out struct {
+				Error struct {
+					Type string `json:"type"`
+				} `json:"error"`
+			}
+			if err := json.Unmarshal(stderr.Bytes(), &out); err != nil {
+				t.Fatalf("stderr is not JSON: %v\n%s", err, stderr.String())
+			}
+			if out.Error.Type != tt.wantType {
+				t.Errorf("error type = %q, want %q", out.Error.Type, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestAskErrorReportedOnce(t *testing.T) {
+	t.Run("json", func(t *testing.T) {
+		provider := iristest.NewMockProvider().WithError(core.ErrUnauthorized)
+		app, _, stderr := newAskTestApp(provider, "question")
+
+		if err := runApp(app, "--json", "ask", "-m", "gpt-4o-mini", "--no-retry"); err == nil {
+			t.Fatal("ask error = nil, want error")
+		}
+
+		// stderr holds exactly one JSON document and nothing else
+		got := stderr.String()
+		dec := json.NewDecoder(strings.NewReader(got))
+		var out map[string]any
+		if err := dec.Decode(&out); err != nil {
+			t.Fatalf("stderr is not JSON: %v\n%s", err, got)
+		}
+		if err := dec.Decode(&out); err != io.EOF {
+			t.Errorf("stderr has trailing output:\n%s", got)
+		}
+	})
+
+	t.Run("text", func(t *testing.T) {
+		app, _, stderr := newAskTestApp(iristest.NewMockProvider(), "  \n")
+
+		if err := runApp(app, "ask", "-m", "gpt-4o-mini"); err == nil {
+			t.Fatal("ask error = nil, want error")
+		}
+		if n := strings.Count(stderr.String(), "Error:"); n != 1 {
+			t.Errorf("stderr reports the error %d times, want 1:\n%s", n, stderr.String())
+		}
+	})
+}
+
+func TestAskNoQuestion(t *testing.T) {
+	app, _, _ := newAskTestApp(iristest.NewMockProvider(), "  \n")
+
+	err := runApp(app, "ask", "-m", "gpt-4o-mini")
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != ExitValidation {
+		t.Errorf("error = %v, want exit code %d", err, ExitValidation)
+	}
+}
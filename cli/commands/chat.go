@@ -5,10 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
-	"github.com/petal-labs/iris/cli/keystore"
 	"github.com/petal-labs/iris/core"
 )
 
@@ -18,6 +19,11 @@ const (
 	ExitValidation = 1
 	ExitProvider   = 2
 	ExitNetwork    = 3
+
+	// Finer classes of provider errors, reported by iris ask.
+	ExitAuth       = 4
+	ExitRateLimit  = 5
+	ExitBadRequest = 6
 )
 
 func (a *App) newChatCommand() *cobra.Command {
@@ -29,7 +35,14 @@ func (a *App) newChatCommand() *cobra.Command {
 Examples:
   iris chat --provider openai --model gpt-4o --prompt "Hello"
   iris chat --prompt "Hello" --stream
-  iris chat --prompt "Hello" --json`,
+  iris chat --prompt "Hello" --json
+  iris chat --session work --prompt "Hello"
+  iris chat --session work --prompt "And then?"
+
+With --session, the conversation is saved under that name and continued
+by later commands with the same name, using the session's provider and
+model unless --provider or --model is given. --system applies when the
+session is created. See 'iris sessions' to list and export sessions.`,
 		RunE: a.runChat,
 	}
 
@@ -38,52 +51,58 @@ Examples:
 	chatCmd.Flags().Float32Var(&a.chatTemperature, "temperature", 0, "Temperature (0 = use default)")
 	chatCmd.Flags().IntVar(&a.chatMaxTokens, "max-tokens", 0, "Max tokens (0 = use default)")
 	chatCmd.Flags().BoolVar(&a.chatStream, "stream", false, "Enable streaming output")
+	chatCmd.Flags().StringVar(&a.chatSession, "session", "", "Save and continue the conversation under this name")
+	chatCmd.Flags().StringArrayVar(&a.chatTags, "tag", nil, "Tag the request in the usage log (repeatable)")
 
 	_ = chatCmd.MarkFlagRequired("prompt")
 	return chatCmd
 }
 
 func (a *App) runChat(cmd *cobra.Command, args []string) error {
-	// Validate provider.
-	providerID := a.provider
-	if providerID == "" {
-		return exitWithCode(ExitValidation, fmt.Errorf("provider required: use --provider flag or set default_provider in config"))
-	}
-
-	// Validate model.
-	modelID := a.model
-	if modelID == "" {
-		return exitWithCode(ExitValidation, fmt.Errorf("model required: use --model flag or set default_model in config"))
+	// Load the session, whose provider and model apply unless overridden.
+	var sess *chatSession
+	if a.chatSession != "" {
+		var err error
+		if sess, err = loadSession(a.chatSession); err != nil {
+			return err
+		}
+		if sess != nil {
+			if !cmd.Flags().Changed("provider") {
+				a.provider = sess.Provider
+			}
+			if !cmd.Flags().Changed("model") {
+				a.model = string(sess.Conversation.Model)
+			}
+		}
 	}
 
-	// Get API key from keystore.
-	ks, err := a.newKeystore()
+	providerID, modelID := a.provider, a.model
+	provider, err := a.openProvider(providerID, modelID)
 	if err != nil {
-		return exitWithCode(ExitValidation, fmt.Errorf("failed to open keystore: %w", err))
+		return err
 	}
 
-	apiKey, err := ks.Get(providerID)
-	if err != nil {
-		if _, ok := err.(*keystore.ErrKeyNotFound); ok {
-			return exitWithCode(ExitValidation, fmt.Errorf("no API key for %s: run 'iris keys set %s' first", providerID, providerID))
+	// Start a new session, or continue the saved one.
+	if a.chatSession != "" && sess == nil {
+		now := time.Now().UTC()
+		sess = &chatSession{Name: a.chatSession, CreatedAt: now}
+		sess.Conversation.System = a.chatSystem
+		if a.chatSystem != "" {
+			sess.Conversation.Messages = []core.Message{{Role: core.RoleSystem, Content: a.chatSystem}}
 		}
-		return exitWithCode(ExitValidation, fmt.Errorf("failed to get API key: %w", err))
-	}
-
-	// Create provider.
-	provider, err := a.createProvider(providerID, apiKey, a.cfg)
-	if err != nil {
-		return exitWithCode(ExitValidation, err)
 	}
 
 	// Create client and build request.
-	client := core.NewClient(provider)
-	builder := client.Chat(core.ModelID(modelID)).User(a.chatPrompt)
-
-	if a.chatSystem != "" {
+	client := core.NewClient(provider, a.usageLogOptions(a.chatTags)...)
+	builder := client.Chat(core.ModelID(modelID))
+	switch {
+	case sess != nil:
+		builder = builder.Messages(sess.Conversation.Messages...)
+	case a.chatSystem != "":
 		// System message should come before user message.
-		builder = client.Chat(core.ModelID(modelID)).System(a.chatSystem).User(a.chatPrompt)
+		builder = builder.System(a.chatSystem)
 	}
+	builder = builder.User(a.chatPrompt)
 	if a.chatTemperature > 0 {
 		builder = builder.Temperature(a.chatTemperature)
 	}
@@ -92,40 +111,93 @@ func (a *App) runChat(cmd *cobra.Command, args []string) error {
 	}
 
 	ctx := context.Background()
+	var output string
 	if a.chatStream {
-		return a.runStreamingChat(ctx, builder)
+		output, err = a.runStreamingChat(ctx, builder)
+	} else {
+		output, err = a.runNonStreamingChat(ctx, builder)
+	}
+	if err != nil || sess == nil {
+		return err
+	}
+
+	// Save the exchange only after a successful response.
+	sess.Provider = providerID
+	sess.Conversation.Model = core.ModelID(modelID)
+	sess.Conversation.Messages = append(sess.Conversation.Messages,
+		core.Message{Role: core.RoleUser, Content: a.chatPrompt},
+		core.Message{Role: core.RoleAssistant, Content: output},
+	)
+	sess.UpdatedAt = time.Now().UTC()
+	return saveSession(sess)
+}
+
+// openProvider checks that a provider and model are selected and creates
+// the provider with its API key.
+func (a *App) openProvider(providerID, modelID string) (core.Provider, error) {
+	// Validate provider.
+	if providerID == "" {
+		return nil, exitWithCode(ExitValidation, fmt.Errorf("provider required: use --provider flag or set default_provider in config"))
+	}
+
+	// Validate model.
+	if modelID == "" {
+		return nil, exitWithCode(ExitValidation, fmt.Errorf("model required: use --model flag or set default_model in config"))
+	}
+
+	// Get API key from keystore.
+	ks, err := a.newKeystore()
+	if err != nil {
+		return nil, exitWithCode(ExitValidation, fmt.Errorf("failed to open keystore: %w", err))
+	}
+
+	apiKey, _, err := a.resolveAPIKey(ks, providerID)
+	if err != nil {
+		return nil, exitWithCode(ExitValidation, err)
+	}
+	if apiKey == "" {
+		return nil, exitWithCode(ExitValidation, fmt.Errorf("no API key for %s: run 'iris keys set %s' first", providerID, providerID))
 	}
-	return a.runNonStreamingChat(ctx, builder)
+
+	// Create provider.
+	provider, err := a.createProvider(providerID, apiKey, a.cfg)
+	if err != nil {
+		return nil, exitWithCode(ExitValidation, err)
+	}
+	return provider, nil
 }
 
-func (a *App) runNonStreamingChat(ctx context.Context, builder *core.ChatBuilder) error {
+// runNonStreamingChat sends the request, prints the response, and returns
+// its text.
+func (a *App) runNonStreamingChat(ctx context.Context, builder *core.ChatBuilder) (string, error) {
 	resp, err := builder.GetResponse(ctx)
 	if err != nil {
-		return a.handleChatError(err)
+		return "", a.handleChatError(err)
 	}
 
 	if a.jsonOutput {
-		return a.outputJSON(resp)
+		return resp.Output, a.outputJSON(resp)
 	}
 
 	// Text output.
 	fmt.Fprintf(a.stdout, "> %s\n", a.chatPrompt)
 	fmt.Fprintln(a.stdout, resp.Output)
-	return nil
+	return resp.Output, nil
 }
 
-func (a *App) runStreamingChat(ctx context.Context, builder *core.ChatBuilder) error {
+// runStreamingChat streams the response to stdout and returns its text.
+func (a *App) runStreamingChat(ctx context.Context, builder *core.ChatBuilder) (string, error) {
 	chatStream, err := builder.Stream(ctx)
 	if err != nil {
-		return a.handleChatError(err)
+		return "", a.handleChatError(err)
 	}
 
 	if a.jsonOutput {
 		resp, err := core.DrainStream(ctx, chatStream)
 		if err != nil {
-			return a.handleChatError(err)
+			return "", a.handleChatError(err)
 		}
-		return a.outputJSON(resp)
+		return resp.Output, a.outputJSON(resp)
 	}
 
 	// Stream text output.
@@ -133,10 +205,12 @@ func (a *App) runStreamingChat(ctx context.Context, builder *core.ChatBuilder) e
 
 	var finalResp *core.ChatResponse
 	var streamErr error
+	var output strings.Builder
 
 	// Read chunks as they arrive.
 	for chunk := range chatStream.Ch {
 		fmt.Fprint(a.stdout, chunk.Delta)
+		output.WriteString(chunk.Delta)
 	}
 
 	// Check for errors.
@@ -159,7 +233,7 @@ func (a *App) runStreamingChat(ctx context.Context, builder *core.ChatBuilder) e
 	fmt.Fprintln(a.stdout)
 
 	if streamErr != nil {
-		return a.handleChatError(streamErr)
+		return "", a.handleChatError(streamErr)
 	}
 
 	// Log usage if verbose.
@@ -170,7 +244,7 @@ func (a *App) runStreamingChat(ctx context.Context, builder *core.ChatBuilder) e
 			finalResp.Usage.TotalTokens)
 	}
 
-	return nil
+	return output.String(), nil
 }
 
 func (a *App) handleChatError(err error) error {
@@ -234,6 +308,9 @@ func (a *App) outputJSON(resp *core.ChatResponse) error {
 			"total_tokens":      resp.Usage.TotalTokens,
 		},
 	}
+	if resp.FinishReason != core.FinishUnknown {
+		output["finish_reason"] = resp.FinishReason
+	}
 
 	enc := json.NewEncoder(a.stdout)
 	enc.SetIndent("", "  ")
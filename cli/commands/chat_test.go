@@ -34,6 +34,9 @@ func TestExitCodes(t *testing.T) {
 		{"validation", ExitValidation, 1},
 		{"provider", ExitProvider, 2},
 		{"network", ExitNetwork, 3},
+		{"auth", ExitAuth, 4},
+		{"rate limit", ExitRateLimit, 5},
+		{"bad request", ExitBadRequest, 6},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,241 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petal-labs/iris/cli/config"
+	"github.com/petal-labs/iris/cli/keystore"
+	"github.com/petal-labs/iris/core"
+)
+
+// pingMaxTokens bounds the completion requested by each doctor check.
+// Some models reject very small limits, so this is not 1.
+const pingMaxTokens = 16
+
+// keylessProviders can be used without an API key.
+var keylessProviders = []string{"ollama"}
+
+// Doctor check statuses.
+const (
+	checkOK   = "ok"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// doctorCheck is the result of checking one provider.
+type doctorCheck struct {
+	Provider  string `json:"provider"`
+	Status    string `json:"status"`
+	Model     string `json:"model,omitempty"`
+	KeySource string `json:"key_source,omitempty"`
+	LatencyMS int64  `json:"latency_ms,omitempty"`
+	Message   string `json:"message,omitempty"`
+
+	// code is the exit code a failed check maps to.
+	code int
+}
+
+func (a *App) newDoctorCommand() *cobra.Command {
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "doctor [provider...]",
+		Short: "Check provider keys, connectivity, and models",
+		Long: `Check that providers are set up correctly.
+
+For each provider configured in the config file or the keystore (or each
+provider named on the command line), doctor resolves the API key, sends a
+minimal chat request to the provider's default model, and reports the
+latency or an actionable error. A local Ollama server is checked without a
+key.
+
+The exit status is 0 when every check passes, and otherwise that of the
+first failure: 1 for setup problems such as missing keys, 2 for provider
+errors, and 3 for network errors.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runDoctor(args, timeout)
+		},
+	}
+	cmd.Flags().DurationVar(&timeout, "timeout", 15*time.Second, "Maximum time for each provider check")
+	return cmd
+}
+
+func (a *App) runDoctor(providerIDs []string, timeout time.Duration) error {
+	ks, ksErr := a.newKeystore()
+	if ksErr != nil {
+		ks = nil
+	}
+	if len(providerIDs) == 0 {
+		providerIDs = a.configuredProviders(ks)
+	}
+	if len(providerIDs) == 0 {
+		err := fmt.Errorf("no providers configured: run 'iris keys set <provider>' or add providers to %s", a.configPath())
+		if a.jsonOutput {
+			a.outputSimpleErrorJSON("validation_error", err.Error())
+		}
+		return exitWithCode(ExitValidation, err)
+	}
+
+	checks := make([]doctorCheck, 0, len(providerIDs))
+	for _, id := range providerIDs {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		checks = append(checks, a.checkProvider(ctx, ks, id))
+		cancel()
+	}
+
+	if a.jsonOutput {
+		if err := a.encodeJSON(checks); err != nil {
+			return err
+		}
+	} else {
+		if ksErr != nil {
+			fmt.Fprintf(a.stdout, "! keystore unavailable, using config keys only: %v\n", ksErr)
+		}
+		for _, c := range checks {
+			a.printCheck(c)
+		}
+	}
+
+	for _, c := range checks {
+		if c.Status == checkFail {
+			return exitWithCode(c.code, fmt.Errorf("%s: %s", c.Provider, c.Message))
+		}
+	}
+	return nil
+}
+
+// configuredProviders returns the providers named in the config, the
+// keystore, or as the default, sorted.
+func (a *App) configuredProviders(ks keystore.Keystore) []string {
+	var ids []string
+	if a.cfg != nil {
+		for id := range a.cfg.Providers {
+			ids = append(ids, id)
+		}
+	}
+	if a.provider != "" {
+		ids = append(ids, a.provider)
+	}
+	if ks != nil {
+		if names, err := ks.List(); err == nil {
+			ids = append(ids, names...)
+		}
+	}
+	sort.Strings(ids)
+	return slices.Compact(ids)
+}
+
+func (a *App) configPath() string {
+	if a.cfgFile != "" {
+		return a.cfgFile
+	}
+	return config.DefaultConfigPath()
+}
+
+func (a *App) checkProvider(ctx context.Context, ks keystore.Keystore, providerID string) doctorCheck {
+	c := doctorCheck{Provider: providerID}
+	fail := func(code int, format string, args ...any) doctorCheck {
+		c.Status, c.code, c.Message = checkFail, code, fmt.Sprintf(format, args...)
+		return c
+	}
+
+	apiKey, source, err := a.resolveAPIKey(ks, providerID)
+	if err != nil {
+		return fail(ExitValidation, "%v", err)
+	}
+	c.KeySource = source
+	if apiKey == "" && !slices.Contains(keylessProviders, providerID) {
+		return fail(ExitValidation, "no API key: run 'iris keys set %s' or set api_key_env in the config", providerID)
+	}
+
+	provider, err := a.createProvider(providerID, apiKey, a.cfg)
+	if err != nil {
+		return fail(ExitValidation, "%v", err)
+	}
+
+	c.Model = a.checkModel(provider)
+	if c.Model == "" {
+		c.Status, c.Message = checkWarn, fmt.Sprintf("key found but not verified: set default_model for %s in the config", providerID)
+		return c
+	}
+
+	start := time.Now()
+	maxTokens := pingMaxTokens
+	_, err = provider.Chat(ctx, &core.ChatRequest{
+		Model:     core.ModelID(c.Model),
+		Messages:  []core.Message{{Role: core.RoleUser, Content: "ping"}},
+		MaxTokens: &maxTokens,
+	})
+	c.LatencyMS = time.Since(start).Milliseconds()
+	if err != nil {
+		return a.diagnose(c, err)
+	}
+	c.Status = checkOK
+	return c
+}
+
+// checkModel returns the model to check: the --model flag for the default
+// provider, then the provider's default_model, then its first known model.
+func (a *App) checkModel(provider core.Provider) string {
+	id := provider.ID()
+	if id == a.provider && a.model != "" {
+		return a.model
+	}
+	if a.cfg != nil {
+		if pc := a.cfg.GetProvider(id); pc != nil && pc.DefaultModel != "" {
+			return pc.DefaultModel
+		}
+	}
+	if models := provider.Models(); len(models) > 0 {
+		return string(models[0].ID)
+	}
+	return ""
+}
+
+// diagnose turns a failed ping into an actionable message.
+func (a *App) diagnose(c doctorCheck, err error) doctorCheck {
+	c.Status, c.code = checkFail, ExitProvider
+	switch {
+	case errors.Is(err, core.ErrUnauthorized):
+		c.Message = fmt.Sprintf("API key rejected: replace it with 'iris keys set %s'", c.Provider)
+	case errors.Is(err, core.ErrRateLimited):
+		c.Status, c.code = checkWarn, 0
+		c.Message = "key accepted but rate limited: check your plan's quota"
+	case errors.Is(err, core.ErrNotFound) && c.Provider == "ollama":
+		c.Message = fmt.Sprintf("model %s is not available: run 'ollama pull %s'", c.Model, c.Model)
+	case errors.Is(err, core.ErrNotFound):
+		c.Message = fmt.Sprintf("model %s is not available: set default_model for %s in the config", c.Model, c.Provider)
+	case errors.Is(err, core.ErrNetwork), errors.Is(err, context.DeadlineExceeded):
+		c.code = ExitNetwork
+		if c.Provider == "ollama" {
+			c.Message = fmt.Sprintf("cannot reach %s: is it running? Start it with 'ollama serve' or set base_url", c.Provider)
+		} else {
+			c.Message = fmt.Sprintf("cannot reach %s: check your network, proxy, or base_url (%v)", c.Provider, err)
+		}
+	default:
+		c.Message = err.Error()
+	}
+	return c
+}
+
+func (a *App) printCheck(c doctorCheck) {
+	mark := map[string]string{checkOK: "✓", checkWarn: "!", checkFail: "✗"}[c.Status]
+	line := fmt.Sprintf("%s %s", mark, c.Provider)
+	if c.Model != "" {
+		line += " (" + c.Model + ")"
+	}
+	if c.Status == checkOK {
+		line += fmt.Sprintf(": %dms", c.LatencyMS)
+	}
+	if c.Message != "" {
+		line += ": " + c.Message
+	}
+	fmt.Fprintln(a.stdout, line)
+}
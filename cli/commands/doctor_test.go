@@ -0,0 +1,149 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/petal-labs/iris/cli/config"
+	"github.com/petal-labs/iris/cli/keystore"
+	"github.com/petal-labs/iris/core"
+	iristest "github.com/petal-labs/iris/testing"
+)
+
+// mapKeystore is an in-memory keystore for tests.
+type mapKeystore map[string]string
+
+func (m mapKeystore) Set(name, value string) error { m[name] = value; return nil }
+func (m mapKeystore) Delete(name string) error     { delete(m, name); return nil }
+
+func (m mapKeystore) Get(name string) (string, error) {
+	if v, ok := m[name]; ok {
+		return v, nil
+	}
+	return "", &keystore.ErrKeyNotFound{Name: name}
+}
+
+func (m mapKeystore) List() ([]string, error) {
+	var names []string
+	for name := range m {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+func newDoctorTestApp(cfg *config.Config, keys mapKeystore, providers map[string]*iristest.MockProvider) (*App, *bytes.Buffer) {
+	var stdout bytes.Buffer
+	app := NewApp(
+		WithConfigLoader(func(string) (*config.Config, error) { return cfg, nil }),
+		WithKeystoreFactory(func() (keystore.Keystore, error) { return keys, nil }),
+		WithProviderFactory(func(id, apiKey string, _ *config.Config) (core.Provider, error) {
+			if p, ok := providers[id]; ok {
+				return p.WithID(id), nil
+			}
+			return nil, errors.New("unsupported provider: " + id)
+		}),
+		WithIO(strings.NewReader(""), &stdout, &bytes.Buffer{}),
+	)
+	return app, &stdout
+}
+
+func TestDoctorAllHealthy(t *testing.T) {
+	cfg := &config.Config{Providers: map[string]config.ProviderConfig{
+		"ollama": {DefaultModel: "llama3.2"},
+	}}
+	openai := iristest.NewMockProvider()
+	ollama := iristest.NewMockProvider()
+	app, stdout := newDoctorTestApp(cfg, mapKeystore{"openai": "sk-test"}, map[string]*iristest.MockProvider{
+		"openai": openai,
+		"ollama": ollama,
+	})
+
+	if err := runApp(app, "doctor"); err != nil {
+		t.Fatalf("doctor error = %v\n%s", err, stdout.String())
+	}
+	out := stdout.String()
+	for _, want := range []string{"✓ ollama (llama3.2)", "✓ openai (mock-model)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if got := ollama.LastCall().Request.Model; got != "llama3.2" {
+		t.Errorf("ollama checked with model %q, want llama3.2", got)
+	}
+}
+
+func TestDoctorMissingKey(t *testing.T) {
+	app, stdout := newDoctorTestApp(&config.Config{}, mapKeystore{}, nil)
+
+	err := runApp(app, "doctor", "anthropic")
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != ExitValidation {
+		t.Fatalf("error = %v, want exit code %d", err, ExitValidation)
+	}
+	if !strings.Contains(stdout.String(), "iris keys set anthropic") {
+		t.Errorf("output should suggest setting the key:\n%s", stdout.String())
+	}
+}
+
+func TestDoctorNoProviders(t *testing.T) {
+	app, _ := newDoctorTestApp(&config.Config{}, mapKeystore{}, nil)
+
+	var exitErr *exitError
+	if err := runApp(app, "doctor"); !errors.As(err, &exitErr) || exitErr.ExitCode() != ExitValidation {
+		t.Fatalf("error = %v, want exit code %d", err, ExitValidation)
+	}
+}
+
+func TestDoctorDiagnosis(t *testing.T) {
+	tests := []struct {
+		name       string
+		provider   string
+		err        error
+		wantStatus string
+		wantCode   int
+		wantMsg    string
+	}{
+		{"unauthorized", "openai", core.ErrUnauthorized, checkFail, ExitProvider, "iris keys set openai"},
+		{"rate limited", "openai", core.ErrRateLimited, checkWarn, ExitSuccess, "rate limited"},
+		{"model missing", "openai", core.ErrNotFound, checkFail, ExitProvider, "set default_model"},
+		{"ollama model missing", "ollama", core.ErrNotFound, checkFail, ExitProvider, "ollama pull mock-model"},
+		{"network", "openai", core.ErrNetwork, checkFail, ExitNetwork, "cannot reach openai"},
+		{"ollama not running", "ollama", core.ErrNetwork, checkFail, ExitNetwork, "ollama serve"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := iristest.NewMockProvider().WithError(tt.err)
+			app, stdout := newDoctorTestApp(&config.Config{}, mapKeystore{"openai": "sk-test"}, map[string]*iristest.MockProvider{
+				tt.provider: p,
+			})
+
+			err := runApp(app, "--json", "doctor", tt.provider)
+			code := ExitSuccess
+			var exitErr *exitError
+			if errors.As(err, &exitErr) {
+				code = exitErr.ExitCode()
+			}
+			if code != tt.wantCode {
+				t.Errorf("exit code = %d, want %d (err = %v)", code, tt.wantCode, err)
+			}
+
+			var checks []doctorCheck
+			if err := json.Unmarshal(stdout.Bytes(), &checks); err != nil {
+				t.Fatalf("output is not JSON: %v\n%s", err, stdout.String())
+			}
+			if len(checks) != 1 {
+				t.Fatalf("got %d checks, want 1", len(checks))
+			}
+			if checks[0].Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", checks[0].Status, tt.wantStatus)
+			}
+			if !strings.Contains(checks[0].Message, tt.wantMsg) {
+				t.Errorf("Message = %q, want it to contain %q", checks[0].Message, tt.wantMsg)
+			}
+		})
+	}
+}
@@ -159,6 +159,8 @@ func defaultModel(provider string) string {
 		return "glm-4.7-flash"
 	case "ollama":
 		return "llama3.2"
+	case "cerebras":
+		return "llama-3.3-70b"
 	default:
 		return "default"
 	}
@@ -0,0 +1,523 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+func (a *App) newNewCommand() *cobra.Command {
+	newCmd := &cobra.Command{
+		Use:   "new",
+		Short: "Generate skeletons for new tools and providers",
+	}
+
+	var toolDir, toolPackage string
+	toolCmd := &cobra.Command{
+		Use:   "tool <name>",
+		Short: "Generate a tool skeleton",
+		Long: `Generate a tool skeleton: an argument struct with a generated schema, a
+type implementing tools.Tool, and tests.
+
+The name is the snake_case tool name the model sees. Creates <name>.go and
+<name>_test.go in --dir.
+
+Example:
+  iris new tool get_weather --dir ./tools`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runNewTool(args[0], toolDir, toolPackage)
+		},
+	}
+	toolCmd.Flags().StringVar(&toolDir, "dir", ".", "Directory to write the files to")
+	toolCmd.Flags().StringVar(&toolPackage, "package", "", "Go package name (default: the directory name)")
+	newCmd.AddCommand(toolCmd)
+
+	var providerDir string
+	providerCmd := &cobra.Command{
+		Use:   "provider <name>",
+		Short: "Generate a provider package skeleton",
+		Long: `Generate a provider package for an OpenAI-compatible API, laid out like
+the built-in providers: model list, core registry wiring, unit tests, and a
+providertest conformance test that runs when the API key is set.
+
+Creates the package directory <name> in --dir.
+
+Example:
+  iris new provider acme --dir ./providers`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runNewProvider(args[0], providerDir)
+		},
+	}
+	providerCmd.Flags().StringVar(&providerDir, "dir", ".", "Directory to create the package in")
+	newCmd.AddCommand(providerCmd)
+
+	return newCmd
+}
+
+var (
+	toolNamePattern    = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	packageNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+)
+
+// scaffoldData is passed to the tool and provider templates.
+type scaffoldData struct {
+	Name    string // tool name or provider ID
+	Package string
+	Type    string // exported Go name derived from Name
+	EnvVar  string
+}
+
+// scaffoldFile is a file generated from a template.
+type scaffoldFile struct {
+	name string
+	tmpl string
+}
+
+func (a *App) runNewTool(name, dir, pkg string) error {
+	if !toolNamePattern.MatchString(name) {
+		return exitWithCode(ExitValidation, fmt.Errorf("invalid tool name %q: use lowercase letters, digits, and underscores, starting with a letter", name))
+	}
+	if pkg == "" {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return err
+		}
+		pkg = strings.ToLower(strings.NewReplacer("-", "", "_", "", ".", "").Replace(filepath.Base(abs)))
+	}
+	if !packageNamePattern.MatchString(pkg) {
+		return exitWithCode(ExitValidation, fmt.Errorf("invalid package name %q: set one with --package", pkg))
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	data := scaffoldData{Name: name, Package: pkg, Type: exportedName(name)}
+	files := []scaffoldFile{
+		{name + ".go", toolTemplate},
+		{name + "_test.go", toolTestTemplate},
+	}
+	if err := writeScaffold(dir, files, data); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Created tool %s in %s\n\n", name, dir)
+	fmt.Fprintln(a.stdout, "Next steps:")
+	fmt.Fprintf(a.stdout, "  Fill in %sArgs, Description, and Call in %s\n", data.Type, filepath.Join(dir, name+".go"))
+	fmt.Fprintf(a.stdout, "  Register it with registry.Register(%s.New%s())\n", pkg, data.Type)
+	return nil
+}
+
+func (a *App) runNewProvider(name, dir string) error {
+	if !packageNamePattern.MatchString(name) {
+		return exitWithCode(ExitValidation, fmt.Errorf("invalid provider name %q: use lowercase letters and digits, starting with a letter", name))
+	}
+
+	pkgDir := filepath.Join(dir, name)
+	if _, err := os.Stat(pkgDir); err == nil {
+		return exitWithCode(ExitValidation, fmt.Errorf("directory %q already exists", pkgDir))
+	}
+	if err := os.MkdirAll(pkgDir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", pkgDir, err)
+	}
+
+	data := scaffoldData{Name: name, Package: name, Type: exportedName(name), EnvVar: envVarForProvider(name)}
+	files := []scaffoldFile{
+		{"doc.go", providerDocTemplate},
+		{"provider.go", providerTemplate},
+		{"models.go", providerModelsTemplate},
+		{"register.go", providerRegisterTemplate},
+		{"provider_test.go", providerTestTemplate},
+		{"conformance_test.go", providerConformanceTemplate},
+	}
+	if err := writeScaffold(pkgDir, files, data); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(a.stdout, "Created provider package %s\n\n", pkgDir)
+	fmt.Fprintln(a.stdout, "Next steps:")
+	fmt.Fprintf(a.stdout, "  Set DefaultBaseURL in provider.go and the model list in models.go\n")
+	fmt.Fprintf(a.stdout, "  %s=<your-key> go test ./%s\n", data.EnvVar, filepath.ToSlash(pkgDir))
+	return nil
+}
+
+// writeScaffold renders files into dir. It refuses to overwrite existing
+// files, checking them all before writing any.
+func writeScaffold(dir string, files []scaffoldFile, data scaffoldData) error {
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		if _, err := os.Stat(path); err == nil {
+			return exitWithCode(ExitValidation, fmt.Errorf("%s already exists", path))
+		}
+	}
+	for _, f := range files {
+		path := filepath.Join(dir, f.name)
+		if err := renderScaffold(path, f.tmpl, data); err != nil {
+			return fmt.Errorf("failed to create %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func renderScaffold(path, tmplContent string, data scaffoldData) error {
+	tmplContent = strings.ReplaceAll(tmplContent, "‵", "`")
+	tmpl, err := template.New(filepath.Base(path)).Parse(tmplContent)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return tmpl.Execute(f, data)
+}
+
+// exportedName converts a snake_case name to an exported Go identifier,
+// such as "get_weather" to "GetWeather".
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+// Templates. "‵" stands in for a backquote, which Go raw strings cannot
+// contain.
+
+var toolTemplate = `package {{.Package}}
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+// {{.Type}}Args are the arguments of the {{.Name}} tool. The schema the
+// model sees is generated from the json and jsonschema struct tags.
+type {{.Type}}Args struct {
+	Input string ‵json:"input" jsonschema:"description=TODO: describe the argument"‵
+}
+
+// {{.Type}} implements the {{.Name}} tool.
+type {{.Type}} struct{}
+
+// New{{.Type}} creates a {{.Name}} tool.
+func New{{.Type}}() *{{.Type}} {
+	return &{{.Type}}{}
+}
+
+// Name implements tools.Tool.
+func (t *{{.Type}}) Name() string { return "{{.Name}}" }
+
+// Description implements tools.Tool.
+func (t *{{.Type}}) Description() string {
+	return "TODO: describe what the tool does and when the model should call it."
+}
+
+// Schema implements tools.Tool.
+func (t *{{.Type}}) Schema() tools.ToolSchema {
+	return tools.MustSchemaFor[{{.Type}}Args]()
+}
+
+// Call implements tools.Tool.
+func (t *{{.Type}}) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	var params {{.Type}}Args
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	// TODO: implement the tool.
+	return map[string]any{"input": params.Input}, nil
+}
+
+// Compile-time check that {{.Type}} implements tools.Tool.
+var _ tools.Tool = (*{{.Type}})(nil)
+`
+
+var toolTestTemplate = `package {{.Package}}
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+func Test{{.Type}}Schema(t *testing.T) {
+	s := New{{.Type}}().Schema()
+	if !json.Valid(s.JSONSchema) {
+		t.Fatalf("schema is not valid JSON: %s", s.JSONSchema)
+	}
+}
+
+func Test{{.Type}}Call(t *testing.T) {
+	reg := tools.NewRegistry()
+	if err := reg.Register(New{{.Type}}()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := reg.Execute(context.Background(), "{{.Name}}", json.RawMessage(‵{"input": "test"}‵)); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+}
+
+func Test{{.Type}}InvalidArguments(t *testing.T) {
+	reg := tools.NewRegistry()
+	if err := reg.Register(New{{.Type}}()); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := reg.Execute(context.Background(), "{{.Name}}", json.RawMessage(‵{}‵))
+	if !errors.Is(err, tools.ErrInvalidArguments) {
+		t.Errorf("Execute() error = %v, want ErrInvalidArguments", err)
+	}
+}
+`
+
+var providerDocTemplate = `// Package {{.Package}} provides an LLM provider for the {{.Type}} API.
+//
+// TODO: describe the service and its models.
+//
+// # Basic Usage
+//
+//	provider, err := {{.Package}}.NewFromEnv()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	resp, err := core.NewClient(provider).Chat({{.Package}}.ModelDefault).
+//	    User("Hello!").
+//	    GetResponse(ctx)
+//
+// The provider is an openaicompat provider configured for {{.Type}}, so New
+// takes the same options as openaicompat.New. An API that is not
+// OpenAI-compatible implements core.Provider directly instead, as the
+// anthropic and gemini providers do.
+//
+// # Registry
+//
+// Importing the package registers the "{{.Name}}" provider, which reads its
+// API key from ProviderConfig or the {{.EnvVar}} environment variable:
+//
+//	provider, model, err := core.NewProviderFromString("{{.Name}}:{{.Name}}-chat")
+package {{.Package}}
+`
+
+var providerTemplate = `package {{.Package}}
+
+import (
+	"errors"
+	"os"
+
+	"github.com/petal-labs/iris/providers/openaicompat"
+)
+
+// DefaultAPIKeyEnvVar is the environment variable name for the {{.Type}} API key.
+const DefaultAPIKeyEnvVar = "{{.EnvVar}}"
+
+// DefaultBaseURL is the default {{.Type}} API base URL.
+// TODO: set the API's base URL.
+const DefaultBaseURL = "https://api.{{.Name}}.example/v1"
+
+// DefaultID is the provider ID of providers created by New.
+const DefaultID = "{{.Name}}"
+
+// ErrAPIKeyNotFound is returned when the API key environment variable is not set.
+var ErrAPIKeyNotFound = errors.New("{{.Name}}: {{.EnvVar}} environment variable not set")
+
+// NewFromEnv creates a new {{.Type}} provider using the {{.EnvVar}} environment variable.
+func NewFromEnv(opts ...openaicompat.Option) (*openaicompat.Provider, error) {
+	apiKey := os.Getenv(DefaultAPIKeyEnvVar)
+	if apiKey == "" {
+		return nil, ErrAPIKeyNotFound
+	}
+	return New(apiKey, opts...), nil
+}
+
+// New creates a {{.Type}} provider with the given API key. The provider
+// reports the {{.Type}} models; opts are applied after these defaults, so
+// openaicompat.WithBaseURL can point it elsewhere.
+func New(apiKey string, opts ...openaicompat.Option) *openaicompat.Provider {
+	defaults := []openaicompat.Option{
+		openaicompat.WithID(DefaultID),
+		openaicompat.WithModels(models...),
+		openaicompat.WithFeatures(features...),
+	}
+	return openaicompat.New(DefaultBaseURL, apiKey, append(defaults, opts...)...)
+}
+`
+
+var providerModelsTemplate = `package {{.Package}}
+
+import "github.com/petal-labs/iris/core"
+
+// Model constants for {{.Type}} models.
+// TODO: list the API's models.
+const (
+	ModelDefault core.ModelID = "{{.Name}}-chat"
+)
+
+// features are the features supported by at least one model.
+var features = []core.Feature{
+	core.FeatureChat,
+	core.FeatureChatStreaming,
+	core.FeatureToolCalling,
+}
+
+// models is the static list of supported models.
+var models = []core.ModelInfo{
+	{ID: ModelDefault, DisplayName: "{{.Type}} Chat", APIEndpoint: core.APIEndpointCompletions, Capabilities: features},
+}
+`
+
+var providerRegisterTemplate = `package {{.Package}}
+
+import (
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers"
+	"github.com/petal-labs/iris/providers/openaicompat"
+)
+
+func init() {
+	providers.Register("{{.Name}}", func(apiKey string) core.Provider {
+		return New(apiKey)
+	})
+
+	core.RegisterProvider("{{.Name}}", func(cfg core.ProviderConfig) (core.Provider, error) {
+		var opts []openaicompat.Option
+		apiKey := cfg.APIKeyOrEnv(DefaultAPIKeyEnvVar)
+		if cfg.Credentials != nil {
+			opts = append(opts, openaicompat.WithCredentials(cfg.Credentials))
+		} else if apiKey == "" {
+			return nil, ErrAPIKeyNotFound
+		}
+		if cfg.BaseURL != "" {
+			opts = append(opts, openaicompat.WithBaseURL(cfg.BaseURL))
+		}
+		return New(apiKey, opts...), nil
+	})
+}
+`
+
+var providerTestTemplate = `package {{.Package}}
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/openaicompat"
+)
+
+func TestNewDefaults(t *testing.T) {
+	p := New("test-key")
+
+	if p.ID() != DefaultID {
+		t.Errorf("ID() = %q, want %q", p.ID(), DefaultID)
+	}
+	if len(p.Models()) != len(models) {
+		t.Errorf("Models() has %d models, want %d", len(p.Models()), len(models))
+	}
+	if !p.Supports(core.FeatureChat) {
+		t.Error("Supports(FeatureChat) = false")
+	}
+}
+
+func TestNewFromEnv(t *testing.T) {
+	t.Setenv(DefaultAPIKeyEnvVar, "")
+	if _, err := NewFromEnv(); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("NewFromEnv() error = %v, want ErrAPIKeyNotFound", err)
+	}
+
+	t.Setenv(DefaultAPIKeyEnvVar, "test-key")
+	if _, err := NewFromEnv(); err != nil {
+		t.Errorf("NewFromEnv() error = %v", err)
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	p, err := core.NewProviderFromConfig(core.ProviderConfig{Name: DefaultID, APIKey: core.NewSecret("test-key")})
+	if err != nil {
+		t.Fatalf("NewProviderFromConfig() error = %v", err)
+	}
+	if p.ID() != DefaultID {
+		t.Errorf("ID() = %q, want %q", p.ID(), DefaultID)
+	}
+}
+
+func TestChat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Authorization = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(‵{
+			"id": "chatcmpl-1",
+			"model": "{{.Name}}-chat",
+			"choices": [{"index": 0, "message": {"role": "assistant", "content": "Hello!"}, "finish_reason": "stop"}],
+			"usage": {"prompt_tokens": 5, "completion_tokens": 2, "total_tokens": 7}
+		}‵))
+	}))
+	defer server.Close()
+
+	p := New("test-key", openaicompat.WithBaseURL(server.URL+"/v1"))
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model: ModelDefault,
+		Messages: []core.Message{
+			{Role: core.RoleUser, Content: "Hi"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Output != "Hello!" {
+		t.Errorf("Output = %q, want %q", resp.Output, "Hello!")
+	}
+}
+`
+
+var providerConformanceTemplate = `package {{.Package}}
+
+import (
+	"os"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/testing/providertest"
+)
+
+func TestConformance(t *testing.T) {
+	key := os.Getenv(DefaultAPIKeyEnvVar)
+	if key == "" {
+		t.Skip(DefaultAPIKeyEnvVar + " not set")
+	}
+	providertest.Run(t, providertest.Config{
+		NewProvider:             func(*testing.T) core.Provider { return New(key) },
+		NewUnauthorizedProvider: func(*testing.T) core.Provider { return New("invalid-key") },
+		Model:                   ModelDefault,
+	})
+}
+`
@@ -0,0 +1,110 @@
+package commands
+
+import (
+	"bytes"
+	"errors"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportedName(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"get_weather", "GetWeather"},
+		{"search", "Search"},
+		{"a__b", "AB"},
+		{"acme2", "Acme2"},
+	}
+
+	for _, tt := range tests {
+		if got := exportedName(tt.input); got != tt.want {
+			t.Errorf("exportedName(%q) = %q, want %q", tt.input, got, tt.want)
+		}
+	}
+}
+
+// checkGenerated fails unless every file in dir is gofmt-clean Go.
+func checkGenerated(t *testing.T, dir string, want []string) {
+	t.Helper()
+	for _, name := range want {
+		src, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("missing %s: %v", name, err)
+		}
+		formatted, err := format.Source(src)
+		if err != nil {
+			t.Fatalf("%s is not valid Go: %v\n%s", name, err, src)
+		}
+		if !bytes.Equal(formatted, src) {
+			t.Errorf("%s is not gofmt-clean:\n%s", name, src)
+		}
+	}
+}
+
+func TestNewTool(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "my-tools")
+	var stdout bytes.Buffer
+	app := NewApp(WithIO(nil, &stdout, nil))
+
+	if err := runApp(app, "new", "tool", "get_weather", "--dir", dir); err != nil {
+		t.Fatalf("new tool error = %v", err)
+	}
+	checkGenerated(t, dir, []string{"get_weather.go", "get_weather_test.go"})
+
+	src, _ := os.ReadFile(filepath.Join(dir, "get_weather.go"))
+	for _, want := range []string{"package mytools", "type GetWeather struct", `return "get_weather"`} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("get_weather.go missing %q", want)
+		}
+	}
+
+	// A second run must not overwrite the files.
+	err := runApp(NewApp(WithIO(nil, &stdout, nil)), "new", "tool", "get_weather", "--dir", dir)
+	var exitErr *exitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode() != ExitValidation {
+		t.Errorf("second run error = %v, want exit code %d", err, ExitValidation)
+	}
+}
+
+func TestNewProvider(t *testing.T) {
+	dir := t.TempDir()
+	var stdout bytes.Buffer
+	app := NewApp(WithIO(nil, &stdout, nil))
+
+	if err := runApp(app, "new", "provider", "acme", "--dir", dir); err != nil {
+		t.Fatalf("new provider error = %v", err)
+	}
+	checkGenerated(t, filepath.Join(dir, "acme"), []string{
+		"doc.go", "provider.go", "models.go", "register.go", "provider_test.go", "conformance_test.go",
+	})
+
+	src, _ := os.ReadFile(filepath.Join(dir, "acme", "provider.go"))
+	if !strings.Contains(string(src), `DefaultAPIKeyEnvVar = "ACME_API_KEY"`) {
+		t.Errorf("provider.go does not use ACME_API_KEY:\n%s", src)
+	}
+}
+
+func TestNewInvalidNames(t *testing.T) {
+	tests := [][]string{
+		{"new", "tool", "GetWeather"},
+		{"new", "tool", "get-weather"},
+		{"new", "provider", "acme_ai"},
+		{"new", "tool", "ok", "--package", "bad-name"},
+	}
+
+	for _, args := range tests {
+		t.Run(strings.Join(args[1:], " "), func(t *testing.T) {
+			args = append(args, "--dir", t.TempDir())
+			err := runApp(NewApp(WithIO(nil, &bytes.Buffer{}, nil)), args...)
+			var exitErr *exitError
+			if !errors.As(err, &exitErr) || exitErr.ExitCode() != ExitValidation {
+				t.Errorf("error = %v, want exit code %d", err, ExitValidation)
+			}
+		})
+	}
+}
@@ -1,82 +1,34 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/petal-labs/iris/cli/config"
+	"github.com/petal-labs/iris/cli/keystore"
 	"github.com/petal-labs/iris/core"
 	"github.com/petal-labs/iris/providers"
-	"github.com/petal-labs/iris/providers/anthropic"
-	"github.com/petal-labs/iris/providers/gemini"
-	"github.com/petal-labs/iris/providers/huggingface"
-	"github.com/petal-labs/iris/providers/ollama"
-	"github.com/petal-labs/iris/providers/openai"
-	"github.com/petal-labs/iris/providers/xai"
-	"github.com/petal-labs/iris/providers/zai"
-)
 
-type providerConstructor func(apiKey, baseURL string) (core.Provider, error)
+	// Register the built-in providers with core.RegisterProvider.
+	_ "github.com/petal-labs/iris/providers/anthropic"
+	_ "github.com/petal-labs/iris/providers/cerebras"
+	_ "github.com/petal-labs/iris/providers/gemini"
+	_ "github.com/petal-labs/iris/providers/huggingface"
+	_ "github.com/petal-labs/iris/providers/ollama"
+	_ "github.com/petal-labs/iris/providers/openai"
+	_ "github.com/petal-labs/iris/providers/xai"
+	_ "github.com/petal-labs/iris/providers/zai"
+)
 
 func defaultProviderFactory() ProviderFactory {
-	constructors := map[string]providerConstructor{
-		"openai": func(apiKey, baseURL string) (core.Provider, error) {
-			var opts []openai.Option
-			if baseURL != "" {
-				opts = append(opts, openai.WithBaseURL(baseURL))
-			}
-			return openai.New(apiKey, opts...), nil
-		},
-		"anthropic": func(apiKey, baseURL string) (core.Provider, error) {
-			var opts []anthropic.Option
-			if baseURL != "" {
-				opts = append(opts, anthropic.WithBaseURL(baseURL))
-			}
-			return anthropic.New(apiKey, opts...), nil
-		},
-		"gemini": func(apiKey, baseURL string) (core.Provider, error) {
-			var opts []gemini.Option
-			if baseURL != "" {
-				opts = append(opts, gemini.WithBaseURL(baseURL))
-			}
-			return gemini.New(apiKey, opts...), nil
-		},
-		"xai": func(apiKey, baseURL string) (core.Provider, error) {
-			var opts []xai.Option
-			if baseURL != "" {
-				opts = append(opts, xai.WithBaseURL(baseURL))
-			}
-			return xai.New(apiKey, opts...), nil
-		},
-		"zai": func(apiKey, baseURL string) (core.Provider, error) {
-			var opts []zai.Option
-			if baseURL != "" {
-				opts = append(opts, zai.WithBaseURL(baseURL))
-			}
-			return zai.New(apiKey, opts...), nil
-		},
-		"ollama": func(apiKey, baseURL string) (core.Provider, error) {
-			var opts []ollama.Option
-			if baseURL != "" {
-				opts = append(opts, ollama.WithBaseURL(baseURL))
-			}
-			if apiKey != "" {
-				opts = append(opts, ollama.WithAPIKey(apiKey))
-			}
-			return ollama.New(opts...), nil
-		},
-		"huggingface": func(apiKey, baseURL string) (core.Provider, error) {
-			var opts []huggingface.Option
-			if baseURL != "" {
-				opts = append(opts, huggingface.WithBaseURL(baseURL))
-			}
-			return huggingface.New(apiKey, opts...), nil
-		},
-	}
-
 	return func(providerID, apiKey string, cfg *config.Config) (core.Provider, error) {
-		baseURL := providerBaseURL(cfg, providerID)
-		if ctor, ok := constructors[providerID]; ok {
-			return ctor(apiKey, baseURL)
+		p, err := core.NewProviderFromConfig(core.ProviderConfig{
+			Name:    providerID,
+			APIKey:  core.NewSecret(apiKey),
+			BaseURL: providerBaseURL(cfg, providerID),
+		})
+		if !errors.Is(err, core.ErrUnknownProvider) {
+			return p, err
 		}
 
 		// Fall back to registry for externally-registered providers.
@@ -84,7 +36,7 @@ func defaultProviderFactory() ProviderFactory {
 			return providers.Create(providerID, apiKey)
 		}
 
-		return nil, fmt.Errorf("unsupported provider: %s (available: %v)", providerID, providers.List())
+		return nil, fmt.Errorf("unsupported provider: %s (available: %v)", providerID, core.RegisteredProviders())
 	}
 }
 
@@ -98,3 +50,39 @@ func providerBaseURL(cfg *config.Config, providerID string) string {
 	}
 	return pc.BaseURL
 }
+
+// Key sources reported by resolveAPIKey.
+const (
+	keySourceKeystore = "keystore"
+	keySourceConfig   = "config"
+)
+
+// resolveAPIKey returns the API key for providerID and where it came from,
+// trying the keystore first and then api_key or api_key_env in the config.
+// A missing key is returned as an empty string; ks may be nil.
+func (a *App) resolveAPIKey(ks keystore.Keystore, providerID string) (key, source string, err error) {
+	if ks != nil {
+		key, err := ks.Get(providerID)
+		if err == nil {
+			return key, keySourceKeystore, nil
+		}
+		if _, ok := err.(*keystore.ErrKeyNotFound); !ok {
+			return "", "", fmt.Errorf("failed to get API key: %w", err)
+		}
+	}
+	if key := configAPIKey(a.cfg, providerID); key != "" {
+		return key, keySourceConfig, nil
+	}
+	return "", "", nil
+}
+
+func configAPIKey(cfg *config.Config, providerID string) string {
+	if cfg == nil {
+		return ""
+	}
+	pc := cfg.GetProvider(providerID)
+	if pc == nil {
+		return ""
+	}
+	return pc.ResolveAPIKey()
+}
@@ -0,0 +1,254 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petal-labs/iris/cli/config"
+	"github.com/petal-labs/iris/core"
+)
+
+// chatSession is a named conversation saved by iris chat --session.
+type chatSession struct {
+	Name         string                 `json:"name"`
+	Provider     string                 `json:"provider"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+	Conversation core.ConversationState `json:"conversation"`
+}
+
+var sessionNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_-]*$`)
+
+// sessionDir returns the directory sessions are saved in, next to the
+// default config file.
+func sessionDir() string {
+	return filepath.Join(filepath.Dir(config.DefaultConfigPath()), "sessions")
+}
+
+func sessionPath(name string) (string, error) {
+	if !sessionNamePattern.MatchString(name) {
+		return "", exitWithCode(ExitValidation, fmt.Errorf("invalid session name %q: use letters, numbers, underscores, and hyphens", name))
+	}
+	return filepath.Join(sessionDir(), name+".json"), nil
+}
+
+// loadSession reads the named session. A session that does not exist yet
+// is returned as nil without error.
+func loadSession(name string) (*chatSession, error) {
+	path, err := sessionPath(name)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session %s: %w", name, err)
+	}
+	var s chatSession
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("failed to parse session %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// saveSession writes s atomically. Sessions hold conversation content, so
+// they are readable only by the user.
+func saveSession(s *chatSession) error {
+	path, err := sessionPath(s.Name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to save session %s: %w", s.Name, err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to save session %s: %w", s.Name, err)
+	}
+	return nil
+}
+
+// requireSession loads a session that must exist.
+func requireSession(name string) (*chatSession, error) {
+	s, err := loadSession(name)
+	if err != nil {
+		return nil, err
+	}
+	if s == nil {
+		return nil, exitWithCode(ExitValidation, fmt.Errorf("session %q not found: run 'iris sessions list' to see saved sessions", name))
+	}
+	return s, nil
+}
+
+func (a *App) newSessionsCommand() *cobra.Command {
+	sessionsCmd := &cobra.Command{
+		Use:   "sessions",
+		Short: "Manage saved chat sessions",
+		Long: `Manage the named conversations saved by 'iris chat --session <name>'.
+
+Sessions are stored as JSON in ~/.iris/sessions. Continue one by passing
+the same --session name to iris chat.`,
+	}
+
+	sessionsCmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List saved sessions",
+		Args:  cobra.NoArgs,
+		RunE:  a.runSessionsList,
+	})
+
+	var output string
+	exportCmd := &cobra.Command{
+		Use:   "export <name>",
+		Short: "Export a session as Markdown",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runSessionsExport(args[0], output)
+		},
+	}
+	exportCmd.Flags().StringVarP(&output, "output", "o", "", "Write to a file instead of stdout")
+	sessionsCmd.AddCommand(exportCmd)
+
+	sessionsCmd.AddCommand(&cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a saved session",
+		Args:  cobra.ExactArgs(1),
+		RunE:  a.runSessionsDelete,
+	})
+
+	return sessionsCmd
+}
+
+// sessionSummary is the JSON listing of a session.
+type sessionSummary struct {
+	Name      string    `json:"name"`
+	Provider  string    `json:"provider"`
+	Model     string    `json:"model"`
+	Messages  int       `json:"messages"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (a *App) runSessionsList(cmd *cobra.Command, args []string) error {
+	entries, err := os.ReadDir(sessionDir())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read sessions: %w", err)
+	}
+
+	summaries := []sessionSummary{}
+	for _, e := range entries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok || e.IsDir() {
+			continue
+		}
+		s, err := loadSession(name)
+		if err != nil || s == nil {
+			continue
+		}
+		summaries = append(summaries, sessionSummary{
+			Name:      s.Name,
+			Provider:  s.Provider,
+			Model:     string(s.Conversation.Model),
+			Messages:  len(s.Conversation.Messages),
+			UpdatedAt: s.UpdatedAt,
+		})
+	}
+	sort.Slice(summaries, func(i, j int) bool { return summaries[i].UpdatedAt.After(summaries[j].UpdatedAt) })
+
+	if a.jsonOutput {
+		return a.encodeJSON(summaries)
+	}
+	if len(summaries) == 0 {
+		fmt.Fprintln(a.stdout, "No saved sessions. Start one with: iris chat --session <name> --prompt \"...\"")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPROVIDER\tMODEL\tMESSAGES\tUPDATED")
+	for _, s := range summaries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", s.Name, s.Provider, s.Model, s.Messages, s.UpdatedAt.Local().Format(time.DateTime))
+	}
+	return w.Flush()
+}
+
+func (a *App) runSessionsExport(name, output string) error {
+	s, err := requireSession(name)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		return writeSessionMarkdown(a.stdout, s)
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", output, err)
+	}
+	if err := writeSessionMarkdown(f, s); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// writeSessionMarkdown writes the conversation as Markdown, one section
+// per message.
+func writeSessionMarkdown(w io.Writer, s *chatSession) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", s.Name)
+	fmt.Fprintf(&b, "- Provider: %s\n", s.Provider)
+	fmt.Fprintf(&b, "- Model: %s\n", s.Conversation.Model)
+	fmt.Fprintf(&b, "- Started: %s\n", s.CreatedAt.Format(time.RFC3339))
+
+	titles := map[core.Role]string{
+		core.RoleSystem:    "System",
+		core.RoleUser:      "User",
+		core.RoleAssistant: "Assistant",
+		core.RoleTool:      "Tool",
+	}
+	for _, msg := range s.Conversation.Messages {
+		title, ok := titles[msg.Role]
+		if !ok {
+			title = string(msg.Role)
+		}
+		fmt.Fprintf(&b, "\n## %s\n\n%s\n", title, strings.TrimSpace(msg.Content))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+func (a *App) runSessionsDelete(cmd *cobra.Command, args []string) error {
+	path, err := sessionPath(args[0])
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return exitWithCode(ExitValidation, fmt.Errorf("session %q not found", args[0]))
+		}
+		return fmt.Errorf("failed to delete session %s: %w", args[0], err)
+	}
+	fmt.Fprintf(a.stdout, "Deleted session %s\n", args[0])
+	return nil
+}
@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petal-labs/iris/cli/config"
+	"github.com/petal-labs/iris/cli/keystore"
+	"github.com/petal-labs/iris/core"
+	iristest "github.com/petal-labs/iris/testing"
+)
+
+func newSessionTestApp(t *testing.T, provider *iristest.MockProvider) (*App, *bytes.Buffer) {
+	t.Helper()
+	var stdout bytes.Buffer
+	app := NewApp(
+		WithConfigLoader(func(string) (*config.Config, error) { return &config.Config{}, nil }),
+		WithKeystoreFactory(func() (keystore.Keystore, error) { return mapKeystore{"openai": "sk-test"}, nil }),
+		WithProviderFactory(func(id, _ string, _ *config.Config) (core.Provider, error) { return provider.WithID(id), nil }),
+		WithIO(strings.NewReader(""), &stdout, &bytes.Buffer{}),
+	)
+	return app, &stdout
+}
+
+func TestChatSession(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	provider := iristest.NewMockProvider(
+		core.ChatResponse{Output: "Hi there"},
+		core.ChatResponse{Output: "Still here"},
+	)
+
+	app, _ := newSessionTestApp(t, provider)
+	if err := runApp(app, "chat", "--provider", "openai", "--model", "gpt-4o", "--session", "work", "--system", "Be brief", "--prompt", "Hello"); err != nil {
+		t.Fatalf("first chat error = %v", err)
+	}
+
+	// The second turn resumes the session's provider and model.
+	app, _ = newSessionTestApp(t, provider)
+	if err := runApp(app, "chat", "--session", "work", "--prompt", "Are you there?"); err != nil {
+		t.Fatalf("second chat error = %v", err)
+	}
+
+	req := provider.LastCall().Request
+	if req.Model != "gpt-4o" {
+		t.Errorf("Model = %q, want gpt-4o", req.Model)
+	}
+	var roles []string
+	for _, m := range req.Messages {
+		roles = append(roles, string(m.Role))
+	}
+	if got := strings.Join(roles, ","); got != "system,user,assistant,user" {
+		t.Errorf("request roles = %s, want system,user,assistant,user", got)
+	}
+
+	s, err := requireSession("work")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Provider != "openai" || len(s.Conversation.Messages) != 5 {
+		t.Errorf("session = %+v", s)
+	}
+
+	info, err := os.Stat(filepath.Join(sessionDir(), "work.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("session file mode = %o, want 600", perm)
+	}
+}
+
+func TestChatSessionNotSavedOnError(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	provider := iristest.NewMockProvider().WithError(core.ErrUnauthorized)
+
+	app, _ := newSessionTestApp(t, provider)
+	if err := runApp(app, "chat", "--provider", "openai", "--model", "gpt-4o", "--session", "work", "--prompt", "Hello"); err == nil {
+		t.Fatal("expected error")
+	}
+	if s, err := loadSession("work"); err != nil || s != nil {
+		t.Errorf("loadSession() = %v, %v; want no session", s, err)
+	}
+}
+
+func TestSessionsListAndExport(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	provider := iristest.NewMockProvider(core.ChatResponse{Output: "Go is a language."})
+
+	app, _ := newSessionTestApp(t, provider)
+	if err := runApp(app, "chat", "--provider", "openai", "--model", "gpt-4o", "--session", "notes", "--prompt", "What is Go?"); err != nil {
+		t.Fatal(err)
+	}
+
+	app, stdout := newSessionTestApp(t, provider)
+	if err := runApp(app, "--json", "sessions", "list"); err != nil {
+		t.Fatalf("sessions list error = %v", err)
+	}
+	var list []sessionSummary
+	if err := json.Unmarshal(stdout.Bytes(), &list); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout.String())
+	}
+	if len(list) != 1 || list[0].Name != "notes" || list[0].Messages != 2 {
+		t.Errorf("list = %+v", list)
+	}
+
+	app, stdout = newSessionTestApp(t, provider)
+	if err := runApp(app, "sessions", "export", "notes"); err != nil {
+		t.Fatalf("sessions export error = %v", err)
+	}
+	out := stdout.String()
+	for _, want := range []string{"# notes", "## User\n\nWhat is Go?", "## Assistant\n\nGo is a language."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("export missing %q:\n%s", want, out)
+		}
+	}
+
+	app, _ = newSessionTestApp(t, provider)
+	if err := runApp(app, "sessions", "delete", "notes"); err != nil {
+		t.Fatalf("sessions delete error = %v", err)
+	}
+	if _, err := requireSession("notes"); err == nil {
+		t.Error("session still exists after delete")
+	}
+}
+
+func TestSessionInvalidName(t *testing.T) {
+	if _, err := sessionPath("../escape"); err == nil {
+		t.Error("sessionPath accepted a path")
+	}
+}
@@ -0,0 +1,255 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petal-labs/iris/cli/config"
+	"github.com/petal-labs/iris/tools"
+	"github.com/petal-labs/iris/tools/std"
+)
+
+// RegistryFactory creates the tool registry used by the tools commands.
+type RegistryFactory func(cfg *config.Config) (*tools.Registry, error)
+
+// WithRegistryFactory injects a tool registry factory dependency.
+func WithRegistryFactory(factory RegistryFactory) AppOption {
+	return func(a *App) {
+		if factory != nil {
+			a.createRegistry = factory
+		}
+	}
+}
+
+// defaultRegistryFactory registers the standard tools enabled by the tools
+// section of the config. Results and errors pass through secret scrubbing,
+// as they would before reaching a model.
+func defaultRegistryFactory(cfg *config.Config) (*tools.Registry, error) {
+	reg := tools.NewRegistry(tools.WithRegistryMiddleware(tools.WithSecretScrubbing(nil)))
+	enabled := []tools.Tool{std.NewCurrentTime(), std.NewCalculator()}
+	if cfg != nil {
+		tc := cfg.Tools
+		if tc.Root != "" {
+			fs := std.FilesystemConfig{Root: tc.Root}
+			enabled = append(enabled, std.NewReadFile(fs), std.NewListDir(fs))
+			if tc.AllowWrite {
+				enabled = append(enabled, std.NewWriteFile(fs))
+			}
+		}
+		if len(tc.AllowedHosts) > 0 {
+			enabled = append(enabled, std.NewHTTPFetch(std.HTTPFetchConfig{AllowedHosts: tc.AllowedHosts}))
+		}
+	}
+	for _, t := range enabled {
+		if err := reg.Register(t); err != nil {
+			return nil, err
+		}
+	}
+	return reg, nil
+}
+
+func (a *App) newToolsCommand() *cobra.Command {
+	toolsCmd := &cobra.Command{
+		Use:   "tools",
+		Short: "Inspect and call tools",
+		Long: `Inspect and call the tools in the configured registry, to debug tool
+schemas before wiring them into an application.
+
+The standard current_time and calculator tools are always available. The
+tools section of the config enables the others:
+
+  tools:
+    root: ./workspace        # read_file, list_dir
+    allow_write: true        # write_file
+    allowed_hosts: [example.com]  # http_fetch`,
+	}
+
+	toolsCmd.AddCommand(&cobra.Command{
+		Use:   "list",
+		Short: "List available tools",
+		Args:  cobra.NoArgs,
+		RunE:  a.runToolsList,
+	})
+	toolsCmd.AddCommand(&cobra.Command{
+		Use:   "inspect <name>",
+		Short: "Show a tool's description, scopes, and schema",
+		Args:  cobra.ExactArgs(1),
+		RunE:  a.runToolsInspect,
+	})
+
+	callCmd := &cobra.Command{
+		Use:   "call <name>",
+		Short: "Call a tool with JSON arguments",
+		Long: `Call a tool through the registry, with argument validation and
+middleware applied as in an application.
+
+Examples:
+  iris tools call calculator --args '{"expression": "2 * (3 + 4)"}'
+  echo '{"path": "."}' | iris tools call list_dir --args -`,
+		Args: cobra.ExactArgs(1),
+		RunE: a.runToolsCall,
+	}
+	callCmd.Flags().StringVar(&a.toolArgs, "args", "{}", "JSON arguments, or - to read them from stdin")
+	callCmd.Flags().DurationVar(&a.toolTimeout, "timeout", 30*time.Second, "Maximum time for the call (0 = no limit)")
+	toolsCmd.AddCommand(callCmd)
+
+	return toolsCmd
+}
+
+// toolInfo is the JSON description of a tool.
+type toolInfo struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Scopes      []string        `json:"scopes,omitempty"`
+	Schema      json.RawMessage `json:"schema,omitempty"`
+}
+
+func describeTool(t tools.Tool) toolInfo {
+	return toolInfo{
+		Name:        t.Name(),
+		Description: t.Description(),
+		Scopes:      tools.ScopesOf(t),
+		Schema:      t.Schema().JSONSchema,
+	}
+}
+
+func (a *App) registry() (*tools.Registry, error) {
+	reg, err := a.createRegistry(a.cfg)
+	if err != nil {
+		return nil, exitWithCode(ExitValidation, fmt.Errorf("failed to load tools: %w", err))
+	}
+	return reg, nil
+}
+
+func (a *App) runToolsList(cmd *cobra.Command, args []string) error {
+	reg, err := a.registry()
+	if err != nil {
+		return err
+	}
+	list := reg.List()
+	sort.Slice(list, func(i, j int) bool { return list[i].Name() < list[j].Name() })
+
+	if a.jsonOutput {
+		infos := make([]toolInfo, len(list))
+		for i, t := range list {
+			infos[i] = describeTool(t)
+		}
+		return a.encodeJSON(infos)
+	}
+
+	w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tSCOPES\tDESCRIPTION")
+	for _, t := range list {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", t.Name(), strings.Join(tools.ScopesOf(t), ","), t.Description())
+	}
+	return w.Flush()
+}
+
+func (a *App) runToolsInspect(cmd *cobra.Command, args []string) error {
+	reg, err := a.registry()
+	if err != nil {
+		return err
+	}
+	t, ok := reg.Get(args[0])
+	if !ok {
+		return exitWithCode(ExitValidation, fmt.Errorf("tool %q not found: run 'iris tools list' to see available tools", args[0]))
+	}
+
+	info := describeTool(t)
+	if a.jsonOutput {
+		return a.encodeJSON(info)
+	}
+
+	fmt.Fprintf(a.stdout, "Name:        %s\n", info.Name)
+	fmt.Fprintf(a.stdout, "Description: %s\n", info.Description)
+	if len(info.Scopes) > 0 {
+		fmt.Fprintf(a.stdout, "Scopes:      %s\n", strings.Join(info.Scopes, ", "))
+	}
+	fmt.Fprintln(a.stdout, "Schema:")
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, info.Schema, "  ", "  "); err != nil {
+		buf.Reset()
+		buf.Write(info.Schema)
+	}
+	fmt.Fprintf(a.stdout, "  %s\n", buf.String())
+	return nil
+}
+
+func (a *App) runToolsCall(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	input := a.toolArgs
+	if input == "-" {
+		data, err := io.ReadAll(a.stdin)
+		if err != nil {
+			return exitWithCode(ExitValidation, fmt.Errorf("failed to read arguments: %w", err))
+		}
+		input = strings.TrimSpace(string(data))
+	}
+	if !json.Valid([]byte(input)) {
+		return exitWithCode(ExitValidation, fmt.Errorf("--args is not valid JSON: %s", input))
+	}
+
+	reg, err := a.registry()
+	if err != nil {
+		return err
+	}
+	if _, ok := reg.Get(name); !ok {
+		return exitWithCode(ExitValidation, fmt.Errorf("tool %q not found: run 'iris tools list' to see available tools", name))
+	}
+
+	ctx := context.Background()
+	if a.toolTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, a.toolTimeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	result, err := reg.Execute(ctx, name, json.RawMessage(input))
+	duration := time.Since(start)
+	if err != nil {
+		return a.handleToolError(err)
+	}
+
+	if a.jsonOutput {
+		return a.encodeJSON(map[string]any{
+			"tool":        name,
+			"result":      result,
+			"duration_ms": duration.Milliseconds(),
+		})
+	}
+	if s, ok := result.(string); ok {
+		fmt.Fprintln(a.stdout, s)
+		return nil
+	}
+	return a.encodeJSON(result)
+}
+
+func (a *App) handleToolError(err error) error {
+	errType, code := "tool_error", ExitProvider
+	if errors.Is(err, tools.ErrInvalidArguments) {
+		errType, code = "validation_error", ExitValidation
+	}
+	if a.jsonOutput {
+		a.outputSimpleErrorJSON(errType, err.Error())
+	} else {
+		fmt.Fprintf(a.stderr, "Error: %v\n", err)
+	}
+	return exitWithCode(code, err)
+}
+
+func (a *App) encodeJSON(v any) error {
+	enc := json.NewEncoder(a.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
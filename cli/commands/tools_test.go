@@ -0,0 +1,147 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/petal-labs/iris/cli/config"
+)
+
+func newToolsTestApp(t *testing.T, cfg *config.Config, stdin string) (*App, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	app := NewApp(
+		WithConfigLoader(func(string) (*config.Config, error) { return cfg, nil }),
+		WithIO(strings.NewReader(stdin), &stdout, &stderr),
+	)
+	return app, &stdout, &stderr
+}
+
+func runApp(app *App, args ...string) error {
+	app.root.SetArgs(args)
+	return app.Execute()
+}
+
+func TestDefaultRegistryFactory(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  config.ToolsConfig
+		want []string
+	}{
+		{"defaults", config.ToolsConfig{}, []string{"calculator", "current_time"}},
+		{"root", config.ToolsConfig{Root: t.TempDir()}, []string{"calculator", "current_time", "list_dir", "read_file"}},
+		{"write", config.ToolsConfig{Root: t.TempDir(), AllowWrite: true}, []string{"calculator", "current_time", "list_dir", "read_file", "write_file"}},
+		{"hosts", config.ToolsConfig{AllowedHosts: []string{"example.com"}}, []string{"calculator", "current_time", "http_fetch"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reg, err := defaultRegistryFactory(&config.Config{Tools: tt.cfg})
+			if err != nil {
+				t.Fatalf("defaultRegistryFactory() error = %v", err)
+			}
+			if got := len(reg.List()); got != len(tt.want) {
+				t.Errorf("len(List()) = %d, want %d", got, len(tt.want))
+			}
+			for _, name := range tt.want {
+				if _, ok := reg.Get(name); !ok {
+					t.Errorf("tool %q not registered", name)
+				}
+			}
+		})
+	}
+}
+
+func TestToolsList(t *testing.T) {
+	app, stdout, _ := newToolsTestApp(t, &config.Config{}, "")
+	if err := runApp(app, "tools", "list"); err != nil {
+		t.Fatalf("tools list error = %v", err)
+	}
+	out := stdout.String()
+	for _, want := range []string{"NAME", "calculator", "current_time"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestToolsListJSON(t *testing.T) {
+	app, stdout, _ := newToolsTestApp(t, &config.Config{}, "")
+	if err := runApp(app, "--json", "tools", "list"); err != nil {
+		t.Fatalf("tools list error = %v", err)
+	}
+	var infos []toolInfo
+	if err := json.Unmarshal(stdout.Bytes(), &infos); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout.String())
+	}
+	if len(infos) != 2 || infos[0].Name != "calculator" || len(infos[0].Schema) == 0 {
+		t.Errorf("infos = %+v", infos)
+	}
+}
+
+func TestToolsInspect(t *testing.T) {
+	app, stdout, _ := newToolsTestApp(t, &config.Config{}, "")
+	if err := runApp(app, "tools", "inspect", "calculator"); err != nil {
+		t.Fatalf("tools inspect error = %v", err)
+	}
+	if out := stdout.String(); !strings.Contains(out, "Name:        calculator") || !strings.Contains(out, `"expression"`) {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestToolsCall(t *testing.T) {
+	app, stdout, _ := newToolsTestApp(t, &config.Config{}, "")
+	if err := runApp(app, "tools", "call", "calculator", "--args", `{"expression": "2 * (3 + 4)"}`); err != nil {
+		t.Fatalf("tools call error = %v", err)
+	}
+	if !strings.Contains(stdout.String(), "14") {
+		t.Errorf("output = %q, want result 14", stdout.String())
+	}
+}
+
+func TestToolsCallStdinJSON(t *testing.T) {
+	app, stdout, _ := newToolsTestApp(t, &config.Config{}, `{"expression": "1 + 1"}`)
+	if err := runApp(app, "--json", "tools", "call", "calculator", "--args", "-"); err != nil {
+		t.Fatalf("tools call error = %v", err)
+	}
+	var out struct {
+		Tool   string          `json:"tool"`
+		Result json.RawMessage `json:"result"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout.String())
+	}
+	if out.Tool != "calculator" || !strings.Contains(string(out.Result), "2") {
+		t.Errorf("output = %s", stdout.String())
+	}
+}
+
+func TestToolsCallErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"unknown tool", []string{"tools", "call", "missing"}, ExitValidation},
+		{"invalid JSON", []string{"tools", "call", "calculator", "--args", "{"}, ExitValidation},
+		{"invalid arguments", []string{"tools", "call", "calculator", "--args", `{}`}, ExitValidation},
+		{"tool failure", []string{"tools", "call", "calculator", "--args", `{"expression": "1 / 0"}`}, ExitProvider},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, _, _ := newToolsTestApp(t, &config.Config{}, "")
+			err := runApp(app, tt.args...)
+			var exitErr *exitError
+			if !errors.As(err, &exitErr) {
+				t.Fatalf("error = %v, want *exitError", err)
+			}
+			if exitErr.ExitCode() != tt.want {
+				t.Errorf("ExitCode() = %d, want %d", exitErr.ExitCode(), tt.want)
+			}
+		})
+	}
+}
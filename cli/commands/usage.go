@@ -0,0 +1,264 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/petal-labs/iris/config"
+	"github.com/petal-labs/iris/core"
+)
+
+// usageDimensions are the breakdowns of iris usage, in output order.
+var usageDimensions = []string{"provider", "model", "tag", "day"}
+
+// untagged is the tag breakdown key of records without tags.
+const untagged = "(untagged)"
+
+// usageLogOptions returns the client options that record requests in the
+// configured usage log, tagged with the configured usage_tags and tags.
+func (a *App) usageLogOptions(tags []string) []core.ClientOption {
+	if a.cfg == nil || a.cfg.Telemetry.UsageLog == "" {
+		return nil
+	}
+	all := append(slices.Clone(a.cfg.Telemetry.UsageTags), tags...)
+	return []core.ClientOption{core.WithTelemetry(config.NewUsageLogHook(a.cfg.Telemetry.UsageLog, all...))}
+}
+
+// usageOptions holds the flags of the usage command.
+type usageOptions struct {
+	file  string
+	by    string
+	since string
+}
+
+func (a *App) newUsageCommand() *cobra.Command {
+	var opts usageOptions
+
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Show token usage and cost",
+		Long: `Show token usage and estimated cost from the usage log.
+
+Requests are recorded when telemetry.usage_log is set in the config file,
+by iris chat and iris ask as well as by SDK clients built from the same
+config. Costs use the per-model prices in the pricing section, in USD per
+million tokens; requests to models without a price are counted but not
+priced. A request with several tags counts toward each of them.
+
+Examples:
+  iris usage
+  iris usage --by model --since 7d
+  iris usage --since 2026-01-01 --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return a.runUsage(opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.file, "file", "", "Usage log to read (default is telemetry.usage_log)")
+	cmd.Flags().StringVar(&opts.by, "by", "", "Show one breakdown: provider, model, tag, or day")
+	cmd.Flags().StringVar(&opts.since, "since", "", "Only count requests since a date (2006-01-02) or age (36h, 7d)")
+	return cmd
+}
+
+// usageGroup is the usage of one breakdown key.
+type usageGroup struct {
+	Key          string  `json:"key,omitempty"`
+	Requests     int     `json:"requests"`
+	Errors       int     `json:"errors"`
+	InputTokens  int     `json:"input_tokens"`
+	OutputTokens int     `json:"output_tokens"`
+	CostUSD      float64 `json:"cost_usd"`
+	Unpriced     int     `json:"unpriced_requests"`
+}
+
+func (g *usageGroup) add(r config.UsageRecord, pricing map[string]config.ModelPricing) {
+	g.Requests++
+	if r.Error {
+		g.Errors++
+	}
+	g.InputTokens += r.InputTokens
+	g.OutputTokens += r.OutputTokens
+	if p, ok := pricing[r.Model]; ok {
+		g.CostUSD += p.Cost(r.InputTokens, r.OutputTokens)
+	} else if r.InputTokens+r.OutputTokens > 0 {
+		g.Unpriced++
+	}
+}
+
+// usageReport is the JSON output of iris usage.
+type usageReport struct {
+	Since  *time.Time              `json:"since,omitempty"`
+	Total  usageGroup              `json:"total"`
+	Groups map[string][]usageGroup `json:"by"`
+}
+
+func (a *App) runUsage(opts usageOptions) error {
+	if opts.by != "" && !slices.Contains(usageDimensions, opts.by) {
+		return exitWithCode(ExitValidation, fmt.Errorf("invalid --by %q: use %s", opts.by, strings.Join(usageDimensions, ", ")))
+	}
+	var since time.Time
+	if opts.since != "" {
+		var err error
+		if since, err = parseSince(opts.since, time.Now()); err != nil {
+			return exitWithCode(ExitValidation, err)
+		}
+	}
+
+	path := opts.file
+	if path == "" && a.cfg != nil {
+		path = a.cfg.Telemetry.UsageLog
+	}
+	if path == "" {
+		return exitWithCode(ExitValidation, errors.New("no usage log: set telemetry.usage_log in the config file or pass --file"))
+	}
+	records, err := config.ReadUsageLog(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return exitWithCode(ExitValidation, fmt.Errorf("usage log %s does not exist yet: it is created by the first request", path))
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read usage log: %w", err)
+	}
+
+	var pricing map[string]config.ModelPricing
+	if a.cfg != nil {
+		pricing = a.cfg.Pricing
+	}
+	dims := usageDimensions
+	if opts.by != "" {
+		dims = []string{opts.by}
+	}
+
+	report := usageReport{Groups: make(map[string][]usageGroup)}
+	if !since.IsZero() {
+		report.Since = &since
+	}
+	groups := make(map[string]map[string]*usageGroup)
+	for _, r := range records {
+		if r.Time.Before(since) {
+			continue
+		}
+		report.Total.add(r, pricing)
+		for _, dim := range dims {
+			if groups[dim] == nil {
+				groups[dim] = make(map[string]*usageGroup)
+			}
+			for _, key := range usageKeys(dim, r) {
+				g, ok := groups[dim][key]
+				if !ok {
+					g = &usageGroup{Key: key}
+					groups[dim][key] = g
+				}
+				g.add(r, pricing)
+			}
+		}
+	}
+	for _, dim := range dims {
+		list := []usageGroup{}
+		for _, g := range groups[dim] {
+			list = append(list, *g)
+		}
+		sortUsageGroups(dim, list)
+		report.Groups[dim] = list
+	}
+
+	if a.jsonOutput {
+		return a.encodeJSON(report)
+	}
+	if report.Total.Requests == 0 {
+		fmt.Fprintln(a.stdout, "No requests recorded in this period.")
+		return nil
+	}
+	return a.printUsage(report, dims)
+}
+
+// usageKeys returns the breakdown keys of r along dim.
+func usageKeys(dim string, r config.UsageRecord) []string {
+	switch dim {
+	case "provider":
+		return []string{r.Provider}
+	case "model":
+		return []string{r.Model}
+	case "tag":
+		if len(r.Tags) == 0 {
+			return []string{untagged}
+		}
+		return r.Tags
+	default:
+		return []string{r.Time.Local().Format(time.DateOnly)}
+	}
+}
+
+// sortUsageGroups orders days by date and other groups by cost, then
+// tokens, most first.
+func sortUsageGroups(dim string, list []usageGroup) {
+	sort.Slice(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if dim != "day" {
+			if a.CostUSD != b.CostUSD {
+				return a.CostUSD > b.CostUSD
+			}
+			if at, bt := a.InputTokens+a.OutputTokens, b.InputTokens+b.OutputTokens; at != bt {
+				return at > bt
+			}
+		}
+		return a.Key < b.Key
+	})
+}
+
+func (a *App) printUsage(report usageReport, dims []string) error {
+	for _, dim := range dims {
+		w := tabwriter.NewWriter(a.stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "%s\tREQUESTS\tERRORS\tINPUT\tOUTPUT\tCOST\n", strings.ToUpper(dim))
+		for _, g := range report.Groups[dim] {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%s\n", g.Key, g.Requests, g.Errors, g.InputTokens, g.OutputTokens, formatCost(g))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(a.stdout)
+	}
+
+	t := report.Total
+	fmt.Fprintf(a.stdout, "Total: %d requests (%d failed), %d input and %d output tokens, %s\n",
+		t.Requests, t.Errors, t.InputTokens, t.OutputTokens, formatCost(t))
+	if t.Unpriced > 0 {
+		fmt.Fprintf(a.stdout, "Costs exclude %d requests to models without a price in the pricing section of the config file.\n", t.Unpriced)
+	}
+	return nil
+}
+
+// formatCost returns the cost of g, or "-" when none of its requests could
+// be priced.
+func formatCost(g usageGroup) string {
+	if g.Unpriced > 0 && g.Unpriced == g.Requests {
+		return "-"
+	}
+	return fmt.Sprintf("$%.4f", g.CostUSD)
+}
+
+// parseSince parses a date, such as 2006-01-02, as local midnight, or an
+// age, such as 36h or 7d, as that long before now.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q: use a date (2006-01-02) or an age (36h, 7d)", s)
+}
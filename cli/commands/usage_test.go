@@ -0,0 +1,138 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/cli/config"
+	"github.com/petal-labs/iris/cli/keystore"
+	"github.com/petal-labs/iris/core"
+	iristest "github.com/petal-labs/iris/testing"
+)
+
+func newUsageTestApp(cfg *config.Config, provider *iristest.MockProvider) (*App, *bytes.Buffer) {
+	var stdout bytes.Buffer
+	app := NewApp(
+		WithConfigLoader(func(string) (*config.Config, error) { return cfg, nil }),
+		WithKeystoreFactory(func() (keystore.Keystore, error) { return mapKeystore{"openai": "sk-test"}, nil }),
+		WithProviderFactory(func(id, _ string, _ *config.Config) (core.Provider, error) { return provider.WithID(id), nil }),
+		WithIO(strings.NewReader(""), &stdout, &bytes.Buffer{}),
+	)
+	return app, &stdout
+}
+
+func TestUsage(t *testing.T) {
+	cfg := &config.Config{
+		DefaultProvider: "openai",
+		Pricing:         map[string]config.ModelPricing{"gpt-4o": {Input: 2.5, Output: 10}},
+	}
+	cfg.Telemetry.UsageLog = filepath.Join(t.TempDir(), "usage.jsonl")
+	cfg.Telemetry.UsageTags = []string{"team-a"}
+
+	provider := iristest.NewMockProvider(
+		core.ChatResponse{Output: "One", Usage: core.TokenUsage{PromptTokens: 1000, CompletionTokens: 100}},
+		core.ChatResponse{Output: "Two", Usage: core.TokenUsage{PromptTokens: 500, CompletionTokens: 50}},
+	)
+	app, _ := newUsageTestApp(cfg, provider)
+	if err := runApp(app, "ask", "-m", "gpt-4o", "--tag", "review", "first"); err != nil {
+		t.Fatalf("ask error = %v", err)
+	}
+	app, _ = newUsageTestApp(cfg, provider)
+	if err := runApp(app, "chat", "-m", "gpt-4o-mini", "--prompt", "second"); err != nil {
+		t.Fatalf("chat error = %v", err)
+	}
+
+	app, stdout := newUsageTestApp(cfg, provider)
+	if err := runApp(app, "--json", "usage"); err != nil {
+		t.Fatalf("usage error = %v", err)
+	}
+	var report usageReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, stdout.String())
+	}
+	if report.Total.Requests != 2 || report.Total.InputTokens != 1500 || report.Total.Unpriced != 1 {
+		t.Errorf("total = %+v", report.Total)
+	}
+	if want := 0.0035; report.Total.CostUSD != want {
+		t.Errorf("total cost = %v, want %v", report.Total.CostUSD, want)
+	}
+	var tags []string
+	for _, g := range report.Groups["tag"] {
+		tags = append(tags, fmt.Sprintf("%s:%d", g.Key, g.Requests))
+	}
+	if got := strings.Join(tags, ","); got != "team-a:2,review:1" {
+		t.Errorf("tags = %s, want team-a:2,review:1", got)
+	}
+	if len(report.Groups["model"]) != 2 || report.Groups["model"][0].Key != "gpt-4o" {
+		t.Errorf("models = %+v", report.Groups["model"])
+	}
+
+	app, stdout = newUsageTestApp(cfg, provider)
+	if err := runApp(app, "usage", "--by", "model"); err != nil {
+		t.Fatalf("usage --by model error = %v", err)
+	}
+	out := stdout.String()
+	for _, want := range []string{"MODEL", "gpt-4o-mini", "$0.0035", "Costs exclude 1 requests"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "PROVIDER") {
+		t.Errorf("--by model printed other breakdowns:\n%s", out)
+	}
+}
+
+func TestUsageErrors(t *testing.T) {
+	missing := &config.Config{}
+	missing.Telemetry.UsageLog = filepath.Join(t.TempDir(), "usage.jsonl")
+
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		args []string
+	}{
+		{"no usage log", &config.Config{}, []string{"usage"}},
+		{"log not created", missing, []string{"usage"}},
+		{"invalid by", missing, []string{"usage", "--by", "week"}},
+		{"invalid since", missing, []string{"usage", "--since", "last week"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app, _ := newUsageTestApp(tt.cfg, iristest.NewMockProvider())
+			err := runApp(app, tt.args...)
+			var exitErr *exitError
+			if !errors.As(err, &exitErr) || exitErr.ExitCode() != ExitValidation {
+				t.Errorf("error = %v, want exit code %d", err, ExitValidation)
+			}
+		})
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"2026-03-01", time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)},
+		{"7d", now.AddDate(0, 0, -7)},
+		{"36h", now.Add(-36 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		got, err := parseSince(tt.input, now)
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseSince(%q) = %v, %v; want %v", tt.input, got, err, tt.want)
+		}
+	}
+	if _, err := parseSince("-1d", now); err == nil {
+		t.Error("parseSince accepted a negative age")
+	}
+}
@@ -5,22 +5,23 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
-	"gopkg.in/yaml.v3"
+	"github.com/petal-labs/iris/config"
 )
 
-// Config represents the CLI configuration.
-type Config struct {
-	DefaultProvider string                    `yaml:"default_provider"`
-	DefaultModel    string                    `yaml:"default_model"`
-	Providers       map[string]ProviderConfig `yaml:"providers"`
-}
+// Config represents the CLI configuration. It is the shared Iris
+// configuration, so the same file can also configure SDK clients.
+type Config = config.Config
 
 // ProviderConfig holds configuration for a specific provider.
-type ProviderConfig struct {
-	APIKeyRef string `yaml:"api_key_ref"`
-	BaseURL   string `yaml:"base_url,omitempty"`
-}
+type ProviderConfig = config.ProviderConfig
+
+// ToolsConfig holds the settings for the tools commands.
+type ToolsConfig = config.ToolsConfig
+
+// ModelPricing holds the price of a model for the usage command.
+type ModelPricing = config.ModelPricing
 
 // DefaultConfigPath returns the default configuration file path for the current platform.
 // - macOS/Linux: ~/.iris/config.yaml
@@ -45,40 +46,28 @@ func DefaultConfigPath() string {
 // LoadConfig loads configuration from the specified path.
 // If the file doesn't exist, returns an empty config without error.
 // Returns an error only if the file exists but cannot be read or parsed.
+//
+// Files ending in .toml are parsed as TOML and all others as YAML. ${ENV}
+// references are expanded and IRIS_PROVIDER and IRIS_MODEL override the
+// configured defaults (see package github.com/petal-labs/iris/config).
 func LoadConfig(path string) (*Config, error) {
-	cfg := &Config{
-		Providers: make(map[string]ProviderConfig),
-	}
-
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
 			// Missing config file is not an error
-			return cfg, nil
+			return &Config{Providers: make(map[string]ProviderConfig)}, nil
 		}
 		return nil, err
 	}
 
-	if err := yaml.Unmarshal(data, cfg); err != nil {
-		return nil, err
+	format := config.FormatYAML
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		format = config.FormatTOML
 	}
-
-	// Ensure Providers map is initialized
-	if cfg.Providers == nil {
-		cfg.Providers = make(map[string]ProviderConfig)
+	cfg, err := config.Parse(data, format)
+	if err != nil {
+		return nil, err
 	}
-
+	cfg.ApplyEnv()
 	return cfg, nil
 }
-
-// GetProvider returns the provider config for the given ID.
-// Returns nil if the provider is not configured.
-func (c *Config) GetProvider(id string) *ProviderConfig {
-	if c.Providers == nil {
-		return nil
-	}
-	if pc, ok := c.Providers[id]; ok {
-		return &pc
-	}
-	return nil
-}
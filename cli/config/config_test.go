@@ -200,3 +200,25 @@ func TestConfigGetProviderNilMap(t *testing.T) {
 		t.Error("GetProvider on nil Providers should return nil")
 	}
 }
+
+func TestLoadConfigTOMLWithEnvExpansion(t *testing.T) {
+	t.Setenv("IRIS_TEST_BASE_URL", "http://gpu-box:11434")
+	content := `
+default_provider = "ollama"
+
+[providers.ollama]
+base_url = "${IRIS_TEST_BASE_URL}"
+`
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write temp config: %v", err)
+	}
+
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if pc := cfg.GetProvider("ollama"); pc == nil || pc.BaseURL != "http://gpu-box:11434" {
+		t.Errorf("GetProvider(ollama) = %+v, want expanded base_url", pc)
+	}
+}
@@ -0,0 +1,318 @@
+// Package config loads Iris settings from YAML or TOML files and the
+// environment, so deployments can choose providers, credentials, models,
+// retries, and telemetry without hard-coding client options.
+//
+// A YAML configuration:
+//
+//	default_provider: openai
+//	default_model: gpt-4o
+//
+//	providers:
+//	  openai:
+//	    api_key: ${OPENAI_API_KEY}
+//	  ollama:
+//	    base_url: ${OLLAMA_HOST:-http://localhost:11434}
+//	    default_model: llama3.2
+//
+//	retry:
+//	  max_retries: 5
+//	  base_delay: 500ms
+//
+//	telemetry:
+//	  log: true
+//	  log_level: debug
+//
+//	tools:
+//	  root: ${HOME}/agent-workspace
+//	  allowed_hosts: [api.example.com]
+//
+// The same keys are used in TOML. String values may reference environment
+// variables as ${NAME} or ${NAME:-default}; bare $NAME is left as is.
+// After loading, IRIS_PROVIDER and IRIS_MODEL override default_provider and
+// default_model.
+//
+//	cfg, err := config.Load("iris.yaml")
+//	client, err := cfg.NewClient("") // default provider
+//	resp, err := client.Chat("").User("Hello").GetResponse(ctx)
+//
+// Providers are constructed through core.NewProviderFromConfig, so the
+// provider packages a configuration may name must be imported.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// Environment variables applied by Load and ApplyEnv.
+const (
+	EnvProvider = "IRIS_PROVIDER"
+	EnvModel    = "IRIS_MODEL"
+)
+
+var (
+	// ErrUnknownFormat is returned for configuration files whose extension is
+	// not .yaml, .yml, or .toml.
+	ErrUnknownFormat = errors.New("config: unknown file format")
+
+	// ErrNoProvider is returned when no provider is named and the
+	// configuration has no default_provider.
+	ErrNoProvider = errors.New("config: no provider selected")
+)
+
+// Format is a configuration file syntax.
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
+)
+
+// Config holds Iris settings.
+type Config struct {
+	DefaultProvider string                    `yaml:"default_provider" toml:"default_provider"`
+	DefaultModel    string                    `yaml:"default_model" toml:"default_model"`
+	Providers       map[string]ProviderConfig `yaml:"providers" toml:"providers"`
+	Retry           *RetryConfig              `yaml:"retry,omitempty" toml:"retry"`
+	Telemetry       TelemetryConfig           `yaml:"telemetry,omitempty" toml:"telemetry"`
+	Tools           ToolsConfig               `yaml:"tools,omitempty" toml:"tools"`
+	Pricing         map[string]ModelPricing   `yaml:"pricing,omitempty" toml:"pricing"`
+}
+
+// ModelPricing is the price of a model in USD per million tokens. It is
+// used by the CLI only, to estimate costs from the usage log.
+type ModelPricing struct {
+	Input  float64 `yaml:"input" toml:"input"`
+	Output float64 `yaml:"output" toml:"output"`
+}
+
+// Cost returns the price in USD of the given token counts.
+func (p ModelPricing) Cost(inputTokens, outputTokens int) float64 {
+	return (float64(inputTokens)*p.Input + float64(outputTokens)*p.Output) / 1e6
+}
+
+// ToolsConfig selects the standard tools (package tools/std) registered by
+// the CLI. It is used by the CLI only. current_time and calculator are
+// always available; tools that reach outside the process are off until
+// configured.
+type ToolsConfig struct {
+	// Root enables read_file and list_dir, confined to this directory.
+	Root string `yaml:"root,omitempty" toml:"root"`
+
+	// AllowWrite also enables write_file under Root.
+	AllowWrite bool `yaml:"allow_write,omitempty" toml:"allow_write"`
+
+	// AllowedHosts enables http_fetch for these hosts.
+	AllowedHosts []string `yaml:"allowed_hosts,omitempty" toml:"allowed_hosts"`
+}
+
+// ProviderConfig holds the settings of one provider.
+type ProviderConfig struct {
+	// APIKey is the credential, usually given as ${ENV_VAR}.
+	APIKey string `yaml:"api_key,omitempty" toml:"api_key"`
+
+	// APIKeyEnv names an environment variable holding the credential.
+	APIKeyEnv string `yaml:"api_key_env,omitempty" toml:"api_key_env"`
+
+	// APIKeyRef names a key in the CLI keystore. It is used by the CLI only.
+	APIKeyRef string `yaml:"api_key_ref,omitempty" toml:"api_key_ref"`
+
+	BaseURL      string `yaml:"base_url,omitempty" toml:"base_url"`
+	DefaultModel string `yaml:"default_model,omitempty" toml:"default_model"`
+}
+
+// ResolveAPIKey returns APIKey, or the value of the APIKeyEnv variable.
+// It returns "" when neither is set, leaving the provider to fall back to its
+// usual environment variable.
+func (p ProviderConfig) ResolveAPIKey() string {
+	if p.APIKey != "" {
+		return p.APIKey
+	}
+	if p.APIKeyEnv != "" {
+		return os.Getenv(p.APIKeyEnv)
+	}
+	return ""
+}
+
+// RetryConfig configures the client retry policy. Unset fields use the
+// defaults of core.DefaultRetryPolicy.
+type RetryConfig struct {
+	Disabled   bool          `yaml:"disabled,omitempty" toml:"disabled"`
+	MaxRetries int           `yaml:"max_retries,omitempty" toml:"max_retries"`
+	BaseDelay  time.Duration `yaml:"base_delay,omitempty" toml:"base_delay"`
+	MaxDelay   time.Duration `yaml:"max_delay,omitempty" toml:"max_delay"`
+	Jitter     *float64      `yaml:"jitter,omitempty" toml:"jitter"`
+}
+
+// TelemetryConfig configures built-in telemetry.
+type TelemetryConfig struct {
+	// Log writes one slog record per request with provider, model, duration,
+	// token usage, and error.
+	Log bool `yaml:"log,omitempty" toml:"log"`
+
+	// LogLevel is the level of successful request records: debug, info
+	// (the default), warn, or error. Failed requests are logged at error.
+	LogLevel string `yaml:"log_level,omitempty" toml:"log_level"`
+
+	// UsageLog appends one JSON line per request with provider, model,
+	// token usage, and UsageTags to this file. See UsageLogHook.
+	UsageLog string `yaml:"usage_log,omitempty" toml:"usage_log"`
+
+	// UsageTags are added to every usage log record, for example a team or
+	// project name.
+	UsageTags []string `yaml:"usage_tags,omitempty" toml:"usage_tags"`
+}
+
+// Load reads a configuration file, choosing the format from its extension,
+// expands ${ENV} references, and applies environment overrides.
+func Load(path string) (*Config, error) {
+	var format Format
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		format = FormatYAML
+	case ".toml":
+		format = FormatTOML
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownFormat, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := Parse(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("config: %s: %w", path, err)
+	}
+	cfg.ApplyEnv()
+	return cfg, nil
+}
+
+// Parse decodes configuration data and expands ${ENV} references. Unlike
+// Load, it does not apply environment overrides.
+func Parse(data []byte, format Format) (*Config, error) {
+	cfg := &Config{}
+	switch format {
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, err
+		}
+	case FormatTOML:
+		if _, err := toml.Decode(string(data), cfg); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+
+	if cfg.Providers == nil {
+		cfg.Providers = make(map[string]ProviderConfig)
+	}
+	cfg.expand()
+	return cfg, nil
+}
+
+// ApplyEnv overrides the default provider and model from IRIS_PROVIDER and
+// IRIS_MODEL when they are set.
+func (c *Config) ApplyEnv() {
+	if v := os.Getenv(EnvProvider); v != "" {
+		c.DefaultProvider = v
+	}
+	if v := os.Getenv(EnvModel); v != "" {
+		c.DefaultModel = v
+	}
+}
+
+// GetProvider returns the settings of the named provider, or nil if it is
+// not configured.
+func (c *Config) GetProvider(name string) *ProviderConfig {
+	if c.Providers == nil {
+		return nil
+	}
+	if pc, ok := c.Providers[name]; ok {
+		return &pc
+	}
+	return nil
+}
+
+// ClientConfig returns the core.ClientConfig for the named provider, or for
+// the default provider when name is empty. The model is the provider's
+// default_model, falling back to the top-level default_model. Providers
+// without a providers entry are allowed and use their built-in defaults.
+func (c *Config) ClientConfig(name string) (core.ClientConfig, error) {
+	if name == "" {
+		name = c.DefaultProvider
+	}
+	if name == "" {
+		return core.ClientConfig{}, ErrNoProvider
+	}
+
+	var pc ProviderConfig
+	if p := c.GetProvider(name); p != nil {
+		pc = *p
+	}
+
+	cc := core.ClientConfig{
+		Provider: core.ProviderConfig{
+			Name:    name,
+			APIKey:  core.NewSecret(pc.ResolveAPIKey()),
+			BaseURL: pc.BaseURL,
+			Model:   core.ModelID(pc.DefaultModel),
+		},
+	}
+	if cc.Provider.Model == "" {
+		cc.Provider.Model = core.ModelID(c.DefaultModel)
+	}
+
+	if r := c.Retry; r != nil {
+		cc.DisableRetry = r.Disabled
+		cc.Retry = &core.RetryConfig{
+			MaxRetries: r.MaxRetries,
+			BaseDelay:  r.BaseDelay,
+			MaxDelay:   r.MaxDelay,
+			Jitter:     0.2,
+		}
+		if r.Jitter != nil {
+			cc.Retry.Jitter = *r.Jitter
+		}
+	}
+
+	var hooks multiHook
+	if c.Telemetry.Log {
+		level, err := parseLevel(c.Telemetry.LogLevel)
+		if err != nil {
+			return core.ClientConfig{}, err
+		}
+		hooks = append(hooks, &logHook{level: level})
+	}
+	if c.Telemetry.UsageLog != "" {
+		hooks = append(hooks, NewUsageLogHook(c.Telemetry.UsageLog, c.Telemetry.UsageTags...))
+	}
+	switch len(hooks) {
+	case 0:
+	case 1:
+		cc.Telemetry = hooks[0]
+	default:
+		cc.Telemetry = hooks
+	}
+	return cc, nil
+}
+
+// NewClient returns a client for the named provider, or for the default
+// provider when name is empty. See ClientConfig and core.NewClientFromConfig.
+func (c *Config) NewClient(name string, opts ...core.ClientOption) (*core.Client, error) {
+	cc, err := c.ClientConfig(name)
+	if err != nil {
+		return nil, err
+	}
+	return core.NewClientFromConfig(cc, opts...)
+}
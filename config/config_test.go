@@ -0,0 +1,247 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+	_ "github.com/petal-labs/iris/providers/ollama"
+)
+
+const yamlConfig = `
+default_provider: openai
+default_model: gpt-4o
+
+providers:
+  openai:
+    api_key: ${IRIS_TEST_OPENAI_KEY}
+  ollama:
+    base_url: ${IRIS_TEST_OLLAMA_HOST:-http://localhost:11434}
+    default_model: llama3.2
+
+retry:
+  max_retries: 5
+  base_delay: 500ms
+
+telemetry:
+  log: true
+  log_level: debug
+`
+
+const tomlConfig = `
+default_provider = "openai"
+default_model = "gpt-4o"
+
+[providers.openai]
+api_key = "${IRIS_TEST_OPENAI_KEY}"
+
+[providers.ollama]
+base_url = "${IRIS_TEST_OLLAMA_HOST:-http://localhost:11434}"
+default_model = "llama3.2"
+
+[retry]
+max_retries = 5
+base_delay = "500ms"
+
+[telemetry]
+log = true
+log_level = "debug"
+`
+
+func writeFile(t *testing.T, name, data string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	t.Setenv("IRIS_TEST_OPENAI_KEY", "sk-test")
+	t.Setenv("IRIS_TEST_OLLAMA_HOST", "")
+	t.Setenv(EnvProvider, "")
+	t.Setenv(EnvModel, "")
+
+	for name, data := range map[string]string{"iris.yaml": yamlConfig, "iris.toml": tomlConfig} {
+		t.Run(name, func(t *testing.T) {
+			cfg, err := Load(writeFile(t, name, data))
+			if err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+			if cfg.DefaultProvider != "openai" || cfg.DefaultModel != "gpt-4o" {
+				t.Errorf("defaults = %q, %q", cfg.DefaultProvider, cfg.DefaultModel)
+			}
+			if got := cfg.Providers["openai"].APIKey; got != "sk-test" {
+				t.Errorf("openai api_key = %q, want expanded value", got)
+			}
+			if got := cfg.Providers["ollama"].BaseURL; got != "http://localhost:11434" {
+				t.Errorf("ollama base_url = %q, want default value", got)
+			}
+			if cfg.Retry == nil || cfg.Retry.MaxRetries != 5 || cfg.Retry.BaseDelay != 500*time.Millisecond {
+				t.Errorf("retry = %+v", cfg.Retry)
+			}
+			if !cfg.Telemetry.Log || cfg.Telemetry.LogLevel != "debug" {
+				t.Errorf("telemetry = %+v", cfg.Telemetry)
+			}
+		})
+	}
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	t.Setenv(EnvProvider, "ollama")
+	t.Setenv(EnvModel, "qwen3")
+
+	cfg, err := Load(writeFile(t, "iris.yml", yamlConfig))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.DefaultProvider != "ollama" || cfg.DefaultModel != "qwen3" {
+		t.Errorf("defaults = %q, %q, want env overrides", cfg.DefaultProvider, cfg.DefaultModel)
+	}
+}
+
+func TestLoadUnknownFormat(t *testing.T) {
+	if _, err := Load(writeFile(t, "iris.json", "{}")); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("Load() error = %v, want ErrUnknownFormat", err)
+	}
+}
+
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("IRIS_TEST_SET", "value")
+	t.Setenv("IRIS_TEST_EMPTY", "")
+
+	tests := map[string]string{
+		"${IRIS_TEST_SET}":               "value",
+		"prefix-${IRIS_TEST_SET}-suffix": "prefix-value-suffix",
+		"${IRIS_TEST_EMPTY:-fallback}":   "fallback",
+		"${IRIS_TEST_SET:-fallback}":     "value",
+		"${IRIS_TEST_UNSET_XYZ}":         "",
+		"$IRIS_TEST_SET":                 "$IRIS_TEST_SET",
+	}
+	for in, want := range tests {
+		if got := expandEnv(in); got != want {
+			t.Errorf("expandEnv(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestClientConfig(t *testing.T) {
+	t.Setenv("IRIS_TEST_KEY", "from-env")
+	jitter := 0.0
+	cfg := &Config{
+		DefaultProvider: "openai",
+		DefaultModel:    "gpt-4o",
+		Providers: map[string]ProviderConfig{
+			"openai": {APIKeyEnv: "IRIS_TEST_KEY", BaseURL: "https://proxy.example.com/v1"},
+			"ollama": {DefaultModel: "llama3.2"},
+		},
+		Retry: &RetryConfig{MaxRetries: 2, Jitter: &jitter},
+	}
+
+	cc, err := cfg.ClientConfig("")
+	if err != nil {
+		t.Fatalf("ClientConfig() error = %v", err)
+	}
+	if cc.Provider.Name != "openai" || cc.Provider.APIKey.Expose() != "from-env" ||
+		cc.Provider.BaseURL != "https://proxy.example.com/v1" || cc.Provider.Model != "gpt-4o" {
+		t.Errorf("Provider = %+v", cc.Provider)
+	}
+	if cc.Retry == nil || cc.Retry.MaxRetries != 2 || cc.Retry.Jitter != 0 {
+		t.Errorf("Retry = %+v", cc.Retry)
+	}
+	if cc.Telemetry != nil {
+		t.Errorf("Telemetry = %T, want nil", cc.Telemetry)
+	}
+
+	cc, err = cfg.ClientConfig("ollama")
+	if err != nil {
+		t.Fatalf("ClientConfig(ollama) error = %v", err)
+	}
+	if cc.Provider.Model != "llama3.2" {
+		t.Errorf("ollama model = %q, want provider default_model", cc.Provider.Model)
+	}
+
+	if _, err := (&Config{}).ClientConfig(""); !errors.Is(err, ErrNoProvider) {
+		t.Errorf("error = %v, want ErrNoProvider", err)
+	}
+	bad := &Config{DefaultProvider: "openai", Telemetry: TelemetryConfig{Log: true, LogLevel: "loud"}}
+	if _, err := bad.ClientConfig(""); err == nil {
+		t.Error("expected error for unknown log_level")
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	t.Setenv("OLLAMA_HOST", "")
+	cfg, err := Parse([]byte(yamlConfig), FormatYAML)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	client, err := cfg.NewClient("ollama")
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	if client.Provider().ID() != "ollama" {
+		t.Errorf("provider = %q, want ollama", client.Provider().ID())
+	}
+
+	if _, err := cfg.NewClient("not-registered"); !errors.Is(err, core.ErrUnknownProvider) {
+		t.Errorf("error = %v, want core.ErrUnknownProvider", err)
+	}
+}
+
+func TestUsageLog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "usage.jsonl")
+	cfg := &Config{
+		DefaultProvider: "openai",
+		Telemetry:       TelemetryConfig{Log: true, UsageLog: path, UsageTags: []string{"team-a"}},
+	}
+	cc, err := cfg.ClientConfig("")
+	if err != nil {
+		t.Fatalf("ClientConfig() error = %v", err)
+	}
+
+	start := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cc.Telemetry.OnRequestEnd(core.RequestEndEvent{
+		Provider: "openai", Model: "gpt-4o", Start: start, End: start.Add(1500 * time.Millisecond),
+		Usage: core.TokenUsage{PromptTokens: 100, CompletionTokens: 20},
+	})
+	cc.Telemetry.OnRequestEnd(core.RequestEndEvent{
+		Provider: "openai", Model: "gpt-4o", Start: start, End: start, Err: core.ErrRateLimited,
+	})
+
+	records, err := ReadUsageLog(path)
+	if err != nil {
+		t.Fatalf("ReadUsageLog() error = %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+	r := records[0]
+	if r.Provider != "openai" || r.Model != "gpt-4o" || r.InputTokens != 100 || r.OutputTokens != 20 ||
+		r.DurationMS != 1500 || r.Error || len(r.Tags) != 1 || r.Tags[0] != "team-a" {
+		t.Errorf("record = %+v", r)
+	}
+	if !records[1].Error {
+		t.Error("failed request not marked as error")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("usage log mode = %o, want 600", perm)
+	}
+}
+
+func TestModelPricingCost(t *testing.T) {
+	p := ModelPricing{Input: 2.5, Output: 10}
+	if got := p.Cost(1_000_000, 500_000); got != 7.5 {
+		t.Errorf("Cost() = %v, want 7.5", got)
+	}
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"os"
+	"regexp"
+)
+
+// envRef matches ${NAME} and ${NAME:-default}.
+var envRef = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(?::-([^}]*))?\}`)
+
+// expandEnv replaces ${NAME} with the value of the environment variable NAME,
+// and ${NAME:-default} with default when NAME is unset or empty.
+func expandEnv(s string) string {
+	return envRef.ReplaceAllStringFunc(s, func(ref string) string {
+		m := envRef.FindStringSubmatch(ref)
+		if v := os.Getenv(m[1]); v != "" {
+			return v
+		}
+		return m[2]
+	})
+}
+
+// expand expands environment references in every string setting.
+func (c *Config) expand() {
+	c.DefaultProvider = expandEnv(c.DefaultProvider)
+	c.DefaultModel = expandEnv(c.DefaultModel)
+	for name, p := range c.Providers {
+		p.APIKey = expandEnv(p.APIKey)
+		p.APIKeyEnv = expandEnv(p.APIKeyEnv)
+		p.APIKeyRef = expandEnv(p.APIKeyRef)
+		p.BaseURL = expandEnv(p.BaseURL)
+		p.DefaultModel = expandEnv(p.DefaultModel)
+		c.Providers[name] = p
+	}
+	c.Telemetry.LogLevel = expandEnv(c.Telemetry.LogLevel)
+	c.Telemetry.UsageLog = expandEnv(c.Telemetry.UsageLog)
+	for i, tag := range c.Telemetry.UsageTags {
+		c.Telemetry.UsageTags[i] = expandEnv(tag)
+	}
+	c.Tools.Root = expandEnv(c.Tools.Root)
+	for i, host := range c.Tools.AllowedHosts {
+		c.Tools.AllowedHosts[i] = expandEnv(host)
+	}
+}
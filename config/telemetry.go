@@ -0,0 +1,64 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// logHook is the telemetry hook enabled by telemetry.log. It writes to the
+// default slog logger at request end; like every TelemetryHook it never sees
+// prompts, responses, or credentials.
+type logHook struct {
+	core.NoopTelemetryHook
+	level slog.Level
+}
+
+func (h *logHook) OnRequestEnd(e core.RequestEndEvent) {
+	level := h.level
+	attrs := []slog.Attr{
+		slog.String("provider", e.Provider),
+		slog.String("model", string(e.Model)),
+		slog.Duration("duration", e.Duration()),
+		slog.Int("input_tokens", e.Usage.PromptTokens),
+		slog.Int("output_tokens", e.Usage.CompletionTokens),
+	}
+	if e.Err != nil {
+		level = slog.LevelError
+		attrs = append(attrs, slog.Any("error", e.Err))
+	}
+	slog.Default().LogAttrs(context.Background(), level, "iris request", attrs...)
+}
+
+func parseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(s) {
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "debug":
+		return slog.LevelDebug, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return 0, fmt.Errorf("config: unknown telemetry log_level %q", s)
+	}
+}
+
+// multiHook sends events to several hooks in order.
+type multiHook []core.TelemetryHook
+
+func (m multiHook) OnRequestStart(e core.RequestStartEvent) {
+	for _, h := range m {
+		h.OnRequestStart(e)
+	}
+}
+
+func (m multiHook) OnRequestEnd(e core.RequestEndEvent) {
+	for _, h := range m {
+		h.OnRequestEnd(e)
+	}
+}
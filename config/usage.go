@@ -0,0 +1,110 @@
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// UsageRecord is one line of the usage log.
+type UsageRecord struct {
+	Time         time.Time `json:"time"`
+	Provider     string    `json:"provider"`
+	Model        string    `json:"model"`
+	InputTokens  int       `json:"input_tokens"`
+	OutputTokens int       `json:"output_tokens"`
+	DurationMS   int64     `json:"duration_ms"`
+	Error        bool      `json:"error,omitempty"`
+	Tags         []string  `json:"tags,omitempty"`
+}
+
+// UsageLogHook is the telemetry hook enabled by telemetry.usage_log. It
+// appends one UsageRecord per request to a JSON Lines file, which
+// 'iris usage' reads. Like every TelemetryHook it never sees prompts,
+// responses, or credentials. Write failures are logged with slog and do not
+// affect the request.
+type UsageLogHook struct {
+	core.NoopTelemetryHook
+	path string
+	tags []string
+	mu   sync.Mutex
+}
+
+// NewUsageLogHook returns a hook that appends to the file at path, creating
+// it when needed, and adds tags to every record.
+func NewUsageLogHook(path string, tags ...string) *UsageLogHook {
+	return &UsageLogHook{path: path, tags: tags}
+}
+
+// OnRequestEnd appends the record of the finished request.
+func (h *UsageLogHook) OnRequestEnd(e core.RequestEndEvent) {
+	rec := UsageRecord{
+		Time:         e.End.UTC(),
+		Provider:     e.Provider,
+		Model:        string(e.Model),
+		InputTokens:  e.Usage.PromptTokens,
+		OutputTokens: e.Usage.CompletionTokens,
+		DurationMS:   e.Duration().Milliseconds(),
+		Error:        e.Err != nil,
+		Tags:         h.tags,
+	}
+	if err := h.append(rec); err != nil {
+		slog.Warn("iris: failed to write usage log", slog.String("path", h.path), slog.Any("error", err))
+	}
+}
+
+func (h *UsageLogHook) append(rec UsageRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	line = append(line, '\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if err := os.MkdirAll(filepath.Dir(h.path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(h.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(line); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadUsageLog reads the records of a usage log written by UsageLogHook.
+func ReadUsageLog(path string) ([]UsageRecord, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []UsageRecord
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var rec UsageRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("config: %s:%d: %w", path, n, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
@@ -0,0 +1,87 @@
+package awssecrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// DefaultTTL is how long a fetched secret is used before it is fetched
+// again, unless WithTTL is given.
+const DefaultTTL = 5 * time.Minute
+
+// Client is the subset of *secretsmanager.Client used by Credential.
+type Client interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+type config struct {
+	ttl          time.Duration
+	jsonKey      string
+	versionStage string
+}
+
+// Option configures Credential.
+type Option func(*config)
+
+// WithTTL sets how long a fetched secret is cached. A rejected key is
+// always fetched again regardless of the TTL.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *config) { c.ttl = ttl }
+}
+
+// WithJSONKey reads the key from the given field of a secret stored as a
+// JSON object, such as {"OPENAI_API_KEY": "sk-..."}.
+func WithJSONKey(key string) Option {
+	return func(c *config) { c.jsonKey = key }
+}
+
+// WithVersionStage fetches the given version stage instead of AWSCURRENT.
+func WithVersionStage(stage string) Option {
+	return func(c *config) { c.versionStage = stage }
+}
+
+// Credential returns a credential provider that reads the API key from the
+// secret secretID, which may be a name or an ARN. The secret is cached for
+// DefaultTTL and fetched again when a provider rejects the cached key.
+func Credential(client Client, secretID string, opts ...Option) core.CredentialRefresher {
+	cfg := config{ttl: DefaultTTL}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return core.CachedCredential(core.CredentialFunc(func(ctx context.Context) (core.Secret, error) {
+		in := &secretsmanager.GetSecretValueInput{SecretId: aws.String(secretID)}
+		if cfg.versionStage != "" {
+			in.VersionStage = aws.String(cfg.versionStage)
+		}
+		out, err := client.GetSecretValue(ctx, in)
+		if err != nil {
+			return core.Secret{}, fmt.Errorf("awssecrets: get %s: %w", secretID, err)
+		}
+		if out.SecretString == nil {
+			return core.Secret{}, fmt.Errorf("%w: awssecrets: %s has no string value", core.ErrNoCredential, secretID)
+		}
+		return parseSecret(secretID, *out.SecretString, cfg.jsonKey)
+	}), cfg.ttl)
+}
+
+func parseSecret(secretID, value, jsonKey string) (core.Secret, error) {
+	if jsonKey != "" {
+		var fields map[string]string
+		if err := json.Unmarshal([]byte(value), &fields); err != nil {
+			return core.Secret{}, fmt.Errorf("awssecrets: %s is not a JSON object: %w", secretID, err)
+		}
+		value = fields[jsonKey]
+	}
+	if value == "" {
+		return core.Secret{}, fmt.Errorf("%w: awssecrets: %s is empty", core.ErrNoCredential, secretID)
+	}
+	return core.NewSecret(value), nil
+}
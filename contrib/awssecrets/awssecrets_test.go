@@ -0,0 +1,78 @@
+package awssecrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+
+	"github.com/petal-labs/iris/core"
+)
+
+type fakeClient struct {
+	values []string
+	calls  int
+	last   *secretsmanager.GetSecretValueInput
+	err    error
+}
+
+func (f *fakeClient) GetSecretValue(_ context.Context, in *secretsmanager.GetSecretValueInput, _ ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	f.last = in
+	if f.err != nil {
+		return nil, f.err
+	}
+	v := f.values[min(f.calls, len(f.values)-1)]
+	f.calls++
+	return &secretsmanager.GetSecretValueOutput{SecretString: aws.String(v)}, nil
+}
+
+func TestCredentialCachesAndRefreshes(t *testing.T) {
+	client := &fakeClient{values: []string{"sk-one", "sk-two"}}
+	creds := Credential(client, "prod/openai", WithVersionStage("AWSPENDING"))
+	ctx := context.Background()
+
+	for range 2 {
+		if key, err := creds.Credential(ctx); err != nil || key.Expose() != "sk-one" {
+			t.Fatalf("Credential() = %q, %v", key.Expose(), err)
+		}
+	}
+	if client.calls != 1 {
+		t.Errorf("GetSecretValue called %d times, want 1", client.calls)
+	}
+	if got := aws.ToString(client.last.SecretId); got != "prod/openai" {
+		t.Errorf("SecretId = %q", got)
+	}
+	if got := aws.ToString(client.last.VersionStage); got != "AWSPENDING" {
+		t.Errorf("VersionStage = %q", got)
+	}
+
+	if err := creds.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := creds.Credential(ctx); key.Expose() != "sk-two" {
+		t.Errorf("Credential() after Refresh = %q, want sk-two", key.Expose())
+	}
+}
+
+func TestCredentialJSONKey(t *testing.T) {
+	client := &fakeClient{values: []string{`{"OPENAI_API_KEY":"sk-json","OTHER":"x"}`}}
+	key, err := Credential(client, "prod/keys", WithJSONKey("OPENAI_API_KEY")).Credential(context.Background())
+	if err != nil || key.Expose() != "sk-json" {
+		t.Errorf("Credential() = %q, %v", key.Expose(), err)
+	}
+
+	_, err = Credential(client, "prod/keys", WithJSONKey("MISSING")).Credential(context.Background())
+	if !errors.Is(err, core.ErrNoCredential) {
+		t.Errorf("missing field error = %v, want ErrNoCredential", err)
+	}
+}
+
+func TestCredentialError(t *testing.T) {
+	denied := errors.New("AccessDeniedException")
+	_, err := Credential(&fakeClient{err: denied}, "prod/openai").Credential(context.Background())
+	if !errors.Is(err, denied) {
+		t.Errorf("Credential() error = %v, want %v", err, denied)
+	}
+}
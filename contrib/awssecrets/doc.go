@@ -0,0 +1,27 @@
+// Package awssecrets reads provider API keys from AWS Secrets Manager, so
+// keys rotated in Secrets Manager are picked up without a restart.
+//
+// Credential returns a core.CredentialRefresher that caches the secret for
+// a few minutes. When a provider rejects the cached key with 401, it fetches
+// the secret again and retries the request with the new key.
+//
+// # Usage
+//
+//	import (
+//	    "github.com/aws/aws-sdk-go-v2/config"
+//	    "github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+//	    "github.com/petal-labs/iris/contrib/awssecrets"
+//	    "github.com/petal-labs/iris/providers/openai"
+//	)
+//
+//	awsCfg, err := config.LoadDefaultConfig(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	creds := awssecrets.Credential(secretsmanager.NewFromConfig(awsCfg), "prod/openai",
+//	    awssecrets.WithJSONKey("OPENAI_API_KEY"))
+//	provider := openai.New("", openai.WithCredentials(creds))
+//
+// Secrets may hold the key as plain text or, with WithJSONKey, as a field
+// of a JSON object. Binary secrets are not supported.
+package awssecrets
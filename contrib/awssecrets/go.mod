@@ -0,0 +1,17 @@
+module github.com/petal-labs/iris/contrib/awssecrets
+
+go 1.24.0
+
+require (
+	github.com/aws/aws-sdk-go-v2 v1.41.1
+	github.com/aws/aws-sdk-go-v2/service/secretsmanager v1.41.1
+	github.com/petal-labs/iris v0.13.0
+)
+
+require (
+	github.com/aws/aws-sdk-go-v2/internal/configsources v1.4.17 // indirect
+	github.com/aws/aws-sdk-go-v2/internal/endpoints/v2 v2.7.17 // indirect
+	github.com/aws/smithy-go v1.24.0 // indirect
+)
+
+replace github.com/petal-labs/iris => ../..
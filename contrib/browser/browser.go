@@ -0,0 +1,248 @@
+package browser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrTooManySessions is returned by NewSession when MaxSessions are open.
+	ErrTooManySessions = errors.New("browser: too many open sessions")
+
+	// ErrClosed is returned when using a closed Browser or Session.
+	ErrClosed = errors.New("browser: closed")
+
+	// ErrHostNotAllowed is returned when navigating to a host outside AllowedHosts.
+	ErrHostNotAllowed = errors.New("browser: host not allowed")
+)
+
+// Config configures a Browser.
+type Config struct {
+	// ExecPath is the Chrome or Chromium binary. If empty, chromedp searches
+	// the usual install locations.
+	ExecPath string
+
+	// Headless runs the browser without a window. Defaults to true.
+	Headless bool
+
+	// AllowedHosts restricts navigation to matching host names. Entries may use
+	// glob patterns such as "*.example.com". Empty allows any host.
+	AllowedHosts []string
+
+	// MaxSessions caps concurrently open sessions. Defaults to 4.
+	MaxSessions int
+
+	// IdleTimeout closes sessions that have not run an action for this long.
+	// Defaults to 5 minutes.
+	IdleTimeout time.Duration
+
+	// ActionTimeout bounds each tool call. Defaults to 30 seconds.
+	ActionTimeout time.Duration
+
+	// MaxTextBytes caps text returned by browser_extract_text. Defaults to 64 KiB.
+	MaxTextBytes int
+
+	// MaxScreenshotBytes caps screenshot size. Defaults to 5 MiB.
+	MaxScreenshotBytes int
+
+	// ViewportWidth and ViewportHeight set the window size. Default 1280x800.
+	ViewportWidth  int
+	ViewportHeight int
+}
+
+// Option configures a Browser.
+type Option func(*Config)
+
+// WithExecPath sets the Chrome binary path.
+func WithExecPath(p string) Option {
+	return func(c *Config) { c.ExecPath = p }
+}
+
+// WithHeadless controls whether the browser runs headless.
+func WithHeadless(headless bool) Option {
+	return func(c *Config) { c.Headless = headless }
+}
+
+// WithAllowedHosts restricts navigation to the given host patterns.
+func WithAllowedHosts(hosts ...string) Option {
+	return func(c *Config) { c.AllowedHosts = append(c.AllowedHosts, hosts...) }
+}
+
+// WithMaxSessions sets the maximum number of concurrent sessions.
+func WithMaxSessions(n int) Option {
+	return func(c *Config) { c.MaxSessions = n }
+}
+
+// WithIdleTimeout sets how long an unused session stays open.
+func WithIdleTimeout(d time.Duration) Option {
+	return func(c *Config) { c.IdleTimeout = d }
+}
+
+// WithActionTimeout sets the timeout for each browser action.
+func WithActionTimeout(d time.Duration) Option {
+	return func(c *Config) { c.ActionTimeout = d }
+}
+
+// WithMaxTextBytes caps extracted text size.
+func WithMaxTextBytes(n int) Option {
+	return func(c *Config) { c.MaxTextBytes = n }
+}
+
+// WithMaxScreenshotBytes caps screenshot size.
+func WithMaxScreenshotBytes(n int) Option {
+	return func(c *Config) { c.MaxScreenshotBytes = n }
+}
+
+// WithViewport sets the browser window size.
+func WithViewport(width, height int) Option {
+	return func(c *Config) {
+		c.ViewportWidth = width
+		c.ViewportHeight = height
+	}
+}
+
+// Browser manages a Chrome process and the sessions opened on it.
+// Browser is safe for concurrent use.
+type Browser struct {
+	config Config
+
+	mu        sync.Mutex
+	allocator allocator
+	sessions  map[*Session]struct{}
+	closed    bool
+
+	// newAllocator starts the browser process; replaced in tests.
+	newAllocator func(Config) (allocator, error)
+}
+
+// allocator starts the browser process and opens tabs on it.
+type allocator interface {
+	newTab() (driver, error)
+	close()
+}
+
+// New creates a Browser. The Chrome process is started lazily by the first
+// call to NewSession.
+func New(opts ...Option) *Browser {
+	cfg := Config{
+		Headless:           true,
+		MaxSessions:        4,
+		IdleTimeout:        5 * time.Minute,
+		ActionTimeout:      30 * time.Second,
+		MaxTextBytes:       64 << 10,
+		MaxScreenshotBytes: 5 << 20,
+		ViewportWidth:      1280,
+		ViewportHeight:     800,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return &Browser{
+		config:       cfg,
+		sessions:     make(map[*Session]struct{}),
+		newAllocator: newChromeAllocator,
+	}
+}
+
+// NewSession opens a new tab. The session must be closed when no longer needed.
+func (b *Browser) NewSession(ctx context.Context) (*Session, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return nil, ErrClosed
+	}
+	if b.config.MaxSessions > 0 && len(b.sessions) >= b.config.MaxSessions {
+		return nil, ErrTooManySessions
+	}
+
+	if b.allocator == nil {
+		alloc, err := b.newAllocator(b.config)
+		if err != nil {
+			return nil, fmt.Errorf("browser: start: %w", err)
+		}
+		b.allocator = alloc
+	}
+
+	d, err := b.allocator.newTab()
+	if err != nil {
+		return nil, fmt.Errorf("browser: open tab: %w", err)
+	}
+
+	s := &Session{browser: b, driver: d}
+	if b.config.IdleTimeout > 0 {
+		s.mu.Lock()
+		s.idle = time.AfterFunc(b.config.IdleTimeout, func() { s.Close() })
+		s.mu.Unlock()
+	}
+	b.sessions[s] = struct{}{}
+	return s, nil
+}
+
+// Sessions returns the number of open sessions.
+func (b *Browser) Sessions() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.sessions)
+}
+
+// Close closes all sessions and stops the browser process.
+func (b *Browser) Close() error {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return nil
+	}
+	b.closed = true
+	sessions := make([]*Session, 0, len(b.sessions))
+	for s := range b.sessions {
+		sessions = append(sessions, s)
+	}
+	alloc := b.allocator
+	b.mu.Unlock()
+
+	for _, s := range sessions {
+		s.Close()
+	}
+	if alloc != nil {
+		alloc.close()
+	}
+	return nil
+}
+
+func (b *Browser) release(s *Session) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.sessions, s)
+}
+
+// checkURL validates a navigation target against the allowlist.
+func (b *Browser) checkURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("browser: invalid url: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("browser: unsupported url scheme %q", u.Scheme)
+	}
+	if len(b.config.AllowedHosts) == 0 {
+		return nil
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, pattern := range b.config.AllowedHosts {
+		if ok, _ := path.Match(strings.ToLower(pattern), host); ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+}
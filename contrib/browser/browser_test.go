@@ -0,0 +1,266 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+type fakeAllocator struct {
+	mu     sync.Mutex
+	tabs   []*fakeTab
+	closed bool
+}
+
+func (a *fakeAllocator) newTab() (driver, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	tab := &fakeTab{pages: map[string]Page{}}
+	a.tabs = append(a.tabs, tab)
+	return tab, nil
+}
+
+func (a *fakeAllocator) close() { a.closed = true }
+
+type fakeTab struct {
+	mu      sync.Mutex
+	pages   map[string]Page
+	current Page
+	body    string
+	shot    []byte
+	clicked []string
+	filled  map[string]string
+	closed  bool
+	delay   time.Duration
+	visited []string
+}
+
+func (t *fakeTab) navigate(ctx context.Context, url string) (Page, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.visited = append(t.visited, url)
+	page, ok := t.pages[url]
+	if !ok {
+		page = Page{URL: url, Title: "Page " + url}
+	}
+	t.current = page
+	return page, nil
+}
+
+func (t *fakeTab) text(ctx context.Context, selector string) (string, error) {
+	if t.delay > 0 {
+		select {
+		case <-time.After(t.delay):
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	return selector + ":" + t.body, nil
+}
+
+func (t *fakeTab) screenshot(ctx context.Context, selector string) ([]byte, error) {
+	return t.shot, nil
+}
+
+func (t *fakeTab) click(ctx context.Context, selector string) error {
+	t.clicked = append(t.clicked, selector)
+	return nil
+}
+
+func (t *fakeTab) fill(ctx context.Context, selector, value string) error {
+	if t.filled == nil {
+		t.filled = map[string]string{}
+	}
+	t.filled[selector] = value
+	return nil
+}
+
+func (t *fakeTab) close() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.closed = true
+}
+
+func (t *fakeTab) isClosed() bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.closed
+}
+
+func newTestBrowser(opts ...Option) (*Browser, *fakeAllocator) {
+	alloc := &fakeAllocator{}
+	b := New(opts...)
+	b.newAllocator = func(Config) (allocator, error) { return alloc, nil }
+	return b, alloc
+}
+
+func toolByName(t *testing.T, s *Session, name string) tools.Tool {
+	t.Helper()
+	for _, tool := range s.Tools() {
+		if tool.Name() == name {
+			return tool
+		}
+	}
+	t.Fatalf("tool %q not found", name)
+	return nil
+}
+
+func TestSessionTools(t *testing.T) {
+	b, alloc := newTestBrowser()
+	defer b.Close()
+
+	s, err := b.NewSession(context.Background())
+	if err != nil {
+		t.Fatalf("NewSession() error = %v", err)
+	}
+	tab := alloc.tabs[0]
+	tab.body = "hello"
+	tab.shot = []byte{0x89, 'P', 'N', 'G'}
+	ctx := context.Background()
+
+	out, err := toolByName(t, s, "browser_navigate").Call(ctx, json.RawMessage(`{"url":"https://example.com/"}`))
+	if err != nil {
+		t.Fatalf("navigate error = %v", err)
+	}
+	if page := out.(Page); page.URL != "https://example.com/" {
+		t.Errorf("page = %+v", page)
+	}
+
+	out, err = toolByName(t, s, "browser_extract_text").Call(ctx, json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("extract_text error = %v", err)
+	}
+	if got := out.(map[string]any)["text"]; got != "body:hello" {
+		t.Errorf("text = %v", got)
+	}
+
+	out, err = toolByName(t, s, "browser_screenshot").Call(ctx, nil)
+	if err != nil {
+		t.Fatalf("screenshot error = %v", err)
+	}
+	if shot := out.(*ScreenshotResult); shot.MimeType != "image/png" || len(shot.Data) != 4 {
+		t.Errorf("screenshot = %+v", shot)
+	}
+
+	if _, err := toolByName(t, s, "browser_click").Call(ctx, json.RawMessage(`{"selector":"#go"}`)); err != nil {
+		t.Fatalf("click error = %v", err)
+	}
+	if _, err := toolByName(t, s, "browser_fill").Call(ctx, json.RawMessage(`{"selector":"#q","value":"iris"}`)); err != nil {
+		t.Fatalf("fill error = %v", err)
+	}
+	if len(tab.clicked) != 1 || tab.filled["#q"] != "iris" {
+		t.Errorf("clicked=%v filled=%v", tab.clicked, tab.filled)
+	}
+
+	if _, err := toolByName(t, s, "browser_click").Call(ctx, json.RawMessage(`{}`)); err == nil {
+		t.Error("expected error for missing selector")
+	}
+
+	if scopes := tools.ScopesOf(toolByName(t, s, "browser_navigate")); len(scopes) != 2 {
+		t.Errorf("navigate scopes = %v", scopes)
+	}
+}
+
+func TestNavigateAllowlist(t *testing.T) {
+	b, alloc := newTestBrowser(WithAllowedHosts("*.example.com", "example.com"))
+	defer b.Close()
+
+	s, _ := b.NewSession(context.Background())
+	tab := alloc.tabs[0]
+	tab.pages["https://example.com/redirect"] = Page{URL: "https://evil.test/"}
+	ctx := context.Background()
+
+	if _, err := s.Navigate(ctx, "https://www.example.com/"); err != nil {
+		t.Errorf("allowed host error = %v", err)
+	}
+	if _, err := s.Navigate(ctx, "https://evil.test/"); !errors.Is(err, ErrHostNotAllowed) {
+		t.Errorf("expected ErrHostNotAllowed, got %v", err)
+	}
+	if _, err := s.Navigate(ctx, "file:///etc/passwd"); err == nil {
+		t.Error("expected error for file scheme")
+	}
+	if _, err := s.Navigate(ctx, "https://example.com/redirect"); !errors.Is(err, ErrHostNotAllowed) {
+		t.Errorf("expected redirect to be blocked, got %v", err)
+	}
+	if last := tab.visited[len(tab.visited)-1]; last != "about:blank" {
+		t.Errorf("expected tab reset after blocked redirect, last visit %q", last)
+	}
+}
+
+func TestSessionLimits(t *testing.T) {
+	b, alloc := newTestBrowser(WithMaxSessions(1), WithMaxTextBytes(8), WithMaxScreenshotBytes(2))
+
+	s, err := b.NewSession(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.NewSession(context.Background()); !errors.Is(err, ErrTooManySessions) {
+		t.Errorf("expected ErrTooManySessions, got %v", err)
+	}
+
+	tab := alloc.tabs[0]
+	tab.body = strings.Repeat("x", 20)
+	tab.shot = []byte("large")
+
+	text, truncated, err := s.Text(context.Background(), "")
+	if err != nil || len(text) != 8 || !truncated {
+		t.Errorf("Text() = %q, %v, %v", text, truncated, err)
+	}
+	if _, err := s.Screenshot(context.Background(), ""); err == nil {
+		t.Error("expected screenshot size error")
+	}
+
+	s.Close()
+	if !tab.isClosed() || b.Sessions() != 0 {
+		t.Errorf("session not released: closed=%v sessions=%d", tab.closed, b.Sessions())
+	}
+	if _, _, err := s.Text(context.Background(), ""); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+	if _, err := b.NewSession(context.Background()); err != nil {
+		t.Errorf("slot not freed: %v", err)
+	}
+
+	b.Close()
+	if !alloc.closed {
+		t.Error("allocator not closed")
+	}
+	if _, err := b.NewSession(context.Background()); !errors.Is(err, ErrClosed) {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+}
+
+func TestActionTimeout(t *testing.T) {
+	b, alloc := newTestBrowser(WithActionTimeout(10 * time.Millisecond))
+	defer b.Close()
+
+	s, _ := b.NewSession(context.Background())
+	alloc.tabs[0].delay = time.Second
+
+	if _, _, err := s.Text(context.Background(), ""); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestIdleTimeoutClosesSession(t *testing.T) {
+	b, alloc := newTestBrowser(WithIdleTimeout(10 * time.Millisecond))
+	defer b.Close()
+
+	if _, err := b.NewSession(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for b.Sessions() != 0 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if b.Sessions() != 0 || !alloc.tabs[0].isClosed() {
+		t.Error("idle session was not closed")
+	}
+}
@@ -0,0 +1,108 @@
+package browser
+
+import (
+	"context"
+
+	"github.com/chromedp/chromedp"
+)
+
+// chromeAllocator runs a Chrome process through chromedp.
+type chromeAllocator struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func newChromeAllocator(cfg Config) (allocator, error) {
+	opts := append([]chromedp.ExecAllocatorOption{}, chromedp.DefaultExecAllocatorOptions[:]...)
+	opts = append(opts,
+		chromedp.Flag("headless", cfg.Headless),
+		chromedp.WindowSize(cfg.ViewportWidth, cfg.ViewportHeight),
+	)
+	if cfg.ExecPath != "" {
+		opts = append(opts, chromedp.ExecPath(cfg.ExecPath))
+	}
+
+	ctx, cancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	return &chromeAllocator{ctx: ctx, cancel: cancel}, nil
+}
+
+func (a *chromeAllocator) newTab() (driver, error) {
+	ctx, cancel := chromedp.NewContext(a.ctx)
+	// Running with no actions starts the browser (on first use) and opens the tab.
+	if err := chromedp.Run(ctx); err != nil {
+		cancel()
+		return nil, err
+	}
+	return &chromeTab{ctx: ctx, cancel: cancel}, nil
+}
+
+func (a *chromeAllocator) close() {
+	a.cancel()
+}
+
+// chromeTab is a driver backed by a chromedp tab context.
+type chromeTab struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// run executes actions on the tab, bounded by the caller's context.
+func (t *chromeTab) run(ctx context.Context, actions ...chromedp.Action) error {
+	runCtx, cancel := context.WithCancel(t.ctx)
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); ok {
+		runCtx, cancel = context.WithDeadline(runCtx, deadline)
+		defer cancel()
+	}
+	stop := context.AfterFunc(ctx, cancel)
+	defer stop()
+
+	if err := chromedp.Run(runCtx, actions...); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+	return nil
+}
+
+func (t *chromeTab) navigate(ctx context.Context, url string) (Page, error) {
+	var page Page
+	err := t.run(ctx,
+		chromedp.Navigate(url),
+		chromedp.Location(&page.URL),
+		chromedp.Title(&page.Title),
+	)
+	return page, err
+}
+
+func (t *chromeTab) text(ctx context.Context, selector string) (string, error) {
+	var text string
+	err := t.run(ctx, chromedp.Text(selector, &text, chromedp.ByQuery))
+	return text, err
+}
+
+func (t *chromeTab) screenshot(ctx context.Context, selector string) ([]byte, error) {
+	var buf []byte
+	if selector == "" {
+		err := t.run(ctx, chromedp.CaptureScreenshot(&buf))
+		return buf, err
+	}
+	err := t.run(ctx, chromedp.Screenshot(selector, &buf, chromedp.ByQuery))
+	return buf, err
+}
+
+func (t *chromeTab) click(ctx context.Context, selector string) error {
+	return t.run(ctx, chromedp.Click(selector, chromedp.ByQuery, chromedp.NodeVisible))
+}
+
+func (t *chromeTab) fill(ctx context.Context, selector, value string) error {
+	return t.run(ctx,
+		chromedp.Clear(selector, chromedp.ByQuery),
+		chromedp.SendKeys(selector, value, chromedp.ByQuery),
+	)
+}
+
+func (t *chromeTab) close() {
+	t.cancel()
+}
@@ -0,0 +1,47 @@
+// Package browser provides browser automation tools for Iris agents, backed by
+// headless Chrome through chromedp.
+//
+// # Usage
+//
+// Create a Browser, open a Session per conversation, and expose the session's
+// tools to the model:
+//
+//	import (
+//	    "github.com/petal-labs/iris/contrib/browser"
+//	    "github.com/petal-labs/iris/tools"
+//	)
+//
+//	b := browser.New(
+//	    browser.WithAllowedHosts("*.wikipedia.org"),
+//	    browser.WithMaxSessions(2),
+//	)
+//	defer b.Close()
+//
+//	session, err := b.NewSession(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer session.Close()
+//
+//	reg := tools.NewRegistry()
+//	for _, t := range session.Tools() {
+//	    reg.Register(t)
+//	}
+//
+// Each session owns one browser tab. The tools it returns are:
+//
+//   - browser_navigate: open a URL and report the page title
+//   - browser_extract_text: read the visible text of the page or an element
+//   - browser_screenshot: capture a PNG of the viewport or an element
+//   - browser_click: click an element
+//   - browser_fill: type a value into an input
+//
+// # Resource Limits
+//
+// The number of concurrent sessions, per-action timeout, idle lifetime, and the
+// size of extracted text and screenshots are all bounded; see Config. Idle
+// sessions are closed automatically.
+//
+// Chrome or Chromium must be installed on the host. Use WithExecPath to point
+// at a specific binary.
+package browser
@@ -0,0 +1,154 @@
+package browser
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// driver performs actions on a single browser tab.
+type driver interface {
+	navigate(ctx context.Context, url string) (Page, error)
+	text(ctx context.Context, selector string) (string, error)
+	screenshot(ctx context.Context, selector string) ([]byte, error)
+	click(ctx context.Context, selector string) error
+	fill(ctx context.Context, selector, value string) error
+	close()
+}
+
+// Page describes the page loaded in a session.
+type Page struct {
+	URL   string `json:"url"`
+	Title string `json:"title"`
+}
+
+// Session is a single browser tab. Actions on a session run one at a time.
+type Session struct {
+	browser *Browser
+	driver  driver
+	idle    *time.Timer
+
+	mu     sync.Mutex
+	closed bool
+}
+
+// Close closes the tab and releases the session slot. It is safe to call
+// more than once.
+func (s *Session) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	if s.idle != nil {
+		s.idle.Stop()
+	}
+	s.driver.close()
+	s.browser.release(s)
+	return nil
+}
+
+// do runs fn with the action timeout applied and the idle timer reset.
+func (s *Session) do(ctx context.Context, fn func(ctx context.Context) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+	if s.idle != nil {
+		s.idle.Reset(s.browser.config.IdleTimeout)
+	}
+
+	if timeout := s.browser.config.ActionTimeout; timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return fn(ctx)
+}
+
+// Navigate loads url and waits for the page to be ready.
+func (s *Session) Navigate(ctx context.Context, url string) (Page, error) {
+	if err := s.browser.checkURL(url); err != nil {
+		return Page{}, err
+	}
+
+	var page Page
+	err := s.do(ctx, func(ctx context.Context) error {
+		var err error
+		page, err = s.driver.navigate(ctx, url)
+		if err != nil {
+			return err
+		}
+		// Redirects may land on a host outside the allowlist.
+		if err := s.browser.checkURL(page.URL); err != nil {
+			_, _ = s.driver.navigate(ctx, "about:blank")
+			return err
+		}
+		return nil
+	})
+	return page, err
+}
+
+// Text returns the visible text of the element matching selector, or of the
+// whole page when selector is empty. The text is truncated to MaxTextBytes.
+func (s *Session) Text(ctx context.Context, selector string) (text string, truncated bool, err error) {
+	if selector == "" {
+		selector = "body"
+	}
+	err = s.do(ctx, func(ctx context.Context) error {
+		var err error
+		text, err = s.driver.text(ctx, selector)
+		return err
+	})
+	if err != nil {
+		return "", false, err
+	}
+	if limit := s.browser.config.MaxTextBytes; limit > 0 && len(text) > limit {
+		text, truncated = truncateUTF8(text, limit), true
+	}
+	return text, truncated, nil
+}
+
+// Screenshot captures a PNG of the element matching selector, or of the
+// viewport when selector is empty.
+func (s *Session) Screenshot(ctx context.Context, selector string) ([]byte, error) {
+	var buf []byte
+	err := s.do(ctx, func(ctx context.Context) error {
+		var err error
+		buf, err = s.driver.screenshot(ctx, selector)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if limit := s.browser.config.MaxScreenshotBytes; limit > 0 && len(buf) > limit {
+		return nil, fmt.Errorf("browser: screenshot is %d bytes, exceeds %d byte limit", len(buf), limit)
+	}
+	return buf, nil
+}
+
+// Click clicks the first element matching selector.
+func (s *Session) Click(ctx context.Context, selector string) error {
+	return s.do(ctx, func(ctx context.Context) error {
+		return s.driver.click(ctx, selector)
+	})
+}
+
+// Fill replaces the value of the input matching selector.
+func (s *Session) Fill(ctx context.Context, selector, value string) error {
+	return s.do(ctx, func(ctx context.Context) error {
+		return s.driver.fill(ctx, selector, value)
+	})
+}
+
+func truncateUTF8(s string, limit int) string {
+	for limit > 0 && limit < len(s) && s[limit]&0xC0 == 0x80 {
+		limit--
+	}
+	return s[:limit]
+}
@@ -0,0 +1,199 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+// Scope labels declared by the browser tools.
+const (
+	ScopeBrowser = "browser"
+	ScopeNetwork = "network"
+)
+
+// Tools returns the browser tools bound to this session.
+func (s *Session) Tools() []tools.Tool {
+	return []tools.Tool{
+		&navigateTool{s},
+		&extractTextTool{s},
+		&screenshotTool{s},
+		&clickTool{s},
+		&fillTool{s},
+	}
+}
+
+func schema(s string) tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: json.RawMessage(s)}
+}
+
+func parseArgs[T any](args json.RawMessage) (T, error) {
+	var v T
+	if len(args) == 0 {
+		return v, nil
+	}
+	if err := json.Unmarshal(args, &v); err != nil {
+		return v, fmt.Errorf("invalid arguments: %w", err)
+	}
+	return v, nil
+}
+
+type navigateTool struct{ s *Session }
+
+func (t *navigateTool) Name() string { return "browser_navigate" }
+func (t *navigateTool) Description() string {
+	return "Open a URL in the browser and return the final URL and page title."
+}
+func (t *navigateTool) Schema() tools.ToolSchema {
+	return schema(`{
+		"type": "object",
+		"properties": {
+			"url": {"type": "string", "description": "Absolute http or https URL"}
+		},
+		"required": ["url"]
+	}`)
+}
+func (t *navigateTool) Scopes() []string { return []string{ScopeBrowser, ScopeNetwork} }
+func (t *navigateTool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	params, err := parseArgs[struct {
+		URL string `json:"url"`
+	}](args)
+	if err != nil {
+		return nil, err
+	}
+	return t.s.Navigate(ctx, params.URL)
+}
+
+type extractTextTool struct{ s *Session }
+
+func (t *extractTextTool) Name() string { return "browser_extract_text" }
+func (t *extractTextTool) Description() string {
+	return "Return the visible text of the current page, or of the element matching a CSS selector."
+}
+func (t *extractTextTool) Schema() tools.ToolSchema {
+	return schema(`{
+		"type": "object",
+		"properties": {
+			"selector": {"type": "string", "description": "CSS selector. Defaults to the whole page."}
+		}
+	}`)
+}
+func (t *extractTextTool) Scopes() []string { return []string{ScopeBrowser} }
+func (t *extractTextTool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	params, err := parseArgs[struct {
+		Selector string `json:"selector"`
+	}](args)
+	if err != nil {
+		return nil, err
+	}
+	text, truncated, err := t.s.Text(ctx, params.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"text": text, "truncated": truncated}, nil
+}
+
+// ScreenshotResult is returned by the browser_screenshot tool.
+// Data is base64-encoded when serialized to JSON.
+type ScreenshotResult struct {
+	MimeType string `json:"mime_type"`
+	Data     []byte `json:"data"`
+}
+
+type screenshotTool struct{ s *Session }
+
+func (t *screenshotTool) Name() string { return "browser_screenshot" }
+func (t *screenshotTool) Description() string {
+	return "Capture a PNG screenshot of the viewport, or of the element matching a CSS selector."
+}
+func (t *screenshotTool) Schema() tools.ToolSchema {
+	return schema(`{
+		"type": "object",
+		"properties": {
+			"selector": {"type": "string", "description": "CSS selector. Defaults to the viewport."}
+		}
+	}`)
+}
+func (t *screenshotTool) Scopes() []string { return []string{ScopeBrowser} }
+func (t *screenshotTool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	params, err := parseArgs[struct {
+		Selector string `json:"selector"`
+	}](args)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := t.s.Screenshot(ctx, params.Selector)
+	if err != nil {
+		return nil, err
+	}
+	return &ScreenshotResult{MimeType: "image/png", Data: buf}, nil
+}
+
+type clickTool struct{ s *Session }
+
+func (t *clickTool) Name() string { return "browser_click" }
+func (t *clickTool) Description() string {
+	return "Click the element matching a CSS selector."
+}
+func (t *clickTool) Schema() tools.ToolSchema {
+	return schema(`{
+		"type": "object",
+		"properties": {
+			"selector": {"type": "string", "description": "CSS selector of the element to click"}
+		},
+		"required": ["selector"]
+	}`)
+}
+func (t *clickTool) Scopes() []string { return []string{ScopeBrowser} }
+func (t *clickTool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	params, err := parseArgs[struct {
+		Selector string `json:"selector"`
+	}](args)
+	if err != nil {
+		return nil, err
+	}
+	if params.Selector == "" {
+		return nil, errors.New("selector is required")
+	}
+	if err := t.s.Click(ctx, params.Selector); err != nil {
+		return nil, err
+	}
+	return map[string]any{"clicked": params.Selector}, nil
+}
+
+type fillTool struct{ s *Session }
+
+func (t *fillTool) Name() string { return "browser_fill" }
+func (t *fillTool) Description() string {
+	return "Type a value into the input or textarea matching a CSS selector, replacing its contents."
+}
+func (t *fillTool) Schema() tools.ToolSchema {
+	return schema(`{
+		"type": "object",
+		"properties": {
+			"selector": {"type": "string", "description": "CSS selector of the input"},
+			"value": {"type": "string", "description": "Text to enter"}
+		},
+		"required": ["selector", "value"]
+	}`)
+}
+func (t *fillTool) Scopes() []string { return []string{ScopeBrowser} }
+func (t *fillTool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	params, err := parseArgs[struct {
+		Selector string `json:"selector"`
+		Value    string `json:"value"`
+	}](args)
+	if err != nil {
+		return nil, err
+	}
+	if params.Selector == "" {
+		return nil, errors.New("selector is required")
+	}
+	if err := t.s.Fill(ctx, params.Selector, params.Value); err != nil {
+		return nil, err
+	}
+	return map[string]any{"filled": params.Selector}, nil
+}
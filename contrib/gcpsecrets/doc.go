@@ -0,0 +1,32 @@
+// Package gcpsecrets reads provider API keys from Google Cloud Secret
+// Manager, so keys rotated in Secret Manager are picked up without a
+// restart.
+//
+// Credential returns a core.CredentialRefresher that caches the secret for
+// a few minutes. When a provider rejects the cached key with 401, it fetches
+// the secret again and retries the request with the new key.
+//
+// # Usage
+//
+//	import (
+//	    secretmanager "cloud.google.com/go/secretmanager/apiv1"
+//	    "cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+//	    "github.com/petal-labs/iris/contrib/gcpsecrets"
+//	    "github.com/petal-labs/iris/providers/anthropic"
+//	)
+//
+//	sm, err := secretmanager.NewClient(ctx)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer sm.Close()
+//
+//	creds := gcpsecrets.Credential(gcpsecrets.ClientFunc(
+//	    func(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+//	        return sm.AccessSecretVersion(ctx, req)
+//	    }), "projects/my-project/secrets/anthropic-key")
+//	provider := anthropic.New("", anthropic.WithCredentials(creds))
+//
+// Credential also accepts a secretmanagerpb.SecretManagerServiceClient
+// created directly on a gRPC connection.
+package gcpsecrets
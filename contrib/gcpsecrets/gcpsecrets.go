@@ -0,0 +1,72 @@
+package gcpsecrets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/grpc"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// DefaultTTL is how long a fetched secret is used before it is fetched
+// again, unless WithTTL is given.
+const DefaultTTL = 5 * time.Minute
+
+// Client is the subset of secretmanagerpb.SecretManagerServiceClient used
+// by Credential. Use ClientFunc to adapt a *secretmanager.Client.
+type Client interface {
+	AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, opts ...grpc.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error)
+}
+
+// ClientFunc adapts a function, such as the AccessSecretVersion method of a
+// *secretmanager.Client, to a Client.
+type ClientFunc func(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest) (*secretmanagerpb.AccessSecretVersionResponse, error)
+
+// AccessSecretVersion calls f.
+func (f ClientFunc) AccessSecretVersion(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest, _ ...grpc.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	return f(ctx, req)
+}
+
+type config struct {
+	ttl time.Duration
+}
+
+// Option configures Credential.
+type Option func(*config)
+
+// WithTTL sets how long a fetched secret is cached. A rejected key is
+// always fetched again regardless of the TTL.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *config) { c.ttl = ttl }
+}
+
+// Credential returns a credential provider that reads the API key from the
+// secret version name, such as
+// "projects/my-project/secrets/openai-key/versions/latest". A name without
+// a version reads the latest version. The secret is cached for DefaultTTL
+// and fetched again when a provider rejects the cached key.
+func Credential(client Client, name string, opts ...Option) core.CredentialRefresher {
+	cfg := config{ttl: DefaultTTL}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+
+	return core.CachedCredential(core.CredentialFunc(func(ctx context.Context) (core.Secret, error) {
+		resp, err := client.AccessSecretVersion(ctx, &secretmanagerpb.AccessSecretVersionRequest{Name: name})
+		if err != nil {
+			return core.Secret{}, fmt.Errorf("gcpsecrets: access %s: %w", name, err)
+		}
+		key := strings.TrimSpace(string(resp.GetPayload().GetData()))
+		if key == "" {
+			return core.Secret{}, fmt.Errorf("%w: gcpsecrets: %s is empty", core.ErrNoCredential, name)
+		}
+		return core.NewSecret(key), nil
+	}), cfg.ttl)
+}
@@ -0,0 +1,87 @@
+package gcpsecrets
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"cloud.google.com/go/secretmanager/apiv1/secretmanagerpb"
+	"google.golang.org/grpc"
+
+	"github.com/petal-labs/iris/core"
+)
+
+type fakeClient struct {
+	values []string
+	calls  int
+	names  []string
+	err    error
+}
+
+func (f *fakeClient) AccessSecretVersion(_ context.Context, req *secretmanagerpb.AccessSecretVersionRequest, _ ...grpc.CallOption) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+	f.names = append(f.names, req.GetName())
+	if f.err != nil {
+		return nil, f.err
+	}
+	v := f.values[min(f.calls, len(f.values)-1)]
+	f.calls++
+	return &secretmanagerpb.AccessSecretVersionResponse{
+		Payload: &secretmanagerpb.SecretPayload{Data: []byte(v)},
+	}, nil
+}
+
+func TestCredentialCachesAndRefreshes(t *testing.T) {
+	client := &fakeClient{values: []string{"sk-one\n", "sk-two\n"}}
+	creds := Credential(client, "projects/p/secrets/key")
+	ctx := context.Background()
+
+	for range 2 {
+		if key, err := creds.Credential(ctx); err != nil || key.Expose() != "sk-one" {
+			t.Fatalf("Credential() = %q, %v", key.Expose(), err)
+		}
+	}
+	if client.calls != 1 {
+		t.Errorf("AccessSecretVersion called %d times, want 1", client.calls)
+	}
+	if client.names[0] != "projects/p/secrets/key/versions/latest" {
+		t.Errorf("name = %q, want the latest version", client.names[0])
+	}
+
+	if err := creds.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := creds.Credential(ctx); key.Expose() != "sk-two" {
+		t.Errorf("Credential() after Refresh = %q, want sk-two", key.Expose())
+	}
+}
+
+func TestCredentialVersion(t *testing.T) {
+	client := &fakeClient{values: []string{"sk"}}
+	Credential(client, "projects/p/secrets/key/versions/3").Credential(context.Background())
+	if client.names[0] != "projects/p/secrets/key/versions/3" {
+		t.Errorf("name = %q", client.names[0])
+	}
+}
+
+func TestCredentialErrors(t *testing.T) {
+	_, err := Credential(&fakeClient{values: []string{" "}}, "projects/p/secrets/key").Credential(context.Background())
+	if !errors.Is(err, core.ErrNoCredential) {
+		t.Errorf("empty secret error = %v, want ErrNoCredential", err)
+	}
+
+	denied := errors.New("permission denied")
+	_, err = Credential(&fakeClient{err: denied}, "projects/p/secrets/key").Credential(context.Background())
+	if !errors.Is(err, denied) {
+		t.Errorf("Credential() error = %v, want %v", err, denied)
+	}
+}
+
+func TestClientFunc(t *testing.T) {
+	fake := &fakeClient{values: []string{"sk-func"}}
+	client := ClientFunc(func(ctx context.Context, req *secretmanagerpb.AccessSecretVersionRequest) (*secretmanagerpb.AccessSecretVersionResponse, error) {
+		return fake.AccessSecretVersion(ctx, req)
+	})
+	if key, err := Credential(client, "projects/p/secrets/key").Credential(context.Background()); err != nil || key.Expose() != "sk-func" {
+		t.Errorf("Credential() = %q, %v", key.Expose(), err)
+	}
+}
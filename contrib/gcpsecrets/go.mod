@@ -0,0 +1,22 @@
+module github.com/petal-labs/iris/contrib/gcpsecrets
+
+go 1.24.0
+
+require (
+	cloud.google.com/go/secretmanager v1.16.0
+	github.com/petal-labs/iris v0.13.0
+	google.golang.org/grpc v1.74.2
+)
+
+require (
+	cloud.google.com/go/iam v1.5.2 // indirect
+	golang.org/x/net v0.43.0 // indirect
+	golang.org/x/sys v0.40.0 // indirect
+	golang.org/x/text v0.28.0 // indirect
+	google.golang.org/genproto v0.0.0-20250603155806-513f23925822 // indirect
+	google.golang.org/genproto/googleapis/api v0.0.0-20250818200422-3122310a409c // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250811230008-5f3141c8851a // indirect
+	google.golang.org/protobuf v1.36.7 // indirect
+)
+
+replace github.com/petal-labs/iris => ../..
@@ -0,0 +1,120 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+
+	"github.com/petal-labs/iris/contrib/grpc/irispb"
+	"github.com/petal-labs/iris/core"
+)
+
+// Provider is a core.Provider backed by a remote ChatService. Provider is
+// safe for concurrent use.
+type Provider struct {
+	client   irispb.ChatServiceClient
+	id       string
+	models   []core.ModelInfo
+	features map[core.Feature]bool
+}
+
+// NewProvider describes the ChatService on cc and returns a provider that
+// forwards to it. The caller owns cc and closes it after use.
+func NewProvider(ctx context.Context, cc grpc.ClientConnInterface) (*Provider, error) {
+	client := irispb.NewChatServiceClient(cc)
+	desc, err := client.Describe(ctx, &irispb.DescribeRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("grpc: describe: %w", fromStatus("grpc", err))
+	}
+	if desc.GetProvider() == "" {
+		return nil, errors.New("grpc: describe returned no provider ID")
+	}
+
+	features := make(map[core.Feature]bool, len(desc.GetFeatures()))
+	for _, f := range desc.GetFeatures() {
+		features[core.Feature(f)] = true
+	}
+	return &Provider{
+		client:   client,
+		id:       desc.GetProvider(),
+		models:   fromProtoModels(desc.GetModels()),
+		features: features,
+	}, nil
+}
+
+// ID returns the ID of the remote provider.
+func (p *Provider) ID() string { return p.id }
+
+// Models returns the models of the remote provider.
+func (p *Provider) Models() []core.ModelInfo { return p.models }
+
+// Supports reports whether the remote provider supports the feature.
+func (p *Provider) Supports(feature core.Feature) bool { return p.features[feature] }
+
+// Chat sends a chat request to the remote provider.
+func (p *Provider) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	pb, err := toProtoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Chat(ctx, pb)
+	if err != nil {
+		return nil, fromStatus(p.id, err)
+	}
+	return fromProtoResponse(resp), nil
+}
+
+// StreamChat sends a streaming chat request to the remote provider.
+func (p *Provider) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	pb, err := toProtoRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	in, err := p.client.StreamChat(ctx, pb)
+	if err != nil {
+		cancel()
+		return nil, fromStatus(p.id, err)
+	}
+
+	ch := make(chan core.ChatChunk)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+
+	go func() {
+		defer cancel()
+		defer close(ch)
+		defer close(errCh)
+		defer close(finalCh)
+
+		for {
+			chunk, err := in.Recv()
+			if err == io.EOF {
+				errCh <- &core.ProviderError{Provider: p.id, Message: "stream ended without a final response", Err: core.ErrNetwork}
+				return
+			}
+			if err != nil {
+				errCh <- fromStatus(p.id, err)
+				return
+			}
+			if final := chunk.GetFinal(); final != nil {
+				finalCh <- fromProtoResponse(final)
+				return
+			}
+			select {
+			case ch <- core.ChatChunk{Delta: chunk.GetDelta(), Parts: fromProtoOutputParts(chunk.GetParts())}:
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	return &core.ChatStream{Ch: ch, Err: errCh, Final: finalCh}, nil
+}
+
+// Compile-time check that Provider implements core.Provider.
+var _ core.Provider = (*Provider)(nil)
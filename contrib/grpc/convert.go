@@ -0,0 +1,319 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/petal-labs/iris/contrib/grpc/irispb"
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/tools"
+)
+
+// schemaProvider is implemented by tools.Tool.
+type schemaProvider interface {
+	Schema() tools.ToolSchema
+}
+
+// remoteTool presents a tool received by the server as a tools.Tool, so the
+// provider finds its schema like it would for a local tool.
+type remoteTool struct {
+	pb *irispb.Tool
+}
+
+func (t remoteTool) Name() string        { return t.pb.GetName() }
+func (t remoteTool) Description() string { return t.pb.GetDescription() }
+
+func (t remoteTool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: rawJSON(t.pb.GetParametersJson())}
+}
+
+// Call is never used: tools run on the client, not on the server.
+func (t remoteTool) Call(context.Context, json.RawMessage) (any, error) {
+	return nil, core.ErrNotSupported
+}
+
+// Compile-time check that remoteTool implements tools.Tool.
+var _ tools.Tool = remoteTool{}
+
+// rawJSON returns s as raw JSON, or nil if s is empty.
+func rawJSON(s string) json.RawMessage {
+	if s == "" {
+		return nil
+	}
+	return json.RawMessage(s)
+}
+
+func toProtoRequest(req *core.ChatRequest) (*irispb.ChatRequest, error) {
+	pb := &irispb.ChatRequest{
+		Model:              string(req.Model),
+		Temperature:        req.Temperature,
+		ParallelToolCalls:  req.ParallelToolCalls,
+		ResponseFormat:     string(req.ResponseFormat),
+		Instructions:       req.Instructions,
+		ReasoningEffort:    string(req.ReasoningEffort),
+		PreviousResponseId: req.PreviousResponseID,
+		Truncation:         req.Truncation,
+		Store:              req.Store,
+		Metadata:           req.Metadata,
+		IdempotencyKey:     req.IdempotencyKey,
+	}
+	if req.MaxTokens != nil {
+		n := int32(*req.MaxTokens)
+		pb.MaxTokens = &n
+	}
+	if req.ToolChoice != nil {
+		pb.ToolChoice = &irispb.ToolChoice{Mode: string(req.ToolChoice.Mode), Name: req.ToolChoice.Name}
+	}
+	if s := req.JSONSchema; s != nil {
+		pb.JsonSchema = &irispb.JSONSchema{Name: s.Name, Description: s.Description, SchemaJson: string(s.Schema), Strict: s.Strict}
+	}
+	for _, t := range req.Tools {
+		tool := &irispb.Tool{Name: t.Name(), Description: t.Description()}
+		if sp, ok := t.(schemaProvider); ok {
+			tool.ParametersJson = string(sp.Schema().JSONSchema)
+		}
+		pb.Tools = append(pb.Tools, tool)
+	}
+	for _, m := range req.Messages {
+		msg, err := toProtoMessage(m)
+		if err != nil {
+			return nil, err
+		}
+		pb.Messages = append(pb.Messages, msg)
+	}
+	return pb, nil
+}
+
+func fromProtoRequest(pb *irispb.ChatRequest) *core.ChatRequest {
+	req := &core.ChatRequest{
+		Model:              core.ModelID(pb.GetModel()),
+		Temperature:        pb.Temperature,
+		ParallelToolCalls:  pb.ParallelToolCalls,
+		ResponseFormat:     core.ResponseFormat(pb.GetResponseFormat()),
+		Instructions:       pb.GetInstructions(),
+		ReasoningEffort:    core.ReasoningEffort(pb.GetReasoningEffort()),
+		PreviousResponseID: pb.GetPreviousResponseId(),
+		Truncation:         pb.GetTruncation(),
+		Store:              pb.Store,
+		Metadata:           pb.GetMetadata(),
+		IdempotencyKey:     pb.GetIdempotencyKey(),
+	}
+	if pb.MaxTokens != nil {
+		n := int(pb.GetMaxTokens())
+		req.MaxTokens = &n
+	}
+	if tc := pb.GetToolChoice(); tc != nil {
+		req.ToolChoice = &core.ToolChoice{Mode: core.ToolChoiceMode(tc.GetMode()), Name: tc.GetName()}
+	}
+	if s := pb.GetJsonSchema(); s != nil {
+		req.JSONSchema = &core.JSONSchemaDefinition{Name: s.GetName(), Description: s.GetDescription(), Schema: rawJSON(s.GetSchemaJson()), Strict: s.GetStrict()}
+	}
+	for _, t := range pb.GetTools() {
+		req.Tools = append(req.Tools, remoteTool{pb: t})
+	}
+	for _, m := range pb.GetMessages() {
+		req.Messages = append(req.Messages, fromProtoMessage(m))
+	}
+	return req
+}
+
+func toProtoMessage(m core.Message) (*irispb.Message, error) {
+	pb := &irispb.Message{
+		Role:      string(m.Role),
+		Content:   m.Content,
+		ToolCalls: toProtoToolCalls(m.ToolCalls),
+	}
+	for _, part := range m.Parts {
+		p, err := toProtoContentPart(part)
+		if err != nil {
+			return nil, err
+		}
+		pb.Parts = append(pb.Parts, p)
+	}
+	for _, r := range m.ToolResults {
+		content, err := json.Marshal(r.Content)
+		if err != nil {
+			return nil, fmt.Errorf("grpc: encode result of tool call %s: %w", r.CallID, err)
+		}
+		pb.ToolResults = append(pb.ToolResults, &irispb.ToolResult{CallId: r.CallID, ContentJson: string(content), IsError: r.IsError})
+	}
+	return pb, nil
+}
+
+func fromProtoMessage(pb *irispb.Message) core.Message {
+	m := core.Message{
+		Role:      core.Role(pb.GetRole()),
+		Content:   pb.GetContent(),
+		ToolCalls: fromProtoToolCalls(pb.GetToolCalls()),
+	}
+	for _, p := range pb.GetParts() {
+		if part := fromProtoContentPart(p); part != nil {
+			m.Parts = append(m.Parts, part)
+		}
+	}
+	for _, r := range pb.GetToolResults() {
+		m.ToolResults = append(m.ToolResults, core.ToolResult{CallID: r.GetCallId(), Content: rawJSON(r.GetContentJson()), IsError: r.GetIsError()})
+	}
+	return m
+}
+
+func toProtoContentPart(part core.ContentPart) (*irispb.ContentPart, error) {
+	switch p := part.(type) {
+	case core.InputText:
+		return &irispb.ContentPart{Part: &irispb.ContentPart_Text{Text: p.Text}}, nil
+	case core.InputImage:
+		return &irispb.ContentPart{Part: &irispb.ContentPart_Image{Image: &irispb.InputImage{ImageUrl: p.ImageURL, FileId: p.FileID, Detail: string(p.Detail)}}}, nil
+	case core.InputFile:
+		return &irispb.ContentPart{Part: &irispb.ContentPart_File{File: &irispb.InputFile{FileId: p.FileID, FileUrl: p.FileURL, FileData: p.FileData, Filename: p.Filename}}}, nil
+	case core.InputAudio:
+		return &irispb.ContentPart{Part: &irispb.ContentPart_Audio{Audio: &irispb.InputAudio{Data: p.Data, Format: string(p.Format)}}}, nil
+	default:
+		return nil, fmt.Errorf("grpc: unsupported content part %q: %w", part.ContentType(), core.ErrNotSupported)
+	}
+}
+
+func fromProtoContentPart(pb *irispb.ContentPart) core.ContentPart {
+	switch p := pb.GetPart().(type) {
+	case *irispb.ContentPart_Text:
+		return core.InputText{Text: p.Text}
+	case *irispb.ContentPart_Image:
+		return core.InputImage{ImageURL: p.Image.GetImageUrl(), FileID: p.Image.GetFileId(), Detail: core.ImageDetail(p.Image.GetDetail())}
+	case *irispb.ContentPart_File:
+		return core.InputFile{FileID: p.File.GetFileId(), FileURL: p.File.GetFileUrl(), FileData: p.File.GetFileData(), Filename: p.File.GetFilename()}
+	case *irispb.ContentPart_Audio:
+		return core.InputAudio{Data: p.Audio.GetData(), Format: core.AudioFormat(p.Audio.GetFormat())}
+	default:
+		return nil
+	}
+}
+
+func toProtoToolCalls(calls []core.ToolCall) []*irispb.ToolCall {
+	var pb []*irispb.ToolCall
+	for _, c := range calls {
+		pb = append(pb, &irispb.ToolCall{Id: c.ID, Name: c.Name, ArgumentsJson: string(c.Arguments)})
+	}
+	return pb
+}
+
+func fromProtoToolCalls(pb []*irispb.ToolCall) []core.ToolCall {
+	var calls []core.ToolCall
+	for _, c := range pb {
+		calls = append(calls, core.ToolCall{ID: c.GetId(), Name: c.GetName(), Arguments: rawJSON(c.GetArgumentsJson())})
+	}
+	return calls
+}
+
+func toProtoResponse(resp *core.ChatResponse) *irispb.ChatResponse {
+	pb := &irispb.ChatResponse{
+		Id:     resp.ID,
+		Model:  string(resp.Model),
+		Output: resp.Output,
+		Usage: &irispb.TokenUsage{
+			PromptTokens:     int32(resp.Usage.PromptTokens),
+			CompletionTokens: int32(resp.Usage.CompletionTokens),
+			TotalTokens:      int32(resp.Usage.TotalTokens),
+		},
+		ToolCalls:         toProtoToolCalls(resp.ToolCalls),
+		Status:            resp.Status,
+		SystemFingerprint: resp.SystemFingerprint,
+		Parts:             toProtoOutputParts(resp.Parts),
+	}
+	if r := resp.Reasoning; r != nil {
+		pb.Reasoning = &irispb.Reasoning{Id: r.ID, Summary: r.Summary}
+	}
+	return pb
+}
+
+func fromProtoResponse(pb *irispb.ChatResponse) *core.ChatResponse {
+	resp := &core.ChatResponse{
+		ID:     pb.GetId(),
+		Model:  core.ModelID(pb.GetModel()),
+		Output: pb.GetOutput(),
+		Usage: core.TokenUsage{
+			PromptTokens:     int(pb.GetUsage().GetPromptTokens()),
+			CompletionTokens: int(pb.GetUsage().GetCompletionTokens()),
+			TotalTokens:      int(pb.GetUsage().GetTotalTokens()),
+		},
+		ToolCalls:         fromProtoToolCalls(pb.GetToolCalls()),
+		Status:            pb.GetStatus(),
+		SystemFingerprint: pb.GetSystemFingerprint(),
+		Parts:             fromProtoOutputParts(pb.GetParts()),
+	}
+	if r := pb.GetReasoning(); r != nil {
+		resp.Reasoning = &core.ReasoningOutput{ID: r.GetId(), Summary: r.GetSummary()}
+	}
+	return resp
+}
+
+func toProtoOutputParts(parts []core.OutputPart) []*irispb.OutputPart {
+	var pb []*irispb.OutputPart
+	for _, part := range parts {
+		switch p := part.(type) {
+		case core.OutputText:
+			pb = append(pb, &irispb.OutputPart{Part: &irispb.OutputPart_Text{Text: p.Text}})
+		case core.OutputImage:
+			pb = append(pb, &irispb.OutputPart{Part: &irispb.OutputPart_Image{Image: &irispb.OutputImage{MimeType: p.MimeType, Data: p.Data, Url: p.URL}}})
+		case core.OutputAudio:
+			pb = append(pb, &irispb.OutputPart{Part: &irispb.OutputPart_Audio{Audio: &irispb.OutputAudio{Id: p.ID, MimeType: p.MimeType, Data: p.Data, Transcript: p.Transcript}}})
+		}
+	}
+	return pb
+}
+
+func fromProtoOutputParts(pb []*irispb.OutputPart) []core.OutputPart {
+	var parts []core.OutputPart
+	for _, part := range pb {
+		switch p := part.GetPart().(type) {
+		case *irispb.OutputPart_Text:
+			parts = append(parts, core.OutputText{Text: p.Text})
+		case *irispb.OutputPart_Image:
+			parts = append(parts, core.OutputImage{MimeType: p.Image.GetMimeType(), Data: p.Image.GetData(), URL: p.Image.GetUrl()})
+		case *irispb.OutputPart_Audio:
+			parts = append(parts, core.OutputAudio{ID: p.Audio.GetId(), MimeType: p.Audio.GetMimeType(), Data: p.Audio.GetData(), Transcript: p.Audio.GetTranscript()})
+		}
+	}
+	return parts
+}
+
+func toProtoModels(models []core.ModelInfo) []*irispb.ModelInfo {
+	var pb []*irispb.ModelInfo
+	for _, m := range models {
+		info := &irispb.ModelInfo{
+			Id:              string(m.ID),
+			DisplayName:     m.DisplayName,
+			ApiEndpoint:     string(m.APIEndpoint),
+			ContextWindow:   int32(m.ContextWindow),
+			MaxOutputTokens: int32(m.MaxOutputTokens),
+		}
+		for _, f := range m.Capabilities {
+			info.Capabilities = append(info.Capabilities, string(f))
+		}
+		for _, mod := range m.Modalities {
+			info.Modalities = append(info.Modalities, string(mod))
+		}
+		pb = append(pb, info)
+	}
+	return pb
+}
+
+func fromProtoModels(pb []*irispb.ModelInfo) []core.ModelInfo {
+	var models []core.ModelInfo
+	for _, info := range pb {
+		m := core.ModelInfo{
+			ID:              core.ModelID(info.GetId()),
+			DisplayName:     info.GetDisplayName(),
+			APIEndpoint:     core.APIEndpoint(info.GetApiEndpoint()),
+			ContextWindow:   int(info.GetContextWindow()),
+			MaxOutputTokens: int(info.GetMaxOutputTokens()),
+		}
+		for _, f := range info.GetCapabilities() {
+			m.Capabilities = append(m.Capabilities, core.Feature(f))
+		}
+		for _, mod := range info.GetModalities() {
+			m.Modalities = append(m.Modalities, core.Modality(mod))
+		}
+		models = append(models, m)
+	}
+	return models
+}
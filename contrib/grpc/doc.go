@@ -0,0 +1,55 @@
+// Package grpc serves Iris providers over gRPC, so Iris can run as a
+// microservice used from services written in other languages.
+//
+// The service and message definitions are in irispb/iris.proto. The server
+// forwards requests to any core.Provider, and the client is a core.Provider
+// backed by a remote server, so Go services can use a remote provider like a
+// local one.
+//
+// # Server
+//
+//	import (
+//	    "google.golang.org/grpc"
+//	    irisgrpc "github.com/petal-labs/iris/contrib/grpc"
+//	    "github.com/petal-labs/iris/providers/openai"
+//	)
+//
+//	s := grpc.NewServer()
+//	irisgrpc.Register(s, openai.New(os.Getenv("OPENAI_API_KEY")))
+//	lis, _ := net.Listen("tcp", ":50051")
+//	log.Fatal(s.Serve(lis))
+//
+// # Client
+//
+//	cc, err := grpc.NewClient("iris:50051", grpc.WithTransportCredentials(creds))
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer cc.Close()
+//
+//	provider, err := irisgrpc.NewProvider(ctx, cc)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	resp, err := core.NewClient(provider).Chat("gpt-4o").User("Hello").GetResponse(ctx)
+//
+// # Errors
+//
+// Provider errors are returned as gRPC status errors with an ErrorInfo
+// detail in ErrorDomain. Its reason names the Iris sentinel error and its
+// metadata holds the provider, HTTP status, error code, and request ID. The
+// client turns them back into *core.ProviderError, so errors.Is works with
+// the core.Err* sentinels on both sides.
+//
+// # Limitations
+//
+// Only chat and streaming chat are served. Tools are described to the
+// server by name, description, and schema and run on the client. Built-in
+// tools and tool resources are not carried.
+//
+// # Security
+//
+// The server holds the provider API keys; clients never see them. Use
+// transport credentials and authentication interceptors to control who can
+// reach the server.
+package grpc
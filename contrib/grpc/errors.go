@@ -0,0 +1,140 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// ErrorDomain is the domain of the ErrorInfo detail attached to errors
+// returned by the server. Its reason names the Iris sentinel error, such as
+// "UNAUTHORIZED" or "RATE_LIMITED".
+const ErrorDomain = "iris.petal-labs.dev"
+
+// errorKinds maps sentinel errors to ErrorInfo reasons and status codes,
+// most specific first.
+var errorKinds = []struct {
+	err    error
+	reason string
+	code   codes.Code
+}{
+	{context.Canceled, "CANCELED", codes.Canceled},
+	{context.DeadlineExceeded, "DEADLINE_EXCEEDED", codes.DeadlineExceeded},
+	{core.ErrUnauthorized, "UNAUTHORIZED", codes.Unauthenticated},
+	{core.ErrRateLimited, "RATE_LIMITED", codes.ResourceExhausted},
+	{core.ErrBadRequest, "BAD_REQUEST", codes.InvalidArgument},
+	{core.ErrNotFound, "NOT_FOUND", codes.NotFound},
+	{core.ErrServer, "SERVER", codes.Unavailable},
+	{core.ErrNetwork, "NETWORK", codes.Unavailable},
+	{core.ErrDecode, "DECODE", codes.Internal},
+	{core.ErrNotSupported, "NOT_SUPPORTED", codes.Unimplemented},
+}
+
+// toStatus converts a provider error to a gRPC status error with an
+// ErrorInfo detail describing it.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Unknown
+	info := &errdetails.ErrorInfo{Domain: ErrorDomain, Metadata: map[string]string{}}
+	for _, k := range errorKinds {
+		if errors.Is(err, k.err) {
+			code, info.Reason = k.code, k.reason
+			break
+		}
+	}
+
+	msg := err.Error()
+	var pe *core.ProviderError
+	if errors.As(err, &pe) {
+		msg = pe.Message
+		info.Metadata["provider"] = pe.Provider
+		if pe.Status != 0 {
+			info.Metadata["status"] = strconv.Itoa(pe.Status)
+		}
+		if pe.Code != "" {
+			info.Metadata["code"] = pe.Code
+		}
+		if pe.RequestID != "" {
+			info.Metadata["request_id"] = pe.RequestID
+		}
+	}
+
+	st := status.New(code, msg)
+	if info.Reason == "" {
+		return st.Err()
+	}
+	if withInfo, err := st.WithDetails(info); err == nil {
+		st = withInfo
+	}
+	return st.Err()
+}
+
+// fromStatus converts a gRPC error to a core.ProviderError. The sentinel
+// error comes from the ErrorInfo detail if the server sent one, and from
+// the status code otherwise. provider is used if the server did not name
+// one.
+func fromStatus(provider string, err error) error {
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	pe := &core.ProviderError{Provider: provider, Message: st.Message()}
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.ErrorInfo)
+		if !ok || info.GetDomain() != ErrorDomain {
+			continue
+		}
+		for _, k := range errorKinds {
+			if info.GetReason() == k.reason {
+				pe.Err = k.err
+				break
+			}
+		}
+		md := info.GetMetadata()
+		if p := md["provider"]; p != "" {
+			pe.Provider = p
+		}
+		pe.Status, _ = strconv.Atoi(md["status"])
+		pe.Code = md["code"]
+		pe.RequestID = md["request_id"]
+	}
+	if pe.Err == nil {
+		pe.Err = sentinelForCode(st.Code())
+	}
+	return pe
+}
+
+// sentinelForCode maps a status code without an ErrorInfo detail, such as
+// one produced by the gRPC transport, to a sentinel error.
+func sentinelForCode(code codes.Code) error {
+	switch code {
+	case codes.Canceled:
+		return context.Canceled
+	case codes.DeadlineExceeded:
+		return context.DeadlineExceeded
+	case codes.Unauthenticated, codes.PermissionDenied:
+		return core.ErrUnauthorized
+	case codes.ResourceExhausted:
+		return core.ErrRateLimited
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return core.ErrBadRequest
+	case codes.NotFound:
+		return core.ErrNotFound
+	case codes.Unimplemented:
+		return core.ErrNotSupported
+	case codes.Unavailable:
+		return core.ErrNetwork
+	default:
+		return core.ErrServer
+	}
+}
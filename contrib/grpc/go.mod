@@ -0,0 +1,18 @@
+module github.com/petal-labs/iris/contrib/grpc
+
+go 1.24.0
+
+require (
+	github.com/petal-labs/iris v0.13.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20251202230838-ff82c1b0f217
+	google.golang.org/grpc v1.79.1
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	golang.org/x/net v0.48.0 // indirect
+	golang.org/x/sys v0.40.0 // indirect
+	golang.org/x/text v0.32.0 // indirect
+)
+
+replace github.com/petal-labs/iris => ../..
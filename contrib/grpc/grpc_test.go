@@ -0,0 +1,334 @@
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/testing/providertest"
+	"github.com/petal-labs/iris/tools"
+)
+
+// fakeProvider answers deterministically and records the last request.
+type fakeProvider struct {
+	apiKey string
+
+	last *core.ChatRequest
+}
+
+func (p *fakeProvider) ID() string { return "fake" }
+
+func (p *fakeProvider) Models() []core.ModelInfo {
+	return []core.ModelInfo{{
+		ID:            "fake-model",
+		DisplayName:   "Fake",
+		Capabilities:  []core.Feature{core.FeatureChat},
+		ContextWindow: 128000,
+		Modalities:    []core.Modality{core.ModalityText, core.ModalityImage},
+	}}
+}
+
+func (p *fakeProvider) Supports(f core.Feature) bool {
+	switch f {
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureEmbeddings:
+		return true
+	}
+	return false
+}
+
+func (p *fakeProvider) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	if err := p.check(ctx); err != nil {
+		return nil, err
+	}
+	p.last = req
+	resp := &core.ChatResponse{
+		ID:    "resp-1",
+		Model: req.Model,
+		Usage: core.TokenUsage{PromptTokens: 10, CompletionTokens: 2, TotalTokens: 12},
+	}
+	if len(req.Tools) > 0 && req.Messages[len(req.Messages)-1].Role == core.RoleUser {
+		resp.ToolCalls = []core.ToolCall{{ID: "call-1", Name: req.Tools[0].Name(), Arguments: json.RawMessage(`{"location":"Paris"}`)}}
+	} else {
+		resp.Output = "hello"
+	}
+	return resp, nil
+}
+
+func (p *fakeProvider) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	if err := p.check(ctx); err != nil {
+		return nil, err
+	}
+	ch := make(chan core.ChatChunk)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+
+	go func() {
+		defer close(ch)
+		defer close(errCh)
+		defer close(finalCh)
+
+		n := 5
+		if strings.Contains(req.Messages[0].Content, "300") {
+			n = 300
+		}
+		var out strings.Builder
+		for range n {
+			select {
+			case ch <- core.ChatChunk{Delta: "x\n"}:
+				out.WriteString("x\n")
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+		finalCh <- &core.ChatResponse{Output: out.String(), Usage: core.TokenUsage{PromptTokens: 10, CompletionTokens: n, TotalTokens: 10 + n}}
+	}()
+
+	return &core.ChatStream{Ch: ch, Err: errCh, Final: finalCh}, nil
+}
+
+func (p *fakeProvider) check(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if p.apiKey != "valid" {
+		return &core.ProviderError{Provider: p.ID(), Status: 401, Code: "invalid_api_key", RequestID: "req-1", Message: "invalid key", Err: core.ErrUnauthorized}
+	}
+	return nil
+}
+
+// dial serves fake over an in-memory listener and returns a provider
+// connected to it.
+func dial(t *testing.T, fake core.Provider) *Provider {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	Register(s, fake)
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { cc.Close() })
+
+	p, err := NewProvider(context.Background(), cc)
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+	return p
+}
+
+func TestConformance(t *testing.T) {
+	providertest.Run(t, providertest.Config{
+		NewProvider: func(t *testing.T) core.Provider {
+			return dial(t, &fakeProvider{apiKey: "valid"})
+		},
+		NewUnauthorizedProvider: func(t *testing.T) core.Provider {
+			return dial(t, &fakeProvider{apiKey: "invalid"})
+		},
+		Model:   "fake-model",
+		Timeout: 5 * time.Second,
+	})
+}
+
+func TestDescribe(t *testing.T) {
+	fake := &fakeProvider{apiKey: "valid"}
+	p := dial(t, fake)
+
+	if p.ID() != "fake" {
+		t.Errorf("ID() = %q, want fake", p.ID())
+	}
+	if !reflect.DeepEqual(p.Models(), fake.Models()) {
+		t.Errorf("Models() = %+v, want %+v", p.Models(), fake.Models())
+	}
+	if !p.Supports(core.FeatureToolCalling) {
+		t.Error("Supports(FeatureToolCalling) = false")
+	}
+	// Only chat features are served
+	if p.Supports(core.FeatureEmbeddings) {
+		t.Error("Supports(FeatureEmbeddings) = true")
+	}
+}
+
+type weatherTool struct{}
+
+func (weatherTool) Name() string        { return "get_weather" }
+func (weatherTool) Description() string { return "Get the weather." }
+func (weatherTool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: json.RawMessage(`{"type":"object","properties":{"location":{"type":"string"}}}`)}
+}
+func (weatherTool) Call(context.Context, json.RawMessage) (any, error) { return nil, nil }
+
+func TestChatRequestRoundTrip(t *testing.T) {
+	fake := &fakeProvider{apiKey: "valid"}
+	p := dial(t, fake)
+
+	temp := float32(0.5)
+	maxTokens := 100
+	store := true
+	req := &core.ChatRequest{
+		Model: "fake-model",
+		Messages: []core.Message{
+			{Role: core.RoleSystem, Content: "Be brief."},
+			{Role: core.RoleUser, Parts: []core.ContentPart{
+				core.InputText{Text: "What is this?"},
+				core.InputImage{ImageURL: "https://example.com/a.png", Detail: core.ImageDetailHigh},
+				core.InputFile{FileID: "file-1"},
+				core.InputAudio{Data: "UklGRg==", Format: core.AudioFormatWAV},
+			}},
+			{Role: core.RoleAssistant, ToolCalls: []core.ToolCall{{ID: "call-1", Name: "get_weather", Arguments: json.RawMessage(`{"location":"Paris"}`)}}},
+			{Role: core.RoleTool, ToolResults: []core.ToolResult{{CallID: "call-1", Content: map[string]int{"temp": 20}}}},
+		},
+		Temperature:        &temp,
+		MaxTokens:          &maxTokens,
+		ToolChoice:         &core.ToolChoice{Mode: core.ToolChoiceAuto},
+		ResponseFormat:     core.ResponseFormatJSONSchema,
+		JSONSchema:         &core.JSONSchemaDefinition{Name: "answer", Schema: json.RawMessage(`{"type":"object"}`), Strict: true},
+		Instructions:       "Answer in French.",
+		ReasoningEffort:    core.ReasoningEffortLow,
+		PreviousResponseID: "resp-0",
+		Store:              &store,
+		Metadata:           map[string]string{"user": "u1"},
+		IdempotencyKey:     "key-1",
+		Tools:              []core.Tool{weatherTool{}},
+	}
+	if _, err := p.Chat(context.Background(), req); err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	got := fake.last
+	if got.Model != req.Model || *got.Temperature != temp || *got.MaxTokens != maxTokens || !*got.Store {
+		t.Errorf("scalar fields = %+v", got)
+	}
+	if got.ResponseFormat != req.ResponseFormat || got.Instructions != req.Instructions ||
+		got.ReasoningEffort != req.ReasoningEffort || got.PreviousResponseID != req.PreviousResponseID ||
+		got.IdempotencyKey != req.IdempotencyKey || got.Metadata["user"] != "u1" {
+		t.Errorf("request fields = %+v", got)
+	}
+	if *got.ToolChoice != *req.ToolChoice {
+		t.Errorf("ToolChoice = %+v", got.ToolChoice)
+	}
+	if string(got.JSONSchema.Schema) != `{"type":"object"}` || !got.JSONSchema.Strict {
+		t.Errorf("JSONSchema = %+v", got.JSONSchema)
+	}
+	if !reflect.DeepEqual(got.Messages[1].Parts, req.Messages[1].Parts) {
+		t.Errorf("Parts = %+v, want %+v", got.Messages[1].Parts, req.Messages[1].Parts)
+	}
+	if !reflect.DeepEqual(got.Messages[2].ToolCalls, req.Messages[2].ToolCalls) {
+		t.Errorf("ToolCalls = %+v", got.Messages[2].ToolCalls)
+	}
+	content, _ := json.Marshal(got.Messages[3].ToolResults[0].Content)
+	if string(content) != `{"temp":20}` {
+		t.Errorf("ToolResult content = %s", content)
+	}
+
+	tool, ok := got.Tools[0].(tools.Tool)
+	if !ok {
+		t.Fatalf("server tool %T does not implement tools.Tool", got.Tools[0])
+	}
+	if tool.Name() != "get_weather" || string(tool.Schema().JSONSchema) != string(weatherTool{}.Schema().JSONSchema) {
+		t.Errorf("tool = %q %s", tool.Name(), tool.Schema().JSONSchema)
+	}
+}
+
+func TestChatResponseRoundTrip(t *testing.T) {
+	resp := &core.ChatResponse{
+		ID:                "resp-1",
+		Model:             "fake-model",
+		Output:            "hi",
+		Usage:             core.TokenUsage{PromptTokens: 1, CompletionTokens: 2, TotalTokens: 3},
+		ToolCalls:         []core.ToolCall{{ID: "c", Name: "n", Arguments: json.RawMessage(`{}`)}},
+		Reasoning:         &core.ReasoningOutput{ID: "r", Summary: []string{"thought"}},
+		Status:            "completed",
+		SystemFingerprint: "fp",
+		Parts: []core.OutputPart{
+			core.OutputText{Text: "hi"},
+			core.OutputImage{MimeType: "image/png", Data: "iVBORw=="},
+			core.OutputAudio{ID: "a", MimeType: "audio/wav", Data: "UklGRg==", Transcript: "hi"},
+		},
+	}
+	if got := fromProtoResponse(toProtoResponse(resp)); !reflect.DeepEqual(got, resp) {
+		t.Errorf("round trip = %+v, want %+v", got, resp)
+	}
+}
+
+func TestErrorDetails(t *testing.T) {
+	p := dial(t, &fakeProvider{apiKey: "invalid"})
+
+	_, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:    "fake-model",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "hi"}},
+	})
+	var pe *core.ProviderError
+	if !errors.As(err, &pe) {
+		t.Fatalf("error = %T %v, want *core.ProviderError", err, err)
+	}
+	want := core.ProviderError{Provider: "fake", Status: 401, Code: "invalid_api_key", RequestID: "req-1", Message: "invalid key", Err: core.ErrUnauthorized}
+	if *pe != want {
+		t.Errorf("ProviderError = %+v, want %+v", *pe, want)
+	}
+}
+
+func TestToStatus(t *testing.T) {
+	for _, k := range errorKinds {
+		st := status.Convert(toStatus(&core.ProviderError{Provider: "x", Message: "m", Err: k.err}))
+		if st.Code() != k.code {
+			t.Errorf("code for %v = %v, want %v", k.err, st.Code(), k.code)
+		}
+		var reason string
+		for _, d := range st.Details() {
+			if info, ok := d.(*errdetails.ErrorInfo); ok {
+				reason = info.GetReason()
+			}
+		}
+		if reason != k.reason {
+			t.Errorf("reason for %v = %q, want %q", k.err, reason, k.reason)
+		}
+		if err := fromStatus("x", st.Err()); !errors.Is(err, k.err) {
+			t.Errorf("round trip of %q = %v, want %v", k.reason, err, k.err)
+		}
+	}
+
+	if st := status.Convert(toStatus(errors.New("boom"))); st.Code() != codes.Unknown || st.Message() != "boom" {
+		t.Errorf("plain error = %v", st)
+	}
+}
+
+func TestFromStatusWithoutDetails(t *testing.T) {
+	tests := []struct {
+		code codes.Code
+		want error
+	}{
+		{codes.Unavailable, core.ErrNetwork},
+		{codes.Unauthenticated, core.ErrUnauthorized},
+		{codes.PermissionDenied, core.ErrUnauthorized},
+		{codes.ResourceExhausted, core.ErrRateLimited},
+		{codes.DeadlineExceeded, context.DeadlineExceeded},
+		{codes.Internal, core.ErrServer},
+	}
+	for _, tt := range tests {
+		err := fromStatus("remote", status.Error(tt.code, "x"))
+		if !errors.Is(err, tt.want) {
+			t.Errorf("fromStatus(%v) = %v, want %v", tt.code, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,6 @@
+// Package irispb contains the protocol buffer types and gRPC stubs generated
+// from iris.proto. Clients in other languages generate their own stubs from
+// the same file.
+package irispb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative iris.proto
@@ -0,0 +1,108 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/petal-labs/iris/contrib/grpc/irispb"
+	"github.com/petal-labs/iris/core"
+)
+
+// servedFeatures are the features the server can report. Only chat is
+// served, so other features are left out.
+var servedFeatures = []core.Feature{
+	core.FeatureChat,
+	core.FeatureChatStreaming,
+	core.FeatureToolCalling,
+	core.FeatureReasoning,
+	core.FeatureStructuredOutput,
+	core.FeatureVision,
+	core.FeatureBuiltInTools,
+	core.FeatureResponseChain,
+}
+
+// Server implements irispb.ChatServiceServer by forwarding to a provider.
+type Server struct {
+	irispb.UnimplementedChatServiceServer
+
+	provider core.Provider
+}
+
+// NewServer returns a server for provider.
+func NewServer(provider core.Provider) *Server {
+	return &Server{provider: provider}
+}
+
+// Register registers a server for provider with s.
+func Register(s grpc.ServiceRegistrar, provider core.Provider) {
+	irispb.RegisterChatServiceServer(s, NewServer(provider))
+}
+
+// Describe returns the provider ID, models, and supported chat features.
+func (s *Server) Describe(context.Context, *irispb.DescribeRequest) (*irispb.DescribeResponse, error) {
+	resp := &irispb.DescribeResponse{
+		Provider: s.provider.ID(),
+		Models:   toProtoModels(s.provider.Models()),
+	}
+	for _, f := range servedFeatures {
+		if s.provider.Supports(f) {
+			resp.Features = append(resp.Features, string(f))
+		}
+	}
+	return resp, nil
+}
+
+// Chat forwards a chat request to the provider.
+func (s *Server) Chat(ctx context.Context, req *irispb.ChatRequest) (*irispb.ChatResponse, error) {
+	resp, err := s.provider.Chat(ctx, fromProtoRequest(req))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toProtoResponse(resp), nil
+}
+
+// StreamChat forwards a streaming chat request to the provider. Each chunk
+// is sent as it arrives and the final response is sent last.
+func (s *Server) StreamChat(req *irispb.ChatRequest, out grpc.ServerStreamingServer[irispb.ChatChunk]) error {
+	stream, err := s.provider.StreamChat(out.Context(), fromProtoRequest(req))
+	if err != nil {
+		return toStatus(err)
+	}
+
+	var streamErr error
+	var final *core.ChatResponse
+	ch, errCh, finalCh := stream.Ch, stream.Err, stream.Final
+	for ch != nil || errCh != nil || (finalCh != nil && final == nil && streamErr == nil) {
+		select {
+		case chunk, ok := <-ch:
+			if !ok {
+				ch = nil
+				continue
+			}
+			if err := out.Send(&irispb.ChatChunk{Delta: chunk.Delta, Parts: toProtoOutputParts(chunk.Parts)}); err != nil {
+				return err
+			}
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+			} else if err != nil && streamErr == nil {
+				streamErr = err
+			}
+		case resp, ok := <-finalCh:
+			if !ok {
+				finalCh = nil
+			} else {
+				final = resp
+			}
+		}
+	}
+
+	if streamErr != nil {
+		return toStatus(streamErr)
+	}
+	if final == nil {
+		final = &core.ChatResponse{}
+	}
+	return out.Send(&irispb.ChatChunk{Final: toProtoResponse(final)})
+}
@@ -23,8 +23,61 @@ var (
 	// GenAIResponseFinishReason is the reason the model stopped generating.
 	// Examples: "stop", "length", "tool_calls", "content_filter"
 	GenAIResponseFinishReason = attribute.Key("gen_ai.response.finish_reason")
+
+	// GenAIOperationName is the operation a span represents: "chat" for
+	// model requests and "execute_tool" for tool calls.
+	GenAIOperationName = attribute.Key("gen_ai.operation.name")
+
+	// GenAIRequestTemperature is the requested sampling temperature.
+	GenAIRequestTemperature = attribute.Key("gen_ai.request.temperature")
+
+	// GenAIRequestMaxTokens is the requested maximum number of output tokens.
+	GenAIRequestMaxTokens = attribute.Key("gen_ai.request.max_tokens")
+
+	// GenAIResponseID is the provider's identifier for the response.
+	GenAIResponseID = attribute.Key("gen_ai.response.id")
+
+	// GenAIResponseModel is the model that generated the response.
+	GenAIResponseModel = attribute.Key("gen_ai.response.model")
+
+	// GenAISystemInstructions holds system instructions as a JSON array of
+	// parts. Only recorded with content capture.
+	GenAISystemInstructions = attribute.Key("gen_ai.system_instructions")
+
+	// GenAIInputMessages holds the request messages as JSON. Only recorded
+	// with content capture.
+	GenAIInputMessages = attribute.Key("gen_ai.input.messages")
+
+	// GenAIOutputMessages holds the response messages as JSON. Only recorded
+	// with content capture.
+	GenAIOutputMessages = attribute.Key("gen_ai.output.messages")
+
+	// GenAIToolName is the name of the executed tool.
+	GenAIToolName = attribute.Key("gen_ai.tool.name")
+
+	// GenAIToolCallID is the model's identifier for the tool call.
+	GenAIToolCallID = attribute.Key("gen_ai.tool.call.id")
+
+	// GenAIToolCallArguments holds the tool arguments as JSON. Only recorded
+	// with content capture.
+	GenAIToolCallArguments = attribute.Key("gen_ai.tool.call.arguments")
+
+	// GenAIToolCallResult holds the tool result as JSON. Only recorded with
+	// content capture.
+	GenAIToolCallResult = attribute.Key("gen_ai.tool.call.result")
 )
 
+// LangSmith attribute keys, used with ContentFormatLangSmith.
+// See: https://docs.smith.langchain.com/observability/how_to_guides/trace_with_opentelemetry
+var (
+	// LangSmithSpanKind is the LangSmith run type: "llm" or "tool".
+	LangSmithSpanKind = attribute.Key("langsmith.span.kind")
+)
+
+// LangSmithMetadataPrefix prefixes request metadata keys, which LangSmith
+// shows as run metadata.
+const LangSmithMetadataPrefix = "langsmith.metadata."
+
 // Iris-specific attribute keys for additional telemetry.
 var (
 	// IrisStreamMode indicates whether the request was streaming.
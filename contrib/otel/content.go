@@ -0,0 +1,275 @@
+package otel
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// Compile-time check that Hook can serve as a capture sink.
+var _ core.CaptureSink = (*Hook)(nil)
+
+// ContentFormat selects whether and how prompts, completions, and tool
+// arguments are recorded on spans.
+type ContentFormat int
+
+const (
+	// ContentFormatNone records no content. This is the default.
+	ContentFormatNone ContentFormat = iota
+
+	// ContentFormatGenAI records content as JSON in gen_ai.input.messages,
+	// gen_ai.output.messages, and gen_ai.system_instructions, following the
+	// OpenTelemetry GenAI semantic conventions.
+	ContentFormatGenAI
+
+	// ContentFormatLangSmith records content as indexed gen_ai.prompt.N and
+	// gen_ai.completion.N attributes and sets langsmith.span.kind, the
+	// layout LangSmith and OpenLLMetry-based tools display.
+	ContentFormatLangSmith
+)
+
+// WithContentFormat enables content recording in the given format. Content
+// reaches the hook only when it is also installed with core.WithCapture.
+func WithContentFormat(f ContentFormat) Option {
+	return func(c *Config) {
+		c.ContentFormat = f
+	}
+}
+
+// WriteCapture implements core.CaptureSink. It adds request parameters,
+// response metadata, and, if a ContentFormat is set, the exchanged content
+// to the request span in ctx. Install the hook as both telemetry hook and
+// capture sink:
+//
+//	hook := irisotel.New(irisotel.WithContentFormat(irisotel.ContentFormatGenAI))
+//	client := core.NewClient(provider, core.WithTelemetry(hook), core.WithCapture(hook))
+func (h *Hook) WriteCapture(ctx context.Context, rec *core.CaptureRecord) error {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	if req := rec.Request; req != nil {
+		if req.Temperature != nil {
+			attrs = append(attrs, GenAIRequestTemperature.Float64(float64(*req.Temperature)))
+		}
+		if req.MaxTokens != nil {
+			attrs = append(attrs, GenAIRequestMaxTokens.Int(*req.MaxTokens))
+		}
+	}
+	if resp := rec.Response; resp != nil {
+		if resp.ID != "" {
+			attrs = append(attrs, GenAIResponseID.String(resp.ID))
+		}
+		if resp.Model != "" {
+			attrs = append(attrs, GenAIResponseModel.String(string(resp.Model)))
+		}
+	}
+
+	switch h.config.ContentFormat {
+	case ContentFormatGenAI:
+		attrs = append(attrs, genAIContent(rec)...)
+	case ContentFormatLangSmith:
+		attrs = append(attrs, langSmithContent(rec)...)
+	}
+
+	span.SetAttributes(attrs...)
+	return nil
+}
+
+// genAIMessage is a message in the GenAI semantic conventions format.
+type genAIMessage struct {
+	Role         string      `json:"role"`
+	Parts        []genAIPart `json:"parts"`
+	FinishReason string      `json:"finish_reason,omitempty"`
+}
+
+// genAIPart is a message part in the GenAI semantic conventions format.
+type genAIPart struct {
+	Type      string          `json:"type"`
+	Content   string          `json:"content,omitempty"`
+	Modality  string          `json:"modality,omitempty"`
+	URI       string          `json:"uri,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+	Response  any             `json:"response,omitempty"`
+}
+
+func genAIContent(rec *core.CaptureRecord) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	if req := rec.Request; req != nil {
+		if req.Instructions != "" {
+			attrs = append(attrs, GenAISystemInstructions.String(marshal([]genAIPart{{Type: "text", Content: req.Instructions}})))
+		}
+		msgs := make([]genAIMessage, 0, len(req.Messages))
+		for _, m := range req.Messages {
+			msgs = append(msgs, genAIInputMessage(m))
+		}
+		attrs = append(attrs, GenAIInputMessages.String(marshal(msgs)))
+	}
+	if resp := rec.Response; resp != nil {
+		msg := genAIMessage{Role: string(core.RoleAssistant), Parts: []genAIPart{}, FinishReason: finishReason(resp)}
+		if resp.Output != "" {
+			msg.Parts = append(msg.Parts, genAIPart{Type: "text", Content: resp.Output})
+		}
+		msg.Parts = append(msg.Parts, genAIToolCalls(resp.ToolCalls)...)
+		attrs = append(attrs, GenAIOutputMessages.String(marshal([]genAIMessage{msg})))
+	}
+	return attrs
+}
+
+func genAIInputMessage(m core.Message) genAIMessage {
+	msg := genAIMessage{Role: string(m.Role), Parts: []genAIPart{}}
+	if m.Content != "" {
+		msg.Parts = append(msg.Parts, genAIPart{Type: "text", Content: m.Content})
+	}
+	for _, p := range m.Parts {
+		switch p := p.(type) {
+		case core.InputText:
+			msg.Parts = append(msg.Parts, genAIPart{Type: "text", Content: p.Text})
+		case *core.InputText:
+			msg.Parts = append(msg.Parts, genAIPart{Type: "text", Content: p.Text})
+		case core.InputImage:
+			msg.Parts = append(msg.Parts, imagePart(p))
+		case *core.InputImage:
+			msg.Parts = append(msg.Parts, imagePart(*p))
+		default:
+			// Other media is recorded by type only
+			msg.Parts = append(msg.Parts, genAIPart{Type: p.ContentType()})
+		}
+	}
+	msg.Parts = append(msg.Parts, genAIToolCalls(m.ToolCalls)...)
+	for _, r := range m.ToolResults {
+		msg.Parts = append(msg.Parts, genAIPart{Type: "tool_call_response", ID: r.CallID, Response: r.Content})
+	}
+	return msg
+}
+
+// imagePart records image URLs, but not inline image data.
+func imagePart(img core.InputImage) genAIPart {
+	if img.ImageURL == "" || strings.HasPrefix(img.ImageURL, "data:") {
+		return genAIPart{Type: img.ContentType(), Modality: "image"}
+	}
+	return genAIPart{Type: "uri", Modality: "image", URI: img.ImageURL}
+}
+
+func genAIToolCalls(calls []core.ToolCall) []genAIPart {
+	var parts []genAIPart
+	for _, c := range calls {
+		parts = append(parts, genAIPart{Type: "tool_call", ID: c.ID, Name: c.Name, Arguments: validJSON(c.Arguments)})
+	}
+	return parts
+}
+
+func langSmithContent(rec *core.CaptureRecord) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{LangSmithSpanKind.String("llm")}
+
+	if req := rec.Request; req != nil {
+		for k, v := range req.Metadata {
+			attrs = append(attrs, attribute.String(LangSmithMetadataPrefix+k, v))
+		}
+
+		n := 0
+		prompt := func(role, content string) string {
+			prefix := "gen_ai.prompt." + strconv.Itoa(n) + "."
+			n++
+			attrs = append(attrs, attribute.String(prefix+"role", role))
+			if content != "" {
+				attrs = append(attrs, attribute.String(prefix+"content", content))
+			}
+			return prefix
+		}
+		if req.Instructions != "" {
+			prompt(string(core.RoleSystem), req.Instructions)
+		}
+		for _, m := range req.Messages {
+			if len(m.ToolResults) > 0 {
+				// One prompt per result, as the tools expect
+				for _, r := range m.ToolResults {
+					prefix := prompt(string(m.Role), marshal(r.Content))
+					attrs = append(attrs, attribute.String(prefix+"tool_call_id", r.CallID))
+				}
+				continue
+			}
+			prefix := prompt(string(m.Role), messageText(m))
+			attrs = append(attrs, langSmithToolCalls(prefix, m.ToolCalls)...)
+		}
+	}
+
+	if resp := rec.Response; resp != nil {
+		const prefix = "gen_ai.completion.0."
+		attrs = append(attrs,
+			attribute.String(prefix+"role", string(core.RoleAssistant)),
+			attribute.String(prefix+"finish_reason", finishReason(resp)),
+		)
+		if resp.Output != "" {
+			attrs = append(attrs, attribute.String(prefix+"content", resp.Output))
+		}
+		attrs = append(attrs, langSmithToolCalls(prefix, resp.ToolCalls)...)
+	}
+	return attrs
+}
+
+func langSmithToolCalls(prefix string, calls []core.ToolCall) []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	for i, c := range calls {
+		p := prefix + "tool_calls." + strconv.Itoa(i) + "."
+		attrs = append(attrs,
+			attribute.String(p+"id", c.ID),
+			attribute.String(p+"name", c.Name),
+			attribute.String(p+"arguments", string(c.Arguments)),
+		)
+	}
+	return attrs
+}
+
+// messageText returns the text of a message, joining its text parts.
+func messageText(m core.Message) string {
+	texts := []string{}
+	if m.Content != "" {
+		texts = append(texts, m.Content)
+	}
+	for _, p := range m.Parts {
+		switch p := p.(type) {
+		case core.InputText:
+			texts = append(texts, p.Text)
+		case *core.InputText:
+			texts = append(texts, p.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func finishReason(resp *core.ChatResponse) string {
+	if len(resp.ToolCalls) > 0 {
+		return "tool_call"
+	}
+	return "stop"
+}
+
+// validJSON returns raw if it is valid JSON and raw as a JSON string
+// otherwise, so marshaling never fails.
+func validJSON(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 || json.Valid(raw) {
+		return raw
+	}
+	quoted, _ := json.Marshal(string(raw))
+	return quoted
+}
+
+// marshal returns v as JSON, or an empty string if it cannot be encoded.
+func marshal(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
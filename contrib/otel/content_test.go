@@ -0,0 +1,198 @@
+package otel
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/petal-labs/iris/core"
+	iristesting "github.com/petal-labs/iris/testing"
+	"github.com/petal-labs/iris/tools"
+)
+
+func newContentClient(t *testing.T, format ContentFormat) (*core.Client, *tracetest.SpanRecorder) {
+	t.Helper()
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	hook := New(WithTracerProvider(tp), WithContentFormat(format))
+
+	provider := iristesting.NewMockProvider(core.ChatResponse{
+		ID:        "resp-1",
+		Model:     "gpt-4o-2024-08-06",
+		Output:    "It is sunny.",
+		ToolCalls: []core.ToolCall{{ID: "call-2", Name: "get_time", Arguments: json.RawMessage(`{}`)}},
+	}).WithID("openai")
+	return core.NewClient(provider, core.WithTelemetry(hook), core.WithCapture(hook)), sr
+}
+
+func sendContentRequest(t *testing.T, client *core.Client) {
+	t.Helper()
+	_, err := client.Chat("gpt-4o").
+		Instructions("Be brief.").
+		User("Weather in Paris? key sk-abcdefghijklmnopqrstuvwxyz").
+		Temperature(0.2).
+		MaxTokens(50).
+		Metadata(map[string]string{"user": "u1"}).
+		GetResponse(context.Background())
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+}
+
+func attributeMap(attrs []attribute.KeyValue) map[string]attribute.Value {
+	m := make(map[string]attribute.Value, len(attrs))
+	for _, a := range attrs {
+		m[string(a.Key)] = a.Value
+	}
+	return m
+}
+
+func TestWriteCaptureGenAIFormat(t *testing.T) {
+	client, sr := newContentClient(t, ContentFormatGenAI)
+	sendContentRequest(t, client)
+
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	attrs := spans[0].Attributes()
+	assertAttribute(t, attrs, "gen_ai.operation.name", "chat")
+	assertAttribute(t, attrs, "gen_ai.response.id", "resp-1")
+	assertAttribute(t, attrs, "gen_ai.response.model", "gpt-4o-2024-08-06")
+	assertAttributeInt(t, attrs, "gen_ai.request.max_tokens", 50)
+	assertAttribute(t, attrs, "gen_ai.system_instructions", `[{"type":"text","content":"Be brief."}]`)
+	assertAttribute(t, attrs, "gen_ai.input.messages",
+		`[{"role":"user","parts":[{"type":"text","content":"Weather in Paris? key [REDACTED]"}]}]`)
+	assertAttribute(t, attrs, "gen_ai.output.messages",
+		`[{"role":"assistant","parts":[{"type":"text","content":"It is sunny."},{"type":"tool_call","id":"call-2","name":"get_time","arguments":{}}],"finish_reason":"tool_call"}]`)
+
+	m := attributeMap(attrs)
+	if _, ok := m["langsmith.span.kind"]; ok {
+		t.Error("GenAI format should not set langsmith.span.kind")
+	}
+}
+
+func TestWriteCaptureLangSmithFormat(t *testing.T) {
+	client, sr := newContentClient(t, ContentFormatLangSmith)
+	sendContentRequest(t, client)
+
+	attrs := sr.Ended()[0].Attributes()
+	assertAttribute(t, attrs, "langsmith.span.kind", "llm")
+	assertAttribute(t, attrs, "langsmith.metadata.user", "u1")
+	assertAttribute(t, attrs, "gen_ai.prompt.0.role", "system")
+	assertAttribute(t, attrs, "gen_ai.prompt.0.content", "Be brief.")
+	assertAttribute(t, attrs, "gen_ai.prompt.1.role", "user")
+	assertAttribute(t, attrs, "gen_ai.prompt.1.content", "Weather in Paris? key [REDACTED]")
+	assertAttribute(t, attrs, "gen_ai.completion.0.role", "assistant")
+	assertAttribute(t, attrs, "gen_ai.completion.0.content", "It is sunny.")
+	assertAttribute(t, attrs, "gen_ai.completion.0.finish_reason", "tool_call")
+	assertAttribute(t, attrs, "gen_ai.completion.0.tool_calls.0.name", "get_time")
+	assertAttribute(t, attrs, "gen_ai.completion.0.tool_calls.0.arguments", "{}")
+}
+
+func TestWriteCaptureWithoutContentFormat(t *testing.T) {
+	client, sr := newContentClient(t, ContentFormatNone)
+	sendContentRequest(t, client)
+
+	m := attributeMap(sr.Ended()[0].Attributes())
+	for _, key := range []string{"gen_ai.input.messages", "gen_ai.output.messages", "gen_ai.system_instructions", "gen_ai.prompt.0.content"} {
+		if _, ok := m[key]; ok {
+			t.Errorf("attribute %q recorded without a content format", key)
+		}
+	}
+	if m["gen_ai.response.id"].AsString() != "resp-1" {
+		t.Error("response metadata should be recorded without a content format")
+	}
+}
+
+func TestGenAIInputMessageToolExchange(t *testing.T) {
+	rec := &core.CaptureRecord{Request: &core.ChatRequest{Messages: []core.Message{
+		{Role: core.RoleUser, Parts: []core.ContentPart{
+			&core.InputText{Text: "What is this?"},
+			core.InputImage{ImageURL: "https://example.com/a.png"},
+			core.InputImage{ImageURL: "data:image/png;base64,iVBORw=="},
+		}},
+		{Role: core.RoleAssistant, ToolCalls: []core.ToolCall{{ID: "call-1", Name: "lookup", Arguments: json.RawMessage(`{"q":"a"}`)}}},
+		{Role: core.RoleTool, ToolResults: []core.ToolResult{{CallID: "call-1", Content: map[string]string{"answer": "b"}}}},
+	}}}
+
+	attrs := genAIContent(rec)
+	assertAttribute(t, attrs, "gen_ai.input.messages", `[`+
+		`{"role":"user","parts":[{"type":"text","content":"What is this?"},{"type":"uri","modality":"image","uri":"https://example.com/a.png"},{"type":"input_image","modality":"image"}]},`+
+		`{"role":"assistant","parts":[{"type":"tool_call","id":"call-1","name":"lookup","arguments":{"q":"a"}}]},`+
+		`{"role":"tool","parts":[{"type":"tool_call_response","id":"call-1","response":{"answer":"b"}}]}]`)
+}
+
+func TestToolMiddleware(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	hook := New(WithTracerProvider(tp), WithContentFormat(ContentFormatLangSmith))
+
+	parentCtx, parent := tp.Tracer("test").Start(context.Background(), "chat")
+	ctx := tools.ContextWithToolContext(parentCtx, &tools.ToolContext{ToolName: "lookup", CallID: "call-1"})
+
+	call := hook.ToolMiddleware()(func(context.Context, json.RawMessage) (any, error) {
+		return map[string]string{"answer": "b"}, nil
+	})
+	if _, err := call(ctx, json.RawMessage(`{"q":"a"}`)); err != nil {
+		t.Fatalf("call error = %v", err)
+	}
+
+	failing := hook.ToolMiddleware()(func(context.Context, json.RawMessage) (any, error) {
+		return nil, errors.New("boom")
+	})
+	if _, err := failing(ctx, json.RawMessage(`{}`)); err == nil {
+		t.Fatal("expected error")
+	}
+	parent.End()
+
+	spans := sr.Ended()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "execute_tool lookup" {
+		t.Errorf("span name = %q", span.Name())
+	}
+	if span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("tool span should be a child of the span in the call context")
+	}
+	attrs := span.Attributes()
+	assertAttribute(t, attrs, "gen_ai.operation.name", "execute_tool")
+	assertAttribute(t, attrs, "gen_ai.tool.name", "lookup")
+	assertAttribute(t, attrs, "gen_ai.tool.call.id", "call-1")
+	assertAttribute(t, attrs, "gen_ai.tool.call.arguments", `{"q":"a"}`)
+	assertAttribute(t, attrs, "gen_ai.tool.call.result", `{"answer":"b"}`)
+	assertAttribute(t, attrs, "langsmith.span.kind", "tool")
+
+	if spans[1].Status().Code != codes.Error {
+		t.Errorf("failing tool span status = %v, want error", spans[1].Status().Code)
+	}
+}
+
+func TestToolMiddlewareWithoutContentFormat(t *testing.T) {
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	hook := New(WithTracerProvider(tp))
+
+	call := hook.ToolMiddleware()(func(context.Context, json.RawMessage) (any, error) {
+		return "secret result", nil
+	})
+	if _, err := call(context.Background(), json.RawMessage(`{"q":"secret"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	m := attributeMap(sr.Ended()[0].Attributes())
+	if _, ok := m["gen_ai.tool.call.arguments"]; ok {
+		t.Error("arguments recorded without a content format")
+	}
+	if _, ok := m["gen_ai.tool.call.result"]; ok {
+		t.Error("result recorded without a content format")
+	}
+}
@@ -32,11 +32,38 @@
 // Each span includes attributes following the OpenTelemetry Semantic Conventions
 // for GenAI:
 //
+//   - gen_ai.operation.name: "chat"
 //   - gen_ai.system: Provider name (e.g., "openai", "anthropic")
 //   - gen_ai.request.model: Model identifier
 //   - gen_ai.usage.input_tokens: Number of input tokens
 //   - gen_ai.usage.output_tokens: Number of output tokens
 //
+// # Prompts, Completions, and Tool Calls
+//
+// Observability tools such as LangSmith show the exchanged messages next to
+// each span. Hook receives them when it is also installed as the client's
+// capture sink, and records them in the format chosen with
+// WithContentFormat:
+//
+//	hook := irisotel.New(irisotel.WithContentFormat(irisotel.ContentFormatLangSmith))
+//	client := core.NewClient(provider, core.WithTelemetry(hook), core.WithCapture(hook))
+//
+// ContentFormatGenAI records gen_ai.input.messages, gen_ai.output.messages,
+// and gen_ai.system_instructions as JSON, following the OpenTelemetry GenAI
+// semantic conventions. ContentFormatLangSmith records indexed
+// gen_ai.prompt.N.* and gen_ai.completion.N.* attributes, request metadata
+// as langsmith.metadata.*, and langsmith.span.kind. Either way the capture
+// also adds gen_ai.request.temperature, gen_ai.request.max_tokens,
+// gen_ai.response.id, and gen_ai.response.model.
+//
+// ToolMiddleware records tool executions as "execute_tool {name}" spans,
+// children of the span in the tool call's context:
+//
+//	registry := tools.NewRegistry(tools.WithRegistryMiddleware(hook.ToolMiddleware()))
+//
+// Export the spans to LangSmith with an OTLP exporter pointed at its
+// OpenTelemetry endpoint.
+//
 // # Custom Configuration
 //
 // The hook can be configured with options:
@@ -51,10 +78,16 @@
 //
 // # Security
 //
-// Following Iris's security design, spans never include sensitive data such as:
-//   - API keys or credentials
-//   - Prompt content (user messages)
-//   - Response content (model outputs)
-//
-// Only operational metadata (provider, model, timing, token counts) is captured.
+// Following Iris's security design, spans never include API keys or
+// credentials, and by default they include no prompt or response content:
+// only operational metadata (provider, model, timing, token counts) is
+// captured.
+//
+// WithContentFormat opts in to recording prompts, model output, and tool
+// arguments and results. Messages pass through the client's capture
+// redaction first, so credentials matching core.DefaultRedactPatterns are
+// removed, and core.CaptureRedactContent keeps only the shape of the
+// exchange. Tool arguments and results recorded by ToolMiddleware are not
+// redacted. Treat trace backends receiving content as holding sensitive
+// data.
 package otel
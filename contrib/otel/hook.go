@@ -44,6 +44,10 @@ type Config struct {
 
 	// AdditionalAttributes adds custom attributes to every span.
 	AdditionalAttributes []attribute.KeyValue
+
+	// ContentFormat selects whether and how content is recorded.
+	// Defaults to ContentFormatNone.
+	ContentFormat ContentFormat
 }
 
 // Option configures a Hook.
@@ -126,6 +130,7 @@ func (h *Hook) OnRequestStartWithContext(ctx context.Context, e core.RequestStar
 	spanName := h.spanName(e)
 
 	attrs := []attribute.KeyValue{
+		GenAIOperationName.String("chat"),
 		GenAISystem.String(e.Provider),
 		GenAIRequestModel.String(string(e.Model)),
 	}
@@ -0,0 +1,64 @@
+package otel
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+// ToolMiddleware returns tool middleware that records each tool call as an
+// "execute_tool {name}" span, a child of the span in the call's context.
+// Arguments and results are recorded only if a ContentFormat is set.
+//
+//	registry := tools.NewRegistry(tools.WithRegistryMiddleware(hook.ToolMiddleware()))
+func (h *Hook) ToolMiddleware() tools.Middleware {
+	return func(next tools.ToolCallFunc) tools.ToolCallFunc {
+		return func(ctx context.Context, args json.RawMessage) (any, error) {
+			name, callID := "unknown", ""
+			if tc := tools.ToolContextFromContext(ctx); tc != nil {
+				name, callID = tc.ToolName, tc.CallID
+			}
+
+			attrs := []attribute.KeyValue{
+				GenAIOperationName.String("execute_tool"),
+				GenAIToolName.String(name),
+			}
+			if callID != "" {
+				attrs = append(attrs, GenAIToolCallID.String(callID))
+			}
+			if h.config.ContentFormat == ContentFormatLangSmith {
+				attrs = append(attrs, LangSmithSpanKind.String("tool"))
+			}
+			if h.config.ContentFormat != ContentFormatNone {
+				attrs = append(attrs, GenAIToolCallArguments.String(string(args)))
+			}
+			attrs = append(attrs, h.config.AdditionalAttributes...)
+
+			ctx, span := h.tracer.Start(ctx, "execute_tool "+name,
+				trace.WithSpanKind(trace.SpanKindInternal),
+				trace.WithAttributes(attrs...),
+			)
+			defer span.End()
+
+			result, err := next(ctx, args)
+			if err != nil {
+				if h.config.RecordError {
+					span.RecordError(err)
+				}
+				span.SetStatus(codes.Error, err.Error())
+				return result, err
+			}
+
+			if h.config.ContentFormat != ContentFormatNone {
+				span.SetAttributes(GenAIToolCallResult.String(marshal(result)))
+			}
+			span.SetStatus(codes.Ok, "")
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Package prometheus exports Iris request metrics to Prometheus.
+//
+// This package implements core.TelemetryHook (with core.RepairTelemetryHook
+// and core.StreamTelemetryHook) by updating counters and histograms
+// registered on a prometheus.Registerer.
+//
+// # Usage
+//
+//	import (
+//	    "github.com/prometheus/client_golang/prometheus"
+//	    "github.com/petal-labs/iris/core"
+//	    irisprom "github.com/petal-labs/iris/contrib/prometheus"
+//	)
+//
+//	hook, err := irisprom.New(prometheus.DefaultRegisterer)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	client := core.NewClient(provider, core.WithTelemetry(hook))
+//
+// # Metrics
+//
+// All metrics carry provider and model labels and use the "iris" namespace
+// unless WithNamespace is given:
+//
+//   - iris_requests_total{status}: completed requests, status "ok" or "error"
+//   - iris_request_errors_total{class}: failed requests by error class
+//     (rate_limited, unauthorized, bad_request, not_found, server, network,
+//     decode, timeout, canceled, stalled, other)
+//   - iris_request_duration_seconds: request latency histogram
+//   - iris_tokens_total{type}: token usage, type "input" or "output"
+//   - iris_retries_total: transport retries
+//   - iris_repair_attempts_total: structured output repair attempts
+//   - iris_stream_time_to_first_token_seconds: time-to-first-token histogram
+//     of streaming requests
+//   - iris_stream_chunks_total: chunks received from streaming requests
+//
+// To combine metrics with other telemetry, such as OpenTelemetry tracing, wrap
+// both hooks in a hook that forwards each event to them.
+//
+// # Security
+//
+// Following Iris's security design, metrics never include prompts,
+// responses, or credentials. Label values are limited to provider and model
+// identifiers and fixed enumerations.
+package prometheus
@@ -0,0 +1,206 @@
+package prometheus
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// Compile-time interface checks.
+var (
+	_ core.TelemetryHook       = (*Hook)(nil)
+	_ core.RepairTelemetryHook = (*Hook)(nil)
+	_ core.StreamTelemetryHook = (*Hook)(nil)
+)
+
+// Hook implements core.TelemetryHook by recording Prometheus metrics.
+// Hook is safe for concurrent use.
+type Hook struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	tokens   *prometheus.CounterVec
+	retries  *prometheus.CounterVec
+	repairs  *prometheus.CounterVec
+	ttft     *prometheus.HistogramVec
+	chunks   *prometheus.CounterVec
+}
+
+// Config configures the Hook metrics.
+type Config struct {
+	// Namespace prefixes every metric name. Defaults to "iris".
+	Namespace string
+
+	// DurationBuckets are the request duration histogram buckets in seconds.
+	// Defaults to exponential buckets from 100ms to about 100s.
+	DurationBuckets []float64
+
+	// TTFTBuckets are the stream time-to-first-token histogram buckets in
+	// seconds. Defaults to exponential buckets from 50ms to about 25s.
+	TTFTBuckets []float64
+
+	// ConstLabels are added to every metric.
+	ConstLabels prometheus.Labels
+}
+
+// Option configures a Hook.
+type Option func(*Config)
+
+// WithNamespace sets the metric namespace.
+func WithNamespace(ns string) Option {
+	return func(c *Config) {
+		c.Namespace = ns
+	}
+}
+
+// WithDurationBuckets sets the request duration histogram buckets.
+func WithDurationBuckets(buckets []float64) Option {
+	return func(c *Config) {
+		c.DurationBuckets = buckets
+	}
+}
+
+// WithTTFTBuckets sets the stream time-to-first-token histogram buckets.
+func WithTTFTBuckets(buckets []float64) Option {
+	return func(c *Config) {
+		c.TTFTBuckets = buckets
+	}
+}
+
+// WithConstLabels adds constant labels to every metric.
+func WithConstLabels(labels prometheus.Labels) Option {
+	return func(c *Config) {
+		c.ConstLabels = labels
+	}
+}
+
+// New creates a Hook and registers its metrics on reg. A nil reg uses
+// prometheus.DefaultRegisterer. It returns an error if a metric with the same
+// name is already registered.
+func New(reg prometheus.Registerer, opts ...Option) (*Hook, error) {
+	cfg := Config{
+		Namespace:       "iris",
+		DurationBuckets: prometheus.ExponentialBuckets(0.1, 2, 11),
+		TTFTBuckets:     prometheus.ExponentialBuckets(0.05, 2, 10),
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if reg == nil {
+		reg = prometheus.DefaultRegisterer
+	}
+
+	counter := func(name, help string, labels ...string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace:   cfg.Namespace,
+			Name:        name,
+			Help:        help,
+			ConstLabels: cfg.ConstLabels,
+		}, append([]string{"provider", "model"}, labels...))
+	}
+	histogram := func(name, help string, buckets []float64) *prometheus.HistogramVec {
+		return prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace:   cfg.Namespace,
+			Name:        name,
+			Help:        help,
+			Buckets:     buckets,
+			ConstLabels: cfg.ConstLabels,
+		}, []string{"provider", "model"})
+	}
+
+	h := &Hook{
+		requests: counter("requests_total", "Completed LLM requests by status.", "status"),
+		errors:   counter("request_errors_total", "Failed LLM requests by error class.", "class"),
+		duration: histogram("request_duration_seconds", "LLM request latency in seconds.", cfg.DurationBuckets),
+		tokens:   counter("tokens_total", "Tokens consumed by type.", "type"),
+		retries:  counter("retries_total", "Transport retries of LLM requests."),
+		repairs:  counter("repair_attempts_total", "Structured output repair attempts."),
+		ttft:     histogram("stream_time_to_first_token_seconds", "Time to the first streamed token in seconds.", cfg.TTFTBuckets),
+		chunks:   counter("stream_chunks_total", "Chunks received from streaming requests."),
+	}
+
+	collectors := []prometheus.Collector{h.requests, h.errors, h.duration, h.tokens, h.retries, h.repairs, h.ttft, h.chunks}
+	for _, c := range collectors {
+		if err := reg.Register(c); err != nil {
+			return nil, err
+		}
+	}
+	return h, nil
+}
+
+// OnRequestStart implements core.TelemetryHook. Metrics are recorded when
+// the request ends.
+func (h *Hook) OnRequestStart(core.RequestStartEvent) {}
+
+// OnRequestEnd implements core.TelemetryHook.
+func (h *Hook) OnRequestEnd(e core.RequestEndEvent) {
+	provider, model := e.Provider, string(e.Model)
+
+	status := "ok"
+	if e.Err != nil {
+		status = "error"
+		h.errors.WithLabelValues(provider, model, errorClass(e.Err)).Inc()
+	}
+	h.requests.WithLabelValues(provider, model, status).Inc()
+	h.duration.WithLabelValues(provider, model).Observe(e.Duration().Seconds())
+
+	if e.Usage.PromptTokens > 0 {
+		h.tokens.WithLabelValues(provider, model, "input").Add(float64(e.Usage.PromptTokens))
+	}
+	if e.Usage.CompletionTokens > 0 {
+		h.tokens.WithLabelValues(provider, model, "output").Add(float64(e.Usage.CompletionTokens))
+	}
+	if e.Retries > 0 {
+		h.retries.WithLabelValues(provider, model).Add(float64(e.Retries))
+	}
+}
+
+// OnRepairAttempt implements core.RepairTelemetryHook.
+func (h *Hook) OnRepairAttempt(e core.RepairEvent) {
+	h.repairs.WithLabelValues(e.Provider, string(e.Model)).Inc()
+}
+
+// OnStreamStats implements core.StreamTelemetryHook. Streams that fail
+// before the first token are not observed in the time-to-first-token
+// histogram.
+func (h *Hook) OnStreamStats(e core.StreamStatsEvent) {
+	provider, model := e.Provider, string(e.Model)
+
+	if e.TimeToFirstToken > 0 {
+		h.ttft.WithLabelValues(provider, model).Observe(e.TimeToFirstToken.Seconds())
+	}
+	if e.ChunkCount > 0 {
+		h.chunks.WithLabelValues(provider, model).Add(float64(e.ChunkCount))
+	}
+}
+
+// errorClass maps an error to a low-cardinality label value.
+func errorClass(err error) string {
+	switch {
+	case errors.Is(err, core.ErrRateLimited):
+		return "rate_limited"
+	case errors.Is(err, core.ErrUnauthorized):
+		return "unauthorized"
+	case errors.Is(err, core.ErrBadRequest):
+		return "bad_request"
+	case errors.Is(err, core.ErrNotFound):
+		return "not_found"
+	case errors.Is(err, core.ErrServer):
+		return "server"
+	case errors.Is(err, core.ErrNetwork):
+		return "network"
+	case errors.Is(err, core.ErrDecode):
+		return "decode"
+	case errors.Is(err, core.ErrStreamStalled):
+		return "stalled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,172 @@
+package prometheus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func newTestHook(t *testing.T) *Hook {
+	t.Helper()
+	h, err := New(prometheus.NewRegistry())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return h
+}
+
+func TestOnRequestEndSuccess(t *testing.T) {
+	h := newTestHook(t)
+	start := time.Now()
+
+	h.OnRequestEnd(core.RequestEndEvent{
+		Provider: "openai",
+		Model:    "gpt-4o",
+		Start:    start,
+		End:      start.Add(1500 * time.Millisecond),
+		Usage:    core.TokenUsage{PromptTokens: 100, CompletionTokens: 40},
+		Retries:  2,
+	})
+
+	if got := testutil.ToFloat64(h.requests.WithLabelValues("openai", "gpt-4o", "ok")); got != 1 {
+		t.Errorf("requests_total{ok} = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(h.tokens.WithLabelValues("openai", "gpt-4o", "input")); got != 100 {
+		t.Errorf("tokens_total{input} = %v, want 100", got)
+	}
+	if got := testutil.ToFloat64(h.tokens.WithLabelValues("openai", "gpt-4o", "output")); got != 40 {
+		t.Errorf("tokens_total{output} = %v, want 40", got)
+	}
+	if got := testutil.ToFloat64(h.retries.WithLabelValues("openai", "gpt-4o")); got != 2 {
+		t.Errorf("retries_total = %v, want 2", got)
+	}
+	if got := testutil.CollectAndCount(h.duration); got != 1 {
+		t.Errorf("duration series = %d, want 1", got)
+	}
+}
+
+func TestOnRequestEndErrorClass(t *testing.T) {
+	h := newTestHook(t)
+
+	h.OnRequestEnd(core.RequestEndEvent{
+		Provider: "anthropic",
+		Model:    "claude",
+		Err:      &core.ProviderError{Provider: "anthropic", Status: 429, Err: core.ErrRateLimited},
+	})
+
+	if got := testutil.ToFloat64(h.requests.WithLabelValues("anthropic", "claude", "error")); got != 1 {
+		t.Errorf("requests_total{error} = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(h.errors.WithLabelValues("anthropic", "claude", "rate_limited")); got != 1 {
+		t.Errorf("request_errors_total{rate_limited} = %v, want 1", got)
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{core.ErrUnauthorized, "unauthorized"},
+		{fmt.Errorf("wrapped: %w", core.ErrServer), "server"},
+		{core.ErrStreamStalled, "stalled"},
+		{context.DeadlineExceeded, "timeout"},
+		{context.Canceled, "canceled"},
+		{fmt.Errorf("boom"), "other"},
+	}
+	for _, tt := range tests {
+		if got := errorClass(tt.err); got != tt.want {
+			t.Errorf("errorClass(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestRepairAttempts(t *testing.T) {
+	h := newTestHook(t)
+	h.OnRepairAttempt(core.RepairEvent{Provider: "openai", Model: "gpt-4o", Attempt: 1})
+
+	if got := testutil.ToFloat64(h.repairs.WithLabelValues("openai", "gpt-4o")); got != 1 {
+		t.Errorf("repair_attempts_total = %v, want 1", got)
+	}
+}
+
+func TestNewDuplicateRegistration(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if _, err := New(reg); err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	if _, err := New(reg); err == nil {
+		t.Error("second New() on the same registry should fail")
+	}
+	if _, err := New(reg, WithNamespace("other")); err != nil {
+		t.Errorf("New() with another namespace error = %v", err)
+	}
+}
+
+func TestHookWithClient(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	h, err := New(reg, WithConstLabels(prometheus.Labels{"service": "test"}))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	client := core.NewClient(&stubProvider{}, core.WithTelemetry(h))
+	if _, err := client.Chat("stub-model").User("Hi").GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+
+	families, err := reg.Gather()
+	if err != nil {
+		t.Fatalf("Gather() error = %v", err)
+	}
+	found := false
+	for _, mf := range families {
+		if mf.GetName() == "iris_requests_total" {
+			found = true
+			if labels := mf.GetMetric()[0].GetLabel(); len(labels) != 4 {
+				t.Errorf("labels = %v, want provider, model, service, status", labels)
+			}
+		}
+	}
+	if !found {
+		t.Error("iris_requests_total not gathered")
+	}
+}
+
+type stubProvider struct{}
+
+func (stubProvider) ID() string                 { return "stub" }
+func (stubProvider) Models() []core.ModelInfo   { return nil }
+func (stubProvider) Supports(core.Feature) bool { return true }
+func (stubProvider) Chat(context.Context, *core.ChatRequest) (*core.ChatResponse, error) {
+	return &core.ChatResponse{Output: "ok", Usage: core.TokenUsage{PromptTokens: 3, CompletionTokens: 1}}, nil
+}
+func (stubProvider) StreamChat(context.Context, *core.ChatRequest) (*core.ChatStream, error) {
+	return nil, core.ErrNotSupported
+}
+
+func TestOnStreamStats(t *testing.T) {
+	h := newTestHook(t)
+
+	h.OnStreamStats(core.StreamStatsEvent{
+		Provider:         "openai",
+		Model:            "gpt-4o",
+		TimeToFirstToken: 300 * time.Millisecond,
+		ChunkCount:       12,
+	})
+	// Failed before the first token: no TTFT observation
+	h.OnStreamStats(core.StreamStatsEvent{Provider: "openai", Model: "gpt-4o"})
+
+	if got := testutil.ToFloat64(h.chunks.WithLabelValues("openai", "gpt-4o")); got != 12 {
+		t.Errorf("stream_chunks_total = %v, want 12", got)
+	}
+	if got := testutil.CollectAndCount(h.ttft); got != 1 {
+		t.Errorf("ttft series = %d, want 1", got)
+	}
+}
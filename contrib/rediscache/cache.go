@@ -0,0 +1,118 @@
+package rediscache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+// DefaultPrefix is prepended to every key unless WithPrefix is given.
+const DefaultPrefix = "iris:tools:"
+
+// DefaultTimeout bounds each Redis operation unless WithTimeout is given.
+const DefaultTimeout = time.Second
+
+// Client is the subset of a Redis client used by Cache. See the package
+// documentation for an adapter for github.com/redis/go-redis/v9.
+type Client interface {
+	// Get returns the value stored at key, with ok false if there is none.
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+
+	// Set stores value at key, expiring after ttl. A zero ttl means the
+	// value does not expire.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+}
+
+// Option configures a Cache.
+type Option func(*Cache)
+
+// WithPrefix sets the prefix prepended to every key, so several
+// applications can share a Redis database.
+func WithPrefix(prefix string) Option {
+	return func(c *Cache) { c.prefix = prefix }
+}
+
+// WithTimeout sets how long each Redis operation may take.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Cache) { c.timeout = d }
+}
+
+// WithErrorHandler sets a function that receives Redis and encoding
+// errors, which the Cache otherwise treats as misses and drops.
+func WithErrorHandler(fn func(error)) Option {
+	return func(c *Cache) { c.onError = fn }
+}
+
+// Cache is a tools.Cache backed by Redis. It is safe for concurrent use if
+// the Client is.
+type Cache struct {
+	client  Client
+	prefix  string
+	timeout time.Duration
+	onError func(error)
+}
+
+// New creates a Cache that stores values through client.
+func New(client Client, opts ...Option) *Cache {
+	c := &Cache{client: client, prefix: DefaultPrefix, timeout: DefaultTimeout}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Get returns the cached value for key, decoded from JSON into the types
+// encoding/json produces for an any.
+func (c *Cache) Get(key string) (any, bool) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	data, ok, err := c.client.Get(ctx, c.prefix+key)
+	if err != nil {
+		c.report(fmt.Errorf("rediscache: get %s: %w", key, err))
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		c.report(fmt.Errorf("rediscache: decode %s: %w", key, err))
+		return nil, false
+	}
+	return value, true
+}
+
+// Set stores value as JSON under key for ttl.
+func (c *Cache) Set(key string, value any, ttl time.Duration) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		c.report(fmt.Errorf("rediscache: encode %s: %w", key, err))
+		return
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+	if err := c.client.Set(ctx, c.prefix+key, data, ttl); err != nil {
+		c.report(fmt.Errorf("rediscache: set %s: %w", key, err))
+	}
+}
+
+func (c *Cache) context() (context.Context, context.CancelFunc) {
+	if c.timeout <= 0 {
+		return context.Background(), func() {}
+	}
+	return context.WithTimeout(context.Background(), c.timeout)
+}
+
+func (c *Cache) report(err error) {
+	if c.onError != nil {
+		c.onError(err)
+	}
+}
+
+// Compile-time check that Cache implements tools.Cache.
+var _ tools.Cache = (*Cache)(nil)
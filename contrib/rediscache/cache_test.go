@@ -0,0 +1,120 @@
+package rediscache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+type fakeClient struct {
+	mu     sync.Mutex
+	values map[string][]byte
+	ttls   map[string]time.Duration
+	err    error
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{values: make(map[string][]byte), ttls: make(map[string]time.Duration)}
+}
+
+func (f *fakeClient) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return nil, false, f.err
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, false, errors.New("no deadline")
+	}
+	v, ok := f.values[key]
+	return v, ok, nil
+}
+
+func (f *fakeClient) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.values[key] = value
+	f.ttls[key] = ttl
+	return nil
+}
+
+func TestCacheRoundTrip(t *testing.T) {
+	client := newFakeClient()
+	cache := New(client, WithPrefix("test:"))
+
+	if _, ok := cache.Get("k"); ok {
+		t.Fatal("Get() hit on empty cache")
+	}
+	cache.Set("k", map[string]int{"temp": 21}, time.Minute)
+
+	if string(client.values["test:k"]) != `{"temp":21}` || client.ttls["test:k"] != time.Minute {
+		t.Errorf("stored %s with ttl %v", client.values["test:k"], client.ttls["test:k"])
+	}
+	got, ok := cache.Get("k")
+	if !ok {
+		t.Fatal("Get() missed after Set")
+	}
+	if want := map[string]any{"temp": float64(21)}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Get() = %#v, want %#v", got, want)
+	}
+}
+
+func TestCacheErrorsAreMisses(t *testing.T) {
+	client := newFakeClient()
+	client.err = errors.New("connection refused")
+	var reported []error
+	cache := New(client, WithErrorHandler(func(err error) { reported = append(reported, err) }))
+
+	cache.Set("k", "v", time.Minute)
+	if _, ok := cache.Get("k"); ok {
+		t.Error("Get() hit despite client error")
+	}
+	cache.Set("bad", make(chan int), time.Minute)
+
+	if len(reported) != 3 || !errors.Is(reported[0], client.err) {
+		t.Errorf("reported = %v", reported)
+	}
+}
+
+func TestCacheWithMiddleware(t *testing.T) {
+	cache := New(newFakeClient())
+	calls := 0
+	tool := tools.ApplyMiddleware(&countingTool{calls: &calls}, tools.WithCache(cache, time.Minute))
+
+	var results []any
+	for range 2 {
+		result, err := tool.Call(context.Background(), json.RawMessage(`{"city":"SF"}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		results = append(results, result)
+	}
+	if calls != 1 {
+		t.Errorf("tool called %d times, want 1", calls)
+	}
+	// A hit returns the same result as the miss that stored it
+	if results[0] != "sunny" || results[1] != results[0] {
+		t.Errorf("results = %#v, want both %q", results, "sunny")
+	}
+}
+
+type countingTool struct{ calls *int }
+
+func (t *countingTool) Name() string        { return "weather" }
+func (t *countingTool) Description() string { return "weather" }
+func (t *countingTool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: json.RawMessage(`{}`)}
+}
+func (t *countingTool) Call(context.Context, json.RawMessage) (any, error) {
+	*t.calls++
+	return "sunny", nil
+}
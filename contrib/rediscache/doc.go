@@ -0,0 +1,52 @@
+// Package rediscache stores tool results in Redis, so replicas of a
+// service share one cache.
+//
+// Cache implements tools.Cache for use with tools.WithCache. It talks to
+// Redis through the small Client interface rather than a particular client
+// library; adapting github.com/redis/go-redis/v9 takes a few lines.
+//
+// # Usage
+//
+//	import (
+//	    "github.com/redis/go-redis/v9"
+//	    "github.com/petal-labs/iris/contrib/rediscache"
+//	    "github.com/petal-labs/iris/tools"
+//	)
+//
+//	type goRedis struct{ *redis.Client }
+//
+//	func (c goRedis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+//	    v, err := c.Client.Get(ctx, key).Bytes()
+//	    if errors.Is(err, redis.Nil) {
+//	        return nil, false, nil
+//	    }
+//	    return v, err == nil, err
+//	}
+//
+//	func (c goRedis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+//	    return c.Client.Set(ctx, key, value, ttl).Err()
+//	}
+//
+//	rdb := redis.NewClient(&redis.Options{Addr: "localhost:6379"})
+//	cache := rediscache.New(goRedis{rdb}, rediscache.WithPrefix("myapp:tools:"))
+//	weather := tools.ApplyMiddleware(weatherTool, tools.WithCache(cache, 10*time.Minute))
+//
+// # Values
+//
+// Results are stored as JSON. A cache hit returns the stored JSON decoded
+// into an any (strings, float64s, maps and slices) rather than the original
+// Go type, so it serializes to the same tool result as a miss. Results that
+// cannot be encoded are not cached.
+//
+// # Errors
+//
+// tools.Cache has no error results, so Redis failures degrade to cache
+// misses and are reported to the function given with WithErrorHandler.
+// Each operation is bounded by the timeout set with WithTimeout.
+//
+// # Security
+//
+// Cached tool results may contain user data. Use a Redis deployment with
+// authentication and TLS, and consider tools.WithSecretScrubbing before
+// caching results that could hold credentials.
+package rediscache
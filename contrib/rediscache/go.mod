@@ -0,0 +1,7 @@
+module github.com/petal-labs/iris/contrib/rediscache
+
+go 1.24.0
+
+require github.com/petal-labs/iris v0.13.0
+
+replace github.com/petal-labs/iris => ../..
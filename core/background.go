@@ -0,0 +1,144 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Background response statuses reported in ChatResponse.Status.
+const (
+	ResponseStatusQueued     = "queued"
+	ResponseStatusInProgress = "in_progress"
+	ResponseStatusCompleted  = "completed"
+	ResponseStatusFailed     = "failed"
+	ResponseStatusCancelled  = "cancelled"
+	ResponseStatusIncomplete = "incomplete"
+)
+
+// DefaultBackgroundPollInterval is the interval between status checks of a
+// ResponseHandle.
+const DefaultBackgroundPollInterval = 2 * time.Second
+
+// BackgroundProvider is an optional interface for providers that can run a
+// chat request in the background and let the caller poll for its result, so
+// long reasoning jobs do not hold an HTTP connection open.
+//
+// Each method returns the response as currently known; its Status is one of
+// the ResponseStatus constants and Output is only complete once the status is
+// ResponseStatusCompleted or ResponseStatusIncomplete.
+type BackgroundProvider interface {
+	// StartBackground submits req and returns as soon as it is queued.
+	StartBackground(ctx context.Context, req *ChatRequest) (*ChatResponse, error)
+
+	// RetrieveBackground returns the current state of a background response.
+	RetrieveBackground(ctx context.Context, id string) (*ChatResponse, error)
+
+	// CancelBackground stops a queued or in-progress background response.
+	CancelBackground(ctx context.Context, id string) (*ChatResponse, error)
+}
+
+// ResponseHandle tracks a background response started with
+// ChatBuilder.Background. The ID can be stored and the handle recreated with
+// NewResponseHandle, for example after a process restart.
+type ResponseHandle struct {
+	// ID identifies the response at the provider.
+	ID string
+
+	// PollInterval is the interval between status checks in Wait.
+	// Zero uses DefaultBackgroundPollInterval.
+	PollInterval time.Duration
+
+	provider BackgroundProvider
+}
+
+// NewResponseHandle returns a handle for an existing background response.
+func NewResponseHandle(p BackgroundProvider, id string) *ResponseHandle {
+	return &ResponseHandle{ID: id, provider: p}
+}
+
+// Poll returns the current state of the response.
+func (h *ResponseHandle) Poll(ctx context.Context) (*ChatResponse, error) {
+	return h.provider.RetrieveBackground(ctx, h.ID)
+}
+
+// Wait polls until the response reaches a terminal status and returns it.
+// A completed or incomplete response is returned without error; a failed or
+// cancelled one is returned with an error wrapping ErrBackgroundFailed.
+// Cancelling ctx stops waiting but not the response; use Cancel for that.
+func (h *ResponseHandle) Wait(ctx context.Context) (*ChatResponse, error) {
+	interval := h.PollInterval
+	if interval <= 0 {
+		interval = DefaultBackgroundPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		resp, err := h.Poll(ctx)
+		if err != nil {
+			return resp, err
+		}
+
+		switch resp.Status {
+		case ResponseStatusCompleted, ResponseStatusIncomplete:
+			return resp, nil
+		case ResponseStatusFailed, ResponseStatusCancelled:
+			return resp, fmt.Errorf("%w: response %s %s", ErrBackgroundFailed, h.ID, resp.Status)
+		}
+
+		select {
+		case <-ctx.Done():
+			return resp, ctx.Err()
+		case <-ticker.C:
+			// Continue polling
+		}
+	}
+}
+
+// Cancel stops the response and returns its final state.
+func (h *ResponseHandle) Cancel(ctx context.Context) (*ChatResponse, error) {
+	return h.provider.CancelBackground(ctx, h.ID)
+}
+
+// Background submits the request to run in the background and returns a
+// handle to poll for its result. The provider must implement
+// BackgroundProvider; otherwise ErrNotSupported is returned. Telemetry,
+// capture, and retries apply to synchronous requests only.
+//
+// Example:
+//
+//	h, err := client.Chat("o3-pro").User(prompt).Background(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	resp, err := h.Wait(ctx)
+func (b *ChatBuilder) Background(ctx context.Context) (*ResponseHandle, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+	if err := b.checkParameters(); err != nil {
+		return nil, err
+	}
+
+	bp, ok := b.client.provider.(BackgroundProvider)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s does not support background responses", ErrNotSupported, b.client.provider.ID())
+	}
+
+	ctx, finish, err := b.client.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer finish()
+
+	resp, err := bp.StartBackground(ctx, b.client.assemblePrompt(b.requestWithIdempotencyKey()))
+	if err != nil {
+		return nil, err
+	}
+	if resp.ID == "" {
+		return nil, errors.New("background response has no ID")
+	}
+	return &ResponseHandle{ID: resp.ID, provider: bp}, nil
+}
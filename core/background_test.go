@@ -0,0 +1,133 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// backgroundProvider is a mockProvider that runs requests in the background.
+// Each RetrieveBackground returns the next status in statuses.
+type backgroundProvider struct {
+	mockProvider
+
+	mu        sync.Mutex
+	started   *ChatRequest
+	statuses  []string
+	polls     int
+	cancelled bool
+}
+
+func (p *backgroundProvider) StartBackground(_ context.Context, req *ChatRequest) (*ChatResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.started = req
+	return &ChatResponse{ID: "resp-1", Status: ResponseStatusQueued}, nil
+}
+
+func (p *backgroundProvider) RetrieveBackground(_ context.Context, id string) (*ChatResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	status := p.statuses[min(p.polls, len(p.statuses)-1)]
+	p.polls++
+	resp := &ChatResponse{ID: id, Status: status}
+	if status == ResponseStatusCompleted {
+		resp.Output = "done"
+	}
+	return resp, nil
+}
+
+func (p *backgroundProvider) CancelBackground(_ context.Context, id string) (*ChatResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cancelled = true
+	return &ChatResponse{ID: id, Status: ResponseStatusCancelled}, nil
+}
+
+func TestBackgroundWait(t *testing.T) {
+	p := &backgroundProvider{
+		mockProvider: mockProvider{id: "mock"},
+		statuses:     []string{ResponseStatusQueued, ResponseStatusInProgress, ResponseStatusCompleted},
+	}
+	client := NewClient(p)
+
+	h, err := client.Chat("mock-model").User("Hi").Background(context.Background())
+	if err != nil {
+		t.Fatalf("Background() error = %v", err)
+	}
+	if h.ID != "resp-1" {
+		t.Errorf("ID = %q, want resp-1", h.ID)
+	}
+	if p.started == nil || p.started.Messages[0].Content != "Hi" {
+		t.Errorf("started request = %+v", p.started)
+	}
+
+	h.PollInterval = time.Millisecond
+	resp, err := h.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if resp.Output != "done" {
+		t.Errorf("Output = %q, want done", resp.Output)
+	}
+	if p.polls != 3 {
+		t.Errorf("polls = %d, want 3", p.polls)
+	}
+}
+
+func TestBackgroundWaitFailed(t *testing.T) {
+	p := &backgroundProvider{statuses: []string{ResponseStatusFailed}}
+	h := NewResponseHandle(p, "resp-1")
+
+	resp, err := h.Wait(context.Background())
+	if !errors.Is(err, ErrBackgroundFailed) {
+		t.Fatalf("Wait() error = %v, want ErrBackgroundFailed", err)
+	}
+	if resp.Status != ResponseStatusFailed {
+		t.Errorf("Status = %q, want failed", resp.Status)
+	}
+}
+
+func TestBackgroundWaitContextDone(t *testing.T) {
+	p := &backgroundProvider{statuses: []string{ResponseStatusInProgress}}
+	h := NewResponseHandle(p, "resp-1")
+	h.PollInterval = time.Hour
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := h.Wait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Wait() error = %v, want DeadlineExceeded", err)
+	}
+	if p.cancelled {
+		t.Error("Wait should not cancel the response")
+	}
+}
+
+func TestBackgroundCancel(t *testing.T) {
+	p := &backgroundProvider{}
+	resp, err := NewResponseHandle(p, "resp-1").Cancel(context.Background())
+	if err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if !p.cancelled || resp.Status != ResponseStatusCancelled {
+		t.Errorf("cancelled = %v, status = %q", p.cancelled, resp.Status)
+	}
+}
+
+func TestBackgroundNotSupported(t *testing.T) {
+	client := NewClient(&mockProvider{id: "mock"})
+	_, err := client.Chat("mock-model").User("Hi").Background(context.Background())
+	if !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Background() error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestBackgroundValidates(t *testing.T) {
+	client := NewClient(&backgroundProvider{mockProvider: mockProvider{id: "mock"}})
+	_, err := client.Chat("mock-model").Background(context.Background())
+	if !errors.Is(err, ErrNoMessages) {
+		t.Errorf("Background() error = %v, want ErrNoMessages", err)
+	}
+}
@@ -0,0 +1,368 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Redacted replaces content removed from captured payloads.
+const Redacted = "[REDACTED]"
+
+// DefaultRedactPatterns match common credential formats (OpenAI, Anthropic,
+// xAI, and Google API keys, and bearer tokens). Captures always redact them,
+// in addition to any patterns added with CaptureRedactPattern.
+var DefaultRedactPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{16,}`),
+	regexp.MustCompile(`\bxai-[A-Za-z0-9]{16,}`),
+	regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`),
+	regexp.MustCompile(`(?i)\bbearer\s+[A-Za-z0-9._~+/-]+=*`),
+}
+
+// CaptureRecord is one captured request and its outcome.
+//
+// # Security
+//
+// Unlike telemetry events, a CaptureRecord contains prompts and model output
+// unless CaptureRedactContent is set. Treat capture sinks as holding
+// sensitive data.
+type CaptureRecord struct {
+	Provider string        `json:"provider"`
+	Model    ModelID       `json:"model"`
+	Start    time.Time     `json:"start"`
+	End      time.Time     `json:"end"`
+	Stream   bool          `json:"stream,omitempty"`
+	Request  *ChatRequest  `json:"request"`
+	Response *ChatResponse `json:"response,omitempty"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// CaptureSink stores capture records. Implementations must be safe for
+// concurrent use. WriteCapture is called before the telemetry hook learns
+// that the request ended, so ctx still carries any span the hook started.
+type CaptureSink interface {
+	WriteCapture(ctx context.Context, rec *CaptureRecord) error
+}
+
+// CaptureFunc adapts a function to a CaptureSink.
+type CaptureFunc func(ctx context.Context, rec *CaptureRecord) error
+
+// WriteCapture calls f.
+func (f CaptureFunc) WriteCapture(ctx context.Context, rec *CaptureRecord) error {
+	return f(ctx, rec)
+}
+
+// CaptureOption configures payload capture.
+type CaptureOption func(*capture)
+
+// CaptureRedactContent replaces message content, instructions, tool
+// arguments and results, and model output with Redacted, keeping only the
+// shape of the exchange.
+func CaptureRedactContent() CaptureOption {
+	return func(c *capture) {
+		c.redactContent = true
+	}
+}
+
+// CaptureRedactPattern replaces text matching re with Redacted, in addition
+// to DefaultRedactPatterns.
+func CaptureRedactPattern(re *regexp.Regexp) CaptureOption {
+	return func(c *capture) {
+		c.patterns = append(c.patterns, re)
+	}
+}
+
+// WithCapture records every chat request the client sends, with its response
+// or error, to sink. Each attempt of a structured output retry is captured
+// separately; streams are captured once they complete. Payloads are redacted
+// before they reach the sink: credentials matching DefaultRedactPatterns
+// always, and content as configured by opts. Sink errors are reported as
+// warnings and never fail the request.
+//
+// Capture is meant for debugging and audit trails. Unlike TelemetryHook it
+// exposes prompts and responses, so enable it deliberately.
+func WithCapture(sink CaptureSink, opts ...CaptureOption) ClientOption {
+	return func(c *Client) {
+		if sink == nil {
+			c.capture = nil
+			return
+		}
+		cp := &capture{sink: sink, patterns: slices.Clone(DefaultRedactPatterns)}
+		for _, opt := range opts {
+			opt(cp)
+		}
+		c.capture = cp
+	}
+}
+
+// capture holds the client capture configuration.
+type capture struct {
+	sink          CaptureSink
+	redactContent bool
+	patterns      []*regexp.Regexp
+}
+
+// record redacts and writes one exchange. It is safe to call on a nil
+// capture.
+func (c *Client) record(ctx context.Context, rec CaptureRecord, req *ChatRequest, resp *ChatResponse, err error) {
+	if c.capture == nil {
+		return
+	}
+	cp := c.capture
+	rec.Request = cp.redactRequest(req)
+	if resp != nil {
+		rec.Response = cp.redactResponse(resp)
+	}
+	if err != nil {
+		rec.Error = cp.redactText(err.Error())
+	}
+	if werr := cp.sink.WriteCapture(ctx, &rec); werr != nil {
+		c.warnf("capture: %v", werr)
+	}
+}
+
+// redactContentString returns s with credentials removed, or Redacted when
+// content is redacted.
+func (cp *capture) redactContentString(s string) string {
+	if s == "" {
+		return s
+	}
+	if cp.redactContent {
+		return Redacted
+	}
+	return cp.redactText(s)
+}
+
+// redactText removes credentials from s.
+func (cp *capture) redactText(s string) string {
+	for _, re := range cp.patterns {
+		s = re.ReplaceAllString(s, Redacted)
+	}
+	return s
+}
+
+// redactJSON redacts raw JSON, keeping it valid.
+func (cp *capture) redactJSON(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return raw
+	}
+	if cp.redactContent {
+		return json.RawMessage(`"` + Redacted + `"`)
+	}
+	out := raw
+	for _, re := range cp.patterns {
+		out = re.ReplaceAll(out, []byte(Redacted))
+	}
+	if !bytes.Equal(out, raw) && !json.Valid(out) {
+		return json.RawMessage(`"` + Redacted + `"`)
+	}
+	return out
+}
+
+func (cp *capture) redactToolCalls(calls []ToolCall) []ToolCall {
+	if calls == nil {
+		return nil
+	}
+	out := make([]ToolCall, len(calls))
+	for i, call := range calls {
+		call.Arguments = cp.redactJSON(call.Arguments)
+		out[i] = call
+	}
+	return out
+}
+
+func (cp *capture) redactRequest(req *ChatRequest) *ChatRequest {
+	r := *req
+	r.Instructions = cp.redactContentString(r.Instructions)
+	r.Messages = make([]Message, len(req.Messages))
+	for i, m := range req.Messages {
+		m.Content = cp.redactContentString(m.Content)
+		m.Parts = cp.redactParts(m.Parts)
+		m.ToolCalls = cp.redactToolCalls(m.ToolCalls)
+		if m.ToolResults != nil {
+			results := make([]ToolResult, len(m.ToolResults))
+			for j, tr := range m.ToolResults {
+				tr.Content = cp.redactToolResult(tr.Content)
+				results[j] = tr
+			}
+			m.ToolResults = results
+		}
+		if m.ComputerResults != nil {
+			results := make([]ComputerCallOutput, len(m.ComputerResults))
+			for j, cr := range m.ComputerResults {
+				// Screenshots are not scanned for credentials; base64 data
+				// can match the patterns by chance
+				if cp.redactContent {
+					cr.Screenshot = Redacted
+				}
+				cr.CurrentURL = cp.redactContentString(cr.CurrentURL)
+				results[j] = cr
+			}
+			m.ComputerResults = results
+		}
+		r.Messages[i] = m
+	}
+	return &r
+}
+
+func (cp *capture) redactParts(parts []ContentPart) []ContentPart {
+	if parts == nil {
+		return nil
+	}
+	out := make([]ContentPart, 0, len(parts))
+	for _, p := range parts {
+		switch p := p.(type) {
+		case InputText:
+			out = append(out, InputText{Text: cp.redactContentString(p.Text)})
+		default:
+			if cp.redactContent {
+				// Only the presence of media is kept
+				out = append(out, InputText{Text: Redacted})
+			} else {
+				out = append(out, p)
+			}
+		}
+	}
+	return out
+}
+
+func (cp *capture) redactToolResult(content any) any {
+	if s, ok := content.(string); ok {
+		return cp.redactContentString(s)
+	}
+	if content == nil {
+		return nil
+	}
+	if cp.redactContent {
+		return Redacted
+	}
+	data, err := json.Marshal(content)
+	if err != nil {
+		return Redacted
+	}
+	return cp.redactJSON(data)
+}
+
+func (cp *capture) redactResponse(resp *ChatResponse) *ChatResponse {
+	r := *resp
+	r.Output = cp.redactContentString(r.Output)
+	r.ToolCalls = cp.redactToolCalls(r.ToolCalls)
+	if r.ComputerCalls != nil {
+		calls := make([]ComputerCall, len(r.ComputerCalls))
+		for i, call := range r.ComputerCalls {
+			call.Action.Text = cp.redactContentString(call.Action.Text)
+			calls[i] = call
+		}
+		r.ComputerCalls = calls
+	}
+	if r.Reasoning != nil {
+		reasoning := *r.Reasoning
+		reasoning.Summary = make([]string, len(r.Reasoning.Summary))
+		for i, s := range r.Reasoning.Summary {
+			reasoning.Summary[i] = cp.redactContentString(s)
+		}
+		r.Reasoning = &reasoning
+	}
+	if r.Parts != nil {
+		parts := make([]OutputPart, 0, len(r.Parts))
+		for _, p := range r.Parts {
+			switch p := p.(type) {
+			case OutputText:
+				parts = append(parts, OutputText{Text: cp.redactContentString(p.Text)})
+			default:
+				if !cp.redactContent {
+					parts = append(parts, p)
+				}
+			}
+		}
+		r.Parts = parts
+	}
+	return &r
+}
+
+// FileSink appends capture records to a file as JSON Lines.
+type FileSink struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// NewFileSink opens path for appending, creating it with mode 0600 if needed.
+func NewFileSink(path string) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{f: f}, nil
+}
+
+// WriteCapture implements CaptureSink.
+func (s *FileSink) WriteCapture(_ context.Context, rec *CaptureRecord) error {
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.f.Write(append(data, '\n'))
+	return err
+}
+
+// Close closes the file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
+
+// ObjectWriter stores one object. It is implemented by thin adapters over
+// S3-compatible clients (AWS S3, MinIO, GCS interoperability, R2).
+type ObjectWriter interface {
+	PutObject(ctx context.Context, key string, data []byte, contentType string) error
+}
+
+// ObjectSink writes each capture record as a JSON object to an ObjectWriter.
+// Keys have the form prefix/YYYY/MM/DD/<start>-<provider>-<n>.json.
+type ObjectSink struct {
+	w      ObjectWriter
+	prefix string
+
+	mu  sync.Mutex
+	seq uint64
+}
+
+// NewObjectSink returns an ObjectSink writing under prefix.
+func NewObjectSink(w ObjectWriter, prefix string) *ObjectSink {
+	return &ObjectSink{w: w, prefix: prefix}
+}
+
+// WriteCapture implements CaptureSink.
+func (s *ObjectSink) WriteCapture(ctx context.Context, rec *CaptureRecord) error {
+	if s.w == nil {
+		return errors.New("capture: nil ObjectWriter")
+	}
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.seq++
+	seq := s.seq
+	s.mu.Unlock()
+
+	start := rec.Start.UTC()
+	key := fmt.Sprintf("%s/%s-%s-%d.json",
+		start.Format("2006/01/02"), start.Format("20060102T150405.000000000Z"), rec.Provider, seq)
+	if prefix := strings.TrimSuffix(s.prefix, "/"); prefix != "" {
+		key = prefix + "/" + key
+	}
+	return s.w.PutObject(ctx, key, data, "application/json")
+}
@@ -0,0 +1,278 @@
+package core
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// captureRecorder is a CaptureSink that keeps records in memory.
+type captureRecorder struct {
+	mu      sync.Mutex
+	records []*CaptureRecord
+}
+
+func (r *captureRecorder) WriteCapture(_ context.Context, rec *CaptureRecord) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, rec)
+	return nil
+}
+
+func TestCaptureRedactsCredentials(t *testing.T) {
+	p := &mockProvider{
+		id: "test-provider",
+		chatFunc: func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+			return &ChatResponse{
+				Output:    "Use Bearer abc.def.ghi to call it",
+				ToolCalls: []ToolCall{{ID: "1", Name: "fetch", Arguments: json.RawMessage(`{"key":"sk-aaaaaaaaaaaaaaaaaaaa"}`)}},
+			}, nil
+		},
+	}
+	sink := &captureRecorder{}
+	c := NewClient(p, WithCapture(sink, CaptureRedactPattern(regexp.MustCompile(`\d{3}-\d{2}-\d{4}`))))
+
+	if _, err := c.Chat("gpt-4").User("My key is sk-abcdefghijklmnopqrstuv and SSN 123-45-6789").GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+
+	if len(sink.records) != 1 {
+		t.Fatalf("records = %d, want 1", len(sink.records))
+	}
+	rec := sink.records[0]
+	if rec.Provider != "test-provider" || rec.Model != "gpt-4" || rec.Stream {
+		t.Errorf("record = %s/%s stream=%v", rec.Provider, rec.Model, rec.Stream)
+	}
+	if got, want := rec.Request.Messages[0].Content, "My key is [REDACTED] and SSN [REDACTED]"; got != want {
+		t.Errorf("request content = %q, want %q", got, want)
+	}
+	if got, want := rec.Response.Output, "Use [REDACTED] to call it"; got != want {
+		t.Errorf("response output = %q, want %q", got, want)
+	}
+	if got, want := string(rec.Response.ToolCalls[0].Arguments), `{"key":"[REDACTED]"}`; got != want {
+		t.Errorf("tool arguments = %s, want %s", got, want)
+	}
+
+	// The request sent to the provider is not modified
+	if got := p.lastRequest.Messages[0].Content; !strings.Contains(got, "sk-abcdefghijklmnopqrstuv") {
+		t.Errorf("provider request was redacted: %q", got)
+	}
+}
+
+func TestCaptureRedactContent(t *testing.T) {
+	p := &mockProvider{
+		id: "test-provider",
+		chatFunc: func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+			return &ChatResponse{Output: "secret answer", Usage: TokenUsage{TotalTokens: 7}}, nil
+		},
+	}
+	sink := &captureRecorder{}
+	c := NewClient(instructionsProvider{p}, WithCapture(sink, CaptureRedactContent()))
+
+	_, err := c.Chat("gpt-4").
+		Instructions("be terse").
+		User("secret question").
+		ToolResults(&ChatResponse{ToolCalls: []ToolCall{{ID: "1", Name: "count", Arguments: json.RawMessage(`{}`)}}},
+			[]ToolResult{{CallID: "1", Content: map[string]int{"n": 1}}}).
+		GetResponse(context.Background())
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+
+	rec := sink.records[0]
+	if rec.Request.Instructions != Redacted || rec.Request.Messages[0].Content != Redacted {
+		t.Errorf("request not redacted: %+v", rec.Request)
+	}
+	last := rec.Request.Messages[len(rec.Request.Messages)-1]
+	if len(last.ToolResults) != 1 || last.ToolResults[0].Content != Redacted {
+		t.Errorf("tool results = %+v, want redacted", last.ToolResults)
+	}
+	if rec.Response.Output != Redacted {
+		t.Errorf("output = %q, want %s", rec.Response.Output, Redacted)
+	}
+	if rec.Response.Usage.TotalTokens != 7 {
+		t.Errorf("usage = %+v, want metadata kept", rec.Response.Usage)
+	}
+}
+
+func TestCaptureStreamAndError(t *testing.T) {
+	p := &mockProvider{
+		id: "test-provider",
+		streamFunc: func(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+			return newTestStream([]string{"hi"}, nil, errors.New("token sk-zzzzzzzzzzzzzzzzzzzz expired")), nil
+		},
+	}
+	done := make(chan *CaptureRecord, 1)
+	c := NewClient(p, WithCapture(CaptureFunc(func(ctx context.Context, rec *CaptureRecord) error {
+		done <- rec
+		return nil
+	})))
+
+	stream, err := c.Chat("gpt-4").User("Hello").Stream(context.Background())
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	for range stream.Ch {
+	}
+
+	rec := <-done
+	if !rec.Stream || rec.Response != nil {
+		t.Errorf("record stream=%v response=%v", rec.Stream, rec.Response)
+	}
+	if rec.Error != "token [REDACTED] expired" {
+		t.Errorf("error = %q", rec.Error)
+	}
+}
+
+// spanHook is a ContextualTelemetryHook that marks the context as holding
+// an open request and records when the request ended.
+type spanHook struct {
+	NoopTelemetryHook
+	ended chan struct{}
+}
+
+type spanKey struct{}
+
+func (h *spanHook) OnRequestStartWithContext(ctx context.Context, _ RequestStartEvent) context.Context {
+	return context.WithValue(ctx, spanKey{}, true)
+}
+
+func (h *spanHook) OnRequestEndWithContext(context.Context, RequestEndEvent) {
+	close(h.ended)
+}
+
+func TestCaptureRunsBeforeRequestEnd(t *testing.T) {
+	tests := []struct {
+		name string
+		run  func(*Client) error
+	}{
+		{"chat", func(c *Client) error {
+			_, err := c.Chat("gpt-4").User("Hello").GetResponse(context.Background())
+			return err
+		}},
+		{"stream", func(c *Client) error {
+			stream, err := c.Chat("gpt-4").User("Hello").Stream(context.Background())
+			if err != nil {
+				return err
+			}
+			_, err = DrainStream(context.Background(), stream)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := &spanHook{ended: make(chan struct{})}
+			captured := make(chan error, 1)
+			p := &mockProvider{
+				id: "test",
+				streamFunc: func(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+					return newTestStream([]string{"hi"}, &ChatResponse{Output: "hi"}, nil), nil
+				},
+			}
+			c := NewClient(p, WithTelemetry(hook), WithCapture(CaptureFunc(func(ctx context.Context, rec *CaptureRecord) error {
+				select {
+				case <-hook.ended:
+					captured <- errors.New("capture ran after the request end event")
+				default:
+					if ctx.Value(spanKey{}) == nil {
+						captured <- errors.New("capture context lacks the telemetry context")
+					} else {
+						captured <- nil
+					}
+				}
+				return nil
+			})))
+
+			if err := tt.run(c); err != nil {
+				t.Fatalf("request error = %v", err)
+			}
+			if err := <-captured; err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestCaptureSinkErrorIsWarning(t *testing.T) {
+	var warnings []string
+	c := NewClient(&mockProvider{id: "test"},
+		WithWarningHandler(func(msg string) { warnings = append(warnings, msg) }),
+		WithCapture(CaptureFunc(func(context.Context, *CaptureRecord) error {
+			return errors.New("disk full")
+		})))
+
+	if _, err := c.Chat("gpt-4").User("Hello").GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v, want sink errors ignored", err)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "disk full") {
+		t.Errorf("warnings = %v", warnings)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.jsonl")
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("NewFileSink() error = %v", err)
+	}
+	c := NewClient(&mockProvider{id: "test"}, WithCapture(sink))
+	for range 2 {
+		if _, err := c.Chat("gpt-4").User("Hello").GetResponse(context.Background()); err != nil {
+			t.Fatalf("GetResponse() error = %v", err)
+		}
+	}
+	if err := sink.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	lines := 0
+	for scanner := bufio.NewScanner(f); scanner.Scan(); lines++ {
+		var rec CaptureRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatalf("line %d: %v", lines, err)
+		}
+		if rec.Request == nil || rec.Request.Messages[0].Content != "Hello" {
+			t.Errorf("line %d request = %+v", lines, rec.Request)
+		}
+	}
+	if lines != 2 {
+		t.Errorf("lines = %d, want 2", lines)
+	}
+}
+
+type objectWriterFunc func(ctx context.Context, key string, data []byte, contentType string) error
+
+func (f objectWriterFunc) PutObject(ctx context.Context, key string, data []byte, contentType string) error {
+	return f(ctx, key, data, contentType)
+}
+
+func TestObjectSink(t *testing.T) {
+	var keys []string
+	sink := NewObjectSink(objectWriterFunc(func(ctx context.Context, key string, data []byte, contentType string) error {
+		keys = append(keys, key)
+		if contentType != "application/json" || !json.Valid(data) {
+			t.Errorf("PutObject(%q, %s, %q)", key, data, contentType)
+		}
+		return nil
+	}), "captures/")
+
+	c := NewClient(&mockProvider{id: "test"}, WithCapture(sink))
+	if _, err := c.Chat("gpt-4").User("Hello").GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if len(keys) != 1 || !regexp.MustCompile(`^captures/\d{4}/\d{2}/\d{2}/\S+-test-1\.json$`).MatchString(keys[0]) {
+		t.Errorf("keys = %v", keys)
+	}
+}
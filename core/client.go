@@ -2,7 +2,10 @@ package core
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
+	"maps"
+	"net/http"
 	"time"
 )
 
@@ -42,10 +45,20 @@ type ImageGenerator interface {
 // Client is the main entry point for interacting with LLM providers.
 // Client is safe for concurrent use.
 type Client struct {
-	provider       Provider
-	telemetry      TelemetryHook
-	retry          RetryPolicy
-	warningHandler WarningHandler
+	provider         Provider
+	telemetry        TelemetryHook
+	retry            RetryPolicy
+	warningHandler   WarningHandler
+	warningListener  WarningListener
+	strictParams     bool
+	toolResultPolicy *ToolResultPolicy
+	defaults         []RequestDefault
+	capture          *capture
+	throughput       *ThroughputEstimator
+	defaultTimeout   time.Duration
+	promptAssembly   PromptAssembly
+	lifecycle        lifecycle
+	limiter          *limiter
 }
 
 // ClientOption configures a Client.
@@ -63,6 +76,7 @@ func NewClient(p Provider, opts ...ClientOption) *Client {
 		retry:          DefaultRetryPolicy(),
 		warningHandler: func(string) {},
 	}
+	c.lifecycle.abortCtx, c.lifecycle.abort = context.WithCancel(context.Background())
 	for _, opt := range opts {
 		opt(c)
 	}
@@ -97,27 +111,59 @@ func WithWarningHandler(h WarningHandler) ClientOption {
 	}
 }
 
+// WithToolResultPolicy sets the default tool result serialization policy
+// applied to every ChatBuilder created by the client.
+func WithToolResultPolicy(p ToolResultPolicy) ClientOption {
+	return func(c *Client) {
+		c.toolResultPolicy = &p
+	}
+}
+
 // Provider returns the underlying provider.
 func (c *Client) Provider() Provider {
 	return c.provider
 }
 
 // Chat returns a ChatBuilder for constructing and executing a chat request.
+// The request starts with any defaults registered with WithDefaults.
 func (c *Client) Chat(model ModelID) *ChatBuilder {
-	return &ChatBuilder{
+	b := &ChatBuilder{
 		client: c,
 		req: ChatRequest{
 			Model: model,
 		},
 	}
+	if c.toolResultPolicy != nil {
+		p := *c.toolResultPolicy
+		b.req.ToolResultPolicy = &p
+	}
+	c.applyDefaults(&b.req)
+	return b
 }
 
 // ChatBuilder provides a fluent API for building chat requests.
 // ChatBuilder is NOT thread-safe and should not be shared across goroutines.
+// To reuse a request concurrently, snapshot it with Spec and run it via Client.Do.
 type ChatBuilder struct {
 	client  *Client
 	req     ChatRequest
 	timeout time.Duration // optional timeout for GetResponse/Stream
+
+	// stallTimeout fails a stream that goes quiet (see StallTimeout)
+	stallTimeout time.Duration
+
+	// streamOpts configures chunk buffering (see StreamOptions)
+	streamOpts StreamOptions
+
+	// Structured output validation (see ValidateJSON)
+	validateJSON bool
+	jsonRetries  int
+
+	// Structured output repair (see RepairAttempts)
+	repairAttempts int
+
+	// priority orders the request under a concurrency limit (see Priority)
+	priority Priority
 }
 
 // System appends a system message.
@@ -138,6 +184,17 @@ func (b *ChatBuilder) Assistant(s string) *ChatBuilder {
 	return b
 }
 
+// Messages appends the given messages in order.
+// The messages are copied, so later changes by the caller do not affect the builder.
+// This is useful for restoring persisted conversation history:
+//
+//	history, err := core.MessagesFromOpenAIJSON(data)
+//	resp, err := client.Chat(model).Messages(history...).User("Next question").GetResponse(ctx)
+func (b *ChatBuilder) Messages(msgs ...Message) *ChatBuilder {
+	b.req.Messages = append(b.req.Messages, cloneMessages(msgs)...)
+	return b
+}
+
 // Temperature sets the temperature parameter.
 func (b *ChatBuilder) Temperature(v float32) *ChatBuilder {
 	b.req.Temperature = &v
@@ -157,7 +214,8 @@ func (b *ChatBuilder) Tools(ts ...Tool) *ChatBuilder {
 }
 
 // Instructions sets the system instructions (Responses API style).
-// For Chat Completions API, this is equivalent to adding a system message.
+// Providers and models without native instructions receive them as a
+// leading system message; see PromptAssembly.
 func (b *ChatBuilder) Instructions(s string) *ChatBuilder {
 	b.req.Instructions = s
 	return b
@@ -180,6 +238,13 @@ func (b *ChatBuilder) WebSearch() *ChatBuilder {
 	return b.BuiltInTool("web_search")
 }
 
+// WebSearchWithOptions adds the web_search built-in tool constrained by
+// opts: allowed and blocked domains, user location, and context size.
+func (b *ChatBuilder) WebSearchWithOptions(opts WebSearchOptions) *ChatBuilder {
+	b.req.BuiltInTools = append(b.req.BuiltInTools, BuiltInTool{Type: "web_search", WebSearch: &opts})
+	return b
+}
+
 // FileSearch adds the file_search built-in tool with optional vector store IDs.
 func (b *ChatBuilder) FileSearch(vectorStoreIDs ...string) *ChatBuilder {
 	// Add the file_search tool
@@ -203,12 +268,37 @@ func (b *ChatBuilder) CodeInterpreter() *ChatBuilder {
 	return b.BuiltInTool("code_interpreter")
 }
 
+// ToolResultPolicy sets how tool results are serialized when sent to the model.
+// It overrides any policy configured on the client.
+func (b *ChatBuilder) ToolResultPolicy(p ToolResultPolicy) *ChatBuilder {
+	b.req.ToolResultPolicy = &p
+	return b
+}
+
 // ContinueFrom chains this request to a previous response.
 func (b *ChatBuilder) ContinueFrom(responseID string) *ChatBuilder {
 	b.req.PreviousResponseID = responseID
 	return b
 }
 
+// Store sets whether the provider persists the response (Responses API
+// store). Responses that are not stored cannot be continued with
+// ContinueFrom or retrieved later.
+func (b *ChatBuilder) Store(store bool) *ChatBuilder {
+	b.req.Store = &store
+	return b
+}
+
+// Metadata attaches key-value pairs to the stored response. Calls merge into
+// the existing metadata.
+func (b *ChatBuilder) Metadata(metadata map[string]string) *ChatBuilder {
+	if b.req.Metadata == nil {
+		b.req.Metadata = make(map[string]string, len(metadata))
+	}
+	maps.Copy(b.req.Metadata, metadata)
+	return b
+}
+
 // Timeout sets an optional timeout for the request.
 // When set, GetResponse and Stream will create a context with this timeout
 // if a context.Background() or context without deadline is passed.
@@ -221,6 +311,9 @@ func (b *ChatBuilder) ContinueFrom(responseID string) *ChatBuilder {
 //
 //	// You can write:
 //	resp, err := client.Chat(model).User("Hello").Timeout(30*time.Second).GetResponse(context.Background())
+//
+// Timeout overrides the client DefaultTimeout and the provider's
+// DefaultTimeout (see TimeoutProvider).
 func (b *ChatBuilder) Timeout(d time.Duration) *ChatBuilder {
 	b.timeout = d
 	return b
@@ -235,85 +328,124 @@ func (b *ChatBuilder) Timeout(d time.Duration) *ChatBuilder {
 //
 // The original builder remains unchanged after cloning.
 func (b *ChatBuilder) Clone() *ChatBuilder {
-	clone := &ChatBuilder{
-		client:  b.client,
-		timeout: b.timeout,
-		req: ChatRequest{
-			Model:              b.req.Model,
-			Instructions:       b.req.Instructions,
-			ReasoningEffort:    b.req.ReasoningEffort,
-			PreviousResponseID: b.req.PreviousResponseID,
-			Truncation:         b.req.Truncation,
-			ResponseFormat:     b.req.ResponseFormat,
-		},
+	return &ChatBuilder{
+		client:         b.client,
+		timeout:        b.timeout,
+		stallTimeout:   b.stallTimeout,
+		streamOpts:     b.streamOpts,
+		req:            cloneChatRequest(&b.req),
+		validateJSON:   b.validateJSON,
+		jsonRetries:    b.jsonRetries,
+		repairAttempts: b.repairAttempts,
+		priority:       b.priority,
+	}
+}
+
+// cloneChatRequest returns a deep copy of req. Tools are copied by reference.
+func cloneChatRequest(req *ChatRequest) ChatRequest {
+	clone := ChatRequest{
+		Model:              req.Model,
+		Instructions:       req.Instructions,
+		ReasoningEffort:    req.ReasoningEffort,
+		PreviousResponseID: req.PreviousResponseID,
+		Truncation:         req.Truncation,
+		ResponseFormat:     req.ResponseFormat,
 	}
 
 	// Deep copy pointer values
-	if b.req.Temperature != nil {
-		t := *b.req.Temperature
-		clone.req.Temperature = &t
+	if req.Temperature != nil {
+		t := *req.Temperature
+		clone.Temperature = &t
+	}
+	if req.MaxTokens != nil {
+		m := *req.MaxTokens
+		clone.MaxTokens = &m
+	}
+	if req.ToolResultPolicy != nil {
+		p := *req.ToolResultPolicy
+		clone.ToolResultPolicy = &p
+	}
+	if req.ToolChoice != nil {
+		c := *req.ToolChoice
+		clone.ToolChoice = &c
+	}
+	if req.ParallelToolCalls != nil {
+		v := *req.ParallelToolCalls
+		clone.ParallelToolCalls = &v
 	}
-	if b.req.MaxTokens != nil {
-		m := *b.req.MaxTokens
-		clone.req.MaxTokens = &m
+	if req.Store != nil {
+		s := *req.Store
+		clone.Store = &s
 	}
-	if b.req.JSONSchema != nil {
-		schemaCopy := *b.req.JSONSchema
+	clone.Metadata = maps.Clone(req.Metadata)
+	if req.JSONSchema != nil {
+		schemaCopy := *req.JSONSchema
 		// Deep copy the schema bytes
-		if len(b.req.JSONSchema.Schema) > 0 {
-			schemaCopy.Schema = make([]byte, len(b.req.JSONSchema.Schema))
-			copy(schemaCopy.Schema, b.req.JSONSchema.Schema)
+		if len(req.JSONSchema.Schema) > 0 {
+			schemaCopy.Schema = make([]byte, len(req.JSONSchema.Schema))
+			copy(schemaCopy.Schema, req.JSONSchema.Schema)
 		}
-		clone.req.JSONSchema = &schemaCopy
+		clone.JSONSchema = &schemaCopy
 	}
 
 	// Deep copy slices
-	if len(b.req.Messages) > 0 {
-		clone.req.Messages = make([]Message, len(b.req.Messages))
-		for i, msg := range b.req.Messages {
-			clone.req.Messages[i] = Message{
-				Role:    msg.Role,
-				Content: msg.Content,
-			}
-			if len(msg.Parts) > 0 {
-				clone.req.Messages[i].Parts = make([]ContentPart, len(msg.Parts))
-				copy(clone.req.Messages[i].Parts, msg.Parts)
-			}
-			if len(msg.ToolCalls) > 0 {
-				clone.req.Messages[i].ToolCalls = make([]ToolCall, len(msg.ToolCalls))
-				copy(clone.req.Messages[i].ToolCalls, msg.ToolCalls)
-			}
-			if len(msg.ToolResults) > 0 {
-				clone.req.Messages[i].ToolResults = make([]ToolResult, len(msg.ToolResults))
-				copy(clone.req.Messages[i].ToolResults, msg.ToolResults)
-			}
-		}
-	}
+	clone.Messages = cloneMessages(req.Messages)
 
-	if len(b.req.Tools) > 0 {
-		clone.req.Tools = make([]Tool, len(b.req.Tools))
-		copy(clone.req.Tools, b.req.Tools)
+	if len(req.Tools) > 0 {
+		clone.Tools = make([]Tool, len(req.Tools))
+		copy(clone.Tools, req.Tools)
 	}
 
-	if len(b.req.BuiltInTools) > 0 {
-		clone.req.BuiltInTools = make([]BuiltInTool, len(b.req.BuiltInTools))
-		copy(clone.req.BuiltInTools, b.req.BuiltInTools)
+	if len(req.BuiltInTools) > 0 {
+		clone.BuiltInTools = make([]BuiltInTool, len(req.BuiltInTools))
+		copy(clone.BuiltInTools, req.BuiltInTools)
 	}
 
 	// Deep copy ToolResources
-	if b.req.ToolResources != nil {
-		clone.req.ToolResources = &ToolResources{}
-		if b.req.ToolResources.FileSearch != nil {
-			clone.req.ToolResources.FileSearch = &FileSearchResources{
-				VectorStoreIDs: make([]string, len(b.req.ToolResources.FileSearch.VectorStoreIDs)),
+	if req.ToolResources != nil {
+		clone.ToolResources = &ToolResources{}
+		if req.ToolResources.FileSearch != nil {
+			clone.ToolResources.FileSearch = &FileSearchResources{
+				VectorStoreIDs: make([]string, len(req.ToolResources.FileSearch.VectorStoreIDs)),
 			}
-			copy(clone.req.ToolResources.FileSearch.VectorStoreIDs, b.req.ToolResources.FileSearch.VectorStoreIDs)
+			copy(clone.ToolResources.FileSearch.VectorStoreIDs, req.ToolResources.FileSearch.VectorStoreIDs)
 		}
 	}
 
 	return clone
 }
 
+// cloneMessages deep copies a message slice. It returns nil for an empty slice.
+func cloneMessages(msgs []Message) []Message {
+	if len(msgs) == 0 {
+		return nil
+	}
+	out := make([]Message, len(msgs))
+	for i, msg := range msgs {
+		out[i] = Message{
+			Role:    msg.Role,
+			Content: msg.Content,
+		}
+		if len(msg.Parts) > 0 {
+			out[i].Parts = make([]ContentPart, len(msg.Parts))
+			copy(out[i].Parts, msg.Parts)
+		}
+		if len(msg.ToolCalls) > 0 {
+			out[i].ToolCalls = make([]ToolCall, len(msg.ToolCalls))
+			copy(out[i].ToolCalls, msg.ToolCalls)
+		}
+		if len(msg.ToolResults) > 0 {
+			out[i].ToolResults = make([]ToolResult, len(msg.ToolResults))
+			copy(out[i].ToolResults, msg.ToolResults)
+		}
+		if len(msg.ComputerResults) > 0 {
+			out[i].ComputerResults = make([]ComputerCallOutput, len(msg.ComputerResults))
+			copy(out[i].ComputerResults, msg.ComputerResults)
+		}
+	}
+	return out
+}
+
 // Truncation sets the truncation mode for the request.
 func (b *ChatBuilder) Truncation(mode string) *ChatBuilder {
 	b.req.Truncation = mode
@@ -430,6 +562,18 @@ func (b *ChatBuilder) ToolError(assistantResp *ChatResponse, callID string, err
 	}})
 }
 
+// requestWithIdempotencyKey returns the request to send for one call.
+// If no idempotency key was set, it returns a shallow copy with a fresh key so
+// the builder can be reused without sharing keys between calls.
+func (b *ChatBuilder) requestWithIdempotencyKey() *ChatRequest {
+	if b.req.IdempotencyKey != "" {
+		return &b.req
+	}
+	req := b.req
+	req.IdempotencyKey = newIdempotencyKey()
+	return &req
+}
+
 // validate checks that the request is valid.
 func (b *ChatBuilder) validate() error {
 	if b.req.Model == "" {
@@ -439,33 +583,79 @@ func (b *ChatBuilder) validate() error {
 		return ErrNoMessages
 	}
 
-	// Validate each message has content (Content, Parts, ToolCalls, or ToolResults)
+	// Validate each message has content (Content, Parts, ToolCalls, ToolResults, or ComputerResults)
 	for _, msg := range b.req.Messages {
-		hasContent := msg.Content != "" || len(msg.Parts) > 0 || len(msg.ToolCalls) > 0 || len(msg.ToolResults) > 0
+		hasContent := msg.Content != "" || len(msg.Parts) > 0 || len(msg.ToolCalls) > 0 ||
+			len(msg.ToolResults) > 0 || len(msg.ComputerResults) > 0
 		if !hasContent {
 			return ErrNoMessages
 		}
 	}
 
+	if b.req.ToolChoice != nil {
+		if err := b.req.ToolChoice.Validate(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 // GetResponse executes the chat request and returns the response.
 // It applies validation, telemetry, and retry logic.
-// If Timeout was set and ctx has no deadline, a timeout context is created internally.
+// If ctx has no deadline, a timeout context is created internally from
+// Timeout, the client DefaultTimeout, or the provider's DefaultTimeout.
 func (b *ChatBuilder) GetResponse(ctx context.Context) (*ChatResponse, error) {
 	if err := b.validate(); err != nil {
 		return nil, err
 	}
+	if err := b.checkParameters(); err != nil {
+		return nil, err
+	}
+	ctx, finish, err := b.client.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer finish()
 
 	// Apply timeout if set and context has no deadline
-	if b.timeout > 0 {
-		if _, hasDeadline := ctx.Deadline(); !hasDeadline {
-			var cancel context.CancelFunc
-			ctx, cancel = context.WithTimeout(ctx, b.timeout)
-			defer cancel()
+	ctx, cancel := b.withRequestTimeout(ctx)
+	defer cancel()
+
+	release, err := b.client.acquire(ctx, b.req.Model, b.priority)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	resp, err := b.execute(ctx)
+	if err != nil || !b.validateJSON {
+		return resp, err
+	}
+
+	// Re-send the request while structured output fails validation
+	for attempt := 0; ; attempt++ {
+		jsonErr := checkJSONOutput(resp.Output, &b.req)
+		if jsonErr == nil {
+			return resp, nil
+		}
+		if attempt >= b.jsonRetries {
+			return resp, jsonErr
+		}
+		if resp, err = b.execute(ctx); err != nil {
+			return resp, err
 		}
 	}
+}
+
+// execute sends the request once, with telemetry and transport retries.
+func (b *ChatBuilder) execute(ctx context.Context) (*ChatResponse, error) {
+	// All attempts share one idempotency key so providers can deduplicate retries
+	orig := b.requestWithIdempotencyKey()
+	req, err := b.client.capMaxTokens(ctx, b.client.assemblePrompt(orig))
+	if err != nil {
+		return nil, err
+	}
 
 	start := time.Now()
 	providerID := b.client.provider.ID()
@@ -483,12 +673,12 @@ func (b *ChatBuilder) GetResponse(ctx context.Context) (*ChatResponse, error) {
 	}
 
 	var resp *ChatResponse
-	var err error
+	retries := 0
 
 	// Execute with retry logic
 retryLoop:
 	for attempt := 0; ; attempt++ {
-		resp, err = b.client.provider.Chat(ctx, &b.req)
+		resp, err = b.client.provider.Chat(ctx, req)
 		if err == nil {
 			break
 		}
@@ -505,6 +695,7 @@ retryLoop:
 			err = ctx.Err()
 			break retryLoop
 		case <-time.After(delay):
+			retries++
 			continue
 		}
 	}
@@ -522,13 +713,23 @@ retryLoop:
 		End:      end,
 		Usage:    usage,
 		Err:      err,
+		Retries:  retries,
 	}
 
+	// Capture first so sinks still see the request span in ctx
+	b.client.record(ctx, CaptureRecord{
+		Provider: providerID,
+		Model:    b.req.Model,
+		Start:    start,
+		End:      end,
+	}, callerPrompt(req, orig), resp, err)
+
 	if ctxHook, ok := b.client.telemetry.(ContextualTelemetryHook); ok {
 		ctxHook.OnRequestEndWithContext(ctx, endEvent)
 	} else {
 		b.client.telemetry.OnRequestEnd(endEvent)
 	}
+	b.client.observeThroughput(endEvent)
 
 	return resp, err
 }
@@ -543,10 +744,29 @@ retryLoop:
 //	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
 //	defer cancel()
 //	stream, err := client.Chat(model).User("...").Stream(ctx)
+//
+// To guard against connections that hang without closing, set StallTimeout.
 func (b *ChatBuilder) Stream(ctx context.Context) (*ChatStream, error) {
 	if err := b.validate(); err != nil {
 		return nil, err
 	}
+	if err := b.checkParameters(); err != nil {
+		return nil, err
+	}
+	orig := b.requestWithIdempotencyKey()
+	req, err := b.client.capMaxTokens(ctx, b.client.assemblePrompt(orig))
+	if err != nil {
+		return nil, err
+	}
+	ctx, finish, err := b.client.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	release, err := b.client.acquire(ctx, b.req.Model, b.priority)
+	if err != nil {
+		finish()
+		return nil, err
+	}
 
 	start := time.Now()
 	providerID := b.client.provider.ID()
@@ -562,8 +782,9 @@ func (b *ChatBuilder) Stream(ctx context.Context) (*ChatStream, error) {
 	} else {
 		b.client.telemetry.OnRequestStart(startEvent)
 	}
+	ctx = b.client.withResumeReporter(ctx, providerID, b.req.Model)
 
-	stream, err := b.client.provider.StreamChat(ctx, &b.req)
+	stream, err := b.openStream(ctx, req)
 	if err != nil {
 		// Emit telemetry end on immediate error
 		endEvent := RequestEndEvent{
@@ -573,16 +794,54 @@ func (b *ChatBuilder) Stream(ctx context.Context) (*ChatStream, error) {
 			End:      time.Now(),
 			Err:      err,
 		}
+		b.client.record(ctx, CaptureRecord{
+			Provider: providerID,
+			Model:    b.req.Model,
+			Start:    start,
+			End:      endEvent.End,
+			Stream:   true,
+		}, callerPrompt(req, orig), nil, err)
 		if ctxHook, ok := b.client.telemetry.(ContextualTelemetryHook); ok {
 			ctxHook.OnRequestEndWithContext(ctx, endEvent)
 		} else {
 			b.client.telemetry.OnRequestEnd(endEvent)
 		}
+		release()
+		finish()
 		return nil, err
 	}
 
-	// Wrap the stream to emit telemetry when it completes
-	return wrapStreamWithTelemetry(ctx, stream, b.client.telemetry, providerID, b.req.Model, start), nil
+	// Learn throughput and capture the exchange once the stream completes,
+	// then free its slot and stop tracking it as in flight
+	onDone := func(resp *ChatResponse, err error) {
+		defer finish()
+		defer release()
+		if b.client.capture != nil || b.client.throughput != nil {
+			end := time.Now()
+			if resp != nil {
+				b.client.observeThroughput(RequestEndEvent{
+					Provider: providerID,
+					Model:    req.Model,
+					Start:    start,
+					End:      end,
+					Usage:    resp.Usage,
+					Err:      err,
+				})
+			}
+			b.client.record(ctx, CaptureRecord{
+				Provider: providerID,
+				Model:    req.Model,
+				Start:    start,
+				End:      end,
+				Stream:   true,
+			}, callerPrompt(req, orig), resp, err)
+		}
+	}
+
+	// Wrap the stream to emit telemetry when it completes; buffering is
+	// outermost so a slow consumer does not skew provider timings
+	stream = wrapStreamWithTelemetry(ctx, stream, b.client.telemetry, providerID, b.req.Model, start, onDone)
+	return shapeStream(stream, b.streamOpts), nil
 }
 
 // MessageBuilder provides a fluent API for building multimodal messages.
@@ -619,6 +878,17 @@ func (m *MessageBuilder) ImageURLWithDetail(url string, detail ImageDetail) *Mes
 	return m
 }
 
+// ImageBytes adds an image from raw bytes, encoded as a data URL.
+// If mimeType is empty it is detected from the data.
+func (m *MessageBuilder) ImageBytes(data []byte, mimeType string) *MessageBuilder {
+	if mimeType == "" {
+		mimeType = http.DetectContentType(data)
+	}
+	url := "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+	m.parts = append(m.parts, &InputImage{ImageURL: url})
+	return m
+}
+
 // ImageFileID adds an image by file ID from the Files API.
 func (m *MessageBuilder) ImageFileID(fileID string) *MessageBuilder {
 	m.parts = append(m.parts, &InputImage{FileID: fileID})
@@ -652,6 +922,17 @@ func (m *MessageBuilder) FileBase64(filename, base64Data string) *MessageBuilder
 	return m
 }
 
+// Audio adds raw audio bytes in the given format.
+func (m *MessageBuilder) Audio(data []byte, format AudioFormat) *MessageBuilder {
+	return m.AudioBase64(base64.StdEncoding.EncodeToString(data), format)
+}
+
+// AudioBase64 adds base64-encoded audio in the given format.
+func (m *MessageBuilder) AudioBase64(base64Data string, format AudioFormat) *MessageBuilder {
+	m.parts = append(m.parts, &InputAudio{Data: base64Data, Format: format})
+	return m
+}
+
 // Done completes the message and returns to the ChatBuilder.
 func (m *MessageBuilder) Done() *ChatBuilder {
 	m.parent.req.Messages = append(m.parent.req.Messages, Message{
@@ -698,6 +979,8 @@ func (b *ChatBuilder) UserWithFileID(text, fileID string) *ChatBuilder {
 }
 
 // wrapStreamWithTelemetry wraps a ChatStream to emit telemetry on completion.
+// If onDone is not nil, it is called with the stream outcome before the
+// request end event.
 func wrapStreamWithTelemetry(
 	ctx context.Context,
 	stream *ChatStream,
@@ -705,10 +988,20 @@ func wrapStreamWithTelemetry(
 	provider string,
 	model ModelID,
 	start time.Time,
+	onDone func(resp *ChatResponse, err error),
 ) *ChatStream {
 	finalCh := make(chan *ChatResponse, 1)
 	errCh := make(chan error, 1)
 
+	// Chunks are only intercepted when someone wants their timing
+	ch := stream.Ch
+	var timer *streamTimer
+	var timed chan struct{}
+	streamHook, timeChunks := hook.(StreamTelemetryHook)
+	if timeChunks {
+		ch, timer, timed = timeStream(stream, start)
+	}
+
 	go func() {
 		defer close(finalCh)
 		defer close(errCh)
@@ -747,6 +1040,11 @@ func wrapStreamWithTelemetry(
 		}
 	done:
 
+		// Before telemetry end, so capture sinks see the open span
+		if onDone != nil {
+			onDone(finalResp, finalErr)
+		}
+
 		// Emit telemetry end
 		usage := TokenUsage{}
 		if finalResp != nil {
@@ -766,15 +1064,32 @@ func wrapStreamWithTelemetry(
 		} else {
 			hook.OnRequestEnd(endEvent)
 		}
+
+		if timeChunks {
+			<-timed
+			end := endEvent.End
+			if timer.last.After(end) {
+				end = timer.last
+			}
+			streamHook.OnStreamStats(timer.event(provider, model, end, finalErr))
+		}
 	}()
 
 	return &ChatStream{
-		Ch:    stream.Ch,
+		Ch:    ch,
 		Err:   errCh,
 		Final: finalCh,
 	}
 }
 
 func (c *Client) warnf(format string, args ...any) {
-	c.warningHandler(fmt.Sprintf(format, args...))
+	c.warn(Warning{Message: fmt.Sprintf(format, args...)})
+}
+
+// warn delivers w to the warning handler and, if set, the warning listener.
+func (c *Client) warn(w Warning) {
+	c.warningHandler(w.String())
+	if c.warningListener != nil {
+		c.warningListener(w)
+	}
 }
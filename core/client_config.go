@@ -0,0 +1,50 @@
+package core
+
+import "time"
+
+// ClientConfig describes a client to build with NewClientFromConfig. It is
+// usually produced from a configuration file by the config package.
+type ClientConfig struct {
+	// Provider selects and configures the provider; see NewProviderFromConfig.
+	// A non-empty Provider.Model becomes the client's DefaultModel.
+	Provider ProviderConfig
+
+	// Retry configures the retry policy. Nil keeps DefaultRetryPolicy.
+	Retry *RetryConfig
+
+	// DisableRetry turns retries off entirely, overriding Retry.
+	DisableRetry bool
+
+	// Telemetry is the telemetry hook. Nil keeps the no-op hook.
+	Telemetry TelemetryHook
+}
+
+// NewClientFromConfig constructs the configured provider and returns a client
+// for it. Options in opts are applied after those derived from cfg.
+func NewClientFromConfig(cfg ClientConfig, opts ...ClientOption) (*Client, error) {
+	p, err := NewProviderFromConfig(cfg.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	var base []ClientOption
+	switch {
+	case cfg.DisableRetry:
+		base = append(base, WithRetryPolicy(noRetry{}))
+	case cfg.Retry != nil:
+		base = append(base, WithRetryPolicy(NewRetryPolicy(*cfg.Retry)))
+	}
+	if cfg.Telemetry != nil {
+		base = append(base, WithTelemetry(cfg.Telemetry))
+	}
+	if cfg.Provider.Model != "" {
+		base = append(base, DefaultModel(cfg.Provider.Model))
+	}
+
+	return NewClient(p, append(base, opts...)...), nil
+}
+
+// noRetry is a RetryPolicy that never retries.
+type noRetry struct{}
+
+func (noRetry) NextDelay(int, error) (time.Duration, bool) { return 0, false }
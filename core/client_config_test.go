@@ -0,0 +1,45 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestNewClientFromConfig(t *testing.T) {
+	provider := &mockProvider{id: "client-config-test"}
+	RegisterProvider("client-config-test", func(cfg ProviderConfig) (Provider, error) {
+		return provider, nil
+	})
+
+	hook := &repairRecorder{}
+	client, err := NewClientFromConfig(ClientConfig{
+		Provider:     ProviderConfig{Name: "client-config-test", Model: "mock-model"},
+		DisableRetry: true,
+		Telemetry:    hook,
+	})
+	if err != nil {
+		t.Fatalf("NewClientFromConfig() error = %v", err)
+	}
+	if client.telemetry != hook {
+		t.Error("telemetry hook not applied")
+	}
+	if _, ok := client.retry.NextDelay(0, ErrRateLimited); ok {
+		t.Error("retry policy retries with DisableRetry")
+	}
+
+	// The configured model is the default for Chat("").
+	if _, err := client.Chat("").User("Hi").GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if provider.lastRequest.Model != "mock-model" {
+		t.Errorf("Model = %q, want mock-model", provider.lastRequest.Model)
+	}
+}
+
+func TestNewClientFromConfigUnknownProvider(t *testing.T) {
+	_, err := NewClientFromConfig(ClientConfig{Provider: ProviderConfig{Name: "missing"}})
+	if !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("error = %v, want ErrUnknownProvider", err)
+	}
+}
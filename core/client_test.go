@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"strings"
 	"sync"
@@ -304,7 +305,8 @@ func TestGetResponseRetryOnRetryableError(t *testing.T) {
 		MaxDelay:   10 * time.Millisecond,
 		Jitter:     0,
 	})
-	c := NewClient(p, WithRetryPolicy(retry))
+	hook := &mockTelemetryHook{}
+	c := NewClient(p, WithRetryPolicy(retry), WithTelemetry(hook))
 
 	resp, err := c.Chat("gpt-4").
 		User("Hello").
@@ -319,6 +321,9 @@ func TestGetResponseRetryOnRetryableError(t *testing.T) {
 	if resp.Output != "Success" {
 		t.Errorf("Output = %v, want Success", resp.Output)
 	}
+	if len(hook.endEvents) != 1 || hook.endEvents[0].Retries != 2 {
+		t.Errorf("end events = %+v, want one event with Retries = 2", hook.endEvents)
+	}
 }
 
 func TestGetResponseNoRetryOnNonRetryableError(t *testing.T) {
@@ -1099,6 +1104,49 @@ func TestCloneIndependence(t *testing.T) {
 	}
 }
 
+func TestStoreAndMetadata(t *testing.T) {
+	client := NewClient(&mockProvider{})
+	original := client.Chat("test-model").
+		Store(false).
+		Metadata(map[string]string{"user": "u1"}).
+		Metadata(map[string]string{"session": "s1"})
+
+	if original.req.Store == nil || *original.req.Store {
+		t.Errorf("Store = %v, want false", original.req.Store)
+	}
+	if len(original.req.Metadata) != 2 || original.req.Metadata["user"] != "u1" || original.req.Metadata["session"] != "s1" {
+		t.Errorf("Metadata = %v, want merged user and session", original.req.Metadata)
+	}
+
+	clone := original.Clone()
+	*clone.req.Store = true
+	clone.req.Metadata["user"] = "u2"
+
+	if *original.req.Store {
+		t.Error("original Store changed by clone")
+	}
+	if original.req.Metadata["user"] != "u1" {
+		t.Error("original Metadata changed by clone")
+	}
+}
+
+func TestWebSearchWithOptions(t *testing.T) {
+	client := NewClient(&mockProvider{})
+	opts := WebSearchOptions{AllowedDomains: []string{"go.dev"}, ContextSize: WebSearchContextLow}
+	b := client.Chat("test-model").WebSearch().WebSearchWithOptions(opts)
+
+	tools := b.req.BuiltInTools
+	if len(tools) != 2 {
+		t.Fatalf("len(BuiltInTools) = %d, want 2", len(tools))
+	}
+	if tools[0].WebSearch != nil {
+		t.Errorf("WebSearch() options = %+v, want nil", tools[0].WebSearch)
+	}
+	if tools[1].Type != "web_search" || tools[1].WebSearch == nil || tools[1].WebSearch.ContextSize != WebSearchContextLow {
+		t.Errorf("WebSearchWithOptions tool = %+v", tools[1])
+	}
+}
+
 func TestCloneReuse(t *testing.T) {
 	callCount := 0
 	provider := &mockProvider{
@@ -1556,3 +1604,69 @@ func TestCloneWithResponseFormatJSON(t *testing.T) {
 		t.Errorf("clone.ResponseFormat = %v, want %v", clone.req.ResponseFormat, ResponseFormatJSON)
 	}
 }
+
+func TestToolResultPolicyClientDefaultAndOverride(t *testing.T) {
+	provider := &mockProvider{id: "test"}
+	client := NewClient(provider, WithToolResultPolicy(ToolResultPolicy{MaxBytes: 100}))
+
+	b := client.Chat("gpt-4").User("Test")
+	if b.req.ToolResultPolicy == nil || b.req.ToolResultPolicy.MaxBytes != 100 {
+		t.Fatalf("client default policy not applied: %+v", b.req.ToolResultPolicy)
+	}
+
+	b.ToolResultPolicy(ToolResultPolicy{Format: ToolResultFormatJSON})
+	if b.req.ToolResultPolicy.Format != ToolResultFormatJSON || b.req.ToolResultPolicy.MaxBytes != 0 {
+		t.Errorf("builder override not applied: %+v", b.req.ToolResultPolicy)
+	}
+
+	// Builders must not share the client's policy pointer.
+	other := client.Chat("gpt-4")
+	if other.req.ToolResultPolicy.MaxBytes != 100 {
+		t.Errorf("override leaked into other builders: %+v", other.req.ToolResultPolicy)
+	}
+
+	clone := b.Clone()
+	clone.req.ToolResultPolicy.MaxBytes = 5
+	if b.req.ToolResultPolicy.MaxBytes == 5 {
+		t.Error("modifying clone's ToolResultPolicy affected original")
+	}
+}
+
+func TestMessageBuilderImageBytesAndAudio(t *testing.T) {
+	provider := &mockProvider{id: "test"}
+	client := NewClient(provider)
+
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	builder := client.Chat("test-model").
+		UserMultimodal().
+		Text("Describe these").
+		ImageBytes(png, "").
+		ImageBytes([]byte{1, 2, 3}, "image/webp").
+		Audio([]byte("RIFF"), AudioFormatWAV).
+		AudioBase64("SUQz", AudioFormatMP3).
+		Done()
+
+	parts := builder.req.Messages[0].Parts
+	if len(parts) != 5 {
+		t.Fatalf("Parts length = %d, want 5", len(parts))
+	}
+
+	img := parts[1].(*InputImage)
+	if img.ImageURL != "data:image/png;base64,"+base64.StdEncoding.EncodeToString(png) {
+		t.Errorf("detected ImageURL = %q", img.ImageURL)
+	}
+	if got := parts[2].(*InputImage).ImageURL; got != "data:image/webp;base64,AQID" {
+		t.Errorf("explicit ImageURL = %q", got)
+	}
+
+	audio, ok := parts[3].(*InputAudio)
+	if !ok {
+		t.Fatalf("Parts[3] is not *InputAudio, got %T", parts[3])
+	}
+	if audio.Data != "UklGRg==" || audio.Format != AudioFormatWAV {
+		t.Errorf("audio = %+v", audio)
+	}
+	if mp3 := parts[4].(*InputAudio); mp3.Data != "SUQz" || mp3.Format != AudioFormatMP3 {
+		t.Errorf("mp3 = %+v", mp3)
+	}
+}
@@ -0,0 +1,116 @@
+package core
+
+// ComputerEnvironment is the kind of machine a computer-use model controls.
+type ComputerEnvironment string
+
+const (
+	ComputerEnvironmentBrowser ComputerEnvironment = "browser"
+	ComputerEnvironmentMac     ComputerEnvironment = "mac"
+	ComputerEnvironmentWindows ComputerEnvironment = "windows"
+	ComputerEnvironmentUbuntu  ComputerEnvironment = "ubuntu"
+	ComputerEnvironmentLinux   ComputerEnvironment = "linux"
+)
+
+// ComputerUseConfig describes the display a computer-use model controls.
+type ComputerUseConfig struct {
+	DisplayWidth  int                 `json:"display_width"`
+	DisplayHeight int                 `json:"display_height"`
+	Environment   ComputerEnvironment `json:"environment"`
+}
+
+// ComputerUse adds the computer_use built-in tool. The model answers with
+// ComputerCalls instead of text; perform each action, take a screenshot,
+// and send it back with ComputerResult on a request chained with
+// ContinueFrom, until the response has no more computer calls.
+func (b *ChatBuilder) ComputerUse(cfg ComputerUseConfig) *ChatBuilder {
+	b.req.BuiltInTools = append(b.req.BuiltInTools, BuiltInTool{Type: "computer_use", Computer: &cfg})
+	return b
+}
+
+// ComputerResult adds the outcome of computer calls as a tool message.
+func (b *ChatBuilder) ComputerResult(results ...ComputerCallOutput) *ChatBuilder {
+	b.req.Messages = append(b.req.Messages, Message{Role: RoleTool, ComputerResults: results})
+	return b
+}
+
+// ComputerActionType identifies a computer action.
+type ComputerActionType string
+
+const (
+	ComputerActionClick       ComputerActionType = "click"
+	ComputerActionDoubleClick ComputerActionType = "double_click"
+	ComputerActionDrag        ComputerActionType = "drag"
+	ComputerActionKeypress    ComputerActionType = "keypress"
+	ComputerActionMove        ComputerActionType = "move"
+	ComputerActionScreenshot  ComputerActionType = "screenshot"
+	ComputerActionScroll      ComputerActionType = "scroll"
+	ComputerActionTypeText    ComputerActionType = "type" // types ComputerAction.Text
+	ComputerActionWait        ComputerActionType = "wait"
+)
+
+// ComputerPoint is a screen position in pixels.
+type ComputerPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// ComputerAction is a mouse, keyboard, or screenshot action requested by
+// the model. Which fields are set depends on Type.
+type ComputerAction struct {
+	Type ComputerActionType `json:"type"`
+
+	// Pointer position for click, double_click, move, and scroll
+	X int `json:"x,omitempty"`
+	Y int `json:"y,omitempty"`
+
+	// Button for click: left, right, wheel, back, or forward
+	Button string `json:"button,omitempty"`
+
+	// Scroll distance in pixels
+	ScrollX int `json:"scroll_x,omitempty"`
+	ScrollY int `json:"scroll_y,omitempty"`
+
+	// Text for type
+	Text string `json:"text,omitempty"`
+
+	// Keys pressed together for keypress, such as ["CTRL", "C"]
+	Keys []string `json:"keys,omitempty"`
+
+	// Path for drag, from start to end
+	Path []ComputerPoint `json:"path,omitempty"`
+}
+
+// ComputerSafetyCheck is a warning the provider attaches to a computer call,
+// such as a suspected prompt injection. Pending checks must be acknowledged
+// in the ComputerCallOutput to proceed.
+type ComputerSafetyCheck struct {
+	ID      string `json:"id"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// ComputerCall is an action the model asks the caller to perform.
+type ComputerCall struct {
+	ID                  string                `json:"id,omitempty"`
+	CallID              string                `json:"call_id"`
+	Action              ComputerAction        `json:"action"`
+	PendingSafetyChecks []ComputerSafetyCheck `json:"pending_safety_checks,omitempty"`
+}
+
+// ComputerCallOutput reports the screen after a computer call was performed.
+type ComputerCallOutput struct {
+	// CallID must match ComputerCall.CallID.
+	CallID string `json:"call_id"`
+
+	// Screenshot is a data URL (data:image/png;base64,...) or HTTPS URL of
+	// the screen after the action.
+	Screenshot string `json:"screenshot"`
+
+	// AcknowledgedSafetyChecks lists the pending safety checks of the call
+	// the user has approved.
+	AcknowledgedSafetyChecks []ComputerSafetyCheck `json:"acknowledged_safety_checks,omitempty"`
+
+	// CurrentURL is the page shown in a browser environment, used by the
+	// provider's safety checks.
+	CurrentURL string `json:"current_url,omitempty"`
+}
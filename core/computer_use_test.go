@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestComputerUseRoundTrip(t *testing.T) {
+	provider := &mockProvider{
+		id: "mock",
+		chatFunc: func(_ context.Context, req *ChatRequest) (*ChatResponse, error) {
+			if req.PreviousResponseID == "" {
+				return &ChatResponse{ID: "resp_1", ComputerCalls: []ComputerCall{{
+					CallID: "call_1",
+					Action: ComputerAction{Type: ComputerActionScreenshot},
+				}}}, nil
+			}
+			return &ChatResponse{ID: "resp_2", Output: "done"}, nil
+		},
+	}
+	client := NewClient(provider)
+	cfg := ComputerUseConfig{DisplayWidth: 1024, DisplayHeight: 768, Environment: ComputerEnvironmentBrowser}
+
+	resp, err := client.Chat("mock-model").ComputerUse(cfg).User("Open example.com").GetResponse(context.Background())
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	tools := provider.lastRequest.BuiltInTools
+	if len(tools) != 1 || tools[0].Type != "computer_use" || *tools[0].Computer != cfg {
+		t.Errorf("BuiltInTools = %+v", tools)
+	}
+	if len(resp.ComputerCalls) != 1 {
+		t.Fatalf("len(ComputerCalls) = %d, want 1", len(resp.ComputerCalls))
+	}
+
+	resp, err = client.Chat("mock-model").
+		ComputerUse(cfg).
+		ContinueFrom(resp.ID).
+		ComputerResult(ComputerCallOutput{CallID: resp.ComputerCalls[0].CallID, Screenshot: "data:image/png;base64,AAAA"}).
+		GetResponse(context.Background())
+	if err != nil {
+		t.Fatalf("GetResponse() with ComputerResult error = %v", err)
+	}
+	if resp.Output != "done" {
+		t.Errorf("Output = %q, want done", resp.Output)
+	}
+	msgs := provider.lastRequest.Messages
+	if len(msgs) != 1 || msgs[0].Role != RoleTool || msgs[0].ComputerResults[0].CallID != "call_1" {
+		t.Errorf("Messages = %+v", msgs)
+	}
+}
+
+func TestComputerResultClone(t *testing.T) {
+	client := NewClient(&mockProvider{})
+	original := client.Chat("test-model").ComputerResult(ComputerCallOutput{CallID: "call_1", Screenshot: "a"})
+
+	clone := original.Clone()
+	clone.req.Messages[0].ComputerResults[0].Screenshot = "b"
+
+	if original.req.Messages[0].ComputerResults[0].Screenshot != "a" {
+		t.Error("original ComputerResults changed by clone")
+	}
+	if original.req.Fingerprint() == clone.req.Fingerprint() {
+		t.Error("fingerprint should cover computer results")
+	}
+}
+
+func TestCaptureRedactsComputerUse(t *testing.T) {
+	cp := &capture{redactContent: true, patterns: DefaultRedactPatterns}
+	req := cp.redactRequest(&ChatRequest{Messages: []Message{{
+		Role:            RoleTool,
+		ComputerResults: []ComputerCallOutput{{CallID: "call_1", Screenshot: "data:image/png;base64,AAAA", CurrentURL: "https://example.com"}},
+	}}})
+	if got := req.Messages[0].ComputerResults[0]; got.Screenshot != Redacted || got.CurrentURL != Redacted || got.CallID != "call_1" {
+		t.Errorf("ComputerResults = %+v", got)
+	}
+
+	resp := cp.redactResponse(&ChatResponse{ComputerCalls: []ComputerCall{{
+		CallID: "call_1",
+		Action: ComputerAction{Type: ComputerActionTypeText, Text: "hunter2"},
+	}}})
+	if got := resp.ComputerCalls[0].Action; got.Text != Redacted || strings.Contains(got.Text, "hunter2") {
+		t.Errorf("Action = %+v", got)
+	}
+}
@@ -0,0 +1,196 @@
+package core
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+	"time"
+)
+
+// Priority orders requests waiting for a slot under WithConcurrencyLimit.
+// Higher priorities are served first; requests of equal priority are served
+// in arrival order. Any int value may be used.
+type Priority int
+
+// Common priorities.
+const (
+	PriorityLow    Priority = -1
+	PriorityNormal Priority = 0
+	PriorityHigh   Priority = 1
+)
+
+// WithConcurrencyLimit caps the number of chats and streams the client runs
+// against its provider at once. Further requests queue by priority (see
+// ChatBuilder.Priority) until a slot frees up or their context ends, so
+// bursts wait instead of overwhelming a local server or tripping provider
+// rate limits. A stream holds its slot until it completes. n <= 0 means no
+// limit.
+//
+// Queue time counts against the request's Timeout. Hooks implementing
+// QueueTelemetryHook receive a QueueEvent for every request that passes
+// through the limiter.
+func WithConcurrencyLimit(n int) ClientOption {
+	return func(c *Client) {
+		if n > 0 {
+			c.limiter = &limiter{limit: n}
+		} else {
+			c.limiter = nil
+		}
+	}
+}
+
+// Priority sets the request's place in the queue when the client has a
+// concurrency limit. The default is PriorityNormal.
+func (b *ChatBuilder) Priority(p Priority) *ChatBuilder {
+	b.priority = p
+	return b
+}
+
+// QueueTelemetryHook is an optional extension of TelemetryHook for the
+// concurrency limiter. When the client has a limit and its hook implements
+// it, every request reports a QueueEvent once it gets a slot or gives up
+// waiting.
+type QueueTelemetryHook interface {
+	TelemetryHook
+
+	// OnQueueWait is called when a request leaves the queue.
+	OnQueueWait(e QueueEvent)
+}
+
+// QueueEvent describes the time a request spent waiting for a concurrency
+// slot.
+//
+// # Security
+//
+// Like the request events, it contains only timing and counts.
+type QueueEvent struct {
+	Provider string        // Provider identifier
+	Model    ModelID       // Model of the request
+	Priority Priority      // Priority of the request
+	Depth    int           // Requests waiting, including this one, when it was queued; zero if it ran at once
+	InFlight int           // Requests holding a slot when this one arrived
+	Wait     time.Duration // Time spent waiting for the slot
+	Err      error         // Context error if the request gave up waiting, nil otherwise
+}
+
+// acquire waits for a concurrency slot and returns the function that frees
+// it. Without a limit it returns at once.
+func (c *Client) acquire(ctx context.Context, model ModelID, p Priority) (func(), error) {
+	if c.limiter == nil {
+		return func() {}, nil
+	}
+	start := time.Now()
+	depth, inFlight, err := c.limiter.acquire(ctx, p)
+	if h, ok := c.telemetry.(QueueTelemetryHook); ok {
+		h.OnQueueWait(QueueEvent{
+			Provider: c.provider.ID(),
+			Model:    model,
+			Priority: p,
+			Depth:    depth,
+			InFlight: inFlight,
+			Wait:     time.Since(start),
+			Err:      err,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sync.OnceFunc(c.limiter.release), nil
+}
+
+// limiter is a counting semaphore whose waiters are served by priority.
+type limiter struct {
+	mu      sync.Mutex
+	limit   int
+	active  int
+	waiters waitQueue
+	seq     uint64
+}
+
+// acquire takes a slot, waiting if none is free. It reports the queue depth
+// and active slots seen on arrival.
+func (l *limiter) acquire(ctx context.Context, p Priority) (depth, inFlight int, err error) {
+	l.mu.Lock()
+	inFlight = l.active
+	if l.active < l.limit && len(l.waiters) == 0 {
+		l.active++
+		l.mu.Unlock()
+		return 0, inFlight, nil
+	}
+	l.seq++
+	w := &waiter{priority: p, seq: l.seq, ready: make(chan struct{})}
+	heap.Push(&l.waiters, w)
+	depth = len(l.waiters)
+	l.mu.Unlock()
+
+	select {
+	case <-w.ready:
+		return depth, inFlight, nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		granted := w.index < 0
+		if !granted {
+			heap.Remove(&l.waiters, w.index)
+		}
+		l.mu.Unlock()
+		if granted {
+			// The slot was handed over as ctx ended; pass it on
+			l.release()
+		}
+		return depth, inFlight, ctx.Err()
+	}
+}
+
+// release frees a slot, handing it to the highest priority waiter if any.
+func (l *limiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.waiters) > 0 {
+		w := heap.Pop(&l.waiters).(*waiter)
+		close(w.ready)
+		return
+	}
+	l.active--
+}
+
+// waiter is a request queued for a slot. index is its position in the
+// queue, or -1 once it has been granted a slot.
+type waiter struct {
+	priority Priority
+	seq      uint64
+	ready    chan struct{}
+	index    int
+}
+
+// waitQueue is a heap of waiters ordered by priority, then arrival.
+type waitQueue []*waiter
+
+func (q waitQueue) Len() int { return len(q) }
+
+func (q waitQueue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q waitQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *waitQueue) Push(x any) {
+	w := x.(*waiter)
+	w.index = len(*q)
+	*q = append(*q, w)
+}
+
+func (q *waitQueue) Pop() any {
+	old := *q
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	w.index = -1
+	*q = old[:len(old)-1]
+	return w
+}
@@ -0,0 +1,183 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+type queueHook struct {
+	NoopTelemetryHook
+	mu     sync.Mutex
+	events []QueueEvent
+}
+
+func (h *queueHook) OnQueueWait(e QueueEvent) {
+	h.mu.Lock()
+	h.events = append(h.events, e)
+	h.mu.Unlock()
+}
+
+func TestConcurrencyLimit(t *testing.T) {
+	var mu sync.Mutex
+	active, peak := 0, 0
+	provider := &mockProvider{
+		id: "mock",
+		chatFunc: func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+			mu.Lock()
+			active++
+			peak = max(peak, active)
+			mu.Unlock()
+			time.Sleep(5 * time.Millisecond)
+			mu.Lock()
+			active--
+			mu.Unlock()
+			return &ChatResponse{}, nil
+		},
+	}
+	hook := &queueHook{}
+	client := NewClient(provider, WithConcurrencyLimit(2), WithTelemetry(hook))
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.Chat("mock-model").User("Hi").GetResponse(context.Background()); err != nil {
+				t.Errorf("GetResponse() error = %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if peak != 2 {
+		t.Errorf("peak concurrency = %d, want 2", peak)
+	}
+	if len(hook.events) != 8 {
+		t.Fatalf("queue events = %d, want 8", len(hook.events))
+	}
+	queued := 0
+	for _, e := range hook.events {
+		if e.Provider != "mock" || e.Model != "mock-model" || e.Err != nil {
+			t.Errorf("event = %+v", e)
+		}
+		if e.Depth > 0 {
+			queued++
+		}
+	}
+	if queued == 0 {
+		t.Error("no request was queued")
+	}
+}
+
+func TestConcurrencyLimitPriority(t *testing.T) {
+	l := &limiter{limit: 1}
+	if _, _, err := l.acquire(context.Background(), PriorityNormal); err != nil {
+		t.Fatalf("acquire() error = %v", err)
+	}
+
+	var mu sync.Mutex
+	var order []Priority
+	var wg sync.WaitGroup
+	for i, p := range []Priority{PriorityLow, PriorityNormal, PriorityHigh} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := l.acquire(context.Background(), p); err != nil {
+				t.Errorf("acquire() error = %v", err)
+				return
+			}
+			mu.Lock()
+			order = append(order, p)
+			mu.Unlock()
+			l.release()
+		}()
+		// Queue in a known order
+		waitFor(t, func() bool {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+			return len(l.waiters) == i+1
+		})
+	}
+
+	l.release()
+	wg.Wait()
+
+	want := []Priority{PriorityHigh, PriorityNormal, PriorityLow}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+	if l.active != 0 {
+		t.Errorf("active = %d, want 0", l.active)
+	}
+}
+
+func TestConcurrencyLimitContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	provider := &mockProvider{
+		id: "mock",
+		streamFunc: func(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+			<-release
+			return newTestStream(nil, &ChatResponse{}, nil), nil
+		},
+	}
+	hook := &queueHook{}
+	client := NewClient(provider, WithConcurrencyLimit(1), WithTelemetry(hook))
+
+	streamCh := make(chan *ChatStream, 1)
+	go func() {
+		stream, err := client.Chat("mock-model").User("Hi").Stream(context.Background())
+		if err != nil {
+			t.Errorf("Stream() error = %v", err)
+		}
+		streamCh <- stream
+	}()
+	waitFor(t, func() bool {
+		client.limiter.mu.Lock()
+		defer client.limiter.mu.Unlock()
+		return client.limiter.active == 1
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.Chat("mock-model").User("Hi").Priority(PriorityHigh).GetResponse(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("GetResponse() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	close(release)
+	if _, err := DrainStream(context.Background(), <-streamCh); err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+	// The stream frees its slot once it completes
+	waitFor(t, func() bool {
+		client.limiter.mu.Lock()
+		defer client.limiter.mu.Unlock()
+		return client.limiter.active == 0 && len(client.limiter.waiters) == 0
+	})
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.events) != 2 {
+		t.Fatalf("queue events = %d, want 2", len(hook.events))
+	}
+	if e := hook.events[1]; !errors.Is(e.Err, context.DeadlineExceeded) || e.Depth != 1 || e.InFlight != 1 || e.Priority != PriorityHigh {
+		t.Errorf("canceled event = %+v", e)
+	}
+}
+
+// waitFor polls cond until it holds or a second passes.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
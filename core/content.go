@@ -63,3 +63,40 @@ type InputFile struct {
 func (f InputFile) ContentType() string {
 	return "input_file"
 }
+
+// AudioFormat identifies the encoding of audio input.
+type AudioFormat string
+
+const (
+	// AudioFormatWAV is WAV (RIFF) audio.
+	AudioFormatWAV AudioFormat = "wav"
+	// AudioFormatMP3 is MP3 audio.
+	AudioFormatMP3 AudioFormat = "mp3"
+)
+
+// MimeType returns the MIME type for the audio format.
+func (f AudioFormat) MimeType() string {
+	switch f {
+	case AudioFormatWAV:
+		return "audio/wav"
+	case AudioFormatMP3:
+		return "audio/mpeg"
+	default:
+		return "audio/" + string(f)
+	}
+}
+
+// InputAudio represents audio content in a multimodal message.
+// It is supported by audio-capable chat models such as gpt-4o-audio-preview
+// and Gemini.
+type InputAudio struct {
+	// Data contains base64-encoded audio bytes.
+	Data string
+	// Format is the audio encoding.
+	Format AudioFormat
+}
+
+// ContentType returns the type identifier for InputAudio.
+func (a InputAudio) ContentType() string {
+	return "input_audio"
+}
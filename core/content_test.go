@@ -165,3 +165,23 @@ func TestMessageBackwardCompatibility(t *testing.T) {
 		t.Errorf("Parts should be nil for simple text messages, got %v", msg.Parts)
 	}
 }
+
+func TestInputAudioContentType(t *testing.T) {
+	audio := InputAudio{Data: "UklGRg==", Format: AudioFormatWAV}
+	if got := audio.ContentType(); got != "input_audio" {
+		t.Errorf("InputAudio.ContentType() = %q, want %q", got, "input_audio")
+	}
+}
+
+func TestAudioFormatMimeType(t *testing.T) {
+	tests := map[AudioFormat]string{
+		AudioFormatWAV: "audio/wav",
+		AudioFormatMP3: "audio/mpeg",
+		"flac":         "audio/flac",
+	}
+	for format, want := range tests {
+		if got := format.MimeType(); got != want {
+			t.Errorf("%q.MimeType() = %q, want %q", format, got, want)
+		}
+	}
+}
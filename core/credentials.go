@@ -0,0 +1,157 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CredentialProvider supplies the API key for each provider request, so keys
+// can rotate without restarting the process. Implementations must be safe
+// for concurrent use and should be fast: providers call Credential before
+// every request, so slow sources should be wrapped with CachedCredential.
+type CredentialProvider interface {
+	Credential(ctx context.Context) (Secret, error)
+}
+
+// CredentialRefresher is implemented by credential providers that can fetch
+// a new credential on demand. When an API rejects a key with 401, providers
+// call Refresh and retry the request once if Credential then returns a
+// different key.
+type CredentialRefresher interface {
+	CredentialProvider
+	Refresh(ctx context.Context) error
+}
+
+// CredentialFunc adapts a function to a CredentialProvider.
+type CredentialFunc func(ctx context.Context) (Secret, error)
+
+// Credential calls f.
+func (f CredentialFunc) Credential(ctx context.Context) (Secret, error) {
+	return f(ctx)
+}
+
+// StaticCredential returns a CredentialProvider that always returns key.
+func StaticCredential(key Secret) CredentialProvider {
+	return CredentialFunc(func(context.Context) (Secret, error) {
+		return key, nil
+	})
+}
+
+// EnvCredential returns a CredentialProvider that reads the first non-empty
+// of the given environment variables on every call. It returns an error
+// wrapping ErrNoCredential if all of them are empty.
+func EnvCredential(envVars ...string) CredentialRefresher {
+	return envCredential(envVars)
+}
+
+type envCredential []string
+
+func (e envCredential) Credential(context.Context) (Secret, error) {
+	for _, name := range e {
+		if v := os.Getenv(name); v != "" {
+			return NewSecret(v), nil
+		}
+	}
+	return Secret{}, fmt.Errorf("%w: environment variable %s not set", ErrNoCredential, strings.Join(e, " or "))
+}
+
+// Refresh does nothing: the environment is read on every call.
+func (e envCredential) Refresh(context.Context) error { return nil }
+
+// FileCredential returns a CredentialProvider that reads the key from the
+// file at path, trimming surrounding whitespace. The file is read again
+// whenever its size or modification time changes, so keys mounted from a
+// secret store (such as a Kubernetes secret volume) rotate without a
+// restart.
+func FileCredential(path string) CredentialRefresher {
+	return &fileCredential{path: path}
+}
+
+type fileCredential struct {
+	path string
+
+	mu      sync.Mutex
+	key     Secret
+	size    int64
+	modTime time.Time
+}
+
+func (f *fileCredential) Credential(context.Context) (Secret, error) {
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return Secret{}, fmt.Errorf("%w: %v", ErrNoCredential, err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if !f.key.IsEmpty() && info.Size() == f.size && info.ModTime().Equal(f.modTime) {
+		return f.key, nil
+	}
+
+	data, err := os.ReadFile(f.path)
+	if err != nil {
+		return Secret{}, fmt.Errorf("%w: %v", ErrNoCredential, err)
+	}
+	key := string(bytes.TrimSpace(data))
+	if key == "" {
+		return Secret{}, fmt.Errorf("%w: %s is empty", ErrNoCredential, f.path)
+	}
+	f.key, f.size, f.modTime = NewSecret(key), info.Size(), info.ModTime()
+	return f.key, nil
+}
+
+// Refresh makes the next call read the file even if it looks unchanged.
+func (f *fileCredential) Refresh(context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.key = Secret{}
+	return nil
+}
+
+// CachedCredential caches the credentials returned by p for ttl, so slow
+// sources such as cloud secret managers are not called on every request.
+// Refresh discards the cached credential. Concurrent callers share one
+// fetch.
+func CachedCredential(p CredentialProvider, ttl time.Duration) CredentialRefresher {
+	return &cachedCredential{provider: p, ttl: ttl}
+}
+
+type cachedCredential struct {
+	provider CredentialProvider
+	ttl      time.Duration
+
+	mu      sync.Mutex
+	key     Secret
+	fetched time.Time
+}
+
+func (c *cachedCredential) Credential(ctx context.Context) (Secret, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.key.IsEmpty() && time.Since(c.fetched) < c.ttl {
+		return c.key, nil
+	}
+	key, err := c.provider.Credential(ctx)
+	if err != nil {
+		return Secret{}, err
+	}
+	c.key, c.fetched = key, time.Now()
+	return key, nil
+}
+
+// Refresh discards the cached credential and refreshes the underlying
+// provider if it supports it.
+func (c *cachedCredential) Refresh(ctx context.Context) error {
+	c.mu.Lock()
+	c.key = Secret{}
+	c.mu.Unlock()
+	if r, ok := c.provider.(CredentialRefresher); ok {
+		return r.Refresh(ctx)
+	}
+	return nil
+}
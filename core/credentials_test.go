@@ -0,0 +1,130 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStaticCredential(t *testing.T) {
+	key, err := StaticCredential(NewSecret("sk-static")).Credential(context.Background())
+	if err != nil || key.Expose() != "sk-static" {
+		t.Errorf("Credential() = %q, %v", key.Expose(), err)
+	}
+}
+
+func TestEnvCredential(t *testing.T) {
+	creds := EnvCredential("IRIS_TEST_KEY_A", "IRIS_TEST_KEY_B")
+
+	t.Setenv("IRIS_TEST_KEY_A", "")
+	t.Setenv("IRIS_TEST_KEY_B", "")
+	if _, err := creds.Credential(context.Background()); !errors.Is(err, ErrNoCredential) {
+		t.Errorf("Credential() error = %v, want ErrNoCredential", err)
+	}
+
+	t.Setenv("IRIS_TEST_KEY_B", "sk-b")
+	if key, _ := creds.Credential(context.Background()); key.Expose() != "sk-b" {
+		t.Errorf("Credential() = %q, want sk-b", key.Expose())
+	}
+
+	// Read on every call, so changes apply without a refresh
+	t.Setenv("IRIS_TEST_KEY_A", "sk-a")
+	if key, _ := creds.Credential(context.Background()); key.Expose() != "sk-a" {
+		t.Errorf("Credential() = %q, want sk-a", key.Expose())
+	}
+}
+
+func TestFileCredential(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key")
+	creds := FileCredential(path)
+	ctx := context.Background()
+
+	if _, err := creds.Credential(ctx); !errors.Is(err, ErrNoCredential) {
+		t.Errorf("missing file error = %v, want ErrNoCredential", err)
+	}
+
+	if err := os.WriteFile(path, []byte("sk-one\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if key, err := creds.Credential(ctx); err != nil || key.Expose() != "sk-one" {
+		t.Fatalf("Credential() = %q, %v", key.Expose(), err)
+	}
+
+	// A rotated file is picked up by its new size and modification time
+	if err := os.WriteFile(path, []byte("sk-rotated\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := creds.Credential(ctx); key.Expose() != "sk-rotated" {
+		t.Errorf("Credential() = %q, want sk-rotated", key.Expose())
+	}
+
+	if err := os.WriteFile(path, []byte("  \n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := creds.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := creds.Credential(ctx); !errors.Is(err, ErrNoCredential) {
+		t.Errorf("empty file error = %v, want ErrNoCredential", err)
+	}
+}
+
+func TestCachedCredential(t *testing.T) {
+	calls := 0
+	source := CredentialFunc(func(context.Context) (Secret, error) {
+		calls++
+		if calls == 3 {
+			return Secret{}, errors.New("secret manager unavailable")
+		}
+		return NewSecret("sk-" + string(rune('0'+calls))), nil
+	})
+	creds := CachedCredential(source, time.Hour)
+	ctx := context.Background()
+
+	for range 3 {
+		if key, _ := creds.Credential(ctx); key.Expose() != "sk-1" {
+			t.Fatalf("Credential() = %q, want cached sk-1", key.Expose())
+		}
+	}
+	if calls != 1 {
+		t.Errorf("source called %d times, want 1", calls)
+	}
+
+	if err := creds.Refresh(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if key, _ := creds.Credential(ctx); key.Expose() != "sk-2" {
+		t.Errorf("Credential() after Refresh = %q, want sk-2", key.Expose())
+	}
+
+	// Errors are not cached
+	creds.Refresh(ctx)
+	if _, err := creds.Credential(ctx); err == nil {
+		t.Error("expected source error")
+	}
+	if key, _ := creds.Credential(ctx); key.Expose() != "sk-4" {
+		t.Errorf("Credential() after error = %q, want sk-4", key.Expose())
+	}
+}
+
+func TestCachedCredentialExpires(t *testing.T) {
+	calls := 0
+	creds := CachedCredential(CredentialFunc(func(context.Context) (Secret, error) {
+		calls++
+		return NewSecret("sk"), nil
+	}), time.Nanosecond)
+
+	creds.Credential(context.Background())
+	time.Sleep(time.Millisecond)
+	creds.Credential(context.Background())
+	if calls != 2 {
+		t.Errorf("source called %d times, want 2 after the TTL", calls)
+	}
+}
@@ -0,0 +1,139 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// deadlineSafetyFactor leaves headroom between the estimated generation time
+// and the deadline.
+const deadlineSafetyFactor = 0.9
+
+// throughputAlpha weighs the newest observation in the moving average.
+const throughputAlpha = 0.2
+
+// ThroughputEstimator learns the output throughput (completion tokens per
+// second) of each provider and model from completed requests. Throughput is
+// measured over the whole request, so it includes connection and queueing
+// latency and errs on the side of fewer tokens.
+//
+// It implements TelemetryHook, so an estimator can also be fed by clients
+// that do not use it to cap MaxTokens. It is safe for concurrent use.
+type ThroughputEstimator struct {
+	mu    sync.Mutex
+	rates map[throughputKey]float64
+}
+
+type throughputKey struct {
+	provider string
+	model    ModelID
+}
+
+// NewThroughputEstimator returns an estimator with no observations.
+func NewThroughputEstimator() *ThroughputEstimator {
+	return &ThroughputEstimator{rates: make(map[throughputKey]float64)}
+}
+
+// Observe records a completed request. Failed requests and requests without
+// completion tokens are ignored.
+func (e *ThroughputEstimator) Observe(ev RequestEndEvent) {
+	d := ev.Duration()
+	if ev.Err != nil || ev.Usage.CompletionTokens <= 0 || d <= 0 {
+		return
+	}
+	rate := float64(ev.Usage.CompletionTokens) / d.Seconds()
+	key := throughputKey{ev.Provider, ev.Model}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if prev, ok := e.rates[key]; ok {
+		rate = throughputAlpha*rate + (1-throughputAlpha)*prev
+	}
+	e.rates[key] = rate
+}
+
+// SetTokensPerSecond seeds the throughput of a provider and model, for
+// example from a previous run. Later observations refine it.
+func (e *ThroughputEstimator) SetTokensPerSecond(provider string, model ModelID, rate float64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.rates[throughputKey{provider, model}] = rate
+}
+
+// TokensPerSecond returns the estimated throughput of a provider and model,
+// and whether there is an estimate.
+func (e *ThroughputEstimator) TokensPerSecond(provider string, model ModelID) (float64, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	rate, ok := e.rates[throughputKey{provider, model}]
+	return rate, ok
+}
+
+// OnRequestStart implements TelemetryHook.
+func (e *ThroughputEstimator) OnRequestStart(RequestStartEvent) {}
+
+// OnRequestEnd implements TelemetryHook by calling Observe.
+func (e *ThroughputEstimator) OnRequestEnd(ev RequestEndEvent) {
+	e.Observe(ev)
+}
+
+// Compile-time check that ThroughputEstimator implements TelemetryHook.
+var _ TelemetryHook = (*ThroughputEstimator)(nil)
+
+// WithDeadlineAwareMaxTokens caps MaxTokens of requests whose context has a
+// deadline to the number of tokens est expects the model to generate before
+// it, with a 10% margin. A smaller MaxTokens set on the request is kept.
+// Requests to models without an estimate are sent unchanged; the client
+// feeds est with every completed request, so estimates appear after the
+// first successful call.
+//
+// A request whose deadline leaves room for less than one token fails with
+// ErrDeadlineTooShort instead of being sent.
+func WithDeadlineAwareMaxTokens(est *ThroughputEstimator) ClientOption {
+	return func(c *Client) {
+		c.throughput = est
+	}
+}
+
+// observeThroughput feeds the client's estimator, if any.
+func (c *Client) observeThroughput(ev RequestEndEvent) {
+	if c.throughput != nil {
+		c.throughput.Observe(ev)
+	}
+}
+
+// capMaxTokens returns req with MaxTokens capped to what can be generated
+// before the context deadline. req is copied before it is changed.
+func (c *Client) capMaxTokens(ctx context.Context, req *ChatRequest) (*ChatRequest, error) {
+	if c.throughput == nil {
+		return req, nil
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return req, nil
+	}
+	rate, ok := c.throughput.TokensPerSecond(c.provider.ID(), req.Model)
+	if !ok || rate <= 0 {
+		return req, nil
+	}
+
+	remaining := time.Until(deadline)
+	limit := math.Floor(remaining.Seconds() * rate * deadlineSafetyFactor)
+	if limit < 1 {
+		return nil, fmt.Errorf("%w: %v left at %.1f tokens/s", ErrDeadlineTooShort, remaining.Round(time.Millisecond), rate)
+	}
+	if limit > math.MaxInt32 {
+		return req, nil
+	}
+	capped := int(limit)
+	if req.MaxTokens != nil && *req.MaxTokens <= capped {
+		return req, nil
+	}
+
+	r := *req
+	r.MaxTokens = &capped
+	return &r, nil
+}
@@ -0,0 +1,110 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestThroughputEstimatorObserve(t *testing.T) {
+	est := NewThroughputEstimator()
+	start := time.Now()
+
+	est.Observe(RequestEndEvent{Provider: "p", Model: "m", Start: start, End: start.Add(2 * time.Second),
+		Usage: TokenUsage{CompletionTokens: 100}})
+	if rate, ok := est.TokensPerSecond("p", "m"); !ok || rate != 50 {
+		t.Fatalf("TokensPerSecond = %v, %v, want 50", rate, ok)
+	}
+
+	// Failures are ignored; later observations are averaged in
+	est.Observe(RequestEndEvent{Provider: "p", Model: "m", Start: start, End: start.Add(time.Second),
+		Err: errors.New("boom")})
+	est.Observe(RequestEndEvent{Provider: "p", Model: "m", Start: start, End: start.Add(time.Second),
+		Usage: TokenUsage{CompletionTokens: 100}})
+	if rate, _ := est.TokensPerSecond("p", "m"); rate != 60 {
+		t.Errorf("TokensPerSecond = %v, want 60", rate)
+	}
+
+	if _, ok := est.TokensPerSecond("p", "other"); ok {
+		t.Error("unexpected estimate for unobserved model")
+	}
+}
+
+func TestDeadlineAwareMaxTokens(t *testing.T) {
+	p := &mockProvider{id: "test"}
+	est := NewThroughputEstimator()
+	est.SetTokensPerSecond("test", "gpt-4", 100)
+	c := NewClient(p, WithDeadlineAwareMaxTokens(est))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	b := c.Chat("gpt-4").User("Hello").MaxTokens(4000)
+	if _, err := b.GetResponse(ctx); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	got := p.lastRequest.MaxTokens
+	if got == nil || *got > 180 || *got < 150 {
+		t.Errorf("MaxTokens = %v, want about 180", got)
+	}
+	if *b.req.MaxTokens != 4000 {
+		t.Errorf("builder MaxTokens changed to %d", *b.req.MaxTokens)
+	}
+
+	// A smaller limit is kept
+	if _, err := c.Chat("gpt-4").User("Hello").MaxTokens(10).GetResponse(ctx); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if got := *p.lastRequest.MaxTokens; got != 10 {
+		t.Errorf("MaxTokens = %d, want 10", got)
+	}
+
+	// Without a deadline the request is unchanged
+	if _, err := c.Chat("gpt-4").User("Hello").GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if p.lastRequest.MaxTokens != nil {
+		t.Errorf("MaxTokens = %d, want unset", *p.lastRequest.MaxTokens)
+	}
+}
+
+func TestDeadlineTooShort(t *testing.T) {
+	p := &mockProvider{id: "test"}
+	est := NewThroughputEstimator()
+	est.SetTokensPerSecond("test", "gpt-4", 10)
+	c := NewClient(p, WithDeadlineAwareMaxTokens(est))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.Chat("gpt-4").User("Hello").GetResponse(ctx)
+	if !errors.Is(err, ErrDeadlineTooShort) {
+		t.Fatalf("GetResponse() error = %v, want ErrDeadlineTooShort", err)
+	}
+	if p.callCount != 0 {
+		t.Errorf("provider called %d times, want 0", p.callCount)
+	}
+	if _, err := c.Chat("gpt-4").User("Hello").Stream(ctx); !errors.Is(err, ErrDeadlineTooShort) {
+		t.Errorf("Stream() error = %v, want ErrDeadlineTooShort", err)
+	}
+}
+
+func TestDeadlineAwareMaxTokensLearns(t *testing.T) {
+	p := &mockProvider{
+		id: "test",
+		chatFunc: func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+			time.Sleep(10 * time.Millisecond)
+			return &ChatResponse{Output: "ok", Usage: TokenUsage{CompletionTokens: 5}}, nil
+		},
+	}
+	est := NewThroughputEstimator()
+	c := NewClient(p, WithDeadlineAwareMaxTokens(est))
+
+	if _, err := c.Chat("gpt-4").User("Hello").GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if rate, ok := est.TokensPerSecond("test", "gpt-4"); !ok || rate <= 0 || rate > 500 {
+		t.Errorf("TokensPerSecond = %v, %v", rate, ok)
+	}
+}
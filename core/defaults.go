@@ -0,0 +1,80 @@
+package core
+
+// RequestDefault pre-populates a new ChatRequest. It runs after Model is set
+// and before any builder calls, so builder calls take precedence.
+type RequestDefault func(req *ChatRequest)
+
+// WithDefaults registers functions that pre-populate every ChatBuilder
+// created by Client.Chat, in the order given. Use it for settings that would
+// otherwise be repeated on every request:
+//
+//	client := core.NewClient(provider,
+//	    core.WithDefaults(func(req *core.ChatRequest) {
+//	        req.ReasoningEffort = core.ReasoningEffortLow
+//	    }),
+//	    core.DefaultTemperature(0.2),
+//	    core.DefaultMaxTokens(1024),
+//	)
+//
+// Each builder gets its own ChatRequest, but values a default assigns are
+// shared as-is: a default that appends a slice or map it holds on to should
+// copy it. Specs are not affected, since a Spec already carries the values of
+// the builder it was taken from.
+func WithDefaults(defaults ...RequestDefault) ClientOption {
+	return func(c *Client) {
+		for _, d := range defaults {
+			if d != nil {
+				c.defaults = append(c.defaults, d)
+			}
+		}
+	}
+}
+
+// DefaultModel sets the model of requests created with an empty model ID,
+// so that client.Chat("") uses m.
+func DefaultModel(m ModelID) ClientOption {
+	return WithDefaults(func(req *ChatRequest) {
+		if req.Model == "" {
+			req.Model = m
+		}
+	})
+}
+
+// DefaultTemperature sets the temperature of every new request.
+func DefaultTemperature(v float32) ClientOption {
+	return WithDefaults(func(req *ChatRequest) {
+		t := v
+		req.Temperature = &t
+	})
+}
+
+// DefaultMaxTokens sets the maximum tokens of every new request.
+func DefaultMaxTokens(n int) ClientOption {
+	return WithDefaults(func(req *ChatRequest) {
+		m := n
+		req.MaxTokens = &m
+	})
+}
+
+// DefaultInstructions sets the instructions of every new request.
+// ChatBuilder.Instructions replaces them.
+func DefaultInstructions(s string) ClientOption {
+	return WithDefaults(func(req *ChatRequest) {
+		req.Instructions = s
+	})
+}
+
+// DefaultSystem prepends a system message to every new request.
+// ChatBuilder.System adds further system messages after it.
+func DefaultSystem(s string) ClientOption {
+	return WithDefaults(func(req *ChatRequest) {
+		req.Messages = append(req.Messages, Message{Role: RoleSystem, Content: s})
+	})
+}
+
+// applyDefaults runs the client's request defaults on req.
+func (c *Client) applyDefaults(req *ChatRequest) {
+	for _, d := range c.defaults {
+		d(req)
+	}
+}
@@ -0,0 +1,54 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+func TestClientDefaults(t *testing.T) {
+	provider := &mockProvider{}
+	client := NewClient(provider,
+		DefaultTemperature(0.2),
+		DefaultMaxTokens(512),
+		DefaultInstructions("Be brief."),
+		DefaultSystem("You are a helpful assistant."),
+		WithDefaults(func(req *ChatRequest) {
+			req.ReasoningEffort = ReasoningEffortLow
+		}),
+	)
+
+	if _, err := client.Chat("mock-model").User("Hi").GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+
+	req := provider.lastRequest
+	if req.Temperature == nil || *req.Temperature != 0.2 {
+		t.Errorf("Temperature = %v, want 0.2", req.Temperature)
+	}
+	if req.MaxTokens == nil || *req.MaxTokens != 512 {
+		t.Errorf("MaxTokens = %v, want 512", req.MaxTokens)
+	}
+	if req.ReasoningEffort != ReasoningEffortLow {
+		t.Errorf("ReasoningEffort = %q, want low", req.ReasoningEffort)
+	}
+	// The mock has no native instructions, so they lead as a system message
+	if len(req.Messages) != 3 || req.Messages[0].Content != "Be brief." ||
+		req.Messages[1].Role != RoleSystem || req.Messages[2].Role != RoleUser {
+		t.Errorf("Messages = %+v, want instructions, system, then user", req.Messages)
+	}
+}
+
+func TestClientDefaultsOverriddenByBuilder(t *testing.T) {
+	client := NewClient(&mockProvider{}, DefaultTemperature(0.2), DefaultMaxTokens(512))
+
+	b := client.Chat("mock-model").Temperature(0.9)
+	if *b.req.Temperature != 0.9 {
+		t.Errorf("Temperature = %v, want builder value 0.9", *b.req.Temperature)
+	}
+
+	// Builders do not share default values.
+	*b.req.MaxTokens = 1
+	if got := *client.Chat("mock-model").req.MaxTokens; got != 512 {
+		t.Errorf("MaxTokens = %d, want 512", got)
+	}
+}
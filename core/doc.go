@@ -34,6 +34,10 @@
 //	go func() { resp1, _ := base.Clone().User("Q1").GetResponse(ctx) }()
 //	go func() { resp2, _ := base.Clone().User("Q2").GetResponse(ctx) }()
 //
+// Settings shared by every request can instead be registered on the client
+// with [WithDefaults] or the typed options such as [DefaultTemperature]; each
+// builder returned by [Client.Chat] starts with them applied.
+//
 // # Streaming
 //
 // Iris treats streaming as a first-class primitive. Use [ChatBuilder.Stream] for
@@ -54,6 +58,32 @@
 //
 // Use [DrainStream] as a convenience to accumulate all chunks into a final response.
 //
+// [ChatBuilder.StreamOptions] bounds the chunk buffer, chooses between blocking
+// and dropping the oldest chunk when a consumer falls behind, and can merge
+// small text deltas:
+//
+//	stream, err := client.Chat(model).User(prompt).
+//	    StreamOptions(core.StreamOptions{
+//	        BufferSize:       64,
+//	        Overflow:         core.StreamOverflowDropOldest,
+//	        CoalesceInterval: 50 * time.Millisecond,
+//	    }).
+//	    Stream(ctx)
+//
+// # Background Responses
+//
+// Providers implementing [BackgroundProvider] can run long requests without
+// holding a connection open. [ChatBuilder.Background] submits the request and
+// returns a [ResponseHandle] to poll, wait on, or cancel:
+//
+//	h, err := client.Chat(model).User(prompt).Background(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	resp, err := h.Wait(ctx)
+//
+// Store h.ID to resume later with [NewResponseHandle].
+//
 // # Provider Interface
 //
 // All providers implement the [Provider] interface:
@@ -85,6 +115,15 @@
 //   - [FeatureEmbeddings]: Text embedding generation
 //   - [FeatureContextualizedEmbeddings]: Document-aware embeddings
 //   - [FeatureReranking]: Search result reranking
+//   - [FeatureVision]: Image input
+//
+// Features often vary by model. [SupportsModel] answers per-model questions
+// using the provider's model table, or live discovery where the provider
+// implements [ModelSupporter]:
+//
+//	if core.SupportsModel(provider, "gpt-4o", core.FeatureVision) {
+//	    // Safe to send images
+//	}
 //
 // # Error Handling
 //
@@ -118,6 +157,14 @@
 //	    log.Printf("Completed in %v, tokens: %d", e.End.Sub(e.Start), e.Usage.TotalTokens)
 //	}
 //
+// Telemetry events never carry prompts or responses. To record full payloads
+// for debugging or audit, use [WithCapture] with a [CaptureSink] such as
+// [FileSink]. Credentials are always redacted from captures, and
+// [CaptureRedactContent] removes message content as well:
+//
+//	sink, err := core.NewFileSink("capture.jsonl")
+//	client := core.NewClient(provider, core.WithCapture(sink, core.CaptureRedactContent()))
+//
 // # Retry Policy
 //
 // Configure retry behavior with [RetryPolicy]:
@@ -132,10 +179,18 @@
 // The default policy retries transient errors (rate limits, server errors) with
 // exponential backoff.
 //
+// With [WithDeadlineAwareMaxTokens], requests whose context has a deadline get
+// MaxTokens capped to what the model is expected to generate in time, based
+// on the throughput a [ThroughputEstimator] learns from earlier requests.
+//
 // # Warnings
 //
 // Non-fatal SDK warnings (for example, mismatched tool result IDs) can be routed
 // through [WithWarningHandler]. The default warning handler is a no-op.
+// [WithWarningListener] receives the same warnings as structured [Warning]
+// values. Requests that set parameters the provider or model ignores, such as
+// ReasoningEffort or built-in tools, produce a [WarningUnsupportedParameter]
+// warning, or fail with [ErrUnsupportedParameter] under [WithStrictParameters].
 //
 // # Multimodal Messages
 //
@@ -3,6 +3,7 @@ package core
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // ProviderError represents an error returned by a provider with full context.
@@ -13,6 +14,11 @@ type ProviderError struct {
 	Code      string
 	Message   string
 	Err       error
+
+	// RetryAfter is how long the provider asked the client to wait before
+	// retrying, from headers such as Retry-After, or zero if it did not
+	// say.
+	RetryAfter time.Duration
 }
 
 // Error implements the error interface.
@@ -53,4 +59,47 @@ var (
 var (
 	ErrModelRequired = errors.New("model required: pass a model ID to Client.Chat(), e.g., client.Chat(\"gpt-4\")")
 	ErrNoMessages    = errors.New("no messages: add at least one message using .System(), .User(), or .Assistant()")
+
+	// ErrInvalidToolChoice is returned when a ToolChoice has an unknown mode
+	// or is missing the tool name.
+	ErrInvalidToolChoice = errors.New("invalid tool choice")
+
+	// ErrInvalidJSONOutput is returned by GetResponse when ValidateJSON is set
+	// and the model output does not satisfy the requested JSON format.
+	ErrInvalidJSONOutput = errors.New("invalid JSON output")
+
+	// ErrUnsupportedParameter is returned in strict parameter mode when a
+	// request sets a parameter the provider or model would ignore.
+	ErrUnsupportedParameter = errors.New("unsupported parameter")
+)
+
+// ErrNoCredential is returned by a CredentialProvider that has no
+// credential to offer, such as EnvCredential when its variables are unset.
+var ErrNoCredential = errors.New("no credential")
+
+// ErrDeadlineTooShort is returned when a client with
+// WithDeadlineAwareMaxTokens expects the context deadline to expire before
+// the model can generate a single token.
+var ErrDeadlineTooShort = errors.New("deadline too short for response")
+
+// ErrBackgroundFailed is returned by ResponseHandle.Wait when a background
+// response fails or is cancelled.
+var ErrBackgroundFailed = errors.New("background response failed")
+
+// ErrStreamStalled is sent on ChatStream.Err when a stream with a StallTimeout
+// receives no data within the inactivity window.
+var ErrStreamStalled = errors.New("stream stalled")
+
+// ErrClientClosed is returned by requests started after Client.Close.
+var ErrClientClosed = errors.New("client closed")
+
+// Provider registry errors.
+var (
+	// ErrUnknownProvider is returned by NewProviderFromConfig for a provider
+	// name that has not been registered.
+	ErrUnknownProvider = errors.New("unknown provider")
+
+	// ErrInvalidProviderString is returned by ParseProviderString for a
+	// malformed provider string.
+	ErrInvalidProviderString = errors.New("invalid provider string")
 )
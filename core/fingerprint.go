@@ -0,0 +1,165 @@
+package core
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+)
+
+// fingerprintVersion is mixed into every fingerprint so the encoding can
+// change without colliding with digests produced by older versions.
+const fingerprintVersion = "iris-fp-v1"
+
+// Fingerprint returns a stable hex-encoded SHA-256 digest of the request
+// content: model, messages (including multimodal parts and tool results),
+// sampling parameters, tools, response format, and Responses API fields.
+//
+// Two requests that would be sent to a provider identically produce the same
+// fingerprint, across processes and SDK versions with the same fingerprint
+// version. IdempotencyKey is not included, so a retried request keeps its
+// fingerprint. Tools contribute their name, description, and parameter
+// schema, so changing a tool's schema changes the fingerprint.
+//
+// Fingerprints are useful as cache keys and for deduplicating requests:
+//
+//	key := req.Fingerprint()
+//	if resp, ok := cache.Get(key); ok {
+//	    return resp, nil
+//	}
+func (r *ChatRequest) Fingerprint() string {
+	fp := requestFingerprint{
+		Version:            fingerprintVersion,
+		Model:              r.Model,
+		Temperature:        r.Temperature,
+		MaxTokens:          r.MaxTokens,
+		ToolChoice:         r.ToolChoice,
+		ParallelToolCalls:  r.ParallelToolCalls,
+		ResponseFormat:     r.ResponseFormat,
+		JSONSchema:         r.JSONSchema,
+		Instructions:       r.Instructions,
+		ReasoningEffort:    r.ReasoningEffort,
+		BuiltInTools:       r.BuiltInTools,
+		PreviousResponseID: r.PreviousResponseID,
+		Truncation:         r.Truncation,
+		ToolResources:      r.ToolResources,
+		ToolResultPolicy:   r.ToolResultPolicy,
+		Store:              r.Store,
+		Metadata:           r.Metadata,
+	}
+
+	for _, msg := range r.Messages {
+		fp.Messages = append(fp.Messages, fingerprintMessageOf(msg))
+	}
+	for _, t := range r.Tools {
+		fp.Tools = append(fp.Tools, fingerprintTool{Name: t.Name(), Description: t.Description(), Schema: toolSchemaJSON(t)})
+	}
+
+	// All fields are plain data; tool result content is pre-encoded, so
+	// marshaling cannot fail.
+	data, _ := json.Marshal(fp)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+type requestFingerprint struct {
+	Version            string                `json:"v"`
+	Model              ModelID               `json:"model"`
+	Messages           []fingerprintMessage  `json:"messages"`
+	Temperature        *float32              `json:"temperature,omitempty"`
+	MaxTokens          *int                  `json:"max_tokens,omitempty"`
+	Tools              []fingerprintTool     `json:"tools,omitempty"`
+	ToolChoice         *ToolChoice           `json:"tool_choice,omitempty"`
+	ParallelToolCalls  *bool                 `json:"parallel_tool_calls,omitempty"`
+	ResponseFormat     ResponseFormat        `json:"response_format,omitempty"`
+	JSONSchema         *JSONSchemaDefinition `json:"json_schema,omitempty"`
+	Instructions       string                `json:"instructions,omitempty"`
+	ReasoningEffort    ReasoningEffort       `json:"reasoning_effort,omitempty"`
+	BuiltInTools       []BuiltInTool         `json:"builtin_tools,omitempty"`
+	PreviousResponseID string                `json:"previous_response_id,omitempty"`
+	Truncation         string                `json:"truncation,omitempty"`
+	ToolResources      *ToolResources        `json:"tool_resources,omitempty"`
+	ToolResultPolicy   *ToolResultPolicy     `json:"tool_result_policy,omitempty"`
+	Store              *bool                 `json:"store,omitempty"`
+	Metadata           map[string]string     `json:"metadata,omitempty"`
+}
+
+type fingerprintMessage struct {
+	Role        Role                    `json:"role"`
+	Content     string                  `json:"content,omitempty"`
+	Parts       []fingerprintPart       `json:"parts,omitempty"`
+	ToolCalls   []ToolCall              `json:"tool_calls,omitempty"`
+	ToolResults []fingerprintToolResult `json:"tool_results,omitempty"`
+
+	ComputerResults []ComputerCallOutput `json:"computer_results,omitempty"`
+}
+
+type fingerprintPart struct {
+	Type  string      `json:"type"`
+	Value ContentPart `json:"value"`
+}
+
+type fingerprintToolResult struct {
+	CallID  string `json:"call_id"`
+	Content string `json:"content"`
+	IsError bool   `json:"is_error,omitempty"`
+}
+
+type fingerprintTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Schema      json.RawMessage `json:"schema,omitempty"`
+}
+
+// toolSchemaJSON returns the JSON encoding of the value returned by t's
+// Schema method, or nil if t has none. Tools from package tools return a
+// tools.ToolSchema, which core cannot name without an import cycle, so the
+// method is found by reflection.
+func toolSchemaJSON(t Tool) json.RawMessage {
+	m := reflect.ValueOf(t).MethodByName("Schema")
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return nil
+	}
+	data, err := json.Marshal(m.Call(nil)[0].Interface())
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+func fingerprintMessageOf(msg Message) fingerprintMessage {
+	fm := fingerprintMessage{
+		Role:      msg.Role,
+		Content:   msg.Content,
+		ToolCalls: msg.ToolCalls,
+
+		ComputerResults: msg.ComputerResults,
+	}
+	for _, part := range msg.Parts {
+		fm.Parts = append(fm.Parts, fingerprintPart{Type: part.ContentType(), Value: part})
+	}
+	for _, tr := range msg.ToolResults {
+		fm.ToolResults = append(fm.ToolResults, fingerprintToolResult{
+			CallID:  tr.CallID,
+			Content: marshalToolResultJSON(tr.Content),
+			IsError: tr.IsError,
+		})
+	}
+	return fm
+}
+
+// IdempotencyKey sets the key providers send with the request so that a
+// retried request is not processed twice. When unset, GetResponse and Stream
+// generate a fresh key per call and reuse it across retries.
+func (b *ChatBuilder) IdempotencyKey(key string) *ChatBuilder {
+	b.req.IdempotencyKey = key
+	return b
+}
+
+// newIdempotencyKey returns a random key for a single logical request.
+func newIdempotencyKey() string {
+	var buf [16]byte
+	_, _ = rand.Read(buf[:])
+	return "iris-" + hex.EncodeToString(buf[:])
+}
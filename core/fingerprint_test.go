@@ -0,0 +1,157 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func fingerprintTestRequest() *ChatRequest {
+	temp := float32(0.2)
+	return &ChatRequest{
+		Model:       "gpt-4o",
+		Temperature: &temp,
+		Messages: []Message{
+			{Role: RoleSystem, Content: "Be brief."},
+			{Role: RoleUser, Parts: []ContentPart{&InputText{Text: "Describe"}, &InputImage{ImageURL: "https://example.com/a.png"}}},
+			{Role: RoleAssistant, ToolCalls: []ToolCall{{ID: "call_1", Name: "lookup", Arguments: []byte(`{"q":"x"}`)}}},
+			{Role: RoleTool, ToolResults: []ToolResult{{CallID: "call_1", Content: map[string]int{"n": 1}}}},
+		},
+		Tools: []Tool{&mockTool{name: "lookup"}},
+	}
+}
+
+func TestFingerprintStable(t *testing.T) {
+	a := fingerprintTestRequest().Fingerprint()
+	b := fingerprintTestRequest().Fingerprint()
+
+	if a != b {
+		t.Errorf("Fingerprint() not stable: %s != %s", a, b)
+	}
+	if len(a) != 64 {
+		t.Errorf("len(Fingerprint()) = %d, want 64", len(a))
+	}
+}
+
+func TestFingerprintChangesWithContent(t *testing.T) {
+	base := fingerprintTestRequest().Fingerprint()
+
+	mutations := map[string]func(r *ChatRequest){
+		"model":       func(r *ChatRequest) { r.Model = "gpt-4o-mini" },
+		"temperature": func(r *ChatRequest) { *r.Temperature = 0.3 },
+		"message":     func(r *ChatRequest) { r.Messages[0].Content = "Be verbose." },
+		"image part":  func(r *ChatRequest) { r.Messages[1].Parts[1] = &InputImage{ImageURL: "https://example.com/b.png"} },
+		"tool result": func(r *ChatRequest) { r.Messages[3].ToolResults[0].Content = map[string]int{"n": 2} },
+		"tools":       func(r *ChatRequest) { r.Tools = nil },
+		"tool choice": func(r *ChatRequest) { r.ToolChoice = &ToolChoice{Mode: ToolChoiceNone} },
+	}
+
+	for name, mutate := range mutations {
+		t.Run(name, func(t *testing.T) {
+			req := fingerprintTestRequest()
+			mutate(req)
+			if req.Fingerprint() == base {
+				t.Error("Fingerprint() unchanged after mutation")
+			}
+		})
+	}
+}
+
+func TestFingerprintIgnoresIdempotencyKeyAndPartPointers(t *testing.T) {
+	base := fingerprintTestRequest().Fingerprint()
+
+	req := fingerprintTestRequest()
+	req.IdempotencyKey = "retry-key"
+	req.Messages[1].Parts = []ContentPart{InputText{Text: "Describe"}, InputImage{ImageURL: "https://example.com/a.png"}}
+
+	if got := req.Fingerprint(); got != base {
+		t.Errorf("Fingerprint() = %s, want %s", got, base)
+	}
+}
+
+func TestGetResponseIdempotencyKeyReusedAcrossRetries(t *testing.T) {
+	var keys []string
+	p := &mockProvider{
+		chatFunc: func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+			keys = append(keys, req.IdempotencyKey)
+			if len(keys) < 3 {
+				return nil, ErrNetwork
+			}
+			return &ChatResponse{Output: "ok"}, nil
+		},
+	}
+	retry := NewRetryPolicy(RetryConfig{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	c := NewClient(p, WithRetryPolicy(retry))
+
+	builder := c.Chat("gpt-4o").User("Hi")
+	if _, err := builder.GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+
+	if len(keys) != 3 {
+		t.Fatalf("attempts = %d, want 3", len(keys))
+	}
+	if !strings.HasPrefix(keys[0], "iris-") {
+		t.Errorf("IdempotencyKey = %q, want generated key", keys[0])
+	}
+	if keys[1] != keys[0] || keys[2] != keys[0] {
+		t.Errorf("retries used different keys: %v", keys)
+	}
+
+	// A second call on the same builder is a new logical request.
+	if _, err := builder.GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if keys[3] == keys[0] {
+		t.Error("second call reused the first call's idempotency key")
+	}
+	if builder.req.IdempotencyKey != "" {
+		t.Errorf("builder IdempotencyKey = %q, want unchanged", builder.req.IdempotencyKey)
+	}
+}
+
+func TestExplicitIdempotencyKey(t *testing.T) {
+	p := &mockProvider{}
+	c := NewClient(p)
+
+	if _, err := c.Chat("gpt-4o").User("Hi").IdempotencyKey("order-42").GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if p.lastRequest.IdempotencyKey != "order-42" {
+		t.Errorf("IdempotencyKey = %q, want %q", p.lastRequest.IdempotencyKey, "order-42")
+	}
+}
+
+// testToolSchema mirrors tools.ToolSchema, which core cannot import.
+type testToolSchema struct {
+	JSONSchema json.RawMessage `json:"json_schema"`
+}
+
+// schemaTool is a tool with a Schema method like the tools in package tools.
+type schemaTool struct {
+	mockTool
+	schema string
+}
+
+func (t *schemaTool) Schema() testToolSchema {
+	return testToolSchema{JSONSchema: json.RawMessage(t.schema)}
+}
+
+func TestFingerprintIncludesToolSchema(t *testing.T) {
+	fingerprint := func(schema string) string {
+		req := fingerprintTestRequest()
+		req.Tools = []Tool{&schemaTool{mockTool: mockTool{name: "lookup"}, schema: schema}}
+		return req.Fingerprint()
+	}
+
+	a := fingerprint(`{"type":"object","properties":{"q":{"type":"string"}}}`)
+	b := fingerprint(`{"type":"object","properties":{"q":{"type":"integer"}}}`)
+	if a == b {
+		t.Error("Fingerprint() unchanged after tool schema change")
+	}
+	if a != fingerprint(`{"type":"object","properties":{"q":{"type":"string"}}}`) {
+		t.Error("Fingerprint() not stable for the same tool schema")
+	}
+}
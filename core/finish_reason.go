@@ -0,0 +1,31 @@
+package core
+
+// FinishReason is why the model stopped generating, normalized across
+// providers. Providers map their own values, such as OpenAI's
+// finish_reason, Anthropic's stop_reason, and Ollama's done_reason, to
+// these; ChatResponse.RawFinishReason keeps the original.
+type FinishReason string
+
+const (
+	// FinishUnknown means the provider reported no finish reason, or one
+	// without an equivalent here.
+	FinishUnknown FinishReason = ""
+	// FinishStop means the model finished its answer or hit a stop sequence.
+	FinishStop FinishReason = "stop"
+	// FinishLength means the output was cut off by MaxTokens or the
+	// context window.
+	FinishLength FinishReason = "length"
+	// FinishToolCalls means the model stopped to call tools.
+	FinishToolCalls FinishReason = "tool_calls"
+	// FinishContentFilter means the output was withheld or cut off by the
+	// provider's safety filters, or the model refused.
+	FinishContentFilter FinishReason = "content_filter"
+	// FinishCanceled means generation was canceled before it finished.
+	FinishCanceled FinishReason = "canceled"
+)
+
+// Truncated reports whether the response was cut off by the token limit,
+// so Output is incomplete.
+func (r *ChatResponse) Truncated() bool {
+	return r.FinishReason == FinishLength
+}
@@ -0,0 +1,22 @@
+package core
+
+import "testing"
+
+func TestChatResponseTruncated(t *testing.T) {
+	tests := []struct {
+		reason FinishReason
+		want   bool
+	}{
+		{FinishLength, true},
+		{FinishStop, false},
+		{FinishToolCalls, false},
+		{FinishUnknown, false},
+	}
+
+	for _, tt := range tests {
+		resp := &ChatResponse{FinishReason: tt.reason}
+		if got := resp.Truncated(); got != tt.want {
+			t.Errorf("Truncated() with %q = %v, want %v", tt.reason, got, tt.want)
+		}
+	}
+}
@@ -187,6 +187,8 @@ type ImageChunk struct {
 }
 
 // ImageStream represents a streaming image generation response.
+// Providers build one with NewImageStream, or with ImageStreamFromFunc when
+// their API has no partial images.
 type ImageStream struct {
 	Ch    <-chan ImageChunk     // Partial images
 	Err   <-chan error          // At most one error
@@ -0,0 +1,226 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+
+	// Register GIF decoding for DecodeImage
+	_ "image/gif"
+)
+
+// Image helper errors.
+var (
+	// ErrNoImageData is returned by image helpers for an ImageData that only
+	// has a URL. Download the image first.
+	ErrNoImageData = errors.New("image has no inline data")
+
+	// ErrUnsupportedImageFormat is returned when an image cannot be decoded
+	// or encoded in the requested format. PNG, JPEG, and GIF are decoded;
+	// PNG and JPEG are encoded.
+	ErrUnsupportedImageFormat = errors.New("unsupported image format")
+)
+
+// jpegQuality is the quality used when encoding JPEG images.
+const jpegQuality = 90
+
+// SaveFile writes the decoded image to path with mode 0644.
+func (d ImageData) SaveFile(path string) error {
+	data, err := d.inline()
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// DecodeImage decodes the image and reports its format.
+func (d ImageData) DecodeImage() (image.Image, ImageFormat, error) {
+	data, err := d.inline()
+	if err != nil {
+		return nil, "", err
+	}
+	img, name, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		if errors.Is(err, image.ErrFormat) {
+			return nil, "", fmt.Errorf("%w: %v", ErrUnsupportedImageFormat, err)
+		}
+		return nil, "", err
+	}
+	return img, ImageFormat(name), nil
+}
+
+// WithFormat returns the image re-encoded as format (PNG or JPEG). The image
+// is returned unchanged if it already has that format. URL and
+// RevisedPrompt are kept.
+func (d ImageData) WithFormat(format ImageFormat) (ImageData, error) {
+	img, current, err := d.DecodeImage()
+	if err != nil {
+		return ImageData{}, err
+	}
+	if current == format {
+		return d, nil
+	}
+	return d.encoded(img, format)
+}
+
+// Resize returns the image scaled to width by height pixels, in its
+// original format (GIF is re-encoded as PNG). If width or height is zero,
+// it is derived from the other to preserve the aspect ratio.
+func (d ImageData) Resize(width, height int) (ImageData, error) {
+	if width < 0 || height < 0 || (width == 0 && height == 0) {
+		return ImageData{}, fmt.Errorf("invalid image size %dx%d", width, height)
+	}
+	img, format, err := d.DecodeImage()
+	if err != nil {
+		return ImageData{}, err
+	}
+
+	b := img.Bounds()
+	if width == 0 {
+		width = max(1, int(math.Round(float64(height)*float64(b.Dx())/float64(b.Dy()))))
+	}
+	if height == 0 {
+		height = max(1, int(math.Round(float64(width)*float64(b.Dy())/float64(b.Dx()))))
+	}
+	if format != ImageFormatJPEG {
+		format = ImageFormatPNG
+	}
+	return d.encoded(resizeBilinear(img, width, height), format)
+}
+
+// EncodeImage encodes img as PNG or JPEG.
+func EncodeImage(img image.Image, format ImageFormat) ([]byte, error) {
+	var buf bytes.Buffer
+	var err error
+	switch format {
+	case ImageFormatPNG:
+		err = png.Encode(&buf, img)
+	case ImageFormatJPEG:
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	default:
+		return nil, fmt.Errorf("%w: cannot encode %q", ErrUnsupportedImageFormat, format)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// inline returns the decoded image bytes.
+func (d ImageData) inline() ([]byte, error) {
+	if d.B64JSON == "" {
+		return nil, ErrNoImageData
+	}
+	return base64.StdEncoding.DecodeString(d.B64JSON)
+}
+
+// encoded returns d with its image replaced by img encoded as format.
+func (d ImageData) encoded(img image.Image, format ImageFormat) (ImageData, error) {
+	data, err := EncodeImage(img, format)
+	if err != nil {
+		return ImageData{}, err
+	}
+	d.B64JSON = base64.StdEncoding.EncodeToString(data)
+	return d, nil
+}
+
+// resizeBilinear scales src to width by height with bilinear interpolation.
+func resizeBilinear(src image.Image, width, height int) *image.NRGBA {
+	b := src.Bounds()
+	in := image.NewNRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(in, in.Bounds(), src, b.Min, draw.Src)
+
+	out := image.NewNRGBA(image.Rect(0, 0, width, height))
+	sx := float64(b.Dx()) / float64(width)
+	sy := float64(b.Dy()) / float64(height)
+
+	for y := range height {
+		fy := math.Max((float64(y)+0.5)*sy-0.5, 0)
+		y0 := int(fy)
+		y1 := min(y0+1, b.Dy()-1)
+		wy := fy - float64(y0)
+
+		for x := range width {
+			fx := math.Max((float64(x)+0.5)*sx-0.5, 0)
+			x0 := int(fx)
+			x1 := min(x0+1, b.Dx()-1)
+			wx := fx - float64(x0)
+
+			p00 := in.PixOffset(x0, y0)
+			p01 := in.PixOffset(x1, y0)
+			p10 := in.PixOffset(x0, y1)
+			p11 := in.PixOffset(x1, y1)
+			o := out.PixOffset(x, y)
+			for c := range 4 {
+				top := float64(in.Pix[p00+c])*(1-wx) + float64(in.Pix[p01+c])*wx
+				bottom := float64(in.Pix[p10+c])*(1-wx) + float64(in.Pix[p11+c])*wx
+				out.Pix[o+c] = uint8(math.Round(top*(1-wy) + bottom*wy))
+			}
+		}
+	}
+	return out
+}
+
+// DrainImageStream reads an ImageStream to the end and returns its final
+// response. onPartial, if not nil, is called for each partial image; an
+// error from it stops reading and is returned. If the stream ends without a
+// final response, the last partial image is returned instead, so a
+// preview is not lost when the provider omits the completed image.
+func DrainImageStream(ctx context.Context, s *ImageStream, onPartial func(ImageChunk) error) (*ImageResponse, error) {
+	if s == nil {
+		return nil, ErrBadRequest
+	}
+
+	var last *ImageChunk
+	for ch := s.Ch; ch != nil; {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case chunk, ok := <-ch:
+			if !ok {
+				ch = nil
+				continue
+			}
+			if onPartial != nil {
+				if err := onPartial(chunk); err != nil {
+					return nil, err
+				}
+			}
+			last = &chunk
+		}
+	}
+
+	errCh, finalCh := s.Err, s.Final
+	var final *ImageResponse
+	for errCh != nil || finalCh != nil {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+			} else if err != nil {
+				return nil, err
+			}
+		case resp, ok := <-finalCh:
+			if !ok {
+				finalCh = nil
+			} else {
+				final = resp
+			}
+		}
+	}
+
+	if final == nil && last != nil {
+		final = &ImageResponse{Data: []ImageData{{B64JSON: last.B64JSON}}}
+	}
+	return final, nil
+}
@@ -0,0 +1,120 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testPNG returns a w by h PNG as ImageData.
+func testPNG(t *testing.T, w, h int) ImageData {
+	t.Helper()
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for y := range h {
+		for x := range w {
+			img.Set(x, y, color.NRGBA{R: uint8(x * 255 / w), G: 128, B: 64, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return ImageData{B64JSON: base64.StdEncoding.EncodeToString(buf.Bytes()), RevisedPrompt: "test"}
+}
+
+func TestImageDataSaveFile(t *testing.T) {
+	d := testPNG(t, 4, 4)
+	path := filepath.Join(t.TempDir(), "out.png")
+	if err := d.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile() error = %v", err)
+	}
+	want, _ := base64.StdEncoding.DecodeString(d.B64JSON)
+	if got, _ := os.ReadFile(path); !bytes.Equal(got, want) {
+		t.Error("saved file does not match image data")
+	}
+
+	if err := (ImageData{URL: "https://example.com/a.png"}).SaveFile(path); !errors.Is(err, ErrNoImageData) {
+		t.Errorf("SaveFile() for URL image error = %v, want ErrNoImageData", err)
+	}
+}
+
+func TestImageDataWithFormat(t *testing.T) {
+	d := testPNG(t, 8, 8)
+
+	jpg, err := d.WithFormat(ImageFormatJPEG)
+	if err != nil {
+		t.Fatalf("WithFormat(jpeg) error = %v", err)
+	}
+	img, format, err := jpg.DecodeImage()
+	if err != nil || format != ImageFormatJPEG || img.Bounds().Dx() != 8 {
+		t.Errorf("DecodeImage() = %v, %q, %v", img.Bounds(), format, err)
+	}
+	if jpg.RevisedPrompt != "test" {
+		t.Errorf("RevisedPrompt = %q, want kept", jpg.RevisedPrompt)
+	}
+
+	if _, err := d.WithFormat(ImageFormatWebP); !errors.Is(err, ErrUnsupportedImageFormat) {
+		t.Errorf("WithFormat(webp) error = %v, want ErrUnsupportedImageFormat", err)
+	}
+}
+
+func TestImageDataResize(t *testing.T) {
+	d := testPNG(t, 40, 20)
+
+	small, err := d.Resize(10, 0)
+	if err != nil {
+		t.Fatalf("Resize() error = %v", err)
+	}
+	img, format, err := small.DecodeImage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := img.Bounds().Size(); got != image.Pt(10, 5) || format != ImageFormatPNG {
+		t.Errorf("resized = %v %s, want (10,5) png", got, format)
+	}
+	// Colors are interpolated, not invented
+	if c := color.NRGBAModel.Convert(img.At(5, 2)).(color.NRGBA); c.G != 128 || c.B != 64 {
+		t.Errorf("pixel = %+v", c)
+	}
+
+	if _, err := d.Resize(0, 0); err == nil {
+		t.Error("Resize(0, 0) should fail")
+	}
+}
+
+func TestDrainImageStream(t *testing.T) {
+	stream, w := NewImageStream(context.Background(), 2)
+	w.Partial(ImageChunk{PartialImageIndex: 0, B64JSON: "cDA="})
+	w.Partial(ImageChunk{PartialImageIndex: 1, B64JSON: "cDE="})
+	w.Complete(nil)
+
+	var partials int
+	resp, err := DrainImageStream(context.Background(), stream, func(ImageChunk) error {
+		partials++
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("DrainImageStream() error = %v", err)
+	}
+	if partials != 2 {
+		t.Errorf("partials = %d, want 2", partials)
+	}
+	// Without a final response the last partial is returned
+	if len(resp.Data) != 1 || resp.Data[0].B64JSON != "cDE=" {
+		t.Errorf("resp = %+v", resp)
+	}
+
+	failed := ImageStreamFromFunc(context.Background(), func(context.Context) (*ImageResponse, error) {
+		return nil, ErrServer
+	})
+	if _, err := DrainImageStream(context.Background(), failed, nil); !errors.Is(err, ErrServer) {
+		t.Errorf("DrainImageStream() error = %v, want ErrServer", err)
+	}
+}
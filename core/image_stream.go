@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// ImageStreamWriter is the producing side of an ImageStream. Providers
+// create one with NewImageStream, send partial images with Partial, and end
+// the stream with exactly one call to Complete or Fail.
+type ImageStreamWriter struct {
+	ctx     context.Context
+	ch      chan ImageChunk
+	errCh   chan error
+	finalCh chan *ImageResponse
+	once    sync.Once
+}
+
+// NewImageStream returns a stream and the writer that feeds it. buffer is
+// the number of partial images held for a slow reader; zero makes Partial
+// wait for the reader.
+func NewImageStream(ctx context.Context, buffer int) (*ImageStream, *ImageStreamWriter) {
+	w := &ImageStreamWriter{
+		ctx:     ctx,
+		ch:      make(chan ImageChunk, max(buffer, 0)),
+		errCh:   make(chan error, 1),
+		finalCh: make(chan *ImageResponse, 1),
+	}
+	return &ImageStream{Ch: w.ch, Err: w.errCh, Final: w.finalCh}, w
+}
+
+// Partial sends a partial image. It returns the context error if the
+// context is done before the reader accepts the chunk; the caller should
+// then stop and call Fail with it.
+func (w *ImageStreamWriter) Partial(chunk ImageChunk) error {
+	select {
+	case w.ch <- chunk:
+		return nil
+	case <-w.ctx.Done():
+		return w.ctx.Err()
+	}
+}
+
+// Complete delivers the final response and closes the stream. A nil resp
+// closes the stream without a final response.
+func (w *ImageStreamWriter) Complete(resp *ImageResponse) {
+	w.once.Do(func() {
+		if resp != nil {
+			w.finalCh <- resp
+		}
+		w.close()
+	})
+}
+
+// Fail delivers err and closes the stream.
+func (w *ImageStreamWriter) Fail(err error) {
+	w.once.Do(func() {
+		if err != nil {
+			w.errCh <- err
+		}
+		w.close()
+	})
+}
+
+func (w *ImageStreamWriter) close() {
+	close(w.ch)
+	close(w.errCh)
+	close(w.finalCh)
+}
+
+// ImageStreamFromFunc runs generate in the background and returns its result
+// as an ImageStream without partial images. Providers whose image API does
+// not stream use it to implement StreamImage, so callers can consume every
+// provider the same way.
+func ImageStreamFromFunc(ctx context.Context, generate func(context.Context) (*ImageResponse, error)) *ImageStream {
+	stream, w := NewImageStream(ctx, 0)
+	go func() {
+		resp, err := generate(ctx)
+		if err != nil {
+			w.Fail(err)
+			return
+		}
+		w.Complete(resp)
+	}()
+	return stream
+}
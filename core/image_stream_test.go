@@ -0,0 +1,67 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestImageStreamWriter(t *testing.T) {
+	stream, w := NewImageStream(context.Background(), 0)
+	go func() {
+		for i := range 2 {
+			if err := w.Partial(ImageChunk{PartialImageIndex: i, B64JSON: "cA=="}); err != nil {
+				w.Fail(err)
+				return
+			}
+		}
+		w.Complete(&ImageResponse{Data: []ImageData{{B64JSON: "Zg=="}}})
+		w.Fail(errors.New("ignored after Complete"))
+	}()
+
+	var chunks []ImageChunk
+	for chunk := range stream.Ch {
+		chunks = append(chunks, chunk)
+	}
+	if len(chunks) != 2 || chunks[1].PartialImageIndex != 1 {
+		t.Errorf("chunks = %+v", chunks)
+	}
+	if err := <-stream.Err; err != nil {
+		t.Errorf("Err = %v, want none", err)
+	}
+	if final := <-stream.Final; final == nil || len(final.Data) != 1 {
+		t.Errorf("Final = %+v", final)
+	}
+}
+
+func TestImageStreamWriterCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, w := NewImageStream(ctx, 0)
+	cancel()
+
+	err := w.Partial(ImageChunk{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Partial() error = %v, want context.Canceled", err)
+	}
+	w.Fail(err)
+	if got := <-stream.Err; !errors.Is(got, context.Canceled) {
+		t.Errorf("Err = %v, want context.Canceled", got)
+	}
+}
+
+func TestImageStreamFromFunc(t *testing.T) {
+	genErr := errors.New("generation failed")
+	stream := ImageStreamFromFunc(context.Background(), func(context.Context) (*ImageResponse, error) {
+		return nil, genErr
+	})
+
+	for range stream.Ch {
+		t.Error("unexpected partial image")
+	}
+	if err := <-stream.Err; !errors.Is(err, genErr) {
+		t.Errorf("Err = %v, want %v", err, genErr)
+	}
+	if final := <-stream.Final; final != nil {
+		t.Errorf("Final = %+v, want nil", final)
+	}
+}
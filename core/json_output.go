@@ -0,0 +1,75 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/petal-labs/iris/schema"
+)
+
+// ValidateJSON makes GetResponse check structured output before returning it.
+// It applies when the response format is ResponseFormatJSON or
+// ResponseFormatJSONSchema: Output must be valid JSON and, for a JSON schema,
+// an object containing the schema's top-level required properties.
+//
+// When validation fails, the request is sent again up to retries more times.
+// If the output is still invalid, GetResponse returns the last response with
+// an error wrapping ErrInvalidJSONOutput. This is useful for providers and
+// local models that do not enforce schemas strictly.
+func (b *ChatBuilder) ValidateJSON(retries int) *ChatBuilder {
+	b.validateJSON = true
+	b.jsonRetries = max(retries, 0)
+	return b
+}
+
+// JSONSchemaFor returns a structured output definition named name whose
+// schema is generated from the Go type T (see package schema). Pair it with
+// GetTypedResponse to decode the output into the same type:
+//
+//	def, err := core.JSONSchemaFor[Weather]("weather")
+//	w, resp, err := core.GetTypedResponse[Weather](ctx,
+//	    client.Chat(model).ResponseJSONSchema(def).User(prompt))
+//
+// Strict is left false because providers with strict modes typically require
+// every property to be required; set it when T has no optional fields.
+func JSONSchemaFor[T any](name string) (*JSONSchemaDefinition, error) {
+	raw, err := schema.RawFor[T]()
+	if err != nil {
+		return nil, err
+	}
+	return &JSONSchemaDefinition{Name: name, Schema: raw}, nil
+}
+
+// checkJSONOutput validates output against the request's response format.
+// Requests without a JSON response format always pass.
+func checkJSONOutput(output string, req *ChatRequest) error {
+	if req.ResponseFormat != ResponseFormatJSON && req.ResponseFormat != ResponseFormatJSONSchema {
+		return nil
+	}
+
+	if !json.Valid([]byte(output)) {
+		return fmt.Errorf("%w: output is not valid JSON", ErrInvalidJSONOutput)
+	}
+
+	if req.ResponseFormat != ResponseFormatJSONSchema || req.JSONSchema == nil {
+		return nil
+	}
+
+	var schema struct {
+		Required []string `json:"required"`
+	}
+	if err := json.Unmarshal(req.JSONSchema.Schema, &schema); err != nil || len(schema.Required) == 0 {
+		return nil
+	}
+
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(output), &obj); err != nil {
+		return fmt.Errorf("%w: output is not a JSON object", ErrInvalidJSONOutput)
+	}
+	for _, name := range schema.Required {
+		if _, ok := obj[name]; !ok {
+			return fmt.Errorf("%w: missing required property %q", ErrInvalidJSONOutput, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestCheckJSONOutput(t *testing.T) {
+	schema := &JSONSchemaDefinition{
+		Name:   "person",
+		Schema: json.RawMessage(`{"type":"object","required":["name","age"]}`),
+	}
+
+	tests := []struct {
+		name    string
+		req     ChatRequest
+		output  string
+		wantErr bool
+	}{
+		{"text format ignored", ChatRequest{}, "not json", false},
+		{"json valid", ChatRequest{ResponseFormat: ResponseFormatJSON}, `{"a":1}`, false},
+		{"json invalid", ChatRequest{ResponseFormat: ResponseFormatJSON}, `{"a":`, true},
+		{"schema satisfied", ChatRequest{ResponseFormat: ResponseFormatJSONSchema, JSONSchema: schema}, `{"name":"Ann","age":3}`, false},
+		{"schema missing required", ChatRequest{ResponseFormat: ResponseFormatJSONSchema, JSONSchema: schema}, `{"name":"Ann"}`, true},
+		{"schema not an object", ChatRequest{ResponseFormat: ResponseFormatJSONSchema, JSONSchema: schema}, `[1,2]`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkJSONOutput(tt.output, &tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkJSONOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidJSONOutput) {
+				t.Errorf("error = %v, want ErrInvalidJSONOutput", err)
+			}
+		})
+	}
+}
+
+func TestValidateJSONRetriesUntilValid(t *testing.T) {
+	outputs := []string{"Sure! {", `{"name":"Ann"}`, `{"name":"Ann","age":3}`}
+	calls := 0
+	p := &mockProvider{
+		chatFunc: func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+			out := outputs[calls]
+			calls++
+			return &ChatResponse{Output: out}, nil
+		},
+	}
+	c := NewClient(p)
+
+	resp, err := c.Chat("llama3.2").
+		User("Extract").
+		ResponseJSONSchema(&JSONSchemaDefinition{Name: "person", Schema: json.RawMessage(`{"required":["name","age"]}`)}).
+		ValidateJSON(2).
+		GetResponse(context.Background())
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+	if resp.Output != outputs[2] {
+		t.Errorf("Output = %q, want %q", resp.Output, outputs[2])
+	}
+}
+
+func TestValidateJSONExhausted(t *testing.T) {
+	calls := 0
+	p := &mockProvider{
+		chatFunc: func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+			calls++
+			return &ChatResponse{Output: "no json here"}, nil
+		},
+	}
+	c := NewClient(p)
+
+	resp, err := c.Chat("llama3.2").User("Extract").ResponseJSON().ValidateJSON(1).GetResponse(context.Background())
+	if !errors.Is(err, ErrInvalidJSONOutput) {
+		t.Fatalf("GetResponse() error = %v, want ErrInvalidJSONOutput", err)
+	}
+	if resp == nil || resp.Output != "no json here" {
+		t.Errorf("resp = %+v, want last response", resp)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestValidateJSONNotSetSkipsValidation(t *testing.T) {
+	p := &mockProvider{
+		chatFunc: func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+			return &ChatResponse{Output: "no json here"}, nil
+		},
+	}
+	c := NewClient(p)
+
+	if _, err := c.Chat("llama3.2").User("Extract").ResponseJSON().GetResponse(context.Background()); err != nil {
+		t.Errorf("GetResponse() error = %v, want nil without ValidateJSON", err)
+	}
+}
+
+func TestJSONSchemaForPairsWithValidation(t *testing.T) {
+	type person struct {
+		Name string `json:"name"`
+		Age  int    `json:"age,omitempty"`
+	}
+
+	def, err := JSONSchemaFor[person]("person")
+	if err != nil {
+		t.Fatalf("JSONSchemaFor() error = %v", err)
+	}
+	if def.Name != "person" {
+		t.Errorf("Name = %q", def.Name)
+	}
+
+	req := &ChatRequest{ResponseFormat: ResponseFormatJSONSchema, JSONSchema: def}
+	if err := checkJSONOutput(`{"age": 3}`, req); !errors.Is(err, ErrInvalidJSONOutput) {
+		t.Errorf("checkJSONOutput() error = %v, want missing name", err)
+	}
+	if err := checkJSONOutput(`{"name": "Ann"}`, req); err != nil {
+		t.Errorf("checkJSONOutput() error = %v", err)
+	}
+}
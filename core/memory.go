@@ -235,6 +235,44 @@ func (c *Conversation) MessageCount() int {
 	return c.memory.Len()
 }
 
+// ConversationState is the serializable state of a Conversation: its model,
+// system message, and history. It encodes to JSON, so a conversation can be
+// saved and continued later with ResumeConversation. Multimodal content
+// parts (Message.Parts) are not encoded.
+type ConversationState struct {
+	Model    ModelID   `json:"model"`
+	System   string    `json:"system,omitempty"`
+	Messages []Message `json:"messages"`
+}
+
+// State returns a snapshot of the conversation.
+func (c *Conversation) State() ConversationState {
+	return ConversationState{
+		Model:    c.model,
+		System:   c.system,
+		Messages: c.memory.GetHistory(),
+	}
+}
+
+// ResumeConversation recreates a conversation from a saved state. The
+// history is restored as saved, including its system message, into the
+// store set by WithMemoryStore or a new in-memory store.
+func ResumeConversation(client *Client, state ConversationState, opts ...ConversationOption) *Conversation {
+	c := &Conversation{
+		memory: NewInMemoryStore(),
+		client: client,
+		model:  state.Model,
+		system: state.System,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.memory.SetMessages(state.Messages)
+	return c
+}
+
 // Stream sends a user message and returns a streaming response.
 // Automatically manages conversation history; the assistant's response
 // is added to history when the stream completes.
@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"sync"
 	"testing"
 )
@@ -268,6 +269,45 @@ func TestMemoryInterfaceImplementation(t *testing.T) {
 // Conversation Streaming Tests
 // -----------------------------------------------------------------------------
 
+func TestConversationStateRoundTrip(t *testing.T) {
+	provider := &mockProvider{id: "test"}
+	client := NewClient(provider)
+
+	conv := NewConversation(client, "test-model", WithSystemMessage("You are helpful"))
+	if _, err := conv.Send("Hello"); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+
+	data, err := json.Marshal(conv.State())
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	var state ConversationState
+	if err := json.Unmarshal(data, &state); err != nil {
+		t.Fatalf("Unmarshal error: %v", err)
+	}
+	if state.Model != "test-model" || state.System != "You are helpful" {
+		t.Errorf("state = %+v", state)
+	}
+
+	resumed := ResumeConversation(client, state)
+	if resumed.MessageCount() != 3 {
+		t.Fatalf("MessageCount() = %d, want 3", resumed.MessageCount())
+	}
+	if _, err := resumed.Send("Again"); err != nil {
+		t.Fatalf("Send() error: %v", err)
+	}
+	if resumed.MessageCount() != 5 {
+		t.Errorf("MessageCount() = %d, want 5", resumed.MessageCount())
+	}
+
+	// Clear keeps the restored system message.
+	resumed.Clear()
+	if history := resumed.GetHistory(); len(history) != 1 || history[0].Content != "You are helpful" {
+		t.Errorf("history after Clear = %+v", history)
+	}
+}
+
 func TestConversationStream(t *testing.T) {
 	provider := &mockProvider{id: "test"}
 	client := NewClient(provider)
@@ -0,0 +1,87 @@
+package core
+
+import "context"
+
+// Modality is a kind of input a model accepts.
+type Modality string
+
+const (
+	ModalityText  Modality = "text"
+	ModalityImage Modality = "image"
+	ModalityAudio Modality = "audio"
+	ModalityVideo Modality = "video"
+)
+
+// SupportsModality reports whether the model accepts the given input modality.
+// Text is assumed for chat models that do not list their modalities.
+func (m ModelInfo) SupportsModality(mod Modality) bool {
+	if len(m.Modalities) == 0 {
+		return mod == ModalityText && m.HasCapability(FeatureChat)
+	}
+	for _, have := range m.Modalities {
+		if have == mod {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsTools reports whether the model supports tool calling.
+func (m ModelInfo) SupportsTools() bool {
+	return m.HasCapability(FeatureToolCalling)
+}
+
+// SupportsReasoning reports whether the model supports extended reasoning.
+func (m ModelInfo) SupportsReasoning() bool {
+	return m.HasCapability(FeatureReasoning)
+}
+
+// ModelSupporter is an optional interface for providers that report
+// capabilities per model rather than provider-wide, for example from live
+// model discovery. Use [SupportsModel] rather than calling it directly.
+type ModelSupporter interface {
+	// SupportsModel reports whether the given model supports the feature.
+	SupportsModel(model ModelID, feature Feature) bool
+}
+
+// ModelDescriber is an optional interface for providers that can look up
+// model metadata from the provider API.
+type ModelDescriber interface {
+	// DescribeModel returns metadata for a single model.
+	DescribeModel(ctx context.Context, model ModelID) (*ModelInfo, error)
+}
+
+// FindModel returns the entry for model in the provider's model table,
+// or nil if the provider does not list it.
+func FindModel(p Provider, model ModelID) *ModelInfo {
+	for _, info := range p.Models() {
+		if info.ID == model {
+			return &info
+		}
+	}
+	return nil
+}
+
+// SupportsModel reports whether a provider supports a feature for a specific
+// model. Providers implementing [ModelSupporter] answer directly. Otherwise
+// the model's entry in Models() is used, falling back to the provider-wide
+// Supports for models the provider does not list.
+func SupportsModel(p Provider, model ModelID, feature Feature) bool {
+	if ms, ok := p.(ModelSupporter); ok {
+		return ms.SupportsModel(model, feature)
+	}
+	if info := FindModel(p, model); info != nil {
+		return info.HasCapability(feature)
+	}
+	return p.Supports(feature)
+}
+
+// DescribeModel returns metadata for a model, querying the provider API when
+// it implements [ModelDescriber] and using the static model table otherwise.
+// It returns nil without error when the model is unknown.
+func DescribeModel(ctx context.Context, p Provider, model ModelID) (*ModelInfo, error) {
+	if d, ok := p.(ModelDescriber); ok {
+		return d.DescribeModel(ctx, model)
+	}
+	return FindModel(p, model), nil
+}
@@ -0,0 +1,81 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+func TestModelInfoModalities(t *testing.T) {
+	vision := ModelInfo{
+		Capabilities: []Feature{FeatureChat, FeatureToolCalling},
+		Modalities:   []Modality{ModalityText, ModalityImage},
+	}
+	if !vision.SupportsModality(ModalityImage) {
+		t.Error("SupportsModality(image) = false, want true")
+	}
+	if vision.SupportsModality(ModalityAudio) {
+		t.Error("SupportsModality(audio) = true, want false")
+	}
+	if !vision.HasCapability(FeatureVision) {
+		t.Error("HasCapability(FeatureVision) = false, want true")
+	}
+	if !vision.SupportsTools() || vision.SupportsReasoning() {
+		t.Errorf("SupportsTools/SupportsReasoning = %v/%v, want true/false", vision.SupportsTools(), vision.SupportsReasoning())
+	}
+
+	// Chat models without listed modalities are assumed to accept text only.
+	plain := ModelInfo{Capabilities: []Feature{FeatureChat}}
+	if !plain.SupportsModality(ModalityText) || plain.HasCapability(FeatureVision) {
+		t.Error("unlisted modalities should default to text only")
+	}
+}
+
+// modelSupporterProvider answers per-model capability queries directly.
+type modelSupporterProvider struct {
+	mockProvider
+}
+
+func (m *modelSupporterProvider) SupportsModel(model ModelID, feature Feature) bool {
+	return model == "live-model" && feature == FeatureReasoning
+}
+
+func TestSupportsModel(t *testing.T) {
+	p := &mockProvider{}
+
+	// Listed model uses its own capabilities.
+	if !SupportsModel(p, "mock-model", FeatureChat) {
+		t.Error("SupportsModel(mock-model, chat) = false, want true")
+	}
+	if SupportsModel(p, "mock-model", FeatureToolCalling) {
+		t.Error("SupportsModel(mock-model, tools) = true, want false")
+	}
+	// Unlisted model falls back to provider-wide support.
+	if !SupportsModel(p, "other-model", FeatureChatStreaming) {
+		t.Error("SupportsModel(other-model, streaming) = false, want true")
+	}
+
+	live := &modelSupporterProvider{}
+	if !SupportsModel(live, "live-model", FeatureReasoning) {
+		t.Error("ModelSupporter should be consulted")
+	}
+	if SupportsModel(live, "mock-model", FeatureChat) {
+		t.Error("ModelSupporter answer should take precedence over Models()")
+	}
+}
+
+func TestDescribeModelStatic(t *testing.T) {
+	p := &mockProvider{}
+
+	info, err := DescribeModel(context.Background(), p, "mock-model")
+	if err != nil {
+		t.Fatalf("DescribeModel() error = %v", err)
+	}
+	if info == nil || info.DisplayName != "Mock Model" {
+		t.Errorf("DescribeModel() = %+v, want Mock Model", info)
+	}
+
+	info, err = DescribeModel(context.Background(), p, "missing")
+	if err != nil || info != nil {
+		t.Errorf("DescribeModel(missing) = %+v, %v, want nil, nil", info, err)
+	}
+}
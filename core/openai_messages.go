@@ -0,0 +1,255 @@
+package core
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// openAIMessage is a message in the OpenAI Chat Completions wire format.
+type openAIMessage struct {
+	Role       string           `json:"role"`
+	Content    json.RawMessage  `json:"content,omitempty"`
+	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+type openAIToolCall struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	Function struct {
+		Name      string `json:"name"`
+		Arguments string `json:"arguments"`
+	} `json:"function"`
+}
+
+type openAIContentPart struct {
+	Type       string            `json:"type"`
+	Text       string            `json:"text,omitempty"`
+	ImageURL   *openAIImageURL   `json:"image_url,omitempty"`
+	InputAudio *openAIInputAudio `json:"input_audio,omitempty"`
+	File       *openAIFile       `json:"file,omitempty"`
+}
+
+type openAIInputAudio struct {
+	Data   string `json:"data"`
+	Format string `json:"format"`
+}
+
+type openAIImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+type openAIFile struct {
+	FileID   string `json:"file_id,omitempty"`
+	FileData string `json:"file_data,omitempty"`
+	Filename string `json:"filename,omitempty"`
+}
+
+// MessagesFromOpenAIJSON decodes conversation history stored in the OpenAI
+// Chat Completions message format. The input may be a JSON array of messages
+// or an object with a "messages" array.
+//
+// "developer" messages are mapped to RoleSystem. Consecutive "tool" messages
+// are merged into a single RoleTool message, matching ChatBuilder.ToolResults.
+// Text-only content arrays are joined into Content; arrays containing images,
+// audio, or files are mapped to Parts.
+func MessagesFromOpenAIJSON(data []byte) ([]Message, error) {
+	var raw []openAIMessage
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		var wrapper struct {
+			Messages []openAIMessage `json:"messages"`
+		}
+		if err := json.Unmarshal(trimmed, &wrapper); err != nil {
+			return nil, fmt.Errorf("decode openai messages: %w", err)
+		}
+		raw = wrapper.Messages
+	} else if err := json.Unmarshal(trimmed, &raw); err != nil {
+		return nil, fmt.Errorf("decode openai messages: %w", err)
+	}
+
+	msgs := make([]Message, 0, len(raw))
+	for i, m := range raw {
+		switch m.Role {
+		case "system", "developer", "user", "assistant":
+			msg, err := messageFromOpenAI(m)
+			if err != nil {
+				return nil, fmt.Errorf("message %d: %w", i, err)
+			}
+			msgs = append(msgs, msg)
+		case "tool":
+			text, _, err := decodeOpenAIContent(m.Content)
+			if err != nil {
+				return nil, fmt.Errorf("message %d: %w", i, err)
+			}
+			result := ToolResult{CallID: m.ToolCallID, Content: text}
+			if n := len(msgs); n > 0 && msgs[n-1].Role == RoleTool {
+				msgs[n-1].ToolResults = append(msgs[n-1].ToolResults, result)
+			} else {
+				msgs = append(msgs, Message{Role: RoleTool, ToolResults: []ToolResult{result}})
+			}
+		default:
+			return nil, fmt.Errorf("message %d: unsupported role %q", i, m.Role)
+		}
+	}
+	return msgs, nil
+}
+
+func messageFromOpenAI(m openAIMessage) (Message, error) {
+	role := Role(m.Role)
+	if m.Role == "developer" {
+		role = RoleSystem
+	}
+
+	text, parts, err := decodeOpenAIContent(m.Content)
+	if err != nil {
+		return Message{}, err
+	}
+	msg := Message{Role: role, Content: text, Parts: parts}
+
+	for _, tc := range m.ToolCalls {
+		args := json.RawMessage(tc.Function.Arguments)
+		if !json.Valid(args) {
+			args, _ = json.Marshal(tc.Function.Arguments)
+		}
+		msg.ToolCalls = append(msg.ToolCalls, ToolCall{ID: tc.ID, Name: tc.Function.Name, Arguments: args})
+	}
+	return msg, nil
+}
+
+// decodeOpenAIContent decodes string, null, or array message content.
+func decodeOpenAIContent(raw json.RawMessage) (string, []ContentPart, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return "", nil, nil
+	}
+
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text, nil, nil
+	}
+
+	var rawParts []openAIContentPart
+	if err := json.Unmarshal(raw, &rawParts); err != nil {
+		return "", nil, errors.New("content must be a string or an array of parts")
+	}
+
+	var texts []string
+	parts := make([]ContentPart, 0, len(rawParts))
+	textOnly := true
+	for _, p := range rawParts {
+		switch p.Type {
+		case "text":
+			texts = append(texts, p.Text)
+			parts = append(parts, &InputText{Text: p.Text})
+		case "image_url":
+			if p.ImageURL == nil {
+				return "", nil, errors.New("image_url part missing image_url")
+			}
+			textOnly = false
+			parts = append(parts, &InputImage{ImageURL: p.ImageURL.URL, Detail: ImageDetail(p.ImageURL.Detail)})
+		case "input_audio":
+			if p.InputAudio == nil {
+				return "", nil, errors.New("input_audio part missing input_audio")
+			}
+			textOnly = false
+			parts = append(parts, &InputAudio{Data: p.InputAudio.Data, Format: AudioFormat(p.InputAudio.Format)})
+		case "file":
+			if p.File == nil {
+				return "", nil, errors.New("file part missing file")
+			}
+			textOnly = false
+			parts = append(parts, &InputFile{FileID: p.File.FileID, FileData: p.File.FileData, Filename: p.File.Filename})
+		default:
+			return "", nil, fmt.Errorf("unsupported content part type %q", p.Type)
+		}
+	}
+
+	if textOnly {
+		return strings.Join(texts, "\n"), nil, nil
+	}
+	return "", parts, nil
+}
+
+// MessagesToOpenAIJSON encodes messages as a JSON array in the OpenAI Chat
+// Completions message format, suitable for persisting conversation history.
+// RoleTool messages are expanded into one "tool" message per result, with
+// result content serialized as by DefaultToolResultPolicy.
+func MessagesToOpenAIJSON(msgs []Message) ([]byte, error) {
+	out := make([]openAIMessage, 0, len(msgs))
+	for i, msg := range msgs {
+		if msg.Role == RoleTool {
+			for _, tr := range msg.ToolResults {
+				content, _ := json.Marshal((*ToolResultPolicy)(nil).Serialize(tr.Content))
+				out = append(out, openAIMessage{Role: "tool", Content: content, ToolCallID: tr.CallID})
+			}
+			continue
+		}
+
+		m := openAIMessage{Role: string(msg.Role)}
+		content, err := encodeOpenAIContent(msg)
+		if err != nil {
+			return nil, fmt.Errorf("message %d: %w", i, err)
+		}
+		m.Content = content
+
+		for _, tc := range msg.ToolCalls {
+			call := openAIToolCall{ID: tc.ID, Type: "function"}
+			call.Function.Name = tc.Name
+			call.Function.Arguments = string(tc.Arguments)
+			m.ToolCalls = append(m.ToolCalls, call)
+		}
+		out = append(out, m)
+	}
+	return json.Marshal(out)
+}
+
+func encodeOpenAIContent(msg Message) (json.RawMessage, error) {
+	if len(msg.Parts) == 0 {
+		if msg.Content == "" && len(msg.ToolCalls) > 0 {
+			return json.RawMessage("null"), nil
+		}
+		return json.Marshal(msg.Content)
+	}
+
+	parts := make([]openAIContentPart, 0, len(msg.Parts)+1)
+	if msg.Content != "" {
+		parts = append(parts, openAIContentPart{Type: "text", Text: msg.Content})
+	}
+	for _, p := range msg.Parts {
+		// MessageBuilder stores parts as pointers; accept values as well.
+		switch v := p.(type) {
+		case *InputText:
+			p = *v
+		case *InputImage:
+			p = *v
+		case *InputFile:
+			p = *v
+		case *InputAudio:
+			p = *v
+		}
+
+		switch v := p.(type) {
+		case InputText:
+			parts = append(parts, openAIContentPart{Type: "text", Text: v.Text})
+		case InputImage:
+			if v.ImageURL == "" {
+				return nil, errors.New("image parts referencing file IDs cannot be encoded")
+			}
+			parts = append(parts, openAIContentPart{Type: "image_url", ImageURL: &openAIImageURL{URL: v.ImageURL, Detail: string(v.Detail)}})
+		case InputAudio:
+			parts = append(parts, openAIContentPart{Type: "input_audio", InputAudio: &openAIInputAudio{Data: v.Data, Format: string(v.Format)}})
+		case InputFile:
+			if v.FileURL != "" {
+				return nil, errors.New("file parts referencing URLs cannot be encoded")
+			}
+			parts = append(parts, openAIContentPart{Type: "file", File: &openAIFile{FileID: v.FileID, FileData: v.FileData, Filename: v.Filename}})
+		default:
+			return nil, fmt.Errorf("unsupported content part type %q", p.ContentType())
+		}
+	}
+	return json.Marshal(parts)
+}
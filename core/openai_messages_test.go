@@ -0,0 +1,172 @@
+package core
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const openAIHistory = `[
+	{"role": "developer", "content": "Be terse."},
+	{"role": "user", "content": [{"type": "text", "text": "Weather?"}, {"type": "text", "text": "In Paris."}]},
+	{"role": "assistant", "content": null, "tool_calls": [
+		{"id": "call_1", "type": "function", "function": {"name": "get_weather", "arguments": "{\"city\":\"Paris\"}"}},
+		{"id": "call_2", "type": "function", "function": {"name": "get_time", "arguments": "{}"}}
+	]},
+	{"role": "tool", "tool_call_id": "call_1", "content": "{\"temp\":21}"},
+	{"role": "tool", "tool_call_id": "call_2", "content": "12:00"},
+	{"role": "assistant", "content": "21C at noon."},
+	{"role": "user", "content": [{"type": "text", "text": "And this?"}, {"type": "image_url", "image_url": {"url": "https://example.com/a.png", "detail": "low"}}]}
+]`
+
+func TestMessagesFromOpenAIJSON(t *testing.T) {
+	msgs, err := MessagesFromOpenAIJSON([]byte(openAIHistory))
+	if err != nil {
+		t.Fatalf("MessagesFromOpenAIJSON() error = %v", err)
+	}
+	if len(msgs) != 6 {
+		t.Fatalf("len = %d, want 6: %+v", len(msgs), msgs)
+	}
+
+	if msgs[0].Role != RoleSystem || msgs[0].Content != "Be terse." {
+		t.Errorf("developer message = %+v", msgs[0])
+	}
+	if msgs[1].Content != "Weather?\nIn Paris." || len(msgs[1].Parts) != 0 {
+		t.Errorf("text-only parts not joined: %+v", msgs[1])
+	}
+
+	calls := msgs[2].ToolCalls
+	if len(calls) != 2 || calls[0].Name != "get_weather" || string(calls[0].Arguments) != `{"city":"Paris"}` {
+		t.Errorf("tool calls = %+v", calls)
+	}
+
+	if msgs[3].Role != RoleTool || len(msgs[3].ToolResults) != 2 {
+		t.Fatalf("tool results not merged: %+v", msgs[3])
+	}
+	if msgs[3].ToolResults[1].CallID != "call_2" || msgs[3].ToolResults[1].Content != "12:00" {
+		t.Errorf("tool result = %+v", msgs[3].ToolResults[1])
+	}
+
+	parts := msgs[5].Parts
+	if len(parts) != 2 {
+		t.Fatalf("parts = %+v", parts)
+	}
+	if img, ok := parts[1].(*InputImage); !ok || img.ImageURL != "https://example.com/a.png" || img.Detail != ImageDetailLow {
+		t.Errorf("image part = %+v", parts[1])
+	}
+}
+
+func TestMessagesFromOpenAIJSONWrapperObject(t *testing.T) {
+	msgs, err := MessagesFromOpenAIJSON([]byte(`{"model": "gpt-4o", "messages": [{"role": "user", "content": "hi"}]}`))
+	if err != nil {
+		t.Fatalf("error = %v", err)
+	}
+	if len(msgs) != 1 || msgs[0].Content != "hi" {
+		t.Errorf("msgs = %+v", msgs)
+	}
+}
+
+func TestMessagesFromOpenAIJSONErrors(t *testing.T) {
+	tests := []string{
+		`not json`,
+		`[{"role": "narrator", "content": "x"}]`,
+		`[{"role": "user", "content": 42}]`,
+		`[{"role": "user", "content": [{"type": "audio"}]}]`,
+		`[{"role": "user", "content": [{"type": "image_url"}]}]`,
+	}
+	for _, in := range tests {
+		if _, err := MessagesFromOpenAIJSON([]byte(in)); err == nil {
+			t.Errorf("expected error for %s", in)
+		}
+	}
+}
+
+func TestMessagesToOpenAIJSONRoundTrip(t *testing.T) {
+	msgs, err := MessagesFromOpenAIJSON([]byte(openAIHistory))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := MessagesToOpenAIJSON(msgs)
+	if err != nil {
+		t.Fatalf("MessagesToOpenAIJSON() error = %v", err)
+	}
+
+	var raw []map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		t.Fatal(err)
+	}
+	if len(raw) != 7 {
+		t.Fatalf("expected tool results expanded to 7 messages, got %d", len(raw))
+	}
+	if raw[2]["content"] != nil {
+		t.Errorf("assistant tool call content = %v, want null", raw[2]["content"])
+	}
+	if raw[3]["role"] != "tool" || raw[3]["tool_call_id"] != "call_1" {
+		t.Errorf("tool message = %v", raw[3])
+	}
+	fn := raw[2]["tool_calls"].([]any)[0].(map[string]any)["function"].(map[string]any)
+	if fn["arguments"] != `{"city":"Paris"}` {
+		t.Errorf("arguments = %v", fn["arguments"])
+	}
+
+	again, err := MessagesFromOpenAIJSON(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != len(msgs) {
+		t.Errorf("round trip changed message count: %d vs %d", len(again), len(msgs))
+	}
+}
+
+func TestMessagesToOpenAIJSONToolResultContent(t *testing.T) {
+	data, err := MessagesToOpenAIJSON([]Message{{
+		Role:        RoleTool,
+		ToolResults: []ToolResult{{CallID: "c1", Content: map[string]int{"n": 1}}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"content":"{\"n\":1}"`) {
+		t.Errorf("tool result not serialized as string: %s", data)
+	}
+}
+
+func TestChatBuilderMessages(t *testing.T) {
+	provider := &mockProvider{id: "mock"}
+	client := NewClient(provider)
+
+	history := []Message{
+		{Role: RoleSystem, Content: "sys"},
+		{Role: RoleUser, Content: "q1"},
+		{Role: RoleAssistant, Content: "a1"},
+	}
+	builder := client.Chat("mock-model").Messages(history...).User("q2")
+	history[0].Content = "changed"
+
+	spec := builder.Spec()
+	got := spec.Messages()
+	if len(got) != 4 || got[0].Content != "sys" || got[3].Content != "q2" {
+		t.Errorf("messages = %+v", got)
+	}
+}
+
+func TestMessagesOpenAIJSONAudio(t *testing.T) {
+	in := `[{"role": "user", "content": [{"type": "text", "text": "Listen"}, {"type": "input_audio", "input_audio": {"data": "UklGRg==", "format": "wav"}}]}]`
+	msgs, err := MessagesFromOpenAIJSON([]byte(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	audio, ok := msgs[0].Parts[1].(*InputAudio)
+	if !ok || audio.Format != AudioFormatWAV || audio.Data != "UklGRg==" {
+		t.Fatalf("audio part = %#v", msgs[0].Parts[1])
+	}
+
+	out, err := MessagesToOpenAIJSON(msgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), `"input_audio":{"data":"UklGRg==","format":"wav"}`) {
+		t.Errorf("encoded = %s", out)
+	}
+}
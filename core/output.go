@@ -0,0 +1,104 @@
+package core
+
+import "encoding/base64"
+
+// OutputPart represents a part of multimodal model output.
+// Providers populate ChatResponse.Parts and ChatChunk.Parts with values
+// implementing this interface when a model returns more than plain text.
+type OutputPart interface {
+	// OutputType returns the type identifier for this output part.
+	OutputType() string
+}
+
+// OutputText represents text output from the model.
+type OutputText struct {
+	// Text is the text content.
+	Text string
+}
+
+// OutputType returns the type identifier for OutputText.
+func (t OutputText) OutputType() string {
+	return "output_text"
+}
+
+// OutputImage represents an image generated inline by the model.
+type OutputImage struct {
+	// MimeType is the image MIME type (e.g. "image/png").
+	MimeType string
+	// Data contains base64-encoded image bytes.
+	Data string
+	// URL is set instead of Data when the provider returns a hosted image.
+	URL string
+}
+
+// OutputType returns the type identifier for OutputImage.
+func (i OutputImage) OutputType() string {
+	return "output_image"
+}
+
+// Bytes decodes the image data. It returns nil if the image is only
+// available by URL.
+func (i OutputImage) Bytes() ([]byte, error) {
+	if i.Data == "" {
+		return nil, nil
+	}
+	return base64.StdEncoding.DecodeString(i.Data)
+}
+
+// OutputAudio represents audio generated by the model.
+type OutputAudio struct {
+	// ID identifies the audio response for providers that allow referencing
+	// it in later turns.
+	ID string
+	// MimeType is the audio MIME type (e.g. "audio/wav"), if known.
+	MimeType string
+	// Data contains base64-encoded audio bytes.
+	Data string
+	// Transcript is the text transcript of the audio, if provided.
+	Transcript string
+}
+
+// OutputType returns the type identifier for OutputAudio.
+func (a OutputAudio) OutputType() string {
+	return "output_audio"
+}
+
+// Bytes decodes the audio data.
+func (a OutputAudio) Bytes() ([]byte, error) {
+	if a.Data == "" {
+		return nil, nil
+	}
+	return base64.StdEncoding.DecodeString(a.Data)
+}
+
+// Images returns the image parts of the response in order.
+func (r *ChatResponse) Images() []OutputImage {
+	var images []OutputImage
+	for _, part := range r.Parts {
+		if img, ok := part.(OutputImage); ok {
+			images = append(images, img)
+		}
+	}
+	return images
+}
+
+// Audio returns the audio parts of the response in order.
+func (r *ChatResponse) Audio() []OutputAudio {
+	var audio []OutputAudio
+	for _, part := range r.Parts {
+		if a, ok := part.(OutputAudio); ok {
+			audio = append(audio, a)
+		}
+	}
+	return audio
+}
+
+// HasMedia reports whether the response contains non-text output parts.
+func (r *ChatResponse) HasMedia() bool {
+	for _, part := range r.Parts {
+		if _, ok := part.(OutputText); !ok {
+			return true
+		}
+	}
+	return false
+}
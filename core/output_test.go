@@ -0,0 +1,71 @@
+package core
+
+import "testing"
+
+func TestOutputPartTypes(t *testing.T) {
+	tests := []struct {
+		part OutputPart
+		want string
+	}{
+		{OutputText{Text: "hi"}, "output_text"},
+		{OutputImage{MimeType: "image/png"}, "output_image"},
+		{OutputAudio{MimeType: "audio/wav"}, "output_audio"},
+	}
+	for _, tt := range tests {
+		if got := tt.part.OutputType(); got != tt.want {
+			t.Errorf("%T.OutputType() = %q, want %q", tt.part, got, tt.want)
+		}
+	}
+}
+
+func TestOutputImageBytes(t *testing.T) {
+	img := OutputImage{MimeType: "image/png", Data: "aW1hZ2U="}
+	data, err := img.Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	if string(data) != "image" {
+		t.Errorf("Bytes() = %q, want %q", data, "image")
+	}
+
+	hosted := OutputImage{URL: "https://example.com/a.png"}
+	data, err = hosted.Bytes()
+	if err != nil || data != nil {
+		t.Errorf("Bytes() for URL image = %v, %v; want nil, nil", data, err)
+	}
+}
+
+func TestOutputAudioBytesInvalid(t *testing.T) {
+	if _, err := (OutputAudio{Data: "not base64!"}).Bytes(); err == nil {
+		t.Error("Bytes() expected error for invalid base64")
+	}
+}
+
+func TestChatResponseMediaAccessors(t *testing.T) {
+	resp := &ChatResponse{
+		Output: "Here you go.",
+		Parts: []OutputPart{
+			OutputText{Text: "Here you go."},
+			OutputImage{MimeType: "image/png", Data: "YQ=="},
+			OutputAudio{ID: "audio-1", Data: "Yg=="},
+			OutputImage{MimeType: "image/jpeg", Data: "Yw=="},
+		},
+	}
+
+	if !resp.HasMedia() {
+		t.Error("HasMedia() = false, want true")
+	}
+	images := resp.Images()
+	if len(images) != 2 || images[1].MimeType != "image/jpeg" {
+		t.Errorf("Images() = %+v, want two images in order", images)
+	}
+	audio := resp.Audio()
+	if len(audio) != 1 || audio[0].ID != "audio-1" {
+		t.Errorf("Audio() = %+v, want one audio part", audio)
+	}
+
+	textOnly := &ChatResponse{Output: "plain"}
+	if textOnly.HasMedia() || textOnly.Images() != nil || textOnly.Audio() != nil {
+		t.Error("text-only response should report no media")
+	}
+}
@@ -0,0 +1,233 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+)
+
+// InstructionsMode controls how ChatRequest.Instructions reaches the
+// provider.
+type InstructionsMode string
+
+const (
+	// InstructionsAuto sends Instructions natively when the provider
+	// reports FeatureInstructions for the model and the request has no
+	// system messages, and as a leading system message otherwise. Native
+	// instructions would replace the system messages on some APIs, so
+	// converting keeps both.
+	InstructionsAuto InstructionsMode = ""
+
+	// InstructionsSystem always sends Instructions as a leading system
+	// message.
+	InstructionsSystem InstructionsMode = "system"
+
+	// InstructionsNative always passes Instructions through unchanged.
+	// Providers without native instructions drop them, which is reported
+	// like any other unsupported parameter.
+	InstructionsNative InstructionsMode = "native"
+)
+
+// DefaultPromptSeparator joins merged system prompts. It matches how the
+// Anthropic and Gemini providers join multiple system messages.
+const DefaultPromptSeparator = "\n\n"
+
+// PromptAssembly is the policy for combining Instructions and system
+// messages before a request reaches the provider. Providers differ here:
+// some accept Instructions natively, some extract every system message to
+// the front and join them, others keep each one in place. The zero value
+// converts Instructions for providers that lack them and leaves system
+// messages alone; HoistSystem and MergeSystem make every provider see the
+// same single leading system prompt.
+type PromptAssembly struct {
+	// Instructions controls how ChatRequest.Instructions is sent.
+	Instructions InstructionsMode
+
+	// HoistSystem moves system messages before all other messages,
+	// keeping their relative order.
+	HoistSystem bool
+
+	// MergeSystem joins all system messages into one, placed where the
+	// first of them was. Content is joined with Separator; Parts are
+	// concatenated.
+	MergeSystem bool
+
+	// Separator joins merged system messages. Empty means
+	// DefaultPromptSeparator.
+	Separator string
+}
+
+// WithPromptAssembly sets the policy for combining Instructions and system
+// messages. Without it, the zero PromptAssembly is used.
+func WithPromptAssembly(pa PromptAssembly) ClientOption {
+	return func(c *Client) {
+		c.promptAssembly = pa
+	}
+}
+
+// Assemble returns req with the policy applied. provider and the request
+// model decide whether Instructions can be sent natively. req is not
+// modified; if the policy changes nothing, req itself is returned.
+func (pa PromptAssembly) Assemble(p Provider, req *ChatRequest) *ChatRequest {
+	convert := req.Instructions != "" && pa.convertInstructions(p, req)
+	hoist := pa.HoistSystem && systemAfterOther(req.Messages)
+	merge := pa.MergeSystem && countSystem(req.Messages)+boolInt(convert) > 1
+	if !convert && !hoist && !merge {
+		return req
+	}
+
+	out := *req
+	msgs := make([]Message, 0, len(req.Messages)+1)
+	if convert {
+		msgs = append(msgs, Message{Role: RoleSystem, Content: req.Instructions})
+		out.Instructions = ""
+	}
+	msgs = append(msgs, req.Messages...)
+	if hoist {
+		msgs = hoistSystem(msgs)
+	}
+	if merge {
+		msgs = mergeSystem(msgs, pa.separator())
+	}
+	out.Messages = msgs
+	return &out
+}
+
+// convertInstructions reports whether Instructions should become a system
+// message.
+func (pa PromptAssembly) convertInstructions(p Provider, req *ChatRequest) bool {
+	switch pa.Instructions {
+	case InstructionsSystem:
+		return true
+	case InstructionsNative:
+		return false
+	default:
+		return countSystem(req.Messages) > 0 || !SupportsModel(p, req.Model, FeatureInstructions)
+	}
+}
+
+func (pa PromptAssembly) separator() string {
+	if pa.Separator == "" {
+		return DefaultPromptSeparator
+	}
+	return pa.Separator
+}
+
+// assemblePrompt applies the client's prompt assembly policy to req.
+func (c *Client) assemblePrompt(req *ChatRequest) *ChatRequest {
+	return c.promptAssembly.Assemble(c.provider, req)
+}
+
+// callerPrompt returns sent with the Instructions and messages of orig, the
+// request before prompt assembly. Capture records it, so sinks see the
+// prompt as the caller wrote it, with Instructions kept apart from the
+// messages.
+func callerPrompt(sent, orig *ChatRequest) *ChatRequest {
+	if sent == orig {
+		return sent
+	}
+	out := *sent
+	out.Instructions = orig.Instructions
+	out.Messages = orig.Messages
+	return &out
+}
+
+// checkInstructions reports Instructions that the provider would drop
+// because the policy passes them through unchanged.
+func (b *ChatBuilder) checkInstructions() error {
+	p := b.client.provider
+	if b.req.Instructions == "" || b.client.promptAssembly.Instructions != InstructionsNative ||
+		SupportsModel(p, b.req.Model, FeatureInstructions) {
+		return nil
+	}
+	msg := fmt.Sprintf("Instructions is not supported by provider %q for model %q", p.ID(), b.req.Model)
+	if b.client.strictParams {
+		return fmt.Errorf("%w: %s", ErrUnsupportedParameter, msg)
+	}
+	b.client.warn(Warning{
+		Code:      WarningUnsupportedParameter,
+		Provider:  p.ID(),
+		Model:     b.req.Model,
+		Parameter: "Instructions",
+		Message:   msg + " and will be ignored; use InstructionsAuto or InstructionsSystem to send it as a system message",
+	})
+	return nil
+}
+
+// countSystem returns the number of system messages in msgs.
+func countSystem(msgs []Message) int {
+	n := 0
+	for _, m := range msgs {
+		if m.Role == RoleSystem {
+			n++
+		}
+	}
+	return n
+}
+
+// systemAfterOther reports whether a system message follows a message of
+// another role.
+func systemAfterOther(msgs []Message) bool {
+	seenOther := false
+	for _, m := range msgs {
+		if m.Role != RoleSystem {
+			seenOther = true
+		} else if seenOther {
+			return true
+		}
+	}
+	return false
+}
+
+// hoistSystem moves system messages to the front, keeping the relative
+// order of both groups. msgs must be owned by the caller.
+func hoistSystem(msgs []Message) []Message {
+	out := make([]Message, 0, len(msgs))
+	for _, m := range msgs {
+		if m.Role == RoleSystem {
+			out = append(out, m)
+		}
+	}
+	for _, m := range msgs {
+		if m.Role != RoleSystem {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// mergeSystem replaces all system messages with one at the position of the
+// first.
+func mergeSystem(msgs []Message, sep string) []Message {
+	var contents []string
+	var parts []ContentPart
+	for _, m := range msgs {
+		if m.Role != RoleSystem {
+			continue
+		}
+		if m.Content != "" {
+			contents = append(contents, m.Content)
+		}
+		parts = append(parts, m.Parts...)
+	}
+
+	out := make([]Message, 0, len(msgs))
+	merged := false
+	for _, m := range msgs {
+		if m.Role != RoleSystem {
+			out = append(out, m)
+			continue
+		}
+		if !merged {
+			out = append(out, Message{Role: RoleSystem, Content: strings.Join(contents, sep), Parts: parts})
+			merged = true
+		}
+	}
+	return out
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
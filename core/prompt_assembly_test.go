@@ -0,0 +1,189 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// instructionsProvider is a mock provider with native instructions.
+type instructionsProvider struct {
+	*mockProvider
+}
+
+func (p instructionsProvider) SupportsModel(_ ModelID, feature Feature) bool {
+	return feature == FeatureInstructions || p.Supports(feature)
+}
+
+func TestPromptAssemblyAssemble(t *testing.T) {
+	native := instructionsProvider{&mockProvider{id: "native"}}
+	plain := &mockProvider{id: "plain"}
+
+	sys := func(s string) Message { return Message{Role: RoleSystem, Content: s} }
+	user := func(s string) Message { return Message{Role: RoleUser, Content: s} }
+
+	tests := []struct {
+		name             string
+		pa               PromptAssembly
+		provider         Provider
+		req              ChatRequest
+		wantInstructions string
+		wantMessages     []Message
+	}{
+		{
+			name:             "auto keeps native instructions",
+			provider:         native,
+			req:              ChatRequest{Instructions: "be brief", Messages: []Message{user("hi")}},
+			wantInstructions: "be brief",
+			wantMessages:     []Message{user("hi")},
+		},
+		{
+			name:         "auto converts without native instructions",
+			provider:     plain,
+			req:          ChatRequest{Instructions: "be brief", Messages: []Message{user("hi")}},
+			wantMessages: []Message{sys("be brief"), user("hi")},
+		},
+		{
+			name:         "auto converts alongside system messages",
+			provider:     native,
+			req:          ChatRequest{Instructions: "be brief", Messages: []Message{sys("you are a bot"), user("hi")}},
+			wantMessages: []Message{sys("be brief"), sys("you are a bot"), user("hi")},
+		},
+		{
+			name:         "system mode always converts",
+			pa:           PromptAssembly{Instructions: InstructionsSystem},
+			provider:     native,
+			req:          ChatRequest{Instructions: "be brief", Messages: []Message{user("hi")}},
+			wantMessages: []Message{sys("be brief"), user("hi")},
+		},
+		{
+			name:             "native mode passes through",
+			pa:               PromptAssembly{Instructions: InstructionsNative},
+			provider:         plain,
+			req:              ChatRequest{Instructions: "be brief", Messages: []Message{user("hi")}},
+			wantInstructions: "be brief",
+			wantMessages:     []Message{user("hi")},
+		},
+		{
+			name:         "hoist",
+			pa:           PromptAssembly{HoistSystem: true},
+			provider:     plain,
+			req:          ChatRequest{Messages: []Message{user("hi"), sys("a"), user("bye"), sys("b")}},
+			wantMessages: []Message{sys("a"), sys("b"), user("hi"), user("bye")},
+		},
+		{
+			name:         "merge in place",
+			pa:           PromptAssembly{MergeSystem: true},
+			provider:     plain,
+			req:          ChatRequest{Messages: []Message{user("hi"), sys("a"), user("bye"), sys("b")}},
+			wantMessages: []Message{user("hi"), sys("a\n\nb"), user("bye")},
+		},
+		{
+			name:         "convert hoist and merge",
+			pa:           PromptAssembly{HoistSystem: true, MergeSystem: true, Separator: "\n"},
+			provider:     plain,
+			req:          ChatRequest{Instructions: "i", Messages: []Message{user("hi"), sys("a")}},
+			wantMessages: []Message{sys("i\na"), user("hi")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			orig := append([]Message(nil), tt.req.Messages...)
+			got := tt.pa.Assemble(tt.provider, &tt.req)
+			if got.Instructions != tt.wantInstructions {
+				t.Errorf("Instructions = %q, want %q", got.Instructions, tt.wantInstructions)
+			}
+			if !reflect.DeepEqual(got.Messages, tt.wantMessages) {
+				t.Errorf("Messages = %+v, want %+v", got.Messages, tt.wantMessages)
+			}
+			if !reflect.DeepEqual(tt.req.Messages, orig) {
+				t.Errorf("Assemble modified the request messages: %+v", tt.req.Messages)
+			}
+		})
+	}
+}
+
+func TestPromptAssemblyUnchangedReturnsRequest(t *testing.T) {
+	req := &ChatRequest{Messages: []Message{{Role: RoleSystem, Content: "a"}, {Role: RoleUser, Content: "hi"}}}
+	pa := PromptAssembly{HoistSystem: true, MergeSystem: true}
+	if got := pa.Assemble(&mockProvider{id: "mock"}, req); got != req {
+		t.Error("Assemble() copied a request it did not change")
+	}
+}
+
+func TestClientAppliesPromptAssembly(t *testing.T) {
+	provider := &mockProvider{id: "mock"}
+	client := NewClient(provider, WithPromptAssembly(PromptAssembly{MergeSystem: true}))
+
+	builder := client.Chat("mock-model").
+		Instructions("be brief").
+		System("you are a bot").
+		User("hi")
+	if _, err := builder.GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+
+	want := []Message{
+		{Role: RoleSystem, Content: "be brief\n\nyou are a bot"},
+		{Role: RoleUser, Content: "hi"},
+	}
+	if provider.lastRequest.Instructions != "" {
+		t.Errorf("Instructions = %q, want empty", provider.lastRequest.Instructions)
+	}
+	if !reflect.DeepEqual(provider.lastRequest.Messages, want) {
+		t.Errorf("Messages = %+v, want %+v", provider.lastRequest.Messages, want)
+	}
+	if builder.req.Instructions != "be brief" || len(builder.req.Messages) != 2 {
+		t.Errorf("builder request modified: %+v", builder.req)
+	}
+}
+
+func TestCaptureRecordsPromptBeforeAssembly(t *testing.T) {
+	provider := &mockProvider{id: "mock"}
+	sink := &captureRecorder{}
+	client := NewClient(provider, WithCapture(sink))
+
+	if _, err := client.Chat("mock-model").Instructions("be brief").User("hi").GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if provider.lastRequest.Instructions != "" || len(provider.lastRequest.Messages) != 2 {
+		t.Fatalf("provider request not assembled: %+v", provider.lastRequest)
+	}
+
+	if len(sink.records) != 1 {
+		t.Fatalf("records = %d, want 1", len(sink.records))
+	}
+	req := sink.records[0].Request
+	if req.Instructions != "be brief" {
+		t.Errorf("captured Instructions = %q, want %q", req.Instructions, "be brief")
+	}
+	want := []Message{{Role: RoleUser, Content: "hi"}}
+	if !reflect.DeepEqual(req.Messages, want) {
+		t.Errorf("captured Messages = %+v, want %+v", req.Messages, want)
+	}
+}
+
+func TestNativeInstructionsUnsupported(t *testing.T) {
+	var warnings []Warning
+	client := NewClient(&mockProvider{id: "mock"},
+		WithPromptAssembly(PromptAssembly{Instructions: InstructionsNative}),
+		WithWarningListener(func(w Warning) { warnings = append(warnings, w) }),
+	)
+	if _, err := client.Chat("mock-model").Instructions("be brief").User("hi").GetResponse(context.Background()); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].Code != WarningUnsupportedParameter || warnings[0].Parameter != "Instructions" {
+		t.Errorf("warnings = %+v", warnings)
+	}
+
+	strict := NewClient(&mockProvider{id: "mock"},
+		WithPromptAssembly(PromptAssembly{Instructions: InstructionsNative}),
+		WithStrictParameters(true),
+	)
+	_, err := strict.Chat("mock-model").Instructions("be brief").User("hi").GetResponse(context.Background())
+	if !errors.Is(err, ErrUnsupportedParameter) {
+		t.Errorf("error = %v, want ErrUnsupportedParameter", err)
+	}
+}
@@ -0,0 +1,160 @@
+package core
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ProviderConfig describes a provider to construct by name.
+type ProviderConfig struct {
+	// Name is the registered provider name, such as "openai" or "ollama".
+	Name string
+
+	// APIKey is the credential. When empty, factories fall back to the
+	// provider's usual environment variable.
+	APIKey Secret
+
+	// Credentials, if set, supplies the API key for each request instead of
+	// APIKey, so keys can rotate. Factories then do not require an API key.
+	Credentials CredentialProvider
+
+	// BaseURL overrides the provider's default endpoint.
+	BaseURL string
+
+	// Model is the default model, if the configuration named one.
+	// Factories do not use it; it is carried for the caller.
+	Model ModelID
+}
+
+// APIKeyOrEnv returns the configured API key, or the first non-empty value of
+// the given environment variables.
+func (c ProviderConfig) APIKeyOrEnv(envVars ...string) string {
+	if key := c.APIKey.Expose(); key != "" {
+		return key
+	}
+	for _, name := range envVars {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// ProviderFactory constructs a provider from configuration.
+type ProviderFactory func(cfg ProviderConfig) (Provider, error)
+
+var (
+	providerFactoriesMu sync.RWMutex
+	providerFactories   = make(map[string]ProviderFactory)
+)
+
+// RegisterProvider makes a provider constructible by name through
+// NewProviderFromConfig. Provider packages call it from init, so importing a
+// provider package (even as _) is enough to register it. Registering a name
+// again replaces the previous factory.
+func RegisterProvider(name string, factory ProviderFactory) {
+	providerFactoriesMu.Lock()
+	defer providerFactoriesMu.Unlock()
+	providerFactories[name] = factory
+}
+
+// RegisteredProviders returns the registered provider names in sorted order.
+func RegisteredProviders() []string {
+	providerFactoriesMu.RLock()
+	defer providerFactoriesMu.RUnlock()
+
+	names := make([]string, 0, len(providerFactories))
+	for name := range providerFactories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// NewProviderFromConfig constructs the provider registered under cfg.Name.
+// It returns an error wrapping ErrUnknownProvider if the name is not
+// registered, which usually means the provider package was not imported.
+func NewProviderFromConfig(cfg ProviderConfig) (Provider, error) {
+	providerFactoriesMu.RLock()
+	factory, ok := providerFactories[cfg.Name]
+	providerFactoriesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (registered: %v)", ErrUnknownProvider, cfg.Name, RegisteredProviders())
+	}
+	return factory(cfg)
+}
+
+// NewProviderFromString parses s with ParseProviderString and constructs the
+// provider. It also returns the model named in s, if any.
+//
+//	provider, model, err := core.NewProviderFromString("openai:gpt-4o")
+//	client := core.NewClient(provider)
+//	resp, err := client.Chat(model).User("Hello").GetResponse(ctx)
+func NewProviderFromString(s string) (Provider, ModelID, error) {
+	cfg, err := ParseProviderString(s)
+	if err != nil {
+		return nil, "", err
+	}
+	p, err := NewProviderFromConfig(cfg)
+	if err != nil {
+		return nil, "", err
+	}
+	return p, cfg.Model, nil
+}
+
+// ParseProviderString parses a provider string. The accepted forms are:
+//
+//	openai                                      provider only
+//	openai:gpt-4o                               provider and model
+//	ollama@http://host:11434                    provider and base URL
+//	ollama@http://host:11434/llama3.2           provider, base URL, and model
+//	openai:gpt-4o@https://proxy.example.com/v1  provider, model, and base URL
+//
+// Everything after the first colon and before the "@" is the model, so models
+// containing colons such as "ollama:llama3.2:3b" work. When the model is given
+// that way, the whole URL is the base URL.
+//
+// Otherwise the last segment of the URL path is the model, unless it is an
+// API version such as "v1" or "v1beta", which stays part of the base URL:
+// "openai@http://localhost:8080/v1" has no model, and
+// "openaicompat@http://localhost:8000/v1/qwen3" has model "qwen3". Use the
+// "name:model@url" form for base URLs whose path ends in something else, or
+// for models containing slashes.
+func ParseProviderString(s string) (ProviderConfig, error) {
+	s = strings.TrimSpace(s)
+
+	spec, rawURL, hasURL := strings.Cut(s, "@")
+	name, model, _ := strings.Cut(spec, ":")
+	if name == "" {
+		return ProviderConfig{}, fmt.Errorf("%w: %q", ErrInvalidProviderString, s)
+	}
+	cfg := ProviderConfig{Name: name, Model: ModelID(model)}
+	if !hasURL {
+		return cfg, nil
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return ProviderConfig{}, fmt.Errorf("%w: %q has no valid base URL", ErrInvalidProviderString, s)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if cfg.Model == "" {
+		if i := strings.LastIndexByte(u.Path, '/'); i >= 0 && !apiVersionSegment.MatchString(u.Path[i+1:]) {
+			cfg.Model = ModelID(u.Path[i+1:])
+			u.Path = u.Path[:i]
+		}
+	}
+	u.RawPath = ""
+	cfg.BaseURL = strings.TrimSuffix(u.String(), "/")
+	return cfg, nil
+}
+
+// apiVersionSegment matches URL path segments that name an API version,
+// such as "v1", "v2", or "v1beta".
+var apiVersionSegment = regexp.MustCompile(`^v\d+([a-z]+\d*)?$`)
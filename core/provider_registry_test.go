@@ -0,0 +1,96 @@
+package core
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseProviderString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want ProviderConfig
+	}{
+		{"openai", ProviderConfig{Name: "openai"}},
+		{"openai:gpt-4o", ProviderConfig{Name: "openai", Model: "gpt-4o"}},
+		{"ollama:llama3.2:3b", ProviderConfig{Name: "ollama", Model: "llama3.2:3b"}},
+		{"ollama@http://host:11434", ProviderConfig{Name: "ollama", BaseURL: "http://host:11434"}},
+		{"ollama@http://host:11434/", ProviderConfig{Name: "ollama", BaseURL: "http://host:11434"}},
+		{"ollama:llama3.2@http://host:11434", ProviderConfig{Name: "ollama", BaseURL: "http://host:11434", Model: "llama3.2"}},
+		{"ollama:llama3.2:3b@http://host:11434", ProviderConfig{Name: "ollama", BaseURL: "http://host:11434", Model: "llama3.2:3b"}},
+		{"ollama@http://host:11434/llama3.2", ProviderConfig{Name: "ollama", BaseURL: "http://host:11434", Model: "llama3.2"}},
+		{"ollama@http://host:11434/llama3.2/", ProviderConfig{Name: "ollama", BaseURL: "http://host:11434", Model: "llama3.2"}},
+		{"openai@http://localhost:8080/v1", ProviderConfig{Name: "openai", BaseURL: "http://localhost:8080/v1"}},
+		{"openai@http://localhost:8080/v1/", ProviderConfig{Name: "openai", BaseURL: "http://localhost:8080/v1"}},
+		{"gemini@https://proxy.example.com/v1beta", ProviderConfig{Name: "gemini", BaseURL: "https://proxy.example.com/v1beta"}},
+		{"openai@https://proxy.example.com/openai/v1", ProviderConfig{Name: "openai", BaseURL: "https://proxy.example.com/openai/v1"}},
+		{"openaicompat@http://localhost:8000/v1/qwen3", ProviderConfig{Name: "openaicompat", BaseURL: "http://localhost:8000/v1", Model: "qwen3"}},
+		{"openai:gpt-4o@https://proxy.example.com/v1", ProviderConfig{Name: "openai", BaseURL: "https://proxy.example.com/v1", Model: "gpt-4o"}},
+		{"openai:gpt-4o@https://proxy.example.com/openai/v1", ProviderConfig{Name: "openai", BaseURL: "https://proxy.example.com/openai/v1", Model: "gpt-4o"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseProviderString(tt.in)
+			if err != nil {
+				t.Fatalf("ParseProviderString() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseProviderString() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseProviderStringInvalid(t *testing.T) {
+	for _, in := range []string{"", ":gpt-4o", "@http://host", ":gpt-4o@http://host", "ollama@", "ollama:llama3.2@", "ollama@not a url"} {
+		if _, err := ParseProviderString(in); !errors.Is(err, ErrInvalidProviderString) {
+			t.Errorf("ParseProviderString(%q) error = %v, want ErrInvalidProviderString", in, err)
+		}
+	}
+}
+
+func TestNewProviderFromString(t *testing.T) {
+	var got ProviderConfig
+	RegisterProvider("registry-test", func(cfg ProviderConfig) (Provider, error) {
+		got = cfg
+		return &mockProvider{id: "registry-test"}, nil
+	})
+
+	p, model, err := NewProviderFromString("registry-test:my-model@http://localhost:8080/v1")
+	if err != nil {
+		t.Fatalf("NewProviderFromString() error = %v", err)
+	}
+	if p.ID() != "registry-test" || model != "my-model" {
+		t.Errorf("provider = %q, model = %q", p.ID(), model)
+	}
+	if got.BaseURL != "http://localhost:8080/v1" {
+		t.Errorf("factory BaseURL = %q", got.BaseURL)
+	}
+
+	found := false
+	for _, name := range RegisteredProviders() {
+		found = found || name == "registry-test"
+	}
+	if !found {
+		t.Error("RegisteredProviders() does not include registry-test")
+	}
+}
+
+func TestNewProviderFromConfigUnknown(t *testing.T) {
+	if _, err := NewProviderFromConfig(ProviderConfig{Name: "no-such-provider"}); !errors.Is(err, ErrUnknownProvider) {
+		t.Errorf("error = %v, want ErrUnknownProvider", err)
+	}
+}
+
+func TestAPIKeyOrEnv(t *testing.T) {
+	t.Setenv("IRIS_TEST_KEY_A", "")
+	t.Setenv("IRIS_TEST_KEY_B", "from-env")
+
+	if got := (ProviderConfig{}).APIKeyOrEnv("IRIS_TEST_KEY_A", "IRIS_TEST_KEY_B"); got != "from-env" {
+		t.Errorf("APIKeyOrEnv() = %q, want from-env", got)
+	}
+	cfg := ProviderConfig{APIKey: NewSecret("explicit")}
+	if got := cfg.APIKeyOrEnv("IRIS_TEST_KEY_B"); got != "explicit" {
+		t.Errorf("APIKeyOrEnv() = %q, want explicit", got)
+	}
+}
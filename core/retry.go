@@ -26,6 +26,8 @@ type RetryConfig struct {
 
 // DefaultRetryPolicy returns a retry policy with sensible defaults.
 // Uses exponential backoff with jitter, max 3 retries, 30s max delay.
+// Policies created by NewRetryPolicy wait at least ProviderError.RetryAfter,
+// and stop retrying if it exceeds the maximum delay.
 func DefaultRetryPolicy() RetryPolicy {
 	return NewRetryPolicy(RetryConfig{
 		MaxRetries: 3,
@@ -67,6 +69,17 @@ func (e *exponentialBackoff) NextDelay(attempt int, err error) (time.Duration, b
 		return 0, false
 	}
 
+	// Honour the provider's requested wait. A wait longer than the
+	// maximum delay would fail again, so give up instead.
+	var retryAfter time.Duration
+	var pe *ProviderError
+	if errors.As(err, &pe) {
+		retryAfter = pe.RetryAfter
+	}
+	if retryAfter > e.cfg.MaxDelay {
+		return 0, false
+	}
+
 	// Calculate exponential backoff: baseDelay * 2^attempt
 	delay := float64(e.cfg.BaseDelay) * math.Pow(2, float64(attempt))
 
@@ -82,10 +95,13 @@ func (e *exponentialBackoff) NextDelay(attempt int, err error) (time.Duration, b
 		delay = float64(e.cfg.MaxDelay)
 	}
 
-	// Ensure non-negative
+	// Ensure non-negative, and at least the requested wait
 	if delay < 0 {
 		delay = 0
 	}
+	if delay < float64(retryAfter) {
+		delay = float64(retryAfter)
+	}
 
 	return time.Duration(delay), true
 }
@@ -155,6 +155,36 @@ func TestRetryPolicyMaxDelayCap(t *testing.T) {
 	}
 }
 
+func TestRetryPolicyRetryAfter(t *testing.T) {
+	policy := NewRetryPolicy(RetryConfig{
+		MaxRetries: 3,
+		BaseDelay:  time.Second,
+		MaxDelay:   30 * time.Second,
+		Jitter:     0,
+	})
+
+	err := &ProviderError{Status: 429, Err: ErrRateLimited, RetryAfter: 12 * time.Second}
+	delay, ok := policy.NextDelay(0, err)
+	if !ok {
+		t.Fatal("should allow retry")
+	}
+	if delay != 12*time.Second {
+		t.Errorf("delay = %v, want RetryAfter 12s", delay)
+	}
+
+	// Backoff longer than RetryAfter wins
+	err.RetryAfter = time.Second
+	if delay, _ := policy.NextDelay(2, err); delay != 4*time.Second {
+		t.Errorf("delay = %v, want backoff 4s", delay)
+	}
+
+	// A wait beyond MaxDelay is not retried
+	err.RetryAfter = time.Hour
+	if _, ok := policy.NextDelay(0, err); ok {
+		t.Error("should not retry when RetryAfter exceeds MaxDelay")
+	}
+}
+
 func TestRetryPolicyJitter(t *testing.T) {
 	policy := NewRetryPolicy(RetryConfig{
 		MaxRetries: 3,
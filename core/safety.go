@@ -0,0 +1,115 @@
+package core
+
+// SafetyCategory is a normalized content safety category. Providers map
+// their own category names to these; categories without an equivalent are
+// reported in lower snake case.
+type SafetyCategory string
+
+const (
+	SafetyHate           SafetyCategory = "hate"
+	SafetyHarassment     SafetyCategory = "harassment"
+	SafetySexual         SafetyCategory = "sexual"
+	SafetyViolence       SafetyCategory = "violence"
+	SafetySelfHarm       SafetyCategory = "self_harm"
+	SafetyDangerous      SafetyCategory = "dangerous"
+	SafetyCivicIntegrity SafetyCategory = "civic_integrity"
+	SafetyJailbreak      SafetyCategory = "jailbreak"
+
+	// SafetyProtectedMaterial is text or code that matches known protected
+	// content, such as song lyrics or licensed source code.
+	SafetyProtectedMaterial SafetyCategory = "protected_material"
+)
+
+// SafetySeverity is a normalized content filter severity, from
+// SafetyNegligible to SafetyHigh. Gemini probabilities and Azure severities
+// map to it; Azure "safe" is SafetyNegligible.
+type SafetySeverity string
+
+const (
+	SafetyUnknown    SafetySeverity = ""
+	SafetyNegligible SafetySeverity = "negligible"
+	SafetyLow        SafetySeverity = "low"
+	SafetyMedium     SafetySeverity = "medium"
+	SafetyHigh       SafetySeverity = "high"
+)
+
+// AtLeast reports whether s is as severe as min or more. SafetyUnknown is
+// below every known severity.
+func (s SafetySeverity) AtLeast(min SafetySeverity) bool {
+	return severityRank(s) >= severityRank(min)
+}
+
+func severityRank(s SafetySeverity) int {
+	switch s {
+	case SafetyNegligible:
+		return 1
+	case SafetyLow:
+		return 2
+	case SafetyMedium:
+		return 3
+	case SafetyHigh:
+		return 4
+	default:
+		return 0
+	}
+}
+
+// Safety is the safety metadata of a response, normalized across
+// providers so applications can handle refusals and filtered content the
+// same way everywhere:
+//   - OpenAI and Azure report Refusal when the model declines to answer.
+//   - Gemini reports Ratings for the response and the prompt, and Blocked
+//     with BlockReason when it withheld either.
+//   - Azure reports Ratings from its content filters. Responses that Azure
+//     filters fail with an error from the azurefoundry package instead.
+type Safety struct {
+	// Refusal is the model's explanation when it declined the request.
+	// Output is usually empty when it is set.
+	Refusal string `json:"refusal,omitempty"`
+
+	// Blocked reports that the provider withheld the prompt or the
+	// response for safety reasons.
+	Blocked bool `json:"blocked,omitempty"`
+
+	// BlockReason is the provider's reason for Blocked, such as Gemini's
+	// "SAFETY" or "PROHIBITED_CONTENT".
+	BlockReason string `json:"block_reason,omitempty"`
+
+	// Ratings are the results of the provider's content filters.
+	Ratings []SafetyRating `json:"ratings,omitempty"`
+}
+
+// SafetyRating is a content filter result for one category.
+type SafetyRating struct {
+	Category SafetyCategory `json:"category"`
+
+	// Severity is set by filters that grade content.
+	Severity SafetySeverity `json:"severity,omitempty"`
+
+	// Detected is set by filters that only detect content, such as Azure's
+	// jailbreak and protected material filters.
+	Detected bool `json:"detected,omitempty"`
+
+	// Blocked reports that this category caused content to be withheld.
+	Blocked bool `json:"blocked,omitempty"`
+
+	// Prompt reports that the rating is for the prompt, not the response.
+	Prompt bool `json:"prompt,omitempty"`
+}
+
+// Flagged reports whether the response was refused or blocked, or has a
+// rating that was detected or at least min. It is false for a nil Safety.
+func (s *Safety) Flagged(min SafetySeverity) bool {
+	if s == nil {
+		return false
+	}
+	if s.Refusal != "" || s.Blocked {
+		return true
+	}
+	for _, r := range s.Ratings {
+		if r.Blocked || r.Detected || (r.Severity != SafetyUnknown && r.Severity.AtLeast(min)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,34 @@
+package core
+
+import "testing"
+
+func TestSafetyFlagged(t *testing.T) {
+	tests := []struct {
+		name   string
+		safety *Safety
+		want   bool
+	}{
+		{"nil", nil, false},
+		{"empty", &Safety{}, false},
+		{"refusal", &Safety{Refusal: "I can't help with that."}, true},
+		{"blocked", &Safety{Blocked: true, BlockReason: "SAFETY"}, true},
+		{"below min", &Safety{Ratings: []SafetyRating{{Category: SafetyHate, Severity: SafetyLow}}}, false},
+		{"at min", &Safety{Ratings: []SafetyRating{{Category: SafetyHate, Severity: SafetyMedium}}}, true},
+		{"detected", &Safety{Ratings: []SafetyRating{{Category: SafetyJailbreak, Detected: true, Prompt: true}}}, true},
+		{"unknown severity", &Safety{Ratings: []SafetyRating{{Category: "other"}}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.safety.Flagged(SafetyMedium); got != tt.want {
+				t.Errorf("Flagged(medium) = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSafetySeverityAtLeast(t *testing.T) {
+	if !SafetyHigh.AtLeast(SafetyLow) || SafetyLow.AtLeast(SafetyHigh) || SafetyUnknown.AtLeast(SafetyNegligible) {
+		t.Error("AtLeast does not order severities")
+	}
+}
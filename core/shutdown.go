@@ -0,0 +1,91 @@
+package core
+
+import (
+	"context"
+	"sync"
+)
+
+// IdleConnectionCloser is an optional interface for providers that keep
+// pooled connections. Client.Close calls CloseIdleConnections once in-flight
+// requests have finished.
+type IdleConnectionCloser interface {
+	CloseIdleConnections()
+}
+
+// lifecycle tracks the in-flight requests of a Client so Close can drain
+// them.
+type lifecycle struct {
+	mu       sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup
+
+	// abortCtx is canceled when Close stops waiting, canceling the requests
+	// still in flight.
+	abortCtx context.Context
+	abort    context.CancelFunc
+}
+
+// begin registers an in-flight request. The returned context is canceled
+// when ctx is or when Close gives up waiting; finish must be called once
+// the request, including any stream it returned, has ended.
+func (c *Client) begin(ctx context.Context) (context.Context, func(), error) {
+	l := &c.lifecycle
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return nil, nil, ErrClientClosed
+	}
+	l.inflight.Add(1)
+	l.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(ctx)
+	stop := context.AfterFunc(l.abortCtx, cancel)
+	var once sync.Once
+	return ctx, func() {
+		once.Do(func() {
+			stop()
+			cancel()
+			l.inflight.Done()
+		})
+	}, nil
+}
+
+// Close shuts the client down for a clean service exit. New requests fail
+// with ErrClientClosed at once, while chats and streams already in flight
+// run until they finish or ctx ends. When ctx ends first, the remaining
+// requests are canceled and ctx's error is returned without waiting for
+// them to unwind. Finally, the provider's idle pooled connections are
+// closed if it implements IdleConnectionCloser.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	if err := client.Close(ctx); err != nil {
+//	    log.Printf("requests canceled during shutdown: %v", err)
+//	}
+func (c *Client) Close(ctx context.Context) error {
+	l := &c.lifecycle
+	l.mu.Lock()
+	l.closed = true
+	l.mu.Unlock()
+
+	drained := make(chan struct{})
+	go func() {
+		l.inflight.Wait()
+		close(drained)
+	}()
+
+	var err error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+		l.abort()
+	}
+
+	if ic, ok := c.provider.(IdleConnectionCloser); ok {
+		ic.CloseIdleConnections()
+	}
+	return err
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// closingProvider records CloseIdleConnections calls.
+type closingProvider struct {
+	*mockProvider
+	closed bool
+}
+
+func (p *closingProvider) CloseIdleConnections() { p.closed = true }
+
+func TestCloseRejectsNewRequests(t *testing.T) {
+	provider := &closingProvider{mockProvider: &mockProvider{id: "mock"}}
+	client := NewClient(provider)
+
+	if err := client.Close(context.Background()); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if !provider.closed {
+		t.Error("idle connections not closed")
+	}
+
+	if _, err := client.Chat("mock-model").User("Hi").GetResponse(context.Background()); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("GetResponse() error = %v, want ErrClientClosed", err)
+	}
+	if _, err := client.Chat("mock-model").User("Hi").Stream(context.Background()); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Stream() error = %v, want ErrClientClosed", err)
+	}
+	if provider.callCount != 0 {
+		t.Errorf("callCount = %d, want 0", provider.callCount)
+	}
+}
+
+func TestCloseWaitsForInFlightRequests(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	provider := &mockProvider{
+		id: "mock",
+		chatFunc: func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+			close(started)
+			<-release
+			return &ChatResponse{Output: "done"}, nil
+		},
+	}
+	client := NewClient(provider)
+
+	respCh := make(chan error, 1)
+	go func() {
+		_, err := client.Chat("mock-model").User("Hi").GetResponse(context.Background())
+		respCh <- err
+	}()
+	<-started
+
+	closeCh := make(chan error, 1)
+	go func() { closeCh <- client.Close(context.Background()) }()
+
+	select {
+	case err := <-closeCh:
+		t.Fatalf("Close() returned %v before the request finished", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+	if err := <-respCh; err != nil {
+		t.Errorf("GetResponse() error = %v", err)
+	}
+	if err := <-closeCh; err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestCloseWaitsForStreams(t *testing.T) {
+	provider := &mockProvider{
+		id: "mock",
+		streamFunc: func(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+			return newTestStream([]string{"Hello"}, &ChatResponse{}, nil), nil
+		},
+	}
+	client := NewClient(provider)
+
+	stream, err := client.Chat("mock-model").User("Hi").Stream(context.Background())
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	closeCh := make(chan error, 1)
+	go func() { closeCh <- client.Close(context.Background()) }()
+
+	select {
+	case err := <-closeCh:
+		t.Fatalf("Close() returned %v before the stream finished", err)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if _, err := DrainStream(context.Background(), stream); err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+	if err := <-closeCh; err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestCloseCancelsRequestsAfterDeadline(t *testing.T) {
+	started := make(chan struct{})
+	provider := &mockProvider{
+		id: "mock",
+		chatFunc: func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+			close(started)
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+	client := NewClient(provider)
+
+	respCh := make(chan error, 1)
+	go func() {
+		_, err := client.Chat("mock-model").User("Hi").GetResponse(context.Background())
+		respCh <- err
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if err := client.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Close() error = %v, want context.DeadlineExceeded", err)
+	}
+
+	select {
+	case err := <-respCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("GetResponse() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("in-flight request was not canceled")
+	}
+}
@@ -0,0 +1,141 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// Spec is an immutable chat request specification.
+//
+// Unlike ChatBuilder, a Spec can be shared freely across goroutines and
+// executed any number of times with Client.Do or Client.DoStream. Build one
+// with ChatBuilder.Spec and derive variants with the With* methods, each of
+// which returns a new Spec and leaves the receiver unchanged:
+//
+//	base := client.Chat(model).System("You are a classifier").Temperature(0).Spec()
+//
+//	for _, item := range items {
+//	    go func(item string) {
+//	        resp, err := client.Do(ctx, base.WithUser(item))
+//	        // ...
+//	    }(item)
+//	}
+type Spec struct {
+	req          ChatRequest
+	timeout      time.Duration
+	stallTimeout time.Duration
+	streamOpts   StreamOptions
+
+	validateJSON   bool
+	jsonRetries    int
+	repairAttempts int
+	priority       Priority
+}
+
+// Spec returns an immutable snapshot of the builder's current request.
+// Later changes to the builder do not affect the returned Spec.
+func (b *ChatBuilder) Spec() Spec {
+	return Spec{
+		req:            cloneChatRequest(&b.req),
+		timeout:        b.timeout,
+		stallTimeout:   b.stallTimeout,
+		streamOpts:     b.streamOpts,
+		validateJSON:   b.validateJSON,
+		jsonRetries:    b.jsonRetries,
+		repairAttempts: b.repairAttempts,
+		priority:       b.priority,
+	}
+}
+
+// Model returns the model identifier.
+func (s Spec) Model() ModelID {
+	return s.req.Model
+}
+
+// Timeout returns the timeout applied by Client.Do, or zero if none is set.
+func (s Spec) Timeout() time.Duration {
+	return s.timeout
+}
+
+// Messages returns a copy of the conversation messages.
+func (s Spec) Messages() []Message {
+	return cloneMessages(s.req.Messages)
+}
+
+// Request returns a deep copy of the underlying ChatRequest.
+func (s Spec) Request() ChatRequest {
+	return cloneChatRequest(&s.req)
+}
+
+// WithModel returns a copy of the Spec targeting a different model.
+func (s Spec) WithModel(model ModelID) Spec {
+	out := s.clone()
+	out.req.Model = model
+	return out
+}
+
+// WithTimeout returns a copy of the Spec with the given timeout.
+func (s Spec) WithTimeout(d time.Duration) Spec {
+	out := s.clone()
+	out.timeout = d
+	return out
+}
+
+// WithPriority returns a copy of the Spec with the given queue priority.
+func (s Spec) WithPriority(p Priority) Spec {
+	out := s.clone()
+	out.priority = p
+	return out
+}
+
+// WithMessages returns a copy of the Spec with msgs appended.
+func (s Spec) WithMessages(msgs ...Message) Spec {
+	out := s.clone()
+	out.req.Messages = append(out.req.Messages, cloneMessages(msgs)...)
+	return out
+}
+
+// WithUser returns a copy of the Spec with a user message appended.
+func (s Spec) WithUser(text string) Spec {
+	return s.WithMessages(Message{Role: RoleUser, Content: text})
+}
+
+func (s Spec) clone() Spec {
+	out := s
+	out.req = cloneChatRequest(&s.req)
+	return out
+}
+
+// builder returns a fresh ChatBuilder for executing spec on c.
+func (c *Client) builder(spec Spec) *ChatBuilder {
+	b := &ChatBuilder{
+		client:         c,
+		req:            cloneChatRequest(&spec.req),
+		timeout:        spec.timeout,
+		stallTimeout:   spec.stallTimeout,
+		streamOpts:     spec.streamOpts,
+		validateJSON:   spec.validateJSON,
+		jsonRetries:    spec.jsonRetries,
+		repairAttempts: spec.repairAttempts,
+		priority:       spec.priority,
+	}
+	if b.req.ToolResultPolicy == nil && c.toolResultPolicy != nil {
+		p := *c.toolResultPolicy
+		b.req.ToolResultPolicy = &p
+	}
+	return b
+}
+
+// Do executes spec and returns the response. It applies the same validation,
+// telemetry, retry, and timeout handling as ChatBuilder.GetResponse.
+// Do is safe to call concurrently with the same Spec.
+func (c *Client) Do(ctx context.Context, spec Spec) (*ChatResponse, error) {
+	return c.builder(spec).GetResponse(ctx)
+}
+
+// DoStream executes spec and returns a streaming response.
+// As with ChatBuilder.Stream, the Spec timeout is not applied.
+// DoStream is safe to call concurrently with the same Spec.
+func (c *Client) DoStream(ctx context.Context, spec Spec) (*ChatStream, error) {
+	return c.builder(spec).Stream(ctx)
+}
@@ -0,0 +1,148 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSpecIsSnapshot(t *testing.T) {
+	client := NewClient(&mockProvider{id: "mock"})
+	builder := client.Chat("mock-model").System("sys").Temperature(0.5)
+	spec := builder.Spec()
+
+	builder.User("later").Temperature(0.9)
+
+	if got := spec.Messages(); len(got) != 1 || got[0].Content != "sys" {
+		t.Errorf("spec messages changed with builder: %+v", got)
+	}
+	if req := spec.Request(); *req.Temperature != 0.5 {
+		t.Errorf("spec temperature = %v, want 0.5", *req.Temperature)
+	}
+
+	msgs := spec.Messages()
+	msgs[0].Content = "mutated"
+	if spec.Messages()[0].Content != "sys" {
+		t.Error("Messages() exposed internal state")
+	}
+}
+
+func TestSpecWithMethodsDoNotMutate(t *testing.T) {
+	client := NewClient(&mockProvider{id: "mock"})
+	base := client.Chat("mock-model").System("sys").Spec()
+
+	a := base.WithUser("a")
+	b := base.WithUser("b").WithModel("other").WithTimeout(time.Second)
+
+	if len(base.Messages()) != 1 {
+		t.Errorf("base modified: %+v", base.Messages())
+	}
+	if got := a.Messages(); len(got) != 2 || got[1].Content != "a" {
+		t.Errorf("a messages = %+v", got)
+	}
+	if got := b.Messages(); len(got) != 2 || got[1].Content != "b" {
+		t.Errorf("b messages = %+v", got)
+	}
+	if a.Model() != "mock-model" || b.Model() != "other" {
+		t.Errorf("models: a=%q b=%q", a.Model(), b.Model())
+	}
+	if a.Timeout() != 0 || b.Timeout() != time.Second {
+		t.Errorf("timeouts: a=%v b=%v", a.Timeout(), b.Timeout())
+	}
+}
+
+func TestClientDoConcurrent(t *testing.T) {
+	provider := &mockProvider{
+		id: "mock",
+		chatFunc: func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+			// Mutating the request must not leak into other calls.
+			last := req.Messages[len(req.Messages)-1].Content
+			req.Messages[0].Content = "clobbered"
+			return &ChatResponse{Output: last}, nil
+		},
+	}
+	client := NewClient(provider)
+	base := client.Chat("mock-model").System("sys").Spec()
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			want := fmt.Sprintf("q%d", i)
+			resp, err := client.Do(context.Background(), base.WithUser(want))
+			if err != nil {
+				errs <- err
+				return
+			}
+			if resp.Output != want {
+				errs <- fmt.Errorf("got %q, want %q", resp.Output, want)
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+
+	if base.Messages()[0].Content != "sys" {
+		t.Error("provider mutation leaked into spec")
+	}
+}
+
+func TestClientDoValidatesAndAppliesTimeout(t *testing.T) {
+	provider := &mockProvider{
+		id: "mock",
+		chatFunc: func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+			if _, ok := ctx.Deadline(); !ok {
+				return nil, errors.New("expected deadline")
+			}
+			return &ChatResponse{}, nil
+		},
+	}
+	client := NewClient(provider)
+
+	if _, err := client.Do(context.Background(), Spec{}); !errors.Is(err, ErrModelRequired) {
+		t.Errorf("expected ErrModelRequired, got %v", err)
+	}
+	if _, err := client.Do(context.Background(), client.Chat("mock-model").Spec()); !errors.Is(err, ErrNoMessages) {
+		t.Errorf("expected ErrNoMessages, got %v", err)
+	}
+
+	spec := client.Chat("mock-model").User("hi").Timeout(time.Minute).Spec()
+	if _, err := client.Do(context.Background(), spec); err != nil {
+		t.Errorf("Do() error = %v", err)
+	}
+}
+
+func TestClientDoStream(t *testing.T) {
+	client := NewClient(&mockProvider{id: "mock"})
+	spec := client.Chat("mock-model").User("hi").Spec()
+
+	stream, err := client.DoStream(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("DoStream() error = %v", err)
+	}
+	if _, err := DrainStream(context.Background(), stream); err != nil {
+		t.Errorf("DrainStream() error = %v", err)
+	}
+}
+
+func TestClientDoAppliesClientToolResultPolicy(t *testing.T) {
+	provider := &mockProvider{id: "mock"}
+	policy := ToolResultPolicy{MaxBytes: 10}
+	client := NewClient(provider, WithToolResultPolicy(policy))
+
+	spec := Spec{}.WithModel("mock-model").WithUser("hi")
+	if _, err := client.Do(context.Background(), spec); err != nil {
+		t.Fatal(err)
+	}
+	if got := provider.lastRequest.ToolResultPolicy; got == nil || got.MaxBytes != 10 {
+		t.Errorf("ToolResultPolicy = %+v", got)
+	}
+}
@@ -0,0 +1,172 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Server-sent event names written by WriteSSE.
+const (
+	// SSEEventDelta carries a text delta: {"delta":"..."}. Reasoning
+	// deltas are sent as {"delta":"","reasoning":"..."}.
+	SSEEventDelta = "delta"
+	// SSEEventError carries a stream error: {"error":"..."}.
+	SSEEventError = "error"
+	// SSEEventDone is the final event, carrying the response ID, model,
+	// token usage, and any tool calls.
+	SSEEventDone = "done"
+)
+
+// sseError is the payload of an error event.
+type sseError struct {
+	Error string `json:"error"`
+}
+
+// sseDone is the payload of the done event.
+type sseDone struct {
+	ID           string       `json:"id,omitempty"`
+	Model        ModelID      `json:"model,omitempty"`
+	Usage        TokenUsage   `json:"usage"`
+	ToolCalls    []ToolCall   `json:"tool_calls,omitempty"`
+	FinishReason FinishReason `json:"finish_reason,omitempty"`
+}
+
+// WriteSSE forwards a chat stream to an HTTP client as server-sent events,
+// flushing after each event. It writes a delta event per text or reasoning
+// chunk, then either an error event or a done event with token usage and
+// the finish reason, and returns the accumulated response as DrainStream
+// would.
+//
+//	func handler(w http.ResponseWriter, r *http.Request) {
+//	    stream, err := client.Chat(model).User(prompt).Stream(r.Context())
+//	    if err != nil {
+//	        http.Error(w, err.Error(), http.StatusBadGateway)
+//	        return
+//	    }
+//	    resp, err := core.WriteSSE(w, stream)
+//	    // save resp for the transcript...
+//	}
+//
+// If writing fails (usually because the client disconnected) WriteSSE returns
+// the write error and discards the rest of the stream. Create the stream with
+// the request context so the provider request is cancelled as well.
+//
+// WriteSSE works with any framework that exposes the http.ResponseWriter,
+// such as Gin's c.Writer or Echo's c.Response(). Use WriteSSETo for other
+// writers.
+func WriteSSE(w http.ResponseWriter, stream *ChatStream) (*ChatResponse, error) {
+	h := w.Header()
+	h.Set("Content-Type", "text/event-stream")
+	h.Set("Cache-Control", "no-cache")
+	h.Set("Connection", "keep-alive")
+	h.Set("X-Accel-Buffering", "no") // disable proxy buffering (nginx)
+
+	rc := http.NewResponseController(w)
+	flush := func() error {
+		if err := rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+			return err
+		}
+		return nil
+	}
+	return WriteSSETo(w, flush, stream)
+}
+
+// WriteSSETo is like WriteSSE but writes events to any io.Writer, calling
+// flush (if non-nil) after each event. It does not set HTTP headers.
+func WriteSSETo(w io.Writer, flush func() error, stream *ChatStream) (*ChatResponse, error) {
+	if stream == nil {
+		return nil, ErrBadRequest
+	}
+
+	write := func(event string, payload any) error {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+			return err
+		}
+		if flush != nil {
+			return flush()
+		}
+		return nil
+	}
+
+	var accumulated, reasoning strings.Builder
+	var parts []OutputPart
+	var streamErr error
+	var finalResp *ChatResponse
+
+	upCh, upErr, upFinal := stream.Ch, stream.Err, stream.Final
+	for upCh != nil || upErr != nil || upFinal != nil {
+		select {
+		case chunk, ok := <-upCh:
+			if !ok {
+				upCh = nil
+				continue
+			}
+			accumulated.WriteString(chunk.Delta)
+			reasoning.WriteString(chunk.Reasoning)
+			parts = append(parts, chunk.Parts...)
+			if chunk.Delta == "" && chunk.Reasoning == "" {
+				continue
+			}
+			if err := write(SSEEventDelta, chunk); err != nil {
+				go drainStream(stream)
+				return nil, err
+			}
+
+		case err, ok := <-upErr:
+			if !ok {
+				upErr = nil
+				continue
+			}
+			if err != nil && streamErr == nil {
+				streamErr = err
+			}
+
+		case resp, ok := <-upFinal:
+			if !ok {
+				upFinal = nil
+				continue
+			}
+			finalResp = resp
+		}
+	}
+
+	if streamErr != nil {
+		if err := write(SSEEventError, sseError{Error: streamErr.Error()}); err != nil {
+			return nil, err
+		}
+		return nil, streamErr
+	}
+
+	if finalResp == nil {
+		finalResp = &ChatResponse{}
+	}
+	if finalResp.Output == "" {
+		finalResp.Output = accumulated.String()
+	}
+	if len(finalResp.Parts) == 0 {
+		finalResp.Parts = parts
+	}
+	if finalResp.Reasoning == nil && reasoning.Len() > 0 {
+		finalResp.Reasoning = &ReasoningOutput{Summary: []string{reasoning.String()}}
+	}
+
+	done := sseDone{
+		ID:           finalResp.ID,
+		Model:        finalResp.Model,
+		Usage:        finalResp.Usage,
+		ToolCalls:    finalResp.ToolCalls,
+		FinishReason: finalResp.FinishReason,
+	}
+	if err := write(SSEEventDone, done); err != nil {
+		return finalResp, err
+	}
+	return finalResp, nil
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWriteSSE(t *testing.T) {
+	final := &ChatResponse{
+		ID:           "resp-1",
+		Model:        "mock-model",
+		Usage:        TokenUsage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5},
+		FinishReason: FinishStop,
+	}
+	rec := httptest.NewRecorder()
+
+	resp, err := WriteSSE(rec, newTestStream([]string{"Hel", "lo"}, final, nil))
+	if err != nil {
+		t.Fatalf("WriteSSE() error = %v", err)
+	}
+	if resp.Output != "Hello" {
+		t.Errorf("Output = %q, want %q", resp.Output, "Hello")
+	}
+
+	if ct := rec.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !rec.Flushed {
+		t.Error("response was not flushed")
+	}
+
+	want := "event: delta\ndata: {\"delta\":\"Hel\"}\n\n" +
+		"event: delta\ndata: {\"delta\":\"lo\"}\n\n" +
+		"event: done\ndata: {\"id\":\"resp-1\",\"model\":\"mock-model\",\"usage\":{\"prompt_tokens\":3,\"completion_tokens\":2,\"total_tokens\":5},\"finish_reason\":\"stop\"}\n\n"
+	if got := rec.Body.String(); got != want {
+		t.Errorf("body =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestWriteSSEError(t *testing.T) {
+	wantErr := errors.New("upstream failed")
+	var buf bytes.Buffer
+
+	_, err := WriteSSETo(&buf, nil, newTestStream([]string{"partial"}, nil, wantErr))
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("WriteSSETo() error = %v, want %v", err, wantErr)
+	}
+	if !strings.HasSuffix(buf.String(), "event: error\ndata: {\"error\":\"upstream failed\"}\n\n") {
+		t.Errorf("body = %q, want trailing error event", buf.String())
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("client disconnected")
+}
+
+func TestWriteSSEWriteError(t *testing.T) {
+	_, err := WriteSSETo(failingWriter{}, nil, newTestStream([]string{"a", "b", "c"}, &ChatResponse{}, nil))
+	if err == nil || err.Error() != "client disconnected" {
+		t.Errorf("WriteSSETo() error = %v, want write error", err)
+	}
+}
@@ -0,0 +1,129 @@
+package core
+
+import "time"
+
+// StreamOverflow selects what a stream does when its buffer is full because
+// the consumer reads slower than the provider sends.
+type StreamOverflow int
+
+const (
+	// StreamOverflowBlock makes the provider wait for the consumer. This is
+	// the default and never loses chunks.
+	StreamOverflowBlock StreamOverflow = iota
+
+	// StreamOverflowDropOldest discards the oldest buffered chunk to make
+	// room, so a slow consumer never holds up the provider connection.
+	// Dropped text is missing from Ch but still present in the Final
+	// response.
+	StreamOverflowDropOldest
+)
+
+// StreamOptions controls buffering of ChatStream.Ch. The zero value keeps
+// the provider's channel as is.
+type StreamOptions struct {
+	// BufferSize is the number of chunks held for a slow consumer.
+	// StreamOverflowDropOldest needs at least one and uses one if zero.
+	BufferSize int
+
+	// Overflow selects what happens when the buffer is full.
+	Overflow StreamOverflow
+
+	// CoalesceInterval merges text deltas that arrive within the interval
+	// into a single chunk, reducing per-chunk overhead for consumers such as
+	// SSE writers. The first delta is held for at most the interval. Chunks
+	// with Parts or Reasoning are never merged and flush pending text first.
+	CoalesceInterval time.Duration
+}
+
+// StreamOptions sets how Stream buffers chunks between the provider and the
+// consumer. Servers running many concurrent streams can use it to bound
+// memory, decouple slow clients from provider connections, and send fewer,
+// larger chunks.
+func (b *ChatBuilder) StreamOptions(opts StreamOptions) *ChatBuilder {
+	b.streamOpts = opts
+	return b
+}
+
+// shapeStream forwards stream.Ch through a channel configured by opts. Err
+// and Final are passed through unchanged.
+func shapeStream(stream *ChatStream, opts StreamOptions) *ChatStream {
+	if opts == (StreamOptions{}) {
+		return stream
+	}
+
+	size := max(opts.BufferSize, 0)
+	if opts.Overflow == StreamOverflowDropOldest {
+		size = max(size, 1)
+	}
+	out := make(chan ChatChunk, size)
+
+	send := func(chunk ChatChunk) {
+		if opts.Overflow != StreamOverflowDropOldest {
+			out <- chunk
+			return
+		}
+		for {
+			select {
+			case out <- chunk:
+				return
+			default:
+			}
+			// Full: discard the oldest chunk unless the consumer just took it
+			select {
+			case <-out:
+			default:
+			}
+		}
+	}
+
+	go func() {
+		defer close(out)
+
+		if opts.CoalesceInterval <= 0 {
+			for chunk := range stream.Ch {
+				send(chunk)
+			}
+			return
+		}
+
+		timer := time.NewTimer(opts.CoalesceInterval)
+		timer.Stop()
+		defer timer.Stop()
+
+		var pending string
+		flush := func() {
+			if pending != "" {
+				timer.Stop()
+				send(ChatChunk{Delta: pending})
+				pending = ""
+			}
+		}
+
+		for {
+			select {
+			case chunk, ok := <-stream.Ch:
+				if !ok {
+					flush()
+					return
+				}
+				if len(chunk.Parts) > 0 || chunk.Reasoning != "" {
+					flush()
+					send(chunk)
+					continue
+				}
+				if chunk.Delta == "" {
+					continue
+				}
+				if pending == "" {
+					timer.Reset(opts.CoalesceInterval)
+				}
+				pending += chunk.Delta
+
+			case <-timer.C:
+				flush()
+			}
+		}
+	}()
+
+	return &ChatStream{Ch: out, Err: stream.Err, Final: stream.Final}
+}
@@ -0,0 +1,136 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShapeStreamZeroOptions(t *testing.T) {
+	stream := newTestStream([]string{"a"}, nil, nil)
+	if got := shapeStream(stream, StreamOptions{}); got != stream {
+		t.Error("zero options should return the stream unchanged")
+	}
+}
+
+func TestShapeStreamDropOldest(t *testing.T) {
+	ch := make(chan ChatChunk)
+	finalCh := make(chan *ChatResponse, 1)
+	errCh := make(chan error)
+	stream := shapeStream(&ChatStream{Ch: ch, Err: errCh, Final: finalCh},
+		StreamOptions{BufferSize: 2, Overflow: StreamOverflowDropOldest})
+
+	// The provider is never blocked even though nobody reads
+	for _, d := range []string{"1", "2", "3", "4", "5"} {
+		select {
+		case ch <- ChatChunk{Delta: d}:
+		case <-time.After(time.Second):
+			t.Fatalf("send of %q blocked", d)
+		}
+	}
+	close(ch)
+	close(errCh)
+	finalCh <- &ChatResponse{Output: "12345"}
+	close(finalCh)
+
+	var got []string
+	for chunk := range stream.Ch {
+		got = append(got, chunk.Delta)
+	}
+	// The last chunk may still be in flight when the buffer drops, so only
+	// the tail is guaranteed
+	if len(got) == 0 || len(got) > 3 || got[len(got)-1] != "5" {
+		t.Errorf("chunks = %v, want the newest chunks ending in 5", got)
+	}
+	if resp := <-stream.Final; resp.Output != "12345" {
+		t.Errorf("Final.Output = %q, want 12345", resp.Output)
+	}
+}
+
+func TestShapeStreamBlockBuffer(t *testing.T) {
+	stream := shapeStream(newTestStream([]string{"a", "b", "c"}, &ChatResponse{ID: "r"}, nil),
+		StreamOptions{BufferSize: 8})
+	if cap(stream.Ch) != 8 {
+		t.Errorf("cap(Ch) = %d, want 8", cap(stream.Ch))
+	}
+	resp, err := DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+	if resp.Output != "abc" {
+		t.Errorf("Output = %q, want abc", resp.Output)
+	}
+}
+
+func TestShapeStreamCoalesce(t *testing.T) {
+	ch := make(chan ChatChunk)
+	finalCh := make(chan *ChatResponse)
+	errCh := make(chan error)
+	close(finalCh)
+	close(errCh)
+	stream := shapeStream(&ChatStream{Ch: ch, Err: errCh, Final: finalCh},
+		StreamOptions{CoalesceInterval: 50 * time.Millisecond})
+
+	go func() {
+		for _, d := range []string{"Hel", "lo", ", "} {
+			ch <- ChatChunk{Delta: d}
+		}
+		ch <- ChatChunk{Parts: []OutputPart{OutputText{Text: "part"}}}
+		ch <- ChatChunk{Delta: "world"}
+		time.Sleep(120 * time.Millisecond)
+		ch <- ChatChunk{Delta: "!"}
+		close(ch)
+	}()
+
+	var chunks []ChatChunk
+	for chunk := range stream.Ch {
+		chunks = append(chunks, chunk)
+	}
+
+	if len(chunks) != 4 {
+		t.Fatalf("got %d chunks, want 4: %+v", len(chunks), chunks)
+	}
+	if chunks[0].Delta != "Hello, " {
+		t.Errorf("chunks[0] = %q, want merged text before the part", chunks[0].Delta)
+	}
+	if len(chunks[1].Parts) != 1 {
+		t.Errorf("chunks[1] = %+v, want the part", chunks[1])
+	}
+	if chunks[2].Delta != "world" {
+		t.Errorf("chunks[2] = %q, want text flushed by the interval", chunks[2].Delta)
+	}
+	if chunks[3].Delta != "!" {
+		t.Errorf("chunks[3] = %q, want text flushed at the end", chunks[3].Delta)
+	}
+}
+
+func TestChatBuilderStreamOptions(t *testing.T) {
+	provider := &mockProvider{
+		id: "mock",
+		streamFunc: func(context.Context, *ChatRequest) (*ChatStream, error) {
+			return newTestStream([]string{"a", "b"}, &ChatResponse{Output: "ab"}, nil), nil
+		},
+	}
+	client := NewClient(provider)
+	spec := client.Chat("mock-model").User("Hi").
+		StreamOptions(StreamOptions{BufferSize: 4, CoalesceInterval: time.Hour}).
+		Spec()
+
+	stream, err := client.DoStream(context.Background(), spec)
+	if err != nil {
+		t.Fatalf("DoStream() error = %v", err)
+	}
+	if cap(stream.Ch) != 4 {
+		t.Errorf("cap(Ch) = %d, want 4", cap(stream.Ch))
+	}
+	var text strings.Builder
+	n := 0
+	for chunk := range stream.Ch {
+		text.WriteString(chunk.Delta)
+		n++
+	}
+	if n != 1 || text.String() != "ab" {
+		t.Errorf("got %d chunks %q, want 1 merged chunk", n, text.String())
+	}
+}
@@ -0,0 +1,63 @@
+package core
+
+import "context"
+
+// ResumeTelemetryHook is an optional extension of TelemetryHook for stream
+// resumption. When the client's hook implements it, providers that resume
+// an interrupted stream report a StreamResumeEvent for each reconnection.
+// The caller's ChatStream continues without a gap, so the hook is the only
+// place a resume is visible.
+type ResumeTelemetryHook interface {
+	TelemetryHook
+
+	// OnStreamResume is called before a provider reconnects an interrupted
+	// stream.
+	OnStreamResume(e StreamResumeEvent)
+}
+
+// StreamResumeEvent describes the reconnection of a stream whose
+// connection failed mid-response.
+//
+// # Security
+//
+// Like the request events, it excludes prompts and model output. Err
+// describes the connection failure.
+type StreamResumeEvent struct {
+	Provider   string  // Provider identifier
+	Model      ModelID // Model that was called
+	ResponseID string  // Provider response being resumed
+	Attempt    int     // Resume attempt number for the stream, starting at 1
+	After      int64   // Provider cursor the stream resumes after, such as an event sequence number
+	Err        error   // Error that interrupted the stream
+}
+
+type resumeReporterKey struct{}
+
+// withResumeReporter returns a context through which providers report stream
+// resumes to the client's telemetry hook. ctx is returned unchanged if the
+// hook does not implement ResumeTelemetryHook.
+func (c *Client) withResumeReporter(ctx context.Context, provider string, model ModelID) context.Context {
+	h, ok := c.telemetry.(ResumeTelemetryHook)
+	if !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, resumeReporterKey{}, func(e StreamResumeEvent) {
+		if e.Provider == "" {
+			e.Provider = provider
+		}
+		if e.Model == "" {
+			e.Model = model
+		}
+		h.OnStreamResume(e)
+	})
+}
+
+// ReportStreamResume is called by providers before they reconnect an
+// interrupted stream. It forwards e to the telemetry hook of the client
+// that started the stream, if any; Provider and Model default to those of
+// the request.
+func ReportStreamResume(ctx context.Context, e StreamResumeEvent) {
+	if report, ok := ctx.Value(resumeReporterKey{}).(func(StreamResumeEvent)); ok {
+		report(e)
+	}
+}
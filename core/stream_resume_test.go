@@ -0,0 +1,45 @@
+package core
+
+import (
+	"context"
+	"testing"
+)
+
+type resumeHook struct {
+	NoopTelemetryHook
+	events []StreamResumeEvent
+}
+
+func (h *resumeHook) OnStreamResume(e StreamResumeEvent) {
+	h.events = append(h.events, e)
+}
+
+func TestStreamReportsResumes(t *testing.T) {
+	provider := &mockProvider{
+		id: "mock",
+		streamFunc: func(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+			ReportStreamResume(ctx, StreamResumeEvent{ResponseID: "resp-1", Attempt: 1, After: 4})
+			return newTestStream([]string{"Hello"}, &ChatResponse{}, nil), nil
+		},
+	}
+	hook := &resumeHook{}
+	client := NewClient(provider, WithTelemetry(hook))
+
+	stream, err := client.Chat("mock-model").User("Hi").Stream(context.Background())
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if _, err := DrainStream(context.Background(), stream); err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+
+	want := StreamResumeEvent{Provider: "mock", Model: "mock-model", ResponseID: "resp-1", Attempt: 1, After: 4}
+	if len(hook.events) != 1 || hook.events[0] != want {
+		t.Errorf("events = %+v, want [%+v]", hook.events, want)
+	}
+}
+
+func TestReportStreamResumeWithoutClient(t *testing.T) {
+	// Reporting outside a client stream does nothing
+	ReportStreamResume(context.Background(), StreamResumeEvent{Attempt: 1})
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"slices"
+	"time"
+)
+
+// streamTimer records chunk arrival times for a StreamStatsEvent.
+type streamTimer struct {
+	start      time.Time
+	last       time.Time
+	firstToken time.Duration
+	chunks     int
+	gaps       []time.Duration
+}
+
+func (t *streamTimer) observe(chunk ChatChunk, now time.Time) {
+	if t.chunks > 0 {
+		t.gaps = append(t.gaps, now.Sub(t.last))
+	}
+	t.chunks++
+	t.last = now
+	if t.firstToken == 0 && (chunk.Delta != "" || chunk.Reasoning != "" || len(chunk.Parts) > 0) {
+		t.firstToken = now.Sub(t.start)
+	}
+}
+
+// event builds the stats event for a stream that ended at end.
+func (t *streamTimer) event(provider string, model ModelID, end time.Time, err error) StreamStatsEvent {
+	e := StreamStatsEvent{
+		Provider:         provider,
+		Model:            model,
+		Start:            t.start,
+		End:              end,
+		TimeToFirstToken: t.firstToken,
+		ChunkCount:       t.chunks,
+		Err:              err,
+	}
+	if len(t.gaps) > 0 {
+		slices.Sort(t.gaps)
+		e.InterChunkP50 = percentile(t.gaps, 50)
+		e.InterChunkP90 = percentile(t.gaps, 90)
+		e.InterChunkP99 = percentile(t.gaps, 99)
+		e.InterChunkMax = t.gaps[len(t.gaps)-1]
+	}
+	return e
+}
+
+// percentile returns the nearest-rank percentile p of sorted values.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100
+	return sorted[max(rank, 1)-1]
+}
+
+// timeStream forwards stream.Ch through a new channel, timing each chunk.
+// The returned channel is closed after stream.Ch, and done is closed once
+// the timer is complete.
+func timeStream(stream *ChatStream, start time.Time) (ch chan ChatChunk, timer *streamTimer, done chan struct{}) {
+	ch = make(chan ChatChunk)
+	timer = &streamTimer{start: start}
+	done = make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer close(ch)
+		for chunk := range stream.Ch {
+			timer.observe(chunk, time.Now())
+			ch <- chunk
+		}
+	}()
+	return ch, timer, done
+}
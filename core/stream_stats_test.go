@@ -0,0 +1,115 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// streamStatsRecorder records stream stats and signals each event.
+type streamStatsRecorder struct {
+	NoopTelemetryHook
+	events chan StreamStatsEvent
+}
+
+func (r *streamStatsRecorder) OnStreamStats(e StreamStatsEvent) {
+	r.events <- e
+}
+
+func TestStreamStatsEvent(t *testing.T) {
+	p := &mockProvider{
+		id: "test-provider",
+		streamFunc: func(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+			return newTestStream([]string{"a", "b", "c"}, &ChatResponse{ID: "resp"}, nil), nil
+		},
+	}
+	hook := &streamStatsRecorder{events: make(chan StreamStatsEvent, 1)}
+	c := NewClient(p, WithTelemetry(hook))
+
+	stream, err := c.Chat("gpt-4").User("Hello").Stream(context.Background())
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	var got string
+	for chunk := range stream.Ch {
+		got += chunk.Delta
+	}
+	<-stream.Final
+	if got != "abc" {
+		t.Errorf("streamed %q, want %q", got, "abc")
+	}
+
+	e := <-hook.events
+	if e.Provider != "test-provider" || e.Model != "gpt-4" {
+		t.Errorf("event = %s/%s", e.Provider, e.Model)
+	}
+	if e.ChunkCount != 3 {
+		t.Errorf("ChunkCount = %d, want 3", e.ChunkCount)
+	}
+	if e.TimeToFirstToken <= 0 || e.TimeToFirstToken > e.Duration() {
+		t.Errorf("TimeToFirstToken = %v, duration %v", e.TimeToFirstToken, e.Duration())
+	}
+	if e.InterChunkP50 > e.InterChunkP99 || e.InterChunkP99 > e.InterChunkMax {
+		t.Errorf("percentiles out of order: p50 %v, p99 %v, max %v",
+			e.InterChunkP50, e.InterChunkP99, e.InterChunkMax)
+	}
+	if e.Err != nil {
+		t.Errorf("Err = %v", e.Err)
+	}
+}
+
+func TestStreamStatsEventError(t *testing.T) {
+	streamErr := errors.New("stream failed")
+	p := &mockProvider{
+		id: "test-provider",
+		streamFunc: func(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+			return newTestStream(nil, nil, streamErr), nil
+		},
+	}
+	hook := &streamStatsRecorder{events: make(chan StreamStatsEvent, 1)}
+	c := NewClient(p, WithTelemetry(hook))
+
+	stream, err := c.Chat("gpt-4").User("Hello").Stream(context.Background())
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	for range stream.Ch {
+	}
+
+	e := <-hook.events
+	if !errors.Is(e.Err, streamErr) {
+		t.Errorf("Err = %v, want %v", e.Err, streamErr)
+	}
+	if e.ChunkCount != 0 || e.TimeToFirstToken != 0 {
+		t.Errorf("ChunkCount = %d, TimeToFirstToken = %v, want zero", e.ChunkCount, e.TimeToFirstToken)
+	}
+}
+
+func TestStreamTimerPercentiles(t *testing.T) {
+	start := time.Unix(0, 0)
+	timer := &streamTimer{start: start}
+
+	// An empty chunk does not count as the first token
+	now := start.Add(5 * time.Millisecond)
+	timer.observe(ChatChunk{}, now)
+	for i := 1; i <= 100; i++ {
+		now = now.Add(time.Duration(i) * time.Millisecond)
+		timer.observe(ChatChunk{Delta: "x"}, now)
+	}
+
+	e := timer.event("p", "m", now, nil)
+	if e.ChunkCount != 101 {
+		t.Errorf("ChunkCount = %d, want 101", e.ChunkCount)
+	}
+	if e.TimeToFirstToken != 6*time.Millisecond {
+		t.Errorf("TimeToFirstToken = %v, want 6ms", e.TimeToFirstToken)
+	}
+	if e.InterChunkP50 != 50*time.Millisecond ||
+		e.InterChunkP90 != 90*time.Millisecond ||
+		e.InterChunkP99 != 99*time.Millisecond ||
+		e.InterChunkMax != 100*time.Millisecond {
+		t.Errorf("percentiles = %v/%v/%v/%v, want 50ms/90ms/99ms/100ms",
+			e.InterChunkP50, e.InterChunkP90, e.InterChunkP99, e.InterChunkMax)
+	}
+}
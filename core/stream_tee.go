@@ -0,0 +1,121 @@
+package core
+
+// TeeStream splits one stream into n independent readers. Every reader
+// receives all chunks in order, the error (if any), and its own copy of the
+// final response, so a server can forward deltas to a client while another
+// goroutine accumulates the transcript:
+//
+//	streams := core.TeeStream(stream, 2)
+//	go forwardToWebSocket(conn, streams[0])
+//	resp, err := core.DrainStream(ctx, streams[1])
+//
+// Readers are buffered without limit, so a slow or not-yet-started reader never
+// blocks the others; readers may be consumed one after another. Each reader
+// should be drained to release its buffer. TeeStream returns nil if n < 1.
+func TeeStream(s *ChatStream, n int) []*ChatStream {
+	if s == nil || n < 1 {
+		return nil
+	}
+
+	ins := make([]chan ChatChunk, n)
+	errs := make([]chan error, n)
+	finals := make([]chan *ChatResponse, n)
+	readers := make([]*ChatStream, n)
+	for i := range readers {
+		ins[i] = make(chan ChatChunk)
+		out := make(chan ChatChunk)
+		errs[i] = make(chan error, 1)
+		finals[i] = make(chan *ChatResponse, 1)
+		go bufferChunks(ins[i], out)
+		readers[i] = &ChatStream{Ch: out, Err: errs[i], Final: finals[i]}
+	}
+
+	go func() {
+		defer func() {
+			for i := range readers {
+				close(errs[i])
+				close(finals[i])
+			}
+		}()
+
+		upCh, upErr, upFinal := s.Ch, s.Err, s.Final
+		for upCh != nil || upErr != nil || upFinal != nil {
+			select {
+			case chunk, ok := <-upCh:
+				if !ok {
+					upCh = nil
+					for _, in := range ins {
+						close(in)
+					}
+					continue
+				}
+				for _, in := range ins {
+					in <- chunk
+				}
+
+			case err, ok := <-upErr:
+				if !ok {
+					upErr = nil
+					continue
+				}
+				if err == nil {
+					continue
+				}
+				for _, ch := range errs {
+					select {
+					case ch <- err:
+					default: // Err emits at most one error
+					}
+				}
+
+			case resp, ok := <-upFinal:
+				if !ok {
+					upFinal = nil
+					continue
+				}
+				for _, ch := range finals {
+					// Readers get separate copies since DrainStream fills in Output
+					var copied *ChatResponse
+					if resp != nil {
+						c := *resp
+						copied = &c
+					}
+					select {
+					case ch <- copied:
+					default:
+					}
+				}
+			}
+		}
+	}()
+
+	return readers
+}
+
+// bufferChunks forwards chunks from in to out through an unbounded queue,
+// closing out after in is closed and the queue is empty.
+func bufferChunks(in <-chan ChatChunk, out chan<- ChatChunk) {
+	defer close(out)
+
+	var queue []ChatChunk
+	for in != nil || len(queue) > 0 {
+		var send chan<- ChatChunk
+		var next ChatChunk
+		if len(queue) > 0 {
+			send = out
+			next = queue[0]
+		}
+
+		select {
+		case chunk, ok := <-in:
+			if !ok {
+				in = nil
+				continue
+			}
+			queue = append(queue, chunk)
+		case send <- next:
+			queue[0] = ChatChunk{}
+			queue = queue[1:]
+		}
+	}
+}
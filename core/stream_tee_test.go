@@ -0,0 +1,77 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// newTestStream returns a stream that emits deltas followed by final or err.
+func newTestStream(deltas []string, final *ChatResponse, err error) *ChatStream {
+	ch := make(chan ChatChunk)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *ChatResponse, 1)
+	go func() {
+		defer close(finalCh)
+		defer close(errCh)
+		for _, d := range deltas {
+			ch <- ChatChunk{Delta: d}
+		}
+		if err != nil {
+			errCh <- err
+		}
+		close(ch)
+		if final != nil {
+			finalCh <- final
+		}
+	}()
+	return &ChatStream{Ch: ch, Err: errCh, Final: finalCh}
+}
+
+func TestTeeStream(t *testing.T) {
+	upstream := newTestStream([]string{"Hello", ", ", "world"}, &ChatResponse{ID: "resp-1"}, nil)
+
+	readers := TeeStream(upstream, 2)
+	if len(readers) != 2 {
+		t.Fatalf("len(readers) = %d, want 2", len(readers))
+	}
+
+	// Readers are consumed one after another; the second must not block the first.
+	first, err := DrainStream(context.Background(), readers[0])
+	if err != nil {
+		t.Fatalf("DrainStream(0) error = %v", err)
+	}
+	second, err := DrainStream(context.Background(), readers[1])
+	if err != nil {
+		t.Fatalf("DrainStream(1) error = %v", err)
+	}
+
+	for i, resp := range []*ChatResponse{first, second} {
+		if resp.Output != "Hello, world" || resp.ID != "resp-1" {
+			t.Errorf("reader %d: resp = %+v", i, resp)
+		}
+	}
+	if first == second {
+		t.Error("readers share the same final response")
+	}
+}
+
+func TestTeeStreamError(t *testing.T) {
+	wantErr := errors.New("boom")
+	readers := TeeStream(newTestStream([]string{"partial"}, nil, wantErr), 3)
+
+	for i, r := range readers {
+		if _, err := DrainStream(context.Background(), r); !errors.Is(err, wantErr) {
+			t.Errorf("reader %d: error = %v, want %v", i, err, wantErr)
+		}
+	}
+}
+
+func TestTeeStreamInvalid(t *testing.T) {
+	if TeeStream(nil, 2) != nil {
+		t.Error("TeeStream(nil) should return nil")
+	}
+	if TeeStream(newTestStream(nil, nil, nil), 0) != nil {
+		t.Error("TeeStream(n=0) should return nil")
+	}
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// StallTimeout sets an inactivity window for streaming requests. If no chunk
+// arrives from the provider within d, Stream cancels the underlying request
+// and reports ErrStreamStalled on the Err channel. The window restarts after
+// each chunk, so long responses are unaffected as long as they keep arriving.
+//
+// Time spent waiting for the consumer to read a chunk does not count toward
+// the window. Zero (the default) disables stall detection.
+func (b *ChatBuilder) StallTimeout(d time.Duration) *ChatBuilder {
+	b.stallTimeout = d
+	return b
+}
+
+// openStream starts the provider stream, wrapped in a stall watchdog when
+// StallTimeout is set.
+func (b *ChatBuilder) openStream(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+	if b.stallTimeout <= 0 {
+		return b.client.provider.StreamChat(ctx, req)
+	}
+
+	// A stalled stream cancels only its own request, not the caller's context
+	streamCtx, cancel := context.WithCancel(ctx)
+	stream, err := b.client.provider.StreamChat(streamCtx, req)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return watchStream(streamCtx, stream, b.stallTimeout, cancel), nil
+}
+
+// watchStream forwards stream through new channels, failing it with
+// ErrStreamStalled and calling cancel if the provider sends nothing for
+// window. cancel is also called once the stream completes normally. If ctx
+// ends while a chunk is waiting for the consumer, the stream fails with the
+// context error and all channels close.
+func watchStream(ctx context.Context, stream *ChatStream, window time.Duration, cancel context.CancelFunc) *ChatStream {
+	ch := make(chan ChatChunk)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *ChatResponse, 1)
+
+	go func() {
+		defer close(finalCh)
+		defer close(errCh)
+		defer cancel()
+
+		timer := time.NewTimer(window)
+		defer timer.Stop()
+
+		var errSent bool
+		upCh, upErr, upFinal := stream.Ch, stream.Err, stream.Final
+		for upCh != nil || upErr != nil || upFinal != nil {
+			select {
+			case chunk, ok := <-upCh:
+				if !ok {
+					upCh = nil
+					close(ch)
+					continue
+				}
+				// Pause the window while the consumer reads the chunk
+				timer.Stop()
+				select {
+				case ch <- chunk:
+				case <-ctx.Done():
+					if !errSent {
+						errCh <- ctx.Err()
+					}
+					close(ch)
+					go drainStream(stream)
+					return
+				}
+				timer.Reset(window)
+
+			case err, ok := <-upErr:
+				if !ok {
+					upErr = nil
+					continue
+				}
+				if err != nil && !errSent {
+					errSent = true
+					errCh <- err
+				}
+
+			case resp, ok := <-upFinal:
+				if !ok {
+					upFinal = nil
+					continue
+				}
+				finalCh <- resp
+
+			case <-timer.C:
+				cancel()
+				// Send the error before closing Ch, as providers do
+				if !errSent {
+					errCh <- fmt.Errorf("%w: no data received for %s", ErrStreamStalled, window)
+				}
+				if upCh != nil {
+					close(ch)
+				}
+				// Drain the provider so its goroutines can exit after cancellation
+				go drainStream(stream)
+				return
+			}
+		}
+	}()
+
+	return &ChatStream{Ch: ch, Err: errCh, Final: finalCh}
+}
+
+// drainStream discards everything remaining on stream until all channels close.
+func drainStream(stream *ChatStream) {
+	for range stream.Ch {
+	}
+	for range stream.Err {
+	}
+	for range stream.Final {
+	}
+}
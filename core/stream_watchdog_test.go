@@ -0,0 +1,147 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStreamStallTimeout(t *testing.T) {
+	cancelled := make(chan struct{})
+	provider := &mockProvider{
+		streamFunc: func(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+			ch := make(chan ChatChunk)
+			errCh := make(chan error, 1)
+			finalCh := make(chan *ChatResponse, 1)
+			go func() {
+				defer close(ch)
+				defer close(errCh)
+				defer close(finalCh)
+				ch <- ChatChunk{Delta: "Hel"}
+				// Hang until the request is cancelled
+				<-ctx.Done()
+				close(cancelled)
+			}()
+			return &ChatStream{Ch: ch, Err: errCh, Final: finalCh}, nil
+		},
+	}
+	client := NewClient(provider)
+
+	stream, err := client.Chat("mock-model").User("Hi").StallTimeout(50 * time.Millisecond).Stream(context.Background())
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	_, err = DrainStream(context.Background(), stream)
+	if !errors.Is(err, ErrStreamStalled) {
+		t.Fatalf("DrainStream() error = %v, want ErrStreamStalled", err)
+	}
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		t.Fatal("underlying request was not cancelled")
+	}
+}
+
+func TestStreamStallTimeoutResetsPerChunk(t *testing.T) {
+	provider := &mockProvider{
+		streamFunc: func(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+			ch := make(chan ChatChunk)
+			errCh := make(chan error, 1)
+			finalCh := make(chan *ChatResponse, 1)
+			go func() {
+				defer close(ch)
+				defer close(errCh)
+				defer close(finalCh)
+				// Total time exceeds the window, but each gap is within it
+				for _, d := range []string{"a", "b", "c", "d"} {
+					time.Sleep(30 * time.Millisecond)
+					ch <- ChatChunk{Delta: d}
+				}
+				finalCh <- &ChatResponse{}
+			}()
+			return &ChatStream{Ch: ch, Err: errCh, Final: finalCh}, nil
+		},
+	}
+	client := NewClient(provider)
+
+	stream, err := client.Chat("mock-model").User("Hi").StallTimeout(100 * time.Millisecond).Stream(context.Background())
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	resp, err := DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+	if resp.Output != "abcd" {
+		t.Errorf("Output = %q, want %q", resp.Output, "abcd")
+	}
+}
+
+func TestStreamStallTimeoutCancelWithoutDraining(t *testing.T) {
+	provider := &mockProvider{
+		streamFunc: func(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+			ch := make(chan ChatChunk)
+			errCh := make(chan error, 1)
+			finalCh := make(chan *ChatResponse, 1)
+			go func() {
+				defer close(ch)
+				defer close(errCh)
+				defer close(finalCh)
+				select {
+				case ch <- ChatChunk{Delta: "Hel"}:
+				case <-ctx.Done():
+				}
+				<-ctx.Done()
+			}()
+			return &ChatStream{Ch: ch, Err: errCh, Final: finalCh}, nil
+		},
+	}
+	client := NewClient(provider)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := client.Chat("mock-model").User("Hi").StallTimeout(time.Minute).Stream(ctx)
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+
+	// Let the watchdog block handing over the first chunk, then cancel
+	// without ever reading Ch
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	timeout := time.After(time.Second)
+	select {
+	case err := <-stream.Err:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Err = %v, want context.Canceled", err)
+		}
+	case <-timeout:
+		t.Fatal("Err was not sent after cancellation")
+	}
+	for {
+		select {
+		case _, ok := <-stream.Err:
+			if ok {
+				continue
+			}
+		case <-timeout:
+			t.Fatal("Err was not closed after cancellation")
+		}
+		break
+	}
+	for {
+		select {
+		case _, ok := <-stream.Final:
+			if ok {
+				continue
+			}
+		case <-timeout:
+			t.Fatal("Final was not closed after cancellation")
+		}
+		break
+	}
+}
@@ -14,7 +14,7 @@ import (
 //   - Final channel emits exactly once on success (or zero times on setup failure)
 //   - If providers cannot compute Usage for streaming, they MAY leave it zeroed
 type ChatStream struct {
-	// Ch emits text deltas in order. Closed when stream ends.
+	// Ch emits text deltas and output parts in order. Closed when stream ends.
 	Ch <-chan ChatChunk
 
 	// Err emits at most one error. MUST be closed when stream ends.
@@ -35,13 +35,16 @@ type ChatStream struct {
 //  2. Check Err channel for any errors
 //  3. Wait for Final to get complete response with usage/tool calls
 //  4. If Final includes Output, use it; otherwise use accumulated deltas
-//  5. Handle context cancellation gracefully
+//  5. If Final includes no Parts, use the parts collected from chunks
+//  6. If Final includes no Reasoning, use the accumulated reasoning deltas
+//  7. Handle context cancellation gracefully
 func DrainStream(ctx context.Context, s *ChatStream) (*ChatResponse, error) {
 	if s == nil {
 		return nil, ErrBadRequest
 	}
 
-	var accumulated strings.Builder
+	var accumulated, reasoning strings.Builder
+	var parts []OutputPart
 	var streamErr error
 	var finalResp *ChatResponse
 
@@ -57,6 +60,8 @@ func DrainStream(ctx context.Context, s *ChatStream) (*ChatResponse, error) {
 				goto checkErr
 			}
 			accumulated.WriteString(chunk.Delta)
+			reasoning.WriteString(chunk.Reasoning)
+			parts = append(parts, chunk.Parts...)
 
 		case err, ok := <-s.Err:
 			if ok && err != nil {
@@ -72,9 +77,31 @@ func DrainStream(ctx context.Context, s *ChatStream) (*ChatResponse, error) {
 	}
 
 checkErr:
+	// Wait for the final response or an error; wrapped streams may still be
+	// relaying them after Ch closes
+	errCh, finalCh := s.Err, s.Final
+	for streamErr == nil && finalResp == nil && finalCh != nil {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+			} else if err != nil {
+				streamErr = err
+			}
+		case resp, ok := <-finalCh:
+			if !ok {
+				finalCh = nil
+			} else {
+				finalResp = resp
+			}
+		}
+	}
+
 	// Drain any remaining error
 	select {
-	case err, ok := <-s.Err:
+	case err, ok := <-errCh:
 		if ok && err != nil {
 			streamErr = err
 		}
@@ -86,25 +113,24 @@ checkErr:
 		return nil, streamErr
 	}
 
-	// Wait for final response
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	case resp, ok := <-s.Final:
-		if ok {
-			finalResp = resp
-		}
-	}
-
 	// Build response
 	if finalResp == nil {
 		// No final response, create one from accumulated content
 		finalResp = &ChatResponse{
 			Output: accumulated.String(),
+			Parts:  parts,
+		}
+	} else {
+		if finalResp.Reasoning == nil && reasoning.Len() > 0 {
+			finalResp.Reasoning = &ReasoningOutput{Summary: []string{reasoning.String()}}
+		}
+		if finalResp.Output == "" {
+			// Final has no output, use accumulated deltas
+			finalResp.Output = accumulated.String()
+		}
+		if len(finalResp.Parts) == 0 {
+			finalResp.Parts = parts
 		}
-	} else if finalResp.Output == "" {
-		// Final has no output, use accumulated deltas
-		finalResp.Output = accumulated.String()
 	}
 
 	return finalResp, nil
@@ -240,3 +240,83 @@ func TestChatStreamTypeHasCorrectChannelDirections(t *testing.T) {
 		t.Fatal("stream should not be nil")
 	}
 }
+
+func TestDrainStreamCollectsParts(t *testing.T) {
+	ch := make(chan ChatChunk, 3)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *ChatResponse, 1)
+
+	image := OutputImage{MimeType: "image/png", Data: "aW1n"}
+	go func() {
+		ch <- ChatChunk{Delta: "Here"}
+		ch <- ChatChunk{Parts: []OutputPart{image}}
+		close(ch)
+		finalCh <- &ChatResponse{ID: "resp-1"}
+		close(finalCh)
+		close(errCh)
+	}()
+
+	stream := &ChatStream{Ch: ch, Err: errCh, Final: finalCh}
+	resp, err := DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Output != "Here" {
+		t.Errorf("Output = %q, want %q", resp.Output, "Here")
+	}
+	if len(resp.Parts) != 1 || resp.Parts[0] != image {
+		t.Errorf("Parts = %v, want [%v]", resp.Parts, image)
+	}
+}
+
+func TestDrainStreamPrefersFinalParts(t *testing.T) {
+	ch := make(chan ChatChunk, 1)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *ChatResponse, 1)
+
+	finalParts := []OutputPart{OutputText{Text: "Here"}, OutputImage{Data: "aW1n"}}
+	go func() {
+		ch <- ChatChunk{Parts: []OutputPart{OutputImage{Data: "aW1n"}}}
+		close(ch)
+		finalCh <- &ChatResponse{Output: "Here", Parts: finalParts}
+		close(finalCh)
+		close(errCh)
+	}()
+
+	stream := &ChatStream{Ch: ch, Err: errCh, Final: finalCh}
+	resp, err := DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.Parts) != 2 {
+		t.Errorf("len(Parts) = %d, want 2", len(resp.Parts))
+	}
+}
+
+func TestDrainStreamCollectsReasoning(t *testing.T) {
+	ch := make(chan ChatChunk, 3)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *ChatResponse, 1)
+
+	go func() {
+		ch <- ChatChunk{Reasoning: "Think "}
+		ch <- ChatChunk{Reasoning: "first."}
+		ch <- ChatChunk{Delta: "Answer"}
+		close(ch)
+		finalCh <- &ChatResponse{ID: "resp-1"}
+		close(finalCh)
+		close(errCh)
+	}()
+
+	stream := &ChatStream{Ch: ch, Err: errCh, Final: finalCh}
+	resp, err := DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp.Output != "Answer" {
+		t.Errorf("Output = %q, want %q", resp.Output, "Answer")
+	}
+	if !resp.HasReasoning() || resp.Reasoning.Summary[0] != "Think first." {
+		t.Errorf("Reasoning = %+v, want [Think first.]", resp.Reasoning)
+	}
+}
@@ -0,0 +1,27 @@
+// Package streamjson parses structured output while it streams, so user
+// interfaces can render JSON results field by field instead of waiting for
+// the final response.
+//
+// A Parser accepts the text deltas of a JSON document and completes the
+// prefix received so far into valid JSON: open strings, arrays, and objects
+// are closed, and keys without a value, trailing commas, and incomplete
+// literals are dropped. String values appear as they grow.
+//
+// Stream and StreamTyped consume a core.ChatStream, calling back with each
+// new partial value and returning the final response:
+//
+//	stream, err := client.Chat(model).
+//	    User("Summarize this article").
+//	    ResponseJSONSchema(schema).
+//	    Stream(ctx)
+//	if err != nil {
+//	    return err
+//	}
+//	summary, resp, err := streamjson.StreamTyped(ctx, stream, func(partial Summary) error {
+//	    render(partial)
+//	    return nil
+//	})
+//
+// Text before the first '{' or '[', such as a Markdown code fence, and text
+// after the document ends are ignored.
+package streamjson
@@ -0,0 +1,69 @@
+package streamjson
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// Parser parses a JSON document from its text deltas. The zero value is
+// ready to use. A Parser is not safe for concurrent use.
+type Parser struct {
+	buf  []byte
+	scan scanner
+	last string
+}
+
+// Write appends delta to the document. It reports whether the completed
+// document changed, so callers can skip deltas that add nothing visible such
+// as whitespace or half of a keyword. Once the document is invalid, Write
+// returns the error and ignores further deltas.
+func (p *Parser) Write(delta string) (changed bool, err error) {
+	if p.scan.err != nil {
+		return false, p.scan.err
+	}
+	p.buf = append(p.buf, delta...)
+	p.scan.feed(p.buf, []byte(delta))
+
+	doc, ok := p.scan.complete(p.buf)
+	if !ok || doc == p.last {
+		return false, p.scan.err
+	}
+	p.last = doc
+	return true, p.scan.err
+}
+
+// JSON returns the document received so far, completed into valid JSON. It
+// returns false before the document's opening '{' or '['.
+func (p *Parser) JSON() (string, bool) {
+	return p.scan.complete(p.buf)
+}
+
+// Done reports whether the top-level value is complete.
+func (p *Parser) Done() bool {
+	return p.scan.end > 0
+}
+
+// Value decodes the completed document into a map or slice. It returns nil
+// before the document starts.
+func (p *Parser) Value() any {
+	var v any
+	if doc, ok := p.JSON(); ok {
+		_ = json.Unmarshal([]byte(doc), &v)
+	}
+	return v
+}
+
+// Decode decodes the completed document into v. Fields not yet received
+// keep their zero values, and string fields may be incomplete.
+func (p *Parser) Decode(v any) error {
+	doc, ok := p.JSON()
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(doc), v); err != nil {
+		return fmt.Errorf("%w: %v", core.ErrInvalidJSONOutput, err)
+	}
+	return nil
+}
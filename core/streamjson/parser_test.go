@@ -0,0 +1,134 @@
+package streamjson
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestParserCompletes(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"empty", "", ""},
+		{"open object", `{`, `{}`},
+		{"partial key", `{"tit`, `{}`},
+		{"key without value", `{"title":`, `{}`},
+		{"partial string value", `{"title": "Hello wo`, `{"title": "Hello wo"}`},
+		{"trailing comma", `{"title": "Hi",`, `{"title": "Hi"}`},
+		{"partial escape", `{"title": "a\`, `{"title": "a"}`},
+		{"partial unicode escape", `{"title": "a\u00`, `{"title": "a"}`},
+		{"complete escape", `{"title": "a\"b`, `{"title": "a\"b"}`},
+		{"partial number", `{"n": 12`, `{"n": 12}`},
+		{"number needing digits", `{"n": 1.`, `{}`},
+		{"partial literal", `{"ok": tr`, `{}`},
+		{"complete literal", `{"ok": true`, `{"ok": true}`},
+		{"nested", `{"items": [{"name": "a"}, {"na`, `{"items": [{"name": "a"}, {}]}`},
+		{"array of strings", `["a", "b`, `["a", "b"]`},
+		{"code fence", "```json\n{\"a\": 1}\n```", `{"a": 1}`},
+		{"trailing text ignored", `{"a": 1} and more`, `{"a": 1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var p Parser
+			p.Write(tt.input)
+			got, _ := p.JSON()
+			if got != tt.want {
+				t.Errorf("JSON() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParserEveryPrefixIsValid(t *testing.T) {
+	doc := `{"title": "Café \"review\"", "score": -4.5e2, "tags": ["a", "b"], "meta": {"ok": false, "note": null}, "empty": []}`
+
+	var p Parser
+	for i := range len(doc) {
+		if _, err := p.Write(doc[i : i+1]); err != nil {
+			t.Fatalf("Write() at %d error = %v", i, err)
+		}
+		got, ok := p.JSON()
+		if !ok {
+			t.Fatalf("JSON() at %d not started", i)
+		}
+		if !json.Valid([]byte(got)) {
+			t.Fatalf("JSON() after %q = %q, not valid JSON", doc[:i+1], got)
+		}
+	}
+	if !p.Done() {
+		t.Error("Done() = false, want true")
+	}
+	got, _ := p.JSON()
+	if got != doc {
+		t.Errorf("JSON() = %q, want the whole document", got)
+	}
+}
+
+func TestParserWriteReportsChanges(t *testing.T) {
+	var p Parser
+	steps := []struct {
+		delta string
+		want  bool
+	}{
+		{"Sure: ", false},
+		{"{", true},
+		{`"a"`, false},
+		{`: `, false},
+		{`"x`, true},
+		{`y"`, true},
+		{"  ", false},
+	}
+	for _, s := range steps {
+		changed, err := p.Write(s.delta)
+		if err != nil {
+			t.Fatalf("Write(%q) error = %v", s.delta, err)
+		}
+		if changed != s.want {
+			t.Errorf("Write(%q) changed = %v, want %v", s.delta, changed, s.want)
+		}
+	}
+}
+
+func TestParserInvalidJSON(t *testing.T) {
+	var p Parser
+	p.Write(`{"a": 1, `)
+	if _, err := p.Write(`oops}`); err == nil {
+		t.Fatal("Write() error = nil, want error")
+	}
+	if _, err := p.Write(`"b": 2}`); err == nil {
+		t.Error("Write() after error = nil, want the first error")
+	}
+	got, _ := p.JSON()
+	if got != `{"a": 1}` {
+		t.Errorf("JSON() = %q, want the last valid prefix", got)
+	}
+}
+
+func TestParserDecode(t *testing.T) {
+	type article struct {
+		Title string   `json:"title"`
+		Tags  []string `json:"tags"`
+	}
+
+	var p Parser
+	p.Write(`{"title": "Streaming JSON", "tags": ["go", "ll`)
+
+	var a article
+	if err := p.Decode(&a); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if a.Title != "Streaming JSON" || len(a.Tags) != 2 || a.Tags[1] != "ll" {
+		t.Errorf("Decode() = %+v, want title and partial tags", a)
+	}
+
+	var n int
+	if err := p.Decode(&n); !errors.Is(err, core.ErrInvalidJSONOutput) {
+		t.Errorf("Decode() into int error = %v, want ErrInvalidJSONOutput", err)
+	}
+}
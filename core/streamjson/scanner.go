@@ -0,0 +1,251 @@
+package streamjson
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Frame states. Objects move through expectKey, expectColon, expectValue,
+// and expectComma; arrays alternate between expectValue and expectComma.
+const (
+	expectKey = iota
+	expectColon
+	expectValue
+	expectComma
+)
+
+// frame is an open object or array.
+type frame struct {
+	object bool
+	state  int
+}
+
+// scanner tracks the structure of a JSON document as it arrives, recording
+// the last position at which the document can be closed into valid JSON.
+type scanner struct {
+	stack []frame
+
+	started bool
+	start   int // index of the top-level '{' or '['
+	end     int // index after the top-level value once it is complete
+	pos     int // number of bytes scanned
+
+	// Scalar in progress
+	inString  bool
+	stringKey bool
+	escape    int // bytes left in an escape sequence, -1 after a backslash
+	escStart  int // index of the backslash of the current escape
+	inNumber  bool
+	numStart  int
+	literal   string // remaining bytes of true, false, or null
+
+	// Last safe cut: buf[start:safeEnd] + safeClose is valid JSON
+	safeEnd   int
+	safeClose string
+
+	err error
+}
+
+// numberRE matches a complete JSON number.
+var numberRE = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// feed scans data, which continues the document at s.pos.
+func (s *scanner) feed(buf []byte, data []byte) {
+	for _, c := range data {
+		if s.err != nil || s.end > 0 {
+			s.pos++
+			continue
+		}
+		s.step(buf, c)
+		s.pos++
+	}
+}
+
+// step scans the byte c at s.pos.
+func (s *scanner) step(buf []byte, c byte) {
+	if !s.started {
+		if c == '{' || c == '[' {
+			s.started, s.start = true, s.pos
+			s.open(c == '{')
+		}
+		return
+	}
+
+	switch {
+	case s.inString:
+		s.stringByte(c)
+		return
+	case s.literal != "":
+		if c != s.literal[0] {
+			s.fail(c)
+			return
+		}
+		s.literal = s.literal[1:]
+		if s.literal == "" {
+			s.valueDone(s.pos + 1)
+		}
+		return
+	case s.inNumber:
+		if strings.IndexByte("0123456789+-.eE", c) >= 0 {
+			return
+		}
+		s.inNumber = false
+		if !numberRE.Match(buf[s.numStart:s.pos]) {
+			s.err = fmt.Errorf("invalid number %q", buf[s.numStart:s.pos])
+			return
+		}
+		s.valueDone(s.pos)
+	}
+
+	if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+		return
+	}
+
+	top := &s.stack[len(s.stack)-1]
+	switch top.state {
+	case expectKey:
+		switch c {
+		case '"':
+			s.inString, s.stringKey = true, true
+		case '}':
+			s.close()
+		default:
+			s.fail(c)
+		}
+	case expectColon:
+		if c != ':' {
+			s.fail(c)
+			return
+		}
+		top.state = expectValue
+	case expectValue:
+		if c == ']' && !top.object {
+			s.close()
+			return
+		}
+		s.value(c)
+	case expectComma:
+		switch {
+		case c == ',' && top.object:
+			top.state = expectKey
+		case c == ',':
+			top.state = expectValue
+		case c == '}' && top.object, c == ']' && !top.object:
+			s.close()
+		default:
+			s.fail(c)
+		}
+	}
+}
+
+// value starts a value with the byte c.
+func (s *scanner) value(c byte) {
+	switch {
+	case c == '{' || c == '[':
+		s.open(c == '{')
+	case c == '"':
+		s.inString, s.stringKey = true, false
+	case c == '-' || (c >= '0' && c <= '9'):
+		s.inNumber, s.numStart = true, s.pos
+	case c == 't':
+		s.literal = "rue"
+	case c == 'f':
+		s.literal = "alse"
+	case c == 'n':
+		s.literal = "ull"
+	default:
+		s.fail(c)
+	}
+}
+
+// stringByte scans the byte c of a string.
+func (s *scanner) stringByte(c byte) {
+	switch {
+	case s.escape == -1:
+		s.escape = 0
+		if c == 'u' {
+			s.escape = 4
+		}
+	case s.escape > 0:
+		s.escape--
+	case c == '\\':
+		s.escape, s.escStart = -1, s.pos
+	case c == '"':
+		s.inString = false
+		if s.stringKey {
+			s.stack[len(s.stack)-1].state = expectColon
+			return
+		}
+		s.valueDone(s.pos + 1)
+	}
+}
+
+// open pushes an object or array and marks a safe cut after it.
+func (s *scanner) open(object bool) {
+	state := expectValue
+	if object {
+		state = expectKey
+	}
+	s.stack = append(s.stack, frame{object: object, state: state})
+	s.markSafe(s.pos + 1)
+}
+
+// close pops the innermost object or array, which ends a value.
+func (s *scanner) close() {
+	s.stack = s.stack[:len(s.stack)-1]
+	s.valueDone(s.pos + 1)
+}
+
+// valueDone records a complete value ending at end.
+func (s *scanner) valueDone(end int) {
+	if len(s.stack) == 0 {
+		s.end = end
+		return
+	}
+	s.stack[len(s.stack)-1].state = expectComma
+	s.markSafe(end)
+}
+
+func (s *scanner) markSafe(end int) {
+	s.safeEnd, s.safeClose = end, s.closers()
+}
+
+// closers returns the brackets that close the open objects and arrays.
+func (s *scanner) closers() string {
+	b := make([]byte, len(s.stack))
+	for i, f := range s.stack {
+		c := byte(']')
+		if f.object {
+			c = '}'
+		}
+		b[len(s.stack)-1-i] = c
+	}
+	return string(b)
+}
+
+func (s *scanner) fail(c byte) {
+	s.err = fmt.Errorf("unexpected %q at offset %d", c, s.pos)
+}
+
+// complete returns buf, the document scanned so far, closed into valid
+// JSON, or false if no document has started.
+func (s *scanner) complete(buf []byte) (string, bool) {
+	switch {
+	case !s.started:
+		return "", false
+	case s.end > 0:
+		return string(buf[s.start:s.end]), true
+	case s.err != nil:
+		return string(buf[s.start:s.safeEnd]) + s.safeClose, true
+	case s.inString && !s.stringKey:
+		end := s.pos
+		if s.escape != 0 {
+			end = s.escStart
+		}
+		return string(buf[s.start:end]) + `"` + s.closers(), true
+	case s.inNumber && numberRE.Match(buf[s.numStart:s.pos]):
+		return string(buf[s.start:s.pos]) + s.closers(), true
+	}
+	return string(buf[s.start:s.safeEnd]) + s.safeClose, true
+}
@@ -0,0 +1,106 @@
+package streamjson
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// Stream reads s to the end like core.DrainStream, calling fn with the
+// partially parsed structured output each time it changes. The partial value
+// is a map[string]any or []any as decoded by encoding/json.
+//
+// If fn returns an error, Stream returns it at once and discards the rest of
+// the stream in the background; cancel the request context to stop the
+// request itself. Output that is not valid JSON stops the updates but not
+// the stream.
+func Stream(ctx context.Context, s *core.ChatStream, fn func(partial any) error) (*core.ChatResponse, error) {
+	p := new(Parser)
+	return relay(ctx, s, p, func() error {
+		return fn(p.Value())
+	})
+}
+
+// StreamTyped is like Stream but decodes each partial value into a new T.
+// The final output is decoded into T and returned with the response; if it
+// is not a complete JSON document, the error wraps core.ErrInvalidJSONOutput.
+func StreamTyped[T any](ctx context.Context, s *core.ChatStream, fn func(partial T) error) (T, *core.ChatResponse, error) {
+	var zero T
+	p := new(Parser)
+	resp, err := relay(ctx, s, p, func() error {
+		var partial T
+		if err := p.Decode(&partial); err != nil {
+			return nil // The document completed so far does not fit T yet
+		}
+		return fn(partial)
+	})
+	if err != nil {
+		return zero, resp, err
+	}
+
+	// Parse the final output, which providers may send only in Final
+	final := p
+	if !p.Done() {
+		final = new(Parser)
+		final.Write(resp.Output)
+	}
+	if !final.Done() {
+		return zero, resp, fmt.Errorf("%w: incomplete JSON document", core.ErrInvalidJSONOutput)
+	}
+	var value T
+	if err := final.Decode(&value); err != nil {
+		return zero, resp, err
+	}
+	return value, resp, nil
+}
+
+// relay forwards the chunks of s to core.DrainStream, feeding their deltas
+// to p and calling update whenever the completed document changes.
+func relay(ctx context.Context, s *core.ChatStream, p *Parser, update func() error) (*core.ChatResponse, error) {
+	if s == nil {
+		return nil, core.ErrBadRequest
+	}
+
+	type result struct {
+		resp *core.ChatResponse
+		err  error
+	}
+	chunks := make(chan core.ChatChunk)
+	done := make(chan result, 1)
+	go func() {
+		resp, err := core.DrainStream(ctx, &core.ChatStream{Ch: chunks, Err: s.Err, Final: s.Final})
+		done <- result{resp, err}
+	}()
+
+	// abort stops relaying, leaving the rest of s to be discarded
+	abort := func() {
+		close(chunks)
+		go func() {
+			for range s.Ch {
+			}
+		}()
+	}
+
+	for chunk := range s.Ch {
+		select {
+		case chunks <- chunk:
+		case <-ctx.Done():
+			abort()
+			return nil, ctx.Err()
+		}
+		if chunk.Delta == "" {
+			continue
+		}
+		if changed, err := p.Write(chunk.Delta); err == nil && changed {
+			if err := update(); err != nil {
+				abort()
+				return nil, err
+			}
+		}
+	}
+	close(chunks)
+
+	r := <-done
+	return r.resp, r.err
+}
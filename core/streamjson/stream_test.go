@@ -0,0 +1,129 @@
+package streamjson
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// newStream returns a stream that sends deltas followed by final.
+func newStream(deltas []string, final *core.ChatResponse) *core.ChatStream {
+	ch := make(chan core.ChatChunk)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+	go func() {
+		defer close(ch)
+		defer close(errCh)
+		defer close(finalCh)
+		for _, d := range deltas {
+			ch <- core.ChatChunk{Delta: d}
+		}
+		finalCh <- final
+	}()
+	return &core.ChatStream{Ch: ch, Err: errCh, Final: finalCh}
+}
+
+func TestStream(t *testing.T) {
+	deltas := []string{`{"title": "Hel`, `lo", "items": [1,`, ` 2]}`}
+	stream := newStream(deltas, &core.ChatResponse{Usage: core.TokenUsage{TotalTokens: 9}})
+
+	var updates []any
+	resp, err := Stream(context.Background(), stream, func(partial any) error {
+		updates = append(updates, partial)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	if resp.Output != `{"title": "Hello", "items": [1, 2]}` {
+		t.Errorf("Output = %q, want accumulated deltas", resp.Output)
+	}
+	if resp.Usage.TotalTokens != 9 {
+		t.Errorf("Usage.TotalTokens = %d, want 9", resp.Usage.TotalTokens)
+	}
+
+	if len(updates) != 3 {
+		t.Fatalf("updates = %d, want 3", len(updates))
+	}
+	first := updates[0].(map[string]any)
+	if first["title"] != "Hel" {
+		t.Errorf("first update title = %v, want Hel", first["title"])
+	}
+	last := updates[2].(map[string]any)
+	if items := last["items"].([]any); len(items) != 2 {
+		t.Errorf("last update items = %v, want 2", items)
+	}
+}
+
+func TestStreamCallbackError(t *testing.T) {
+	errStop := errors.New("stop")
+	stream := newStream([]string{`{"a": 1,`, ` "b": 2`, `}`}, &core.ChatResponse{})
+
+	calls := 0
+	_, err := Stream(context.Background(), stream, func(any) error {
+		calls++
+		return errStop
+	})
+	if !errors.Is(err, errStop) {
+		t.Errorf("Stream() error = %v, want stop", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestStreamTyped(t *testing.T) {
+	type summary struct {
+		Title  string   `json:"title"`
+		Points []string `json:"points"`
+	}
+
+	t.Run("deltas", func(t *testing.T) {
+		stream := newStream([]string{`{"title": "Go", "points": ["fast"`, `, "simple"]}`}, &core.ChatResponse{})
+
+		var partials []summary
+		value, _, err := StreamTyped(context.Background(), stream, func(partial summary) error {
+			partials = append(partials, partial)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("StreamTyped() error = %v", err)
+		}
+		if len(partials) != 2 || len(partials[0].Points) != 1 {
+			t.Errorf("partials = %+v, want 2 with growing points", partials)
+		}
+		if value.Title != "Go" || len(value.Points) != 2 {
+			t.Errorf("value = %+v, want final summary", value)
+		}
+	})
+
+	t.Run("output only in final", func(t *testing.T) {
+		stream := newStream(nil, &core.ChatResponse{Output: `{"title": "Go"}`})
+		value, _, err := StreamTyped(context.Background(), stream, func(summary) error { return nil })
+		if err != nil {
+			t.Fatalf("StreamTyped() error = %v", err)
+		}
+		if value.Title != "Go" {
+			t.Errorf("value = %+v, want title from final output", value)
+		}
+	})
+
+	t.Run("incomplete", func(t *testing.T) {
+		stream := newStream([]string{`{"title": "Go"`}, &core.ChatResponse{})
+		_, resp, err := StreamTyped(context.Background(), stream, func(summary) error { return nil })
+		if !errors.Is(err, core.ErrInvalidJSONOutput) {
+			t.Errorf("StreamTyped() error = %v, want ErrInvalidJSONOutput", err)
+		}
+		if resp == nil {
+			t.Error("resp = nil, want the final response")
+		}
+	})
+}
+
+func TestStreamNil(t *testing.T) {
+	if _, err := Stream(context.Background(), nil, func(any) error { return nil }); !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("Stream(nil) error = %v, want ErrBadRequest", err)
+	}
+}
@@ -68,6 +68,7 @@ type RequestEndEvent struct {
 	End      time.Time  // When the request completed
 	Usage    TokenUsage // Token consumption
 	Err      error      // Error if request failed, nil on success
+	Retries  int        // Transport retries before the final attempt
 }
 
 // Duration returns the elapsed time for the request.
@@ -117,3 +118,81 @@ type ContextualTelemetryHook interface {
 	// OnRequestEndWithContext is called when a request to a provider completes.
 	OnRequestEndWithContext(ctx context.Context, e RequestEndEvent)
 }
+
+// RepairTelemetryHook is an optional extension of TelemetryHook for counting
+// structured output repairs. When the client's hook implements it,
+// GetTypedResponse calls OnRepairAttempt before each repair request.
+type RepairTelemetryHook interface {
+	TelemetryHook
+
+	// OnRepairAttempt is called when invalid structured output triggers a
+	// repair request.
+	OnRepairAttempt(e RepairEvent)
+}
+
+// RepairEvent describes a structured output repair attempt.
+//
+// # Security
+//
+// Like the request events, it excludes prompts and model output. Err
+// describes why decoding or validation failed.
+type RepairEvent struct {
+	Provider string  // Provider identifier
+	Model    ModelID // Model that produced the invalid output
+	Attempt  int     // Repair attempt number, starting at 1
+	Err      error   // Decoding or validation error that triggered the repair
+}
+
+// notifyRepair reports a repair attempt to the telemetry hook, if supported.
+func (c *Client) notifyRepair(e RepairEvent) {
+	if h, ok := c.telemetry.(RepairTelemetryHook); ok {
+		h.OnRepairAttempt(e)
+	}
+}
+
+// StreamTelemetryHook is an optional extension of TelemetryHook for
+// streaming latency. When the client's hook implements it, every stream
+// started with ChatBuilder.Stream reports a StreamStatsEvent once it
+// completes, after OnRequestEnd.
+type StreamTelemetryHook interface {
+	TelemetryHook
+
+	// OnStreamStats is called when a stream completes, successfully or not.
+	OnStreamStats(e StreamStatsEvent)
+}
+
+// StreamStatsEvent describes the timing of a completed stream. Chunks are
+// timed as the provider delivers them, independent of how fast the caller
+// reads them.
+//
+// # Security
+//
+// Like the request events, it contains only timing and counts, never chunk
+// content.
+type StreamStatsEvent struct {
+	Provider string    // Provider identifier
+	Model    ModelID   // Model that was called
+	Start    time.Time // When the request started
+	End      time.Time // When the stream completed
+
+	// TimeToFirstToken is the time from Start to the first chunk carrying
+	// content. It is zero if no content arrived.
+	TimeToFirstToken time.Duration
+
+	// ChunkCount is the number of chunks received.
+	ChunkCount int
+
+	// Inter-chunk latency percentiles and maximum, measured between
+	// consecutive chunks. They are zero with fewer than two chunks.
+	InterChunkP50 time.Duration
+	InterChunkP90 time.Duration
+	InterChunkP99 time.Duration
+	InterChunkMax time.Duration
+
+	Err error // Error if the stream failed, nil on success
+}
+
+// Duration returns the total stream duration.
+func (e StreamStatsEvent) Duration() time.Duration {
+	return e.End.Sub(e.Start)
+}
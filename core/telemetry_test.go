@@ -198,6 +198,7 @@ func TestEventStructsHaveNoSecretFields(t *testing.T) {
 		End:      time.Now(),   // safe: timestamp
 		Usage:    TokenUsage{}, // safe: token counts only
 		Err:      nil,          // safe: error type (not content)
+		Retries:  0,            // safe: retry count
 	}
 
 	// If this test compiles, the structs don't have fields like:
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// TimeoutProvider is implemented by providers with a default request
+// timeout, such as one set with their WithTimeout option. Local servers and
+// long reasoning runs need very different limits, so each provider reports
+// its own.
+type TimeoutProvider interface {
+	// DefaultTimeout returns the timeout for requests that set none, or zero
+	// for no timeout.
+	DefaultTimeout() time.Duration
+}
+
+// DefaultTimeout sets the timeout of GetResponse and Client.Do for requests
+// that set no Timeout, overriding the provider's DefaultTimeout. A context
+// deadline always takes precedence.
+func DefaultTimeout(d time.Duration) ClientOption {
+	return func(c *Client) {
+		c.defaultTimeout = d
+	}
+}
+
+// requestTimeout returns the timeout of a request: the builder Timeout if
+// set, then the client DefaultTimeout, then the provider's DefaultTimeout.
+func (b *ChatBuilder) requestTimeout() time.Duration {
+	if b.timeout > 0 {
+		return b.timeout
+	}
+	if b.client.defaultTimeout > 0 {
+		return b.client.defaultTimeout
+	}
+	if tp, ok := b.client.provider.(TimeoutProvider); ok {
+		return tp.DefaultTimeout()
+	}
+	return 0
+}
+
+// withRequestTimeout applies the request timeout to ctx unless ctx already
+// has a deadline.
+func (b *ChatBuilder) withRequestTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if _, hasDeadline := ctx.Deadline(); hasDeadline {
+		return ctx, func() {}
+	}
+	if d := b.requestTimeout(); d > 0 {
+		return context.WithTimeout(ctx, d)
+	}
+	return ctx, func() {}
+}
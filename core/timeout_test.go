@@ -0,0 +1,90 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// timeoutProvider is a mockProvider with a default request timeout.
+type timeoutProvider struct {
+	mockProvider
+	timeout time.Duration
+}
+
+func (p *timeoutProvider) DefaultTimeout() time.Duration { return p.timeout }
+
+// deadlineRecorder returns a chat function that records the time left until
+// the request deadline, or -1 if the request has none.
+func deadlineRecorder(remaining *time.Duration) func(context.Context, *ChatRequest) (*ChatResponse, error) {
+	return func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+		*remaining = -1
+		if deadline, ok := ctx.Deadline(); ok {
+			*remaining = time.Until(deadline)
+		}
+		return &ChatResponse{Output: "OK"}, nil
+	}
+}
+
+func TestRequestTimeoutPrecedence(t *testing.T) {
+	tests := []struct {
+		name            string
+		providerTimeout time.Duration
+		clientTimeout   time.Duration
+		builderTimeout  time.Duration
+		want            time.Duration // zero for no deadline
+	}{
+		{"none", 0, 0, 0, 0},
+		{"provider default", time.Minute, 0, 0, time.Minute},
+		{"client overrides provider", time.Minute, 2 * time.Minute, 0, 2 * time.Minute},
+		{"builder overrides both", time.Minute, 2 * time.Minute, 3 * time.Minute, 3 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var remaining time.Duration
+			provider := &timeoutProvider{timeout: tt.providerTimeout}
+			provider.id = "test"
+			provider.chatFunc = deadlineRecorder(&remaining)
+
+			var opts []ClientOption
+			if tt.clientTimeout > 0 {
+				opts = append(opts, DefaultTimeout(tt.clientTimeout))
+			}
+			b := NewClient(provider, opts...).Chat("test-model").User("Hello")
+			if tt.builderTimeout > 0 {
+				b.Timeout(tt.builderTimeout)
+			}
+			if _, err := b.GetResponse(context.Background()); err != nil {
+				t.Fatalf("GetResponse() error = %v", err)
+			}
+
+			if tt.want == 0 {
+				if remaining != -1 {
+					t.Errorf("deadline in %v, want none", remaining)
+				}
+				return
+			}
+			if remaining <= tt.want-time.Second || remaining > tt.want {
+				t.Errorf("deadline in %v, want about %v", remaining, tt.want)
+			}
+		})
+	}
+}
+
+func TestProviderTimeoutNotAppliedWhenContextHasDeadline(t *testing.T) {
+	var remaining time.Duration
+	provider := &timeoutProvider{timeout: 10 * time.Millisecond}
+	provider.id = "test"
+	provider.chatFunc = deadlineRecorder(&remaining)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	if _, err := NewClient(provider).Chat("test-model").User("Hello").GetResponse(ctx); err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if remaining < 50*time.Second {
+		t.Errorf("deadline in %v, want the context deadline", remaining)
+	}
+}
@@ -0,0 +1,69 @@
+package core
+
+import "fmt"
+
+// ToolChoiceMode controls whether and how the model calls tools.
+type ToolChoiceMode string
+
+const (
+	// ToolChoiceAuto lets the model decide whether to call tools. This is the
+	// provider default when tools are present.
+	ToolChoiceAuto ToolChoiceMode = "auto"
+	// ToolChoiceNone prevents the model from calling tools.
+	ToolChoiceNone ToolChoiceMode = "none"
+	// ToolChoiceRequired forces the model to call at least one tool.
+	ToolChoiceRequired ToolChoiceMode = "required"
+	// ToolChoiceTool forces the model to call the tool named in ToolChoice.Name.
+	ToolChoiceTool ToolChoiceMode = "tool"
+)
+
+// ToolChoice selects how the model uses the tools in a request.
+// Providers map it to their native setting (tool_choice, tool_config, etc.).
+type ToolChoice struct {
+	// Mode is the tool calling mode.
+	Mode ToolChoiceMode `json:"mode"`
+
+	// Name is the tool to call when Mode is ToolChoiceTool.
+	Name string `json:"name,omitempty"`
+}
+
+// ForceTool returns a ToolChoice that requires the model to call the named tool.
+func ForceTool(name string) ToolChoice {
+	return ToolChoice{Mode: ToolChoiceTool, Name: name}
+}
+
+// Validate checks that the mode is recognized and that a tool name is set
+// when one is required.
+func (c ToolChoice) Validate() error {
+	switch c.Mode {
+	case ToolChoiceAuto, ToolChoiceNone, ToolChoiceRequired:
+		return nil
+	case ToolChoiceTool:
+		if c.Name == "" {
+			return fmt.Errorf("%w: tool name required for mode %q", ErrInvalidToolChoice, c.Mode)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: unknown mode %q", ErrInvalidToolChoice, c.Mode)
+	}
+}
+
+// ToolChoice sets how the model may use tools. Use ToolChoiceTool via
+// ForceTool to require a specific tool:
+//
+//	resp, err := client.Chat(model).
+//	    Tools(weatherTool).
+//	    ToolChoice(core.ForceTool("get_weather")).
+//	    User("What's the weather in Paris?").
+//	    GetResponse(ctx)
+func (b *ChatBuilder) ToolChoice(choice ToolChoice) *ChatBuilder {
+	b.req.ToolChoice = &choice
+	return b
+}
+
+// ParallelToolCalls enables or disables parallel tool calls in a single model
+// turn. When unset, the provider default applies (usually enabled).
+func (b *ChatBuilder) ParallelToolCalls(enabled bool) *ChatBuilder {
+	b.req.ParallelToolCalls = &enabled
+	return b
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestToolChoiceValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		choice  ToolChoice
+		wantErr bool
+	}{
+		{"auto", ToolChoice{Mode: ToolChoiceAuto}, false},
+		{"none", ToolChoice{Mode: ToolChoiceNone}, false},
+		{"required", ToolChoice{Mode: ToolChoiceRequired}, false},
+		{"forced tool", ForceTool("get_weather"), false},
+		{"tool without name", ToolChoice{Mode: ToolChoiceTool}, true},
+		{"unknown mode", ToolChoice{Mode: "sometimes"}, true},
+		{"empty mode", ToolChoice{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.choice.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidToolChoice) {
+				t.Errorf("Validate() error = %v, want ErrInvalidToolChoice", err)
+			}
+		})
+	}
+}
+
+func TestChatBuilderToolChoice(t *testing.T) {
+	provider := &mockProvider{}
+	client := NewClient(provider)
+
+	_, err := client.Chat("test-model").
+		User("Weather?").
+		Tools(&mockTool{name: "get_weather"}).
+		ToolChoice(ForceTool("get_weather")).
+		ParallelToolCalls(false).
+		GetResponse(context.Background())
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+
+	req := provider.lastRequest
+	if req.ToolChoice == nil || req.ToolChoice.Mode != ToolChoiceTool || req.ToolChoice.Name != "get_weather" {
+		t.Errorf("ToolChoice = %+v, want forced get_weather", req.ToolChoice)
+	}
+	if req.ParallelToolCalls == nil || *req.ParallelToolCalls {
+		t.Errorf("ParallelToolCalls = %v, want false", req.ParallelToolCalls)
+	}
+}
+
+func TestChatBuilderInvalidToolChoice(t *testing.T) {
+	client := NewClient(&mockProvider{})
+
+	_, err := client.Chat("test-model").
+		User("Hi").
+		ToolChoice(ToolChoice{Mode: ToolChoiceTool}).
+		GetResponse(context.Background())
+	if !errors.Is(err, ErrInvalidToolChoice) {
+		t.Errorf("GetResponse() error = %v, want ErrInvalidToolChoice", err)
+	}
+}
+
+func TestChatBuilderCloneCopiesToolChoice(t *testing.T) {
+	client := NewClient(&mockProvider{})
+	original := client.Chat("test-model").
+		ToolChoice(ToolChoice{Mode: ToolChoiceRequired}).
+		ParallelToolCalls(true)
+
+	clone := original.Clone()
+	clone.req.ToolChoice.Mode = ToolChoiceNone
+	*clone.req.ParallelToolCalls = false
+
+	if original.req.ToolChoice.Mode != ToolChoiceRequired {
+		t.Errorf("original ToolChoice.Mode = %q, want %q", original.req.ToolChoice.Mode, ToolChoiceRequired)
+	}
+	if !*original.req.ParallelToolCalls {
+		t.Error("original ParallelToolCalls changed by clone")
+	}
+}
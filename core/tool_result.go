@@ -0,0 +1,132 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// ToolResultFormat controls how tool result content is serialized before it
+// is sent back to the model.
+type ToolResultFormat string
+
+const (
+	// ToolResultFormatAuto sends strings verbatim and JSON-encodes everything else.
+	// This is the default and matches how providers historically formatted results.
+	ToolResultFormatAuto ToolResultFormat = ""
+	// ToolResultFormatJSON always JSON-encodes the result, including plain strings.
+	ToolResultFormatJSON ToolResultFormat = "json"
+	// ToolResultFormatText renders results as plain text. Strings, byte slices,
+	// errors, and fmt.Stringer values use their textual form; other values fall
+	// back to JSON.
+	ToolResultFormatText ToolResultFormat = "text"
+)
+
+// DefaultToolResultTruncationMarker is appended to tool results cut by MaxBytes.
+const DefaultToolResultTruncationMarker = "...[truncated]"
+
+// toolResultMarshalError is sent when a tool result cannot be JSON-encoded.
+const toolResultMarshalError = "{\"error\": \"failed to marshal tool result\"}"
+
+// ToolResultPolicy controls how ToolResult content is serialized for the model.
+// The providers that send tool results as text apply the policy when mapping
+// RoleTool messages, so results are formatted identically across them: OpenAI
+// Chat Completions, Anthropic, Azure AI Foundry, Ollama, Z.ai, and the
+// OpenAI-compatible providers (openaicompat, cerebras, and vllm). Other
+// providers ignore it.
+type ToolResultPolicy struct {
+	// Format selects the serialization strategy. Defaults to ToolResultFormatAuto.
+	Format ToolResultFormat `json:"format,omitempty"`
+
+	// MaxBytes caps the serialized size of a single result. Zero means no limit.
+	// Truncation never splits a UTF-8 sequence.
+	MaxBytes int `json:"max_bytes,omitempty"`
+
+	// TruncationMarker is appended to truncated results.
+	// Defaults to DefaultToolResultTruncationMarker.
+	TruncationMarker string `json:"truncation_marker,omitempty"`
+}
+
+// DefaultToolResultPolicy returns the policy used when none is configured:
+// automatic formatting with no size limit.
+func DefaultToolResultPolicy() ToolResultPolicy {
+	return ToolResultPolicy{Format: ToolResultFormatAuto}
+}
+
+// Serialize converts tool result content to the string sent to the model.
+// A nil policy behaves like DefaultToolResultPolicy.
+func (p *ToolResultPolicy) Serialize(content any) string {
+	policy := DefaultToolResultPolicy()
+	if p != nil {
+		policy = *p
+	}
+
+	var out string
+	switch policy.Format {
+	case ToolResultFormatJSON:
+		out = marshalToolResultJSON(content)
+	case ToolResultFormatText:
+		out = toolResultText(content)
+	default:
+		if s, ok := content.(string); ok {
+			out = s
+		} else {
+			out = marshalToolResultJSON(content)
+		}
+	}
+
+	return policy.truncate(out)
+}
+
+// truncate shortens s to MaxBytes, including the truncation marker.
+func (p ToolResultPolicy) truncate(s string) string {
+	if p.MaxBytes <= 0 || len(s) <= p.MaxBytes {
+		return s
+	}
+
+	marker := p.TruncationMarker
+	if marker == "" {
+		marker = DefaultToolResultTruncationMarker
+	}
+
+	limit := p.MaxBytes - len(marker)
+	if limit <= 0 {
+		// The marker alone does not fit; return a plain byte cut instead.
+		limit = p.MaxBytes
+		marker = ""
+	}
+
+	// Back off to a rune boundary so the result stays valid UTF-8.
+	for limit > 0 && !utf8.RuneStart(s[limit]) {
+		limit--
+	}
+
+	return s[:limit] + marker
+}
+
+func marshalToolResultJSON(content any) string {
+	data, err := json.Marshal(content)
+	if err != nil {
+		return toolResultMarshalError
+	}
+	return string(data)
+}
+
+func toolResultText(content any) string {
+	switch v := content.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case json.RawMessage:
+		return string(v)
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return marshalToolResultJSON(content)
+	}
+}
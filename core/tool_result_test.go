@@ -0,0 +1,78 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+type stringerValue struct{}
+
+func (stringerValue) String() string { return "stringer" }
+
+func TestToolResultPolicySerialize(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  *ToolResultPolicy
+		content any
+		want    string
+	}{
+		{"nil policy string", nil, "plain text", "plain text"},
+		{"nil policy object", nil, map[string]string{"key": "value"}, `{"key":"value"}`},
+		{"auto unmarshalable", &ToolResultPolicy{}, make(chan int), toolResultMarshalError},
+		{"json string", &ToolResultPolicy{Format: ToolResultFormatJSON}, "plain", `"plain"`},
+		{"json number", &ToolResultPolicy{Format: ToolResultFormatJSON}, 42, "42"},
+		{"text string", &ToolResultPolicy{Format: ToolResultFormatText}, "plain", "plain"},
+		{"text bytes", &ToolResultPolicy{Format: ToolResultFormatText}, []byte("raw"), "raw"},
+		{"text error", &ToolResultPolicy{Format: ToolResultFormatText}, errors.New("boom"), "boom"},
+		{"text stringer", &ToolResultPolicy{Format: ToolResultFormatText}, stringerValue{}, "stringer"},
+		{"text nil", &ToolResultPolicy{Format: ToolResultFormatText}, nil, ""},
+		{"text object falls back to json", &ToolResultPolicy{Format: ToolResultFormatText}, []int{1, 2}, "[1,2]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Serialize(tt.content); got != tt.want {
+				t.Errorf("Serialize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToolResultPolicyTruncation(t *testing.T) {
+	policy := &ToolResultPolicy{MaxBytes: 20}
+	got := policy.Serialize(strings.Repeat("a", 100))
+	if len(got) != 20 {
+		t.Errorf("len = %d, want 20", len(got))
+	}
+	if !strings.HasSuffix(got, DefaultToolResultTruncationMarker) {
+		t.Errorf("result %q missing truncation marker", got)
+	}
+
+	policy = &ToolResultPolicy{MaxBytes: 10, TruncationMarker: "~"}
+	if got := policy.Serialize("short"); got != "short" {
+		t.Errorf("short result should be untouched, got %q", got)
+	}
+	if got := policy.Serialize("0123456789abc"); got != "012345678~" {
+		t.Errorf("Serialize() = %q, want %q", got, "012345678~")
+	}
+}
+
+func TestToolResultPolicyTruncationKeepsUTF8(t *testing.T) {
+	policy := &ToolResultPolicy{MaxBytes: 6, TruncationMarker: "."}
+	got := policy.Serialize("héllo wörld")
+	if !utf8.ValidString(got) {
+		t.Errorf("truncated result is not valid UTF-8: %q", got)
+	}
+	if len(got) > 6 {
+		t.Errorf("len = %d, want <= 6", len(got))
+	}
+}
+
+func TestToolResultPolicyTinyLimit(t *testing.T) {
+	policy := &ToolResultPolicy{MaxBytes: 3}
+	if got := policy.Serialize("abcdef"); got != "abc" {
+		t.Errorf("Serialize() = %q, want %q", got, "abc")
+	}
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"context"
+	"time"
+)
+
+// FeatureTranscription indicates support for speech-to-text transcription.
+const FeatureTranscription Feature = "transcription"
+
+// Transcriber is an optional interface for providers that convert speech
+// to text.
+type Transcriber interface {
+	// Transcribe converts the speech in an audio file to text.
+	Transcribe(ctx context.Context, req *TranscriptionRequest) (*TranscriptionResponse, error)
+}
+
+// TranscriptionRequest represents a request to transcribe audio.
+type TranscriptionRequest struct {
+	// Model selects the speech model. Local servers that load a single
+	// model ignore it.
+	Model ModelID `json:"model,omitempty"`
+
+	// Audio is the contents of the audio file, such as WAV or MP3.
+	Audio []byte `json:"-"`
+
+	// Filename names the audio file. Its extension tells the server the
+	// audio format; defaults to "audio.wav".
+	Filename string `json:"-"`
+
+	// Language is the ISO-639-1 language of the speech, such as "en".
+	// Empty detects the language.
+	Language string `json:"language,omitempty"`
+
+	// Prompt guides the transcription, for example with the spelling of
+	// names or the text preceding the audio.
+	Prompt string `json:"prompt,omitempty"`
+
+	// Temperature controls sampling; zero is the most deterministic.
+	Temperature *float32 `json:"temperature,omitempty"`
+
+	// Translate translates the speech to English instead of transcribing
+	// it in its own language.
+	Translate bool `json:"translate,omitempty"`
+}
+
+// TranscriptionResponse contains the text of transcribed audio.
+type TranscriptionResponse struct {
+	// Text is the full transcript.
+	Text string `json:"text"`
+
+	// Language is the language of the speech as reported by the provider.
+	Language string `json:"language,omitempty"`
+
+	// Duration is the length of the audio, if the provider reports it.
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// Segments are the timed parts of the transcript, if the provider
+	// returns them.
+	Segments []TranscriptionSegment `json:"segments,omitempty"`
+}
+
+// TranscriptionSegment is a timed part of a transcript.
+type TranscriptionSegment struct {
+	Start time.Duration `json:"start"`
+	End   time.Duration `json:"end"`
+	Text  string        `json:"text"`
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Validator is implemented by structured output types that check their own
+// invariants. GetTypedResponse calls Validate after decoding; an error is
+// treated like a decoding failure and can trigger a repair attempt.
+type Validator interface {
+	Validate() error
+}
+
+// RepairAttempts sets how many times GetTypedResponse re-prompts the model
+// when its output cannot be decoded or fails validation. Each repair adds the
+// invalid output and the error to the conversation and asks the model to
+// correct it. Zero (the default) disables repair.
+func (b *ChatBuilder) RepairAttempts(n int) *ChatBuilder {
+	b.repairAttempts = max(n, 0)
+	return b
+}
+
+// GetTypedResponse sends the request and decodes the JSON output into T.
+// Use it with ResponseJSON or ResponseJSONSchema:
+//
+//	type Weather struct {
+//	    City  string  `json:"city"`
+//	    TempC float64 `json:"temp_c"`
+//	}
+//
+//	w, resp, err := core.GetTypedResponse[Weather](ctx,
+//	    client.Chat(model).
+//	        ResponseJSONSchema(schema).
+//	        User("Weather in Paris as JSON").
+//	        RepairAttempts(2))
+//
+// Output wrapped in a Markdown code fence is accepted. If T (or *T)
+// implements Validator, Validate is called after decoding. When decoding or
+// validation fails and RepairAttempts is set, the model is re-prompted with
+// the error; otherwise, or once repairs are exhausted, the error wraps
+// ErrInvalidJSONOutput and the last response is returned with it.
+//
+// The builder is not modified: repair turns are added to a copy.
+func GetTypedResponse[T any](ctx context.Context, b *ChatBuilder) (T, *ChatResponse, error) {
+	var zero T
+	work := b.Clone()
+
+	for attempt := 0; ; attempt++ {
+		resp, err := work.GetResponse(ctx)
+
+		// Invalid output from ValidateJSON is repairable like a decode failure
+		if err != nil && !(errors.Is(err, ErrInvalidJSONOutput) && resp != nil) {
+			return zero, resp, err
+		}
+		if err == nil {
+			var value T
+			if value, err = decodeTyped[T](resp.Output, &work.req); err == nil {
+				return value, resp, nil
+			}
+		}
+
+		if attempt >= work.repairAttempts {
+			return zero, resp, err
+		}
+
+		work.client.notifyRepair(RepairEvent{
+			Provider: work.client.provider.ID(),
+			Model:    work.req.Model,
+			Attempt:  attempt + 1,
+			Err:      err,
+		})
+		work.Assistant(resp.Output).User(repairPrompt(err))
+	}
+}
+
+// decodeTyped decodes output into T and validates it.
+func decodeTyped[T any](output string, req *ChatRequest) (T, error) {
+	var value T
+	output = trimCodeFence(output)
+
+	if err := checkJSONOutput(output, req); err != nil {
+		return value, err
+	}
+	if err := json.Unmarshal([]byte(output), &value); err != nil {
+		return value, fmt.Errorf("%w: %v", ErrInvalidJSONOutput, err)
+	}
+
+	var v any = value
+	if _, ok := v.(Validator); !ok {
+		v = &value
+	}
+	if validator, ok := v.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return value, fmt.Errorf("%w: %v", ErrInvalidJSONOutput, err)
+		}
+	}
+	return value, nil
+}
+
+// trimCodeFence strips surrounding whitespace and a Markdown code fence such
+// as ```json ... ``` from model output.
+func trimCodeFence(s string) string {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "```") || !strings.HasSuffix(s, "```") || len(s) < 6 {
+		return s
+	}
+	body := strings.TrimSuffix(s[3:], "```")
+	// Drop the language tag on the opening line
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	}
+	return strings.TrimSpace(body)
+}
+
+// repairPrompt asks the model to correct output that failed with err.
+func repairPrompt(err error) string {
+	return "Your previous response could not be used: " + err.Error() +
+		". Reply again with only the corrected JSON, without explanations."
+}
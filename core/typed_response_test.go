@@ -0,0 +1,134 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type typedWeather struct {
+	City  string  `json:"city"`
+	TempC float64 `json:"temp_c"`
+}
+
+func (w typedWeather) Validate() error {
+	if w.City == "" {
+		return errors.New("city is required")
+	}
+	return nil
+}
+
+// sequenceProvider returns the given outputs in order.
+func sequenceProvider(outputs ...string) *mockProvider {
+	var mu sync.Mutex
+	i := 0
+	return &mockProvider{
+		chatFunc: func(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			out := outputs[min(i, len(outputs)-1)]
+			i++
+			return &ChatResponse{Output: out}, nil
+		},
+	}
+}
+
+type repairRecorder struct {
+	NoopTelemetryHook
+	mu     sync.Mutex
+	events []RepairEvent
+}
+
+func (r *repairRecorder) OnRepairAttempt(e RepairEvent) {
+	r.mu.Lock()
+	r.events = append(r.events, e)
+	r.mu.Unlock()
+}
+
+func TestGetTypedResponse(t *testing.T) {
+	provider := sequenceProvider("```json\n{\"city\":\"Paris\",\"temp_c\":21.5}\n```")
+	client := NewClient(provider)
+
+	w, resp, err := GetTypedResponse[typedWeather](context.Background(),
+		client.Chat("mock-model").ResponseJSON().User("Weather?"))
+	if err != nil {
+		t.Fatalf("GetTypedResponse() error = %v", err)
+	}
+	if w.City != "Paris" || w.TempC != 21.5 {
+		t.Errorf("value = %+v", w)
+	}
+	if resp == nil {
+		t.Error("response is nil")
+	}
+}
+
+func TestGetTypedResponseRepair(t *testing.T) {
+	provider := sequenceProvider(
+		`{"city": "Par`,
+		`{"city": "", "temp_c": 3}`,
+		`{"city": "Paris", "temp_c": 3}`,
+	)
+	hook := &repairRecorder{}
+	client := NewClient(provider, WithTelemetry(hook))
+	builder := client.Chat("mock-model").ResponseJSON().User("Weather?").RepairAttempts(2)
+
+	w, _, err := GetTypedResponse[typedWeather](context.Background(), builder)
+	if err != nil {
+		t.Fatalf("GetTypedResponse() error = %v", err)
+	}
+	if w.City != "Paris" {
+		t.Errorf("City = %q, want Paris", w.City)
+	}
+
+	if len(hook.events) != 2 || hook.events[0].Attempt != 1 || hook.events[1].Attempt != 2 {
+		t.Errorf("repair events = %+v, want attempts 1 and 2", hook.events)
+	}
+
+	// The final request carries the repair conversation.
+	msgs := provider.lastRequest.Messages
+	if len(msgs) != 5 {
+		t.Fatalf("len(messages) = %d, want 5", len(msgs))
+	}
+	if msgs[3].Content != `{"city": "", "temp_c": 3}` || !strings.Contains(msgs[4].Content, "city is required") {
+		t.Errorf("repair turn = %q / %q", msgs[3].Content, msgs[4].Content)
+	}
+
+	// The caller's builder is unchanged.
+	if len(builder.req.Messages) != 1 {
+		t.Errorf("builder messages = %d, want 1", len(builder.req.Messages))
+	}
+}
+
+func TestGetTypedResponseRepairExhausted(t *testing.T) {
+	provider := sequenceProvider("not json")
+	client := NewClient(provider)
+
+	_, resp, err := GetTypedResponse[typedWeather](context.Background(),
+		client.Chat("mock-model").ResponseJSON().User("Weather?").RepairAttempts(1))
+	if !errors.Is(err, ErrInvalidJSONOutput) {
+		t.Fatalf("error = %v, want ErrInvalidJSONOutput", err)
+	}
+	if resp == nil || resp.Output != "not json" {
+		t.Errorf("resp = %+v, want last response", resp)
+	}
+	if provider.callCount != 2 {
+		t.Errorf("callCount = %d, want 2", provider.callCount)
+	}
+}
+
+func TestTrimCodeFence(t *testing.T) {
+	tests := map[string]string{
+		`{"a":1}`:                  `{"a":1}`,
+		"  {\"a\":1}\n":            `{"a":1}`,
+		"```json\n{\"a\":1}\n```":  `{"a":1}`,
+		"```\n[1,2]\n```":          `[1,2]`,
+		"text ```json {} ``` text": "text ```json {} ``` text",
+	}
+	for in, want := range tests {
+		if got := trimCodeFence(in); got != want {
+			t.Errorf("trimCodeFence(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
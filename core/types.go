@@ -18,6 +18,18 @@ const (
 	FeatureReranking                Feature = "reranking"
 	FeatureStructuredOutput         Feature = "structured_output"
 	FeatureBatch                    Feature = "batch"
+	FeatureVision                   Feature = "vision"
+
+	// FeatureInstructions is reported by providers and models that accept
+	// ChatRequest.Instructions natively. For the others, Instructions is
+	// sent as a leading system message (see PromptAssembly).
+	FeatureInstructions Feature = "instructions"
+
+	// FeatureToolCallingEmulated is reported alongside FeatureToolCalling
+	// by providers that emulate tool calls with prompts and output parsing
+	// instead of native function calling (see tools.EmulateToolCalling).
+	// Emulated calls are less reliable than native ones.
+	FeatureToolCallingEmulated Feature = "tool_calling_emulated"
 )
 
 // ResponseFormat specifies the output format constraint for chat responses.
@@ -69,7 +81,46 @@ const (
 
 // BuiltInTool represents a built-in tool available in the Responses API.
 type BuiltInTool struct {
-	Type string `json:"type"` // "web_search", "file_search", "code_interpreter"
+	Type string `json:"type"` // "web_search", "file_search", "code_interpreter", "computer_use"
+
+	// Computer configures the computer_use tool.
+	Computer *ComputerUseConfig `json:"computer,omitempty"`
+
+	// WebSearch configures the web_search tool. Nil uses provider defaults.
+	WebSearch *WebSearchOptions `json:"web_search,omitempty"`
+}
+
+// WebSearchContextSize controls how much search context the model retrieves.
+type WebSearchContextSize string
+
+const (
+	WebSearchContextLow    WebSearchContextSize = "low"
+	WebSearchContextMedium WebSearchContextSize = "medium"
+	WebSearchContextHigh   WebSearchContextSize = "high"
+)
+
+// WebSearchOptions constrains the web_search built-in tool. Providers map
+// the options they support and ignore the rest.
+type WebSearchOptions struct {
+	// AllowedDomains restricts results to these domains.
+	AllowedDomains []string `json:"allowed_domains,omitempty"`
+
+	// BlockedDomains excludes these domains from results.
+	BlockedDomains []string `json:"blocked_domains,omitempty"`
+
+	// UserLocation localizes results.
+	UserLocation *WebSearchLocation `json:"user_location,omitempty"`
+
+	// ContextSize trades answer quality for cost and latency.
+	ContextSize WebSearchContextSize `json:"context_size,omitempty"`
+}
+
+// WebSearchLocation is the approximate location of the user.
+type WebSearchLocation struct {
+	Country  string `json:"country,omitempty"` // ISO 3166-1 alpha-2, e.g. "US"
+	Region   string `json:"region,omitempty"`
+	City     string `json:"city,omitempty"`
+	Timezone string `json:"timezone,omitempty"` // IANA, e.g. "America/Chicago"
 }
 
 // ReasoningOutput contains reasoning information from the model.
@@ -84,15 +135,31 @@ type ModelInfo struct {
 	DisplayName  string      `json:"display_name"`
 	Capabilities []Feature   `json:"capabilities"`
 	APIEndpoint  APIEndpoint `json:"api_endpoint,omitempty"` // defaults to completions
+
+	// ContextWindow is the maximum number of input plus output tokens.
+	// Zero means unknown.
+	ContextWindow int `json:"context_window,omitempty"`
+
+	// MaxOutputTokens is the maximum number of tokens the model can generate
+	// in one response. Zero means unknown.
+	MaxOutputTokens int `json:"max_output_tokens,omitempty"`
+
+	// Modalities lists the input modalities the model accepts.
+	// Empty means unknown; text is assumed for chat models.
+	Modalities []Modality `json:"modalities,omitempty"`
 }
 
 // HasCapability reports whether the model supports the given feature.
+// FeatureVision is also reported for models that accept image input.
 func (m ModelInfo) HasCapability(f Feature) bool {
 	for _, cap := range m.Capabilities {
 		if cap == f {
 			return true
 		}
 	}
+	if f == FeatureVision {
+		return m.SupportsModality(ModalityImage)
+	}
 	return false
 }
 
@@ -127,6 +194,9 @@ type Message struct {
 	Parts       []ContentPart `json:"-"`                      // Multimodal content parts (Responses API only)
 	ToolCalls   []ToolCall    `json:"tool_calls,omitempty"`   // For assistant messages requesting tools
 	ToolResults []ToolResult  `json:"tool_results,omitempty"` // For tool result messages (RoleTool)
+
+	// ComputerResults reports performed computer calls (RoleTool).
+	ComputerResults []ComputerCallOutput `json:"computer_results,omitempty"`
 }
 
 // TokenUsage tracks token consumption for a request.
@@ -270,6 +340,10 @@ type ChatRequest struct {
 	MaxTokens   *int      `json:"max_tokens,omitempty"`
 	Tools       []Tool    `json:"-"` // Tools are handled separately by providers
 
+	// Tool calling controls. Nil leaves the provider default in place.
+	ToolChoice        *ToolChoice `json:"tool_choice,omitempty"`
+	ParallelToolCalls *bool       `json:"parallel_tool_calls,omitempty"`
+
 	// Structured output fields
 	ResponseFormat ResponseFormat        `json:"response_format,omitempty"`
 	JSONSchema     *JSONSchemaDefinition `json:"json_schema,omitempty"`
@@ -281,6 +355,22 @@ type ChatRequest struct {
 	PreviousResponseID string          `json:"previous_response_id,omitempty"`
 	Truncation         string          `json:"truncation,omitempty"`
 	ToolResources      *ToolResources  `json:"tool_resources,omitempty"`
+
+	// Store controls whether the provider persists the response so it can
+	// be retrieved or continued later. Nil leaves the provider default.
+	Store *bool `json:"store,omitempty"`
+
+	// Metadata is attached to the stored response and can be used to
+	// search for it.
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// ToolResultPolicy controls how ToolResult content is serialized by providers.
+	// Nil uses DefaultToolResultPolicy.
+	ToolResultPolicy *ToolResultPolicy `json:"-"`
+
+	// IdempotencyKey is sent as the Idempotency-Key header by providers that
+	// support it. ChatBuilder fills it in automatically for each call.
+	IdempotencyKey string `json:"-"`
 }
 
 // ChatResponse represents a response from a chat model.
@@ -292,9 +382,55 @@ type ChatResponse struct {
 	Usage     TokenUsage `json:"usage"`
 	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
 
+	// ComputerCalls holds the actions requested through the computer_use
+	// built-in tool.
+	ComputerCalls []ComputerCall `json:"computer_calls,omitempty"`
+
 	// Responses API fields
 	Reasoning *ReasoningOutput `json:"reasoning,omitempty"`
 	Status    string           `json:"status,omitempty"`
+
+	// SystemFingerprint identifies the backend configuration that served the
+	// request, when the provider reports one (OpenAI system_fingerprint).
+	// A change between responses means the model backend changed.
+	SystemFingerprint string `json:"system_fingerprint,omitempty"`
+
+	// Citations lists the sources of a search-grounded response, in the
+	// order the provider numbers them, so Citations[0] is the source of
+	// "[1]" in Output.
+	Citations []Citation `json:"citations,omitempty"`
+
+	// Metadata holds provider-specific response data that has no field of
+	// its own, such as Perplexity's related questions. Each provider
+	// documents the keys it sets.
+	Metadata map[string]any `json:"metadata,omitempty"`
+
+	// Parts holds multimodal output (text, images, audio) in the order the
+	// model produced it. It is only populated when the response contains
+	// non-text output; text is always available in Output as well.
+	Parts []OutputPart `json:"-"`
+
+	// Safety holds the provider's safety metadata, such as a refusal or
+	// content filter ratings, when it reports any.
+	Safety *Safety `json:"safety,omitempty"`
+
+	// FinishReason is why the model stopped, normalized across providers.
+	// FinishLength means Output was cut off by MaxTokens.
+	FinishReason FinishReason `json:"finish_reason,omitempty"`
+
+	// RawFinishReason is the provider's own finish reason, such as
+	// Anthropic's "max_tokens" or Gemini's "MAX_TOKENS".
+	RawFinishReason string `json:"raw_finish_reason,omitempty"`
+}
+
+// Citation is a source that a search-grounded response is based on. Only
+// URL is always set.
+type Citation struct {
+	URL     string `json:"url"`
+	Title   string `json:"title,omitempty"`
+	Snippet string `json:"snippet,omitempty"`
+	// Date is the publication date as reported by the provider.
+	Date string `json:"date,omitempty"`
 }
 
 // HasToolCalls reports whether the response contains any tool calls.
@@ -321,9 +457,14 @@ func (r *ChatResponse) HasReasoning() bool {
 }
 
 // ChatChunk represents an incremental streaming response.
-// Delta contains incremental assistant text.
+// Delta contains incremental assistant text. Reasoning contains incremental
+// reasoning text, for providers that stream the model's thinking before its
+// answer. Parts carries non-text output such as inline images or audio as
+// soon as the provider emits it.
 type ChatChunk struct {
-	Delta string `json:"delta"`
+	Delta     string       `json:"delta"`
+	Reasoning string       `json:"reasoning,omitempty"`
+	Parts     []OutputPart `json:"-"`
 }
 
 // -----------------------------------------------------------------------------
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"context"
+	"encoding/base64"
+	"time"
+)
+
+// FeatureVideoGeneration indicates support for video generation.
+const FeatureVideoGeneration Feature = "video_generation"
+
+// VideoGenerator is an optional interface for providers that support video
+// generation.
+type VideoGenerator interface {
+	// GenerateVideo generates videos from a text prompt and an optional
+	// first frame. Generation can take minutes; ctx bounds the wait.
+	GenerateVideo(ctx context.Context, req *VideoGenerateRequest) (*VideoResponse, error)
+}
+
+// VideoGenerateRequest represents a request to generate videos.
+type VideoGenerateRequest struct {
+	Model  ModelID `json:"model"`
+	Prompt string  `json:"prompt"`
+
+	// Image is the first frame for image-to-video models.
+	Image *ImageInput `json:"-"`
+
+	// Duration is the length of the video. Zero uses the model's default.
+	Duration time.Duration `json:"duration,omitempty"`
+
+	// AspectRatio is the frame shape, such as "16:9" or "9:16". Empty uses
+	// the model's default.
+	AspectRatio string `json:"aspect_ratio,omitempty"`
+
+	// ResponseFormat is "url" (the default) or "b64_json".
+	ResponseFormat string `json:"response_format,omitempty"`
+}
+
+// VideoResponse represents the response from a video generation request.
+type VideoResponse struct {
+	Created int64       `json:"created"`
+	Data    []VideoData `json:"data"`
+}
+
+// VideoData represents a single generated video.
+type VideoData struct {
+	B64JSON string `json:"b64_json,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// GetBytes decodes and returns the video data.
+func (d VideoData) GetBytes() ([]byte, error) {
+	if d.B64JSON != "" {
+		return base64.StdEncoding.DecodeString(d.B64JSON)
+	}
+	return nil, nil // URL must be fetched separately
+}
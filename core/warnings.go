@@ -0,0 +1,94 @@
+package core
+
+import "fmt"
+
+// WarningCode classifies a Warning.
+type WarningCode string
+
+const (
+	// WarningGeneral is used for warnings without a more specific code.
+	WarningGeneral WarningCode = ""
+	// WarningUnsupportedParameter reports a request parameter that the
+	// provider or model ignores.
+	WarningUnsupportedParameter WarningCode = "unsupported_parameter"
+)
+
+// Warning is a structured non-fatal warning emitted by the SDK.
+type Warning struct {
+	// Code classifies the warning.
+	Code WarningCode
+
+	// Provider and Model identify the request the warning applies to, if any.
+	Provider string
+	Model    ModelID
+
+	// Parameter names the request parameter the warning is about, if any
+	// (e.g. "ReasoningEffort").
+	Parameter string
+
+	// Message is a human-readable description.
+	Message string
+}
+
+// String returns the warning message.
+func (w Warning) String() string {
+	return w.Message
+}
+
+// WarningListener receives structured warnings emitted by the SDK.
+// Implementations should be safe for concurrent use.
+type WarningListener func(w Warning)
+
+// WithWarningListener sets a listener that receives every warning in
+// structured form. It is called in addition to the WarningHandler.
+func WithWarningListener(l WarningListener) ClientOption {
+	return func(c *Client) {
+		c.warningListener = l
+	}
+}
+
+// WithStrictParameters makes requests that set parameters the provider or
+// model ignores fail with ErrUnsupportedParameter instead of emitting a
+// warning.
+func WithStrictParameters(strict bool) ClientOption {
+	return func(c *Client) {
+		c.strictParams = strict
+	}
+}
+
+// featureParameter pairs a request parameter with the feature it needs.
+type featureParameter struct {
+	name    string
+	feature Feature
+	isSet   func(*ChatRequest) bool
+}
+
+var featureParameters = []featureParameter{
+	{"ReasoningEffort", FeatureReasoning, func(r *ChatRequest) bool { return r.ReasoningEffort != "" }},
+	{"BuiltInTools", FeatureBuiltInTools, func(r *ChatRequest) bool { return len(r.BuiltInTools) > 0 }},
+	{"PreviousResponseID", FeatureResponseChain, func(r *ChatRequest) bool { return r.PreviousResponseID != "" }},
+}
+
+// checkParameters warns about request parameters the provider or model
+// would silently drop. In strict mode the first one is returned as an error.
+func (b *ChatBuilder) checkParameters() error {
+	p := b.client.provider
+	for _, param := range featureParameters {
+		if !param.isSet(&b.req) || SupportsModel(p, b.req.Model, param.feature) {
+			continue
+		}
+		msg := fmt.Sprintf("%s is not supported by provider %q for model %q",
+			param.name, p.ID(), b.req.Model)
+		if b.client.strictParams {
+			return fmt.Errorf("%w: %s", ErrUnsupportedParameter, msg)
+		}
+		b.client.warn(Warning{
+			Code:      WarningUnsupportedParameter,
+			Provider:  p.ID(),
+			Model:     b.req.Model,
+			Parameter: param.name,
+			Message:   msg + " and will be ignored",
+		})
+	}
+	return b.checkInstructions()
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestUnsupportedParameterWarning(t *testing.T) {
+	var mu sync.Mutex
+	var messages []string
+	var warnings []Warning
+
+	provider := &mockProvider{id: "mock"}
+	client := NewClient(provider,
+		WithWarningHandler(func(msg string) {
+			mu.Lock()
+			messages = append(messages, msg)
+			mu.Unlock()
+		}),
+		WithWarningListener(func(w Warning) {
+			mu.Lock()
+			warnings = append(warnings, w)
+			mu.Unlock()
+		}),
+	)
+
+	_, err := client.Chat("mock-model").
+		User("Hello").
+		ReasoningEffort(ReasoningEffortHigh).
+		GetResponse(context.Background())
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+
+	if len(warnings) != 1 {
+		t.Fatalf("len(warnings) = %d, want 1", len(warnings))
+	}
+	w := warnings[0]
+	if w.Code != WarningUnsupportedParameter || w.Parameter != "ReasoningEffort" ||
+		w.Provider != "mock" || w.Model != "mock-model" {
+		t.Errorf("warning = %+v", w)
+	}
+	if len(messages) != 1 || messages[0] != w.Message {
+		t.Errorf("handler messages = %v, want %q", messages, w.Message)
+	}
+	if provider.callCount != 1 {
+		t.Errorf("callCount = %d, want 1", provider.callCount)
+	}
+}
+
+func TestUnsupportedParameterStrict(t *testing.T) {
+	provider := &mockProvider{id: "mock"}
+	client := NewClient(provider, WithStrictParameters(true))
+
+	_, err := client.Chat("mock-model").
+		User("Hello").
+		WebSearch().
+		GetResponse(context.Background())
+	if !errors.Is(err, ErrUnsupportedParameter) {
+		t.Fatalf("GetResponse() error = %v, want ErrUnsupportedParameter", err)
+	}
+	if provider.callCount != 0 {
+		t.Errorf("callCount = %d, want 0", provider.callCount)
+	}
+
+	_, err = client.Chat("mock-model").
+		User("Hello").
+		WebSearch().
+		Stream(context.Background())
+	if !errors.Is(err, ErrUnsupportedParameter) {
+		t.Fatalf("Stream() error = %v, want ErrUnsupportedParameter", err)
+	}
+}
+
+func TestSupportedParameterNoWarning(t *testing.T) {
+	var warned bool
+	provider := &modelSupporterProvider{mockProvider{id: "mock"}}
+	client := NewClient(provider, WithStrictParameters(true), WithWarningHandler(func(string) { warned = true }))
+
+	_, err := client.Chat("live-model").
+		User("Hello").
+		ReasoningEffort(ReasoningEffortLow).
+		GetResponse(context.Background())
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if warned {
+		t.Error("unexpected warning for supported parameter")
+	}
+}
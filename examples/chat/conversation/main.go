@@ -50,21 +50,8 @@ func (c *Conversation) Send(ctx context.Context, userMessage string) (string, er
 		Content: userMessage,
 	})
 
-	// Build request with full history
-	builder := c.client.Chat(c.model)
-	for _, msg := range c.messages {
-		switch msg.Role {
-		case core.RoleSystem:
-			builder = builder.System(msg.Content)
-		case core.RoleUser:
-			builder = builder.User(msg.Content)
-		case core.RoleAssistant:
-			builder = builder.Assistant(msg.Content)
-		}
-	}
-
-	// Get response
-	resp, err := builder.GetResponse(ctx)
+	// Build request with full history and get response
+	resp, err := c.client.Chat(c.model).Messages(c.messages...).GetResponse(ctx)
 	if err != nil {
 		// Remove the failed user message
 		c.messages = c.messages[:len(c.messages)-1]
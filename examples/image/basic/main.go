@@ -50,19 +50,13 @@ func main() {
 
 	// Save the image
 	if len(resp.Data) > 0 {
-		data, err := resp.Data[0].GetBytes()
-		if err != nil {
-			fmt.Fprintln(os.Stderr, "Error decoding image:", err)
-			os.Exit(1)
-		}
-
 		filename := "landscape.png"
-		if err := os.WriteFile(filename, data, 0644); err != nil {
+		if err := resp.Data[0].SaveFile(filename); err != nil {
 			fmt.Fprintln(os.Stderr, "Error saving image:", err)
 			os.Exit(1)
 		}
 
-		fmt.Printf("Image saved to %s (%d bytes)\n", filename, len(data))
+		fmt.Printf("Image saved to %s\n", filename)
 
 		if resp.Data[0].RevisedPrompt != "" {
 			fmt.Printf("Revised prompt: %s\n", resp.Data[0].RevisedPrompt)
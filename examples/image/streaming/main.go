@@ -49,53 +49,34 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Process partial images as they arrive
+	// Save partial images as they arrive, then collect the final response
 	partialCount := 0
-	for chunk := range stream.Ch {
+	final, err := core.DrainImageStream(ctx, stream, func(chunk core.ImageChunk) error {
 		partialCount++
 		fmt.Printf("Received partial image %d (index %d)\n", partialCount, chunk.PartialImageIndex)
 
-		// Optionally save partial images
-		data, err := core.ImageData{B64JSON: chunk.B64JSON}.GetBytes()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error decoding partial image: %v\n", err)
-			continue
-		}
-
 		filename := fmt.Sprintf("partial_%d.png", chunk.PartialImageIndex)
-		if err := os.WriteFile(filename, data, 0644); err != nil {
+		if err := (core.ImageData{B64JSON: chunk.B64JSON}).SaveFile(filename); err != nil {
 			fmt.Fprintf(os.Stderr, "Error saving partial image: %v\n", err)
-			continue
-		}
-		fmt.Printf("  Saved to %s (%d bytes)\n", filename, len(data))
-	}
-
-	// Check for errors
-	select {
-	case err := <-stream.Err:
-		if err != nil {
-			fmt.Fprintln(os.Stderr, "Stream error:", err)
-			os.Exit(1)
+			return nil
 		}
-	default:
+		fmt.Printf("  Saved to %s\n", filename)
+		return nil
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Stream error:", err)
+		os.Exit(1)
 	}
 
 	// Save final image
-	final := <-stream.Final
 	if final != nil && len(final.Data) > 0 {
-		data, err := final.Data[0].GetBytes()
-		if err != nil {
-			fmt.Fprintln(os.Stderr, "Error decoding final image:", err)
-			os.Exit(1)
-		}
-
 		filename := "final.png"
-		if err := os.WriteFile(filename, data, 0644); err != nil {
+		if err := final.Data[0].SaveFile(filename); err != nil {
 			fmt.Fprintln(os.Stderr, "Error saving final image:", err)
 			os.Exit(1)
 		}
 
-		fmt.Printf("\nFinal image saved to %s (%d bytes)\n", filename, len(data))
+		fmt.Printf("\nFinal image saved to %s\n", filename)
 
 		if final.Data[0].RevisedPrompt != "" {
 			fmt.Printf("Revised prompt: %s\n", final.Data[0].RevisedPrompt)
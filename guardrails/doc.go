@@ -0,0 +1,65 @@
+// Package guardrails checks the input and output of chat requests with
+// composable validators.
+//
+// A guard wraps a core.Provider, so it applies to every request of a
+// core.Client built on it. Each rule pairs a [Validator] with the [Action]
+// to take when the validator reports a violation:
+//
+//	guarded := guardrails.Wrap(provider,
+//	    guardrails.CheckInput(guardrails.MaxLength(8000), guardrails.Block),
+//	    guardrails.CheckInput(guardrails.Jailbreak(), guardrails.Block),
+//	    guardrails.CheckInput(guardrails.Topic(classifier, "gpt-4o-mini", "cooking", "recipes"), guardrails.Block),
+//	    guardrails.CheckOutput(guardrails.Denylist(ssnPattern), guardrails.Rewrite),
+//	    guardrails.CheckOutput(guardrails.ValidJSON(), guardrails.Warn),
+//	    guardrails.OnViolation(func(v guardrails.Violation) { log.Println(v) }),
+//	)
+//	client := core.NewClient(guarded)
+//
+//	resp, err := client.Chat(model).User(question).GetResponse(ctx)
+//	if errors.Is(err, guardrails.ErrBlocked) {
+//	    // The question or the answer was rejected.
+//	}
+//
+// # Actions
+//
+// [Block] fails the request with a [*BlockedError]; blocked input is never
+// sent to the model. [Rewrite] replaces the text with the validator's fixed
+// version, such as the text with denied patterns redacted; validators that
+// cannot rewrite (those that do not implement [Rewriter]) block instead.
+// [Quarantine] replaces the text with a notice that it was withheld. [Warn]
+// only reports the violation.
+//
+// Every violation is passed to the [OnViolation] handler, which defaults to
+// logging with log/slog.
+//
+// # What is checked
+//
+// Input rules check the newest user message: the last message of the
+// request, when it has the user role. Output rules check the response text;
+// responses with only tool calls are not checked.
+//
+// Tool result rules check the results of tools, serialized as they will be
+// sent. Agents that read web pages or files pass untrusted text to the
+// model, which may contain instructions planted for it; [PromptInjection]
+// looks for them:
+//
+//	guardrails.CheckToolResults(guardrails.PromptInjection(), guardrails.Rewrite)
+//
+// frames suspicious results with a warning not to follow instructions in
+// them, and [Quarantine] withholds them instead. Violations name the tool
+// call in Violation.CallID.
+//
+// A guard changes only the request it sends, while callers such as
+// core.Conversation keep the original messages in their history. So that
+// text redacted or withheld once is not sent in a later request, Rewrite
+// and Quarantine rules are also applied to earlier user messages and tool
+// results, without reporting them again. Tool results after the last
+// assistant message are new; the others are earlier.
+//
+// Output rules need the whole response, so with output rules a streamed
+// response is buffered and delivered as one chunk once it has been checked.
+//
+// Validators run in the order they were added and see the text as rewritten
+// by earlier rules. An error from a validator, such as a failed classifier
+// request, fails the request.
+package guardrails
@@ -0,0 +1,448 @@
+package guardrails
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// ErrBlocked is matched by errors.Is for requests blocked by a guardrail.
+var ErrBlocked = errors.New("blocked by guardrail")
+
+// Action is what a guard does when a validator reports a violation.
+type Action string
+
+const (
+	// Block fails the request with a *BlockedError.
+	Block Action = "block"
+	// Rewrite replaces the text with the validator's rewritten version.
+	Rewrite Action = "rewrite"
+	// Warn reports the violation and lets the text through.
+	Warn Action = "warn"
+	// Quarantine replaces the text with a notice that it was withheld.
+	Quarantine Action = "quarantine"
+)
+
+// IsValid reports whether the action is a recognized value.
+func (a Action) IsValid() bool {
+	switch a {
+	case Block, Rewrite, Warn, Quarantine:
+		return true
+	default:
+		return false
+	}
+}
+
+// Stage is the part of a request that a rule checks.
+type Stage string
+
+const (
+	// StageInput checks user messages before they are sent.
+	StageInput Stage = "input"
+	// StageOutput checks the response text.
+	StageOutput Stage = "output"
+	// StageToolResult checks tool results before they are sent.
+	StageToolResult Stage = "tool_result"
+)
+
+// Validator checks a text.
+//
+// Implementations must be safe for concurrent use.
+type Validator interface {
+	// Name identifies the validator in violations.
+	Name() string
+
+	// Validate returns a reason when text violates the validator's rule,
+	// or "" when it passes. An error means the text could not be checked.
+	Validate(ctx context.Context, text string) (reason string, err error)
+}
+
+// Rewriter is implemented by validators that can fix a violating text.
+// Rules with the Rewrite action block when their validator is not a
+// Rewriter.
+type Rewriter interface {
+	Validator
+
+	// Rewrite returns text with the violation removed.
+	Rewrite(text string) string
+}
+
+// Func returns a validator named name that calls fn. It cannot rewrite.
+func Func(name string, fn func(ctx context.Context, text string) (string, error)) Validator {
+	return funcValidator{name: name, fn: fn}
+}
+
+type funcValidator struct {
+	name string
+	fn   func(ctx context.Context, text string) (string, error)
+}
+
+func (f funcValidator) Name() string { return f.name }
+
+func (f funcValidator) Validate(ctx context.Context, text string) (string, error) {
+	return f.fn(ctx, text)
+}
+
+// Violation describes a text that failed a validator.
+type Violation struct {
+	Validator string // Name of the validator
+	Stage     Stage  // Part of the request that failed
+	CallID    string // Tool call whose result failed, for StageToolResult
+	Action    Action // Action taken; Block when a Rewrite rule could not rewrite
+	Reason    string // Reason given by the validator
+}
+
+// String returns a one-line description of the violation.
+func (v Violation) String() string {
+	subject := string(v.Stage)
+	if v.CallID != "" {
+		subject += " " + v.CallID
+	}
+	return fmt.Sprintf("%s %s by %s: %s", subject, actionVerb(v.Action), v.Validator, v.Reason)
+}
+
+func actionVerb(a Action) string {
+	switch a {
+	case Block:
+		return "blocked"
+	case Rewrite:
+		return "rewritten"
+	case Quarantine:
+		return "quarantined"
+	default:
+		return "flagged"
+	}
+}
+
+// quarantineNotice replaces quarantined text.
+func quarantineNotice(v Violation) string {
+	return fmt.Sprintf("[Content withheld by the %s guardrail: %s]", v.Validator, v.Reason)
+}
+
+// BlockedError is returned for requests blocked by a guardrail. It matches
+// ErrBlocked.
+type BlockedError struct {
+	Violation Violation
+}
+
+func (e *BlockedError) Error() string {
+	return "guardrails: " + e.Violation.String()
+}
+
+// Unwrap returns ErrBlocked.
+func (e *BlockedError) Unwrap() error {
+	return ErrBlocked
+}
+
+// rule pairs a validator with its stage and action.
+type rule struct {
+	stage     Stage
+	validator Validator
+	action    Action
+}
+
+// Option configures a guard created by Wrap.
+type Option func(*Provider)
+
+// CheckInput adds a rule that checks the newest user message with v.
+// Invalid actions are treated as Block.
+func CheckInput(v Validator, action Action) Option {
+	return func(p *Provider) {
+		p.rules = append(p.rules, newRule(StageInput, v, action))
+	}
+}
+
+// CheckOutput adds a rule that checks the response text with v.
+// Invalid actions are treated as Block.
+func CheckOutput(v Validator, action Action) Option {
+	return func(p *Provider) {
+		p.rules = append(p.rules, newRule(StageOutput, v, action))
+	}
+}
+
+// CheckToolResults adds a rule that checks tool results with v, such as
+// web pages or file contents that may carry instructions for the model.
+// Invalid actions are treated as Block.
+func CheckToolResults(v Validator, action Action) Option {
+	return func(p *Provider) {
+		p.rules = append(p.rules, newRule(StageToolResult, v, action))
+	}
+}
+
+func newRule(stage Stage, v Validator, action Action) rule {
+	if !action.IsValid() {
+		action = Block
+	}
+	return rule{stage: stage, validator: v, action: action}
+}
+
+// OnViolation sets the function that receives every violation. It must be
+// safe for concurrent use. The default logs violations with log/slog.
+func OnViolation(fn func(Violation)) Option {
+	return func(p *Provider) {
+		if fn != nil {
+			p.onViolation = fn
+		}
+	}
+}
+
+// logViolation is the default violation handler.
+func logViolation(v Violation) {
+	slog.Warn("iris guardrail violation",
+		slog.String("validator", v.Validator),
+		slog.String("stage", string(v.Stage)),
+		slog.String("call_id", v.CallID),
+		slog.String("action", string(v.Action)),
+		slog.String("reason", v.Reason),
+	)
+}
+
+// Provider wraps a provider to check requests and responses. Create one
+// with Wrap. Provider is safe for concurrent use if the wrapped provider
+// and the validators are.
+type Provider struct {
+	provider    core.Provider
+	rules       []rule
+	onViolation func(Violation)
+}
+
+// Wrap returns a provider that checks the requests and responses of p
+// against the rules given as options.
+func Wrap(p core.Provider, opts ...Option) *Provider {
+	g := &Provider{provider: p, onViolation: logViolation}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// ID returns the wrapped provider's ID.
+func (g *Provider) ID() string { return g.provider.ID() }
+
+// Models returns the wrapped provider's models.
+func (g *Provider) Models() []core.ModelInfo { return g.provider.Models() }
+
+// Supports reports whether the wrapped provider supports the feature.
+func (g *Provider) Supports(feature core.Feature) bool { return g.provider.Supports(feature) }
+
+// SupportsModel reports whether the wrapped provider supports the feature
+// for the model.
+func (g *Provider) SupportsModel(model core.ModelID, feature core.Feature) bool {
+	return core.SupportsModel(g.provider, model, feature)
+}
+
+// Chat checks the request, sends it, and checks the response.
+func (g *Provider) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	req, err := g.checkRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.provider.Chat(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.checkResponse(ctx, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// StreamChat checks the request and streams the response. With output
+// rules, the response is buffered until it has been checked and then
+// delivered as one chunk.
+func (g *Provider) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	req, err := g.checkRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := g.provider.StreamChat(ctx, req)
+	if err != nil || !g.has(StageOutput) {
+		return stream, err
+	}
+
+	ch := make(chan core.ChatChunk, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		defer close(errCh)
+		defer close(finalCh)
+
+		resp, err := core.DrainStream(ctx, stream)
+		if err == nil {
+			err = g.checkResponse(ctx, resp)
+		}
+		if err != nil {
+			errCh <- err
+			return
+		}
+		if resp.Output != "" {
+			ch <- core.ChatChunk{Delta: resp.Output}
+		}
+		finalCh <- resp
+	}()
+
+	return &core.ChatStream{Ch: ch, Err: errCh, Final: finalCh}, nil
+}
+
+// has reports whether any rule checks stage.
+func (g *Provider) has(stage Stage) bool {
+	return slices.ContainsFunc(g.rules, func(r rule) bool { return r.stage == stage })
+}
+
+// checkRequest checks the newest user message and the tool results and
+// returns the request to send, a copy when anything was rewritten.
+//
+// Callers keep the original text in their history, so the text-changing
+// rules are also applied, silently, to earlier user messages and tool
+// results. Otherwise text redacted in one request would be sent in the
+// next.
+func (g *Provider) checkRequest(ctx context.Context, req *core.ChatRequest) (*core.ChatRequest, error) {
+	hasInput, hasToolResult := g.has(StageInput), g.has(StageToolResult)
+	if !hasInput && !hasToolResult {
+		return req, nil
+	}
+
+	// Messages after the last assistant message are new in this request.
+	turn := len(req.Messages)
+	for turn > 0 && req.Messages[turn-1].Role != core.RoleAssistant {
+		turn--
+	}
+	last := len(req.Messages) - 1
+
+	var msgs []core.Message
+	for i, msg := range req.Messages {
+		var updated core.Message
+		var changed bool
+		var err error
+		switch {
+		case msg.Role == core.RoleUser && hasInput:
+			updated, changed, err = g.checkUserMessage(ctx, msg, i == last)
+		case msg.Role == core.RoleTool && hasToolResult:
+			updated, changed, err = g.checkToolMessage(ctx, req.ToolResultPolicy, msg, i >= turn)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !changed {
+			continue
+		}
+		if msgs == nil {
+			msgs = slices.Clone(req.Messages)
+		}
+		msgs[i] = updated
+	}
+	if msgs == nil {
+		return req, nil
+	}
+
+	out := *req
+	out.Messages = msgs
+	return &out, nil
+}
+
+// checkUserMessage checks the content of a user message. Only the newest
+// message is reported and can be blocked.
+func (g *Provider) checkUserMessage(ctx context.Context, msg core.Message, newest bool) (core.Message, bool, error) {
+	text, err := g.check(ctx, StageInput, "", msg.Content, newest)
+	if err != nil || text == msg.Content {
+		return msg, false, err
+	}
+	msg.Content = text
+	return msg, true, nil
+}
+
+// checkToolMessage checks the results of a tool message, serialized as the
+// provider would send them. Only results of the current turn are reported
+// and can be blocked.
+func (g *Provider) checkToolMessage(ctx context.Context, policy *core.ToolResultPolicy, msg core.Message, current bool) (core.Message, bool, error) {
+	// Check the whole result; the provider applies MaxBytes when sending.
+	var p core.ToolResultPolicy
+	if policy != nil {
+		p = *policy
+	}
+	p.MaxBytes = 0
+
+	var results []core.ToolResult
+	for i, r := range msg.ToolResults {
+		original := p.Serialize(r.Content)
+		text, err := g.check(ctx, StageToolResult, r.CallID, original, current)
+		if err != nil {
+			return msg, false, err
+		}
+		if text == original {
+			continue
+		}
+		if results == nil {
+			results = slices.Clone(msg.ToolResults)
+		}
+		results[i].Content = text
+	}
+	if results == nil {
+		return msg, false, nil
+	}
+	msg.ToolResults = results
+	return msg, true, nil
+}
+
+// checkResponse checks the response text, rewriting it in place.
+func (g *Provider) checkResponse(ctx context.Context, resp *core.ChatResponse) error {
+	if resp.Output == "" {
+		return nil
+	}
+	text, err := g.check(ctx, StageOutput, "", resp.Output, true)
+	if err != nil {
+		return err
+	}
+	resp.Output = text
+	return nil
+}
+
+// check runs the rules of stage on text in order and returns the text as
+// changed by them. Unless report is set, only the rules that change text
+// run and violations are neither reported nor blocked.
+func (g *Provider) check(ctx context.Context, stage Stage, callID, text string, report bool) (string, error) {
+	for _, r := range g.rules {
+		if r.stage != stage {
+			continue
+		}
+		rw, canRewrite := r.validator.(Rewriter)
+		action := r.action
+		if action == Rewrite && !canRewrite {
+			action = Block
+		}
+		if !report && action != Rewrite && action != Quarantine {
+			continue
+		}
+
+		name := r.validator.Name()
+		reason, err := r.validator.Validate(ctx, text)
+		if err != nil {
+			return "", fmt.Errorf("guardrails: %s: %w", name, err)
+		}
+		if reason == "" {
+			continue
+		}
+
+		v := Violation{Validator: name, Stage: stage, CallID: callID, Action: action, Reason: reason}
+		if report {
+			g.onViolation(v)
+		}
+		switch action {
+		case Block:
+			return "", &BlockedError{Violation: v}
+		case Rewrite:
+			text = rw.Rewrite(text)
+		case Quarantine:
+			text = quarantineNotice(v)
+		}
+	}
+	return text, nil
+}
+
+// Compile-time check that Provider implements core.Provider.
+var _ core.Provider = (*Provider)(nil)
@@ -32,6 +32,10 @@ type GeneratedModel struct {
 	Capabilities []string
 	APIEndpoint  string
 	IsImageModel bool
+
+	ContextWindow   int
+	MaxOutputTokens int
+	Modalities      []string
 }
 
 // Generate produces Go source code for the given models.
@@ -82,6 +86,13 @@ func (g *Generator) convertModels(models []ModelData) []GeneratedModel {
 		// Check if this is an image model
 		gm.IsImageModel = isImageModel(m)
 
+		// Token limits and input modalities
+		if m.Limit != nil {
+			gm.ContextWindow = m.Limit.Context
+			gm.MaxOutputTokens = m.Limit.Output
+		}
+		gm.Modalities = mapModalities(m)
+
 		result = append(result, gm)
 	}
 	return result
@@ -117,6 +128,28 @@ func (g *Generator) mapCapabilities(m ModelData) []string {
 	return caps
 }
 
+// mapModalities converts models.dev input modalities to Iris modalities.
+// Modalities Iris does not model (e.g. "pdf") are skipped.
+func mapModalities(m ModelData) []string {
+	if m.Modalities == nil {
+		return nil
+	}
+	var mods []string
+	for _, in := range m.Modalities.Input {
+		switch in {
+		case "text":
+			mods = append(mods, "core.ModalityText")
+		case "image":
+			mods = append(mods, "core.ModalityImage")
+		case "audio":
+			mods = append(mods, "core.ModalityAudio")
+		case "video":
+			mods = append(mods, "core.ModalityVideo")
+		}
+	}
+	return mods
+}
+
 // mapAPIEndpoint determines the API endpoint for a model.
 func (g *Generator) mapAPIEndpoint(m ModelData) string {
 	// Image models don't need API endpoint specification
@@ -246,6 +279,15 @@ var models = []core.ModelInfo{
 		DisplayName: "{{.DisplayName}}",
 {{- if .APIEndpoint}}
 		APIEndpoint: {{.APIEndpoint}},
+{{- end}}
+{{- if .ContextWindow}}
+		ContextWindow: {{.ContextWindow}},
+{{- end}}
+{{- if .MaxOutputTokens}}
+		MaxOutputTokens: {{.MaxOutputTokens}},
+{{- end}}
+{{- if .Modalities}}
+		Modalities: []core.Modality{ {{- range $i, $m := .Modalities}}{{if $i}}, {{end}}{{$m}}{{end -}} },
 {{- end}}
 		Capabilities: []core.Feature{
 {{- range .Capabilities}}
@@ -202,6 +202,8 @@ func TestGenerate(t *testing.T) {
 			Name:             "GPT-4o",
 			ToolCall:         true,
 			StructuredOutput: true,
+			Limit:            &LimitData{Context: 128000, Output: 16384},
+			Modalities:       &ModalityData{Input: []string{"text", "image", "pdf"}, Output: []string{"text"}},
 		},
 		{
 			ID:         "dall-e-3",
@@ -225,6 +227,9 @@ func TestGenerate(t *testing.T) {
 		"ModelGPT4o",
 		"core.FeatureImageGeneration",
 		"core.FeatureToolCalling",
+		"ContextWindow:   128000,",
+		"MaxOutputTokens: 16384,",
+		"Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},",
 		"func buildModelRegistry()",
 		"func GetModelInfo(",
 	}
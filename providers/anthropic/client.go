@@ -32,7 +32,7 @@ func (p *Anthropic) doChat(ctx context.Context, req *core.ChatRequest) (*core.Ch
 	}
 
 	// Set headers
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildChatHeaders(req) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/petal-labs/iris/core"
 )
@@ -398,3 +399,68 @@ func TestDoChatWithMaxTokens(t *testing.T) {
 		t.Fatalf("Chat() error = %v", err)
 	}
 }
+
+func TestDoChatIdempotencyKey(t *testing.T) {
+	var gotKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotKey = r.Header.Get("Idempotency-Key")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id":"msg_1","type":"message","role":"assistant","model":"claude-sonnet-4-5","content":[{"type":"text","text":"Hi"}],"usage":{"input_tokens":1,"output_tokens":1}}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	_, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:          "claude-sonnet-4-5",
+		Messages:       []core.Message{{Role: core.RoleUser, Content: "Hello"}},
+		IdempotencyKey: "key-789",
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if gotKey != "key-789" {
+		t.Errorf("Idempotency-Key = %q, want %q", gotKey, "key-789")
+	}
+}
+
+func TestDoChatWithCredentials(t *testing.T) {
+	var keys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get("x-api-key"))
+		if r.Header.Get("x-api-key") != "sk-rotated" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"type":"error","error":{"type":"authentication_error","message":"invalid x-api-key"}}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(anthropicResponse{
+			ID:      "msg_123",
+			Type:    "message",
+			Role:    "assistant",
+			Content: []anthropicResponseContent{{Type: "text", Text: "Hi"}},
+		})
+	}))
+	defer server.Close()
+
+	fetches := 0
+	creds := core.CachedCredential(core.CredentialFunc(func(context.Context) (core.Secret, error) {
+		fetches++
+		if fetches == 1 {
+			return core.NewSecret("sk-revoked"), nil
+		}
+		return core.NewSecret("sk-rotated"), nil
+	}), time.Hour)
+	p := New("", WithBaseURL(server.URL), WithCredentials(creds))
+
+	req := &core.ChatRequest{
+		Model:    "claude-sonnet-4-5",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hello"}},
+	}
+	if _, err := p.Chat(context.Background(), req); err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if len(keys) != 2 || keys[0] != "sk-revoked" || keys[1] != "sk-rotated" {
+		t.Errorf("keys sent = %q, want the revoked key then the rotated key", keys)
+	}
+}
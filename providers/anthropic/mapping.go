@@ -20,7 +20,7 @@ type schemaProvider interface {
 
 // buildRequest creates an Anthropic API request from an Iris ChatRequest.
 func buildRequest(req *core.ChatRequest, stream bool) *anthropicRequest {
-	system, messages := mapMessages(req.Messages)
+	system, messages := mapMessages(req.Messages, req.ToolResultPolicy)
 
 	maxTokens := defaultMaxTokens
 	if req.MaxTokens != nil {
@@ -43,16 +43,39 @@ func buildRequest(req *core.ChatRequest, stream bool) *anthropicRequest {
 	// Map tools if present
 	if len(req.Tools) > 0 {
 		antReq.Tools = mapTools(req.Tools)
-		antReq.ToolChoice = map[string]string{"type": "auto"}
+		antReq.ToolChoice = mapToolChoice(req.ToolChoice, req.ParallelToolCalls)
 	}
 
 	return antReq
 }
 
+// mapToolChoice converts a tool choice to Anthropic's tool_choice object.
+// Anthropic calls "required" "any", and controls parallel calls with
+// disable_parallel_tool_use, which is not accepted with type "none".
+func mapToolChoice(choice *core.ToolChoice, parallel *bool) *anthropicToolChoice {
+	tc := &anthropicToolChoice{Type: "auto"}
+	if choice != nil {
+		switch choice.Mode {
+		case core.ToolChoiceNone:
+			return &anthropicToolChoice{Type: "none"}
+		case core.ToolChoiceRequired:
+			tc.Type = "any"
+		case core.ToolChoiceTool:
+			tc.Type = "tool"
+			tc.Name = choice.Name
+		}
+	}
+	if parallel != nil && !*parallel {
+		tc.DisableParallelToolUse = true
+	}
+	return tc
+}
+
 // mapMessages converts Iris messages to Anthropic format.
 // It extracts system messages into a single string and converts
 // user/assistant messages to the Anthropic content block format.
-func mapMessages(msgs []core.Message) (system string, messages []anthropicMessage) {
+// Tool result content is serialized according to policy (nil uses the default).
+func mapMessages(msgs []core.Message, policy *core.ToolResultPolicy) (system string, messages []anthropicMessage) {
 	var systemParts []string
 
 	for _, msg := range msgs {
@@ -67,7 +90,7 @@ func mapMessages(msgs []core.Message) (system string, messages []anthropicMessag
 				content = append(content, anthropicContentBlock{
 					Type:      "tool_result",
 					ToolUseID: tr.CallID,
-					Content:   marshalToolResultContent(tr.Content),
+					Content:   policy.Serialize(tr.Content),
 					IsError:   tr.IsError,
 				})
 			}
@@ -127,20 +150,6 @@ func mapMessages(msgs []core.Message) (system string, messages []anthropicMessag
 	return system, messages
 }
 
-// marshalToolResultContent converts tool result content to a string.
-func marshalToolResultContent(content any) string {
-	switch v := content.(type) {
-	case string:
-		return v
-	default:
-		data, err := json.Marshal(v)
-		if err != nil {
-			return "{\"error\": \"failed to marshal tool result\"}"
-		}
-		return string(data)
-	}
-}
-
 // mapTools converts Iris tools to Anthropic tool format.
 // Tools that implement schemaProvider will have their schema included.
 func mapTools(irisTools []core.Tool) []anthropicTool {
@@ -206,6 +215,26 @@ func mapResponse(resp *anthropicResponse) (*core.ChatResponse, error) {
 
 	result.Output = strings.Join(textParts, "")
 	result.ToolCalls = toolCalls
+	result.FinishReason = mapStopReason(resp.StopReason)
+	result.RawFinishReason = resp.StopReason
 
 	return result, nil
 }
+
+// mapStopReason converts an Anthropic stop_reason to a finish reason.
+// pause_turn, sent when a long-running server tool pauses the turn, has
+// no equivalent.
+func mapStopReason(reason string) core.FinishReason {
+	switch reason {
+	case "end_turn", "stop_sequence":
+		return core.FinishStop
+	case "max_tokens", "model_context_window_exceeded":
+		return core.FinishLength
+	case "tool_use":
+		return core.FinishToolCalls
+	case "refusal":
+		return core.FinishContentFilter
+	default:
+		return core.FinishUnknown
+	}
+}
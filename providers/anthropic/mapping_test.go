@@ -65,7 +65,7 @@ func TestMapMessages(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			system, messages := mapMessages(tt.messages)
+			system, messages := mapMessages(tt.messages, nil)
 
 			if system != tt.wantSystem {
 				t.Errorf("system = %q, want %q", system, tt.wantSystem)
@@ -304,6 +304,32 @@ func TestMapResponse(t *testing.T) {
 	}
 }
 
+func TestMapStopReason(t *testing.T) {
+	tests := []struct {
+		reason string
+		want   core.FinishReason
+	}{
+		{"end_turn", core.FinishStop},
+		{"stop_sequence", core.FinishStop},
+		{"max_tokens", core.FinishLength},
+		{"model_context_window_exceeded", core.FinishLength},
+		{"tool_use", core.FinishToolCalls},
+		{"refusal", core.FinishContentFilter},
+		{"pause_turn", core.FinishUnknown},
+	}
+
+	for _, tt := range tests {
+		resp := &anthropicResponse{StopReason: tt.reason}
+		result, err := mapResponse(resp)
+		if err != nil {
+			t.Fatalf("mapResponse() error = %v", err)
+		}
+		if result.FinishReason != tt.want || result.RawFinishReason != tt.reason {
+			t.Errorf("stop_reason %q: FinishReason = %q, RawFinishReason = %q, want %q", tt.reason, result.FinishReason, result.RawFinishReason, tt.want)
+		}
+	}
+}
+
 func TestMapResponseWithToolCalls(t *testing.T) {
 	resp := &anthropicResponse{
 		ID:    "msg_456",
@@ -392,3 +418,52 @@ func TestMapResponseMultipleTextBlocks(t *testing.T) {
 		t.Errorf("Output = %q, want 'First Second'", result.Output)
 	}
 }
+
+func TestBuildRequestToolChoice(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name     string
+		choice   *core.ToolChoice
+		parallel *bool
+		want     anthropicToolChoice
+	}{
+		{"default", nil, nil, anthropicToolChoice{Type: "auto"}},
+		{"required", &core.ToolChoice{Mode: core.ToolChoiceRequired}, nil, anthropicToolChoice{Type: "any"}},
+		{"specific tool", &core.ToolChoice{Mode: core.ToolChoiceTool, Name: "get_weather"}, nil, anthropicToolChoice{Type: "tool", Name: "get_weather"}},
+		{"none ignores parallel", &core.ToolChoice{Mode: core.ToolChoiceNone}, &disabled, anthropicToolChoice{Type: "none"}},
+		{"parallel disabled", nil, &disabled, anthropicToolChoice{Type: "auto", DisableParallelToolUse: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &core.ChatRequest{
+				Model:             "claude-sonnet-4-5",
+				Messages:          []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+				Tools:             []core.Tool{&mockTool{name: "get_weather", description: "Get weather"}},
+				ToolChoice:        tt.choice,
+				ParallelToolCalls: tt.parallel,
+			}
+
+			result := buildRequest(req, false)
+			if result.ToolChoice == nil {
+				t.Fatal("ToolChoice = nil")
+			}
+			if *result.ToolChoice != tt.want {
+				t.Errorf("ToolChoice = %+v, want %+v", *result.ToolChoice, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapMessagesToolResultPolicy(t *testing.T) {
+	msgs := []core.Message{{
+		Role:        core.RoleTool,
+		ToolResults: []core.ToolResult{{CallID: "call_1", Content: "plain text"}},
+	}}
+
+	policy := &core.ToolResultPolicy{Format: core.ToolResultFormatJSON}
+	_, result := mapMessages(msgs, policy)
+	if result[0].Content[0].Content != `"plain text"` {
+		t.Errorf("Content = %q, want %q", result[0].Content[0].Content, `"plain text"`)
+	}
+}
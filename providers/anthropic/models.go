@@ -14,8 +14,11 @@ const (
 // models is the static list of supported models.
 var models = []core.ModelInfo{
 	{
-		ID:          ModelClaudeSonnet45,
-		DisplayName: "Claude Sonnet 4.5",
+		ID:              ModelClaudeSonnet45,
+		DisplayName:     "Claude Sonnet 4.5",
+		ContextWindow:   200000,
+		MaxOutputTokens: 64000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -23,8 +26,11 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelClaudeHaiku45,
-		DisplayName: "Claude Haiku 4.5",
+		ID:              ModelClaudeHaiku45,
+		DisplayName:     "Claude Haiku 4.5",
+		ContextWindow:   200000,
+		MaxOutputTokens: 64000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -32,8 +38,11 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelClaudeOpus45,
-		DisplayName: "Claude Opus 4.5",
+		ID:              ModelClaudeOpus45,
+		DisplayName:     "Claude Opus 4.5",
+		ContextWindow:   200000,
+		MaxOutputTokens: 64000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -2,6 +2,7 @@ package anthropic
 
 import (
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/petal-labs/iris/core"
@@ -16,9 +17,23 @@ type Config struct {
 	// BaseURL is the API base URL. Defaults to https://api.anthropic.com
 	BaseURL string
 
-	// HTTPClient is the HTTP client to use. Defaults to http.DefaultClient.
+	// HTTPClient is the HTTP client to use. Defaults to a client with pooled
+	// connections shared by all providers. When set, Transport and Proxy are
+	// ignored.
 	HTTPClient *http.Client
 
+	// Transport replaces the default transport, for example to tune
+	// connection pooling or add instrumentation.
+	Transport http.RoundTripper
+
+	// Proxy routes requests through a proxy instead of the one configured by
+	// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY.
+	Proxy *url.URL
+
+	// Credentials, if set, supplies the API key for each request instead of
+	// APIKey, so keys can rotate without a restart.
+	Credentials core.CredentialProvider
+
 	// Version is the Anthropic API version. Defaults to 2023-06-01.
 	Version string
 
@@ -58,6 +73,29 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// WithTransport sets the transport used by the default HTTP client.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Config) {
+		c.Transport = rt
+	}
+}
+
+// WithProxy routes requests through the proxy at proxyURL.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Config) {
+		c.Proxy = proxyURL
+	}
+}
+
+// WithCredentials resolves the API key from creds for every request instead
+// of using a fixed key. A request rejected with 401 is retried once after
+// refreshing creds, if it implements core.CredentialRefresher.
+func WithCredentials(creds core.CredentialProvider) Option {
+	return func(c *Config) {
+		c.Credentials = creds
+	}
+}
+
 // WithVersion sets the Anthropic API version.
 func WithVersion(version string) Option {
 	return func(c *Config) {
@@ -75,7 +113,8 @@ func WithHeader(key, value string) Option {
 	}
 }
 
-// WithTimeout sets the request timeout.
+// WithTimeout sets the default request timeout, which core.Client applies
+// to requests that have no context deadline or Timeout of their own.
 func WithTimeout(d time.Duration) Option {
 	return func(c *Config) {
 		c.Timeout = d
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 // DefaultAPIKeyEnvVar is the environment variable name for the Anthropic API key.
@@ -44,7 +46,6 @@ func New(apiKey string, opts ...Option) *Anthropic {
 	cfg := Config{
 		APIKey:       core.NewSecret(apiKey),
 		BaseURL:      DefaultBaseURL,
-		HTTPClient:   http.DefaultClient,
 		Version:      DefaultVersion,
 		FilesAPIBeta: DefaultFilesAPIBeta,
 	}
@@ -52,6 +53,12 @@ func New(apiKey string, opts ...Option) *Anthropic {
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+	}
+	if cfg.Credentials != nil {
+		cfg.HTTPClient = httpclient.WithCredentials(cfg.HTTPClient, cfg.Credentials, httpclient.HeaderAuth("x-api-key"))
+	}
 
 	return &Anthropic{config: cfg}
 }
@@ -61,6 +68,12 @@ func (p *Anthropic) ID() string {
 	return "anthropic"
 }
 
+// CloseIdleConnections closes the idle pooled connections of the provider's
+// HTTP client. core.Client.Close calls it during shutdown.
+func (p *Anthropic) CloseIdleConnections() {
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
 // Models returns the list of available models.
 func (p *Anthropic) Models() []core.ModelInfo {
 	// Return a copy to prevent mutation
@@ -69,6 +82,12 @@ func (p *Anthropic) Models() []core.ModelInfo {
 	return result
 }
 
+// DefaultTimeout returns the timeout set with WithTimeout. core.Client
+// applies it to requests that have no deadline or Timeout of their own.
+func (p *Anthropic) DefaultTimeout() time.Duration {
+	return p.config.Timeout
+}
+
 // Supports reports whether the provider supports the given feature.
 func (p *Anthropic) Supports(feature core.Feature) bool {
 	switch feature {
@@ -98,6 +117,16 @@ func (p *Anthropic) buildHeaders() http.Header {
 	return headers
 }
 
+// buildChatHeaders returns the headers for a chat request, adding the
+// request's idempotency key so retried attempts can be deduplicated.
+func (p *Anthropic) buildChatHeaders(req *core.ChatRequest) http.Header {
+	headers := p.buildHeaders()
+	if req.IdempotencyKey != "" {
+		headers.Set("Idempotency-Key", req.IdempotencyKey)
+	}
+	return headers
+}
+
 // Chat sends a non-streaming chat request.
 func (p *Anthropic) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
 	return p.doChat(ctx, req)
@@ -110,3 +139,9 @@ func (p *Anthropic) StreamChat(ctx context.Context, req *core.ChatRequest) (*cor
 
 // Compile-time check that Anthropic implements Provider.
 var _ core.Provider = (*Anthropic)(nil)
+
+// Compile-time check that Anthropic implements TimeoutProvider.
+var _ core.TimeoutProvider = (*Anthropic)(nil)
+
+// Compile-time check that Anthropic implements IdleConnectionCloser.
+var _ core.IdleConnectionCloser = (*Anthropic)(nil)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 func TestNew(t *testing.T) {
@@ -23,8 +24,8 @@ func TestNew(t *testing.T) {
 		t.Errorf("Version = %q, want %q", p.config.Version, DefaultVersion)
 	}
 
-	if p.config.HTTPClient != http.DefaultClient {
-		t.Error("HTTPClient should be http.DefaultClient")
+	if p.config.HTTPClient != httpclient.Default() {
+		t.Error("HTTPClient should be the shared default client")
 	}
 }
 
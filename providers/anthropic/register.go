@@ -9,4 +9,18 @@ func init() {
 	providers.Register("anthropic", func(apiKey string) core.Provider {
 		return New(apiKey)
 	})
+
+	core.RegisterProvider("anthropic", func(cfg core.ProviderConfig) (core.Provider, error) {
+		var opts []Option
+		apiKey := cfg.APIKeyOrEnv(DefaultAPIKeyEnvVar)
+		if cfg.Credentials != nil {
+			opts = append(opts, WithCredentials(cfg.Credentials))
+		} else if apiKey == "" {
+			return nil, ErrAPIKeyNotFound
+		}
+		if cfg.BaseURL != "" {
+			opts = append(opts, WithBaseURL(cfg.BaseURL))
+		}
+		return New(apiKey, opts...), nil
+	})
 }
@@ -70,7 +70,7 @@ func (p *Anthropic) doStreamChat(ctx context.Context, req *core.ChatRequest) (*c
 	}
 
 	// Set headers
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildChatHeaders(req) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -126,6 +126,7 @@ func (p *Anthropic) processSSEStream(
 	var responseID string
 	var responseModel string
 	var usage anthropicUsage
+	var stopReason string
 	var currentBlockIndex int
 
 	for {
@@ -218,6 +219,9 @@ func (p *Anthropic) processSSEStream(
 			if event.Usage != nil {
 				usage.OutputTokens = event.Usage.OutputTokens
 			}
+			if event.Delta != nil && event.Delta.StopReason != "" {
+				stopReason = event.Delta.StopReason
+			}
 
 		case "message_stop":
 			// Stream finished - break the loop
@@ -254,6 +258,8 @@ done:
 			CompletionTokens: usage.OutputTokens,
 			TotalTokens:      usage.InputTokens + usage.OutputTokens,
 		},
+		FinishReason:    mapStopReason(stopReason),
+		RawFinishReason: stopReason,
 	}
 
 	finalCh <- finalResp
@@ -249,6 +249,10 @@ func TestDoStreamChat(t *testing.T) {
 	if finalResp.Usage.CompletionTokens != 5 {
 		t.Errorf("CompletionTokens = %d, want 5", finalResp.Usage.CompletionTokens)
 	}
+
+	if finalResp.FinishReason != core.FinishStop || finalResp.RawFinishReason != "end_turn" {
+		t.Errorf("FinishReason = %q, RawFinishReason = %q, want stop, end_turn", finalResp.FinishReason, finalResp.RawFinishReason)
+	}
 }
 
 func TestDoStreamChatWithToolUse(t *testing.T) {
@@ -347,6 +351,10 @@ func TestDoStreamChatWithToolUse(t *testing.T) {
 	if string(tc.Arguments) != `{"location":"NYC"}` {
 		t.Errorf("ToolCall Arguments = %s, want '{\"location\":\"NYC\"}'", tc.Arguments)
 	}
+
+	if finalResp.FinishReason != core.FinishToolCalls {
+		t.Errorf("FinishReason = %q, want tool_calls", finalResp.FinishReason)
+	}
 }
 
 func TestDoStreamChatError(t *testing.T) {
@@ -4,14 +4,21 @@ import "encoding/json"
 
 // anthropicRequest represents a request to the Anthropic Messages API.
 type anthropicRequest struct {
-	Model       string             `json:"model"`
-	Messages    []anthropicMessage `json:"messages"`
-	MaxTokens   int                `json:"max_tokens"`
-	System      string             `json:"system,omitempty"`
-	Temperature *float32           `json:"temperature,omitempty"`
-	Stream      bool               `json:"stream,omitempty"`
-	Tools       []anthropicTool    `json:"tools,omitempty"`
-	ToolChoice  interface{}        `json:"tool_choice,omitempty"`
+	Model       string               `json:"model"`
+	Messages    []anthropicMessage   `json:"messages"`
+	MaxTokens   int                  `json:"max_tokens"`
+	System      string               `json:"system,omitempty"`
+	Temperature *float32             `json:"temperature,omitempty"`
+	Stream      bool                 `json:"stream,omitempty"`
+	Tools       []anthropicTool      `json:"tools,omitempty"`
+	ToolChoice  *anthropicToolChoice `json:"tool_choice,omitempty"`
+}
+
+// anthropicToolChoice controls how the model uses tools.
+type anthropicToolChoice struct {
+	Type                   string `json:"type"` // "auto", "any", "tool", "none"
+	Name                   string `json:"name,omitempty"`
+	DisableParallelToolUse bool   `json:"disable_parallel_tool_use,omitempty"`
 }
 
 // anthropicMessage represents a message in the Anthropic format.
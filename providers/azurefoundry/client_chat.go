@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
 )
 
 // doChat performs a non-streaming chat completion request.
@@ -164,9 +165,15 @@ func mapChatResponse(resp *azureResponse) (*core.ChatResponse, error) {
 	}
 
 	// Extract content from first choice
+	var content *azureContentFilters
+	var refusal string
 	if len(resp.Choices) > 0 {
 		choice := resp.Choices[0]
+		content = choice.ContentFilterResults
+		result.FinishReason = normalize.FinishReason(choice.FinishReason)
+		result.RawFinishReason = choice.FinishReason
 		if choice.Message != nil {
+			refusal = choice.Message.Refusal
 			result.Output = choice.Message.Content
 
 			// Map tool calls if present
@@ -179,6 +186,7 @@ func mapChatResponse(resp *azureResponse) (*core.ChatResponse, error) {
 			}
 		}
 	}
+	result.Safety = mapSafety(refusal, resp.PromptFilterResults, content)
 
 	return result, nil
 }
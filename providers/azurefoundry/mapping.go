@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/toolchoice"
 	"github.com/petal-labs/iris/tools"
 )
 
@@ -23,6 +24,7 @@ type azureRequest struct {
 	Stream              bool                 `json:"stream"`
 	Tools               []azureTool          `json:"tools,omitempty"`
 	ToolChoice          any                  `json:"tool_choice,omitempty"`
+	ParallelToolCalls   *bool                `json:"parallel_tool_calls,omitempty"`
 	ResponseFormat      *azureResponseFormat `json:"response_format,omitempty"`
 	Seed                *int                 `json:"seed,omitempty"`
 	FrequencyPenalty    *float32             `json:"frequency_penalty,omitempty"`
@@ -194,7 +196,8 @@ type schemaProvider interface {
 }
 
 // mapMessages converts Iris messages to Azure message format.
-func mapMessages(msgs []core.Message) []azureMessage {
+// Tool result content is serialized according to policy (nil uses the default).
+func mapMessages(msgs []core.Message, policy *core.ToolResultPolicy) []azureMessage {
 	result := make([]azureMessage, 0, len(msgs))
 
 	for _, msg := range msgs {
@@ -202,7 +205,7 @@ func mapMessages(msgs []core.Message) []azureMessage {
 		case core.RoleTool:
 			// Tool result messages: expand into individual messages per result
 			for _, tr := range msg.ToolResults {
-				content := marshalToolResultContent(tr.Content)
+				content := policy.Serialize(tr.Content)
 				result = append(result, azureMessage{
 					Role:       "tool",
 					Content:    content,
@@ -249,20 +252,6 @@ func mapToolCallsToAzure(calls []core.ToolCall) []azureToolCall {
 	return result
 }
 
-// marshalToolResultContent converts tool result content to a JSON string.
-func marshalToolResultContent(content any) string {
-	switch v := content.(type) {
-	case string:
-		return v
-	default:
-		data, err := json.Marshal(v)
-		if err != nil {
-			return `{"error": "failed to marshal tool result"}`
-		}
-		return string(data)
-	}
-}
-
 // mapTools converts Iris tools to Azure tool format.
 func mapTools(irisTools []core.Tool) []azureTool {
 	if len(irisTools) == 0 {
@@ -299,7 +288,7 @@ func mapTools(irisTools []core.Tool) []azureTool {
 func buildRequest(req *core.ChatRequest, stream bool) *azureRequest {
 	azReq := &azureRequest{
 		Model:    string(req.Model),
-		Messages: mapMessages(req.Messages),
+		Messages: mapMessages(req.Messages, req.ToolResultPolicy),
 		Stream:   stream,
 	}
 
@@ -315,7 +304,8 @@ func buildRequest(req *core.ChatRequest, stream bool) *azureRequest {
 	// Map tools if present
 	if len(req.Tools) > 0 {
 		azReq.Tools = mapTools(req.Tools)
-		azReq.ToolChoice = "auto"
+		azReq.ToolChoice = toolchoice.OpenAI(req.ToolChoice)
+		azReq.ParallelToolCalls = req.ParallelToolCalls
 	}
 
 	// Map response format for structured output
@@ -376,6 +366,82 @@ func mapResponse(resp *azureResponse) *core.ChatResponse {
 	return result
 }
 
+// mapSafety converts a refusal and Azure content filter results to Iris
+// safety metadata. It returns nil when there is nothing to report.
+func mapSafety(refusal string, prompt []azureFilterResult, content *azureContentFilters) *core.Safety {
+	var ratings []core.SafetyRating
+	for i := range prompt {
+		ratings = append(ratings, prompt[i].ContentFilterResults.ratings(true)...)
+	}
+	ratings = append(ratings, content.ratings(false)...)
+	if refusal == "" && len(ratings) == 0 {
+		return nil
+	}
+	return &core.Safety{Refusal: refusal, Ratings: ratings}
+}
+
+// ratings returns the filter results as Iris safety ratings.
+func (f *azureContentFilters) ratings(prompt bool) []core.SafetyRating {
+	if f == nil {
+		return nil
+	}
+	var ratings []core.SafetyRating
+	for _, s := range []struct {
+		category core.SafetyCategory
+		result   *azureFilterSeverity
+	}{
+		{core.SafetyHate, f.Hate},
+		{core.SafetySelfHarm, f.SelfHarm},
+		{core.SafetySexual, f.Sexual},
+		{core.SafetyViolence, f.Violence},
+	} {
+		if s.result == nil {
+			continue
+		}
+		ratings = append(ratings, core.SafetyRating{
+			Category: s.category,
+			Severity: mapSeverity(s.result.Severity),
+			Blocked:  s.result.Filtered,
+			Prompt:   prompt,
+		})
+	}
+	for _, d := range []struct {
+		category core.SafetyCategory
+		result   *azureFilterDetected
+	}{
+		{core.SafetyJailbreak, f.Jailbreak},
+		{core.SafetyProtectedMaterial, f.ProtectedMaterialText},
+		{core.SafetyProtectedMaterial, f.ProtectedMaterialCode},
+	} {
+		if d.result == nil {
+			continue
+		}
+		ratings = append(ratings, core.SafetyRating{
+			Category: d.category,
+			Detected: d.result.Detected,
+			Blocked:  d.result.Filtered,
+			Prompt:   prompt,
+		})
+	}
+	return ratings
+}
+
+// mapSeverity converts an Azure filter severity to an Iris severity.
+func mapSeverity(severity string) core.SafetySeverity {
+	switch severity {
+	case "safe":
+		return core.SafetyNegligible
+	case "low":
+		return core.SafetyLow
+	case "medium":
+		return core.SafetyMedium
+	case "high":
+		return core.SafetyHigh
+	default:
+		return core.SafetyUnknown
+	}
+}
+
 // mapUsage converts Azure token usage to Iris format.
 func mapUsage(usage azureUsage) core.TokenUsage {
 	return core.TokenUsage{
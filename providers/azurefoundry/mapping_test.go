@@ -14,7 +14,7 @@ func TestMapMessages(t *testing.T) {
 		{Role: core.RoleAssistant, Content: "Hi there!"},
 	}
 
-	result := mapMessages(msgs)
+	result := mapMessages(msgs, nil)
 
 	if len(result) != 3 {
 		t.Fatalf("len(result) = %d, want 3", len(result))
@@ -37,7 +37,7 @@ func TestMapMessages(t *testing.T) {
 }
 
 func TestMapMessagesEmpty(t *testing.T) {
-	result := mapMessages([]core.Message{})
+	result := mapMessages([]core.Message{}, nil)
 
 	if len(result) != 0 {
 		t.Errorf("len(result) = %d, want 0", len(result))
@@ -59,7 +59,7 @@ func TestMapMessagesWithToolCalls(t *testing.T) {
 		},
 	}
 
-	result := mapMessages(msgs)
+	result := mapMessages(msgs, nil)
 
 	if len(result) != 1 {
 		t.Fatalf("len(result) = %d, want 1", len(result))
@@ -98,7 +98,7 @@ func TestMapMessagesWithToolResults(t *testing.T) {
 		},
 	}
 
-	result := mapMessages(msgs)
+	result := mapMessages(msgs, nil)
 
 	// Each tool result should become a separate message
 	if len(result) != 2 {
@@ -409,19 +409,40 @@ func TestMapToolCallsToAzure(t *testing.T) {
 	}
 }
 
-func TestMarshalToolResultContentString(t *testing.T) {
-	result := marshalToolResultContent("plain text")
-	if result != "plain text" {
-		t.Errorf("marshalToolResultContent(string) = %q, want 'plain text'", result)
+func TestMapMessagesToolResultString(t *testing.T) {
+	msgs := []core.Message{{
+		Role:        core.RoleTool,
+		ToolResults: []core.ToolResult{{CallID: "call_1", Content: "plain text"}},
+	}}
+
+	result := mapMessages(msgs, nil)
+	if result[0].Content != "plain text" {
+		t.Errorf("Content = %q, want 'plain text'", result[0].Content)
+	}
+}
+
+func TestMapMessagesToolResultObject(t *testing.T) {
+	msgs := []core.Message{{
+		Role:        core.RoleTool,
+		ToolResults: []core.ToolResult{{CallID: "call_1", Content: map[string]interface{}{"key": "value"}}},
+	}}
+
+	result := mapMessages(msgs, nil)
+	if result[0].Content != `{"key":"value"}` {
+		t.Errorf("Content = %q, want {\"key\":\"value\"}", result[0].Content)
 	}
 }
 
-func TestMarshalToolResultContentObject(t *testing.T) {
-	obj := map[string]interface{}{"key": "value"}
-	result := marshalToolResultContent(obj)
+func TestMapMessagesToolResultPolicy(t *testing.T) {
+	msgs := []core.Message{{
+		Role:        core.RoleTool,
+		ToolResults: []core.ToolResult{{CallID: "call_1", Content: "plain text"}},
+	}}
 
-	if result != `{"key":"value"}` {
-		t.Errorf("marshalToolResultContent(object) = %q, want {\"key\":\"value\"}", result)
+	policy := &core.ToolResultPolicy{Format: core.ToolResultFormatJSON}
+	result := mapMessages(msgs, policy)
+	if result[0].Content != `"plain text"` {
+		t.Errorf("Content = %q, want %q", result[0].Content, `"plain text"`)
 	}
 }
 
@@ -573,3 +594,66 @@ func TestMapResponseWithToolCalls(t *testing.T) {
 		t.Errorf("ToolCalls[0].Name = %q, want get_weather", tc.Name)
 	}
 }
+
+func TestMapChatResponseSafety(t *testing.T) {
+	resp := &azureResponse{
+		ID:    "chatcmpl-safety",
+		Model: "gpt-4o",
+		Choices: []azureChoice{
+			{
+				Message: &azureRespMsg{Role: "assistant", Refusal: "I can't help with that."},
+				ContentFilterResults: &azureContentFilters{
+					Hate:                  &azureFilterSeverity{Severity: "safe"},
+					Violence:              &azureFilterSeverity{Severity: "medium"},
+					ProtectedMaterialText: &azureFilterDetected{Detected: true},
+				},
+			},
+		},
+		PromptFilterResults: []azureFilterResult{
+			{ContentFilterResults: azureContentFilters{Jailbreak: &azureFilterDetected{Detected: true}}},
+		},
+	}
+
+	result, err := mapChatResponse(resp)
+	if err != nil {
+		t.Fatalf("mapChatResponse() error = %v", err)
+	}
+	if result.Safety == nil {
+		t.Fatal("Safety is nil")
+	}
+	if result.Safety.Refusal != "I can't help with that." {
+		t.Errorf("Refusal = %q", result.Safety.Refusal)
+	}
+
+	want := []core.SafetyRating{
+		{Category: core.SafetyJailbreak, Detected: true, Prompt: true},
+		{Category: core.SafetyHate, Severity: core.SafetyNegligible},
+		{Category: core.SafetyViolence, Severity: core.SafetyMedium},
+		{Category: core.SafetyProtectedMaterial, Detected: true},
+	}
+	if len(result.Safety.Ratings) != len(want) {
+		t.Fatalf("Ratings = %+v, want %+v", result.Safety.Ratings, want)
+	}
+	for i, r := range result.Safety.Ratings {
+		if r != want[i] {
+			t.Errorf("Ratings[%d] = %+v, want %+v", i, r, want[i])
+		}
+	}
+}
+
+func TestMapChatResponseNoSafety(t *testing.T) {
+	resp := &azureResponse{
+		Choices: []azureChoice{{Message: &azureRespMsg{Role: "assistant", Content: "Hi"}, FinishReason: "stop"}},
+	}
+
+	result, err := mapChatResponse(resp)
+	if err != nil {
+		t.Fatalf("mapChatResponse() error = %v", err)
+	}
+	if result.Safety != nil {
+		t.Errorf("Safety = %+v, want nil", result.Safety)
+	}
+	if result.FinishReason != core.FinishStop || result.RawFinishReason != "stop" {
+		t.Errorf("FinishReason = %q, RawFinishReason = %q, want stop", result.FinishReason, result.RawFinishReason)
+	}
+}
@@ -2,6 +2,7 @@ package azurefoundry
 
 import (
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/petal-labs/iris/core"
@@ -26,10 +27,23 @@ type Config struct {
 	// Defaults to DefaultAPIVersion.
 	APIVersion string
 
-	// HTTPClient is the HTTP client to use.
-	// Defaults to http.DefaultClient.
+	// HTTPClient is the HTTP client to use. Defaults to a client with pooled
+	// connections shared by all providers. When set, Transport and Proxy are
+	// ignored.
 	HTTPClient *http.Client
 
+	// Transport replaces the default transport, for example to tune
+	// connection pooling or add instrumentation.
+	Transport http.RoundTripper
+
+	// Proxy routes requests through a proxy instead of the one configured by
+	// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY.
+	Proxy *url.URL
+
+	// Credentials, if set, supplies the API key for each request instead of
+	// APIKey, so keys can rotate without a restart.
+	Credentials core.CredentialProvider
+
 	// TokenCredential provides Entra ID tokens (alternative to APIKey).
 	// When set, APIKey is ignored and Bearer token auth is used.
 	TokenCredential TokenCredential
@@ -84,6 +98,29 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// WithTransport sets the transport used by the default HTTP client.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Config) {
+		c.Transport = rt
+	}
+}
+
+// WithProxy routes requests through the proxy at proxyURL.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Config) {
+		c.Proxy = proxyURL
+	}
+}
+
+// WithCredentials resolves the API key from creds for every request instead
+// of using a fixed key. A request rejected with 401 is retried once after
+// refreshing creds, if it implements core.CredentialRefresher.
+func WithCredentials(creds core.CredentialProvider) Option {
+	return func(c *Config) {
+		c.Credentials = creds
+	}
+}
+
 // WithHeader adds an extra header to include in all requests.
 // Can be called multiple times to add multiple headers.
 func WithHeader(key, value string) Option {
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 // Environment variable names for configuration.
@@ -53,12 +54,17 @@ func New(endpoint, apiKey string, opts ...Option) *AzureFoundry {
 		Endpoint:   normalizeEndpoint(endpoint),
 		APIKey:     core.NewSecret(apiKey),
 		APIVersion: DefaultAPIVersion,
-		HTTPClient: http.DefaultClient,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+	}
+	if cfg.Credentials != nil && cfg.TokenCredential == nil {
+		cfg.HTTPClient = httpclient.WithCredentials(cfg.HTTPClient, cfg.Credentials, httpclient.HeaderAuth("api-key"))
+	}
 
 	// Adjust default API version for OpenAI endpoint format
 	if cfg.UseOpenAIEndpoint && cfg.APIVersion == DefaultAPIVersion {
@@ -117,12 +123,17 @@ func NewWithCredential(endpoint string, credential TokenCredential, opts ...Opti
 		Endpoint:        normalizeEndpoint(endpoint),
 		TokenCredential: credential,
 		APIVersion:      DefaultAPIVersion,
-		HTTPClient:      http.DefaultClient,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+	}
+	if cfg.Credentials != nil && cfg.TokenCredential == nil {
+		cfg.HTTPClient = httpclient.WithCredentials(cfg.HTTPClient, cfg.Credentials, httpclient.HeaderAuth("api-key"))
+	}
 
 	// Adjust default API version for OpenAI endpoint format
 	if cfg.UseOpenAIEndpoint && cfg.APIVersion == DefaultAPIVersion {
@@ -142,6 +153,12 @@ func (p *AzureFoundry) ID() string {
 	return "azurefoundry"
 }
 
+// CloseIdleConnections closes the idle pooled connections of the provider's
+// HTTP client. core.Client.Close calls it during shutdown.
+func (p *AzureFoundry) CloseIdleConnections() {
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
 // Models returns the list of available models.
 // Azure AI Foundry supports various models depending on your deployment.
 // This returns common models; actual availability depends on your Azure configuration.
@@ -226,3 +243,6 @@ func normalizeEndpoint(endpoint string) string {
 
 // Compile-time check that AzureFoundry implements Provider.
 var _ core.Provider = (*AzureFoundry)(nil)
+
+// Compile-time check that AzureFoundry implements IdleConnectionCloser.
+var _ core.IdleConnectionCloser = (*AzureFoundry)(nil)
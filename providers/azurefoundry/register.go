@@ -1,6 +1,9 @@
 package azurefoundry
 
 import (
+	"cmp"
+	"os"
+
 	"github.com/petal-labs/iris/core"
 	"github.com/petal-labs/iris/providers"
 )
@@ -11,4 +14,22 @@ func init() {
 		// or use NewFromEnv/New directly for full configuration
 		return New("", apiKey)
 	})
+
+	core.RegisterProvider("azurefoundry", func(cfg core.ProviderConfig) (core.Provider, error) {
+		endpoint := cmp.Or(cfg.BaseURL, os.Getenv(EnvEndpoint))
+		if endpoint == "" {
+			return nil, ErrEndpointNotFound
+		}
+		var opts []Option
+		apiKey := cfg.APIKeyOrEnv(EnvAPIKey)
+		if cfg.Credentials != nil {
+			opts = append(opts, WithCredentials(cfg.Credentials))
+		} else if apiKey == "" {
+			return nil, ErrAPIKeyNotFound
+		}
+		if deploymentID := os.Getenv(EnvDeploymentID); deploymentID != "" {
+			opts = append(opts, WithDeploymentID(deploymentID))
+		}
+		return New(endpoint, apiKey, opts...), nil
+	})
 }
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
 	"github.com/petal-labs/iris/providers/internal/toolcalls"
 )
 
@@ -25,6 +26,9 @@ type azureStreamChunk struct {
 	Choices           []azureStreamChoice `json:"choices"`
 	Usage             *azureUsage         `json:"usage,omitempty"`
 	SystemFingerprint string              `json:"system_fingerprint,omitempty"`
+
+	// PromptFilterResults are sent once, usually in a chunk without choices.
+	PromptFilterResults []azureFilterResult `json:"prompt_filter_results,omitempty"`
 }
 
 // azureStreamChoice represents a choice in a streaming response.
@@ -135,6 +139,10 @@ func (p *AzureFoundry) readSSEStream(
 	var usage *azureUsage
 	var contentFiltered bool
 	var contentBuilder strings.Builder
+	var refusal strings.Builder
+	var promptFilters []azureFilterResult
+	var contentFilters *azureContentFilters
+	var finishReason string
 
 	for {
 		// Check for context cancellation
@@ -192,12 +200,22 @@ func (p *AzureFoundry) readSSEStream(
 		if chunk.Usage != nil {
 			usage = chunk.Usage
 		}
+		if len(chunk.PromptFilterResults) > 0 {
+			promptFilters = chunk.PromptFilterResults
+		}
 
 		// Process choices
 		for _, choice := range chunk.Choices {
 			// Check for content filtering
-			if choice.ContentFilterResults != nil && choice.ContentFilterResults.IsFiltered() {
-				contentFiltered = true
+			if choice.ContentFilterResults != nil {
+				contentFilters = choice.ContentFilterResults
+				if contentFilters.IsFiltered() {
+					contentFiltered = true
+				}
+			}
+			refusal.WriteString(choice.Delta.Refusal)
+			if choice.FinishReason != nil && *choice.FinishReason != "" {
+				finishReason = *choice.FinishReason
 			}
 
 			// Emit content delta
@@ -239,10 +257,13 @@ func (p *AzureFoundry) readSSEStream(
 
 	// Build final response
 	finalResp := &core.ChatResponse{
-		ID:        responseID,
-		Model:     core.ModelID(finalModel),
-		Output:    contentBuilder.String(),
-		ToolCalls: toolCalls,
+		ID:              responseID,
+		Model:           core.ModelID(finalModel),
+		Output:          contentBuilder.String(),
+		ToolCalls:       toolCalls,
+		Safety:          mapSafety(refusal.String(), promptFilters, contentFilters),
+		FinishReason:    normalize.FinishReason(finishReason),
+		RawFinishReason: finishReason,
 	}
 
 	if usage != nil {
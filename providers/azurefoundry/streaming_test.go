@@ -459,6 +459,53 @@ func TestStreamChatContentFiltered(t *testing.T) {
 	}
 }
 
+func TestStreamChatSafety(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+
+		fmt.Fprint(w, sseResponse(
+			`{"id":"","model":"","choices":[],"prompt_filter_results":[{"prompt_index":0,"content_filter_results":{"hate":{"filtered":false,"severity":"low"}}}]}`,
+			`{"id":"chatcmpl-1","model":"gpt-4o","choices":[{"index":0,"delta":{"content":"Hello"},"content_filter_results":{"violence":{"filtered":false,"severity":"safe"}}}]}`,
+			`{"id":"chatcmpl-1","model":"gpt-4o","choices":[{"index":0,"delta":{},"finish_reason":"length"}]}`,
+			"[DONE]",
+		))
+	}))
+	defer server.Close()
+
+	p := New(server.URL, "test-key")
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model:    "gpt-4o",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Test"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	final, err := core.DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+	if final.Safety == nil {
+		t.Fatal("Safety is nil")
+	}
+	if final.FinishReason != core.FinishLength || final.RawFinishReason != "length" {
+		t.Errorf("FinishReason = %q, RawFinishReason = %q, want length", final.FinishReason, final.RawFinishReason)
+	}
+	want := []core.SafetyRating{
+		{Category: core.SafetyHate, Severity: core.SafetyLow, Prompt: true},
+		{Category: core.SafetyViolence, Severity: core.SafetyNegligible},
+	}
+	if len(final.Safety.Ratings) != len(want) {
+		t.Fatalf("Ratings = %+v, want %+v", final.Safety.Ratings, want)
+	}
+	for i, r := range final.Safety.Ratings {
+		if r != want[i] {
+			t.Errorf("Ratings[%d] = %+v, want %+v", i, r, want[i])
+		}
+	}
+}
+
 func TestToolCallAssemblerEmpty(t *testing.T) {
 	a := newToolCallAssembler()
 	calls, err := a.finalize()
@@ -0,0 +1,35 @@
+// Package cerebras provides an LLM provider for the Cerebras Inference API.
+//
+// Cerebras serves open-weight models such as Llama, Qwen, and GPT-OSS at
+// high speed through an OpenAI-compatible API. Use this package with a
+// direct Cerebras API key; the huggingface provider can also route to
+// Cerebras through Hugging Face's inference providers.
+//
+// # Basic Usage
+//
+//	provider, err := cerebras.NewFromEnv()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	resp, err := core.NewClient(provider).Chat(cerebras.ModelLlama3370B).
+//	    User("Hello!").
+//	    GetResponse(ctx)
+//
+// The provider is an openaicompat provider configured for Cerebras, so New
+// takes the same options as openaicompat.New.
+//
+// # Rate Limits
+//
+// Cerebras limits requests per day and tokens per minute and reports when
+// each limit resets in x-ratelimit-reset-* headers rather than Retry-After.
+// When a request is rate limited, ProviderError.RetryAfter is set from the
+// limit that was exhausted, so the retry policy of a core.Client waits until
+// the reset, and gives up at once when the daily limit is reached.
+//
+// # Registry
+//
+// Importing the package registers the "cerebras" provider, which reads its
+// API key from ProviderConfig or the CEREBRAS_API_KEY environment variable:
+//
+//	provider, model, err := core.NewProviderFromString("cerebras:llama-3.3-70b")
+package cerebras
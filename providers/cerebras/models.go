@@ -0,0 +1,56 @@
+package cerebras
+
+import "github.com/petal-labs/iris/core"
+
+// Model constants for Cerebras models.
+const (
+	// Llama
+	ModelLlama318B  core.ModelID = "llama3.1-8b"
+	ModelLlama3370B core.ModelID = "llama-3.3-70b"
+
+	// GPT-OSS
+	ModelGPTOSS120B core.ModelID = "gpt-oss-120b"
+
+	// Qwen 3
+	ModelQwen332B              core.ModelID = "qwen-3-32b"
+	ModelQwen3235BInstruct2507 core.ModelID = "qwen-3-235b-a22b-instruct-2507"
+
+	// Z.ai GLM
+	ModelGLM46 core.ModelID = "zai-glm-4.6"
+)
+
+// features are the features supported by at least one model.
+var features = []core.Feature{
+	core.FeatureChat,
+	core.FeatureChatStreaming,
+	core.FeatureToolCalling,
+	core.FeatureStructuredOutput,
+	core.FeatureReasoning,
+}
+
+// Capabilities of the models without and with reasoning output.
+var (
+	chatCapabilities = []core.Feature{
+		core.FeatureChat,
+		core.FeatureChatStreaming,
+		core.FeatureToolCalling,
+		core.FeatureStructuredOutput,
+	}
+	reasoningCapabilities = []core.Feature{
+		core.FeatureChat,
+		core.FeatureChatStreaming,
+		core.FeatureToolCalling,
+		core.FeatureStructuredOutput,
+		core.FeatureReasoning,
+	}
+)
+
+// models is the static list of supported models.
+var models = []core.ModelInfo{
+	{ID: ModelLlama318B, DisplayName: "Llama 3.1 8B", APIEndpoint: core.APIEndpointCompletions, Capabilities: chatCapabilities},
+	{ID: ModelLlama3370B, DisplayName: "Llama 3.3 70B", APIEndpoint: core.APIEndpointCompletions, Capabilities: chatCapabilities},
+	{ID: ModelGPTOSS120B, DisplayName: "GPT-OSS 120B", APIEndpoint: core.APIEndpointCompletions, Capabilities: reasoningCapabilities},
+	{ID: ModelQwen332B, DisplayName: "Qwen 3 32B", APIEndpoint: core.APIEndpointCompletions, Capabilities: reasoningCapabilities},
+	{ID: ModelQwen3235BInstruct2507, DisplayName: "Qwen 3 235B Instruct", APIEndpoint: core.APIEndpointCompletions, Capabilities: chatCapabilities},
+	{ID: ModelGLM46, DisplayName: "Z.ai GLM 4.6", APIEndpoint: core.APIEndpointCompletions, Capabilities: reasoningCapabilities},
+}
@@ -0,0 +1,44 @@
+package cerebras
+
+import (
+	"errors"
+	"os"
+
+	"github.com/petal-labs/iris/providers/openaicompat"
+)
+
+// DefaultAPIKeyEnvVar is the environment variable name for the Cerebras API key.
+const DefaultAPIKeyEnvVar = "CEREBRAS_API_KEY"
+
+// DefaultBaseURL is the default Cerebras API base URL.
+const DefaultBaseURL = "https://api.cerebras.ai/v1"
+
+// DefaultID is the provider ID of providers created by New.
+const DefaultID = "cerebras"
+
+// ErrAPIKeyNotFound is returned when the API key environment variable is not set.
+var ErrAPIKeyNotFound = errors.New("cerebras: CEREBRAS_API_KEY environment variable not set")
+
+// NewFromEnv creates a new Cerebras provider using the CEREBRAS_API_KEY environment variable.
+func NewFromEnv(opts ...openaicompat.Option) (*openaicompat.Provider, error) {
+	apiKey := os.Getenv(DefaultAPIKeyEnvVar)
+	if apiKey == "" {
+		return nil, ErrAPIKeyNotFound
+	}
+	return New(apiKey, opts...), nil
+}
+
+// New creates a Cerebras provider with the given API key. The provider
+// reports the Cerebras models and reads the rate limit reset headers; opts
+// are applied after these defaults, so openaicompat.WithBaseURL can point
+// it elsewhere.
+func New(apiKey string, opts ...openaicompat.Option) *openaicompat.Provider {
+	defaults := []openaicompat.Option{
+		openaicompat.WithID(DefaultID),
+		openaicompat.WithModels(models...),
+		openaicompat.WithFeatures(features...),
+		openaicompat.WithMaxCompletionTokens(),
+		openaicompat.WithRetryAfter(retryAfter),
+	}
+	return openaicompat.New(DefaultBaseURL, apiKey, append(defaults, opts...)...)
+}
@@ -0,0 +1,116 @@
+package cerebras
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/openaicompat"
+)
+
+func TestNewDefaults(t *testing.T) {
+	p := New("test-key")
+
+	if p.ID() != DefaultID {
+		t.Errorf("ID() = %q, want %q", p.ID(), DefaultID)
+	}
+	if len(p.Models()) != len(models) {
+		t.Errorf("Models() has %d models, want %d", len(p.Models()), len(models))
+	}
+	if !p.Supports(core.FeatureReasoning) || p.Supports(core.FeatureVision) {
+		t.Error("Supports does not match the Cerebras features")
+	}
+}
+
+func TestNewFromEnv(t *testing.T) {
+	t.Setenv(DefaultAPIKeyEnvVar, "")
+	if _, err := NewFromEnv(); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("NewFromEnv() error = %v, want ErrAPIKeyNotFound", err)
+	}
+
+	t.Setenv(DefaultAPIKeyEnvVar, "test-key")
+	if _, err := NewFromEnv(); err != nil {
+		t.Errorf("NewFromEnv() error = %v", err)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{"none", http.Header{}, 0},
+		{"token limit", http.Header{
+			"X-Ratelimit-Remaining-Requests-Day": {"999"},
+			"X-Ratelimit-Reset-Requests-Day":     {"33011.38"},
+			"X-Ratelimit-Reset-Tokens-Minute":    {"11.5"},
+		}, 11500 * time.Millisecond},
+		{"daily limit", http.Header{
+			"X-Ratelimit-Remaining-Requests-Day": {"0"},
+			"X-Ratelimit-Reset-Requests-Day":     {"3600"},
+			"X-Ratelimit-Reset-Tokens-Minute":    {"11.5"},
+		}, time.Hour},
+		{"retry-after wins", http.Header{
+			"Retry-After":                     {"2"},
+			"X-Ratelimit-Reset-Tokens-Minute": {"11.5"},
+		}, 2 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryAfter(tt.header); got != tt.want {
+				t.Errorf("retryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChatRateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer test-key" {
+			t.Errorf("Authorization = %q", got)
+		}
+		w.Header().Set("x-ratelimit-remaining-tokens-minute", "0")
+		w.Header().Set("x-ratelimit-reset-tokens-minute", "4")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"message": "Tokens per minute limit exceeded", "type": "too_many_tokens_error", "code": "token_quota_exceeded"}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", openaicompat.WithBaseURL(server.URL+"/v1/"))
+	_, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:    ModelLlama318B,
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+	})
+
+	var pe *core.ProviderError
+	if !errors.As(err, &pe) || !errors.Is(err, core.ErrRateLimited) {
+		t.Fatalf("error = %v, want a rate limit error", err)
+	}
+	if pe.Provider != DefaultID || pe.RetryAfter != 4*time.Second {
+		t.Errorf("error = %+v, want cerebras with RetryAfter 4s", pe)
+	}
+}
+
+func TestRegisteredFactory(t *testing.T) {
+	t.Setenv(DefaultAPIKeyEnvVar, "")
+	if _, err := core.NewProviderFromConfig(core.ProviderConfig{Name: "cerebras"}); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("error = %v, want ErrAPIKeyNotFound", err)
+	}
+
+	t.Setenv(DefaultAPIKeyEnvVar, "test-key")
+	p, model, err := core.NewProviderFromString("cerebras:llama-3.3-70b")
+	if err != nil {
+		t.Fatalf("NewProviderFromString() error = %v", err)
+	}
+	if p.ID() != DefaultID || model != ModelLlama3370B {
+		t.Errorf("provider %q, model %q", p.ID(), model)
+	}
+}
@@ -0,0 +1,39 @@
+package cerebras
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/petal-labs/iris/providers/internal/normalize"
+)
+
+// Rate limit headers sent by Cerebras. Reset values are in seconds.
+const (
+	headerRemainingRequestsDay = "x-ratelimit-remaining-requests-day"
+	headerResetRequestsDay     = "x-ratelimit-reset-requests-day"
+	headerResetTokensMinute    = "x-ratelimit-reset-tokens-minute"
+)
+
+// retryAfter returns how long to wait after a rate limited response: until
+// the daily request limit resets if it is exhausted, and otherwise until
+// the per-minute token limit resets. A Retry-After header takes precedence.
+func retryAfter(h http.Header) time.Duration {
+	if d := normalize.RetryAfter(h); d > 0 {
+		return d
+	}
+	if h.Get(headerRemainingRequestsDay) == "0" {
+		return parseSeconds(h.Get(headerResetRequestsDay))
+	}
+	return parseSeconds(h.Get(headerResetTokensMinute))
+}
+
+// parseSeconds parses a number of seconds such as "11.38", returning zero
+// if v is not a positive number.
+func parseSeconds(v string) time.Duration {
+	seconds, err := strconv.ParseFloat(v, 64)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds * float64(time.Second))
+}
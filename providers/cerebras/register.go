@@ -0,0 +1,27 @@
+package cerebras
+
+import (
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers"
+	"github.com/petal-labs/iris/providers/openaicompat"
+)
+
+func init() {
+	providers.Register("cerebras", func(apiKey string) core.Provider {
+		return New(apiKey)
+	})
+
+	core.RegisterProvider("cerebras", func(cfg core.ProviderConfig) (core.Provider, error) {
+		var opts []openaicompat.Option
+		apiKey := cfg.APIKeyOrEnv(DefaultAPIKeyEnvVar)
+		if cfg.Credentials != nil {
+			opts = append(opts, openaicompat.WithCredentials(cfg.Credentials))
+		} else if apiKey == "" {
+			return nil, ErrAPIKeyNotFound
+		}
+		if cfg.BaseURL != "" {
+			opts = append(opts, openaicompat.WithBaseURL(cfg.BaseURL))
+		}
+		return New(apiKey, opts...), nil
+	})
+}
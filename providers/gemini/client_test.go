@@ -1,11 +1,14 @@
 package gemini
 
 import (
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/petal-labs/iris/core"
@@ -446,3 +449,51 @@ func TestDoChatContextCanceled(t *testing.T) {
 		t.Errorf("sentinel = %v, want ErrNetwork", provErr.Err)
 	}
 }
+
+func TestDoChatCompressesLargeRequests(t *testing.T) {
+	var encodings []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				t.Fatalf("gzip.NewReader error = %v", err)
+			}
+			body = zr
+		}
+		var reqBody geminiRequest
+		if err := json.NewDecoder(body).Decode(&reqBody); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(geminiResponse{
+			Candidates: []geminiCandidate{{
+				Content:      geminiContent{Role: "model", Parts: []geminiPart{{Text: "ok"}}},
+				FinishReason: "STOP",
+			}},
+		})
+	}))
+	defer server.Close()
+
+	large := strings.Repeat("Describe this document. ", 2000)
+	for _, opts := range [][]Option{
+		{WithBaseURL(server.URL)},
+		{WithBaseURL(server.URL), WithoutCompression()},
+	} {
+		provider := New("test-api-key", opts...)
+		_, err := provider.Chat(context.Background(), &core.ChatRequest{
+			Model:    "gemini-2.5-flash",
+			Messages: []core.Message{{Role: core.RoleUser, Content: large}},
+		})
+		if err != nil {
+			t.Fatalf("Chat error = %v", err)
+		}
+	}
+
+	if len(encodings) != 2 || encodings[0] != "gzip" || encodings[1] != "" {
+		t.Errorf("Content-Encoding = %q, want [gzip \"\"]", encodings)
+	}
+}
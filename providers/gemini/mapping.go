@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/outputparts"
 	"github.com/petal-labs/iris/tools"
 )
 
@@ -65,11 +66,35 @@ func buildRequest(req *core.ChatRequest) *geminiRequest {
 	// Map tools if present
 	if len(req.Tools) > 0 {
 		gemReq.Tools = mapTools(req.Tools)
+		gemReq.ToolConfig = mapToolConfig(req.ToolChoice)
 	}
 
 	return gemReq
 }
 
+// mapToolConfig converts a tool choice to Gemini's function calling config.
+// A specific tool maps to mode ANY restricted to that function. Gemini has no
+// switch for parallel function calls, so ParallelToolCalls is not mapped.
+func mapToolConfig(choice *core.ToolChoice) *geminiToolConfig {
+	if choice == nil {
+		return nil
+	}
+
+	cfg := geminiFunctionCallingConfig{}
+	switch choice.Mode {
+	case core.ToolChoiceNone:
+		cfg.Mode = "NONE"
+	case core.ToolChoiceRequired:
+		cfg.Mode = "ANY"
+	case core.ToolChoiceTool:
+		cfg.Mode = "ANY"
+		cfg.AllowedFunctionNames = []string{choice.Name}
+	default:
+		cfg.Mode = "AUTO"
+	}
+	return &geminiToolConfig{FunctionCallingConfig: cfg}
+}
+
 // mapMessages converts Iris messages to Gemini format.
 // It extracts system messages into a single string and converts
 // user/assistant messages to the Gemini content format.
@@ -111,6 +136,18 @@ func mapMessageParts(msg core.Message) []geminiPart {
 
 	parts := make([]geminiPart, 0, len(msg.Parts))
 	for _, part := range msg.Parts {
+		// MessageBuilder stores parts as pointers; accept both forms.
+		switch p := part.(type) {
+		case *core.InputText:
+			part = *p
+		case *core.InputImage:
+			part = *p
+		case *core.InputFile:
+			part = *p
+		case *core.InputAudio:
+			part = *p
+		}
+
 		switch p := part.(type) {
 		case core.InputText:
 			parts = append(parts, geminiPart{Text: p.Text})
@@ -118,6 +155,13 @@ func mapMessageParts(msg core.Message) []geminiPart {
 			parts = append(parts, mapInputImage(p))
 		case core.InputFile:
 			parts = append(parts, mapInputFile(p))
+		case core.InputAudio:
+			parts = append(parts, geminiPart{
+				InlineData: &geminiInlineData{
+					MimeType: p.Format.MimeType(),
+					Data:     p.Data,
+				},
+			})
 		}
 	}
 	return parts
@@ -339,14 +383,18 @@ func mapResponse(resp *geminiResponse, model string) (*core.ChatResponse, error)
 
 	// Extract content from first candidate
 	if len(resp.Candidates) == 0 {
+		result.Safety = mapSafety(resp.PromptFeedback, nil, "")
+		result.FinishReason, result.RawFinishReason = mapFinishReason(resp.PromptFeedback, "", false)
 		return result, nil
 	}
 
 	candidate := resp.Candidates[0]
+	result.Safety = mapSafety(resp.PromptFeedback, candidate.SafetyRatings, candidate.FinishReason)
 
 	var textParts []string
 	var toolCalls []core.ToolCall
 	var thoughtParts []string
+	var outputParts outputparts.Builder
 	toolCallIndex := 0
 
 	for _, part := range candidate.Content.Parts {
@@ -360,6 +408,11 @@ func mapResponse(resp *geminiResponse, model string) (*core.ChatResponse, error)
 
 		if part.Text != "" {
 			textParts = append(textParts, part.Text)
+			outputParts.AddText(part.Text)
+		}
+
+		if part.InlineData != nil {
+			outputParts.Add(mapInlineOutput(part.InlineData))
 		}
 
 		if part.FunctionCall != nil {
@@ -374,6 +427,8 @@ func mapResponse(resp *geminiResponse, model string) (*core.ChatResponse, error)
 
 	result.Output = strings.Join(textParts, "")
 	result.ToolCalls = toolCalls
+	result.Parts = outputParts.Parts()
+	result.FinishReason, result.RawFinishReason = mapFinishReason(resp.PromptFeedback, candidate.FinishReason, len(toolCalls) > 0)
 
 	// Add reasoning output if thoughts were present
 	if len(thoughtParts) > 0 {
@@ -385,6 +440,120 @@ func mapResponse(resp *geminiResponse, model string) (*core.ChatResponse, error)
 	return result, nil
 }
 
+// blockFinishReasons are the finish reasons of candidates that Gemini
+// stopped for safety reasons.
+var blockFinishReasons = map[string]bool{
+	"SAFETY":             true,
+	"RECITATION":         true,
+	"PROHIBITED_CONTENT": true,
+	"BLOCKLIST":          true,
+	"SPII":               true,
+	"IMAGE_SAFETY":       true,
+}
+
+// mapSafety converts prompt feedback and the safety ratings and finish
+// reason of a candidate to Iris safety metadata. It returns nil when there
+// is nothing to report.
+func mapSafety(feedback *geminiPromptFeedback, ratings []geminiSafetyRating, finishReason string) *core.Safety {
+	safety := &core.Safety{}
+	if feedback != nil {
+		if feedback.BlockReason != "" {
+			safety.Blocked = true
+			safety.BlockReason = feedback.BlockReason
+		}
+		for _, r := range feedback.SafetyRatings {
+			safety.Ratings = append(safety.Ratings, mapSafetyRating(r, true))
+		}
+	}
+	for _, r := range ratings {
+		safety.Ratings = append(safety.Ratings, mapSafetyRating(r, false))
+	}
+	if blockFinishReasons[finishReason] && !safety.Blocked {
+		safety.Blocked = true
+		safety.BlockReason = finishReason
+	}
+	if !safety.Blocked && len(safety.Ratings) == 0 {
+		return nil
+	}
+	return safety
+}
+
+// mapFinishReason returns the normalized and raw finish reason of a
+// candidate's finishReason, or of the prompt block reason when no
+// candidate finished. Gemini finishes with STOP after function calls, so
+// hasToolCalls turns STOP into FinishToolCalls.
+func mapFinishReason(feedback *geminiPromptFeedback, reason string, hasToolCalls bool) (core.FinishReason, string) {
+	if reason == "" && feedback != nil && feedback.BlockReason != "" {
+		return core.FinishContentFilter, feedback.BlockReason
+	}
+	switch {
+	case reason == "STOP" && hasToolCalls:
+		return core.FinishToolCalls, reason
+	case reason == "STOP":
+		return core.FinishStop, reason
+	case reason == "MAX_TOKENS":
+		return core.FinishLength, reason
+	case blockFinishReasons[reason]:
+		return core.FinishContentFilter, reason
+	default:
+		return core.FinishUnknown, reason
+	}
+}
+
+// mapSafetyRating converts a Gemini safety rating to an Iris rating.
+func mapSafetyRating(r geminiSafetyRating, prompt bool) core.SafetyRating {
+	return core.SafetyRating{
+		Category: mapSafetyCategory(r.Category),
+		Severity: mapSafetyProbability(r.Probability),
+		Blocked:  r.Blocked,
+		Prompt:   prompt,
+	}
+}
+
+// mapSafetyCategory converts a Gemini harm category, such as
+// HARM_CATEGORY_HATE_SPEECH, to an Iris category.
+func mapSafetyCategory(category string) core.SafetyCategory {
+	switch category {
+	case "HARM_CATEGORY_HATE_SPEECH":
+		return core.SafetyHate
+	case "HARM_CATEGORY_HARASSMENT":
+		return core.SafetyHarassment
+	case "HARM_CATEGORY_SEXUALLY_EXPLICIT":
+		return core.SafetySexual
+	case "HARM_CATEGORY_DANGEROUS_CONTENT":
+		return core.SafetyDangerous
+	case "HARM_CATEGORY_CIVIC_INTEGRITY":
+		return core.SafetyCivicIntegrity
+	default:
+		return core.SafetyCategory(strings.ToLower(strings.TrimPrefix(category, "HARM_CATEGORY_")))
+	}
+}
+
+// mapSafetyProbability converts a Gemini harm probability to an Iris
+// severity.
+func mapSafetyProbability(probability string) core.SafetySeverity {
+	switch probability {
+	case "NEGLIGIBLE":
+		return core.SafetyNegligible
+	case "LOW":
+		return core.SafetyLow
+	case "MEDIUM":
+		return core.SafetyMedium
+	case "HIGH":
+		return core.SafetyHigh
+	default:
+		return core.SafetyUnknown
+	}
+}
+
+// mapInlineOutput converts inline data returned by the model to an output part.
+func mapInlineOutput(data *geminiInlineData) core.OutputPart {
+	if strings.HasPrefix(data.MimeType, "audio/") {
+		return core.OutputAudio{MimeType: data.MimeType, Data: data.Data}
+	}
+	return core.OutputImage{MimeType: data.MimeType, Data: data.Data}
+}
+
 // mapResponseFormat converts Iris response format to Gemini format.
 // Returns the responseMimeType and optional responseSchema.
 func mapResponseFormat(req *core.ChatRequest) (mimeType string, schema json.RawMessage) {
@@ -171,6 +171,61 @@ func TestMapResponse(t *testing.T) {
 	}
 }
 
+func TestMapResponseWithInlineMedia(t *testing.T) {
+	resp := &geminiResponse{
+		Candidates: []geminiCandidate{
+			{
+				Content: geminiContent{
+					Role: "model",
+					Parts: []geminiPart{
+						{Text: "Here is "},
+						{Text: "your cat:"},
+						{InlineData: &geminiInlineData{MimeType: "image/png", Data: "aW1n"}},
+						{InlineData: &geminiInlineData{MimeType: "audio/wav", Data: "YXVk"}},
+					},
+				},
+			},
+		},
+	}
+
+	result, err := mapResponse(resp, "gemini-2.5-flash-image")
+	if err != nil {
+		t.Fatalf("mapResponse error = %v", err)
+	}
+
+	if result.Output != "Here is your cat:" {
+		t.Errorf("Output = %q, want 'Here is your cat:'", result.Output)
+	}
+	if len(result.Parts) != 3 {
+		t.Fatalf("len(Parts) = %d, want 3", len(result.Parts))
+	}
+	if text, ok := result.Parts[0].(core.OutputText); !ok || text.Text != "Here is your cat:" {
+		t.Errorf("Parts[0] = %#v, want merged OutputText", result.Parts[0])
+	}
+	if img, ok := result.Parts[1].(core.OutputImage); !ok || img.MimeType != "image/png" || img.Data != "aW1n" {
+		t.Errorf("Parts[1] = %#v, want OutputImage", result.Parts[1])
+	}
+	if audio, ok := result.Parts[2].(core.OutputAudio); !ok || audio.MimeType != "audio/wav" {
+		t.Errorf("Parts[2] = %#v, want OutputAudio", result.Parts[2])
+	}
+}
+
+func TestMapResponseTextOnlyHasNoParts(t *testing.T) {
+	resp := &geminiResponse{
+		Candidates: []geminiCandidate{
+			{Content: geminiContent{Role: "model", Parts: []geminiPart{{Text: "Hi"}}}},
+		},
+	}
+
+	result, err := mapResponse(resp, "gemini-2.5-flash")
+	if err != nil {
+		t.Fatalf("mapResponse error = %v", err)
+	}
+	if result.Parts != nil {
+		t.Errorf("Parts = %v, want nil for text-only output", result.Parts)
+	}
+}
+
 func TestMapResponseWithToolCalls(t *testing.T) {
 	resp := &geminiResponse{
 		Candidates: []geminiCandidate{
@@ -421,6 +476,116 @@ func TestMapInputFile_WithFileURL(t *testing.T) {
 	}
 }
 
+func TestMapResponseSafety(t *testing.T) {
+	resp := &geminiResponse{
+		Candidates: []geminiCandidate{
+			{
+				FinishReason: "SAFETY",
+				SafetyRatings: []geminiSafetyRating{
+					{Category: "HARM_CATEGORY_HATE_SPEECH", Probability: "NEGLIGIBLE"},
+					{Category: "HARM_CATEGORY_DANGEROUS_CONTENT", Probability: "HIGH", Blocked: true},
+					{Category: "HARM_CATEGORY_MEDICAL", Probability: "LOW"},
+				},
+			},
+		},
+	}
+
+	result, err := mapResponse(resp, "gemini-2.5-flash")
+	if err != nil {
+		t.Fatalf("mapResponse() error = %v", err)
+	}
+	if result.Safety == nil {
+		t.Fatal("Safety is nil")
+	}
+	if !result.Safety.Blocked || result.Safety.BlockReason != "SAFETY" {
+		t.Errorf("Blocked = %v, BlockReason = %q, want true, SAFETY", result.Safety.Blocked, result.Safety.BlockReason)
+	}
+
+	want := []core.SafetyRating{
+		{Category: core.SafetyHate, Severity: core.SafetyNegligible},
+		{Category: core.SafetyDangerous, Severity: core.SafetyHigh, Blocked: true},
+		{Category: "medical", Severity: core.SafetyLow},
+	}
+	if len(result.Safety.Ratings) != len(want) {
+		t.Fatalf("Ratings = %+v, want %+v", result.Safety.Ratings, want)
+	}
+	for i, r := range result.Safety.Ratings {
+		if r != want[i] {
+			t.Errorf("Ratings[%d] = %+v, want %+v", i, r, want[i])
+		}
+	}
+}
+
+func TestMapResponseBlockedPrompt(t *testing.T) {
+	resp := &geminiResponse{
+		PromptFeedback: &geminiPromptFeedback{
+			BlockReason: "PROHIBITED_CONTENT",
+			SafetyRatings: []geminiSafetyRating{
+				{Category: "HARM_CATEGORY_HARASSMENT", Probability: "MEDIUM"},
+			},
+		},
+	}
+
+	result, err := mapResponse(resp, "gemini-2.5-flash")
+	if err != nil {
+		t.Fatalf("mapResponse() error = %v", err)
+	}
+	if result.Safety == nil || !result.Safety.Blocked || result.Safety.BlockReason != "PROHIBITED_CONTENT" {
+		t.Fatalf("Safety = %+v, want blocked prompt", result.Safety)
+	}
+	want := core.SafetyRating{Category: core.SafetyHarassment, Severity: core.SafetyMedium, Prompt: true}
+	if len(result.Safety.Ratings) != 1 || result.Safety.Ratings[0] != want {
+		t.Errorf("Ratings = %+v, want [%+v]", result.Safety.Ratings, want)
+	}
+	if result.FinishReason != core.FinishContentFilter || result.RawFinishReason != "PROHIBITED_CONTENT" {
+		t.Errorf("FinishReason = %q, RawFinishReason = %q, want content_filter, PROHIBITED_CONTENT", result.FinishReason, result.RawFinishReason)
+	}
+}
+
+func TestMapResponseFinishReason(t *testing.T) {
+	call := geminiPart{FunctionCall: &geminiFunctionCall{Name: "lookup", Args: json.RawMessage(`{}`)}}
+	tests := []struct {
+		name      string
+		candidate geminiCandidate
+		want      core.FinishReason
+	}{
+		{"stop", geminiCandidate{FinishReason: "STOP"}, core.FinishStop},
+		{"function call", geminiCandidate{Content: geminiContent{Parts: []geminiPart{call}}, FinishReason: "STOP"}, core.FinishToolCalls},
+		{"max tokens", geminiCandidate{FinishReason: "MAX_TOKENS"}, core.FinishLength},
+		{"recitation", geminiCandidate{FinishReason: "RECITATION"}, core.FinishContentFilter},
+		{"other", geminiCandidate{FinishReason: "MALFORMED_FUNCTION_CALL"}, core.FinishUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &geminiResponse{Candidates: []geminiCandidate{tt.candidate}}
+			result, err := mapResponse(resp, "gemini-2.5-flash")
+			if err != nil {
+				t.Fatalf("mapResponse() error = %v", err)
+			}
+			if result.FinishReason != tt.want || result.RawFinishReason != tt.candidate.FinishReason {
+				t.Errorf("FinishReason = %q, RawFinishReason = %q, want %q, %q", result.FinishReason, result.RawFinishReason, tt.want, tt.candidate.FinishReason)
+			}
+		})
+	}
+}
+
+func TestMapResponseNoSafety(t *testing.T) {
+	resp := &geminiResponse{
+		Candidates: []geminiCandidate{
+			{Content: geminiContent{Parts: []geminiPart{{Text: "Hi"}}}, FinishReason: "STOP"},
+		},
+	}
+
+	result, err := mapResponse(resp, "gemini-2.5-flash")
+	if err != nil {
+		t.Fatalf("mapResponse() error = %v", err)
+	}
+	if result.Safety != nil {
+		t.Errorf("Safety = %+v, want nil", result.Safety)
+	}
+}
+
 func TestMapResponseFormatJSON(t *testing.T) {
 	req := &core.ChatRequest{
 		Model:          "gemini-2.5-pro",
@@ -535,3 +700,81 @@ func TestBuildRequestWithResponseFormat(t *testing.T) {
 		t.Errorf("ResponseSchema = %s, want %s", result.GenerationConfig.ResponseSchema, schemaBytes)
 	}
 }
+
+func TestMapMessages_WithBuilderPartsAndAudio(t *testing.T) {
+	msgs := []core.Message{
+		{
+			Role: core.RoleUser,
+			Parts: []core.ContentPart{
+				&core.InputText{Text: "What is said here?"},
+				&core.InputAudio{Data: "SUQz", Format: core.AudioFormatMP3},
+				&core.InputImage{ImageURL: "data:image/png;base64,iVBOR"},
+			},
+		},
+	}
+
+	_, contents := mapMessages(msgs)
+	if len(contents) != 1 || len(contents[0].Parts) != 3 {
+		t.Fatalf("unexpected contents: %+v", contents)
+	}
+
+	parts := contents[0].Parts
+	if parts[0].Text != "What is said here?" {
+		t.Errorf("Part[0].Text = %q", parts[0].Text)
+	}
+	if parts[1].InlineData == nil || parts[1].InlineData.MimeType != "audio/mpeg" || parts[1].InlineData.Data != "SUQz" {
+		t.Errorf("audio part = %+v", parts[1].InlineData)
+	}
+	if parts[2].InlineData == nil || parts[2].InlineData.MimeType != "image/png" {
+		t.Errorf("image part = %+v", parts[2].InlineData)
+	}
+}
+
+type toolChoiceTestTool struct{ name string }
+
+func (t toolChoiceTestTool) Name() string        { return t.name }
+func (t toolChoiceTestTool) Description() string { return "test tool" }
+
+func TestBuildRequestToolConfig(t *testing.T) {
+	tests := []struct {
+		name      string
+		choice    *core.ToolChoice
+		wantMode  string
+		wantNames []string
+	}{
+		{"default", nil, "", nil},
+		{"auto", &core.ToolChoice{Mode: core.ToolChoiceAuto}, "AUTO", nil},
+		{"none", &core.ToolChoice{Mode: core.ToolChoiceNone}, "NONE", nil},
+		{"required", &core.ToolChoice{Mode: core.ToolChoiceRequired}, "ANY", nil},
+		{"specific tool", &core.ToolChoice{Mode: core.ToolChoiceTool, Name: "get_weather"}, "ANY", []string{"get_weather"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &core.ChatRequest{
+				Model:      "gemini-2.5-flash",
+				Messages:   []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+				Tools:      []core.Tool{toolChoiceTestTool{name: "get_weather"}},
+				ToolChoice: tt.choice,
+			}
+
+			result := buildRequest(req)
+			if tt.wantMode == "" {
+				if result.ToolConfig != nil {
+					t.Errorf("ToolConfig = %+v, want nil", result.ToolConfig)
+				}
+				return
+			}
+			if result.ToolConfig == nil {
+				t.Fatal("ToolConfig = nil")
+			}
+			cfg := result.ToolConfig.FunctionCallingConfig
+			if cfg.Mode != tt.wantMode {
+				t.Errorf("Mode = %q, want %q", cfg.Mode, tt.wantMode)
+			}
+			if len(cfg.AllowedFunctionNames) != len(tt.wantNames) || (len(tt.wantNames) > 0 && cfg.AllowedFunctionNames[0] != tt.wantNames[0]) {
+				t.Errorf("AllowedFunctionNames = %v, want %v", cfg.AllowedFunctionNames, tt.wantNames)
+			}
+		})
+	}
+}
@@ -22,8 +22,11 @@ const (
 // models is the static list of supported models.
 var models = []core.ModelInfo{
 	{
-		ID:          ModelGemini3Pro,
-		DisplayName: "Gemini 3 Pro Preview",
+		ID:              ModelGemini3Pro,
+		DisplayName:     "Gemini 3 Pro Preview",
+		ContextWindow:   1048576,
+		MaxOutputTokens: 65536,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage, core.ModalityAudio, core.ModalityVideo},
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -32,8 +35,11 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGemini3Flash,
-		DisplayName: "Gemini 3 Flash Preview",
+		ID:              ModelGemini3Flash,
+		DisplayName:     "Gemini 3 Flash Preview",
+		ContextWindow:   1048576,
+		MaxOutputTokens: 65536,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage, core.ModalityAudio, core.ModalityVideo},
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -42,8 +48,11 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGemini25Flash,
-		DisplayName: "Gemini 2.5 Flash",
+		ID:              ModelGemini25Flash,
+		DisplayName:     "Gemini 2.5 Flash",
+		ContextWindow:   1048576,
+		MaxOutputTokens: 65536,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage, core.ModalityAudio, core.ModalityVideo},
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -52,8 +61,11 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGemini25FlashLite,
-		DisplayName: "Gemini 2.5 Flash Lite",
+		ID:              ModelGemini25FlashLite,
+		DisplayName:     "Gemini 2.5 Flash Lite",
+		ContextWindow:   1048576,
+		MaxOutputTokens: 65536,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage, core.ModalityAudio, core.ModalityVideo},
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -62,8 +74,11 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGemini25Pro,
-		DisplayName: "Gemini 2.5 Pro",
+		ID:              ModelGemini25Pro,
+		DisplayName:     "Gemini 2.5 Pro",
+		ContextWindow:   1048576,
+		MaxOutputTokens: 65536,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage, core.ModalityAudio, core.ModalityVideo},
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -73,15 +88,21 @@ var models = []core.ModelInfo{
 	},
 	// Image generation models (Nano Banana)
 	{
-		ID:          ModelGemini25FlashImage,
-		DisplayName: "Gemini 2.5 Flash Image (Nano Banana)",
+		ID:              ModelGemini25FlashImage,
+		DisplayName:     "Gemini 2.5 Flash Image (Nano Banana)",
+		ContextWindow:   32768,
+		MaxOutputTokens: 32768,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
 		Capabilities: []core.Feature{
 			core.FeatureImageGeneration,
 		},
 	},
 	{
-		ID:          ModelGemini3ProImage,
-		DisplayName: "Gemini 3 Pro Image Preview (Nano Banana Pro)",
+		ID:              ModelGemini3ProImage,
+		DisplayName:     "Gemini 3 Pro Image Preview (Nano Banana Pro)",
+		ContextWindow:   65536,
+		MaxOutputTokens: 32768,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
 		Capabilities: []core.Feature{
 			core.FeatureImageGeneration,
 		},
@@ -2,6 +2,7 @@ package gemini
 
 import (
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/petal-labs/iris/core"
@@ -16,9 +17,27 @@ type Config struct {
 	// BaseURL is the API base URL. Defaults to https://generativelanguage.googleapis.com
 	BaseURL string
 
-	// HTTPClient is the HTTP client to use. Defaults to http.DefaultClient.
+	// HTTPClient is the HTTP client to use. Defaults to a client with pooled
+	// connections shared by all providers. When set, Transport and Proxy are
+	// ignored.
 	HTTPClient *http.Client
 
+	// Transport replaces the default transport, for example to tune
+	// connection pooling or add instrumentation.
+	Transport http.RoundTripper
+
+	// Proxy routes requests through a proxy instead of the one configured by
+	// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY.
+	Proxy *url.URL
+
+	// Credentials, if set, supplies the API key for each request instead of
+	// APIKey, so keys can rotate without a restart.
+	Credentials core.CredentialProvider
+
+	// DisableCompression stops the default HTTP client from gzip-compressing
+	// large JSON request bodies, such as requests with inline images.
+	DisableCompression bool
+
 	// Headers contains optional extra headers to include in requests.
 	Headers http.Header
 
@@ -46,6 +65,36 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// WithTransport sets the transport used by the default HTTP client.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Config) {
+		c.Transport = rt
+	}
+}
+
+// WithProxy routes requests through the proxy at proxyURL.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Config) {
+		c.Proxy = proxyURL
+	}
+}
+
+// WithCredentials resolves the API key from creds for every request instead
+// of using a fixed key. A request rejected with 401 is retried once after
+// refreshing creds, if it implements core.CredentialRefresher.
+func WithCredentials(creds core.CredentialProvider) Option {
+	return func(c *Config) {
+		c.Credentials = creds
+	}
+}
+
+// WithoutCompression sends request bodies uncompressed.
+func WithoutCompression() Option {
+	return func(c *Config) {
+		c.DisableCompression = true
+	}
+}
+
 // WithHeader adds an extra header to include in requests.
 func WithHeader(key, value string) Option {
 	return func(c *Config) {
@@ -56,7 +105,8 @@ func WithHeader(key, value string) Option {
 	}
 }
 
-// WithTimeout sets the request timeout.
+// WithTimeout sets the default request timeout, which core.Client applies
+// to requests that have no context deadline or Timeout of their own.
 func WithTimeout(d time.Duration) Option {
 	return func(c *Config) {
 		c.Timeout = d
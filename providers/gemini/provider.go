@@ -5,8 +5,10 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 // Environment variable names for the Gemini API key.
@@ -48,14 +50,22 @@ type Gemini struct {
 // New creates a new Gemini provider with the given API key and options.
 func New(apiKey string, opts ...Option) *Gemini {
 	cfg := Config{
-		APIKey:     core.NewSecret(apiKey),
-		BaseURL:    DefaultBaseURL,
-		HTTPClient: http.DefaultClient,
+		APIKey:  core.NewSecret(apiKey),
+		BaseURL: DefaultBaseURL,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+		if !cfg.DisableCompression {
+			cfg.HTTPClient = httpclient.WithCompression(cfg.HTTPClient)
+		}
+	}
+	if cfg.Credentials != nil {
+		cfg.HTTPClient = httpclient.WithCredentials(cfg.HTTPClient, cfg.Credentials, httpclient.HeaderAuth("x-goog-api-key"))
+	}
 
 	return &Gemini{config: cfg}
 }
@@ -65,6 +75,12 @@ func (p *Gemini) ID() string {
 	return "gemini"
 }
 
+// CloseIdleConnections closes the idle pooled connections of the provider's
+// HTTP client. core.Client.Close calls it during shutdown.
+func (p *Gemini) CloseIdleConnections() {
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
 // Models returns the list of available models.
 func (p *Gemini) Models() []core.ModelInfo {
 	// Return a copy to prevent mutation
@@ -73,6 +89,12 @@ func (p *Gemini) Models() []core.ModelInfo {
 	return result
 }
 
+// DefaultTimeout returns the timeout set with WithTimeout. core.Client
+// applies it to requests that have no deadline or Timeout of their own.
+func (p *Gemini) DefaultTimeout() time.Duration {
+	return p.config.Timeout
+}
+
 // Supports reports whether the provider supports the given feature.
 func (p *Gemini) Supports(feature core.Feature) bool {
 	switch feature {
@@ -116,3 +138,9 @@ var _ core.Provider = (*Gemini)(nil)
 
 // Compile-time check that Gemini implements ImageGenerator.
 var _ core.ImageGenerator = (*Gemini)(nil)
+
+// Compile-time check that Gemini implements TimeoutProvider.
+var _ core.TimeoutProvider = (*Gemini)(nil)
+
+// Compile-time check that Gemini implements IdleConnectionCloser.
+var _ core.IdleConnectionCloser = (*Gemini)(nil)
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 func TestNew(t *testing.T) {
@@ -19,8 +20,13 @@ func TestNew(t *testing.T) {
 		t.Errorf("BaseURL = %q, want %q", p.config.BaseURL, DefaultBaseURL)
 	}
 
-	if p.config.HTTPClient != http.DefaultClient {
-		t.Error("HTTPClient should be http.DefaultClient")
+	if p.config.HTTPClient.Transport == httpclient.Default().Transport {
+		t.Error("HTTPClient should compress requests")
+	}
+
+	p = New("test-key", WithoutCompression())
+	if p.config.HTTPClient != httpclient.Default() {
+		t.Error("HTTPClient should be the shared default client without compression")
 	}
 }
 
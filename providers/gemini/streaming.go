@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/outputparts"
 )
 
 // doStreamChat performs a streaming chat request.
@@ -85,7 +86,11 @@ func (p *Gemini) processSSEStream(
 	var accumulatedText strings.Builder
 	var toolCalls []core.ToolCall
 	var thoughtParts []string
+	var outputParts outputparts.Builder
 	var usage *geminiUsage
+	var promptFeedback *geminiPromptFeedback
+	var safetyRatings []geminiSafetyRating
+	var finishReason string
 	toolCallIndex := 0
 
 	for {
@@ -139,6 +144,10 @@ func (p *Gemini) processSSEStream(
 			usage = event.UsageMetadata
 		}
 
+		if event.PromptFeedback != nil {
+			promptFeedback = event.PromptFeedback
+		}
+
 		// Process candidates
 		if len(event.Candidates) == 0 {
 			continue
@@ -146,6 +155,14 @@ func (p *Gemini) processSSEStream(
 
 		candidate := event.Candidates[0]
 
+		// Ratings in later chunks cover the response so far
+		if len(candidate.SafetyRatings) > 0 {
+			safetyRatings = candidate.SafetyRatings
+		}
+		if candidate.FinishReason != "" {
+			finishReason = candidate.FinishReason
+		}
+
 		for _, part := range candidate.Content.Parts {
 			// Check if this is a thought part
 			if part.Thought != nil && *part.Thought {
@@ -158,6 +175,7 @@ func (p *Gemini) processSSEStream(
 			// Emit text delta
 			if part.Text != "" {
 				accumulatedText.WriteString(part.Text)
+				outputParts.AddText(part.Text)
 				select {
 				case chunkCh <- core.ChatChunk{Delta: part.Text}:
 				case <-ctx.Done():
@@ -166,6 +184,18 @@ func (p *Gemini) processSSEStream(
 				}
 			}
 
+			// Emit inline media as it arrives
+			if part.InlineData != nil {
+				media := mapInlineOutput(part.InlineData)
+				outputParts.Add(media)
+				select {
+				case chunkCh <- core.ChatChunk{Parts: []core.OutputPart{media}}:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+
 			// Accumulate tool calls
 			if part.FunctionCall != nil {
 				toolCalls = append(toolCalls, core.ToolCall{
@@ -183,7 +213,10 @@ func (p *Gemini) processSSEStream(
 		Model:     core.ModelID(model),
 		Output:    accumulatedText.String(),
 		ToolCalls: toolCalls,
+		Parts:     outputParts.Parts(),
+		Safety:    mapSafety(promptFeedback, safetyRatings, finishReason),
 	}
+	finalResp.FinishReason, finalResp.RawFinishReason = mapFinishReason(promptFeedback, finishReason, len(toolCalls) > 0)
 
 	if usage != nil {
 		finalResp.Usage = core.TokenUsage{
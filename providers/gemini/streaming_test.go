@@ -167,6 +167,101 @@ func TestDoStreamChatWithToolCall(t *testing.T) {
 	}
 }
 
+func TestDoStreamChatWithInlineImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+
+		events := []string{
+			`data: {"candidates":[{"content":{"parts":[{"text":"Here you go"}]}}]}`,
+			``,
+			`data: {"candidates":[{"content":{"parts":[{"inlineData":{"mimeType":"image/png","data":"aW1n"}}]},"finishReason":"STOP"}]}`,
+			``,
+		}
+
+		for _, line := range events {
+			w.Write([]byte(line + "\n"))
+		}
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model: "gemini-2.5-flash-image",
+		Messages: []core.Message{
+			{Role: core.RoleUser, Content: "Draw a cat"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	var streamed []core.OutputPart
+	for chunk := range stream.Ch {
+		streamed = append(streamed, chunk.Parts...)
+	}
+	if len(streamed) != 1 {
+		t.Fatalf("streamed parts = %d, want 1", len(streamed))
+	}
+	if img, ok := streamed[0].(core.OutputImage); !ok || img.Data != "aW1n" {
+		t.Errorf("streamed part = %#v, want OutputImage", streamed[0])
+	}
+
+	finalResp := <-stream.Final
+	if finalResp == nil {
+		t.Fatal("finalResp is nil")
+	}
+	if len(finalResp.Parts) != 2 {
+		t.Fatalf("final Parts = %d, want 2", len(finalResp.Parts))
+	}
+	if text, ok := finalResp.Parts[0].(core.OutputText); !ok || text.Text != "Here you go" {
+		t.Errorf("final Parts[0] = %#v, want OutputText", finalResp.Parts[0])
+	}
+}
+
+func TestDoStreamChatSafety(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+
+		events := []string{
+			`data: {"candidates":[{"content":{"parts":[{"text":"Hello"}]},"safetyRatings":[{"category":"HARM_CATEGORY_HARASSMENT","probability":"NEGLIGIBLE"}]}]}`,
+			``,
+			`data: {"candidates":[{"content":{"parts":[]},"finishReason":"SAFETY","safetyRatings":[{"category":"HARM_CATEGORY_HARASSMENT","probability":"HIGH","blocked":true}]}]}`,
+			``,
+		}
+		for _, line := range events {
+			w.Write([]byte(line + "\n"))
+		}
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model:    "gemini-2.5-flash",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hello"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	final, err := core.DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+	if final.Safety == nil || !final.Safety.Blocked || final.Safety.BlockReason != "SAFETY" {
+		t.Fatalf("Safety = %+v, want blocked response", final.Safety)
+	}
+	want := core.SafetyRating{Category: core.SafetyHarassment, Severity: core.SafetyHigh, Blocked: true}
+	if len(final.Safety.Ratings) != 1 || final.Safety.Ratings[0] != want {
+		t.Errorf("Ratings = %+v, want [%+v]", final.Safety.Ratings, want)
+	}
+	if final.FinishReason != core.FinishContentFilter || final.RawFinishReason != "SAFETY" {
+		t.Errorf("FinishReason = %q, RawFinishReason = %q, want content_filter, SAFETY", final.FinishReason, final.RawFinishReason)
+	}
+}
+
 func TestDoStreamChatError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
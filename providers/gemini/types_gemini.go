@@ -5,10 +5,22 @@ import "encoding/json"
 
 // geminiRequest represents a request to the Gemini generateContent API.
 type geminiRequest struct {
-	Contents          []geminiContent  `json:"contents"`
-	SystemInstruction *geminiContent   `json:"system_instruction,omitempty"`
-	GenerationConfig  *geminiGenConfig `json:"generationConfig,omitempty"`
-	Tools             []geminiTool     `json:"tools,omitempty"`
+	Contents          []geminiContent   `json:"contents"`
+	SystemInstruction *geminiContent    `json:"system_instruction,omitempty"`
+	GenerationConfig  *geminiGenConfig  `json:"generationConfig,omitempty"`
+	Tools             []geminiTool      `json:"tools,omitempty"`
+	ToolConfig        *geminiToolConfig `json:"toolConfig,omitempty"`
+}
+
+// geminiToolConfig configures function calling behavior.
+type geminiToolConfig struct {
+	FunctionCallingConfig geminiFunctionCallingConfig `json:"functionCallingConfig"`
+}
+
+// geminiFunctionCallingConfig selects the function calling mode.
+type geminiFunctionCallingConfig struct {
+	Mode                 string   `json:"mode"` // "AUTO", "ANY", "NONE"
+	AllowedFunctionNames []string `json:"allowedFunctionNames,omitempty"`
 }
 
 // geminiContent represents a content block (user or model turn).
@@ -75,14 +87,30 @@ type geminiFunctionResp struct {
 
 // geminiResponse represents a response from the Gemini API.
 type geminiResponse struct {
-	Candidates    []geminiCandidate `json:"candidates"`
-	UsageMetadata *geminiUsage      `json:"usageMetadata,omitempty"`
+	Candidates     []geminiCandidate     `json:"candidates"`
+	UsageMetadata  *geminiUsage          `json:"usageMetadata,omitempty"`
+	PromptFeedback *geminiPromptFeedback `json:"promptFeedback,omitempty"`
 }
 
 // geminiCandidate represents a response candidate.
 type geminiCandidate struct {
-	Content      geminiContent `json:"content"`
-	FinishReason string        `json:"finishReason,omitempty"`
+	Content       geminiContent        `json:"content"`
+	FinishReason  string               `json:"finishReason,omitempty"`
+	SafetyRatings []geminiSafetyRating `json:"safetyRatings,omitempty"`
+}
+
+// geminiPromptFeedback reports safety results for the prompt. BlockReason
+// is set when the prompt was blocked and no candidates were generated.
+type geminiPromptFeedback struct {
+	BlockReason   string               `json:"blockReason,omitempty"`
+	SafetyRatings []geminiSafetyRating `json:"safetyRatings,omitempty"`
+}
+
+// geminiSafetyRating is the probability of harm in one category.
+type geminiSafetyRating struct {
+	Category    string `json:"category"`
+	Probability string `json:"probability"`
+	Blocked     bool   `json:"blocked,omitempty"`
 }
 
 // geminiUsage tracks token usage.
@@ -3,6 +3,7 @@ package huggingface
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -47,8 +48,32 @@ type HubModelInfo struct {
 
 	// Inference is the inference status ("warm" or empty).
 	Inference string
+
+	// Author is the user or organization that owns the model.
+	Author string
+
+	// Downloads is the number of downloads in the last 30 days.
+	Downloads int
+
+	// Likes is the number of likes.
+	Likes int
+
+	// TrendingScore ranks models by recent activity.
+	TrendingScore float64
 }
 
+// ModelSort is the order in which ListModels returns models.
+type ModelSort string
+
+// Sort orders for ListModelsOptions. All sort in descending order.
+const (
+	SortDownloads    ModelSort = "downloads"
+	SortLikes        ModelSort = "likes"
+	SortTrending     ModelSort = "trendingScore"
+	SortLastModified ModelSort = "lastModified"
+	SortCreated      ModelSort = "createdAt"
+)
+
 // ListModelsOptions configures the ListModels query.
 type ListModelsOptions struct {
 	// Provider filters by inference provider.
@@ -58,15 +83,47 @@ type ListModelsOptions struct {
 	// PipelineTag filters by task type (e.g., "text-generation", "text-to-image").
 	PipelineTag string
 
-	// Limit is the maximum number of results to return.
+	// Author filters by the user or organization that owns the model
+	// (e.g., "meta-llama").
+	Author string
+
+	// Search filters by a substring of the model ID.
+	Search string
+
+	// Inference filters by inference status. Use ModelStatusWarm for models
+	// with at least one live inference provider.
+	Inference ModelStatus
+
+	// Sort orders the results, in descending order. Defaults to the Hub's
+	// own order.
+	Sort ModelSort
+
+	// Limit is the maximum number of results per page.
 	Limit int
+
+	// Cursor resumes a listing at the page ListModelsPage returned it for.
+	Cursor string
+}
+
+// ModelsPage is one page of ListModelsPage results.
+type ModelsPage struct {
+	// Models are the models on the page.
+	Models []HubModelInfo
+
+	// NextCursor is set in ListModelsOptions.Cursor to fetch the next page.
+	// It is empty on the last page.
+	NextCursor string
 }
 
 // hubModelInfoResponse represents the response from the models API.
 type hubModelInfoResponse struct {
-	ID          string `json:"id"`
-	PipelineTag string `json:"pipeline_tag"`
-	Inference   string `json:"inference"`
+	ID            string  `json:"id"`
+	PipelineTag   string  `json:"pipeline_tag"`
+	Inference     string  `json:"inference"`
+	Author        string  `json:"author"`
+	Downloads     int     `json:"downloads"`
+	Likes         int     `json:"likes"`
+	TrendingScore float64 `json:"trendingScore"`
 }
 
 // hubModelDetailResponse represents the detailed model info response.
@@ -171,25 +228,21 @@ func (p *HuggingFace) GetModelProviders(ctx context.Context, modelID string) ([]
 	return providers, nil
 }
 
-// ListModels queries available models with optional filters.
+// ListModels queries available models with optional filters. It returns
+// only the first page; use ListModelsPage or WalkModels to list more.
 func (p *HuggingFace) ListModels(ctx context.Context, opts ListModelsOptions) ([]HubModelInfo, error) {
-	// Build query parameters
-	params := url.Values{}
-
-	if opts.Provider != "" {
-		params.Set("inference_provider", opts.Provider)
-	}
-
-	if opts.PipelineTag != "" {
-		params.Set("pipeline_tag", opts.PipelineTag)
-	}
-
-	if opts.Limit > 0 {
-		params.Set("limit", strconv.Itoa(opts.Limit))
+	page, err := p.ListModelsPage(ctx, opts)
+	if err != nil {
+		return nil, err
 	}
+	return page.Models, nil
+}
 
+// ListModelsPage returns one page of models matching opts, starting at
+// opts.Cursor, and the cursor of the next page.
+func (p *HuggingFace) ListModelsPage(ctx context.Context, opts ListModelsOptions) (*ModelsPage, error) {
 	apiURL := p.hubAPIURL("/models")
-	if len(params) > 0 {
+	if params := listModelsParams(opts); len(params) > 0 {
 		apiURL = apiURL + "?" + params.Encode()
 	}
 
@@ -219,12 +272,97 @@ func (p *HuggingFace) ListModels(ctx context.Context, opts ListModelsOptions) ([
 		return nil, newDecodeError(err)
 	}
 
-	models := make([]HubModelInfo, len(results))
+	page := &ModelsPage{
+		Models:     make([]HubModelInfo, len(results)),
+		NextCursor: nextCursor(resp.Header.Get("Link")),
+	}
 	for i, r := range results {
-		models[i] = HubModelInfo(r)
+		page.Models[i] = HubModelInfo(r)
 	}
 
-	return models, nil
+	return page, nil
+}
+
+// WalkModels calls fn for every model matching opts, fetching pages of
+// opts.Limit models as needed. It stops at the first error from fn and
+// returns it, except that ErrStopWalk stops without an error.
+func (p *HuggingFace) WalkModels(ctx context.Context, opts ListModelsOptions, fn func(HubModelInfo) error) error {
+	for {
+		page, err := p.ListModelsPage(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, m := range page.Models {
+			if err := fn(m); err != nil {
+				if errors.Is(err, ErrStopWalk) {
+					return nil
+				}
+				return err
+			}
+		}
+		if page.NextCursor == "" || len(page.Models) == 0 {
+			return nil
+		}
+		opts.Cursor = page.NextCursor
+	}
+}
+
+// listModelsParams returns the query parameters of a models request.
+func listModelsParams(opts ListModelsOptions) url.Values {
+	params := url.Values{}
+
+	if opts.Provider != "" {
+		params.Set("inference_provider", opts.Provider)
+	}
+
+	if opts.PipelineTag != "" {
+		params.Set("pipeline_tag", opts.PipelineTag)
+	}
+
+	if opts.Author != "" {
+		params.Set("author", opts.Author)
+	}
+
+	if opts.Search != "" {
+		params.Set("search", opts.Search)
+	}
+
+	if opts.Inference != ModelStatusUnknown {
+		params.Set("inference", string(opts.Inference))
+	}
+
+	if opts.Sort != "" {
+		params.Set("sort", string(opts.Sort))
+		params.Set("direction", "-1")
+	}
+
+	if opts.Limit > 0 {
+		params.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
+	if opts.Cursor != "" {
+		params.Set("cursor", opts.Cursor)
+	}
+
+	return params
+}
+
+// nextCursor returns the cursor of the rel="next" URL in a Link header, or
+// "" if there is no next page.
+func nextCursor(link string) string {
+	for _, part := range strings.Split(link, ",") {
+		target, rel, ok := strings.Cut(part, ";")
+		if !ok || !strings.Contains(rel, `rel="next"`) {
+			continue
+		}
+		target = strings.Trim(strings.TrimSpace(target), "<>")
+		u, err := url.Parse(target)
+		if err != nil {
+			return ""
+		}
+		return u.Query().Get("cursor")
+	}
+	return ""
 }
 
 // String returns a human-readable representation of the model status.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -510,3 +511,155 @@ func TestHubAPIURL(t *testing.T) {
 		t.Errorf("hubAPIURL() with custom = %q, want %q", got, expected2)
 	}
 }
+
+func TestListModelsFilters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		want := map[string]string{
+			"author":    "meta-llama",
+			"search":    "Llama-3",
+			"inference": "warm",
+			"sort":      "trendingScore",
+			"direction": "-1",
+		}
+		for key, value := range want {
+			if query.Get(key) != value {
+				t.Errorf("%s param = %q, want %q", key, query.Get(key), value)
+			}
+		}
+		json.NewEncoder(w).Encode([]map[string]any{
+			{"id": "meta-llama/Llama-3-8B-Instruct", "author": "meta-llama", "downloads": 1200, "likes": 30, "trendingScore": 12.5},
+		})
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL), WithHubAPIBaseURL(server.URL+"/api"))
+	models, err := p.ListModels(context.Background(), ListModelsOptions{
+		Author:    "meta-llama",
+		Search:    "Llama-3",
+		Inference: ModelStatusWarm,
+		Sort:      SortTrending,
+	})
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(models) != 1 {
+		t.Fatalf("models count = %d, want 1", len(models))
+	}
+	m := models[0]
+	if m.Author != "meta-llama" || m.Downloads != 1200 || m.Likes != 30 || m.TrendingScore != 12.5 {
+		t.Errorf("model = %+v, want author, downloads, likes, and trending score", m)
+	}
+}
+
+// newPagedModelsServer returns a Hub server that lists ids in pages of two,
+// linking each page to the next with a cursor.
+func newPagedModelsServer(t *testing.T, ids []string) *httptest.Server {
+	t.Helper()
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pipeline_tag") != "text-generation" {
+			t.Errorf("pipeline_tag param = %q, want text-generation on every page", r.URL.Query().Get("pipeline_tag"))
+		}
+		start := 0
+		if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+			fmt.Sscanf(cursor, "page-%d", &start)
+		}
+		end := min(start+2, len(ids))
+		if end < len(ids) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/api/models?limit=2&cursor=page-%d>; rel="next"`, server.URL, end))
+		}
+		page := make([]map[string]any, 0, end-start)
+		for _, id := range ids[start:end] {
+			page = append(page, map[string]any{"id": id})
+		}
+		json.NewEncoder(w).Encode(page)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestListModelsPage(t *testing.T) {
+	server := newPagedModelsServer(t, []string{"a/1", "a/2", "a/3"})
+	p := New("test-key", WithBaseURL(server.URL), WithHubAPIBaseURL(server.URL+"/api"))
+
+	opts := ListModelsOptions{PipelineTag: "text-generation", Limit: 2}
+	page, err := p.ListModelsPage(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListModelsPage() error = %v", err)
+	}
+	if len(page.Models) != 2 || page.NextCursor != "page-2" {
+		t.Fatalf("page = %+v, want 2 models and cursor page-2", page)
+	}
+
+	opts.Cursor = page.NextCursor
+	page, err = p.ListModelsPage(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("ListModelsPage() error = %v", err)
+	}
+	if len(page.Models) != 1 || page.Models[0].ID != "a/3" || page.NextCursor != "" {
+		t.Errorf("last page = %+v, want a/3 and no cursor", page)
+	}
+}
+
+func TestWalkModels(t *testing.T) {
+	server := newPagedModelsServer(t, []string{"a/1", "a/2", "a/3", "a/4", "a/5"})
+	p := New("test-key", WithBaseURL(server.URL), WithHubAPIBaseURL(server.URL+"/api"))
+	opts := ListModelsOptions{PipelineTag: "text-generation", Limit: 2}
+
+	t.Run("all pages", func(t *testing.T) {
+		var ids []string
+		err := p.WalkModels(context.Background(), opts, func(m HubModelInfo) error {
+			ids = append(ids, m.ID)
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WalkModels() error = %v", err)
+		}
+		if len(ids) != 5 || ids[4] != "a/5" {
+			t.Errorf("ids = %v, want a/1 through a/5", ids)
+		}
+	})
+
+	t.Run("stop", func(t *testing.T) {
+		var ids []string
+		err := p.WalkModels(context.Background(), opts, func(m HubModelInfo) error {
+			ids = append(ids, m.ID)
+			if len(ids) == 3 {
+				return ErrStopWalk
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("WalkModels() error = %v", err)
+		}
+		if len(ids) != 3 {
+			t.Errorf("ids = %v, want 3", ids)
+		}
+	})
+
+	t.Run("callback error", func(t *testing.T) {
+		errBoom := errors.New("boom")
+		err := p.WalkModels(context.Background(), opts, func(HubModelInfo) error { return errBoom })
+		if !errors.Is(err, errBoom) {
+			t.Errorf("WalkModels() error = %v, want boom", err)
+		}
+	})
+}
+
+func TestNextCursor(t *testing.T) {
+	tests := []struct {
+		link string
+		want string
+	}{
+		{`<https://huggingface.co/api/models?limit=2&cursor=eyJfaWQiOnsiJGd0Ijo>; rel="next"`, "eyJfaWQiOnsiJGd0Ijo"},
+		{`<https://huggingface.co/api/models?cursor=prev>; rel="prev", <https://huggingface.co/api/models?cursor=next>; rel="next"`, "next"},
+		{`<https://huggingface.co/api/models?cursor=prev>; rel="prev"`, ""},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := nextCursor(tt.link); got != tt.want {
+			t.Errorf("nextCursor(%q) = %q, want %q", tt.link, got, tt.want)
+		}
+	}
+}
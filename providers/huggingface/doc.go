@@ -31,6 +31,45 @@
 //   - GetModelStatus: Check if a model has available inference providers
 //   - GetModelProviders: List providers serving a specific model
 //   - ListModels: Query available models with filters
+//   - ListModelsPage: Fetch one page of models and the cursor of the next
+//   - WalkModels: Visit every matching model across pages
+//
+// Models can be filtered by provider, task, author, search term, and
+// inference status, and sorted by downloads, likes, or trending score:
+//
+//	err := provider.WalkModels(ctx, huggingface.ListModelsOptions{
+//	    PipelineTag: "text-generation",
+//	    Inference:   huggingface.ModelStatusWarm,
+//	    Sort:        huggingface.SortTrending,
+//	}, func(m huggingface.HubModelInfo) error {
+//	    fmt.Println(m.ID, m.Downloads)
+//	    return nil
+//	})
+//
+// # Image Generation
+//
+// GenerateImage runs text-to-image models such as FLUX and SDXL. The model
+// suffix or provider policy selects the inference provider as for chat;
+// otherwise the first of hf-inference, fal-ai, together, and nebius that
+// serves the model is used:
+//
+//	resp, err := provider.GenerateImage(ctx, &core.ImageGenerateRequest{
+//	    Model:  "black-forest-labs/FLUX.1-schnell:fal-ai",
+//	    Prompt: "A lighthouse at dawn",
+//	})
+//
+// # Embeddings
+//
+// CreateEmbeddings runs feature-extraction models such as
+// sentence-transformers/all-MiniLM-L6-v2, routed the same way to
+// hf-inference, nebius, sambanova, or scaleway. Inputs are sent in batches,
+// token-level outputs are mean- or CLS-pooled, and vectors can be
+// normalized:
+//
+//	provider := huggingface.New("hf_xxxx",
+//	    huggingface.WithEmbeddingPooling(huggingface.PoolingMean),
+//	    huggingface.WithNormalizeEmbeddings(true),
+//	)
 //
 // # Authentication
 //
@@ -0,0 +1,198 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// DefaultEmbeddingBatchSize is the number of inputs sent per embedding
+// request when no batch size is configured.
+const DefaultEmbeddingBatchSize = 32
+
+// Pooling strategies for feature-extraction models that return one vector
+// per token instead of one per input.
+const (
+	// PoolingMean averages the token vectors (default).
+	PoolingMean = "mean"
+
+	// PoolingCLS uses the vector of the first ([CLS]) token.
+	PoolingCLS = "cls"
+)
+
+// embeddingProviders lists the supported feature-extraction providers in
+// order of preference.
+var embeddingProviders = []string{
+	ProviderHFInference,
+	ProviderNebius,
+	ProviderSambaNova,
+	ProviderScaleway,
+}
+
+// CreateEmbeddings generates embeddings with a feature-extraction model such
+// as sentence-transformers/all-MiniLM-L6-v2 or BAAI/bge-large-en-v1.5.
+//
+// The inference provider is chosen as for GenerateImage: by model suffix,
+// WithProviderPolicy, or the first of hf-inference, nebius, sambanova, and
+// scaleway that serves the model. Inputs are sent in batches of
+// WithEmbeddingBatchSize. Token-level outputs are pooled with
+// WithEmbeddingPooling, and vectors are L2-normalized when
+// WithNormalizeEmbeddings is set.
+func (p *HuggingFace) CreateEmbeddings(ctx context.Context, req *core.EmbeddingRequest) (*core.EmbeddingResponse, error) {
+	route, err := p.resolveRoute(ctx, req.Model, taskFeatureExtraction, embeddingProviders)
+	if err != nil {
+		return nil, err
+	}
+
+	batchSize := p.config.EmbeddingBatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultEmbeddingBatchSize
+	}
+
+	resp := &core.EmbeddingResponse{
+		Vectors: make([]core.EmbeddingVector, 0, len(req.Input)),
+		Model:   req.Model,
+	}
+	for start := 0; start < len(req.Input); start += batchSize {
+		batch := req.Input[start:min(start+batchSize, len(req.Input))]
+		texts := make([]string, len(batch))
+		for i, input := range batch {
+			texts[i] = input.Text
+		}
+
+		var vectors [][]float32
+		var usage core.EmbeddingUsage
+		if route.provider == ProviderHFInference {
+			vectors, err = p.embedHFInference(ctx, route, req, texts)
+		} else {
+			vectors, usage, err = p.embedOpenAIStyle(ctx, route, req, texts)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(vectors) != len(batch) {
+			return nil, &core.ProviderError{
+				Provider: "huggingface",
+				Message:  "embedding response has a different number of vectors than inputs",
+				Err:      core.ErrDecode,
+			}
+		}
+
+		for i, vec := range vectors {
+			if p.config.NormalizeEmbeddings {
+				normalizeVector(vec)
+			}
+			resp.Vectors = append(resp.Vectors, core.EmbeddingVector{
+				Index:    start + i,
+				ID:       batch[i].ID,
+				Vector:   vec,
+				Metadata: batch[i].Metadata,
+			})
+		}
+		resp.Usage.PromptTokens += usage.PromptTokens
+		resp.Usage.TotalTokens += usage.TotalTokens
+	}
+	return resp, nil
+}
+
+// embedHFInference embeds texts with HF Inference, which returns one vector
+// per text for sentence-transformers models and one vector per token for
+// other models.
+func (p *HuggingFace) embedHFInference(ctx context.Context, route inferenceRoute, req *core.EmbeddingRequest, texts []string) ([][]float32, error) {
+	body := hfFeatureExtractionRequest{Inputs: texts, Truncate: req.Truncation}
+	if p.config.NormalizeEmbeddings {
+		body.Normalize = &p.config.NormalizeEmbeddings
+	}
+
+	respBody, err := p.postRouted(ctx, "/hf-inference/models/"+route.providerID+"/pipeline/feature-extraction", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var pooled [][]float32
+	if err := json.Unmarshal(respBody, &pooled); err == nil {
+		return pooled, nil
+	}
+	var tokens [][][]float32
+	if err := json.Unmarshal(respBody, &tokens); err != nil {
+		return nil, newDecodeError(err)
+	}
+	vectors := make([][]float32, len(tokens))
+	for i, t := range tokens {
+		vectors[i] = poolTokens(t, p.config.EmbeddingPooling)
+	}
+	return vectors, nil
+}
+
+// embedOpenAIStyle embeds texts with providers that serve an OpenAI-style
+// embeddings endpoint.
+func (p *HuggingFace) embedOpenAIStyle(ctx context.Context, route inferenceRoute, req *core.EmbeddingRequest, texts []string) ([][]float32, core.EmbeddingUsage, error) {
+	body := openAIStyleEmbeddingRequest{
+		Model:          route.providerID,
+		Input:          texts,
+		Dimensions:     req.Dimensions,
+		EncodingFormat: string(core.EncodingFormatFloat),
+	}
+
+	respBody, err := p.postRouted(ctx, "/"+route.provider+"/v1/embeddings", body)
+	if err != nil {
+		return nil, core.EmbeddingUsage{}, err
+	}
+	var embResp openAIStyleEmbeddingResponse
+	if err := json.Unmarshal(respBody, &embResp); err != nil {
+		return nil, core.EmbeddingUsage{}, newDecodeError(err)
+	}
+
+	vectors := make([][]float32, len(embResp.Data))
+	for _, d := range embResp.Data {
+		if d.Index >= 0 && d.Index < len(vectors) {
+			vectors[d.Index] = d.Embedding
+		}
+	}
+	usage := core.EmbeddingUsage{
+		PromptTokens: embResp.Usage.PromptTokens,
+		TotalTokens:  embResp.Usage.TotalTokens,
+	}
+	return vectors, usage, nil
+}
+
+// poolTokens reduces token vectors to one vector with the given pooling
+// strategy. Unknown strategies use mean pooling.
+func poolTokens(tokens [][]float32, pooling string) []float32 {
+	if len(tokens) == 0 {
+		return nil
+	}
+	if pooling == PoolingCLS {
+		return tokens[0]
+	}
+	vec := make([]float32, len(tokens[0]))
+	for _, t := range tokens {
+		for j := range min(len(t), len(vec)) {
+			vec[j] += t[j]
+		}
+	}
+	for j := range vec {
+		vec[j] /= float32(len(tokens))
+	}
+	return vec
+}
+
+// normalizeVector scales vec to unit length in place.
+func normalizeVector(vec []float32) {
+	var sum float64
+	for _, v := range vec {
+		sum += float64(v) * float64(v)
+	}
+	if sum == 0 {
+		return
+	}
+	norm := float32(math.Sqrt(sum))
+	for i := range vec {
+		vec[i] /= norm
+	}
+}
+
+// Compile-time check that HuggingFace implements EmbeddingProvider.
+var _ core.EmbeddingProvider = (*HuggingFace)(nil)
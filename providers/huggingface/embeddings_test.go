@@ -0,0 +1,188 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// newEmbeddingServer returns a server that serves the Hub provider mapping
+// of sentence-transformers/all-MiniLM-L6-v2 under /api and the router under
+// the root, with route handling router requests.
+func newEmbeddingServer(t *testing.T, mapping map[string]any, route http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/models/sentence-transformers/all-MiniLM-L6-v2" {
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":                       "sentence-transformers/all-MiniLM-L6-v2",
+				"inferenceProviderMapping": mapping,
+			})
+			return
+		}
+		route(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func embeddingInputs(texts ...string) []core.EmbeddingInput {
+	inputs := make([]core.EmbeddingInput, len(texts))
+	for i, text := range texts {
+		inputs[i] = core.EmbeddingInput{Text: text, ID: "doc-" + text}
+	}
+	return inputs
+}
+
+func TestCreateEmbeddingsHFInferenceBatches(t *testing.T) {
+	var batches [][]string
+	server := newEmbeddingServer(t, map[string]any{
+		"hf-inference": map[string]any{"providerId": "sentence-transformers/all-MiniLM-L6-v2", "status": "live", "task": "feature-extraction"},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/hf-inference/models/sentence-transformers/all-MiniLM-L6-v2/pipeline/feature-extraction" {
+			t.Errorf("Path = %q, want feature-extraction pipeline", r.URL.Path)
+		}
+		var body hfFeatureExtractionRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if body.Normalize == nil || !*body.Normalize {
+			t.Errorf("Normalize = %v, want true", body.Normalize)
+		}
+		batches = append(batches, body.Inputs)
+
+		vectors := make([][]float32, len(body.Inputs))
+		for i := range vectors {
+			vectors[i] = []float32{3, 4}
+		}
+		json.NewEncoder(w).Encode(vectors)
+	})
+
+	p := New("test-key",
+		WithBaseURL(server.URL),
+		WithHubAPIBaseURL(server.URL+"/api"),
+		WithEmbeddingBatchSize(2),
+		WithNormalizeEmbeddings(true),
+	)
+	resp, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model: "sentence-transformers/all-MiniLM-L6-v2",
+		Input: embeddingInputs("a", "b", "c"),
+	})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings() error = %v", err)
+	}
+
+	if len(batches) != 2 || len(batches[0]) != 2 || len(batches[1]) != 1 {
+		t.Errorf("batches = %v, want sizes 2 and 1", batches)
+	}
+	if len(resp.Vectors) != 3 {
+		t.Fatalf("len(Vectors) = %d, want 3", len(resp.Vectors))
+	}
+	last := resp.Vectors[2]
+	if last.Index != 2 || last.ID != "doc-c" {
+		t.Errorf("Vectors[2] = {Index: %d, ID: %q}, want {2, doc-c}", last.Index, last.ID)
+	}
+	if math.Abs(float64(last.Vector[0])-0.6) > 1e-6 || math.Abs(float64(last.Vector[1])-0.8) > 1e-6 {
+		t.Errorf("Vector = %v, want normalized [0.6 0.8]", last.Vector)
+	}
+}
+
+func TestCreateEmbeddingsPooling(t *testing.T) {
+	server := newEmbeddingServer(t, map[string]any{
+		"hf-inference": map[string]any{"providerId": "sentence-transformers/all-MiniLM-L6-v2", "status": "live", "task": "feature-extraction"},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([][][]float32{{{1, 2}, {3, 6}}})
+	})
+
+	tests := []struct {
+		name    string
+		pooling string
+		want    []float32
+	}{
+		{"mean", PoolingMean, []float32{2, 4}},
+		{"default", "", []float32{2, 4}},
+		{"cls", PoolingCLS, []float32{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := New("test-key",
+				WithBaseURL(server.URL),
+				WithHubAPIBaseURL(server.URL+"/api"),
+				WithEmbeddingPooling(tt.pooling),
+			)
+			resp, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+				Model: "sentence-transformers/all-MiniLM-L6-v2",
+				Input: embeddingInputs("a"),
+			})
+			if err != nil {
+				t.Fatalf("CreateEmbeddings() error = %v", err)
+			}
+			got := resp.Vectors[0].Vector
+			if len(got) != 2 || got[0] != tt.want[0] || got[1] != tt.want[1] {
+				t.Errorf("Vector = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateEmbeddingsOpenAIStyle(t *testing.T) {
+	server := newEmbeddingServer(t, map[string]any{
+		"hf-inference": map[string]any{"providerId": "sentence-transformers/all-MiniLM-L6-v2", "status": "live", "task": "feature-extraction"},
+		"nebius":       map[string]any{"providerId": "BAAI/bge-en-icl", "status": "live", "task": "feature-extraction"},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/nebius/v1/embeddings" {
+			t.Errorf("Path = %q, want /nebius/v1/embeddings", r.URL.Path)
+		}
+		var body openAIStyleEmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if body.Model != "BAAI/bge-en-icl" {
+			t.Errorf("Model = %q, want BAAI/bge-en-icl", body.Model)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{
+				{"index": 1, "embedding": []float32{0.3}},
+				{"index": 0, "embedding": []float32{0.1}},
+			},
+			"usage": map[string]any{"prompt_tokens": 4, "total_tokens": 4},
+		})
+	})
+
+	p := New("test-key", WithBaseURL(server.URL), WithHubAPIBaseURL(server.URL+"/api"), WithProviderPolicy(ProviderNebius))
+	resp, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model: "sentence-transformers/all-MiniLM-L6-v2",
+		Input: embeddingInputs("a", "b"),
+	})
+	if err != nil {
+		t.Fatalf("CreateEmbeddings() error = %v", err)
+	}
+	if resp.Vectors[0].Vector[0] != 0.1 || resp.Vectors[1].Vector[0] != 0.3 {
+		t.Errorf("Vectors = %+v, want ordered by index", resp.Vectors)
+	}
+	if resp.Usage.TotalTokens != 4 {
+		t.Errorf("Usage.TotalTokens = %d, want 4", resp.Usage.TotalTokens)
+	}
+}
+
+func TestCreateEmbeddingsNotSupported(t *testing.T) {
+	server := newEmbeddingServer(t, map[string]any{
+		"hf-inference": map[string]any{"providerId": "sentence-transformers/all-MiniLM-L6-v2", "status": "live", "task": "sentence-similarity"},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected router request to %q", r.URL.Path)
+	})
+
+	p := New("test-key", WithBaseURL(server.URL), WithHubAPIBaseURL(server.URL+"/api"))
+	_, err := p.CreateEmbeddings(context.Background(), &core.EmbeddingRequest{
+		Model: "sentence-transformers/all-MiniLM-L6-v2",
+		Input: embeddingInputs("a"),
+	})
+	if !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("CreateEmbeddings() error = %v, want ErrNotSupported", err)
+	}
+}
@@ -9,6 +9,10 @@ import (
 // ErrToolArgsInvalidJSON is returned when tool call arguments contain invalid JSON.
 var ErrToolArgsInvalidJSON = errors.New("tool args invalid json")
 
+// ErrStopWalk is returned by a WalkModels callback to stop listing models
+// without an error.
+var ErrStopWalk = errors.New("stop walk")
+
 // normalizeError converts an HTTP error response to a ProviderError with the appropriate sentinel.
 func normalizeError(status int, body []byte, requestID string) error {
 	return normalize.OpenAIStyleProviderError("huggingface", status, body, requestID)
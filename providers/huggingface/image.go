@@ -0,0 +1,191 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// imageProviders lists the supported text-to-image providers in order of
+// preference.
+var imageProviders = []string{
+	ProviderHFInference,
+	ProviderFalAI,
+	ProviderTogether,
+	ProviderNebius,
+}
+
+// GenerateImage generates images with a text-to-image model such as
+// black-forest-labs/FLUX.1-schnell or stabilityai/stable-diffusion-xl-base-1.0.
+//
+// Like chat, the inference provider can be chosen with a model suffix
+// ("black-forest-labs/FLUX.1-schnell:fal-ai") or WithProviderPolicy.
+// Otherwise the first of hf-inference, fal-ai, together, and nebius that
+// serves the model is used. The provider's model ID is looked up with the
+// Hub API and cached.
+//
+// Size is sent as width and height. hf-inference returns one image per
+// request, so N images take N requests. fal-ai returns image URLs, which
+// are downloaded when ResponseFormat is "b64_json"; the other providers
+// return base64 data.
+func (p *HuggingFace) GenerateImage(ctx context.Context, req *core.ImageGenerateRequest) (*core.ImageResponse, error) {
+	route, err := p.resolveRoute(ctx, req.Model, taskTextToImage, imageProviders)
+	if err != nil {
+		return nil, err
+	}
+
+	width, height := imageDimensions(req.Size)
+	var resp *core.ImageResponse
+	switch route.provider {
+	case ProviderHFInference:
+		resp, err = p.generateHFInference(ctx, route, req, width, height)
+	case ProviderFalAI:
+		resp, err = p.generateFalAI(ctx, route, req, width, height)
+	default:
+		resp, err = p.generateOpenAIStyle(ctx, route, req, width, height)
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp.Created = time.Now().Unix()
+	return resp, nil
+}
+
+// EditImage is not supported by Hugging Face Inference Providers.
+func (p *HuggingFace) EditImage(ctx context.Context, req *core.ImageEditRequest) (*core.ImageResponse, error) {
+	return nil, &core.ProviderError{
+		Provider: "huggingface",
+		Message:  "image editing is not supported by Hugging Face Inference Providers",
+		Err:      core.ErrNotSupported,
+	}
+}
+
+// StreamImage generates images and delivers them as an ImageStream with no
+// partial images, since text-to-image providers do not stream.
+func (p *HuggingFace) StreamImage(ctx context.Context, req *core.ImageGenerateRequest) (*core.ImageStream, error) {
+	return core.ImageStreamFromFunc(ctx, func(ctx context.Context) (*core.ImageResponse, error) {
+		return p.GenerateImage(ctx, req)
+	}), nil
+}
+
+// generateHFInference generates images with HF Inference, which returns the
+// raw bytes of one image per request.
+func (p *HuggingFace) generateHFInference(ctx context.Context, route inferenceRoute, req *core.ImageGenerateRequest, width, height int) (*core.ImageResponse, error) {
+	body := hfInferenceImageRequest{Inputs: req.Prompt}
+	if width > 0 {
+		body.Parameters = &hfInferenceImageParams{Width: width, Height: height}
+	}
+
+	n := max(req.N, 1)
+	resp := &core.ImageResponse{Data: make([]core.ImageData, 0, n)}
+	for range n {
+		data, err := p.postRouted(ctx, "/hf-inference/models/"+route.providerID, body)
+		if err != nil {
+			return nil, err
+		}
+		resp.Data = append(resp.Data, core.ImageData{B64JSON: base64.StdEncoding.EncodeToString(data)})
+	}
+	return resp, nil
+}
+
+// generateFalAI generates images with fal.ai, which returns image URLs.
+func (p *HuggingFace) generateFalAI(ctx context.Context, route inferenceRoute, req *core.ImageGenerateRequest, width, height int) (*core.ImageResponse, error) {
+	body := falImageRequest{Prompt: req.Prompt}
+	if req.N > 1 {
+		body.NumImages = req.N
+	}
+	if width > 0 {
+		body.ImageSize = &falImageSize{Width: width, Height: height}
+	}
+	switch req.Format {
+	case core.ImageFormatPNG, core.ImageFormatJPEG:
+		body.OutputFormat = string(req.Format)
+	}
+
+	respBody, err := p.postRouted(ctx, "/fal-ai/"+route.providerID, body)
+	if err != nil {
+		return nil, err
+	}
+	var falResp falImageResponse
+	if err := json.Unmarshal(respBody, &falResp); err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	resp := &core.ImageResponse{Data: make([]core.ImageData, len(falResp.Images))}
+	for i, img := range falResp.Images {
+		if req.ResponseFormat != "b64_json" {
+			resp.Data[i] = core.ImageData{URL: img.URL}
+			continue
+		}
+		data, err := p.downloadImage(ctx, img.URL)
+		if err != nil {
+			return nil, err
+		}
+		resp.Data[i] = core.ImageData{B64JSON: base64.StdEncoding.EncodeToString(data)}
+	}
+	return resp, nil
+}
+
+// generateOpenAIStyle generates images with providers that serve an
+// OpenAI-style images endpoint (Together and Nebius).
+func (p *HuggingFace) generateOpenAIStyle(ctx context.Context, route inferenceRoute, req *core.ImageGenerateRequest, width, height int) (*core.ImageResponse, error) {
+	body := openAIStyleImageRequest{
+		Model:          route.providerID,
+		Prompt:         req.Prompt,
+		Width:          width,
+		Height:         height,
+		ResponseFormat: "b64_json",
+	}
+	if req.N > 1 {
+		body.N = req.N
+	}
+	if route.provider == ProviderTogether {
+		body.ResponseFormat = "base64"
+	}
+
+	respBody, err := p.postRouted(ctx, "/"+route.provider+"/v1/images/generations", body)
+	if err != nil {
+		return nil, err
+	}
+	var imgResp openAIStyleImageResponse
+	if err := json.Unmarshal(respBody, &imgResp); err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	resp := &core.ImageResponse{Data: make([]core.ImageData, len(imgResp.Data))}
+	for i, img := range imgResp.Data {
+		resp.Data[i] = core.ImageData{B64JSON: img.B64JSON, URL: img.URL}
+	}
+	return resp, nil
+}
+
+// downloadImage fetches an image returned by URL. The Hugging Face token is
+// not sent, since the image is hosted by the inference provider.
+func (p *HuggingFace) downloadImage(ctx context.Context, url string) ([]byte, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	return p.doRoutedRequest(httpReq)
+}
+
+// imageDimensions parses an image size such as "1024x1536" into width and
+// height. It returns zeros for ImageSizeAuto and unset sizes.
+func imageDimensions(size core.ImageSize) (width, height int) {
+	w, h, ok := strings.Cut(string(size), "x")
+	if !ok {
+		return 0, 0
+	}
+	width, errW := strconv.Atoi(w)
+	height, errH := strconv.Atoi(h)
+	if errW != nil || errH != nil {
+		return 0, 0
+	}
+	return width, height
+}
@@ -0,0 +1,258 @@
+package huggingface
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// newImageServer returns a server that serves the Hub provider mapping of a
+// model under /api and the router under the root, with route handling
+// router requests.
+func newImageServer(t *testing.T, mapping map[string]any, route http.HandlerFunc) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/models/black-forest-labs/FLUX.1-schnell" {
+			if r.URL.Query().Get("expand") != "inferenceProviderMapping" {
+				t.Errorf("expand param = %q, want inferenceProviderMapping", r.URL.Query().Get("expand"))
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"id":                       "black-forest-labs/FLUX.1-schnell",
+				"inferenceProviderMapping": mapping,
+			})
+			return
+		}
+		route(w, r)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGenerateImageHFInference(t *testing.T) {
+	var calls atomic.Int32
+	server := newImageServer(t, map[string]any{
+		"hf-inference": map[string]any{"providerId": "black-forest-labs/FLUX.1-schnell", "status": "live", "task": "text-to-image"},
+		"fal-ai":       map[string]any{"providerId": "fal-ai/flux/schnell", "status": "live", "task": "text-to-image"},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Path != "/hf-inference/models/black-forest-labs/FLUX.1-schnell" {
+			t.Errorf("Path = %q, want /hf-inference/models/black-forest-labs/FLUX.1-schnell", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("Authorization = %q, want Bearer test-key", r.Header.Get("Authorization"))
+		}
+
+		var body hfInferenceImageRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if body.Inputs != "a red fox" {
+			t.Errorf("Inputs = %q, want a red fox", body.Inputs)
+		}
+		if body.Parameters == nil || body.Parameters.Width != 1024 || body.Parameters.Height != 768 {
+			t.Errorf("Parameters = %+v, want 1024x768", body.Parameters)
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		w.Write([]byte("png-bytes"))
+	})
+
+	p := New("test-key", WithBaseURL(server.URL), WithHubAPIBaseURL(server.URL+"/api"))
+	resp, err := p.GenerateImage(context.Background(), &core.ImageGenerateRequest{
+		Model:  "black-forest-labs/FLUX.1-schnell",
+		Prompt: "a red fox",
+		Size:   "1024x768",
+		N:      2,
+	})
+	if err != nil {
+		t.Fatalf("GenerateImage() error = %v", err)
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("router calls = %d, want 2", calls.Load())
+	}
+	if len(resp.Data) != 2 {
+		t.Fatalf("len(Data) = %d, want 2", len(resp.Data))
+	}
+	want := base64.StdEncoding.EncodeToString([]byte("png-bytes"))
+	if resp.Data[0].B64JSON != want {
+		t.Errorf("B64JSON = %q, want %q", resp.Data[0].B64JSON, want)
+	}
+}
+
+func TestGenerateImageFalAI(t *testing.T) {
+	var server *httptest.Server
+	server = newImageServer(t, map[string]any{
+		"fal-ai": map[string]any{"providerId": "fal-ai/flux/schnell", "status": "live", "task": "text-to-image"},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/fal-ai/fal-ai/flux/schnell":
+			var body falImageRequest
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			if body.Prompt != "a red fox" {
+				t.Errorf("Prompt = %q, want a red fox", body.Prompt)
+			}
+			if body.ImageSize != nil {
+				t.Errorf("ImageSize = %+v, want nil for auto size", body.ImageSize)
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"images": []map[string]any{{"url": server.URL + "/files/fox.png", "content_type": "image/png"}},
+			})
+		case "/files/fox.png":
+			if r.Header.Get("Authorization") != "" {
+				t.Errorf("Authorization = %q, want none for image download", r.Header.Get("Authorization"))
+			}
+			w.Write([]byte("fox"))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	})
+
+	p := New("test-key", WithBaseURL(server.URL), WithHubAPIBaseURL(server.URL+"/api"))
+
+	t.Run("url", func(t *testing.T) {
+		resp, err := p.GenerateImage(context.Background(), &core.ImageGenerateRequest{
+			Model:  "black-forest-labs/FLUX.1-schnell",
+			Prompt: "a red fox",
+			Size:   core.ImageSizeAuto,
+		})
+		if err != nil {
+			t.Fatalf("GenerateImage() error = %v", err)
+		}
+		if len(resp.Data) != 1 || resp.Data[0].URL != server.URL+"/files/fox.png" {
+			t.Errorf("Data = %+v, want one image URL", resp.Data)
+		}
+	})
+
+	t.Run("b64_json", func(t *testing.T) {
+		resp, err := p.GenerateImage(context.Background(), &core.ImageGenerateRequest{
+			Model:          "black-forest-labs/FLUX.1-schnell",
+			Prompt:         "a red fox",
+			ResponseFormat: "b64_json",
+		})
+		if err != nil {
+			t.Fatalf("GenerateImage() error = %v", err)
+		}
+		want := base64.StdEncoding.EncodeToString([]byte("fox"))
+		if len(resp.Data) != 1 || resp.Data[0].B64JSON != want {
+			t.Errorf("Data = %+v, want downloaded image", resp.Data)
+		}
+	})
+}
+
+func TestGenerateImageProviderSuffix(t *testing.T) {
+	server := newImageServer(t, map[string]any{
+		"hf-inference": map[string]any{"providerId": "black-forest-labs/FLUX.1-schnell", "status": "live", "task": "text-to-image"},
+		"together":     map[string]any{"providerId": "black-forest-labs/FLUX.1-schnell-Free", "status": "live", "task": "text-to-image"},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/together/v1/images/generations" {
+			t.Errorf("Path = %q, want /together/v1/images/generations", r.URL.Path)
+		}
+		var body openAIStyleImageRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if body.Model != "black-forest-labs/FLUX.1-schnell-Free" {
+			t.Errorf("Model = %q, want black-forest-labs/FLUX.1-schnell-Free", body.Model)
+		}
+		if body.ResponseFormat != "base64" {
+			t.Errorf("ResponseFormat = %q, want base64", body.ResponseFormat)
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": []map[string]any{{"b64_json": "aW1n"}},
+		})
+	})
+
+	p := New("test-key", WithBaseURL(server.URL), WithHubAPIBaseURL(server.URL+"/api"))
+	resp, err := p.GenerateImage(context.Background(), &core.ImageGenerateRequest{
+		Model:  "black-forest-labs/FLUX.1-schnell:together",
+		Prompt: "a red fox",
+	})
+	if err != nil {
+		t.Fatalf("GenerateImage() error = %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].B64JSON != "aW1n" {
+		t.Errorf("Data = %+v, want one base64 image", resp.Data)
+	}
+}
+
+func TestGenerateImageNotSupported(t *testing.T) {
+	server := newImageServer(t, map[string]any{
+		"hf-inference": map[string]any{"providerId": "black-forest-labs/FLUX.1-schnell", "status": "staging", "task": "text-to-image"},
+		"replicate":    map[string]any{"providerId": "black-forest-labs/flux-schnell", "status": "live", "task": "text-to-image"},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected router request to %q", r.URL.Path)
+	})
+
+	p := New("test-key", WithBaseURL(server.URL), WithHubAPIBaseURL(server.URL+"/api"))
+
+	tests := []struct {
+		name  string
+		model core.ModelID
+	}{
+		{"no live supported provider", "black-forest-labs/FLUX.1-schnell"},
+		{"unsupported suffix", "black-forest-labs/FLUX.1-schnell:replicate"},
+		{"provider not serving model", "black-forest-labs/FLUX.1-schnell:nebius"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.GenerateImage(context.Background(), &core.ImageGenerateRequest{Model: tt.model, Prompt: "a red fox"})
+			if !errors.Is(err, core.ErrNotSupported) {
+				t.Errorf("GenerateImage() error = %v, want ErrNotSupported", err)
+			}
+		})
+	}
+}
+
+func TestGenerateImageError(t *testing.T) {
+	server := newImageServer(t, map[string]any{
+		"nebius": map[string]any{"providerId": "black-forest-labs/flux-schnell", "status": "live", "task": "text-to-image"},
+	}, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error": {"message": "rate limit exceeded"}}`))
+	})
+
+	p := New("test-key", WithBaseURL(server.URL), WithHubAPIBaseURL(server.URL+"/api"))
+	_, err := p.GenerateImage(context.Background(), &core.ImageGenerateRequest{
+		Model:  "black-forest-labs/FLUX.1-schnell",
+		Prompt: "a red fox",
+	})
+	if !errors.Is(err, core.ErrRateLimited) {
+		t.Errorf("GenerateImage() error = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestEditImageNotSupported(t *testing.T) {
+	p := New("test-key")
+	_, err := p.EditImage(context.Background(), &core.ImageEditRequest{Prompt: "add a hat"})
+	if !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("EditImage() error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestImageDimensions(t *testing.T) {
+	tests := []struct {
+		size          core.ImageSize
+		width, height int
+	}{
+		{"1024x1536", 1024, 1536},
+		{core.ImageSizeAuto, 0, 0},
+		{"", 0, 0},
+		{"widexhigh", 0, 0},
+	}
+	for _, tt := range tests {
+		w, h := imageDimensions(tt.size)
+		if w != tt.width || h != tt.height {
+			t.Errorf("imageDimensions(%q) = %d, %d, want %d, %d", tt.size, w, h, tt.width, tt.height)
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
+	"github.com/petal-labs/iris/providers/internal/toolchoice"
 	"github.com/petal-labs/iris/tools"
 )
 
@@ -76,7 +78,7 @@ func buildRequest(req *core.ChatRequest, model string, stream bool) *hfRequest {
 	// Map tools if present
 	if len(req.Tools) > 0 {
 		hfReq.Tools = mapTools(req.Tools)
-		hfReq.ToolChoice = "auto"
+		hfReq.ToolChoice = toolchoice.OpenAI(req.ToolChoice)
 	}
 
 	return hfReq
@@ -97,6 +99,8 @@ func mapResponse(resp *hfResponse) (*core.ChatResponse, error) {
 	// Extract content from first choice
 	if len(resp.Choices) > 0 {
 		choice := resp.Choices[0]
+		result.FinishReason = normalize.FinishReason(choice.FinishReason)
+		result.RawFinishReason = choice.FinishReason
 		result.Output = choice.Message.Content
 
 		// Map tool calls if present
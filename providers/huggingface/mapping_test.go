@@ -224,6 +224,9 @@ func TestMapResponse(t *testing.T) {
 		if result.Usage.TotalTokens != 30 {
 			t.Errorf("TotalTokens = %d, want 30", result.Usage.TotalTokens)
 		}
+		if result.FinishReason != core.FinishStop || result.RawFinishReason != "stop" {
+			t.Errorf("FinishReason = %q, RawFinishReason = %q, want stop", result.FinishReason, result.RawFinishReason)
+		}
 	})
 
 	t.Run("with tool calls", func(t *testing.T) {
@@ -2,6 +2,7 @@ package huggingface
 
 import (
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/petal-labs/iris/core"
@@ -40,9 +41,33 @@ type Config struct {
 	// Defaults to https://huggingface.co/api
 	HubAPIBaseURL string
 
-	// HTTPClient is the HTTP client to use. Defaults to http.DefaultClient.
+	// HTTPClient is the HTTP client to use. Defaults to a client with pooled
+	// connections shared by all providers. When set, Transport and Proxy are
+	// ignored.
 	HTTPClient *http.Client
 
+	// Transport replaces the default transport, for example to tune
+	// connection pooling or add instrumentation.
+	Transport http.RoundTripper
+
+	// Proxy routes requests through a proxy instead of the one configured by
+	// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY.
+	Proxy *url.URL
+
+	// Credentials, if set, supplies the API key for each request instead of
+	// APIKey, so keys can rotate without a restart.
+	Credentials core.CredentialProvider
+
+	// PathPrefix is inserted before the path of every request URL, for
+	// gateways that route by path.
+	PathPrefix string
+
+	// QueryParams are set on every request URL.
+	QueryParams url.Values
+
+	// RequestEditors are called on every request before it is sent.
+	RequestEditors []func(*http.Request)
+
 	// Headers contains optional extra headers to include in requests.
 	Headers http.Header
 
@@ -54,6 +79,17 @@ type Config struct {
 	// provider name (e.g., "cerebras", "together", "groq").
 	// This can be overridden per-request by appending a suffix to the model name.
 	ProviderPolicy string
+
+	// EmbeddingBatchSize is the number of inputs sent per embedding request.
+	// Defaults to DefaultEmbeddingBatchSize.
+	EmbeddingBatchSize int
+
+	// EmbeddingPooling reduces token-level feature-extraction outputs to one
+	// vector per input: PoolingMean (default) or PoolingCLS.
+	EmbeddingPooling string
+
+	// NormalizeEmbeddings scales embedding vectors to unit length.
+	NormalizeEmbeddings bool
 }
 
 // Option configures the Hugging Face provider.
@@ -73,6 +109,63 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// WithTransport sets the transport used by the default HTTP client.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Config) {
+		c.Transport = rt
+	}
+}
+
+// WithProxy routes requests through the proxy at proxyURL.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Config) {
+		c.Proxy = proxyURL
+	}
+}
+
+// WithCredentials resolves the API key from creds for every request instead
+// of using a fixed key. A request rejected with 401 is retried once after
+// refreshing creds, if it implements core.CredentialRefresher.
+func WithCredentials(creds core.CredentialProvider) Option {
+	return func(c *Config) {
+		c.Credentials = creds
+	}
+}
+
+// WithPathPrefix inserts prefix before the path of every request URL, for
+// gateways such as LiteLLM or Portkey that route by path. With prefix
+// "/team-a", a request for https://gateway.example.com/v1/chat/completions
+// is sent to https://gateway.example.com/team-a/v1/chat/completions.
+func WithPathPrefix(prefix string) Option {
+	return func(c *Config) {
+		c.PathPrefix = prefix
+	}
+}
+
+// WithQueryParams sets query parameters on every request URL, such as a
+// version or routing parameter required by a gateway. Repeated calls add to
+// the parameters.
+func WithQueryParams(params url.Values) Option {
+	return func(c *Config) {
+		if c.QueryParams == nil {
+			c.QueryParams = make(url.Values)
+		}
+		for name, values := range params {
+			c.QueryParams[name] = append(c.QueryParams[name], values...)
+		}
+	}
+}
+
+// WithRequestEditor calls fn on every request before it is sent, after
+// authentication and other headers are set. fn may modify the request but
+// must not read or replace its body. Repeated calls add editors, which run
+// in order.
+func WithRequestEditor(fn func(*http.Request)) Option {
+	return func(c *Config) {
+		c.RequestEditors = append(c.RequestEditors, fn)
+	}
+}
+
 // WithHeader adds an extra header to include in requests.
 func WithHeader(key, value string) Option {
 	return func(c *Config) {
@@ -83,7 +176,8 @@ func WithHeader(key, value string) Option {
 	}
 }
 
-// WithTimeout sets the request timeout.
+// WithTimeout sets the default request timeout, which core.Client applies
+// to requests that have no context deadline or Timeout of their own.
 func WithTimeout(d time.Duration) Option {
 	return func(c *Config) {
 		c.Timeout = d
@@ -100,6 +194,30 @@ func WithProviderPolicy(policy string) Option {
 	}
 }
 
+// WithEmbeddingBatchSize sets the number of inputs sent per embedding
+// request. Larger inputs are split across several requests.
+func WithEmbeddingBatchSize(n int) Option {
+	return func(c *Config) {
+		c.EmbeddingBatchSize = n
+	}
+}
+
+// WithEmbeddingPooling sets how token-level feature-extraction outputs are
+// reduced to one vector per input: PoolingMean or PoolingCLS.
+func WithEmbeddingPooling(pooling string) Option {
+	return func(c *Config) {
+		c.EmbeddingPooling = pooling
+	}
+}
+
+// WithNormalizeEmbeddings scales embedding vectors to unit length, so their
+// dot product is their cosine similarity.
+func WithNormalizeEmbeddings(normalize bool) Option {
+	return func(c *Config) {
+		c.NormalizeEmbeddings = normalize
+	}
+}
+
 // WithHubAPIBaseURL sets the Hub API base URL for model discovery.
 // This is primarily useful for testing.
 func WithHubAPIBaseURL(url string) Option {
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 // Environment variable names for the Hugging Face API token.
@@ -43,6 +46,9 @@ func NewFromEnv(opts ...Option) (*HuggingFace, error) {
 // HuggingFace is safe for concurrent use.
 type HuggingFace struct {
 	config Config
+
+	// routes caches non-chat routes by task, model, and provider.
+	routes sync.Map // string -> inferenceRoute
 }
 
 // New creates a new Hugging Face provider with the given API key and options.
@@ -52,12 +58,22 @@ func New(apiKey string, opts ...Option) *HuggingFace {
 		APIKey:        core.NewSecret(apiKey),
 		BaseURL:       DefaultBaseURL,
 		HubAPIBaseURL: HubAPIBaseURL,
-		HTTPClient:    http.DefaultClient,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+	}
+	cfg.HTTPClient = httpclient.WithRewrite(cfg.HTTPClient, httpclient.Rewrite{
+		PathPrefix: cfg.PathPrefix,
+		Query:      cfg.QueryParams,
+		Editors:    cfg.RequestEditors,
+	})
+	if cfg.Credentials != nil {
+		cfg.HTTPClient = httpclient.WithCredentials(cfg.HTTPClient, cfg.Credentials, httpclient.BearerAuth)
+	}
 
 	return &HuggingFace{config: cfg}
 }
@@ -67,16 +83,28 @@ func (p *HuggingFace) ID() string {
 	return "huggingface"
 }
 
+// CloseIdleConnections closes the idle pooled connections of the provider's
+// HTTP client. core.Client.Close calls it during shutdown.
+func (p *HuggingFace) CloseIdleConnections() {
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
 // Models returns an empty list since Hugging Face supports thousands of
 // user-specified models. Use ListModels() to discover available models.
 func (p *HuggingFace) Models() []core.ModelInfo {
 	return []core.ModelInfo{}
 }
 
+// DefaultTimeout returns the timeout set with WithTimeout. core.Client
+// applies it to requests that have no deadline or Timeout of their own.
+func (p *HuggingFace) DefaultTimeout() time.Duration {
+	return p.config.Timeout
+}
+
 // Supports reports whether the provider supports the given feature.
 func (p *HuggingFace) Supports(feature core.Feature) bool {
 	switch feature {
-	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling:
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureImageGeneration, core.FeatureEmbeddings:
 		return true
 	default:
 		return false
@@ -111,5 +139,14 @@ func (p *HuggingFace) StreamChat(ctx context.Context, req *core.ChatRequest) (*c
 	return p.doStreamChat(ctx, req)
 }
 
-// Compile-time check that HuggingFace implements Provider.
-var _ core.Provider = (*HuggingFace)(nil)
+// Compile-time checks that HuggingFace implements Provider and ImageGenerator.
+var (
+	_ core.Provider       = (*HuggingFace)(nil)
+	_ core.ImageGenerator = (*HuggingFace)(nil)
+)
+
+// Compile-time check that HuggingFace implements TimeoutProvider.
+var _ core.TimeoutProvider = (*HuggingFace)(nil)
+
+// Compile-time check that HuggingFace implements IdleConnectionCloser.
+var _ core.IdleConnectionCloser = (*HuggingFace)(nil)
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 func TestHuggingFaceImplementsProvider(t *testing.T) {
@@ -25,8 +26,8 @@ func TestNew(t *testing.T) {
 		if p.config.BaseURL != DefaultBaseURL {
 			t.Errorf("BaseURL = %q, want %q", p.config.BaseURL, DefaultBaseURL)
 		}
-		if p.config.HTTPClient != http.DefaultClient {
-			t.Error("HTTPClient should be http.DefaultClient")
+		if p.config.HTTPClient != httpclient.Default() {
+			t.Error("HTTPClient should be the shared default client")
 		}
 	})
 
@@ -88,8 +89,8 @@ func TestSupports(t *testing.T) {
 		{core.FeatureChatStreaming, true},
 		{core.FeatureToolCalling, true},
 		{core.FeatureReasoning, false},
-		{core.FeatureImageGeneration, false},
-		{core.FeatureEmbeddings, false},
+		{core.FeatureImageGeneration, true},
+		{core.FeatureEmbeddings, true},
 		{core.Feature("unknown"), false},
 	}
 
@@ -9,4 +9,18 @@ func init() {
 	providers.Register("huggingface", func(apiKey string) core.Provider {
 		return New(apiKey)
 	})
+
+	core.RegisterProvider("huggingface", func(cfg core.ProviderConfig) (core.Provider, error) {
+		var opts []Option
+		apiKey := cfg.APIKeyOrEnv(HFTokenEnvVar, HuggingFaceTokenEnvVar)
+		if cfg.Credentials != nil {
+			opts = append(opts, WithCredentials(cfg.Credentials))
+		} else if apiKey == "" {
+			return nil, ErrAPIKeyNotFound
+		}
+		if cfg.BaseURL != "" {
+			opts = append(opts, WithBaseURL(cfg.BaseURL))
+		}
+		return New(apiKey, opts...), nil
+	})
 }
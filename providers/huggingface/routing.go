@@ -0,0 +1,150 @@
+package huggingface
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// Inference providers that non-chat requests can be routed to. Chat
+// requests accept any provider name, since the router serves them all.
+const (
+	ProviderHFInference = "hf-inference"
+	ProviderFalAI       = "fal-ai"
+	ProviderTogether    = "together"
+	ProviderNebius      = "nebius"
+	ProviderSambaNova   = "sambanova"
+	ProviderScaleway    = "scaleway"
+)
+
+// Hub tasks of the provider mappings used to route non-chat requests.
+const (
+	taskTextToImage       = "text-to-image"
+	taskFeatureExtraction = "feature-extraction"
+)
+
+// inferenceRoute is the inference provider and provider model ID a
+// non-chat request is sent to.
+type inferenceRoute struct {
+	provider   string
+	providerID string
+}
+
+// resolveRoute picks the inference provider for a task and looks up the
+// provider's model ID. The provider comes from the model suffix or the
+// provider policy; otherwise the first of supported that serves the model
+// is used. Routes are cached, since the Hub mapping rarely changes.
+func (p *HuggingFace) resolveRoute(ctx context.Context, model core.ModelID, task string, supported []string) (inferenceRoute, error) {
+	modelID, provider, _ := strings.Cut(string(model), ":")
+	if provider == "" {
+		provider = p.config.ProviderPolicy
+	}
+	switch provider {
+	case "", PolicyAuto, PolicyFastest, PolicyCheapest:
+		provider = ""
+	default:
+		if !containsString(supported, provider) {
+			return inferenceRoute{}, &core.ProviderError{
+				Provider: "huggingface",
+				Message:  fmt.Sprintf("%s is not supported for provider %q (supported: %s)", task, provider, strings.Join(supported, ", ")),
+				Err:      core.ErrNotSupported,
+			}
+		}
+	}
+
+	key := task + ":" + modelID + ":" + provider
+	if route, ok := p.routes.Load(key); ok {
+		return route.(inferenceRoute), nil
+	}
+
+	mappings, err := p.GetModelProviders(ctx, modelID)
+	if err != nil {
+		return inferenceRoute{}, err
+	}
+	route, ok := pickRoute(mappings, task, provider, supported)
+	if !ok {
+		msg := fmt.Sprintf("no supported inference provider serves %s for %s", modelID, task)
+		if provider != "" {
+			msg = fmt.Sprintf("%s does not serve %s for %s", provider, modelID, task)
+		}
+		return inferenceRoute{}, &core.ProviderError{Provider: "huggingface", Message: msg, Err: core.ErrNotSupported}
+	}
+	p.routes.Store(key, route)
+	return route, nil
+}
+
+// pickRoute returns the live mapping for task of provider, or of the first
+// of supported if provider is empty.
+func pickRoute(mappings []InferenceProvider, task, provider string, supported []string) (inferenceRoute, bool) {
+	byName := make(map[string]InferenceProvider, len(mappings))
+	for _, m := range mappings {
+		if m.Task == task && m.Status == "live" {
+			byName[m.Name] = m
+		}
+	}
+	candidates := supported
+	if provider != "" {
+		candidates = []string{provider}
+	}
+	for _, name := range candidates {
+		if m, ok := byName[name]; ok {
+			return inferenceRoute{provider: name, providerID: m.ProviderID}, true
+		}
+	}
+	return inferenceRoute{}, false
+}
+
+// containsString reports whether s is in list.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// postRouted sends a JSON request to an inference provider through the
+// router and returns the response body.
+func (p *HuggingFace) postRouted(ctx context.Context, path string, body any) ([]byte, error) {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.BaseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	return p.doRoutedRequest(httpReq)
+}
+
+// doRoutedRequest sends req and returns the body of a successful response.
+func (p *HuggingFace) doRoutedRequest(req *http.Request) ([]byte, error) {
+	resp, err := p.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+	return respBody, nil
+}
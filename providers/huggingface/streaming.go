@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
 	"github.com/petal-labs/iris/providers/internal/toolcalls"
 )
 
@@ -124,6 +125,7 @@ func (p *HuggingFace) processSSEStream(
 	var responseID string
 	var responseModel string
 	var usage *hfUsage
+	var finishReason string
 
 	for {
 		// Check for context cancellation
@@ -184,6 +186,10 @@ func (p *HuggingFace) processSSEStream(
 
 		// Process choices
 		for _, choice := range chunk.Choices {
+			if choice.FinishReason != nil && *choice.FinishReason != "" {
+				finishReason = *choice.FinishReason
+			}
+
 			// Emit content delta
 			if choice.Delta.Content != "" {
 				select {
@@ -210,9 +216,11 @@ func (p *HuggingFace) processSSEStream(
 
 	// Build final response
 	finalResp := &core.ChatResponse{
-		ID:        responseID,
-		Model:     core.ModelID(responseModel),
-		ToolCalls: toolCalls,
+		ID:              responseID,
+		Model:           core.ModelID(responseModel),
+		ToolCalls:       toolCalls,
+		FinishReason:    normalize.FinishReason(finishReason),
+		RawFinishReason: finishReason,
 	}
 
 	if usage != nil {
@@ -11,7 +11,7 @@ type hfRequest struct {
 	MaxTokens   *int        `json:"max_tokens,omitempty"`
 	Stream      bool        `json:"stream"`
 	Tools       []hfTool    `json:"tools,omitempty"`
-	ToolChoice  string      `json:"tool_choice,omitempty"`
+	ToolChoice  any         `json:"tool_choice,omitempty"`
 }
 
 // hfMessage represents a message in the HF format.
@@ -115,3 +115,93 @@ type hfStreamFunction struct {
 	Name      string `json:"name,omitempty"`
 	Arguments string `json:"arguments,omitempty"`
 }
+
+// Image generation types
+
+// hfInferenceImageRequest is the request body of an HF Inference
+// text-to-image model.
+type hfInferenceImageRequest struct {
+	Inputs     string                  `json:"inputs"`
+	Parameters *hfInferenceImageParams `json:"parameters,omitempty"`
+}
+
+// hfInferenceImageParams are the optional parameters of an HF Inference
+// text-to-image request.
+type hfInferenceImageParams struct {
+	Width  int `json:"width,omitempty"`
+	Height int `json:"height,omitempty"`
+}
+
+// falImageRequest is the request body of a fal.ai text-to-image model.
+type falImageRequest struct {
+	Prompt       string        `json:"prompt"`
+	NumImages    int           `json:"num_images,omitempty"`
+	ImageSize    *falImageSize `json:"image_size,omitempty"`
+	OutputFormat string        `json:"output_format,omitempty"` // "jpeg" or "png"
+}
+
+// falImageSize is the size of a fal.ai image.
+type falImageSize struct {
+	Width  int `json:"width"`
+	Height int `json:"height"`
+}
+
+// falImageResponse is the response of a fal.ai text-to-image model.
+type falImageResponse struct {
+	Images []struct {
+		URL         string `json:"url"`
+		ContentType string `json:"content_type"`
+	} `json:"images"`
+}
+
+// openAIStyleImageRequest is the request body of the OpenAI-style images
+// endpoints served by Together and Nebius.
+type openAIStyleImageRequest struct {
+	Model          string `json:"model"`
+	Prompt         string `json:"prompt"`
+	N              int    `json:"n,omitempty"`
+	Width          int    `json:"width,omitempty"`
+	Height         int    `json:"height,omitempty"`
+	ResponseFormat string `json:"response_format,omitempty"`
+}
+
+// openAIStyleImageResponse is the response of an OpenAI-style images
+// endpoint.
+type openAIStyleImageResponse struct {
+	Data []struct {
+		B64JSON string `json:"b64_json"`
+		URL     string `json:"url"`
+	} `json:"data"`
+}
+
+// Embedding types
+
+// hfFeatureExtractionRequest is the request body of an HF Inference
+// feature-extraction model.
+type hfFeatureExtractionRequest struct {
+	Inputs    []string `json:"inputs"`
+	Normalize *bool    `json:"normalize,omitempty"`
+	Truncate  *bool    `json:"truncate,omitempty"`
+}
+
+// openAIStyleEmbeddingRequest is the request body of the OpenAI-style
+// embeddings endpoints served by inference providers.
+type openAIStyleEmbeddingRequest struct {
+	Model          string   `json:"model"`
+	Input          []string `json:"input"`
+	Dimensions     *int     `json:"dimensions,omitempty"`
+	EncodingFormat string   `json:"encoding_format,omitempty"`
+}
+
+// openAIStyleEmbeddingResponse is the response of an OpenAI-style
+// embeddings endpoint.
+type openAIStyleEmbeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Usage struct {
+		PromptTokens int `json:"prompt_tokens"`
+		TotalTokens  int `json:"total_tokens"`
+	} `json:"usage"`
+}
@@ -0,0 +1,93 @@
+package httpclient
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+)
+
+// CompressionThreshold is the smallest request body Compress compresses.
+// Smaller bodies are not worth the CPU time.
+const CompressionThreshold = 16 << 10
+
+// Compress returns a transport that sends JSON request bodies of at least
+// minSize bytes gzip-compressed with Content-Encoding: gzip. Only use it for
+// APIs that accept compressed requests. A nil rt uses http.DefaultTransport.
+//
+// Responses need no wrapper: http.Transport requests gzip and decompresses
+// the body, including streams, as it is read.
+func Compress(rt http.RoundTripper, minSize int) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &compressTransport{base: rt, minSize: int64(minSize)}
+}
+
+// WithCompression returns a copy of c whose transport is wrapped by Compress
+// with CompressionThreshold.
+func WithCompression(c *http.Client) *http.Client {
+	cc := *c
+	cc.Transport = Compress(c.Transport, CompressionThreshold)
+	return &cc
+}
+
+type compressTransport struct {
+	base    http.RoundTripper
+	minSize int64
+}
+
+func (t *compressTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.shouldCompress(req) {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	out := req.Clone(req.Context())
+	if buf.Len() < len(body) {
+		body = buf.Bytes()
+		out.Header.Set("Content-Encoding", "gzip")
+	}
+	out.ContentLength = int64(len(body))
+	out.Body = io.NopCloser(bytes.NewReader(body))
+	out.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+	return t.base.RoundTrip(out)
+}
+
+// CloseIdleConnections forwards to the base transport, so
+// http.Client.CloseIdleConnections reaches the wrapped connection pool.
+func (t *compressTransport) CloseIdleConnections() {
+	if ci, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		ci.CloseIdleConnections()
+	}
+}
+
+// shouldCompress reports whether req has a JSON body large enough to
+// compress that is not already encoded.
+func (t *compressTransport) shouldCompress(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody || req.ContentLength < t.minSize {
+		return false
+	}
+	if req.Header.Get("Content-Encoding") != "" {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/json"
+}
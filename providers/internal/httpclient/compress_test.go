@@ -0,0 +1,146 @@
+package httpclient
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// echoServer records the Content-Encoding of each request and returns the
+// decompressed body.
+func echoServer(t *testing.T, encoding *string) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*encoding = r.Header.Get("Content-Encoding")
+		var body io.Reader = r.Body
+		if *encoding == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			body = zr
+		}
+		data, err := io.ReadAll(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func post(t *testing.T, c *http.Client, url, contentType, body string) string {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	defer resp.Body.Close()
+	got, _ := io.ReadAll(resp.Body)
+	return string(got)
+}
+
+func TestCompress(t *testing.T) {
+	var encoding string
+	srv := echoServer(t, &encoding)
+	c := &http.Client{Transport: Compress(nil, 1024)}
+
+	large := `{"data":"` + strings.Repeat("a", 4096) + `"}`
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		want        string
+	}{
+		{"large JSON", "application/json; charset=utf-8", large, "gzip"},
+		{"small JSON", "application/json", `{"data":"a"}`, ""},
+		{"large binary", "application/octet-stream", large, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := post(t, c, srv.URL, tt.contentType, tt.body)
+			if encoding != tt.want {
+				t.Errorf("Content-Encoding = %q, want %q", encoding, tt.want)
+			}
+			if got != tt.body {
+				t.Errorf("server received %d bytes, want %d", len(got), len(tt.body))
+			}
+		})
+	}
+}
+
+func TestCompressKeepsIncompressibleBody(t *testing.T) {
+	var encoding string
+	srv := echoServer(t, &encoding)
+	c := &http.Client{Transport: Compress(nil, 1)}
+
+	// Too short for gzip to shrink
+	body := `{}`
+	if got := post(t, c, srv.URL, "application/json", body); got != body {
+		t.Errorf("server received %q, want %q", got, body)
+	}
+	if encoding != "" {
+		t.Errorf("Content-Encoding = %q, want none", encoding)
+	}
+}
+
+func TestWithCompression(t *testing.T) {
+	c := WithCompression(Default())
+	if c == Default() {
+		t.Error("WithCompression should return a copy")
+	}
+	if _, ok := Default().Transport.(*compressTransport); ok {
+		t.Error("WithCompression should not modify the original client")
+	}
+	if _, ok := c.Transport.(*compressTransport); !ok {
+		t.Errorf("Transport = %T, want *compressTransport", c.Transport)
+	}
+}
+
+func TestGzipStreamDecodedIncrementally(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+			t.Error("client should accept gzip")
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte("data: first\n\n"))
+		zw.Flush()
+		w.(http.Flusher).Flush()
+
+		<-release
+		zw.Write([]byte("data: second\n\n"))
+		zw.Close()
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	resp, err := Default().Get(srv.URL)
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	defer resp.Body.Close()
+
+	// The first event must be readable while the server is still streaming
+	line, err := bufio.NewReader(resp.Body).ReadString('\n')
+	if err != nil {
+		t.Fatalf("ReadString() error = %v", err)
+	}
+	if line != "data: first\n" {
+		t.Errorf("line = %q, want %q", line, "data: first\n")
+	}
+	if !resp.Uncompressed {
+		t.Error("response should be decompressed by the transport")
+	}
+}
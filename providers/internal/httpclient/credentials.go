@@ -0,0 +1,110 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// SetAuth writes a credential to request headers.
+type SetAuth func(h http.Header, key string)
+
+// BearerAuth sets the Authorization header to a bearer token.
+func BearerAuth(h http.Header, key string) {
+	h.Set("Authorization", "Bearer "+key)
+}
+
+// HeaderAuth returns a SetAuth that sets the named header to the key.
+func HeaderAuth(name string) SetAuth {
+	return func(h http.Header, key string) {
+		h.Set(name, key)
+	}
+}
+
+// Authenticate returns a transport that resolves the credential from creds
+// for every request and writes it with set, replacing any static key. When
+// the API answers 401 and creds is a core.CredentialRefresher, the
+// credential is refreshed and the request retried once with the new key, if
+// the key changed and the body can be replayed. A nil rt uses
+// http.DefaultTransport.
+func Authenticate(rt http.RoundTripper, creds core.CredentialProvider, set SetAuth) http.RoundTripper {
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	return &authTransport{base: rt, creds: creds, set: set}
+}
+
+// WithCredentials returns a copy of c whose transport is wrapped by
+// Authenticate.
+func WithCredentials(c *http.Client, creds core.CredentialProvider, set SetAuth) *http.Client {
+	cc := *c
+	cc.Transport = Authenticate(c.Transport, creds, set)
+	return &cc
+}
+
+type authTransport struct {
+	base  http.RoundTripper
+	creds core.CredentialProvider
+	set   SetAuth
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	key, err := t.creds.Credential(ctx)
+	if err != nil {
+		closeBody(req)
+		return nil, err
+	}
+
+	resp, err := t.base.RoundTrip(t.authorize(req, key))
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	refresher, ok := t.creds.(core.CredentialRefresher)
+	if !ok || (req.Body != nil && req.GetBody == nil) {
+		return resp, nil
+	}
+	if refresher.Refresh(ctx) != nil {
+		return resp, nil
+	}
+	newKey, err := t.creds.Credential(ctx)
+	if err != nil || newKey.Expose() == key.Expose() {
+		return resp, nil
+	}
+
+	retry := t.authorize(req, newKey)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+		retry.Body = body
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+	return t.base.RoundTrip(retry)
+}
+
+// CloseIdleConnections forwards to the base transport, so
+// http.Client.CloseIdleConnections reaches the wrapped connection pool.
+func (t *authTransport) CloseIdleConnections() {
+	if ci, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		ci.CloseIdleConnections()
+	}
+}
+
+// authorize returns a copy of req carrying key. RoundTrippers must not
+// modify the caller's request.
+func (t *authTransport) authorize(req *http.Request, key core.Secret) *http.Request {
+	r := req.Clone(req.Context())
+	t.set(r.Header, key.Expose())
+	return r
+}
+
+func closeBody(req *http.Request) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+}
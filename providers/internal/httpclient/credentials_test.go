@@ -0,0 +1,171 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// rotatingCredential returns old until refreshed and new afterwards.
+type rotatingCredential struct {
+	mu        sync.Mutex
+	old, new  string
+	refreshed bool
+}
+
+func (c *rotatingCredential) Credential(context.Context) (core.Secret, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.refreshed {
+		return core.NewSecret(c.new), nil
+	}
+	return core.NewSecret(c.old), nil
+}
+
+func (c *rotatingCredential) Refresh(context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.refreshed = true
+	return nil
+}
+
+// keyServer accepts only the bearer token "sk-valid" and echoes the body.
+func keyServer(t *testing.T, attempts *int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*attempts++
+		body, _ := io.ReadAll(r.Body)
+		if r.Header.Get("Authorization") != "Bearer sk-valid" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func postBody(t *testing.T, c *http.Client, url string) *http.Response {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodPost, url, strings.NewReader(`{"model":"m"}`))
+	req.Header.Set("Authorization", "Bearer sk-static")
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+func TestAuthenticateReplacesStaticKey(t *testing.T) {
+	attempts := 0
+	srv := keyServer(t, &attempts)
+	c := WithCredentials(srv.Client(), core.StaticCredential(core.NewSecret("sk-valid")), BearerAuth)
+
+	if resp := postBody(t, c, srv.URL); resp.StatusCode != http.StatusOK {
+		t.Errorf("status = %d, want 200", resp.StatusCode)
+	}
+}
+
+func TestAuthenticateRetriesWithRefreshedKey(t *testing.T) {
+	attempts := 0
+	srv := keyServer(t, &attempts)
+	creds := &rotatingCredential{old: "sk-revoked", new: "sk-valid"}
+	c := WithCredentials(srv.Client(), creds, BearerAuth)
+
+	resp := postBody(t, c, srv.URL)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != `{"model":"m"}` {
+		t.Errorf("retried body = %q, want the original body", body)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+}
+
+func TestAuthenticateDoesNotRetryUnchangedKey(t *testing.T) {
+	attempts := 0
+	srv := keyServer(t, &attempts)
+	creds := &rotatingCredential{old: "sk-revoked", new: "sk-revoked"}
+	c := WithCredentials(srv.Client(), creds, BearerAuth)
+
+	if resp := postBody(t, c, srv.URL); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", resp.StatusCode)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestAuthenticateWithoutRefresherDoesNotRetry(t *testing.T) {
+	attempts := 0
+	srv := keyServer(t, &attempts)
+	c := WithCredentials(srv.Client(), core.StaticCredential(core.NewSecret("sk-revoked")), BearerAuth)
+
+	if resp := postBody(t, c, srv.URL); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %d, want 401", resp.StatusCode)
+	}
+	if attempts != 1 {
+		t.Errorf("attempts = %d, want 1", attempts)
+	}
+}
+
+func TestAuthenticateCredentialError(t *testing.T) {
+	attempts := 0
+	srv := keyServer(t, &attempts)
+	c := WithCredentials(srv.Client(), core.EnvCredential("IRIS_TEST_UNSET_KEY"), BearerAuth)
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	_, err := c.Do(req)
+	if !errors.Is(err, core.ErrNoCredential) {
+		t.Errorf("Do() error = %v, want ErrNoCredential", err)
+	}
+	if attempts != 0 {
+		t.Errorf("attempts = %d, want no request", attempts)
+	}
+}
+
+// idleTransport counts CloseIdleConnections calls.
+type idleTransport struct {
+	http.RoundTripper
+	closed int
+}
+
+func (t *idleTransport) CloseIdleConnections() { t.closed++ }
+
+func TestAuthenticateCloseIdleConnections(t *testing.T) {
+	base := &idleTransport{RoundTripper: http.DefaultTransport}
+	c := WithCredentials(&http.Client{Transport: base}, core.StaticCredential(core.NewSecret("sk-valid")), BearerAuth)
+
+	c.CloseIdleConnections()
+	if base.closed != 1 {
+		t.Errorf("base CloseIdleConnections calls = %d, want 1", base.closed)
+	}
+
+	// A base transport without the method is skipped
+	WithCredentials(&http.Client{Transport: roundTripperOnly{}}, core.StaticCredential(core.NewSecret("sk-valid")), BearerAuth).CloseIdleConnections()
+}
+
+type roundTripperOnly struct{}
+
+func (roundTripperOnly) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("unused")
+}
+
+func TestHeaderAuth(t *testing.T) {
+	h := http.Header{}
+	HeaderAuth("x-api-key")(h, "sk-ant")
+	if h.Get("x-api-key") != "sk-ant" {
+		t.Errorf("x-api-key = %q", h.Get("x-api-key"))
+	}
+}
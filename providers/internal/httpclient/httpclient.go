@@ -0,0 +1,79 @@
+// Package httpclient builds the HTTP clients used by providers, with
+// connection pooling, HTTP/2, and connection-level timeouts.
+//
+// The clients have no overall request timeout because streaming responses
+// and long reasoning requests can legitimately run for minutes. Request
+// deadlines come from the context or the provider's WithTimeout option.
+package httpclient
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Transport defaults.
+const (
+	DialTimeout           = 30 * time.Second
+	KeepAlive             = 30 * time.Second
+	TLSHandshakeTimeout   = 10 * time.Second
+	IdleConnTimeout       = 90 * time.Second
+	ExpectContinueTimeout = 1 * time.Second
+	MaxIdleConns          = 100
+	MaxIdleConnsPerHost   = 16
+)
+
+// NewTransport returns a transport with the package defaults. Proxies are
+// taken from HTTPS_PROXY, HTTP_PROXY, and NO_PROXY.
+func NewTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   DialTimeout,
+		KeepAlive: KeepAlive,
+	}
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          MaxIdleConns,
+		MaxIdleConnsPerHost:   MaxIdleConnsPerHost,
+		IdleConnTimeout:       IdleConnTimeout,
+		TLSHandshakeTimeout:   TLSHandshakeTimeout,
+		ExpectContinueTimeout: ExpectContinueTimeout,
+	}
+}
+
+var defaultClient = sync.OnceValue(func() *http.Client {
+	return &http.Client{Transport: NewTransport()}
+})
+
+// Default returns the client shared by all providers that are not given a
+// transport or proxy, so they share one connection pool.
+func Default() *http.Client {
+	return defaultClient()
+}
+
+// New returns the client for a provider. rt, if not nil, replaces the
+// transport. proxy, if not nil, routes requests through that URL; it applies
+// to the default transport or to rt if rt is an *http.Transport, which is
+// cloned first. With neither, Default is returned.
+func New(rt http.RoundTripper, proxy *url.URL) *http.Client {
+	if rt == nil && proxy == nil {
+		return Default()
+	}
+	if proxy != nil {
+		t, ok := rt.(*http.Transport)
+		switch {
+		case rt == nil:
+			t = NewTransport()
+		case ok:
+			t = t.Clone()
+		}
+		if t != nil {
+			t.Proxy = http.ProxyURL(proxy)
+			rt = t
+		}
+	}
+	return &http.Client{Transport: rt}
+}
@@ -0,0 +1,129 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+	iristest "github.com/petal-labs/iris/testing"
+)
+
+func TestDefaultIsShared(t *testing.T) {
+	if Default() != Default() {
+		t.Error("Default() should return the same client")
+	}
+	if New(nil, nil) != Default() {
+		t.Error("New(nil, nil) should return Default()")
+	}
+	if Default() == http.DefaultClient {
+		t.Error("Default() should not be http.DefaultClient")
+	}
+}
+
+func TestNewTransport(t *testing.T) {
+	tr := NewTransport()
+	if !tr.ForceAttemptHTTP2 {
+		t.Error("ForceAttemptHTTP2 should be true")
+	}
+	if tr.MaxIdleConnsPerHost != MaxIdleConnsPerHost {
+		t.Errorf("MaxIdleConnsPerHost = %d, want %d", tr.MaxIdleConnsPerHost, MaxIdleConnsPerHost)
+	}
+	if tr.TLSHandshakeTimeout != TLSHandshakeTimeout {
+		t.Errorf("TLSHandshakeTimeout = %v, want %v", tr.TLSHandshakeTimeout, TLSHandshakeTimeout)
+	}
+	if tr.Proxy == nil {
+		t.Error("Proxy should read the environment")
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestNewWithTransport(t *testing.T) {
+	rt := roundTripperFunc(func(*http.Request) (*http.Response, error) { return nil, nil })
+	c := New(rt, nil)
+	if _, ok := c.Transport.(roundTripperFunc); !ok {
+		t.Errorf("Transport = %T, want roundTripperFunc", c.Transport)
+	}
+
+	// A proxy cannot be applied to a custom RoundTripper
+	proxy, _ := url.Parse("http://proxy.internal:3128")
+	c = New(rt, proxy)
+	if _, ok := c.Transport.(roundTripperFunc); !ok {
+		t.Errorf("Transport = %T, want roundTripperFunc", c.Transport)
+	}
+}
+
+func TestNewWithProxy(t *testing.T) {
+	proxy, _ := url.Parse("http://proxy.internal:3128")
+	req, _ := http.NewRequest(http.MethodGet, "https://api.example.com", nil)
+
+	c := New(nil, proxy)
+	tr, ok := c.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", c.Transport)
+	}
+	got, err := tr.Proxy(req)
+	if err != nil || got.String() != proxy.String() {
+		t.Errorf("Proxy = %v, %v; want %v", got, err, proxy)
+	}
+
+	// A custom *http.Transport is cloned, not modified
+	base := &http.Transport{MaxIdleConnsPerHost: 4}
+	c = New(base, proxy)
+	tr = c.Transport.(*http.Transport)
+	if tr == base {
+		t.Error("transport should be cloned")
+	}
+	if base.Proxy != nil {
+		t.Error("original transport should not be modified")
+	}
+	if tr.MaxIdleConnsPerHost != 4 {
+		t.Errorf("MaxIdleConnsPerHost = %d, want 4", tr.MaxIdleConnsPerHost)
+	}
+	if got, _ := tr.Proxy(req); got.String() != proxy.String() {
+		t.Errorf("Proxy = %v, want %v", got, proxy)
+	}
+}
+
+// pooledProvider is a provider whose connection pool is c, like the HTTP
+// providers built on this package.
+type pooledProvider struct {
+	core.Provider
+	c *http.Client
+}
+
+func (p pooledProvider) CloseIdleConnections() { p.c.CloseIdleConnections() }
+
+func TestClientCloseReachesBaseTransport(t *testing.T) {
+	wrappers := map[string]func(*http.Client) *http.Client{
+		"compress": WithCompression,
+		"rewrite": func(c *http.Client) *http.Client {
+			return WithRewrite(c, Rewrite{PathPrefix: "/api"})
+		},
+		"credentials": func(c *http.Client) *http.Client {
+			return WithCredentials(c, core.StaticCredential(core.NewSecret("sk-test")), BearerAuth)
+		},
+		"stacked": func(c *http.Client) *http.Client {
+			c = WithCredentials(c, core.StaticCredential(core.NewSecret("sk-test")), BearerAuth)
+			return WithCompression(WithRewrite(c, Rewrite{PathPrefix: "/api"}))
+		},
+	}
+
+	for name, wrap := range wrappers {
+		t.Run(name, func(t *testing.T) {
+			base := &idleTransport{RoundTripper: http.DefaultTransport}
+			p := pooledProvider{Provider: iristest.NewMockProvider(), c: wrap(&http.Client{Transport: base})}
+
+			if err := core.NewClient(p).Close(context.Background()); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+			if base.closed != 1 {
+				t.Errorf("base CloseIdleConnections calls = %d, want 1", base.closed)
+			}
+		})
+	}
+}
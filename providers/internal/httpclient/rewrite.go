@@ -0,0 +1,81 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Rewrite describes changes made to every request before it is sent, for
+// routing through gateways such as LiteLLM or Portkey.
+type Rewrite struct {
+	// PathPrefix is inserted before the path of the request URL.
+	PathPrefix string
+
+	// Query parameters are set on every request, replacing any of the same
+	// name.
+	Query url.Values
+
+	// Editors are called in order after the other changes, with headers
+	// (including authentication) already set.
+	Editors []func(*http.Request)
+}
+
+// IsZero reports whether rw changes nothing.
+func (rw Rewrite) IsZero() bool {
+	return strings.Trim(rw.PathPrefix, "/") == "" && len(rw.Query) == 0 && len(rw.Editors) == 0
+}
+
+// WithRewrite returns a copy of c whose transport applies rw to every
+// request. It returns c if rw changes nothing.
+func WithRewrite(c *http.Client, rw Rewrite) *http.Client {
+	if rw.IsZero() {
+		return c
+	}
+	base := c.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	rw.PathPrefix = "/" + strings.Trim(rw.PathPrefix, "/")
+	if rw.PathPrefix == "/" {
+		rw.PathPrefix = ""
+	}
+	cc := *c
+	cc.Transport = &rewriteTransport{base: base, rw: rw}
+	return &cc
+}
+
+type rewriteTransport struct {
+	base http.RoundTripper
+	rw   Rewrite
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// RoundTrippers must not modify the caller's request.
+	r := req.Clone(req.Context())
+	if t.rw.PathPrefix != "" {
+		r.URL.Path = t.rw.PathPrefix + r.URL.Path
+		if r.URL.RawPath != "" {
+			r.URL.RawPath = t.rw.PathPrefix + r.URL.RawPath
+		}
+	}
+	if len(t.rw.Query) > 0 {
+		q := r.URL.Query()
+		for name, values := range t.rw.Query {
+			q[name] = append([]string(nil), values...)
+		}
+		r.URL.RawQuery = q.Encode()
+	}
+	for _, edit := range t.rw.Editors {
+		edit(r)
+	}
+	return t.base.RoundTrip(r)
+}
+
+// CloseIdleConnections forwards to the base transport, so
+// http.Client.CloseIdleConnections reaches the wrapped connection pool.
+func (t *rewriteTransport) CloseIdleConnections() {
+	if ci, ok := t.base.(interface{ CloseIdleConnections() }); ok {
+		ci.CloseIdleConnections()
+	}
+}
@@ -0,0 +1,53 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestWithRewrite(t *testing.T) {
+	var got *http.Request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+	}))
+	defer srv.Close()
+
+	c := WithRewrite(srv.Client(), Rewrite{
+		PathPrefix: "gateway/openai/",
+		Query:      url.Values{"api-version": {"2025-01-01"}, "limit": {"5"}},
+		Editors: []func(*http.Request){
+			func(r *http.Request) { r.Header.Set("X-Gateway-Key", "gw-"+r.Header.Get("Authorization")) },
+		},
+	})
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/v1/models?limit=20&after=m1", nil)
+	req.Header.Set("Authorization", "Bearer sk")
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if got.URL.Path != "/gateway/openai/v1/models" {
+		t.Errorf("path = %q", got.URL.Path)
+	}
+	q := got.URL.Query()
+	if q.Get("api-version") != "2025-01-01" || q.Get("after") != "m1" || len(q["limit"]) != 1 || q.Get("limit") != "5" {
+		t.Errorf("query = %q", got.URL.RawQuery)
+	}
+	if got.Header.Get("X-Gateway-Key") != "gw-Bearer sk" {
+		t.Errorf("X-Gateway-Key = %q", got.Header.Get("X-Gateway-Key"))
+	}
+	if req.URL.Path != "/v1/models" {
+		t.Errorf("caller's request was modified: %q", req.URL.Path)
+	}
+}
+
+func TestWithRewriteZero(t *testing.T) {
+	c := &http.Client{}
+	if WithRewrite(c, Rewrite{PathPrefix: "/"}) != c {
+		t.Error("WithRewrite with no changes should return the client unchanged")
+	}
+}
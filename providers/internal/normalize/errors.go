@@ -3,7 +3,10 @@ package normalize
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/petal-labs/iris/core"
 )
@@ -37,11 +40,12 @@ func OpenAIStyleProviderError(provider string, status int, body []byte, requestI
 }
 
 // NetworkError wraps transport failures as provider-specific network errors.
+// The cause stays reachable, so errors.Is reports context cancellation.
 func NetworkError(provider string, err error) error {
 	return &core.ProviderError{
 		Provider: provider,
 		Message:  err.Error(),
-		Err:      core.ErrNetwork,
+		Err:      errors.Join(core.ErrNetwork, err),
 	}
 }
 
@@ -74,6 +78,28 @@ func ProviderError(provider string, status int, requestID, code, message string,
 	}
 }
 
+// RetryAfter returns the wait requested by a Retry-After header, given in
+// seconds or as an HTTP date, or by a retry-after-ms header. It returns zero
+// if neither is present or valid.
+func RetryAfter(h http.Header) time.Duration {
+	if v := h.Get("retry-after-ms"); v != "" {
+		if ms, err := strconv.ParseFloat(v, 64); err == nil && ms > 0 {
+			return time.Duration(ms * float64(time.Millisecond))
+		}
+	}
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if seconds, err := strconv.ParseFloat(v, 64); err == nil {
+		return max(time.Duration(seconds*float64(time.Second)), 0)
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return max(time.Until(t), 0)
+	}
+	return 0
+}
+
 // SentinelForStatus maps an HTTP status code to a core sentinel error.
 func SentinelForStatus(status int) error {
 	return SentinelForStatusWithOverrides(status, nil)
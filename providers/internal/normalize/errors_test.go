@@ -1,9 +1,12 @@
 package normalize
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/petal-labs/iris/core"
 )
@@ -137,3 +140,41 @@ func TestSentinelForStatusWithOverrides(t *testing.T) {
 		t.Errorf("sentinel = %v, want ErrNotFound", sentinel)
 	}
 }
+
+func TestNetworkErrorKeepsContextError(t *testing.T) {
+	err := NetworkError("test-provider", fmt.Errorf("Post %q: %w", "https://api.example.com", context.Canceled))
+
+	if !errors.Is(err, core.ErrNetwork) {
+		t.Error("error should wrap core.ErrNetwork")
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Error("error should wrap context.Canceled")
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		want   time.Duration
+	}{
+		{"none", http.Header{}, 0},
+		{"seconds", http.Header{"Retry-After": {"7"}}, 7 * time.Second},
+		{"fractional seconds", http.Header{"Retry-After": {"1.5"}}, 1500 * time.Millisecond},
+		{"milliseconds", http.Header{"Retry-After": {"7"}, "Retry-After-Ms": {"250"}}, 250 * time.Millisecond},
+		{"past date", http.Header{"Retry-After": {"Wed, 21 Oct 2015 07:28:00 GMT"}}, 0},
+		{"invalid", http.Header{"Retry-After": {"soon"}}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RetryAfter(tt.header); got != tt.want {
+				t.Errorf("RetryAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	future := time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)
+	if got := RetryAfter(http.Header{"Retry-After": {future}}); got <= 50*time.Second || got > time.Minute {
+		t.Errorf("RetryAfter(date) = %v, want about 1m", got)
+	}
+}
@@ -0,0 +1,20 @@
+package normalize
+
+import "github.com/petal-labs/iris/core"
+
+// FinishReason maps an OpenAI-style finish_reason, as returned by Chat
+// Completions and compatible APIs, to a normalized finish reason.
+func FinishReason(raw string) core.FinishReason {
+	switch raw {
+	case "stop":
+		return core.FinishStop
+	case "length":
+		return core.FinishLength
+	case "tool_calls", "function_call":
+		return core.FinishToolCalls
+	case "content_filter":
+		return core.FinishContentFilter
+	default:
+		return core.FinishUnknown
+	}
+}
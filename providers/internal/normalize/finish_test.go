@@ -0,0 +1,28 @@
+package normalize
+
+import (
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestFinishReason(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want core.FinishReason
+	}{
+		{"stop", core.FinishStop},
+		{"length", core.FinishLength},
+		{"tool_calls", core.FinishToolCalls},
+		{"function_call", core.FinishToolCalls},
+		{"content_filter", core.FinishContentFilter},
+		{"", core.FinishUnknown},
+		{"eos_token", core.FinishUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := FinishReason(tt.raw); got != tt.want {
+			t.Errorf("FinishReason(%q) = %q, want %q", tt.raw, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+// Package outputparts provides shared assembly of multimodal response parts.
+package outputparts
+
+import "github.com/petal-labs/iris/core"
+
+// Builder collects output parts in the order a model produced them.
+// Adjacent text is merged into a single core.OutputText part.
+type Builder struct {
+	parts    []core.OutputPart
+	hasMedia bool
+}
+
+// AddText appends text output.
+func (b *Builder) AddText(text string) {
+	if text == "" {
+		return
+	}
+	if n := len(b.parts); n > 0 {
+		if prev, ok := b.parts[n-1].(core.OutputText); ok {
+			b.parts[n-1] = core.OutputText{Text: prev.Text + text}
+			return
+		}
+	}
+	b.parts = append(b.parts, core.OutputText{Text: text})
+}
+
+// Add appends a non-text output part.
+func (b *Builder) Add(part core.OutputPart) {
+	b.parts = append(b.parts, part)
+	b.hasMedia = true
+}
+
+// Parts returns the collected parts, or nil if the output was text only.
+func (b *Builder) Parts() []core.OutputPart {
+	if !b.hasMedia {
+		return nil
+	}
+	return b.parts
+}
@@ -0,0 +1,40 @@
+package outputparts
+
+import (
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestBuilderTextOnly(t *testing.T) {
+	var b Builder
+	b.AddText("hello ")
+	b.AddText("world")
+
+	if parts := b.Parts(); parts != nil {
+		t.Errorf("Parts() = %v, want nil for text-only output", parts)
+	}
+}
+
+func TestBuilderMergesAdjacentText(t *testing.T) {
+	var b Builder
+	b.AddText("Here ")
+	b.AddText("it is:")
+	b.Add(core.OutputImage{MimeType: "image/png", Data: "aW1n"})
+	b.AddText("")
+	b.AddText("Done.")
+
+	parts := b.Parts()
+	if len(parts) != 3 {
+		t.Fatalf("len(Parts()) = %d, want 3", len(parts))
+	}
+	if got := parts[0].(core.OutputText).Text; got != "Here it is:" {
+		t.Errorf("parts[0] = %q, want %q", got, "Here it is:")
+	}
+	if _, ok := parts[1].(core.OutputImage); !ok {
+		t.Errorf("parts[1] = %T, want core.OutputImage", parts[1])
+	}
+	if got := parts[2].(core.OutputText).Text; got != "Done." {
+		t.Errorf("parts[2] = %q, want %q", got, "Done.")
+	}
+}
@@ -0,0 +1,28 @@
+// Package toolchoice maps core.ToolChoice to the OpenAI-compatible
+// tool_choice wire format shared by several providers.
+package toolchoice
+
+import "github.com/petal-labs/iris/core"
+
+// Function is the tool_choice object that forces a specific function.
+type Function struct {
+	Type     string       `json:"type"`
+	Function FunctionName `json:"function"`
+}
+
+// FunctionName names the forced function.
+type FunctionName struct {
+	Name string `json:"name"`
+}
+
+// OpenAI returns the Chat Completions tool_choice value for choice:
+// "auto", "none", "required", or a Function object. A nil choice maps to "auto".
+func OpenAI(choice *core.ToolChoice) any {
+	if choice == nil {
+		return string(core.ToolChoiceAuto)
+	}
+	if choice.Mode == core.ToolChoiceTool {
+		return Function{Type: "function", Function: FunctionName{Name: choice.Name}}
+	}
+	return string(choice.Mode)
+}
@@ -0,0 +1,33 @@
+package toolchoice
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestOpenAI(t *testing.T) {
+	tests := []struct {
+		name   string
+		choice *core.ToolChoice
+		want   string
+	}{
+		{"nil defaults to auto", nil, `"auto"`},
+		{"none", &core.ToolChoice{Mode: core.ToolChoiceNone}, `"none"`},
+		{"required", &core.ToolChoice{Mode: core.ToolChoiceRequired}, `"required"`},
+		{"specific tool", &core.ToolChoice{Mode: core.ToolChoiceTool, Name: "get_weather"}, `{"type":"function","function":{"name":"get_weather"}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(OpenAI(tt.choice))
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("OpenAI() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
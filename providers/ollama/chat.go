@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -12,6 +13,12 @@ import (
 
 // doChat sends a non-streaming chat request to the Ollama API.
 func (p *Ollama) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	// Inline remote images, which Ollama cannot fetch itself
+	req, err := p.resolveImages(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build request body
 	ollamaReq := mapRequest(req, false)
 
@@ -41,7 +48,7 @@ func (p *Ollama) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatR
 			Provider: "ollama",
 			Code:     "network_error",
 			Message:  err.Error(),
-			Err:      core.ErrNetwork,
+			Err:      errors.Join(core.ErrNetwork, err),
 		}
 	}
 	defer resp.Body.Close()
@@ -38,6 +38,12 @@
 //   - Streaming responses
 //   - Tool/function calling (for supported models)
 //   - Thinking/reasoning mode (for supported models like qwen3)
+//   - Structured output via ResponseJSON and ResponseJSONSchema (sent as format)
+//   - Image input for vision models such as llava (remote image URLs are
+//     downloaded and sent inline as base64)
+//   - Per-model capability discovery via DescribeModel (the show API)
+//   - Image generation with image models, through Ollama's OpenAI-compatible
+//     images endpoint (GenerateImage; EditImage is not supported)
 //
 // # Models
 //
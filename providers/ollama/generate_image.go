@@ -0,0 +1,139 @@
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// imageGenerationsPath is Ollama's OpenAI-compatible image generation
+// endpoint, available in releases with experimental image generation.
+const imageGenerationsPath = "/v1/images/generations"
+
+// GenerateImage generates images with an image generation model pulled into
+// Ollama (for example x/z-image-turbo), using its OpenAI-compatible images
+// endpoint. Servers without that endpoint yield an error wrapping
+// core.ErrNotSupported; chat models yield an error from Ollama.
+func (p *Ollama) GenerateImage(ctx context.Context, req *core.ImageGenerateRequest) (*core.ImageResponse, error) {
+	body, err := json.Marshal(mapImageRequest(req))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := p.config.BaseURL + imageGenerationsPath
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, &core.ProviderError{
+			Provider: "ollama",
+			Code:     "network_error",
+			Message:  err.Error(),
+			Err:      errors.Join(core.ErrNetwork, err),
+		}
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, &core.ProviderError{
+			Provider: "ollama",
+			Code:     "read_error",
+			Message:  err.Error(),
+			Err:      core.ErrNetwork,
+		}
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseImageError(resp.StatusCode, respBody)
+	}
+
+	var imgResp ollamaImageResponse
+	if err := json.Unmarshal(respBody, &imgResp); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return mapImageResponse(&imgResp), nil
+}
+
+// EditImage is not supported by Ollama.
+func (p *Ollama) EditImage(ctx context.Context, req *core.ImageEditRequest) (*core.ImageResponse, error) {
+	return nil, &core.ProviderError{
+		Provider: "ollama",
+		Code:     "not_supported",
+		Message:  "Ollama does not support image editing",
+		Err:      core.ErrNotSupported,
+	}
+}
+
+// StreamImage generates images and delivers them as an ImageStream with no
+// partial images, since Ollama does not stream image generation.
+func (p *Ollama) StreamImage(ctx context.Context, req *core.ImageGenerateRequest) (*core.ImageStream, error) {
+	return core.ImageStreamFromFunc(ctx, func(ctx context.Context) (*core.ImageResponse, error) {
+		return p.GenerateImage(ctx, req)
+	}), nil
+}
+
+// parseImageError maps an image endpoint error. A 404 without an Ollama
+// error body means the server predates image generation.
+func parseImageError(status int, body []byte) error {
+	var errResp ollamaErrorResponse
+	if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
+		return mapOllamaError(status, errResp.Error)
+	}
+	if status == http.StatusNotFound {
+		return &core.ProviderError{
+			Provider: "ollama",
+			Code:     "not_supported",
+			Message:  "this Ollama server has no image generation endpoint; upgrade Ollama and pull an image generation model",
+			Status:   status,
+			Err:      core.ErrNotSupported,
+		}
+	}
+	return mapOllamaError(status, string(body))
+}
+
+// mapImageRequest converts a core image request to Ollama's OpenAI-compatible
+// format. Images are always requested base64-encoded, as Ollama does not
+// host them.
+func mapImageRequest(req *core.ImageGenerateRequest) *ollamaImageRequest {
+	r := &ollamaImageRequest{
+		Model:          string(req.Model),
+		Prompt:         req.Prompt,
+		N:              req.N,
+		ResponseFormat: "b64_json",
+	}
+	if req.Size != "" && req.Size != core.ImageSizeAuto {
+		r.Size = string(req.Size)
+	}
+	return r
+}
+
+// mapImageResponse converts an Ollama image response to core format.
+func mapImageResponse(resp *ollamaImageResponse) *core.ImageResponse {
+	r := &core.ImageResponse{
+		Created: resp.Created,
+		Data:    make([]core.ImageData, len(resp.Data)),
+	}
+	for i, d := range resp.Data {
+		r.Data[i] = core.ImageData{B64JSON: d.B64JSON}
+	}
+	return r
+}
+
+// Compile-time check that Ollama implements ImageGenerator.
+var _ core.ImageGenerator = (*Ollama)(nil)
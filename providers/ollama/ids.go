@@ -0,0 +1,65 @@
+package ollama
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"sync"
+	"time"
+)
+
+// crockford is the Crockford base32 alphabet used by ULIDs.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var (
+	idMu      sync.Mutex
+	idLastMS  uint64
+	idLastRnd [10]byte
+)
+
+// newToolCallID returns a globally unique tool call ID of the form
+// "call_<ULID>". Ollama does not assign tool call IDs, and index-based IDs
+// collide across iterations of an agent loop. IDs generated within the same
+// millisecond are monotonically increasing, so they also sort by creation.
+func newToolCallID() string {
+	idMu.Lock()
+	ms := uint64(time.Now().UnixMilli())
+	if ms <= idLastMS {
+		ms = idLastMS
+		incrementEntropy(&idLastRnd)
+	} else {
+		idLastMS = ms
+		_, _ = rand.Read(idLastRnd[:])
+	}
+	rnd := idLastRnd
+	idMu.Unlock()
+
+	var raw [16]byte
+	binary.BigEndian.PutUint16(raw[0:2], uint16(ms>>32))
+	binary.BigEndian.PutUint32(raw[2:6], uint32(ms))
+	copy(raw[6:], rnd[:])
+	return "call_" + encodeULID(raw)
+}
+
+// incrementEntropy adds one to the 80-bit big-endian value in b.
+func incrementEntropy(b *[10]byte) {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return
+		}
+	}
+}
+
+// encodeULID encodes 128 bits as 26 Crockford base32 characters.
+func encodeULID(raw [16]byte) string {
+	hi := binary.BigEndian.Uint64(raw[0:8])
+	lo := binary.BigEndian.Uint64(raw[8:16])
+
+	var out [26]byte
+	for i := 25; i >= 0; i-- {
+		out[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+	return string(out[:])
+}
@@ -0,0 +1,162 @@
+package ollama
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// maxImageBytes caps the size of a remote image downloaded for a request.
+const maxImageBytes = 20 << 20
+
+// resolveImages prepares image parts for Ollama, which only accepts inline
+// base64 images. Remote image URLs are downloaded and replaced with data URLs;
+// images referenced by file ID are rejected. The request is returned as-is
+// when no download is needed; otherwise a copy is returned and req is not
+// modified.
+func (p *Ollama) resolveImages(ctx context.Context, req *core.ChatRequest) (*core.ChatRequest, error) {
+	var messages []core.Message
+
+	for i, msg := range req.Messages {
+		var parts []core.ContentPart
+		for j, part := range msg.Parts {
+			img, ok := imagePart(part)
+			if !ok {
+				continue
+			}
+			if img.FileID != "" && img.ImageURL == "" {
+				return nil, fmt.Errorf("%w: ollama does not support file ID images", core.ErrNotSupported)
+			}
+			if strings.HasPrefix(img.ImageURL, "data:") {
+				continue
+			}
+
+			dataURL, err := p.fetchImage(ctx, img.ImageURL)
+			if err != nil {
+				return nil, err
+			}
+			if parts == nil {
+				parts = append([]core.ContentPart(nil), msg.Parts...)
+			}
+			parts[j] = &core.InputImage{ImageURL: dataURL, Detail: img.Detail}
+		}
+
+		if parts == nil {
+			continue
+		}
+		// Copy on first write so the caller's request is left untouched
+		if messages == nil {
+			messages = append([]core.Message(nil), req.Messages...)
+		}
+		messages[i].Parts = parts
+	}
+
+	if messages == nil {
+		return req, nil
+	}
+	resolved := *req
+	resolved.Messages = messages
+	return &resolved, nil
+}
+
+// fetchImage downloads an image and returns it as a base64 data URL.
+func (p *Ollama) fetchImage(ctx context.Context, url string) (string, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		return "", fmt.Errorf("%w: unsupported image URL %q", core.ErrBadRequest, url)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create image request: %w", err)
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("%w: fetch image %q: %v", core.ErrNetwork, url, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: fetch image %q: status %d", core.ErrBadRequest, url, resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxImageBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("%w: fetch image %q: %v", core.ErrNetwork, url, err)
+	}
+	if len(data) > maxImageBytes {
+		return "", fmt.Errorf("%w: image %q exceeds %d bytes", core.ErrBadRequest, url, maxImageBytes)
+	}
+
+	mime := resp.Header.Get("Content-Type")
+	if mime == "" {
+		mime = http.DetectContentType(data)
+	}
+	return "data:" + mime + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
+
+// imagePart returns the image for an InputImage part, by value or pointer.
+func imagePart(part core.ContentPart) (core.InputImage, bool) {
+	switch p := part.(type) {
+	case *core.InputImage:
+		return *p, true
+	case core.InputImage:
+		return p, true
+	default:
+		return core.InputImage{}, false
+	}
+}
+
+// textPart returns the text for an InputText part, by value or pointer.
+func textPart(part core.ContentPart) (string, bool) {
+	switch p := part.(type) {
+	case *core.InputText:
+		return p.Text, true
+	case core.InputText:
+		return p.Text, true
+	default:
+		return "", false
+	}
+}
+
+// mapContentParts splits multimodal parts into message text and base64 images.
+// Text parts are joined with newlines after any plain Content. Images must
+// already be inline (see resolveImages); other URLs are skipped.
+func mapContentParts(content string, parts []core.ContentPart) (string, []string) {
+	texts := make([]string, 0, len(parts)+1)
+	if content != "" {
+		texts = append(texts, content)
+	}
+
+	var images []string
+	for _, part := range parts {
+		if text, ok := textPart(part); ok {
+			texts = append(texts, text)
+			continue
+		}
+		if img, ok := imagePart(part); ok {
+			if data, ok := dataURLPayload(img.ImageURL); ok {
+				images = append(images, data)
+			}
+		}
+	}
+
+	return strings.Join(texts, "\n"), images
+}
+
+// dataURLPayload returns the base64 payload of a base64 data URL.
+func dataURLPayload(url string) (string, bool) {
+	if !strings.HasPrefix(url, "data:") {
+		return "", false
+	}
+	meta, data, ok := strings.Cut(url, ",")
+	if !ok || !strings.HasSuffix(meta, ";base64") {
+		return "", false
+	}
+	return data, true
+}
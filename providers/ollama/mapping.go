@@ -2,7 +2,6 @@ package ollama
 
 import (
 	"encoding/json"
-	"fmt"
 
 	"github.com/petal-labs/iris/core"
 	"github.com/petal-labs/iris/tools"
@@ -12,13 +11,13 @@ import (
 func mapRequest(req *core.ChatRequest, stream bool) *ollamaRequest {
 	ollamaReq := &ollamaRequest{
 		Model:    string(req.Model),
-		Messages: mapMessages(req.Messages),
+		Messages: mapMessages(req.Messages, req.ToolResultPolicy),
 		Stream:   stream,
 	}
 
 	// Map tools
-	if len(req.Tools) > 0 {
-		ollamaReq.Tools = mapTools(req.Tools)
+	if toolset := selectTools(req.Tools, req.ToolChoice); len(toolset) > 0 {
+		ollamaReq.Tools = mapTools(toolset)
 	}
 
 	// Map thinking/reasoning
@@ -26,6 +25,11 @@ func mapRequest(req *core.ChatRequest, stream bool) *ollamaRequest {
 		ollamaReq.Think = think
 	}
 
+	// Map structured output format
+	if format := mapFormat(req); format != nil {
+		ollamaReq.Format = format
+	}
+
 	// Map options (temperature, max tokens, etc.)
 	if opts := mapOptions(req); opts != nil {
 		ollamaReq.Options = opts
@@ -34,18 +38,45 @@ func mapRequest(req *core.ChatRequest, stream bool) *ollamaRequest {
 	return ollamaReq
 }
 
+// selectTools approximates a tool choice, which Ollama has no parameter for.
+// ToolChoiceNone omits tools entirely and ToolChoiceTool offers only the named
+// tool; ToolChoiceRequired cannot be enforced and behaves like auto.
+func selectTools(ts []core.Tool, choice *core.ToolChoice) []core.Tool {
+	if choice == nil {
+		return ts
+	}
+	switch choice.Mode {
+	case core.ToolChoiceNone:
+		return nil
+	case core.ToolChoiceTool:
+		for _, t := range ts {
+			if t.Name() == choice.Name {
+				return []core.Tool{t}
+			}
+		}
+	}
+	return ts
+}
+
 // mapMessages converts core messages to Ollama messages.
-func mapMessages(messages []core.Message) []ollamaMessage {
+// Tool result content is serialized according to policy (nil uses the default).
+func mapMessages(messages []core.Message, policy *core.ToolResultPolicy) []ollamaMessage {
 	result := make([]ollamaMessage, 0, len(messages))
 
+	// Ollama matches tool results to calls by tool name, so remember the name
+	// for each call ID seen in earlier assistant turns.
+	toolNames := make(map[string]string)
+
 	for _, msg := range messages {
 		switch msg.Role {
 		case core.RoleTool:
 			// Tool result messages: create individual tool messages for each result
 			for _, tr := range msg.ToolResults {
 				result = append(result, ollamaMessage{
-					Role:    "tool",
-					Content: marshalToolResultContent(tr.Content),
+					Role:       "tool",
+					Content:    policy.Serialize(tr.Content),
+					ToolName:   toolNames[tr.CallID],
+					ToolCallID: tr.CallID,
 				})
 			}
 
@@ -57,14 +88,19 @@ func mapMessages(messages []core.Message) []ollamaMessage {
 			}
 			if len(msg.ToolCalls) > 0 {
 				ollamaMsg.ToolCalls = mapCoreToolCallsToOllama(msg.ToolCalls)
+				for _, tc := range msg.ToolCalls {
+					toolNames[tc.ID] = tc.Name
+				}
 			}
 			result = append(result, ollamaMsg)
 
 		default:
-			// System, User messages
+			// System, User messages, with images for multimodal content
+			content, images := mapContentParts(msg.Content, msg.Parts)
 			result = append(result, ollamaMessage{
 				Role:    string(msg.Role),
-				Content: msg.Content,
+				Content: content,
+				Images:  images,
 			})
 		}
 	}
@@ -81,6 +117,7 @@ func mapCoreToolCallsToOllama(calls []core.ToolCall) []ollamaToolCall {
 			args = map[string]interface{}{}
 		}
 		result[i] = ollamaToolCall{
+			ID: tc.ID,
 			Function: ollamaFunctionCall{
 				Name:      tc.Name,
 				Arguments: args,
@@ -90,20 +127,6 @@ func mapCoreToolCallsToOllama(calls []core.ToolCall) []ollamaToolCall {
 	return result
 }
 
-// marshalToolResultContent converts tool result content to a string.
-func marshalToolResultContent(content any) string {
-	switch v := content.(type) {
-	case string:
-		return v
-	default:
-		data, err := json.Marshal(v)
-		if err != nil {
-			return "{\"error\": \"failed to marshal tool result\"}"
-		}
-		return string(data)
-	}
-}
-
 // schemaProvider is an interface for tools that provide a JSON schema.
 type schemaProvider interface {
 	Schema() tools.ToolSchema
@@ -161,6 +184,22 @@ func mapThinking(effort core.ReasoningEffort) *bool {
 	}
 }
 
+// mapFormat converts the response format to Ollama's format field, which
+// accepts "json" or a JSON schema object.
+func mapFormat(req *core.ChatRequest) interface{} {
+	switch req.ResponseFormat {
+	case core.ResponseFormatJSON:
+		return "json"
+	case core.ResponseFormatJSONSchema:
+		if req.JSONSchema == nil || len(req.JSONSchema.Schema) == 0 {
+			return "json"
+		}
+		return req.JSONSchema.Schema
+	default:
+		return nil
+	}
+}
+
 // mapOptions converts request parameters to Ollama options.
 func mapOptions(req *core.ChatRequest) *ollamaOptions {
 	opts := &ollamaOptions{}
@@ -206,16 +245,40 @@ func mapResponse(resp *ollamaResponse) *core.ChatResponse {
 	// Map usage from durations
 	chatResp.Usage = mapUsage(resp)
 
+	chatResp.FinishReason = mapDoneReason(resp.DoneReason, len(chatResp.ToolCalls) > 0)
+	chatResp.RawFinishReason = resp.DoneReason
+
 	return chatResp
 }
 
+// mapDoneReason converts an Ollama done_reason to a finish reason. Ollama
+// reports "stop" after tool calls too, so hasToolCalls turns it into
+// FinishToolCalls. "load" and "unload", for requests that only load or
+// unload the model, have no equivalent.
+func mapDoneReason(reason string, hasToolCalls bool) core.FinishReason {
+	switch {
+	case reason == "stop" && hasToolCalls:
+		return core.FinishToolCalls
+	case reason == "stop":
+		return core.FinishStop
+	case reason == "length":
+		return core.FinishLength
+	default:
+		return core.FinishUnknown
+	}
+}
+
 // mapToolCalls converts Ollama tool calls to core tool calls.
 func mapToolCalls(toolCalls []ollamaToolCall) []core.ToolCall {
 	result := make([]core.ToolCall, 0, len(toolCalls))
 
-	for i, tc := range toolCalls {
-		// Ollama doesn't provide tool call IDs, generate one
-		callID := fmt.Sprintf("call_%d", i)
+	for _, tc := range toolCalls {
+		// Older Ollama versions don't provide tool call IDs; generate a
+		// globally unique one so IDs don't collide across agent iterations.
+		callID := tc.ID
+		if callID == "" {
+			callID = newToolCallID()
+		}
 
 		// Convert arguments map to JSON
 		argsJSON, err := json.Marshal(tc.Function.Arguments)
@@ -0,0 +1,120 @@
+package ollama
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// ollamaShowRequest is the request body for the Ollama show API.
+type ollamaShowRequest struct {
+	Model string `json:"model"`
+}
+
+// ollamaShowResponse is the subset of the show API response used for
+// capability discovery.
+type ollamaShowResponse struct {
+	Capabilities []string               `json:"capabilities"`
+	ModelInfo    map[string]interface{} `json:"model_info"`
+}
+
+// DescribeModel queries the Ollama show API for a locally available model and
+// returns its capabilities and context window. Results are cached, so later
+// SupportsModel calls for the model use the discovered capabilities.
+func (p *Ollama) DescribeModel(ctx context.Context, model core.ModelID) (*core.ModelInfo, error) {
+	body, err := json.Marshal(ollamaShowRequest{Model: string(model)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.BaseURL+"/api/show", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, &core.ProviderError{
+			Provider: "ollama",
+			Code:     "network_error",
+			Message:  err.Error(),
+			Err:      errors.Join(core.ErrNetwork, err),
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, parseErrorResponse(resp)
+	}
+
+	var show ollamaShowResponse
+	if err := json.NewDecoder(resp.Body).Decode(&show); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	info := mapShowResponse(model, &show)
+	p.discovered.Store(model, info)
+	return info, nil
+}
+
+// SupportsModel reports whether a model supports the feature. Models described
+// by DescribeModel use their discovered capabilities, the example models use
+// their static entries, and any other model falls back to Supports.
+func (p *Ollama) SupportsModel(model core.ModelID, feature core.Feature) bool {
+	if v, ok := p.discovered.Load(model); ok {
+		return v.(*core.ModelInfo).HasCapability(feature)
+	}
+	if info := core.FindModel(p, model); info != nil {
+		return info.HasCapability(feature)
+	}
+	return p.Supports(feature)
+}
+
+// mapShowResponse converts a show API response to core.ModelInfo.
+func mapShowResponse(model core.ModelID, show *ollamaShowResponse) *core.ModelInfo {
+	info := &core.ModelInfo{
+		ID:          model,
+		DisplayName: string(model),
+		Modalities:  []core.Modality{core.ModalityText},
+	}
+
+	for _, c := range show.Capabilities {
+		switch c {
+		case "completion":
+			info.Capabilities = append(info.Capabilities,
+				core.FeatureChat, core.FeatureChatStreaming, core.FeatureStructuredOutput)
+		case "tools":
+			info.Capabilities = append(info.Capabilities, core.FeatureToolCalling)
+		case "thinking":
+			info.Capabilities = append(info.Capabilities, core.FeatureReasoning)
+		case "vision":
+			info.Modalities = append(info.Modalities, core.ModalityImage)
+		case "embedding":
+			info.Capabilities = append(info.Capabilities, core.FeatureEmbeddings)
+		case "image":
+			info.Capabilities = append(info.Capabilities, core.FeatureImageGeneration)
+		}
+	}
+
+	// Context length is reported under an architecture-specific key,
+	// e.g. "llama.context_length".
+	for key, v := range show.ModelInfo {
+		if n, ok := v.(float64); ok && strings.HasSuffix(key, ".context_length") {
+			info.ContextWindow = int(n)
+			break
+		}
+	}
+
+	return info
+}
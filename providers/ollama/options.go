@@ -2,6 +2,7 @@ package ollama
 
 import (
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/petal-labs/iris/core"
@@ -26,10 +27,23 @@ type Config struct {
 	// Defaults to DefaultLocalURL.
 	BaseURL string
 
-	// HTTPClient is the HTTP client to use for requests.
-	// Defaults to http.DefaultClient.
+	// HTTPClient is the HTTP client to use. Defaults to a client with pooled
+	// connections shared by all providers. When set, Transport and Proxy are
+	// ignored.
 	HTTPClient *http.Client
 
+	// Transport replaces the default transport, for example to tune
+	// connection pooling or add instrumentation.
+	Transport http.RoundTripper
+
+	// Proxy routes requests through a proxy instead of the one configured by
+	// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY.
+	Proxy *url.URL
+
+	// Credentials, if set, supplies the API key for each request instead of
+	// APIKey, so keys can rotate without a restart.
+	Credentials core.CredentialProvider
+
 	// Headers contains additional HTTP headers to include in requests.
 	Headers http.Header
 
@@ -71,6 +85,29 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// WithTransport sets the transport used by the default HTTP client.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Config) {
+		c.Transport = rt
+	}
+}
+
+// WithProxy routes requests through the proxy at proxyURL.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Config) {
+		c.Proxy = proxyURL
+	}
+}
+
+// WithCredentials resolves the API key from creds for every request instead
+// of using a fixed key. A request rejected with 401 is retried once after
+// refreshing creds, if it implements core.CredentialRefresher.
+func WithCredentials(creds core.CredentialProvider) Option {
+	return func(c *Config) {
+		c.Credentials = creds
+	}
+}
+
 // WithHeaders sets additional HTTP headers to include in requests.
 func WithHeaders(headers http.Header) Option {
 	return func(c *Config) {
@@ -78,7 +115,8 @@ func WithHeaders(headers http.Header) Option {
 	}
 }
 
-// WithTimeout sets the request timeout.
+// WithTimeout sets the default request timeout, which core.Client applies
+// to requests that have no context deadline or Timeout of their own.
 func WithTimeout(timeout time.Duration) Option {
 	return func(c *Config) {
 		c.Timeout = timeout
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 // Environment variable names for Ollama configuration.
@@ -59,6 +62,9 @@ func NewCloudFromEnv(opts ...Option) (*Ollama, error) {
 // Ollama is safe for concurrent use.
 type Ollama struct {
 	config Config
+
+	// discovered caches model metadata from DescribeModel.
+	discovered sync.Map // core.ModelID -> *core.ModelInfo
 }
 
 // New creates a new Ollama provider with the given options.
@@ -66,13 +72,18 @@ type Ollama struct {
 // For Ollama Cloud, use WithCloud() and WithAPIKey().
 func New(opts ...Option) *Ollama {
 	cfg := Config{
-		BaseURL:    DefaultLocalURL,
-		HTTPClient: http.DefaultClient,
+		BaseURL: DefaultLocalURL,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+	}
+	if cfg.Credentials != nil {
+		cfg.HTTPClient = httpclient.WithCredentials(cfg.HTTPClient, cfg.Credentials, httpclient.BearerAuth)
+	}
 
 	return &Ollama{config: cfg}
 }
@@ -82,6 +93,12 @@ func (p *Ollama) ID() string {
 	return "ollama"
 }
 
+// CloseIdleConnections closes the idle pooled connections of the provider's
+// HTTP client. core.Client.Close calls it during shutdown.
+func (p *Ollama) CloseIdleConnections() {
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
 // Models returns example models available through Ollama.
 // Note: Ollama models are dynamic - you can use any model you have pulled locally.
 func (p *Ollama) Models() []core.ModelInfo {
@@ -99,10 +116,17 @@ func (p *Ollama) Models() []core.ModelInfo {
 	}
 }
 
+// DefaultTimeout returns the timeout set with WithTimeout. core.Client
+// applies it to requests that have no deadline or Timeout of their own.
+func (p *Ollama) DefaultTimeout() time.Duration {
+	return p.config.Timeout
+}
+
 // Supports reports whether the provider supports the given feature.
 func (p *Ollama) Supports(feature core.Feature) bool {
 	switch feature {
-	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning:
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning,
+		core.FeatureStructuredOutput, core.FeatureImageGeneration:
 		return true
 	default:
 		return false
@@ -143,3 +167,9 @@ func (p *Ollama) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.C
 
 // Compile-time check that Ollama implements Provider.
 var _ core.Provider = (*Ollama)(nil)
+
+// Compile-time check that Ollama implements TimeoutProvider.
+var _ core.TimeoutProvider = (*Ollama)(nil)
+
+// Compile-time check that Ollama implements IdleConnectionCloser.
+var _ core.IdleConnectionCloser = (*Ollama)(nil)
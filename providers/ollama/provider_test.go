@@ -3,6 +3,7 @@ package ollama
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 // TestNew tests the provider constructor.
@@ -23,8 +25,8 @@ func TestNew(t *testing.T) {
 		if !p.config.APIKey.IsEmpty() {
 			t.Errorf("APIKey should be empty")
 		}
-		if p.config.HTTPClient != http.DefaultClient {
-			t.Error("HTTPClient should be http.DefaultClient")
+		if p.config.HTTPClient != httpclient.Default() {
+			t.Error("HTTPClient should be the shared default client")
 		}
 	})
 
@@ -110,6 +112,8 @@ func TestProviderSupports(t *testing.T) {
 		{core.FeatureChatStreaming, true},
 		{core.FeatureToolCalling, true},
 		{core.FeatureReasoning, true},
+		{core.FeatureStructuredOutput, true},
+		{core.FeatureImageGeneration, true},
 		{core.Feature("unknown"), false},
 	}
 
@@ -655,6 +659,32 @@ func TestMapResponse(t *testing.T) {
 			t.Errorf("Reasoning.Summary[0] = %q, want %q", result.Reasoning.Summary[0], "15% of 240 = 36")
 		}
 	})
+
+	t.Run("finish reason", func(t *testing.T) {
+		call := ollamaToolCall{Function: ollamaFunctionCall{Name: "weather"}}
+		tests := []struct {
+			reason    string
+			toolCalls []ollamaToolCall
+			want      core.FinishReason
+		}{
+			{"stop", nil, core.FinishStop},
+			{"stop", []ollamaToolCall{call}, core.FinishToolCalls},
+			{"length", nil, core.FinishLength},
+			{"unload", nil, core.FinishUnknown},
+		}
+
+		for _, tt := range tests {
+			resp := &ollamaResponse{
+				Message:    ollamaMessage{Role: "assistant", ToolCalls: tt.toolCalls},
+				Done:       true,
+				DoneReason: tt.reason,
+			}
+			result := mapResponse(resp)
+			if result.FinishReason != tt.want || result.RawFinishReason != tt.reason {
+				t.Errorf("done_reason %q: FinishReason = %q, RawFinishReason = %q, want %q", tt.reason, result.FinishReason, result.RawFinishReason, tt.want)
+			}
+		}
+	})
 }
 
 // TestMapToolCalls tests tool call mapping.
@@ -670,12 +700,12 @@ func TestMapToolCalls(t *testing.T) {
 		t.Fatalf("Result length = %d, want 2", len(result))
 	}
 
-	// Check IDs are generated
-	if result[0].ID != "call_0" {
-		t.Errorf("ID[0] = %q, want call_0", result[0].ID)
+	// Check IDs are generated and unique
+	if !strings.HasPrefix(result[0].ID, "call_") || !strings.HasPrefix(result[1].ID, "call_") {
+		t.Errorf("IDs = %q, %q, want call_ prefix", result[0].ID, result[1].ID)
 	}
-	if result[1].ID != "call_1" {
-		t.Errorf("ID[1] = %q, want call_1", result[1].ID)
+	if result[0].ID == result[1].ID {
+		t.Errorf("IDs collide: %q", result[0].ID)
 	}
 
 	// Check names
@@ -777,3 +807,366 @@ type mockTool struct {
 
 func (t *mockTool) Name() string        { return t.name }
 func (t *mockTool) Description() string { return t.description }
+
+func TestMapRequestToolChoice(t *testing.T) {
+	weather := &mockTool{name: "get_weather", description: "Get weather"}
+	search := &mockTool{name: "search", description: "Search"}
+
+	tests := []struct {
+		name      string
+		choice    *core.ToolChoice
+		wantTools []string
+	}{
+		{"default", nil, []string{"get_weather", "search"}},
+		{"none", &core.ToolChoice{Mode: core.ToolChoiceNone}, nil},
+		{"specific tool", &core.ToolChoice{Mode: core.ToolChoiceTool, Name: "search"}, []string{"search"}},
+		{"unknown tool keeps all", &core.ToolChoice{Mode: core.ToolChoiceTool, Name: "missing"}, []string{"get_weather", "search"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &core.ChatRequest{
+				Model:      "llama3.2",
+				Messages:   []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+				Tools:      []core.Tool{weather, search},
+				ToolChoice: tt.choice,
+			}
+
+			result := mapRequest(req, false)
+			if len(result.Tools) != len(tt.wantTools) {
+				t.Fatalf("len(Tools) = %d, want %d", len(result.Tools), len(tt.wantTools))
+			}
+			for i, name := range tt.wantTools {
+				if result.Tools[i].Function.Name != name {
+					t.Errorf("Tools[%d] = %q, want %q", i, result.Tools[i].Function.Name, name)
+				}
+			}
+		})
+	}
+}
+
+func TestMapRequestFormat(t *testing.T) {
+	schema := json.RawMessage(`{"type":"object","properties":{"name":{"type":"string"}},"required":["name"]}`)
+
+	tests := []struct {
+		name   string
+		format core.ResponseFormat
+		schema *core.JSONSchemaDefinition
+		want   string
+	}{
+		{"text", core.ResponseFormatText, nil, ""},
+		{"json", core.ResponseFormatJSON, nil, `"json"`},
+		{"json schema", core.ResponseFormatJSONSchema, &core.JSONSchemaDefinition{Name: "person", Schema: schema}, string(schema)},
+		{"json schema without schema", core.ResponseFormatJSONSchema, nil, `"json"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &core.ChatRequest{
+				Model:          "llama3.2",
+				Messages:       []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+				ResponseFormat: tt.format,
+				JSONSchema:     tt.schema,
+			}
+
+			body, err := json.Marshal(mapRequest(req, false))
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			var decoded map[string]json.RawMessage
+			if err := json.Unmarshal(body, &decoded); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got := string(decoded["format"]); got != tt.want {
+				t.Errorf("format = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMapMessagesWithImages(t *testing.T) {
+	msgs := []core.Message{
+		{
+			Role: core.RoleUser,
+			Parts: []core.ContentPart{
+				&core.InputText{Text: "What is in this picture?"},
+				&core.InputImage{ImageURL: "data:image/png;base64,aW1nMQ=="},
+				core.InputImage{ImageURL: "data:image/jpeg;base64,aW1nMg=="},
+			},
+		},
+	}
+
+	result := mapMessages(msgs, nil)
+	if len(result) != 1 {
+		t.Fatalf("len(result) = %d, want 1", len(result))
+	}
+	if result[0].Content != "What is in this picture?" {
+		t.Errorf("Content = %q", result[0].Content)
+	}
+	if len(result[0].Images) != 2 || result[0].Images[0] != "aW1nMQ==" || result[0].Images[1] != "aW1nMg==" {
+		t.Errorf("Images = %v, want base64 payloads", result[0].Images)
+	}
+}
+
+func TestChatWithRemoteImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cat.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("png-bytes"))
+		case "/api/chat":
+			var req ollamaRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("Failed to decode request: %v", err)
+			}
+			if len(req.Messages) != 1 || len(req.Messages[0].Images) != 1 {
+				t.Fatalf("Messages = %+v, want one message with one image", req.Messages)
+			}
+			if got := req.Messages[0].Images[0]; got != "cG5nLWJ5dGVz" {
+				t.Errorf("Images[0] = %q, want base64 of downloaded image", got)
+			}
+			json.NewEncoder(w).Encode(ollamaResponse{
+				Model:   "llava",
+				Message: ollamaMessage{Role: "assistant", Content: "A cat."},
+				Done:    true,
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	req := &core.ChatRequest{
+		Model: "llava",
+		Messages: []core.Message{{
+			Role: core.RoleUser,
+			Parts: []core.ContentPart{
+				&core.InputText{Text: "Describe"},
+				&core.InputImage{ImageURL: server.URL + "/cat.png"},
+			},
+		}},
+	}
+
+	p := New(WithBaseURL(server.URL))
+	resp, err := p.Chat(context.Background(), req)
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Output != "A cat." {
+		t.Errorf("Output = %q, want %q", resp.Output, "A cat.")
+	}
+
+	// The caller's request keeps the original URL.
+	if img := req.Messages[0].Parts[1].(*core.InputImage); img.ImageURL != server.URL+"/cat.png" {
+		t.Errorf("request mutated: ImageURL = %q", img.ImageURL)
+	}
+}
+
+func TestChatRejectsFileIDImage(t *testing.T) {
+	p := New(WithBaseURL("http://127.0.0.1:0"))
+	_, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model: "llava",
+		Messages: []core.Message{{
+			Role:  core.RoleUser,
+			Parts: []core.ContentPart{&core.InputImage{FileID: "file-123"}},
+		}},
+	})
+	if !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("Chat() error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestMapToolCallsUniqueAcrossResponses(t *testing.T) {
+	calls := []ollamaToolCall{{Function: ollamaFunctionCall{Name: "func1"}}}
+
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		id := mapToolCalls(calls)[0].ID
+		if len(id) != len("call_")+26 {
+			t.Fatalf("ID = %q, want call_ followed by a ULID", id)
+		}
+		if seen[id] {
+			t.Fatalf("duplicate tool call ID %q", id)
+		}
+		seen[id] = true
+	}
+}
+
+func TestMapToolCallsPreservesProviderID(t *testing.T) {
+	calls := []ollamaToolCall{{ID: "abc", Function: ollamaFunctionCall{Name: "func1"}}}
+
+	if id := mapToolCalls(calls)[0].ID; id != "abc" {
+		t.Errorf("ID = %q, want abc", id)
+	}
+}
+
+func TestMapMessagesEchoesToolCallIDs(t *testing.T) {
+	msgs := []core.Message{
+		{Role: core.RoleUser, Content: "Weather?"},
+		{
+			Role: core.RoleAssistant,
+			ToolCalls: []core.ToolCall{
+				{ID: "call_A", Name: "get_weather", Arguments: json.RawMessage(`{"city":"Paris"}`)},
+				{ID: "call_B", Name: "get_time", Arguments: json.RawMessage(`{}`)},
+			},
+		},
+		{
+			Role: core.RoleTool,
+			ToolResults: []core.ToolResult{
+				{CallID: "call_B", Content: "noon"},
+				{CallID: "call_A", Content: "sunny"},
+			},
+		},
+	}
+
+	result := mapMessages(msgs, nil)
+	if len(result) != 4 {
+		t.Fatalf("len(result) = %d, want 4", len(result))
+	}
+	if got := result[1].ToolCalls[0].ID; got != "call_A" {
+		t.Errorf("assistant ToolCalls[0].ID = %q, want call_A", got)
+	}
+	if result[2].ToolCallID != "call_B" || result[2].ToolName != "get_time" {
+		t.Errorf("result[2] = %+v, want call_B/get_time", result[2])
+	}
+	if result[3].ToolCallID != "call_A" || result[3].ToolName != "get_weather" {
+		t.Errorf("result[3] = %+v, want call_A/get_weather", result[3])
+	}
+}
+
+func TestDescribeModel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/show" {
+			t.Errorf("Path = %s, want /api/show", r.URL.Path)
+		}
+		var req ollamaShowRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Model != "llava" {
+			t.Errorf("Model = %q, want llava", req.Model)
+		}
+		w.Write([]byte(`{
+			"capabilities": ["completion", "vision"],
+			"model_info": {"general.architecture": "llama", "llama.context_length": 4096}
+		}`))
+	}))
+	defer server.Close()
+
+	p := New(WithBaseURL(server.URL))
+
+	// Before discovery, unknown models fall back to provider-wide support.
+	if !p.SupportsModel("llava", core.FeatureToolCalling) {
+		t.Error("SupportsModel before discovery should use provider-wide support")
+	}
+
+	info, err := p.DescribeModel(context.Background(), "llava")
+	if err != nil {
+		t.Fatalf("DescribeModel() error = %v", err)
+	}
+	if info.ContextWindow != 4096 {
+		t.Errorf("ContextWindow = %d, want 4096", info.ContextWindow)
+	}
+	if !info.HasCapability(core.FeatureVision) || info.SupportsTools() {
+		t.Errorf("Capabilities = %v, Modalities = %v", info.Capabilities, info.Modalities)
+	}
+
+	if p.SupportsModel("llava", core.FeatureToolCalling) {
+		t.Error("SupportsModel after discovery should use discovered capabilities")
+	}
+	if !core.SupportsModel(p, "llava", core.FeatureVision) {
+		t.Error("core.SupportsModel(llava, vision) = false, want true")
+	}
+}
+
+func TestDescribeModelNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error": "model 'nope' not found"}`))
+	}))
+	defer server.Close()
+
+	p := New(WithBaseURL(server.URL))
+	if _, err := p.DescribeModel(context.Background(), "nope"); err == nil {
+		t.Fatal("DescribeModel() expected error")
+	}
+}
+
+func TestNewProviderFromString(t *testing.T) {
+	p, model, err := core.NewProviderFromString("ollama@http://gpu-box:11434/llama3.2")
+	if err != nil {
+		t.Fatalf("NewProviderFromString() error = %v", err)
+	}
+	o, ok := p.(*Ollama)
+	if !ok {
+		t.Fatalf("provider type = %T, want *Ollama", p)
+	}
+	if o.config.BaseURL != "http://gpu-box:11434" {
+		t.Errorf("BaseURL = %q", o.config.BaseURL)
+	}
+	if model != "llama3.2" {
+		t.Errorf("model = %q, want llama3.2", model)
+	}
+}
+
+func TestGenerateImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/images/generations" {
+			t.Errorf("path = %q, want /v1/images/generations", r.URL.Path)
+		}
+		var req ollamaImageRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Model != "x/z-image-turbo" || req.ResponseFormat != "b64_json" || req.Size != "1024x1024" {
+			t.Errorf("request = %+v", req)
+		}
+		w.Write([]byte(`{"created": 1, "data": [{"b64_json": "aW1hZ2U="}]}`))
+	}))
+	defer server.Close()
+
+	p := New(WithBaseURL(server.URL))
+	stream, err := p.StreamImage(context.Background(), &core.ImageGenerateRequest{
+		Model:  "x/z-image-turbo",
+		Prompt: "A lighthouse",
+		Size:   core.ImageSize1024x1024,
+	})
+	if err != nil {
+		t.Fatalf("StreamImage() error = %v", err)
+	}
+	for range stream.Ch {
+	}
+	if err := <-stream.Err; err != nil {
+		t.Fatalf("stream error = %v", err)
+	}
+	resp := <-stream.Final
+	if data, _ := resp.Data[0].GetBytes(); string(data) != "image" {
+		t.Errorf("image = %q, want image", data)
+	}
+}
+
+func TestGenerateImageNotSupported(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	p := New(WithBaseURL(server.URL))
+	_, err := p.GenerateImage(context.Background(), &core.ImageGenerateRequest{Model: "x/z-image-turbo", Prompt: "A lighthouse"})
+	if !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("GenerateImage() error = %v, want ErrNotSupported", err)
+	}
+
+	if _, err := p.EditImage(context.Background(), &core.ImageEditRequest{}); !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("EditImage() error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestMapMessagesToolResultPolicy(t *testing.T) {
+	msgs := []core.Message{{
+		Role:        core.RoleTool,
+		ToolResults: []core.ToolResult{{CallID: "call_1", Content: "plain text"}},
+	}}
+
+	policy := &core.ToolResultPolicy{Format: core.ToolResultFormatJSON}
+	result := mapMessages(msgs, policy)
+	if result[0].Content != `"plain text"` {
+		t.Errorf("Content = %q, want %q", result[0].Content, `"plain text"`)
+	}
+}
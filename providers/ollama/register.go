@@ -1,6 +1,9 @@
 package ollama
 
 import (
+	"cmp"
+	"os"
+
 	"github.com/petal-labs/iris/core"
 	"github.com/petal-labs/iris/providers"
 )
@@ -10,4 +13,20 @@ func init() {
 	providers.Register("ollama", func(apiKey string) core.Provider {
 		return New()
 	})
+
+	core.RegisterProvider("ollama", func(cfg core.ProviderConfig) (core.Provider, error) {
+		// Like NewLocal: the base URL falls back to OLLAMA_HOST, and the API
+		// key is only needed for Ollama Cloud.
+		var opts []Option
+		if baseURL := cmp.Or(cfg.BaseURL, os.Getenv(OllamaHostEnvVar)); baseURL != "" {
+			opts = append(opts, WithBaseURL(baseURL))
+		}
+		if apiKey := cfg.APIKey.Expose(); apiKey != "" {
+			opts = append(opts, WithAPIKey(apiKey))
+		}
+		if cfg.Credentials != nil {
+			opts = append(opts, WithCredentials(cfg.Credentials))
+		}
+		return New(opts...), nil
+	})
 }
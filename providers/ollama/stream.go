@@ -5,6 +5,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -13,6 +14,12 @@ import (
 
 // doStreamChat sends a streaming chat request to the Ollama API.
 func (p *Ollama) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	// Inline remote images, which Ollama cannot fetch itself
+	req, err := p.resolveImages(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Build request body
 	ollamaReq := mapRequest(req, true)
 
@@ -42,7 +49,7 @@ func (p *Ollama) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core
 			Provider: "ollama",
 			Code:     "network_error",
 			Message:  err.Error(),
-			Err:      core.ErrNetwork,
+			Err:      errors.Join(core.ErrNetwork, err),
 		}
 	}
 
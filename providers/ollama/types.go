@@ -14,12 +14,13 @@ type ollamaRequest struct {
 
 // ollamaMessage represents a message in the Ollama chat API.
 type ollamaMessage struct {
-	Role      string           `json:"role"`
-	Content   string           `json:"content"`
-	Images    []string         `json:"images,omitempty"`
-	ToolCalls []ollamaToolCall `json:"tool_calls,omitempty"`
-	Thinking  string           `json:"thinking,omitempty"`
-	ToolName  string           `json:"tool_name,omitempty"`
+	Role       string           `json:"role"`
+	Content    string           `json:"content"`
+	Images     []string         `json:"images,omitempty"`
+	ToolCalls  []ollamaToolCall `json:"tool_calls,omitempty"`
+	Thinking   string           `json:"thinking,omitempty"`
+	ToolName   string           `json:"tool_name,omitempty"`
+	ToolCallID string           `json:"tool_call_id,omitempty"`
 }
 
 // ollamaOptions contains model parameters for the Ollama API.
@@ -49,7 +50,9 @@ type ollamaResponse struct {
 }
 
 // ollamaToolCall represents a tool call from the model.
+// Older Ollama versions omit ID; one is generated when mapping responses.
 type ollamaToolCall struct {
+	ID       string             `json:"id,omitempty"`
 	Function ollamaFunctionCall `json:"function"`
 }
 
@@ -76,3 +79,20 @@ type ollamaToolFunction struct {
 type ollamaErrorResponse struct {
 	Error string `json:"error"`
 }
+
+// ollamaImageRequest is a request to the OpenAI-compatible images endpoint.
+type ollamaImageRequest struct {
+	Model          string `json:"model"`
+	Prompt         string `json:"prompt"`
+	N              int    `json:"n,omitempty"`
+	Size           string `json:"size,omitempty"`
+	ResponseFormat string `json:"response_format,omitempty"`
+}
+
+// ollamaImageResponse is a response from the OpenAI-compatible images endpoint.
+type ollamaImageResponse struct {
+	Created int64 `json:"created"`
+	Data    []struct {
+		B64JSON string `json:"b64_json"`
+	} `json:"data"`
+}
@@ -8,6 +8,8 @@ import (
 	"net/http"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
+	"github.com/petal-labs/iris/providers/internal/outputparts"
 )
 
 // chatCompletionsPath is the API endpoint for chat completions.
@@ -32,7 +34,7 @@ func (p *OpenAI) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatR
 	}
 
 	// Set headers
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildChatHeaders(ctx, req) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -72,8 +74,9 @@ func (p *OpenAI) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatR
 // mapResponse converts an OpenAI response to an Iris ChatResponse.
 func mapResponse(resp *openAIResponse) (*core.ChatResponse, error) {
 	result := &core.ChatResponse{
-		ID:    resp.ID,
-		Model: core.ModelID(resp.Model),
+		ID:                resp.ID,
+		Model:             core.ModelID(resp.Model),
+		SystemFingerprint: resp.SystemFingerprint,
 		Usage: core.TokenUsage{
 			PromptTokens:     resp.Usage.PromptTokens,
 			CompletionTokens: resp.Usage.CompletionTokens,
@@ -85,6 +88,21 @@ func mapResponse(resp *openAIResponse) (*core.ChatResponse, error) {
 	if len(resp.Choices) > 0 {
 		choice := resp.Choices[0]
 		result.Output = choice.Message.Content
+		result.Safety = mapRefusal(choice.Message.Refusal)
+		result.FinishReason = normalize.FinishReason(choice.FinishReason)
+		result.RawFinishReason = choice.FinishReason
+
+		// Map audio output if present
+		if audio := choice.Message.Audio; audio != nil {
+			var parts outputparts.Builder
+			parts.AddText(choice.Message.Content)
+			parts.Add(core.OutputAudio{
+				ID:         audio.ID,
+				Data:       audio.Data,
+				Transcript: audio.Transcript,
+			})
+			result.Parts = parts.Parts()
+		}
 
 		// Map tool calls if present
 		if len(choice.Message.ToolCalls) > 0 {
@@ -99,6 +117,15 @@ func mapResponse(resp *openAIResponse) (*core.ChatResponse, error) {
 	return result, nil
 }
 
+// mapRefusal returns the safety metadata of a refusal, or nil if the model
+// did not refuse.
+func mapRefusal(refusal string) *core.Safety {
+	if refusal == "" {
+		return nil
+	}
+	return &core.Safety{Refusal: refusal}
+}
+
 // mapToolCalls converts OpenAI tool calls to Iris ToolCalls.
 func mapToolCalls(calls []openAIToolCall) ([]core.ToolCall, error) {
 	result := make([]core.ToolCall, len(calls))
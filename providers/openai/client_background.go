@@ -0,0 +1,73 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
+)
+
+// StartBackground submits a request to the Responses API with
+// background=true and returns the queued response. Only models served by the
+// Responses API can run in the background.
+func (p *OpenAI) StartBackground(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	if !p.shouldUseResponsesAPI(req.Model) {
+		return nil, fmt.Errorf("%w: background mode requires a Responses API model, got %s", core.ErrNotSupported, req.Model)
+	}
+
+	if err := checkResponsesParts(req.Messages); err != nil {
+		return nil, err
+	}
+	respReq := buildResponsesRequest(req, false)
+	respReq.Background = true
+
+	body, err := json.Marshal(respReq)
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	var respResp responsesResponse
+	if err := p.doResponsesRequest(ctx, http.MethodPost, responsesPath, body, p.buildChatHeaders(ctx, req), &respResp); err != nil {
+		return nil, err
+	}
+	return mapBackgroundResponse(&respResp)
+}
+
+// RetrieveBackground returns the current state of a background response.
+// A failed response is returned together with a ProviderError wrapping
+// core.ErrBackgroundFailed.
+func (p *OpenAI) RetrieveBackground(ctx context.Context, id string) (*core.ChatResponse, error) {
+	var respResp responsesResponse
+	if err := p.doResponsesRequest(ctx, http.MethodGet, responsePath(id), nil, p.buildHeaders(ctx), &respResp); err != nil {
+		return nil, err
+	}
+	return mapBackgroundResponse(&respResp)
+}
+
+// CancelBackground cancels a queued or in-progress background response.
+func (p *OpenAI) CancelBackground(ctx context.Context, id string) (*core.ChatResponse, error) {
+	var respResp responsesResponse
+	if err := p.doResponsesRequest(ctx, http.MethodPost, responsePath(id)+"/cancel", nil, p.buildHeaders(ctx), &respResp); err != nil {
+		return nil, err
+	}
+	return mapBackgroundResponse(&respResp)
+}
+
+// mapBackgroundResponse maps a background response, turning the error of a
+// failed response into a ProviderError.
+func mapBackgroundResponse(resp *responsesResponse) (*core.ChatResponse, error) {
+	result, err := mapResponsesResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Status == core.ResponseStatusFailed && resp.Error != nil {
+		return result, normalize.ProviderError("openai", 0, "", resp.Error.Code, resp.Error.Message, core.ErrBackgroundFailed)
+	}
+	return result, nil
+}
+
+// Compile-time check that OpenAI implements BackgroundProvider.
+var _ core.BackgroundProvider = (*OpenAI)(nil)
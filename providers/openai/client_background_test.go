@@ -0,0 +1,132 @@
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestStartBackground(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/responses" {
+			t.Errorf("request = %s %s, want POST /responses", r.Method, r.URL.Path)
+		}
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("decode body: %v", err)
+		}
+		if body["background"] != true {
+			t.Errorf("background = %v, want true", body["background"])
+		}
+		if _, ok := body["stream"]; ok {
+			t.Error("stream should be omitted")
+		}
+		json.NewEncoder(w).Encode(responsesResponse{ID: "resp-bg", Model: "gpt-5.2", Status: "queued"})
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.StartBackground(context.Background(), &core.ChatRequest{
+		Model:    ModelGPT52,
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Think hard"}},
+	})
+	if err != nil {
+		t.Fatalf("StartBackground() error = %v", err)
+	}
+	if resp.ID != "resp-bg" || resp.Status != core.ResponseStatusQueued {
+		t.Errorf("resp = %+v, want queued resp-bg", resp)
+	}
+}
+
+func TestStartBackgroundCompletionsModel(t *testing.T) {
+	p := New("test-key", WithBaseURL("http://unused.invalid"))
+	_, err := p.StartBackground(context.Background(), &core.ChatRequest{
+		Model:    ModelGPT4o,
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+	})
+	if !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestRetrieveAndCancelBackground(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/responses/resp-bg":
+			json.NewEncoder(w).Encode(responsesResponse{
+				ID:         "resp-bg",
+				Status:     "completed",
+				OutputText: "42",
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/responses/resp-bg/cancel":
+			json.NewEncoder(w).Encode(responsesResponse{ID: "resp-bg", Status: "cancelled"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	ctx := context.Background()
+
+	resp, err := p.RetrieveBackground(ctx, "resp-bg")
+	if err != nil {
+		t.Fatalf("RetrieveBackground() error = %v", err)
+	}
+	if resp.Status != core.ResponseStatusCompleted || resp.Output != "42" {
+		t.Errorf("resp = %+v, want completed with output 42", resp)
+	}
+
+	resp, err = p.CancelBackground(ctx, "resp-bg")
+	if err != nil {
+		t.Fatalf("CancelBackground() error = %v", err)
+	}
+	if resp.Status != core.ResponseStatusCancelled {
+		t.Errorf("Status = %q, want cancelled", resp.Status)
+	}
+}
+
+func TestRetrieveBackgroundFailed(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(responsesResponse{
+			ID:     "resp-bg",
+			Status: "failed",
+			Error:  &responsesError{Code: "server_error", Message: "model crashed"},
+		})
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.RetrieveBackground(context.Background(), "resp-bg")
+	if !errors.Is(err, core.ErrBackgroundFailed) {
+		t.Fatalf("error = %v, want ErrBackgroundFailed", err)
+	}
+	var pe *core.ProviderError
+	if !errors.As(err, &pe) || pe.Code != "server_error" || pe.Message != "model crashed" {
+		t.Errorf("error = %#v, want provider error with code and message", err)
+	}
+	if resp == nil || resp.Status != core.ResponseStatusFailed {
+		t.Errorf("resp = %+v, want failed response", resp)
+	}
+}
+
+func TestRetrieveBackgroundNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"message":"No response found","type":"invalid_request_error"}}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	_, err := p.RetrieveBackground(context.Background(), "missing")
+	var pe *core.ProviderError
+	if !errors.As(err, &pe) || pe.Status != http.StatusNotFound {
+		t.Errorf("error = %v, want 404 provider error", err)
+	}
+}
@@ -72,7 +72,7 @@ func (p *OpenAI) CreateBatch(ctx context.Context, requests []core.BatchRequest)
 		}
 	}
 
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildHeaders(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -120,7 +120,7 @@ func (p *OpenAI) GetBatchStatus(ctx context.Context, id core.BatchID) (*core.Bat
 		}
 	}
 
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildHeaders(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -207,7 +207,7 @@ func (p *OpenAI) CancelBatch(ctx context.Context, id core.BatchID) error {
 		}
 	}
 
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildHeaders(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -251,7 +251,7 @@ func (p *OpenAI) ListBatches(ctx context.Context, limit int) ([]core.BatchInfo,
 		}
 	}
 
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildHeaders(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -28,7 +28,7 @@ func (p *OpenAI) CreateEmbeddings(ctx context.Context, req *core.EmbeddingReques
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildHeaders(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -54,7 +54,7 @@ func (p *OpenAI) UploadFile(ctx context.Context, req *FileUploadRequest) (*File,
 	}
 
 	// Set headers (but override Content-Type for multipart)
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildHeaders(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -168,7 +168,7 @@ func (p *OpenAI) ListFiles(ctx context.Context, req *FileListRequest) (*FileList
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildHeaders(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -211,7 +211,7 @@ func (p *OpenAI) GetFile(ctx context.Context, fileID string) (*File, error) {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildHeaders(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -255,7 +255,7 @@ func (p *OpenAI) DownloadFile(ctx context.Context, fileID string) (io.ReadCloser
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildHeaders(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -288,7 +288,7 @@ func (p *OpenAI) DeleteFile(ctx context.Context, fileID string) error {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildHeaders(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
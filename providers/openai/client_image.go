@@ -30,7 +30,7 @@ func (p *OpenAI) GenerateImage(ctx context.Context, req *core.ImageGenerateReque
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildHeaders(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -158,7 +158,7 @@ func (p *OpenAI) EditImage(ctx context.Context, req *core.ImageEditRequest) (*co
 	}
 
 	// Set headers (but override Content-Type for multipart)
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildHeaders(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
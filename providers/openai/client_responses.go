@@ -6,8 +6,11 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
 )
 
 // responsesPath is the API endpoint for the Responses API.
@@ -16,6 +19,9 @@ const responsesPath = "/responses"
 // doResponsesChat performs a non-streaming request to the Responses API.
 func (p *OpenAI) doResponsesChat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
 	// Build Responses API request
+	if err := checkResponsesParts(req.Messages); err != nil {
+		return nil, err
+	}
 	respReq := buildResponsesRequest(req, false)
 
 	// Marshal request body
@@ -24,15 +30,89 @@ func (p *OpenAI) doResponsesChat(ctx context.Context, req *core.ChatRequest) (*c
 		return nil, newDecodeError(err)
 	}
 
+	var respResp responsesResponse
+	if err := p.doResponsesRequest(ctx, http.MethodPost, responsesPath, body, p.buildChatHeaders(ctx, req), &respResp); err != nil {
+		return nil, err
+	}
+
+	// Map to Iris response
+	return mapResponsesResponse(&respResp)
+}
+
+// GetResponse retrieves a stored response by ID, for example one referenced
+// by a ContinueFrom chain.
+func (p *OpenAI) GetResponse(ctx context.Context, id string) (*core.ChatResponse, error) {
+	var respResp responsesResponse
+	if err := p.doResponsesRequest(ctx, http.MethodGet, responsePath(id), nil, p.buildHeaders(ctx), &respResp); err != nil {
+		return nil, err
+	}
+	return mapResponsesResponse(&respResp)
+}
+
+// DeleteResponse deletes a stored response. Later requests can no longer
+// continue from it.
+func (p *OpenAI) DeleteResponse(ctx context.Context, id string) error {
+	var result ResponseDeleteResponse
+	if err := p.doResponsesRequest(ctx, http.MethodDelete, responsePath(id), nil, p.buildHeaders(ctx), &result); err != nil {
+		return err
+	}
+	if !result.Deleted {
+		return normalize.ProviderError("openai", http.StatusOK, "", "delete_failed", "response was not deleted", core.ErrBadRequest)
+	}
+	return nil
+}
+
+// ListResponseInputItems returns the input items of a stored response, oldest
+// first. All pages are fetched.
+func (p *OpenAI) ListResponseInputItems(ctx context.Context, id string) ([]ResponseInputItem, error) {
+	var items []ResponseInputItem
+	after := ""
+	for {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(inputItemsPageSize))
+		query.Set("order", "asc")
+		if after != "" {
+			query.Set("after", after)
+		}
+
+		var page responseInputItemList
+		path := responsePath(id) + "/input_items?" + query.Encode()
+		if err := p.doResponsesRequest(ctx, http.MethodGet, path, nil, p.buildHeaders(ctx), &page); err != nil {
+			return nil, err
+		}
+		items = append(items, page.Data...)
+
+		if !page.HasMore || page.LastID == "" {
+			return items, nil
+		}
+		after = page.LastID
+	}
+}
+
+// inputItemsPageSize is the page size used by ListResponseInputItems.
+const inputItemsPageSize = 100
+
+// responsePath returns the path of a stored response.
+func responsePath(id string) string {
+	return responsesPath + "/" + url.PathEscape(id)
+}
+
+// doResponsesRequest sends a request to a Responses API endpoint and decodes
+// the JSON result into out. body may be nil.
+func (p *OpenAI) doResponsesRequest(ctx context.Context, method, path string, body []byte, headers http.Header, out any) error {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
 	// Create HTTP request
-	url := p.config.BaseURL + responsesPath
-	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	httpReq, err := http.NewRequestWithContext(ctx, method, p.config.BaseURL+path, reader)
 	if err != nil {
-		return nil, newNetworkError(err)
+		return newNetworkError(err)
 	}
 
 	// Set headers
-	for key, values := range p.buildHeaders() {
+	for key, values := range headers {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -41,14 +121,14 @@ func (p *OpenAI) doResponsesChat(ctx context.Context, req *core.ChatRequest) (*c
 	// Execute request
 	resp, err := p.config.HTTPClient.Do(httpReq)
 	if err != nil {
-		return nil, newNetworkError(err)
+		return newNetworkError(err)
 	}
 	defer resp.Body.Close()
 
 	// Read response body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, newNetworkError(err)
+		return newNetworkError(err)
 	}
 
 	// Extract request ID from response headers
@@ -56,15 +136,12 @@ func (p *OpenAI) doResponsesChat(ctx context.Context, req *core.ChatRequest) (*c
 
 	// Check for error status
 	if resp.StatusCode >= 400 {
-		return nil, normalizeError(resp.StatusCode, respBody, requestID)
+		return normalizeError(resp.StatusCode, respBody, requestID)
 	}
 
 	// Parse response
-	var respResp responsesResponse
-	if err := json.Unmarshal(respBody, &respResp); err != nil {
-		return nil, newDecodeError(err)
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return newDecodeError(err)
 	}
-
-	// Map to Iris response
-	return mapResponsesResponse(&respResp)
+	return nil
 }
@@ -808,3 +808,130 @@ func TestResponsesAPIMultimodalFileWithID(t *testing.T) {
 		t.Errorf("Output = %q, want expected text", resp.Output)
 	}
 }
+
+func TestGetResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/responses/resp-123" {
+			t.Errorf("request = %s %s, want GET /responses/resp-123", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(responsesResponse{
+			ID:         "resp-123",
+			Model:      "gpt-5.2",
+			Status:     "completed",
+			OutputText: "Stored answer",
+		})
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.GetResponse(context.Background(), "resp-123")
+	if err != nil {
+		t.Fatalf("GetResponse() error = %v", err)
+	}
+	if resp.ID != "resp-123" || resp.Output != "Stored answer" {
+		t.Errorf("resp = %+v", resp)
+	}
+}
+
+func TestDeleteResponse(t *testing.T) {
+	deleted := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/responses/resp-123" {
+			t.Errorf("request = %s %s, want DELETE /responses/resp-123", r.Method, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(ResponseDeleteResponse{ID: "resp-123", Object: "response", Deleted: deleted})
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	if err := p.DeleteResponse(context.Background(), "resp-123"); err != nil {
+		t.Fatalf("DeleteResponse() error = %v", err)
+	}
+
+	deleted = false
+	if err := p.DeleteResponse(context.Background(), "resp-123"); !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("DeleteResponse() error = %v, want ErrBadRequest", err)
+	}
+}
+
+func TestDeleteResponseNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"message":"Response not found","type":"invalid_request_error"}}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	err := p.DeleteResponse(context.Background(), "missing")
+	var pe *core.ProviderError
+	if !errors.As(err, &pe) || pe.Status != http.StatusNotFound {
+		t.Errorf("DeleteResponse() error = %v, want 404 provider error", err)
+	}
+}
+
+func TestListResponseInputItems(t *testing.T) {
+	var afters []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/responses/resp-123/input_items" {
+			t.Errorf("Path = %q", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("order"); got != "asc" {
+			t.Errorf("order = %q, want asc", got)
+		}
+		after := r.URL.Query().Get("after")
+		afters = append(afters, after)
+
+		if after == "" {
+			w.Write([]byte(`{"object":"list","data":[
+				{"id":"msg_1","type":"message","role":"user","status":"completed",
+				 "content":[{"type":"input_text","text":"What is 2+2?"}]}
+			],"first_id":"msg_1","last_id":"msg_1","has_more":true}`))
+			return
+		}
+		w.Write([]byte(`{"object":"list","data":[
+			{"id":"fco_1","type":"function_call_output","call_id":"call_1","output":"4"}
+		],"first_id":"fco_1","last_id":"fco_1","has_more":false}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	items, err := p.ListResponseInputItems(context.Background(), "resp-123")
+	if err != nil {
+		t.Fatalf("ListResponseInputItems() error = %v", err)
+	}
+
+	if len(afters) != 2 || afters[1] != "msg_1" {
+		t.Errorf("after params = %v, want [\"\" msg_1]", afters)
+	}
+	if len(items) != 2 {
+		t.Fatalf("len(items) = %d, want 2", len(items))
+	}
+	if items[0].Role != "user" || len(items[0].Content) != 1 || items[0].Content[0].Text != "What is 2+2?" {
+		t.Errorf("items[0] = %+v", items[0])
+	}
+	if items[1].CallID != "call_1" || !strings.Contains(string(items[1].Raw), `"output":"4"`) {
+		t.Errorf("items[1] = %+v, Raw = %s", items[1], items[1].Raw)
+	}
+}
+
+func TestResponsesAPIAudioNotSent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	req := &core.ChatRequest{
+		Model: ModelGPT52,
+		Messages: []core.Message{{
+			Role:  core.RoleUser,
+			Parts: []core.ContentPart{&core.InputAudio{Data: "UklGRg==", Format: core.AudioFormatWAV}},
+		}},
+	}
+	if _, err := p.Chat(context.Background(), req); !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("Chat() error = %v, want ErrBadRequest", err)
+	}
+	if _, err := p.StreamChat(context.Background(), req); !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("StreamChat() error = %v, want ErrBadRequest", err)
+	}
+}
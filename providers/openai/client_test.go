@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/petal-labs/iris/core"
@@ -341,6 +342,45 @@ func TestChatWithOrgAndProject(t *testing.T) {
 	}
 }
 
+func TestChatThroughGateway(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/litellm/v1/chat/completions" {
+			t.Errorf("path = %q, want %q", r.URL.Path, "/litellm/v1/chat/completions")
+		}
+		if r.URL.Query().Get("team") != "research" {
+			t.Errorf("team = %q, want %q", r.URL.Query().Get("team"), "research")
+		}
+		if r.Header.Get("X-Gateway-Auth") != "Bearer test-key" {
+			t.Errorf("X-Gateway-Auth = %q, want the provider key", r.Header.Get("X-Gateway-Auth"))
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(openAIResponse{
+			ID:      "chatcmpl-test",
+			Model:   "gpt-4o",
+			Choices: []openAIChoice{{Message: openAIRespMsg{Content: "OK"}}},
+		})
+	}))
+	defer server.Close()
+
+	p := New("test-key",
+		WithBaseURL(server.URL+"/v1"),
+		WithPathPrefix("/litellm"),
+		WithQueryParams(url.Values{"team": {"research"}}),
+		WithRequestEditor(func(r *http.Request) {
+			r.Header.Set("X-Gateway-Auth", r.Header.Get("Authorization"))
+		}),
+	)
+
+	_, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:    "gpt-4o",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Test"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+}
+
 func TestMapResponseEmptyChoices(t *testing.T) {
 	resp := &openAIResponse{
 		ID:      "chatcmpl-empty",
@@ -406,3 +446,126 @@ func TestMapToolCallsInvalidJSON(t *testing.T) {
 		t.Errorf("expected ErrToolArgsInvalidJSON, got %v", err)
 	}
 }
+
+func TestMapResponseWithAudio(t *testing.T) {
+	resp := &openAIResponse{
+		ID:    "chatcmpl-audio",
+		Model: "gpt-4o-audio-preview",
+		Choices: []openAIChoice{
+			{
+				Message: openAIRespMsg{
+					Role: "assistant",
+					Audio: &openAIRespAudio{
+						ID:         "audio_abc",
+						Data:       "UklGRg==",
+						Transcript: "Hello there",
+					},
+				},
+			},
+		},
+	}
+
+	result, err := mapResponse(resp)
+	if err != nil {
+		t.Fatalf("mapResponse() error = %v", err)
+	}
+
+	audio := result.Audio()
+	if len(audio) != 1 {
+		t.Fatalf("len(Audio()) = %d, want 1", len(audio))
+	}
+	if audio[0].ID != "audio_abc" || audio[0].Data != "UklGRg==" || audio[0].Transcript != "Hello there" {
+		t.Errorf("Audio()[0] = %+v", audio[0])
+	}
+	if len(result.Parts) != 1 {
+		t.Errorf("len(Parts) = %d, want 1 (no text content)", len(result.Parts))
+	}
+}
+
+func TestMapResponseWithRefusal(t *testing.T) {
+	resp := &openAIResponse{
+		ID:    "chatcmpl-refusal",
+		Model: "gpt-4o",
+		Choices: []openAIChoice{
+			{Message: openAIRespMsg{Role: "assistant", Refusal: "I can't help with that."}},
+		},
+	}
+
+	result, err := mapResponse(resp)
+	if err != nil {
+		t.Fatalf("mapResponse() error = %v", err)
+	}
+	if result.Safety == nil || result.Safety.Refusal != "I can't help with that." {
+		t.Errorf("Safety = %+v, want refusal", result.Safety)
+	}
+
+	resp.Choices[0].Message = openAIRespMsg{Role: "assistant", Content: "Hello"}
+	result, err = mapResponse(resp)
+	if err != nil {
+		t.Fatalf("mapResponse() error = %v", err)
+	}
+	if result.Safety != nil {
+		t.Errorf("Safety = %+v, want nil", result.Safety)
+	}
+}
+
+func TestMapResponseFinishReason(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want core.FinishReason
+	}{
+		{"stop", core.FinishStop},
+		{"length", core.FinishLength},
+		{"tool_calls", core.FinishToolCalls},
+		{"content_filter", core.FinishContentFilter},
+		{"", core.FinishUnknown},
+	}
+
+	for _, tt := range tests {
+		resp := &openAIResponse{
+			Choices: []openAIChoice{
+				{Message: openAIRespMsg{Role: "assistant", Content: "Hi"}, FinishReason: tt.raw},
+			},
+		}
+		result, err := mapResponse(resp)
+		if err != nil {
+			t.Fatalf("mapResponse() error = %v", err)
+		}
+		if result.FinishReason != tt.want || result.RawFinishReason != tt.raw {
+			t.Errorf("finish_reason %q: FinishReason = %q, RawFinishReason = %q", tt.raw, result.FinishReason, result.RawFinishReason)
+		}
+	}
+}
+
+func TestChatIdempotencyKeyAndSystemFingerprint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Idempotency-Key"); got != "key-123" {
+			t.Errorf("Idempotency-Key = %q, want %q", got, "key-123")
+		}
+
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(openAIResponse{
+			ID:                "chatcmpl-fp",
+			Model:             "gpt-4o",
+			SystemFingerprint: "fp_44709d6fcb",
+			Choices: []openAIChoice{
+				{Message: openAIRespMsg{Role: "assistant", Content: "Hi"}},
+			},
+		})
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:          "gpt-4o",
+		Messages:       []core.Message{{Role: core.RoleUser, Content: "Hello"}},
+		IdempotencyKey: "key-123",
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	if resp.SystemFingerprint != "fp_44709d6fcb" {
+		t.Errorf("SystemFingerprint = %q, want %q", resp.SystemFingerprint, "fp_44709d6fcb")
+	}
+}
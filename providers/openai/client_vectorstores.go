@@ -15,8 +15,8 @@ import (
 )
 
 // buildHeadersWithBeta returns headers with the OpenAI-Beta header set.
-func (p *OpenAI) buildHeadersWithBeta() http.Header {
-	headers := p.buildHeaders()
+func (p *OpenAI) buildHeadersWithBeta(ctx context.Context) http.Header {
+	headers := p.buildHeaders(ctx)
 	headers.Set("OpenAI-Beta", "assistants=v2")
 	return headers
 }
@@ -34,7 +34,7 @@ func (p *OpenAI) CreateVectorStore(ctx context.Context, req *VectorStoreCreateRe
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeadersWithBeta() {
+	for key, values := range p.buildHeadersWithBeta(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -96,7 +96,7 @@ func (p *OpenAI) ListVectorStores(ctx context.Context, req *VectorStoreListReque
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeadersWithBeta() {
+	for key, values := range p.buildHeadersWithBeta(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -139,7 +139,7 @@ func (p *OpenAI) GetVectorStore(ctx context.Context, vectorStoreID string) (*Vec
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeadersWithBeta() {
+	for key, values := range p.buildHeadersWithBeta(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -182,7 +182,7 @@ func (p *OpenAI) DeleteVectorStore(ctx context.Context, vectorStoreID string) er
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeadersWithBeta() {
+	for key, values := range p.buildHeadersWithBeta(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -238,7 +238,7 @@ func (p *OpenAI) AddFileToVectorStore(ctx context.Context, vectorStoreID string,
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeadersWithBeta() {
+	for key, values := range p.buildHeadersWithBeta(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -303,7 +303,7 @@ func (p *OpenAI) ListVectorStoreFiles(ctx context.Context, vectorStoreID string,
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeadersWithBeta() {
+	for key, values := range p.buildHeadersWithBeta(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -346,7 +346,7 @@ func (p *OpenAI) GetVectorStoreFile(ctx context.Context, vectorStoreID, fileID s
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeadersWithBeta() {
+	for key, values := range p.buildHeadersWithBeta(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -389,7 +389,7 @@ func (p *OpenAI) DeleteVectorStoreFile(ctx context.Context, vectorStoreID, fileI
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeadersWithBeta() {
+	for key, values := range p.buildHeadersWithBeta(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
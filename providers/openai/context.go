@@ -0,0 +1,38 @@
+package openai
+
+import "context"
+
+type organizationKey struct{}
+
+type projectKey struct{}
+
+// ContextWithOrganization returns a context that sends org in the
+// OpenAI-Organization header of requests made with it, overriding
+// WithOrganization. It lets one provider bill requests to several
+// organizations.
+func ContextWithOrganization(ctx context.Context, org string) context.Context {
+	return context.WithValue(ctx, organizationKey{}, org)
+}
+
+// ContextWithProject returns a context that sends project in the
+// OpenAI-Project header of requests made with it, overriding WithProject.
+// It lets one provider bill requests to several projects.
+func ContextWithProject(ctx context.Context, project string) context.Context {
+	return context.WithValue(ctx, projectKey{}, project)
+}
+
+// organizationFromContext returns the organization set on ctx, or def.
+func organizationFromContext(ctx context.Context, def string) string {
+	if org, ok := ctx.Value(organizationKey{}).(string); ok && org != "" {
+		return org
+	}
+	return def
+}
+
+// projectFromContext returns the project set on ctx, or def.
+func projectFromContext(ctx context.Context, def string) string {
+	if project, ok := ctx.Value(projectKey{}).(string); ok && project != "" {
+		return project
+	}
+	return def
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/toolchoice"
 	"github.com/petal-labs/iris/tools"
 )
 
@@ -14,7 +15,8 @@ type schemaProvider interface {
 }
 
 // mapMessages converts Iris messages to OpenAI message format.
-func mapMessages(msgs []core.Message) []openAIMessage {
+// Tool result content is serialized according to policy (nil uses the default).
+func mapMessages(msgs []core.Message, policy *core.ToolResultPolicy) []openAIMessage {
 	result := make([]openAIMessage, 0, len(msgs))
 
 	for _, msg := range msgs {
@@ -22,7 +24,7 @@ func mapMessages(msgs []core.Message) []openAIMessage {
 		case core.RoleTool:
 			// Tool result messages: expand into individual messages per result
 			for _, tr := range msg.ToolResults {
-				content := marshalToolResultContent(tr.Content)
+				content := policy.Serialize(tr.Content)
 				result = append(result, openAIMessage{
 					Role:       "tool",
 					Content:    content,
@@ -46,6 +48,7 @@ func mapMessages(msgs []core.Message) []openAIMessage {
 			result = append(result, openAIMessage{
 				Role:    string(msg.Role),
 				Content: msg.Content,
+				Parts:   mapContentParts(msg),
 			})
 		}
 	}
@@ -53,6 +56,62 @@ func mapMessages(msgs []core.Message) []openAIMessage {
 	return result
 }
 
+// mapContentParts converts multimodal message parts to Chat Completions
+// content parts. It returns nil for text-only messages.
+func mapContentParts(msg core.Message) []openAIContentPart {
+	if len(msg.Parts) == 0 {
+		return nil
+	}
+
+	parts := make([]openAIContentPart, 0, len(msg.Parts)+1)
+	if msg.Content != "" {
+		parts = append(parts, openAIContentPart{Type: "text", Text: msg.Content})
+	}
+	for _, part := range msg.Parts {
+		switch p := contentPartValue(part).(type) {
+		case core.InputText:
+			parts = append(parts, openAIContentPart{Type: "text", Text: p.Text})
+		case core.InputImage:
+			// Chat Completions only accepts images by URL; file IDs require the Responses API.
+			if p.ImageURL == "" {
+				continue
+			}
+			parts = append(parts, openAIContentPart{
+				Type:     "image_url",
+				ImageURL: &openAIImageURLPart{URL: p.ImageURL, Detail: string(p.Detail)},
+			})
+		case core.InputAudio:
+			parts = append(parts, openAIContentPart{
+				Type:       "input_audio",
+				InputAudio: &openAIInputAudioPart{Data: p.Data, Format: string(p.Format)},
+			})
+		case core.InputFile:
+			parts = append(parts, openAIContentPart{
+				Type: "file",
+				File: &openAIFilePart{FileID: p.FileID, FileData: p.FileData, Filename: p.Filename},
+			})
+		}
+	}
+	return parts
+}
+
+// contentPartValue returns the value form of the built-in content parts.
+// MessageBuilder stores parts as pointers, but callers building messages
+// directly may use values; both are accepted.
+func contentPartValue(part core.ContentPart) core.ContentPart {
+	switch p := part.(type) {
+	case *core.InputText:
+		return *p
+	case *core.InputImage:
+		return *p
+	case *core.InputFile:
+		return *p
+	case *core.InputAudio:
+		return *p
+	}
+	return part
+}
+
 // mapToolCallsToOpenAI converts Iris ToolCalls to OpenAI format.
 func mapToolCallsToOpenAI(calls []core.ToolCall) []openAIToolCall {
 	result := make([]openAIToolCall, len(calls))
@@ -69,20 +128,6 @@ func mapToolCallsToOpenAI(calls []core.ToolCall) []openAIToolCall {
 	return result
 }
 
-// marshalToolResultContent converts tool result content to a JSON string.
-func marshalToolResultContent(content any) string {
-	switch v := content.(type) {
-	case string:
-		return v
-	default:
-		data, err := json.Marshal(v)
-		if err != nil {
-			return "{\"error\": \"failed to marshal tool result\"}"
-		}
-		return string(data)
-	}
-}
-
 // mapTools converts Iris tools to OpenAI tool format.
 // Tools that implement schemaProvider will have their schema included.
 func mapTools(irisTools []core.Tool) []openAITool {
@@ -120,7 +165,7 @@ func mapTools(irisTools []core.Tool) []openAITool {
 func buildRequest(req *core.ChatRequest, stream bool) *openAIRequest {
 	oaiReq := &openAIRequest{
 		Model:    string(req.Model),
-		Messages: mapMessages(req.Messages),
+		Messages: mapMessages(req.Messages, req.ToolResultPolicy),
 		Stream:   stream,
 	}
 
@@ -136,7 +181,8 @@ func buildRequest(req *core.ChatRequest, stream bool) *openAIRequest {
 	// Map tools if present
 	if len(req.Tools) > 0 {
 		oaiReq.Tools = mapTools(req.Tools)
-		oaiReq.ToolChoice = "auto"
+		oaiReq.ToolChoice = toolchoice.OpenAI(req.ToolChoice)
+		oaiReq.ParallelToolCalls = req.ParallelToolCalls
 	}
 
 	// Map response format for structured output
@@ -2,8 +2,10 @@ package openai
 
 import (
 	"encoding/json"
+	"fmt"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/outputparts"
 	"github.com/petal-labs/iris/tools"
 )
 
@@ -42,13 +44,21 @@ func buildResponsesRequest(req *core.ChatRequest, stream bool) *responsesRequest
 		respReq.PreviousResponseID = req.PreviousResponseID
 	}
 
-	// Set truncation mode
+	// Set truncation mode; computer use requires automatic truncation
 	if req.Truncation != "" {
 		respReq.Truncation = req.Truncation
+	} else if hasComputerUse(req.BuiltInTools) {
+		respReq.Truncation = "auto"
 	}
 
+	// Set storage options
+	respReq.Store = req.Store
+	respReq.Metadata = req.Metadata
+
 	// Map tools (both custom and built-in)
 	respReq.Tools = mapResponsesTools(req.Tools, req.BuiltInTools)
+	respReq.ToolChoice = mapResponsesToolChoice(req.ToolChoice)
+	respReq.ParallelToolCalls = req.ParallelToolCalls
 
 	// Map tool resources
 	if req.ToolResources != nil && req.ToolResources.FileSearch != nil {
@@ -93,6 +103,14 @@ func buildResponsesInput(msgs []core.Message, instructions string) responsesInpu
 			continue
 		}
 
+		// Computer call results are separate input items
+		for _, cr := range msg.ComputerResults {
+			messages = append(messages, responsesInputMessage{ComputerOutput: mapComputerCallOutput(cr)})
+		}
+		if len(msg.ComputerResults) > 0 && msg.Content == "" && len(msg.Parts) == 0 {
+			continue
+		}
+
 		role := string(msg.Role)
 		// Responses API uses "developer" instead of "system" for system messages
 		if msg.Role == core.RoleSystem {
@@ -103,7 +121,9 @@ func buildResponsesInput(msgs []core.Message, instructions string) responsesInpu
 		if len(msg.Parts) > 0 {
 			parts := make([]responsesContentPart, 0, len(msg.Parts))
 			for _, part := range msg.Parts {
-				parts = append(parts, mapContentPart(part))
+				if cp, ok := mapContentPart(part); ok {
+					parts = append(parts, cp)
+				}
 			}
 			messages = append(messages, responsesInputMessage{
 				Role:    role,
@@ -120,15 +140,17 @@ func buildResponsesInput(msgs []core.Message, instructions string) responsesInpu
 	return responsesInput{Messages: messages}
 }
 
-// mapContentPart converts a core.ContentPart to a responsesContentPart.
-func mapContentPart(part core.ContentPart) responsesContentPart {
-	switch p := part.(type) {
-	case *core.InputText:
+// mapContentPart converts a core.ContentPart to a responsesContentPart. It
+// reports false for parts the Responses API has no input type for, such as
+// audio.
+func mapContentPart(part core.ContentPart) (responsesContentPart, bool) {
+	switch p := contentPartValue(part).(type) {
+	case core.InputText:
 		return responsesContentPart{
 			Type: "input_text",
 			Text: p.Text,
-		}
-	case *core.InputImage:
+		}, true
+	case core.InputImage:
 		cp := responsesContentPart{
 			Type:     "input_image",
 			ImageURL: p.ImageURL,
@@ -137,18 +159,59 @@ func mapContentPart(part core.ContentPart) responsesContentPart {
 		if p.Detail != "" {
 			cp.Detail = string(p.Detail)
 		}
-		return cp
-	case *core.InputFile:
+		return cp, true
+	case core.InputFile:
 		return responsesContentPart{
 			Type:     "input_file",
 			FileID:   p.FileID,
 			FileURL:  p.FileURL,
 			FileData: p.FileData,
 			Filename: p.Filename,
-		}
+		}, true
 	default:
-		return responsesContentPart{}
+		return responsesContentPart{}, false
+	}
+}
+
+// checkResponsesParts returns an error for message parts the Responses API
+// cannot take, rather than dropping them from the request.
+func checkResponsesParts(msgs []core.Message) error {
+	for _, msg := range msgs {
+		for _, part := range msg.Parts {
+			if _, ok := mapContentPart(part); ok {
+				continue
+			}
+			message := fmt.Sprintf("%s content is not supported by the Responses API", part.ContentType())
+			if _, ok := contentPartValue(part).(core.InputAudio); ok {
+				message += "; use an audio model on Chat Completions, such as gpt-4o-audio-preview"
+			}
+			return &core.ProviderError{
+				Provider: "openai",
+				Code:     "invalid_request",
+				Message:  message,
+				Err:      core.ErrBadRequest,
+			}
+		}
+	}
+	return nil
+}
+
+// responsesToolChoiceFunction forces a specific function in the Responses API.
+type responsesToolChoiceFunction struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// mapResponsesToolChoice converts a tool choice to the Responses API format.
+// The Responses API flattens the function name into the choice object.
+func mapResponsesToolChoice(choice *core.ToolChoice) any {
+	if choice == nil {
+		return nil
+	}
+	if choice.Mode == core.ToolChoiceTool {
+		return responsesToolChoiceFunction{Type: "function", Name: choice.Name}
 	}
+	return string(choice.Mode)
 }
 
 // mapResponsesTools converts Iris tools and built-in tools to Responses API format.
@@ -157,9 +220,16 @@ func mapResponsesTools(irisTools []core.Tool, builtInTools []core.BuiltInTool) [
 
 	// Add built-in tools first
 	for _, t := range builtInTools {
-		result = append(result, responsesTool{
-			Type: t.Type,
-		})
+		switch {
+		case t.Type == "computer_use":
+			result = append(result, mapComputerUseTool(t.Computer))
+		case t.Type == "web_search" && t.WebSearch != nil:
+			result = append(result, mapWebSearchTool(t.WebSearch))
+		default:
+			result = append(result, responsesTool{
+				Type: t.Type,
+			})
+		}
 	}
 
 	// Add custom function tools
@@ -216,13 +286,22 @@ func mapResponsesResponse(resp *responsesResponse) (*core.ChatResponse, error) {
 	// Process output items
 	var toolCalls []core.ToolCall
 	var reasoningSummaries []string
+	var refusal string
+	var parts outputparts.Builder
 
 	for _, item := range resp.Output {
 		switch item.Type {
 		case "message":
 			// Extract text content from message
 			for _, content := range item.Content {
+				if content.Type == "refusal" {
+					refusal += content.Refusal
+				}
 				if content.Type == "output_text" || content.Type == "text" {
+					parts.AddText(content.Text)
+					if resp.OutputText != "" {
+						continue
+					}
 					if result.Output == "" {
 						result.Output = content.Text
 					} else {
@@ -239,6 +318,15 @@ func mapResponsesResponse(resp *responsesResponse) (*core.ChatResponse, error) {
 				}
 			}
 
+		case "image_generation_call":
+			// Extract generated image
+			if item.Result != "" {
+				parts.Add(mapImageGenerationOutput(&item))
+			}
+
+		case "computer_call":
+			result.ComputerCalls = append(result.ComputerCalls, mapComputerCall(&item))
+
 		case "function_call":
 			// Extract function call
 			if !json.Valid([]byte(item.Arguments)) {
@@ -257,6 +345,10 @@ func mapResponsesResponse(resp *responsesResponse) (*core.ChatResponse, error) {
 		result.ToolCalls = toolCalls
 	}
 
+	result.Parts = parts.Parts()
+	result.Safety = mapRefusal(refusal)
+	result.FinishReason, result.RawFinishReason = mapResponsesFinishReason(resp.Status, resp.IncompleteDetails, result)
+
 	// Set reasoning output if any
 	if len(reasoningSummaries) > 0 {
 		result.Reasoning = &core.ReasoningOutput{
@@ -266,3 +358,132 @@ func mapResponsesResponse(resp *responsesResponse) (*core.ChatResponse, error) {
 
 	return result, nil
 }
+
+// mapResponsesFinishReason derives the finish reason of a Responses API
+// response, which has none of its own, from its status. The raw reason is
+// the status, or the reason given for an incomplete response.
+func mapResponsesFinishReason(status string, incomplete *incompleteInfo, result *core.ChatResponse) (core.FinishReason, string) {
+	switch status {
+	case "completed":
+		if len(result.ToolCalls) > 0 || len(result.ComputerCalls) > 0 {
+			return core.FinishToolCalls, status
+		}
+		return core.FinishStop, status
+	case "incomplete":
+		if incomplete == nil || incomplete.Reason == "" {
+			return core.FinishUnknown, status
+		}
+		switch incomplete.Reason {
+		case "max_output_tokens":
+			return core.FinishLength, incomplete.Reason
+		case "content_filter":
+			return core.FinishContentFilter, incomplete.Reason
+		default:
+			return core.FinishUnknown, incomplete.Reason
+		}
+	case "cancelled":
+		return core.FinishCanceled, status
+	default:
+		return core.FinishUnknown, ""
+	}
+}
+
+// mapImageGenerationOutput converts an image_generation_call item to an output part.
+func mapImageGenerationOutput(item *responsesOutput) core.OutputImage {
+	format := item.OutputFormat
+	if format == "" {
+		format = "png"
+	}
+	return core.OutputImage{
+		MimeType: "image/" + format,
+		Data:     item.Result,
+	}
+}
+
+// hasComputerUse reports whether tools include the computer_use tool.
+func hasComputerUse(tools []core.BuiltInTool) bool {
+	for _, t := range tools {
+		if t.Type == "computer_use" {
+			return true
+		}
+	}
+	return false
+}
+
+// mapComputerUseTool converts a computer_use configuration to the
+// computer_use_preview tool.
+func mapComputerUseTool(cfg *core.ComputerUseConfig) responsesTool {
+	tool := responsesTool{Type: "computer_use_preview"}
+	if cfg != nil {
+		tool.DisplayWidth = cfg.DisplayWidth
+		tool.DisplayHeight = cfg.DisplayHeight
+		tool.Environment = string(cfg.Environment)
+	}
+	return tool
+}
+
+// mapComputerCall converts a computer_call output item to a ComputerCall.
+func mapComputerCall(item *responsesOutput) core.ComputerCall {
+	call := core.ComputerCall{
+		ID:     item.ID,
+		CallID: item.CallID,
+	}
+	if a := item.Action; a != nil {
+		call.Action = core.ComputerAction{
+			Type:    core.ComputerActionType(a.Type),
+			X:       a.X,
+			Y:       a.Y,
+			Button:  a.Button,
+			ScrollX: a.ScrollX,
+			ScrollY: a.ScrollY,
+			Text:    a.Text,
+			Keys:    a.Keys,
+		}
+		for _, p := range a.Path {
+			call.Action.Path = append(call.Action.Path, core.ComputerPoint{X: p.X, Y: p.Y})
+		}
+	}
+	for _, sc := range item.PendingSafetyChecks {
+		call.PendingSafetyChecks = append(call.PendingSafetyChecks, core.ComputerSafetyCheck(sc))
+	}
+	return call
+}
+
+// mapComputerCallOutput converts a ComputerCallOutput to an input item.
+func mapComputerCallOutput(out core.ComputerCallOutput) *responsesComputerCallOutput {
+	item := &responsesComputerCallOutput{
+		Type:   "computer_call_output",
+		CallID: out.CallID,
+		Output: responsesComputerScreenshot{
+			Type:     "computer_screenshot",
+			ImageURL: out.Screenshot,
+		},
+		CurrentURL: out.CurrentURL,
+	}
+	for _, sc := range out.AcknowledgedSafetyChecks {
+		item.AcknowledgedSafetyChecks = append(item.AcknowledgedSafetyChecks, responsesSafetyCheck(sc))
+	}
+	return item
+}
+
+// mapWebSearchTool converts web search options to the web_search tool.
+// The Responses API has no domain blocklist, so BlockedDomains is ignored.
+func mapWebSearchTool(opts *core.WebSearchOptions) responsesTool {
+	tool := responsesTool{
+		Type:              "web_search",
+		SearchContextSize: string(opts.ContextSize),
+	}
+	if len(opts.AllowedDomains) > 0 {
+		tool.Filters = &responsesSearchFilters{AllowedDomains: opts.AllowedDomains}
+	}
+	if loc := opts.UserLocation; loc != nil {
+		tool.UserLocation = &responsesSearchLocation{
+			Type:     "approximate",
+			Country:  loc.Country,
+			Region:   loc.Region,
+			City:     loc.City,
+			Timezone: loc.Timezone,
+		}
+	}
+	return tool
+}
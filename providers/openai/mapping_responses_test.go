@@ -3,6 +3,8 @@ package openai
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"strings"
 	"testing"
 
 	"github.com/petal-labs/iris/core"
@@ -295,6 +297,68 @@ func TestMapResponsesResponseBasic(t *testing.T) {
 	}
 }
 
+func TestMapResponsesResponseRefusal(t *testing.T) {
+	resp := &responsesResponse{
+		ID:     "resp-refusal",
+		Model:  "gpt-5.2",
+		Status: "completed",
+		Output: []responsesOutput{
+			{
+				Type: "message",
+				Role: "assistant",
+				Content: []responsesMessageContent{
+					{Type: "refusal", Refusal: "I can't help with that."},
+				},
+			},
+		},
+	}
+
+	result, err := mapResponsesResponse(resp)
+	if err != nil {
+		t.Fatalf("mapResponsesResponse() error = %v", err)
+	}
+	if result.Safety == nil || result.Safety.Refusal != "I can't help with that." {
+		t.Errorf("Safety = %+v, want refusal", result.Safety)
+	}
+}
+
+func TestMapResponsesFinishReason(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     string
+		incomplete *incompleteInfo
+		toolCalls  bool
+		want       core.FinishReason
+		wantRaw    string
+	}{
+		{"completed", "completed", nil, false, core.FinishStop, "completed"},
+		{"tool calls", "completed", nil, true, core.FinishToolCalls, "completed"},
+		{"max tokens", "incomplete", &incompleteInfo{Reason: "max_output_tokens"}, false, core.FinishLength, "max_output_tokens"},
+		{"content filter", "incomplete", &incompleteInfo{Reason: "content_filter"}, false, core.FinishContentFilter, "content_filter"},
+		{"cancelled", "cancelled", nil, false, core.FinishCanceled, "cancelled"},
+		{"in progress", "in_progress", nil, false, core.FinishUnknown, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := &responsesResponse{Status: tt.status, IncompleteDetails: tt.incomplete}
+			if tt.toolCalls {
+				resp.Output = []responsesOutput{
+					{Type: "function_call", CallID: "call_1", Name: "lookup", Arguments: `{}`},
+				}
+			}
+
+			result, err := mapResponsesResponse(resp)
+			if err != nil {
+				t.Fatalf("mapResponsesResponse() error = %v", err)
+			}
+			if result.FinishReason != tt.want || result.RawFinishReason != tt.wantRaw {
+				t.Errorf("FinishReason = %q, RawFinishReason = %q, want %q, %q", result.FinishReason, result.RawFinishReason, tt.want, tt.wantRaw)
+			}
+		})
+	}
+}
+
 func TestMapResponsesResponseWithReasoning(t *testing.T) {
 	resp := &responsesResponse{
 		ID:         "resp-reason",
@@ -466,6 +530,42 @@ func TestBuildResponsesRequestWithToolResources(t *testing.T) {
 	}
 }
 
+func TestBuildResponsesRequestStoreAndMetadata(t *testing.T) {
+	store := false
+	req := &core.ChatRequest{
+		Model:    "gpt-5",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+		Store:    &store,
+		Metadata: map[string]string{"ticket": "T-42"},
+	}
+
+	data, err := json.Marshal(buildResponsesRequest(req, false))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var body map[string]any
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if body["store"] != false {
+		t.Errorf("store = %v, want false", body["store"])
+	}
+	if md, ok := body["metadata"].(map[string]any); !ok || md["ticket"] != "T-42" {
+		t.Errorf("metadata = %v, want ticket T-42", body["metadata"])
+	}
+
+	// Unset fields are omitted so the API default applies
+	data, _ = json.Marshal(buildResponsesRequest(&core.ChatRequest{Model: "gpt-5"}, false))
+	body = nil
+	json.Unmarshal(data, &body)
+	if _, ok := body["store"]; ok {
+		t.Error("store should be omitted when unset")
+	}
+	if _, ok := body["metadata"]; ok {
+		t.Error("metadata should be omitted when unset")
+	}
+}
+
 func TestBuildResponsesRequestWithoutToolResources(t *testing.T) {
 	req := &core.ChatRequest{
 		Model: "gpt-4.1-mini",
@@ -555,6 +655,19 @@ func TestBuildResponsesInputMultimodal(t *testing.T) {
 			},
 			wantJSON: `[{"role":"user","content":[{"type":"input_image","image_url":"https://example.com/cat.jpg","detail":"high"}]}]`,
 		},
+		{
+			name: "value parts",
+			messages: []core.Message{
+				{
+					Role: core.RoleUser,
+					Parts: []core.ContentPart{
+						core.InputText{Text: "Analyze this document"},
+						core.InputFile{FileID: "file-abc123"},
+					},
+				},
+			},
+			wantJSON: `[{"role":"user","content":[{"type":"input_text","text":"Analyze this document"},{"type":"input_file","file_id":"file-abc123"}]}]`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -570,3 +683,250 @@ func TestBuildResponsesInputMultimodal(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckResponsesPartsRejectsAudio(t *testing.T) {
+	for _, part := range []core.ContentPart{
+		&core.InputAudio{Data: "UklGRg==", Format: core.AudioFormatWAV},
+		core.InputAudio{Data: "UklGRg==", Format: core.AudioFormatWAV},
+	} {
+		err := checkResponsesParts([]core.Message{{
+			Role:  core.RoleUser,
+			Parts: []core.ContentPart{&core.InputText{Text: "Transcribe this"}, part},
+		}})
+		if !errors.Is(err, core.ErrBadRequest) || !strings.Contains(err.Error(), "input_audio") {
+			t.Errorf("checkResponsesParts(%T) error = %v, want ErrBadRequest for input_audio", part, err)
+		}
+	}
+
+	if err := checkResponsesParts([]core.Message{{
+		Role:  core.RoleUser,
+		Parts: []core.ContentPart{&core.InputText{Text: "hi"}, core.InputImage{ImageURL: "https://example.com/a.png"}},
+	}}); err != nil {
+		t.Errorf("checkResponsesParts() error = %v for supported parts", err)
+	}
+}
+
+func TestMapResponsesResponseWithGeneratedImage(t *testing.T) {
+	resp := &responsesResponse{
+		ID:     "resp-img",
+		Model:  "gpt-5.2",
+		Status: "completed",
+		Output: []responsesOutput{
+			{
+				Type:    "message",
+				Content: []responsesMessageContent{{Type: "output_text", Text: "Here is your image."}},
+			},
+			{
+				Type:         "image_generation_call",
+				ID:           "ig_123",
+				Result:       "aW1n",
+				OutputFormat: "webp",
+			},
+		},
+	}
+
+	result, err := mapResponsesResponse(resp)
+	if err != nil {
+		t.Fatalf("mapResponsesResponse() error = %v", err)
+	}
+
+	if result.Output != "Here is your image." {
+		t.Errorf("Output = %q, want %q", result.Output, "Here is your image.")
+	}
+	if len(result.Parts) != 2 {
+		t.Fatalf("len(Parts) = %d, want 2", len(result.Parts))
+	}
+	images := result.Images()
+	if len(images) != 1 {
+		t.Fatalf("len(Images()) = %d, want 1", len(images))
+	}
+	if images[0].MimeType != "image/webp" || images[0].Data != "aW1n" {
+		t.Errorf("Images()[0] = %+v", images[0])
+	}
+}
+
+func TestMapResponsesResponseOutputTextNotDuplicated(t *testing.T) {
+	resp := &responsesResponse{
+		OutputText: "Hello",
+		Output: []responsesOutput{
+			{
+				Type:    "message",
+				Content: []responsesMessageContent{{Type: "output_text", Text: "Hello"}},
+			},
+		},
+	}
+
+	result, err := mapResponsesResponse(resp)
+	if err != nil {
+		t.Fatalf("mapResponsesResponse() error = %v", err)
+	}
+	if result.Output != "Hello" {
+		t.Errorf("Output = %q, want %q", result.Output, "Hello")
+	}
+}
+
+func TestBuildResponsesRequestWithToolChoice(t *testing.T) {
+	parallel := true
+	req := &core.ChatRequest{
+		Model:             "gpt-5.2",
+		Messages:          []core.Message{{Role: core.RoleUser, Content: "Weather?"}},
+		Tools:             []core.Tool{&mockTool{name: "get_weather", description: "Get weather"}},
+		ToolChoice:        &core.ToolChoice{Mode: core.ToolChoiceTool, Name: "get_weather"},
+		ParallelToolCalls: &parallel,
+	}
+
+	body, err := json.Marshal(buildResponsesRequest(req, false))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := string(decoded["tool_choice"]); got != `{"type":"function","name":"get_weather"}` {
+		t.Errorf("tool_choice = %s", got)
+	}
+	if got := string(decoded["parallel_tool_calls"]); got != "true" {
+		t.Errorf("parallel_tool_calls = %s, want true", got)
+	}
+
+	// Without a choice the provider default applies.
+	req.ToolChoice = nil
+	if tc := buildResponsesRequest(req, false).ToolChoice; tc != nil {
+		t.Errorf("ToolChoice = %v, want nil", tc)
+	}
+}
+
+func TestBuildResponsesRequestComputerUse(t *testing.T) {
+	req := &core.ChatRequest{
+		Model: ModelComputerUsePreview,
+		Messages: []core.Message{{
+			Role: core.RoleTool,
+			ComputerResults: []core.ComputerCallOutput{{
+				CallID:                   "call_1",
+				Screenshot:               "data:image/png;base64,AAAA",
+				AcknowledgedSafetyChecks: []core.ComputerSafetyCheck{{ID: "sc_1", Code: "malicious_instructions"}},
+				CurrentURL:               "https://example.com",
+			}},
+		}},
+		BuiltInTools: []core.BuiltInTool{{
+			Type: "computer_use",
+			Computer: &core.ComputerUseConfig{
+				DisplayWidth:  1024,
+				DisplayHeight: 768,
+				Environment:   core.ComputerEnvironmentBrowser,
+			},
+		}},
+		PreviousResponseID: "resp_1",
+	}
+
+	data, err := json.Marshal(buildResponsesRequest(req, false))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var body struct {
+		Truncation string           `json:"truncation"`
+		Tools      []map[string]any `json:"tools"`
+		Input      []map[string]any `json:"input"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if body.Truncation != "auto" {
+		t.Errorf("truncation = %q, want auto", body.Truncation)
+	}
+	if len(body.Tools) != 1 {
+		t.Fatalf("len(tools) = %d, want 1", len(body.Tools))
+	}
+	tool := body.Tools[0]
+	if tool["type"] != "computer_use_preview" || tool["display_width"] != float64(1024) ||
+		tool["display_height"] != float64(768) || tool["environment"] != "browser" {
+		t.Errorf("tool = %v", tool)
+	}
+
+	if len(body.Input) != 1 {
+		t.Fatalf("len(input) = %d, want 1: %s", len(body.Input), data)
+	}
+	item := body.Input[0]
+	if item["type"] != "computer_call_output" || item["call_id"] != "call_1" || item["current_url"] != "https://example.com" {
+		t.Errorf("input item = %v", item)
+	}
+	if _, ok := item["role"]; ok {
+		t.Error("computer_call_output should not have a role")
+	}
+	output, _ := item["output"].(map[string]any)
+	if output["type"] != "computer_screenshot" || output["image_url"] != "data:image/png;base64,AAAA" {
+		t.Errorf("output = %v", output)
+	}
+	checks, _ := item["acknowledged_safety_checks"].([]any)
+	if len(checks) != 1 {
+		t.Errorf("acknowledged_safety_checks = %v", item["acknowledged_safety_checks"])
+	}
+}
+
+func TestMapResponsesResponseComputerCall(t *testing.T) {
+	var resp responsesResponse
+	err := json.Unmarshal([]byte(`{
+		"id": "resp_1",
+		"status": "completed",
+		"output": [
+			{"type": "reasoning", "id": "rs_1", "summary": []},
+			{"type": "computer_call", "id": "cu_1", "call_id": "call_1", "status": "completed",
+			 "action": {"type": "click", "button": "left", "x": 156, "y": 50},
+			 "pending_safety_checks": [{"id": "sc_1", "code": "irrelevant_domain", "message": "Check the domain"}]},
+			{"type": "computer_call", "id": "cu_2", "call_id": "call_2",
+			 "action": {"type": "drag", "path": [{"x": 1, "y": 2}, {"x": 3, "y": 4}]}}
+		]
+	}`), &resp)
+	if err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	result, err := mapResponsesResponse(&resp)
+	if err != nil {
+		t.Fatalf("mapResponsesResponse() error = %v", err)
+	}
+	if len(result.ComputerCalls) != 2 {
+		t.Fatalf("len(ComputerCalls) = %d, want 2", len(result.ComputerCalls))
+	}
+
+	click := result.ComputerCalls[0]
+	if click.CallID != "call_1" || click.Action.Type != core.ComputerActionClick ||
+		click.Action.Button != "left" || click.Action.X != 156 || click.Action.Y != 50 {
+		t.Errorf("click = %+v", click)
+	}
+	if len(click.PendingSafetyChecks) != 1 || click.PendingSafetyChecks[0].Code != "irrelevant_domain" {
+		t.Errorf("PendingSafetyChecks = %+v", click.PendingSafetyChecks)
+	}
+
+	drag := result.ComputerCalls[1].Action
+	if drag.Type != core.ComputerActionDrag || len(drag.Path) != 2 || drag.Path[1] != (core.ComputerPoint{X: 3, Y: 4}) {
+		t.Errorf("drag = %+v", drag)
+	}
+}
+
+func TestMapResponsesToolsWebSearchOptions(t *testing.T) {
+	tools := mapResponsesTools(nil, []core.BuiltInTool{
+		{Type: "web_search"},
+		{Type: "web_search", WebSearch: &core.WebSearchOptions{
+			AllowedDomains: []string{"arxiv.org"},
+			BlockedDomains: []string{"example.com"},
+			UserLocation:   &core.WebSearchLocation{Country: "US", City: "Chicago", Timezone: "America/Chicago"},
+			ContextSize:    core.WebSearchContextHigh,
+		}},
+	})
+
+	data, err := json.Marshal(tools)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	want := `[{"type":"web_search"},` +
+		`{"type":"web_search","filters":{"allowed_domains":["arxiv.org"]},` +
+		`"user_location":{"type":"approximate","country":"US","city":"Chicago","timezone":"America/Chicago"},` +
+		`"search_context_size":"high"}]`
+	if string(data) != want {
+		t.Errorf("tools =\n%s\nwant\n%s", data, want)
+	}
+}
@@ -3,6 +3,7 @@ package openai
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/petal-labs/iris/core"
@@ -14,7 +15,7 @@ func TestMapMessagesSystem(t *testing.T) {
 		{Role: core.RoleSystem, Content: "You are a helpful assistant."},
 	}
 
-	result := mapMessages(msgs)
+	result := mapMessages(msgs, nil)
 
 	if len(result) != 1 {
 		t.Fatalf("len(result) = %d, want 1", len(result))
@@ -34,7 +35,7 @@ func TestMapMessagesUser(t *testing.T) {
 		{Role: core.RoleUser, Content: "Hello!"},
 	}
 
-	result := mapMessages(msgs)
+	result := mapMessages(msgs, nil)
 
 	if result[0].Role != "user" {
 		t.Errorf("Role = %q, want %q", result[0].Role, "user")
@@ -46,7 +47,7 @@ func TestMapMessagesAssistant(t *testing.T) {
 		{Role: core.RoleAssistant, Content: "Hi there!"},
 	}
 
-	result := mapMessages(msgs)
+	result := mapMessages(msgs, nil)
 
 	if result[0].Role != "assistant" {
 		t.Errorf("Role = %q, want %q", result[0].Role, "assistant")
@@ -60,7 +61,7 @@ func TestMapMessagesMultiple(t *testing.T) {
 		{Role: core.RoleAssistant, Content: "Assistant reply"},
 	}
 
-	result := mapMessages(msgs)
+	result := mapMessages(msgs, nil)
 
 	if len(result) != 3 {
 		t.Fatalf("len(result) = %d, want 3", len(result))
@@ -86,7 +87,7 @@ func TestMapMessagesMultiple(t *testing.T) {
 }
 
 func TestMapMessagesEmpty(t *testing.T) {
-	result := mapMessages(nil)
+	result := mapMessages(nil, nil)
 
 	if len(result) != 0 {
 		t.Errorf("len(result) = %d, want 0", len(result))
@@ -345,8 +346,8 @@ func TestBuildRequestWithoutTools(t *testing.T) {
 		t.Errorf("len(Tools) = %d, want 0", len(result.Tools))
 	}
 
-	if result.ToolChoice != "" {
-		t.Errorf("ToolChoice = %q, want empty", result.ToolChoice)
+	if result.ToolChoice != nil {
+		t.Errorf("ToolChoice = %v, want nil", result.ToolChoice)
 	}
 }
 
@@ -530,3 +531,143 @@ func TestBuildRequestWithResponseFormat(t *testing.T) {
 		t.Errorf("ResponseFormat.Type = %q, want %q", result.ResponseFormat.Type, "json_schema")
 	}
 }
+
+func TestMapMessagesMultimodalParts(t *testing.T) {
+	msgs := []core.Message{{
+		Role: core.RoleUser,
+		Parts: []core.ContentPart{
+			&core.InputText{Text: "Transcribe and describe"},
+			&core.InputImage{ImageURL: "https://example.com/a.png", Detail: core.ImageDetailLow},
+			&core.InputImage{FileID: "file-123"},
+			&core.InputAudio{Data: "UklGRg==", Format: core.AudioFormatWAV},
+		},
+	}}
+
+	result := mapMessages(msgs, nil)
+	data, err := json.Marshal(result[0])
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded struct {
+		Role    string `json:"role"`
+		Content []struct {
+			Type       string `json:"type"`
+			Text       string `json:"text"`
+			ImageURL   *struct{ URL, Detail string }
+			InputAudio *struct{ Data, Format string } `json:"input_audio"`
+		} `json:"content"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("content is not an array: %s", data)
+	}
+
+	if len(decoded.Content) != 3 {
+		t.Fatalf("expected 3 parts (file ID image skipped), got %d: %s", len(decoded.Content), data)
+	}
+	if decoded.Content[0].Type != "text" || decoded.Content[0].Text != "Transcribe and describe" {
+		t.Errorf("text part = %+v", decoded.Content[0])
+	}
+	if decoded.Content[1].Type != "image_url" {
+		t.Errorf("image part type = %q", decoded.Content[1].Type)
+	}
+	audio := decoded.Content[2]
+	if audio.Type != "input_audio" || audio.InputAudio == nil || audio.InputAudio.Format != "wav" {
+		t.Errorf("audio part = %s", data)
+	}
+}
+
+func TestMapMessagesValueParts(t *testing.T) {
+	pointers := []core.ContentPart{
+		&core.InputText{Text: "Transcribe"},
+		&core.InputImage{ImageURL: "https://example.com/a.png"},
+		&core.InputAudio{Data: "UklGRg==", Format: core.AudioFormatWAV},
+		&core.InputFile{FileID: "file-123"},
+	}
+	values := []core.ContentPart{
+		core.InputText{Text: "Transcribe"},
+		core.InputImage{ImageURL: "https://example.com/a.png"},
+		core.InputAudio{Data: "UklGRg==", Format: core.AudioFormatWAV},
+		core.InputFile{FileID: "file-123"},
+	}
+
+	want, _ := json.Marshal(mapMessages([]core.Message{{Role: core.RoleUser, Parts: pointers}}, nil))
+	got, _ := json.Marshal(mapMessages([]core.Message{{Role: core.RoleUser, Parts: values}}, nil))
+	if string(got) != string(want) {
+		t.Errorf("value parts = %s, want %s", got, want)
+	}
+	if !strings.Contains(string(got), `"input_audio"`) || !strings.Contains(string(got), `"file"`) {
+		t.Errorf("parts missing: %s", got)
+	}
+}
+
+func TestMapMessagesTextOnlyContentIsString(t *testing.T) {
+	data, err := json.Marshal(mapMessages([]core.Message{{Role: core.RoleUser, Content: "hi"}}, nil)[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"role":"user","content":"hi"}` {
+		t.Errorf("Marshal() = %s", data)
+	}
+}
+
+func TestBuildRequestWithToolChoice(t *testing.T) {
+	tool := &mockBasicTool{name: "get_weather", description: "Get weather"}
+	parallel := false
+
+	req := &core.ChatRequest{
+		Model: "gpt-4o",
+		Messages: []core.Message{
+			{Role: core.RoleUser, Content: "Weather?"},
+		},
+		Tools:             []core.Tool{tool},
+		ToolChoice:        &core.ToolChoice{Mode: core.ToolChoiceTool, Name: "get_weather"},
+		ParallelToolCalls: &parallel,
+	}
+
+	body, err := json.Marshal(buildRequest(req, false))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded map[string]json.RawMessage
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got := string(decoded["tool_choice"]); got != `{"type":"function","function":{"name":"get_weather"}}` {
+		t.Errorf("tool_choice = %s", got)
+	}
+	if got := string(decoded["parallel_tool_calls"]); got != "false" {
+		t.Errorf("parallel_tool_calls = %s, want false", got)
+	}
+}
+
+func TestBuildRequestToolChoiceRequired(t *testing.T) {
+	req := &core.ChatRequest{
+		Model:      "gpt-4o",
+		Messages:   []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+		Tools:      []core.Tool{&mockBasicTool{name: "t", description: "d"}},
+		ToolChoice: &core.ToolChoice{Mode: core.ToolChoiceRequired},
+	}
+
+	result := buildRequest(req, false)
+	if result.ToolChoice != "required" {
+		t.Errorf("ToolChoice = %v, want required", result.ToolChoice)
+	}
+	if result.ParallelToolCalls != nil {
+		t.Errorf("ParallelToolCalls = %v, want nil", *result.ParallelToolCalls)
+	}
+}
+
+func TestMapMessagesToolResultPolicy(t *testing.T) {
+	msgs := []core.Message{{
+		Role:        core.RoleTool,
+		ToolResults: []core.ToolResult{{CallID: "call_1", Content: "plain text"}},
+	}}
+
+	policy := &core.ToolResultPolicy{Format: core.ToolResultFormatJSON}
+	result := mapMessages(msgs, policy)
+	if result[0].Content != `"plain text"` {
+		t.Errorf("Content = %q, want %q", result[0].Content, `"plain text"`)
+	}
+}
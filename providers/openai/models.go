@@ -49,6 +49,9 @@ const (
 	ModelO1                 core.ModelID = "o1"
 	ModelO1Pro              core.ModelID = "o1-pro"
 
+	// Computer use
+	ModelComputerUsePreview core.ModelID = "computer-use-preview"
+
 	// Image generation models
 	ModelGPTImage15         core.ModelID = "gpt-image-1.5"
 	ModelGPTImage1          core.ModelID = "gpt-image-1"
@@ -62,9 +65,12 @@ const (
 var models = []core.ModelInfo{
 	// GPT-5.2 series (Responses API with reasoning and built-in tools)
 	{
-		ID:          ModelGPT52,
-		DisplayName: "GPT-5.2",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT52,
+		DisplayName:     "GPT-5.2",
+		ContextWindow:   400000,
+		MaxOutputTokens: 128000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -75,9 +81,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT52Pro,
-		DisplayName: "GPT-5.2 Pro",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT52Pro,
+		DisplayName:     "GPT-5.2 Pro",
+		ContextWindow:   400000,
+		MaxOutputTokens: 128000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -88,9 +97,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT52Codex,
-		DisplayName: "GPT-5.2 Codex",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT52Codex,
+		DisplayName:     "GPT-5.2 Codex",
+		ContextWindow:   400000,
+		MaxOutputTokens: 128000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -102,9 +114,12 @@ var models = []core.ModelInfo{
 	},
 	// GPT-5.1 series (Responses API with reasoning and built-in tools)
 	{
-		ID:          ModelGPT51,
-		DisplayName: "GPT-5.1",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT51,
+		DisplayName:     "GPT-5.1",
+		ContextWindow:   400000,
+		MaxOutputTokens: 128000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -115,9 +130,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT51Codex,
-		DisplayName: "GPT-5.1 Codex",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT51Codex,
+		DisplayName:     "GPT-5.1 Codex",
+		ContextWindow:   400000,
+		MaxOutputTokens: 128000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -128,9 +146,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT51CodexMini,
-		DisplayName: "GPT-5.1 Codex Mini",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT51CodexMini,
+		DisplayName:     "GPT-5.1 Codex Mini",
+		ContextWindow:   400000,
+		MaxOutputTokens: 128000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -141,9 +162,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT51CodexMax,
-		DisplayName: "GPT-5.1 Codex Max",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT51CodexMax,
+		DisplayName:     "GPT-5.1 Codex Max",
+		ContextWindow:   400000,
+		MaxOutputTokens: 128000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -155,9 +179,12 @@ var models = []core.ModelInfo{
 	},
 	// GPT-5 series (Responses API with reasoning and built-in tools)
 	{
-		ID:          ModelGPT5,
-		DisplayName: "GPT-5",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT5,
+		DisplayName:     "GPT-5",
+		ContextWindow:   400000,
+		MaxOutputTokens: 128000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -168,9 +195,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT5Mini,
-		DisplayName: "GPT-5 Mini",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT5Mini,
+		DisplayName:     "GPT-5 Mini",
+		ContextWindow:   400000,
+		MaxOutputTokens: 128000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -181,9 +211,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT5Nano,
-		DisplayName: "GPT-5 Nano",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT5Nano,
+		DisplayName:     "GPT-5 Nano",
+		ContextWindow:   400000,
+		MaxOutputTokens: 128000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -193,9 +226,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT5Pro,
-		DisplayName: "GPT-5 Pro",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT5Pro,
+		DisplayName:     "GPT-5 Pro",
+		ContextWindow:   400000,
+		MaxOutputTokens: 272000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -206,9 +242,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT5Codex,
-		DisplayName: "GPT-5 Codex",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT5Codex,
+		DisplayName:     "GPT-5 Codex",
+		ContextWindow:   400000,
+		MaxOutputTokens: 128000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -220,9 +259,12 @@ var models = []core.ModelInfo{
 	},
 	// GPT-4.1 series (Responses API without reasoning)
 	{
-		ID:          ModelGPT41,
-		DisplayName: "GPT-4.1",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT41,
+		DisplayName:     "GPT-4.1",
+		ContextWindow:   1047576,
+		MaxOutputTokens: 32768,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -232,9 +274,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT41Mini,
-		DisplayName: "GPT-4.1 Mini",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT41Mini,
+		DisplayName:     "GPT-4.1 Mini",
+		ContextWindow:   1047576,
+		MaxOutputTokens: 32768,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -244,9 +289,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT41Nano,
-		DisplayName: "GPT-4.1 Nano",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelGPT41Nano,
+		DisplayName:     "GPT-4.1 Nano",
+		ContextWindow:   1047576,
+		MaxOutputTokens: 32768,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -257,9 +305,12 @@ var models = []core.ModelInfo{
 	},
 	// GPT-4o series (Chat Completions API)
 	{
-		ID:          ModelGPT4o,
-		DisplayName: "GPT-4o",
-		APIEndpoint: core.APIEndpointCompletions,
+		ID:              ModelGPT4o,
+		DisplayName:     "GPT-4o",
+		ContextWindow:   128000,
+		MaxOutputTokens: 16384,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointCompletions,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -267,9 +318,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT4oMini,
-		DisplayName: "GPT-4o Mini",
-		APIEndpoint: core.APIEndpointCompletions,
+		ID:              ModelGPT4oMini,
+		DisplayName:     "GPT-4o Mini",
+		ContextWindow:   128000,
+		MaxOutputTokens: 16384,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointCompletions,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -278,9 +332,12 @@ var models = []core.ModelInfo{
 	},
 	// GPT-4 series (Chat Completions API)
 	{
-		ID:          ModelGPT4Turbo,
-		DisplayName: "GPT-4 Turbo",
-		APIEndpoint: core.APIEndpointCompletions,
+		ID:              ModelGPT4Turbo,
+		DisplayName:     "GPT-4 Turbo",
+		ContextWindow:   128000,
+		MaxOutputTokens: 4096,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointCompletions,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -288,9 +345,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT4,
-		DisplayName: "GPT-4",
-		APIEndpoint: core.APIEndpointCompletions,
+		ID:              ModelGPT4,
+		DisplayName:     "GPT-4",
+		ContextWindow:   8192,
+		MaxOutputTokens: 8192,
+		Modalities:      []core.Modality{core.ModalityText},
+		APIEndpoint:     core.APIEndpointCompletions,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -299,9 +359,12 @@ var models = []core.ModelInfo{
 	},
 	// GPT-3.5 series (Chat Completions API)
 	{
-		ID:          ModelGPT35Turbo,
-		DisplayName: "GPT-3.5 Turbo",
-		APIEndpoint: core.APIEndpointCompletions,
+		ID:              ModelGPT35Turbo,
+		DisplayName:     "GPT-3.5 Turbo",
+		ContextWindow:   16385,
+		MaxOutputTokens: 4096,
+		Modalities:      []core.Modality{core.ModalityText},
+		APIEndpoint:     core.APIEndpointCompletions,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -309,9 +372,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT35Turbo16k,
-		DisplayName: "GPT-3.5 Turbo 16k",
-		APIEndpoint: core.APIEndpointCompletions,
+		ID:              ModelGPT35Turbo16k,
+		DisplayName:     "GPT-3.5 Turbo 16k",
+		ContextWindow:   16385,
+		MaxOutputTokens: 4096,
+		Modalities:      []core.Modality{core.ModalityText},
+		APIEndpoint:     core.APIEndpointCompletions,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -319,9 +385,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelGPT35TurboInstruct,
-		DisplayName: "GPT-3.5 Turbo Instruct",
-		APIEndpoint: core.APIEndpointCompletions,
+		ID:              ModelGPT35TurboInstruct,
+		DisplayName:     "GPT-3.5 Turbo Instruct",
+		ContextWindow:   4096,
+		MaxOutputTokens: 4096,
+		Modalities:      []core.Modality{core.ModalityText},
+		APIEndpoint:     core.APIEndpointCompletions,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -329,9 +398,12 @@ var models = []core.ModelInfo{
 	},
 	// Reasoning models (o-series) - Responses API with reasoning
 	{
-		ID:          ModelO4Mini,
-		DisplayName: "o4-mini",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelO4Mini,
+		DisplayName:     "o4-mini",
+		ContextWindow:   200000,
+		MaxOutputTokens: 100000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -342,9 +414,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelO4MiniDeepResearch,
-		DisplayName: "o4-mini Deep Research",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelO4MiniDeepResearch,
+		DisplayName:     "o4-mini Deep Research",
+		ContextWindow:   200000,
+		MaxOutputTokens: 100000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -355,9 +430,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelO3,
-		DisplayName: "o3",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelO3,
+		DisplayName:     "o3",
+		ContextWindow:   200000,
+		MaxOutputTokens: 100000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -368,9 +446,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelO3Mini,
-		DisplayName: "o3-mini",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelO3Mini,
+		DisplayName:     "o3-mini",
+		ContextWindow:   200000,
+		MaxOutputTokens: 100000,
+		Modalities:      []core.Modality{core.ModalityText},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -381,9 +462,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelO1,
-		DisplayName: "o1",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelO1,
+		DisplayName:     "o1",
+		ContextWindow:   200000,
+		MaxOutputTokens: 100000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -393,9 +477,12 @@ var models = []core.ModelInfo{
 		},
 	},
 	{
-		ID:          ModelO1Pro,
-		DisplayName: "o1 Pro",
-		APIEndpoint: core.APIEndpointResponses,
+		ID:              ModelO1Pro,
+		DisplayName:     "o1 Pro",
+		ContextWindow:   200000,
+		MaxOutputTokens: 100000,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
 		Capabilities: []core.Feature{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
@@ -404,6 +491,21 @@ var models = []core.ModelInfo{
 			core.FeatureResponseChain,
 		},
 	},
+	// Computer use (Responses API with the computer_use built-in tool)
+	{
+		ID:              ModelComputerUsePreview,
+		DisplayName:     "Computer Use Preview",
+		ContextWindow:   8192,
+		MaxOutputTokens: 1024,
+		Modalities:      []core.Modality{core.ModalityText, core.ModalityImage},
+		APIEndpoint:     core.APIEndpointResponses,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureBuiltInTools,
+			core.FeatureResponseChain,
+		},
+	},
 	// Image generation models
 	{
 		ID:          ModelGPTImage15,
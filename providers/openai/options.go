@@ -2,6 +2,7 @@ package openai
 
 import (
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/petal-labs/iris/core"
@@ -16,9 +17,33 @@ type Config struct {
 	// BaseURL is the API base URL. Defaults to https://api.openai.com/v1
 	BaseURL string
 
-	// HTTPClient is the HTTP client to use. Defaults to http.DefaultClient.
+	// HTTPClient is the HTTP client to use. Defaults to a client with pooled
+	// connections shared by all providers. When set, Transport and Proxy are
+	// ignored.
 	HTTPClient *http.Client
 
+	// Transport replaces the default transport, for example to tune
+	// connection pooling or add instrumentation.
+	Transport http.RoundTripper
+
+	// Proxy routes requests through a proxy instead of the one configured by
+	// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY.
+	Proxy *url.URL
+
+	// Credentials, if set, supplies the API key for each request instead of
+	// APIKey, so keys can rotate without a restart.
+	Credentials core.CredentialProvider
+
+	// PathPrefix is inserted before the path of every request URL, for
+	// gateways that route by path.
+	PathPrefix string
+
+	// QueryParams are set on every request URL.
+	QueryParams url.Values
+
+	// RequestEditors are called on every request before it is sent.
+	RequestEditors []func(*http.Request)
+
 	// OrgID is the optional OpenAI organization ID.
 	OrgID string
 
@@ -30,6 +55,10 @@ type Config struct {
 
 	// Timeout is the optional request timeout.
 	Timeout time.Duration
+
+	// StreamResumeAttempts is the number of times a Responses API stream
+	// that fails mid-response is reconnected. Zero disables resuming.
+	StreamResumeAttempts int
 }
 
 // DefaultBaseURL is the default OpenAI API base URL.
@@ -52,20 +81,93 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
-// WithOrgID sets the OpenAI organization ID header.
-func WithOrgID(org string) Option {
+// WithTransport sets the transport used by the default HTTP client.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Config) {
+		c.Transport = rt
+	}
+}
+
+// WithProxy routes requests through the proxy at proxyURL.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Config) {
+		c.Proxy = proxyURL
+	}
+}
+
+// WithCredentials resolves the API key from creds for every request instead
+// of using a fixed key. A request rejected with 401 is retried once after
+// refreshing creds, if it implements core.CredentialRefresher.
+func WithCredentials(creds core.CredentialProvider) Option {
+	return func(c *Config) {
+		c.Credentials = creds
+	}
+}
+
+// WithPathPrefix inserts prefix before the path of every request URL, for
+// gateways such as LiteLLM or Portkey that route by path. With prefix
+// "/team-a", a request for https://gateway.example.com/v1/chat/completions
+// is sent to https://gateway.example.com/team-a/v1/chat/completions.
+func WithPathPrefix(prefix string) Option {
+	return func(c *Config) {
+		c.PathPrefix = prefix
+	}
+}
+
+// WithQueryParams sets query parameters on every request URL, such as a
+// version or routing parameter required by a gateway. Repeated calls add to
+// the parameters.
+func WithQueryParams(params url.Values) Option {
+	return func(c *Config) {
+		if c.QueryParams == nil {
+			c.QueryParams = make(url.Values)
+		}
+		for name, values := range params {
+			c.QueryParams[name] = append(c.QueryParams[name], values...)
+		}
+	}
+}
+
+// WithRequestEditor calls fn on every request before it is sent, after
+// authentication and other headers are set. fn may modify the request but
+// must not read or replace its body. Repeated calls add editors, which run
+// in order.
+func WithRequestEditor(fn func(*http.Request)) Option {
+	return func(c *Config) {
+		c.RequestEditors = append(c.RequestEditors, fn)
+	}
+}
+
+// WithOrganization sets the organization sent in the OpenAI-Organization
+// header, which selects the organization requests are billed to. Use
+// ContextWithOrganization to override it for a single request.
+func WithOrganization(org string) Option {
 	return func(c *Config) {
 		c.OrgID = org
 	}
 }
 
-// WithProjectID sets the OpenAI project ID header.
-func WithProjectID(project string) Option {
+// WithProject sets the project sent in the OpenAI-Project header, which
+// selects the project requests are billed to. Use ContextWithProject to
+// override it for a single request.
+func WithProject(project string) Option {
 	return func(c *Config) {
 		c.ProjectID = project
 	}
 }
 
+// WithOrgID sets the OpenAI organization ID header. It is equivalent to
+// WithOrganization.
+func WithOrgID(org string) Option {
+	return WithOrganization(org)
+}
+
+// WithProjectID sets the OpenAI project ID header. It is equivalent to
+// WithProject.
+func WithProjectID(project string) Option {
+	return WithProject(project)
+}
+
 // WithHeader adds an extra header to include in requests.
 func WithHeader(key, value string) Option {
 	return func(c *Config) {
@@ -76,9 +178,28 @@ func WithHeader(key, value string) Option {
 	}
 }
 
-// WithTimeout sets the request timeout.
+// WithTimeout sets the default request timeout, which core.Client applies
+// to requests that have no context deadline or Timeout of their own.
 func WithTimeout(d time.Duration) Option {
 	return func(c *Config) {
 		c.Timeout = d
 	}
 }
+
+// WithStreamResume makes Responses API streams reconnect up to attempts
+// times when their connection fails mid-response, continuing after the last
+// event received. Resuming needs a stored background response, so streamed
+// requests run with background=true unless they set Store to false; each
+// resume is reported to a core.ResumeTelemetryHook.
+//
+// Background responses are stored by OpenAI, subject to its data retention
+// for stored responses, and run on OpenAI's side independently of the
+// connection, so they are billed even if no one reads them. When a stream
+// ends early, because its context is canceled or the resume attempts run
+// out, the response is canceled so it stops generating; tokens produced
+// before the cancellation are still billed.
+func WithStreamResume(attempts int) Option {
+	return func(c *Config) {
+		c.StreamResumeAttempts = attempts
+	}
+}
@@ -2,8 +2,11 @@ package openai
 
 import (
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
+
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 func TestWithBaseURL(t *testing.T) {
@@ -43,6 +46,32 @@ func TestWithProjectID(t *testing.T) {
 	}
 }
 
+func TestWithOrganizationAndProject(t *testing.T) {
+	cfg := Config{}
+	WithOrganization("org-billing")(&cfg)
+	WithProject("proj-billing")(&cfg)
+
+	if cfg.OrgID != "org-billing" {
+		t.Errorf("OrgID = %q, want %q", cfg.OrgID, "org-billing")
+	}
+	if cfg.ProjectID != "proj-billing" {
+		t.Errorf("ProjectID = %q, want %q", cfg.ProjectID, "proj-billing")
+	}
+}
+
+func TestWithQueryParams(t *testing.T) {
+	cfg := Config{}
+	WithQueryParams(url.Values{"team": {"a"}})(&cfg)
+	WithQueryParams(url.Values{"team": {"b"}, "region": {"eu"}})(&cfg)
+
+	if got := cfg.QueryParams["team"]; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("team = %q, want [a b]", got)
+	}
+	if got := cfg.QueryParams.Get("region"); got != "eu" {
+		t.Errorf("region = %q, want %q", got, "eu")
+	}
+}
+
 func TestWithHeader(t *testing.T) {
 	cfg := Config{}
 	WithHeader("X-Custom-Header", "custom-value")(&cfg)
@@ -90,8 +119,8 @@ func TestDefaultValues(t *testing.T) {
 		t.Errorf("BaseURL = %q, want %q", p.config.BaseURL, DefaultBaseURL)
 	}
 
-	if p.config.HTTPClient != http.DefaultClient {
-		t.Error("HTTPClient should default to http.DefaultClient")
+	if p.config.HTTPClient != httpclient.Default() {
+		t.Error("HTTPClient should default to the shared default client")
 	}
 }
 
@@ -131,3 +160,55 @@ func TestOptionsApplied(t *testing.T) {
 		t.Errorf("Timeout = %v, want %v", p.config.Timeout, 30*time.Second)
 	}
 }
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestWithTransport(t *testing.T) {
+	var called bool
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		called = true
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: r}, nil
+	})
+
+	p := New("test-key", WithTransport(rt))
+	if p.config.HTTPClient == httpclient.Default() {
+		t.Fatal("HTTPClient should not be the shared default client")
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.openai.com/v1/models", nil)
+	resp, err := p.config.HTTPClient.Do(req)
+	if err != nil {
+		t.Fatalf("Do() error = %v", err)
+	}
+	resp.Body.Close()
+	if !called {
+		t.Error("custom transport was not used")
+	}
+}
+
+func TestWithProxy(t *testing.T) {
+	proxy, _ := url.Parse("http://proxy.internal:3128")
+	p := New("test-key", WithProxy(proxy))
+
+	tr, ok := p.config.HTTPClient.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Transport = %T, want *http.Transport", p.config.HTTPClient.Transport)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://api.openai.com/v1/models", nil)
+	got, err := tr.Proxy(req)
+	if err != nil || got.String() != proxy.String() {
+		t.Errorf("Proxy = %v, %v; want %v", got, err, proxy)
+	}
+}
+
+func TestWithHTTPClientOverridesTransport(t *testing.T) {
+	customClient := &http.Client{}
+	proxy, _ := url.Parse("http://proxy.internal:3128")
+
+	p := New("test-key", WithHTTPClient(customClient), WithProxy(proxy))
+	if p.config.HTTPClient != customClient {
+		t.Error("HTTPClient should take precedence over Proxy")
+	}
+}
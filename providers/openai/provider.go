@@ -5,8 +5,10 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 // DefaultAPIKeyEnvVar is the environment variable name for the OpenAI API key.
@@ -26,7 +28,7 @@ var ErrAPIKeyNotFound = errors.New("openai: OPENAI_API_KEY environment variable
 //
 // Additional options can be passed to customize the provider:
 //
-//	provider, err := openai.NewFromEnv(openai.WithOrgID("org-xxx"))
+//	provider, err := openai.NewFromEnv(openai.WithOrganization("org-xxx"))
 func NewFromEnv(opts ...Option) (*OpenAI, error) {
 	apiKey := os.Getenv(DefaultAPIKeyEnvVar)
 	if apiKey == "" {
@@ -44,14 +46,24 @@ type OpenAI struct {
 // New creates a new OpenAI provider with the given API key and options.
 func New(apiKey string, opts ...Option) *OpenAI {
 	cfg := Config{
-		APIKey:     core.NewSecret(apiKey),
-		BaseURL:    DefaultBaseURL,
-		HTTPClient: http.DefaultClient,
+		APIKey:  core.NewSecret(apiKey),
+		BaseURL: DefaultBaseURL,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+	}
+	cfg.HTTPClient = httpclient.WithRewrite(cfg.HTTPClient, httpclient.Rewrite{
+		PathPrefix: cfg.PathPrefix,
+		Query:      cfg.QueryParams,
+		Editors:    cfg.RequestEditors,
+	})
+	if cfg.Credentials != nil {
+		cfg.HTTPClient = httpclient.WithCredentials(cfg.HTTPClient, cfg.Credentials, httpclient.BearerAuth)
+	}
 
 	return &OpenAI{config: cfg}
 }
@@ -61,6 +73,12 @@ func (p *OpenAI) ID() string {
 	return "openai"
 }
 
+// CloseIdleConnections closes the idle pooled connections of the provider's
+// HTTP client. core.Client.Close calls it during shutdown.
+func (p *OpenAI) CloseIdleConnections() {
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
 // Models returns the list of available models.
 func (p *OpenAI) Models() []core.ModelInfo {
 	// Return a copy to prevent mutation
@@ -69,6 +87,12 @@ func (p *OpenAI) Models() []core.ModelInfo {
 	return result
 }
 
+// DefaultTimeout returns the timeout set with WithTimeout. core.Client
+// applies it to requests that have no deadline or Timeout of their own.
+func (p *OpenAI) DefaultTimeout() time.Duration {
+	return p.config.Timeout
+}
+
 // Supports reports whether the provider supports the given feature.
 func (p *OpenAI) Supports(feature core.Feature) bool {
 	switch feature {
@@ -81,8 +105,21 @@ func (p *OpenAI) Supports(feature core.Feature) bool {
 	}
 }
 
-// buildHeaders constructs the HTTP headers for an API request.
-func (p *OpenAI) buildHeaders() http.Header {
+// SupportsModel reports whether a model supports the feature. Models served
+// by the Responses API also accept native instructions.
+func (p *OpenAI) SupportsModel(model core.ModelID, feature core.Feature) bool {
+	if feature == core.FeatureInstructions {
+		return p.shouldUseResponsesAPI(model)
+	}
+	if info := core.FindModel(p, model); info != nil {
+		return info.HasCapability(feature)
+	}
+	return p.Supports(feature)
+}
+
+// buildHeaders constructs the HTTP headers for an API request. An
+// organization or project set on ctx overrides the configured one.
+func (p *OpenAI) buildHeaders(ctx context.Context) http.Header {
 	headers := make(http.Header)
 
 	// Required headers
@@ -90,13 +127,13 @@ func (p *OpenAI) buildHeaders() http.Header {
 	headers.Set("Content-Type", "application/json")
 
 	// Optional organization header
-	if p.config.OrgID != "" {
-		headers.Set("OpenAI-Organization", p.config.OrgID)
+	if org := organizationFromContext(ctx, p.config.OrgID); org != "" {
+		headers.Set("OpenAI-Organization", org)
 	}
 
 	// Optional project header
-	if p.config.ProjectID != "" {
-		headers.Set("OpenAI-Project", p.config.ProjectID)
+	if project := projectFromContext(ctx, p.config.ProjectID); project != "" {
+		headers.Set("OpenAI-Project", project)
 	}
 
 	// Copy any extra headers
@@ -109,6 +146,16 @@ func (p *OpenAI) buildHeaders() http.Header {
 	return headers
 }
 
+// buildChatHeaders returns the headers for a chat request, adding the
+// request's idempotency key so retried attempts can be deduplicated.
+func (p *OpenAI) buildChatHeaders(ctx context.Context, req *core.ChatRequest) http.Header {
+	headers := p.buildHeaders(ctx)
+	if req.IdempotencyKey != "" {
+		headers.Set("Idempotency-Key", req.IdempotencyKey)
+	}
+	return headers
+}
+
 // Chat sends a non-streaming chat request.
 // Routes to either the Chat Completions API or Responses API based on the model.
 func (p *OpenAI) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
@@ -142,6 +189,9 @@ func (p *OpenAI) shouldUseResponsesAPI(model core.ModelID) bool {
 // Compile-time check that OpenAI implements Provider.
 var _ core.Provider = (*OpenAI)(nil)
 
+// Compile-time check that OpenAI implements ModelSupporter.
+var _ core.ModelSupporter = (*OpenAI)(nil)
+
 // Compile-time check that OpenAI implements ImageGenerator.
 var _ core.ImageGenerator = (*OpenAI)(nil)
 
@@ -150,3 +200,9 @@ var _ core.EmbeddingProvider = (*OpenAI)(nil)
 
 // Compile-time check that OpenAI implements BatchProvider.
 var _ core.BatchProvider = (*OpenAI)(nil)
+
+// Compile-time check that OpenAI implements TimeoutProvider.
+var _ core.TimeoutProvider = (*OpenAI)(nil)
+
+// Compile-time check that OpenAI implements IdleConnectionCloser.
+var _ core.IdleConnectionCloser = (*OpenAI)(nil)
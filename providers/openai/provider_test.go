@@ -1,6 +1,7 @@
 package openai
 
 import (
+	"context"
 	"errors"
 	"os"
 	"testing"
@@ -117,6 +118,20 @@ func TestSupportsUnknownFeature(t *testing.T) {
 	}
 }
 
+func TestSupportsModelInstructions(t *testing.T) {
+	p := New("test-key")
+
+	if !p.SupportsModel(ModelGPT52, core.FeatureInstructions) {
+		t.Error("SupportsModel(gpt-5.2, FeatureInstructions) = false, want true")
+	}
+	if p.SupportsModel(ModelGPT4o, core.FeatureInstructions) {
+		t.Error("SupportsModel(gpt-4o, FeatureInstructions) = true, want false")
+	}
+	if !p.SupportsModel(ModelGPT4o, core.FeatureToolCalling) {
+		t.Error("SupportsModel(gpt-4o, FeatureToolCalling) = false, want true")
+	}
+}
+
 func TestSupportsImageGeneration(t *testing.T) {
 	p := New("test-key")
 
@@ -139,7 +154,7 @@ func TestImplementsImageGenerator(t *testing.T) {
 
 func TestBuildHeadersAuth(t *testing.T) {
 	p := New("sk-test-key-123")
-	headers := p.buildHeaders()
+	headers := p.buildHeaders(context.Background())
 
 	auth := headers.Get("Authorization")
 	if auth != "Bearer sk-test-key-123" {
@@ -154,7 +169,7 @@ func TestBuildHeadersAuth(t *testing.T) {
 
 func TestBuildHeadersWithOrgID(t *testing.T) {
 	p := New("test-key", WithOrgID("org-abc123"))
-	headers := p.buildHeaders()
+	headers := p.buildHeaders(context.Background())
 
 	org := headers.Get("OpenAI-Organization")
 	if org != "org-abc123" {
@@ -164,7 +179,7 @@ func TestBuildHeadersWithOrgID(t *testing.T) {
 
 func TestBuildHeadersWithProjectID(t *testing.T) {
 	p := New("test-key", WithProjectID("proj-xyz789"))
-	headers := p.buildHeaders()
+	headers := p.buildHeaders(context.Background())
 
 	project := headers.Get("OpenAI-Project")
 	if project != "proj-xyz789" {
@@ -172,9 +187,32 @@ func TestBuildHeadersWithProjectID(t *testing.T) {
 	}
 }
 
+func TestBuildHeadersContextOverrides(t *testing.T) {
+	p := New("test-key", WithOrganization("org-default"), WithProject("proj-default"))
+
+	ctx := ContextWithProject(context.Background(), "proj-billing")
+	headers := p.buildHeaders(ctx)
+	if got := headers.Get("OpenAI-Project"); got != "proj-billing" {
+		t.Errorf("OpenAI-Project = %q, want %q", got, "proj-billing")
+	}
+	if got := headers.Get("OpenAI-Organization"); got != "org-default" {
+		t.Errorf("OpenAI-Organization = %q, want %q", got, "org-default")
+	}
+
+	ctx = ContextWithOrganization(ctx, "org-other")
+	if got := p.buildHeaders(ctx).Get("OpenAI-Organization"); got != "org-other" {
+		t.Errorf("OpenAI-Organization = %q, want %q", got, "org-other")
+	}
+
+	// Without overrides the configured values are used
+	if got := p.buildHeaders(context.Background()).Get("OpenAI-Project"); got != "proj-default" {
+		t.Errorf("OpenAI-Project = %q, want %q", got, "proj-default")
+	}
+}
+
 func TestBuildHeadersWithoutOptionals(t *testing.T) {
 	p := New("test-key")
-	headers := p.buildHeaders()
+	headers := p.buildHeaders(context.Background())
 
 	if headers.Get("OpenAI-Organization") != "" {
 		t.Error("OpenAI-Organization should be empty when not configured")
@@ -190,7 +228,7 @@ func TestBuildHeadersWithCustomHeaders(t *testing.T) {
 		WithHeader("X-Custom-One", "value1"),
 		WithHeader("X-Custom-Two", "value2"),
 	)
-	headers := p.buildHeaders()
+	headers := p.buildHeaders(context.Background())
 
 	if headers.Get("X-Custom-One") != "value1" {
 		t.Errorf("X-Custom-One = %q, want %q", headers.Get("X-Custom-One"), "value1")
@@ -207,7 +245,7 @@ func TestBuildHeadersAllOptions(t *testing.T) {
 		WithProjectID("my-project"),
 		WithHeader("X-Request-ID", "req-123"),
 	)
-	headers := p.buildHeaders()
+	headers := p.buildHeaders(context.Background())
 
 	// Check all headers are present
 	checks := map[string]string{
@@ -248,7 +286,7 @@ func TestNewFromEnvSuccess(t *testing.T) {
 	}
 
 	// Verify the API key was set correctly
-	headers := p.buildHeaders()
+	headers := p.buildHeaders(context.Background())
 	auth := headers.Get("Authorization")
 	if auth != "Bearer sk-test-from-env-123" {
 		t.Errorf("Authorization = %q, want %q", auth, "Bearer sk-test-from-env-123")
@@ -292,8 +330,25 @@ func TestNewFromEnvWithOptions(t *testing.T) {
 		t.Fatalf("NewFromEnv() error = %v", err)
 	}
 
-	headers := p.buildHeaders()
+	headers := p.buildHeaders(context.Background())
 	if headers.Get("OpenAI-Organization") != "org-from-env" {
 		t.Errorf("Organization header not set correctly")
 	}
 }
+
+func TestNewProviderFromConfig(t *testing.T) {
+	t.Setenv(DefaultAPIKeyEnvVar, "")
+	if _, err := core.NewProviderFromConfig(core.ProviderConfig{Name: "openai"}); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("error = %v, want ErrAPIKeyNotFound", err)
+	}
+
+	t.Setenv(DefaultAPIKeyEnvVar, "sk-env")
+	p, err := core.NewProviderFromConfig(core.ProviderConfig{Name: "openai", BaseURL: "https://proxy.example.com/v1"})
+	if err != nil {
+		t.Fatalf("NewProviderFromConfig() error = %v", err)
+	}
+	o := p.(*OpenAI)
+	if o.config.APIKey.Expose() != "sk-env" || o.config.BaseURL != "https://proxy.example.com/v1" {
+		t.Errorf("config = %+v", o.config)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
 	"io"
@@ -11,16 +12,19 @@ import (
 	"strings"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
+	"github.com/petal-labs/iris/providers/internal/outputparts"
 	"github.com/petal-labs/iris/providers/internal/toolcalls"
 )
 
 // Streaming response types for OpenAI SSE protocol.
 
 type openAIStreamChunk struct {
-	ID      string               `json:"id"`
-	Model   string               `json:"model"`
-	Choices []openAIStreamChoice `json:"choices"`
-	Usage   *openAIUsage         `json:"usage,omitempty"`
+	ID                string               `json:"id"`
+	Model             string               `json:"model"`
+	SystemFingerprint string               `json:"system_fingerprint,omitempty"`
+	Choices           []openAIStreamChoice `json:"choices"`
+	Usage             *openAIUsage         `json:"usage,omitempty"`
 }
 
 type openAIStreamChoice struct {
@@ -33,6 +37,8 @@ type openAIStreamDelta struct {
 	Role      string                 `json:"role,omitempty"`
 	Content   string                 `json:"content,omitempty"`
 	ToolCalls []openAIStreamToolCall `json:"tool_calls,omitempty"`
+	Audio     *openAIRespAudio       `json:"audio,omitempty"`
+	Refusal   string                 `json:"refusal,omitempty"`
 }
 
 type openAIStreamToolCall struct {
@@ -80,6 +86,41 @@ func (a *toolCallAssembler) finalize() ([]core.ToolCall, error) {
 	return calls, nil
 }
 
+// audioAssembler accumulates streaming audio fragments. Each fragment carries
+// independently base64-encoded data, so fragments are decoded and re-encoded
+// as a whole when the stream finishes.
+type audioAssembler struct {
+	id         string
+	data       []byte
+	transcript strings.Builder
+	seen       bool
+}
+
+func (a *audioAssembler) addFragment(audio *openAIRespAudio) error {
+	a.seen = true
+	if audio.ID != "" {
+		a.id = audio.ID
+	}
+	if audio.Data != "" {
+		decoded, err := base64.StdEncoding.DecodeString(audio.Data)
+		if err != nil {
+			return newDecodeError(err)
+		}
+		a.data = append(a.data, decoded...)
+	}
+	a.transcript.WriteString(audio.Transcript)
+	return nil
+}
+
+// part returns the assembled audio output.
+func (a *audioAssembler) part() core.OutputAudio {
+	return core.OutputAudio{
+		ID:         a.id,
+		Data:       base64.StdEncoding.EncodeToString(a.data),
+		Transcript: a.transcript.String(),
+	}
+}
+
 // doStreamChat performs a streaming chat completion request.
 func (p *OpenAI) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
 	// Build OpenAI request with stream=true
@@ -99,7 +140,7 @@ func (p *OpenAI) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core
 	}
 
 	// Set headers
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildChatHeaders(ctx, req) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -151,9 +192,14 @@ func (p *OpenAI) processSSEStream(
 
 	reader := bufio.NewReader(body)
 	assembler := newToolCallAssembler()
+	var audio audioAssembler
+	var content strings.Builder
+	var refusal strings.Builder
+	var finishReason string
 
 	var responseID string
 	var responseModel string
+	var systemFingerprint string
 	var usage *openAIUsage
 
 	for {
@@ -209,14 +255,23 @@ func (p *OpenAI) processSSEStream(
 		if chunk.Model != "" {
 			responseModel = chunk.Model
 		}
+		if chunk.SystemFingerprint != "" {
+			systemFingerprint = chunk.SystemFingerprint
+		}
 		if chunk.Usage != nil {
 			usage = chunk.Usage
 		}
 
 		// Process choices
 		for _, choice := range chunk.Choices {
+			refusal.WriteString(choice.Delta.Refusal)
+			if choice.FinishReason != nil && *choice.FinishReason != "" {
+				finishReason = *choice.FinishReason
+			}
+
 			// Emit content delta
 			if choice.Delta.Content != "" {
+				content.WriteString(choice.Delta.Content)
 				select {
 				case chunkCh <- core.ChatChunk{Delta: choice.Delta.Content}:
 				case <-ctx.Done():
@@ -225,6 +280,25 @@ func (p *OpenAI) processSSEStream(
 				}
 			}
 
+			// Emit audio fragments
+			if choice.Delta.Audio != nil {
+				if err := audio.addFragment(choice.Delta.Audio); err != nil {
+					errCh <- err
+					return
+				}
+				fragment := core.OutputAudio{
+					ID:         choice.Delta.Audio.ID,
+					Data:       choice.Delta.Audio.Data,
+					Transcript: choice.Delta.Audio.Transcript,
+				}
+				select {
+				case chunkCh <- core.ChatChunk{Parts: []core.OutputPart{fragment}}:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+
 			// Accumulate tool calls
 			for _, tc := range choice.Delta.ToolCalls {
 				assembler.addFragment(tc)
@@ -241,9 +315,13 @@ func (p *OpenAI) processSSEStream(
 
 	// Build final response
 	finalResp := &core.ChatResponse{
-		ID:        responseID,
-		Model:     core.ModelID(responseModel),
-		ToolCalls: toolCalls,
+		ID:                responseID,
+		Model:             core.ModelID(responseModel),
+		SystemFingerprint: systemFingerprint,
+		ToolCalls:         toolCalls,
+		Safety:            mapRefusal(refusal.String()),
+		FinishReason:      normalize.FinishReason(finishReason),
+		RawFinishReason:   finishReason,
 	}
 
 	if usage != nil {
@@ -254,5 +332,12 @@ func (p *OpenAI) processSSEStream(
 		}
 	}
 
+	if audio.seen {
+		var parts outputparts.Builder
+		parts.AddText(content.String())
+		parts.Add(audio.part())
+		finalResp.Parts = parts.Parts()
+	}
+
 	finalCh <- finalResp
 }
@@ -31,7 +31,7 @@ func (p *OpenAI) StreamImage(ctx context.Context, req *core.ImageGenerateRequest
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildHeaders(ctx) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
@@ -52,31 +52,14 @@ func (p *OpenAI) StreamImage(ctx context.Context, req *core.ImageGenerateRequest
 		return nil, parseImageError(resp)
 	}
 
-	chunkCh := make(chan core.ImageChunk, 10)
-	errCh := make(chan error, 1)
-	finalCh := make(chan *core.ImageResponse, 1)
-
-	go p.processImageStream(ctx, resp, chunkCh, errCh, finalCh)
-
-	return &core.ImageStream{
-		Ch:    chunkCh,
-		Err:   errCh,
-		Final: finalCh,
-	}, nil
+	stream, w := core.NewImageStream(ctx, 10)
+	go p.processImageStream(ctx, resp, w)
+	return stream, nil
 }
 
-// processImageStream reads SSE events and dispatches to channels.
-func (p *OpenAI) processImageStream(
-	ctx context.Context,
-	resp *http.Response,
-	chunkCh chan<- core.ImageChunk,
-	errCh chan<- error,
-	finalCh chan<- *core.ImageResponse,
-) {
+// processImageStream reads SSE events and writes them to the image stream.
+func (p *OpenAI) processImageStream(ctx context.Context, resp *http.Response, w *core.ImageStreamWriter) {
 	defer resp.Body.Close()
-	defer close(chunkCh)
-	defer close(errCh)
-	defer close(finalCh)
 
 	scanner := bufio.NewScanner(resp.Body)
 	// Base64-encoded images can be several MB, increase buffer from default 64KB
@@ -85,11 +68,9 @@ func (p *OpenAI) processImageStream(
 	var completedEvent *openAIImageCompletedEvent
 
 	for scanner.Scan() {
-		select {
-		case <-ctx.Done():
-			errCh <- ctx.Err()
+		if err := ctx.Err(); err != nil {
+			w.Fail(err)
 			return
-		default:
 		}
 
 		line := scanner.Text()
@@ -116,10 +97,8 @@ func (p *OpenAI) processImageStream(
 		if err := json.Unmarshal([]byte(data), &event); err == nil {
 			switch event.Type {
 			case "image_generation.partial_image":
-				select {
-				case chunkCh <- mapImageChunk(&event):
-				case <-ctx.Done():
-					errCh <- ctx.Err()
+				if err := w.Partial(mapImageChunk(&event)); err != nil {
+					w.Fail(err)
 					return
 				}
 				continue
@@ -135,14 +114,19 @@ func (p *OpenAI) processImageStream(
 	}
 
 	if err := scanner.Err(); err != nil {
-		errCh <- fmt.Errorf("stream read error: %w", err)
+		if ctx.Err() != nil {
+			err = ctx.Err()
+		}
+		w.Fail(fmt.Errorf("stream read error: %w", err))
 		return
 	}
 
 	// Send final response from completed event
+	var final *core.ImageResponse
 	if completedEvent != nil {
-		finalCh <- &core.ImageResponse{
+		final = &core.ImageResponse{
 			Data: []core.ImageData{{B64JSON: completedEvent.B64JSON}},
 		}
 	}
+	w.Complete(final)
 }
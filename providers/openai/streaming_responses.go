@@ -8,17 +8,29 @@ import (
 	"errors"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/outputparts"
 	"github.com/petal-labs/iris/providers/internal/toolcalls"
 )
 
 // doResponsesStreamChat performs a streaming request to the Responses API.
+// With WithStreamResume, the response runs in the background so that an
+// interrupted stream can reconnect and continue after the last event.
 func (p *OpenAI) doResponsesStreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
 	// Build Responses API request with stream=true
+	if err := checkResponsesParts(req.Messages); err != nil {
+		return nil, err
+	}
 	respReq := buildResponsesRequest(req, true)
 
+	// Only stored background responses can be streamed again
+	resumable := p.config.StreamResumeAttempts > 0 && (req.Store == nil || *req.Store)
+	respReq.Background = resumable
+
 	// Marshal request body
 	body, err := json.Marshal(respReq)
 	if err != nil {
@@ -33,26 +45,15 @@ func (p *OpenAI) doResponsesStreamChat(ctx context.Context, req *core.ChatReques
 	}
 
 	// Set headers
-	for key, values := range p.buildHeaders() {
+	for key, values := range p.buildChatHeaders(ctx, req) {
 		for _, v := range values {
 			httpReq.Header.Add(key, v)
 		}
 	}
 
-	// Execute request
-	resp, err := p.config.HTTPClient.Do(httpReq)
+	respBody, err := p.openResponsesStream(httpReq)
 	if err != nil {
-		return nil, newNetworkError(err)
-	}
-
-	// Extract request ID from response headers
-	requestID := resp.Header.Get("x-request-id")
-
-	// Check for error status
-	if resp.StatusCode >= 400 {
-		defer resp.Body.Close()
-		respBody, _ := io.ReadAll(resp.Body)
-		return nil, normalizeError(resp.StatusCode, respBody, requestID)
+		return nil, err
 	}
 
 	// Create channels
@@ -61,7 +62,7 @@ func (p *OpenAI) doResponsesStreamChat(ctx context.Context, req *core.ChatReques
 	finalCh := make(chan *core.ChatResponse, 1)
 
 	// Start goroutine to process SSE stream
-	go p.processResponsesStream(ctx, resp.Body, chunkCh, errCh, finalCh)
+	go p.processResponsesStream(ctx, respBody, resumable, chunkCh, errCh, finalCh)
 
 	return &core.ChatStream{
 		Ch:    chunkCh,
@@ -70,15 +71,57 @@ func (p *OpenAI) doResponsesStreamChat(ctx context.Context, req *core.ChatReques
 	}, nil
 }
 
+// openResponsesStream sends a request for a Responses API event stream and
+// returns the body of a successful response.
+func (p *OpenAI) openResponsesStream(httpReq *http.Request) (io.ReadCloser, error) {
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	// Check for error status
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, normalizeError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+	return resp.Body, nil
+}
+
+// resumeResponsesStream reopens the event stream of background response id
+// after the event with sequence number after.
+func (p *OpenAI) resumeResponsesStream(ctx context.Context, id string, after int64) (io.ReadCloser, error) {
+	url := p.config.BaseURL + responsePath(id) + "?stream=true&starting_after=" + strconv.FormatInt(after, 10)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	for key, values := range p.buildHeaders(ctx) {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+	return p.openResponsesStream(httpReq)
+}
+
 // responsesStreamState holds state during streaming.
 type responsesStreamState struct {
 	responseID    string
 	responseModel string
 	status        string
+	incomplete    *incompleteInfo
 	usage         *responsesUsage
 	toolCalls     *toolcalls.Assembler
 	toolCallDelta map[int]bool // index -> whether argument deltas were seen
 	reasoning     []string     // reasoning summaries
+	refusal       string
+	computerCalls []core.ComputerCall
+	parts         outputparts.Builder
+
+	sequence    int64 // sequence number of the last event
+	hasSequence bool  // whether events carry sequence numbers
+	done        bool  // whether the end of the response was received
+	resumes     int   // reconnections so far
 }
 
 func newResponsesStreamState() *responsesStreamState {
@@ -88,28 +131,103 @@ func newResponsesStreamState() *responsesStreamState {
 	}
 }
 
-// processResponsesStream reads the SSE stream from the Responses API and emits chunks.
+// processResponsesStream reads the SSE stream from the Responses API and
+// emits chunks. If resumable, a connection that fails before the
+// response ends is reopened after the last event received.
 func (p *OpenAI) processResponsesStream(
 	ctx context.Context,
 	body io.ReadCloser,
+	resumable bool,
 	chunkCh chan<- core.ChatChunk,
 	errCh chan<- error,
 	finalCh chan<- *core.ChatResponse,
 ) {
-	defer body.Close()
 	defer close(chunkCh)
 	defer close(errCh)
 	defer close(finalCh)
 
-	reader := bufio.NewReader(body)
 	state := newResponsesStreamState()
 
+	for {
+		err := p.readResponsesStream(ctx, body, state, chunkCh)
+		body.Close()
+		if err == nil && !state.done && resumable {
+			err = newNetworkError(io.ErrUnexpectedEOF)
+		}
+		if err != nil && resumable && errors.Is(err, core.ErrNetwork) && state.responseID != "" && state.hasSequence {
+			if body, err = p.reconnectResponsesStream(ctx, state, err); err == nil {
+				continue
+			}
+		}
+		if err != nil {
+			if resumable {
+				p.cancelAbandonedResponse(ctx, state)
+			}
+			errCh <- err
+			return
+		}
+		break
+	}
+
+	// Build final response
+	finalResp := &core.ChatResponse{
+		ID:     state.responseID,
+		Model:  core.ModelID(state.responseModel),
+		Status: state.status,
+	}
+
+	if state.usage != nil {
+		finalResp.Usage = core.TokenUsage{
+			PromptTokens:     state.usage.InputTokens,
+			CompletionTokens: state.usage.OutputTokens,
+			TotalTokens:      state.usage.TotalTokens,
+		}
+	}
+
+	// Finalize tool calls
+	toolCalls, err := state.toolCalls.Finalize()
+	if err != nil {
+		if errors.Is(err, toolcalls.ErrInvalidJSON) {
+			errCh <- ErrToolArgsInvalidJSON
+			return
+		}
+		errCh <- err
+		return
+	}
+	if len(toolCalls) > 0 {
+		finalResp.ToolCalls = toolCalls
+	}
+
+	// Set reasoning if any
+	if len(state.reasoning) > 0 {
+		finalResp.Reasoning = &core.ReasoningOutput{
+			Summary: state.reasoning,
+		}
+	}
+
+	finalResp.ComputerCalls = state.computerCalls
+	finalResp.Parts = state.parts.Parts()
+	finalResp.Safety = mapRefusal(state.refusal)
+	finalResp.FinishReason, finalResp.RawFinishReason = mapResponsesFinishReason(state.status, state.incomplete, finalResp)
+
+	finalCh <- finalResp
+}
+
+// readResponsesStream processes the events of one connection until it ends.
+// A failed read is returned as a network error.
+func (p *OpenAI) readResponsesStream(
+	ctx context.Context,
+	body io.Reader,
+	state *responsesStreamState,
+	chunkCh chan<- core.ChatChunk,
+) error {
+	reader := bufio.NewReader(body)
+
 	for {
 		// Check for context cancellation
 		select {
 		case <-ctx.Done():
-			errCh <- ctx.Err()
-			return
+			return ctx.Err()
 		default:
 		}
 
@@ -117,10 +235,9 @@ func (p *OpenAI) processResponsesStream(
 		line, err := reader.ReadString('\n')
 		if err != nil {
 			if err == io.EOF {
-				break
+				return nil
 			}
-			errCh <- newNetworkError(err)
-			return
+			return newNetworkError(err)
 		}
 
 		// Trim whitespace
@@ -146,60 +263,77 @@ func (p *OpenAI) processResponsesStream(
 
 		// Check for done signal
 		if payload == "[DONE]" {
-			break
+			state.done = true
+			return nil
 		}
 
 		// Parse event
 		var event responsesStreamEvent
 		if err := json.Unmarshal([]byte(payload), &event); err != nil {
-			errCh <- newDecodeError(err)
-			return
+			return newDecodeError(err)
 		}
 
 		// Process event based on type
 		if err := p.handleResponsesStreamEvent(ctx, &event, state, chunkCh); err != nil {
-			errCh <- err
-			return
+			return err
 		}
-	}
-
-	// Build final response
-	finalResp := &core.ChatResponse{
-		ID:     state.responseID,
-		Model:  core.ModelID(state.responseModel),
-		Status: state.status,
-	}
-
-	if state.usage != nil {
-		finalResp.Usage = core.TokenUsage{
-			PromptTokens:     state.usage.InputTokens,
-			CompletionTokens: state.usage.OutputTokens,
-			TotalTokens:      state.usage.TotalTokens,
+		if event.SequenceNumber != nil {
+			state.sequence, state.hasSequence = *event.SequenceNumber, true
+		}
+		switch event.Type {
+		case "response.completed", "response.failed", "response.incomplete":
+			state.done = true
 		}
 	}
+}
 
-	// Finalize tool calls
-	toolCalls, err := state.toolCalls.Finalize()
-	if err != nil {
-		if errors.Is(err, toolcalls.ErrInvalidJSON) {
-			errCh <- ErrToolArgsInvalidJSON
-			return
-		}
-		errCh <- err
+// abandonedCancelTimeout bounds the request that cancels an abandoned
+// background response.
+const abandonedCancelTimeout = 10 * time.Second
+
+// cancelAbandonedResponse cancels the background response of a stream that
+// ended before the response did, because ctx was canceled or the resume
+// attempts ran out. Otherwise the response would keep generating, and
+// billing, with no one reading it. The cancel request is detached from ctx,
+// which may already be done, and its failure is ignored.
+func (p *OpenAI) cancelAbandonedResponse(ctx context.Context, state *responsesStreamState) {
+	if state.done || state.responseID == "" {
 		return
 	}
-	if len(toolCalls) > 0 {
-		finalResp.ToolCalls = toolCalls
-	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), abandonedCancelTimeout)
+	defer cancel()
+	_, _ = p.CancelBackground(ctx, state.responseID)
+}
 
-	// Set reasoning if any
-	if len(state.reasoning) > 0 {
-		finalResp.Reasoning = &core.ReasoningOutput{
-			Summary: state.reasoning,
+// reconnectResponsesStream reopens an interrupted stream, reporting each
+// attempt to telemetry. Failed reconnections are retried until the resume
+// attempts run out, after which the last error is returned.
+func (p *OpenAI) reconnectResponsesStream(
+	ctx context.Context,
+	state *responsesStreamState,
+	cause error,
+) (io.ReadCloser, error) {
+	for state.resumes < p.config.StreamResumeAttempts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		state.resumes++
+		core.ReportStreamResume(ctx, core.StreamResumeEvent{
+			ResponseID: state.responseID,
+			Attempt:    state.resumes,
+			After:      state.sequence,
+			Err:        cause,
+		})
+		body, err := p.resumeResponsesStream(ctx, state.responseID, state.sequence)
+		if err == nil {
+			return body, nil
 		}
+		if !errors.Is(err, core.ErrNetwork) {
+			return nil, err
+		}
+		cause = err
 	}
-
-	finalCh <- finalResp
+	return nil, cause
 }
 
 // handleResponsesStreamEvent processes a single streaming event.
@@ -221,7 +355,7 @@ func (p *OpenAI) handleResponsesStreamEvent(
 			}
 		}
 
-	case "response.completed":
+	case "response.completed", "response.incomplete":
 		// Final response with usage
 		if len(event.Response) > 0 {
 			var resp responsesResponse
@@ -229,10 +363,14 @@ func (p *OpenAI) handleResponsesStreamEvent(
 				state.responseID = resp.ID
 				state.responseModel = resp.Model
 				state.status = resp.Status
+				state.incomplete = resp.IncompleteDetails
 				state.usage = resp.Usage
 			}
 		}
 
+	case "response.refusal.done":
+		state.refusal += event.Refusal
+
 	case "response.output_item.added":
 		// New output item - could be reasoning, message, or function_call
 		// We'll handle the content in the delta events
@@ -242,6 +380,7 @@ func (p *OpenAI) handleResponsesStreamEvent(
 		if len(event.Delta) > 0 {
 			var delta responsesContentDelta
 			if err := json.Unmarshal(event.Delta, &delta); err == nil && delta.Text != "" {
+				state.parts.AddText(delta.Text)
 				select {
 				case chunkCh <- core.ChatChunk{Delta: delta.Text}:
 				case <-ctx.Done():
@@ -255,6 +394,7 @@ func (p *OpenAI) handleResponsesStreamEvent(
 		if len(event.Delta) > 0 {
 			var delta responsesContentDelta
 			if err := json.Unmarshal(event.Delta, &delta); err == nil && delta.Text != "" {
+				state.parts.AddText(delta.Text)
 				select {
 				case chunkCh <- core.ChatChunk{Delta: delta.Text}:
 				case <-ctx.Done():
@@ -296,6 +436,9 @@ func (p *OpenAI) handleResponsesStreamEvent(
 					}
 					state.toolCalls.AddFragment(fragment)
 
+				case "computer_call":
+					state.computerCalls = append(state.computerCalls, mapComputerCall(&item))
+
 				case "reasoning":
 					// Extract reasoning summary
 					for _, summary := range item.Summary {
@@ -303,6 +446,18 @@ func (p *OpenAI) handleResponsesStreamEvent(
 							state.reasoning = append(state.reasoning, summary.Text)
 						}
 					}
+
+				case "image_generation_call":
+					// Emit the generated image
+					if item.Result != "" {
+						image := mapImageGenerationOutput(&item)
+						state.parts.Add(image)
+						select {
+						case chunkCh <- core.ChatChunk{Parts: []core.OutputPart{image}}:
+						case <-ctx.Done():
+							return ctx.Err()
+						}
+					}
 				}
 			}
 		}
@@ -3,6 +3,7 @@ package openai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -259,6 +260,43 @@ func TestResponsesAPIStreamChatWithReasoning(t *testing.T) {
 	}
 }
 
+func TestResponsesAPIStreamComputerCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+
+		events := []string{
+			`data: {"type":"response.created","response":{"id":"resp-cu","model":"computer-use-preview","status":"in_progress"}}`,
+			`data: {"type":"response.output_item.done","output_index":0,"item":{"type":"computer_call","id":"cu_1","call_id":"call_1","action":{"type":"type","text":"hello"}}}`,
+			`data: {"type":"response.completed","response":{"id":"resp-cu","model":"computer-use-preview","status":"completed"}}`,
+		}
+		for _, event := range events {
+			fmt.Fprintf(w, "%s\n\n", event)
+		}
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model:        ModelComputerUsePreview,
+		Messages:     []core.Message{{Role: core.RoleUser, Content: "Type hello"}},
+		BuiltInTools: []core.BuiltInTool{{Type: "computer_use", Computer: &core.ComputerUseConfig{DisplayWidth: 800, DisplayHeight: 600}}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+	resp, err := core.DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+	if len(resp.ComputerCalls) != 1 {
+		t.Fatalf("len(ComputerCalls) = %d, want 1", len(resp.ComputerCalls))
+	}
+	if a := resp.ComputerCalls[0].Action; a.Type != core.ComputerActionTypeText || a.Text != "hello" {
+		t.Errorf("Action = %+v, want type hello", a)
+	}
+}
+
 func TestResponsesAPIStreamChatError(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("x-request-id", "req-stream-err")
@@ -324,3 +362,232 @@ func TestOlderModelStreamUsesCompletionsAPI(t *testing.T) {
 		t.Errorf("Called path = %q, want /chat/completions", calledPath)
 	}
 }
+
+func TestResponsesAPIStreamChatWithGeneratedImage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+
+		events := []string{
+			`event: response.created` + "\n" + `data: {"type":"response.created","response":{"id":"resp-img-stream","model":"gpt-5.2","status":"in_progress"}}`,
+			`event: response.output_text.delta` + "\n" + `data: {"type":"response.output_text.delta","delta":{"type":"text","text":"Drawing"}}`,
+			`event: response.output_item.done` + "\n" + `data: {"type":"response.output_item.done","output_index":1,"item":{"type":"image_generation_call","id":"ig_1","status":"completed","result":"aW1n"}}`,
+			`event: response.completed` + "\n" + `data: {"type":"response.completed","response":{"id":"resp-img-stream","model":"gpt-5.2","status":"completed"}}`,
+			`data: [DONE]`,
+		}
+
+		flusher := w.(http.Flusher)
+		for _, event := range events {
+			fmt.Fprintf(w, "%s\n\n", event)
+			flusher.Flush()
+		}
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model: ModelGPT52,
+		Messages: []core.Message{
+			{Role: core.RoleUser, Content: "Draw a cat"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	resp, err := core.DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+
+	if resp.Output != "Drawing" {
+		t.Errorf("Output = %q, want %q", resp.Output, "Drawing")
+	}
+	images := resp.Images()
+	if len(images) != 1 {
+		t.Fatalf("len(Images()) = %d, want 1", len(images))
+	}
+	if images[0].MimeType != "image/png" || images[0].Data != "aW1n" {
+		t.Errorf("Images()[0] = %+v", images[0])
+	}
+	if len(resp.Parts) != 2 {
+		t.Errorf("len(Parts) = %d, want 2", len(resp.Parts))
+	}
+}
+
+// resumeRecorder records stream resumes reported to telemetry.
+type resumeRecorder struct {
+	core.NoopTelemetryHook
+	events []core.StreamResumeEvent
+}
+
+func (r *resumeRecorder) OnStreamResume(e core.StreamResumeEvent) {
+	r.events = append(r.events, e)
+}
+
+func TestResponsesAPIStreamResume(t *testing.T) {
+	var resumedAfter string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/responses":
+			var reqBody map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+				t.Fatalf("Failed to decode request: %v", err)
+			}
+			if reqBody["background"] != true {
+				t.Errorf("background = %v, want true", reqBody["background"])
+			}
+			// The connection ends before the response completes
+			fmt.Fprint(w, `data: {"type":"response.created","sequence_number":0,"response":{"id":"resp-1","model":"gpt-5.2","status":"in_progress"}}`+"\n\n")
+			fmt.Fprint(w, `data: {"type":"response.output_text.delta","sequence_number":1,"delta":{"type":"text","text":"Hello"}}`+"\n\n")
+		case r.Method == http.MethodGet && r.URL.Path == "/responses/resp-1":
+			if r.URL.Query().Get("stream") != "true" {
+				t.Errorf("stream = %q, want true", r.URL.Query().Get("stream"))
+			}
+			resumedAfter = r.URL.Query().Get("starting_after")
+			fmt.Fprint(w, `data: {"type":"response.output_text.delta","sequence_number":2,"delta":{"type":"text","text":" World"}}`+"\n\n")
+			fmt.Fprint(w, `data: {"type":"response.completed","sequence_number":3,"response":{"id":"resp-1","model":"gpt-5.2","status":"completed","usage":{"input_tokens":5,"output_tokens":2,"total_tokens":7}}}`+"\n\n")
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	hook := &resumeRecorder{}
+	client := core.NewClient(New("test-key", WithBaseURL(server.URL), WithStreamResume(2)), core.WithTelemetry(hook))
+	stream, err := client.Chat(ModelGPT52).User("Say hello").Stream(context.Background())
+	if err != nil {
+		t.Fatalf("Stream() error = %v", err)
+	}
+	resp, err := core.DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+
+	if resp.Output != "Hello World" {
+		t.Errorf("Output = %q, want %q", resp.Output, "Hello World")
+	}
+	if resp.Status != core.ResponseStatusCompleted || resp.Usage.TotalTokens != 7 {
+		t.Errorf("Status = %q, Usage = %+v", resp.Status, resp.Usage)
+	}
+	if resumedAfter != "1" {
+		t.Errorf("starting_after = %q, want 1", resumedAfter)
+	}
+	if len(hook.events) != 1 {
+		t.Fatalf("resume events = %d, want 1", len(hook.events))
+	}
+	e := hook.events[0]
+	if e.Provider != "openai" || e.Model != ModelGPT52 || e.ResponseID != "resp-1" ||
+		e.Attempt != 1 || e.After != 1 || !errors.Is(e.Err, core.ErrNetwork) {
+		t.Errorf("resume event = %+v", e)
+	}
+}
+
+func TestResponsesAPIStreamResumeExhausted(t *testing.T) {
+	resumes, cancels := 0, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/responses/resp-1/cancel" {
+			cancels++
+			fmt.Fprint(w, `{"id":"resp-1","object":"response","status":"cancelled"}`)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		if r.Method == http.MethodGet {
+			resumes++
+		}
+		fmt.Fprint(w, `data: {"type":"response.created","sequence_number":0,"response":{"id":"resp-1","model":"gpt-5.2","status":"in_progress"}}`+"\n\n")
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL), WithStreamResume(2))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model:    ModelGPT52,
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Say hello"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+	if _, err := core.DrainStream(context.Background(), stream); !errors.Is(err, core.ErrNetwork) {
+		t.Errorf("DrainStream() error = %v, want ErrNetwork", err)
+	}
+	if resumes != 2 {
+		t.Errorf("resumes = %d, want 2", resumes)
+	}
+	if cancels != 1 {
+		t.Errorf("cancels = %d, want the abandoned response canceled once", cancels)
+	}
+}
+
+func TestResponsesAPIStreamResumeCancelsOnContextCancel(t *testing.T) {
+	canceled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/responses/resp-1/cancel" {
+			fmt.Fprint(w, `{"id":"resp-1","object":"response","status":"cancelled"}`)
+			close(canceled)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"type":"response.created","sequence_number":0,"response":{"id":"resp-1","model":"gpt-5.2","status":"in_progress"}}`+"\n\n")
+		fmt.Fprint(w, `data: {"type":"response.output_text.delta","sequence_number":1,"delta":{"type":"text","text":"Hello"}}`+"\n\n")
+		w.(http.Flusher).Flush()
+		// Keep generating until the client goes away
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p := New("test-key", WithBaseURL(server.URL), WithStreamResume(2))
+	stream, err := p.StreamChat(ctx, &core.ChatRequest{
+		Model:    ModelGPT52,
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Say hello"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+	<-stream.Ch
+	cancel()
+
+	if _, err := core.DrainStream(context.Background(), stream); !errors.Is(err, context.Canceled) {
+		t.Errorf("DrainStream() error = %v, want context.Canceled", err)
+	}
+	select {
+	case <-canceled:
+	case <-time.After(time.Second):
+		t.Fatal("background response was not canceled")
+	}
+}
+
+func TestResponsesAPIStreamResumeSkippedWithoutStore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var reqBody map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&reqBody); err != nil {
+			t.Fatalf("Failed to decode request: %v", err)
+		}
+		if _, ok := reqBody["background"]; ok {
+			t.Errorf("background = %v, want unset", reqBody["background"])
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, `data: {"type":"response.output_text.delta","sequence_number":0,"delta":{"type":"text","text":"Hi"}}`+"\n\n")
+	}))
+	defer server.Close()
+
+	store := false
+	p := New("test-key", WithBaseURL(server.URL), WithStreamResume(2))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model:    ModelGPT52,
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Say hello"}},
+		Store:    &store,
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+	resp, err := core.DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+	if resp.Output != "Hi" {
+		t.Errorf("Output = %q, want Hi", resp.Output)
+	}
+}
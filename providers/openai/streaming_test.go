@@ -96,6 +96,70 @@ func TestStreamChatSuccess(t *testing.T) {
 	}
 }
 
+func TestStreamChatRefusal(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, sseResponse(
+			`{"id":"chatcmpl-123","model":"gpt-4o","choices":[{"index":0,"delta":{"role":"assistant","refusal":""}}]}`,
+			`{"id":"chatcmpl-123","model":"gpt-4o","choices":[{"index":0,"delta":{"refusal":"I can't"}}]}`,
+			`{"id":"chatcmpl-123","model":"gpt-4o","choices":[{"index":0,"delta":{"refusal":" help with that."}}]}`,
+			"[DONE]",
+		))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model:    "gpt-4o",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	final, err := core.DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+	if final.Safety == nil || final.Safety.Refusal != "I can't help with that." {
+		t.Errorf("Safety = %+v, want refusal", final.Safety)
+	}
+}
+
+func TestStreamChatFinishReason(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, sseResponse(
+			`{"id":"chatcmpl-123","model":"gpt-4o","choices":[{"index":0,"delta":{"content":"Once upon"},"finish_reason":null}]}`,
+			`{"id":"chatcmpl-123","model":"gpt-4o","choices":[{"index":0,"delta":{},"finish_reason":"length"}]}`,
+			"[DONE]",
+		))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model:    "gpt-4o",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Tell me a story"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	final, err := core.DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+	if final.FinishReason != core.FinishLength || final.RawFinishReason != "length" {
+		t.Errorf("FinishReason = %q, RawFinishReason = %q, want length", final.FinishReason, final.RawFinishReason)
+	}
+	if !final.Truncated() {
+		t.Error("Truncated() = false, want true")
+	}
+}
+
 func TestStreamChatWithToolCalls(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/event-stream")
@@ -487,3 +551,98 @@ func TestToolCallAssemblerInvalidJSON(t *testing.T) {
 		t.Errorf("expected ErrToolArgsInvalidJSON, got %v", err)
 	}
 }
+
+func TestStreamChatWithAudio(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+
+		// "hello" and " world" base64-encoded independently
+		fmt.Fprint(w, sseResponse(
+			`{"id":"chatcmpl-a","model":"gpt-4o-audio-preview","choices":[{"index":0,"delta":{"role":"assistant","audio":{"id":"audio_1","data":"aGVsbG8=","transcript":"Hel"}}}]}`,
+			`{"id":"chatcmpl-a","model":"gpt-4o-audio-preview","choices":[{"index":0,"delta":{"audio":{"data":"IHdvcmxk","transcript":"lo"}}}]}`,
+			"[DONE]",
+		))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model: "gpt-4o-audio-preview",
+		Messages: []core.Message{
+			{Role: core.RoleUser, Content: "Say hello"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	var fragments int
+	for chunk := range stream.Ch {
+		fragments += len(chunk.Parts)
+	}
+	if fragments != 2 {
+		t.Errorf("streamed audio fragments = %d, want 2", fragments)
+	}
+
+	select {
+	case err := <-stream.Err:
+		if err != nil {
+			t.Fatalf("Stream error: %v", err)
+		}
+	default:
+	}
+
+	final := <-stream.Final
+	if final == nil {
+		t.Fatal("final response is nil")
+	}
+	audio := final.Audio()
+	if len(audio) != 1 {
+		t.Fatalf("len(Audio()) = %d, want 1", len(audio))
+	}
+	data, err := audio[0].Bytes()
+	if err != nil {
+		t.Fatalf("Bytes() error = %v", err)
+	}
+	if string(data) != "hello world" {
+		t.Errorf("audio data = %q, want %q", data, "hello world")
+	}
+	if audio[0].ID != "audio_1" || audio[0].Transcript != "Hello" {
+		t.Errorf("audio = %+v", audio[0])
+	}
+}
+
+func TestStreamChatSystemFingerprint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Idempotency-Key"); got != "key-456" {
+			t.Errorf("Idempotency-Key = %q, want %q", got, "key-456")
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, sseResponse(
+			`{"id":"chatcmpl-fp","model":"gpt-4o","system_fingerprint":"fp_abc","choices":[{"index":0,"delta":{"content":"Hi"}}]}`,
+			"[DONE]",
+		))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model:          "gpt-4o",
+		Messages:       []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+		IdempotencyKey: "key-456",
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	resp, err := core.DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+	if resp.SystemFingerprint != "fp_abc" {
+		t.Errorf("SystemFingerprint = %q, want %q", resp.SystemFingerprint, "fp_abc")
+	}
+}
@@ -4,14 +4,15 @@ import "encoding/json"
 
 // openAIRequest represents a request to the OpenAI chat completions API.
 type openAIRequest struct {
-	Model          string                `json:"model"`
-	Messages       []openAIMessage       `json:"messages"`
-	Temperature    *float32              `json:"temperature,omitempty"`
-	MaxTokens      *int                  `json:"max_tokens,omitempty"`
-	Stream         bool                  `json:"stream"`
-	Tools          []openAITool          `json:"tools,omitempty"`
-	ToolChoice     string                `json:"tool_choice,omitempty"`
-	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+	Model             string                `json:"model"`
+	Messages          []openAIMessage       `json:"messages"`
+	Temperature       *float32              `json:"temperature,omitempty"`
+	MaxTokens         *int                  `json:"max_tokens,omitempty"`
+	Stream            bool                  `json:"stream"`
+	Tools             []openAITool          `json:"tools,omitempty"`
+	ToolChoice        any                   `json:"tool_choice,omitempty"`
+	ParallelToolCalls *bool                 `json:"parallel_tool_calls,omitempty"`
+	ResponseFormat    *openAIResponseFormat `json:"response_format,omitempty"`
 }
 
 // openAIResponseFormat represents the response_format parameter.
@@ -30,10 +31,51 @@ type openAIJSONSchema struct {
 
 // openAIMessage represents a message in the OpenAI format.
 type openAIMessage struct {
-	Role       string           `json:"role"`
-	Content    string           `json:"content,omitempty"`
-	ToolCalls  []openAIToolCall `json:"tool_calls,omitempty"`   // For assistant messages requesting tools
-	ToolCallID string           `json:"tool_call_id,omitempty"` // For tool result messages
+	Role       string              `json:"role"`
+	Content    string              `json:"content,omitempty"`
+	Parts      []openAIContentPart `json:"-"`                      // Multimodal content; replaces Content when set
+	ToolCalls  []openAIToolCall    `json:"tool_calls,omitempty"`   // For assistant messages requesting tools
+	ToolCallID string              `json:"tool_call_id,omitempty"` // For tool result messages
+}
+
+// MarshalJSON encodes content as an array of parts when Parts is set.
+func (m openAIMessage) MarshalJSON() ([]byte, error) {
+	type alias openAIMessage
+	if len(m.Parts) == 0 {
+		return json.Marshal(alias(m))
+	}
+	return json.Marshal(struct {
+		alias
+		Content []openAIContentPart `json:"content"`
+	}{alias: alias(m), Content: m.Parts})
+}
+
+// openAIContentPart is a multimodal content part in a Chat Completions message.
+type openAIContentPart struct {
+	Type       string                `json:"type"`
+	Text       string                `json:"text,omitempty"`
+	ImageURL   *openAIImageURLPart   `json:"image_url,omitempty"`
+	InputAudio *openAIInputAudioPart `json:"input_audio,omitempty"`
+	File       *openAIFilePart       `json:"file,omitempty"`
+}
+
+// openAIImageURLPart references an image by URL or data URL.
+type openAIImageURLPart struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// openAIInputAudioPart carries base64-encoded audio.
+type openAIInputAudioPart struct {
+	Data   string `json:"data"`
+	Format string `json:"format"`
+}
+
+// openAIFilePart references an uploaded file or inline file data.
+type openAIFilePart struct {
+	FileID   string `json:"file_id,omitempty"`
+	FileData string `json:"file_data,omitempty"`
+	Filename string `json:"filename,omitempty"`
 }
 
 // openAITool represents a tool definition in the OpenAI format.
@@ -51,12 +93,13 @@ type openAIFunction struct {
 
 // openAIResponse represents a response from the OpenAI chat completions API.
 type openAIResponse struct {
-	ID      string         `json:"id"`
-	Object  string         `json:"object"`
-	Created int64          `json:"created"`
-	Model   string         `json:"model"`
-	Choices []openAIChoice `json:"choices"`
-	Usage   openAIUsage    `json:"usage"`
+	ID                string         `json:"id"`
+	Object            string         `json:"object"`
+	Created           int64          `json:"created"`
+	Model             string         `json:"model"`
+	SystemFingerprint string         `json:"system_fingerprint,omitempty"`
+	Choices           []openAIChoice `json:"choices"`
+	Usage             openAIUsage    `json:"usage"`
 }
 
 // openAIChoice represents a single choice in an OpenAI response.
@@ -71,6 +114,16 @@ type openAIRespMsg struct {
 	Role      string           `json:"role"`
 	Content   string           `json:"content"`
 	ToolCalls []openAIToolCall `json:"tool_calls,omitempty"`
+	Audio     *openAIRespAudio `json:"audio,omitempty"`
+	Refusal   string           `json:"refusal,omitempty"`
+}
+
+// openAIRespAudio is audio output returned by audio-capable models.
+type openAIRespAudio struct {
+	ID         string `json:"id"`
+	Data       string `json:"data"`
+	Transcript string `json:"transcript,omitempty"`
+	ExpiresAt  int64  `json:"expires_at,omitempty"`
 }
 
 // openAIToolCall represents a tool call in an OpenAI response.
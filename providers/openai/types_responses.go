@@ -12,11 +12,16 @@ type responsesRequest struct {
 	MaxOutputTokens    *int                     `json:"max_output_tokens,omitempty"`
 	Temperature        *float32                 `json:"temperature,omitempty"`
 	Tools              []responsesTool          `json:"tools,omitempty"`
+	ToolChoice         any                      `json:"tool_choice,omitempty"`
+	ParallelToolCalls  *bool                    `json:"parallel_tool_calls,omitempty"`
 	ToolResources      *responsesToolResources  `json:"tool_resources,omitempty"`
 	Reasoning          *responsesReasoningParam `json:"reasoning,omitempty"`
 	PreviousResponseID string                   `json:"previous_response_id,omitempty"`
 	Truncation         string                   `json:"truncation,omitempty"`
+	Store              *bool                    `json:"store,omitempty"`
+	Metadata           map[string]string        `json:"metadata,omitempty"`
 	Stream             bool                     `json:"stream,omitempty"`
+	Background         bool                     `json:"background,omitempty"`
 	StreamOptions      *streamOptions           `json:"stream_options,omitempty"`
 }
 
@@ -99,6 +104,19 @@ func (c *responsesContent) UnmarshalJSON(data []byte) error {
 type responsesInputMessage struct {
 	Role    string           `json:"role"`
 	Content responsesContent `json:"content"`
+
+	// ComputerOutput, when set, replaces the message with a
+	// computer_call_output item.
+	ComputerOutput *responsesComputerCallOutput `json:"-"`
+}
+
+// MarshalJSON implements custom marshaling for responsesInputMessage.
+func (m responsesInputMessage) MarshalJSON() ([]byte, error) {
+	if m.ComputerOutput != nil {
+		return json.Marshal(m.ComputerOutput)
+	}
+	type plain responsesInputMessage
+	return json.Marshal(plain(m))
 }
 
 // responsesContentPart represents a content part in a Responses API input message.
@@ -128,6 +146,30 @@ type responsesTool struct {
 	Name        string          `json:"name,omitempty"`
 	Description string          `json:"description,omitempty"`
 	Parameters  json.RawMessage `json:"parameters,omitempty"`
+
+	// For computer_use_preview
+	DisplayWidth  int    `json:"display_width,omitempty"`
+	DisplayHeight int    `json:"display_height,omitempty"`
+	Environment   string `json:"environment,omitempty"`
+
+	// For web_search
+	Filters           *responsesSearchFilters  `json:"filters,omitempty"`
+	UserLocation      *responsesSearchLocation `json:"user_location,omitempty"`
+	SearchContextSize string                   `json:"search_context_size,omitempty"`
+}
+
+// responsesSearchFilters restricts web_search results.
+type responsesSearchFilters struct {
+	AllowedDomains []string `json:"allowed_domains,omitempty"`
+}
+
+// responsesSearchLocation is the approximate user location for web_search.
+type responsesSearchLocation struct {
+	Type     string `json:"type"` // "approximate"
+	Country  string `json:"country,omitempty"`
+	Region   string `json:"region,omitempty"`
+	City     string `json:"city,omitempty"`
+	Timezone string `json:"timezone,omitempty"`
 }
 
 // responsesResponse represents a response from the OpenAI Responses API.
@@ -152,7 +194,7 @@ type incompleteInfo struct {
 // responsesOutput represents an output item in a Responses API response.
 // The Type field determines which other fields are populated.
 type responsesOutput struct {
-	Type   string `json:"type"` // "reasoning", "message", "function_call"
+	Type   string `json:"type"` // "reasoning", "message", "function_call", "image_generation_call"
 	ID     string `json:"id,omitempty"`
 	Status string `json:"status,omitempty"`
 	Role   string `json:"role,omitempty"`
@@ -167,6 +209,55 @@ type responsesOutput struct {
 	CallID    string `json:"call_id,omitempty"`
 	Name      string `json:"name,omitempty"`
 	Arguments string `json:"arguments,omitempty"`
+
+	// For image_generation_call type
+	Result       string `json:"result,omitempty"` // base64 encoded image
+	OutputFormat string `json:"output_format,omitempty"`
+
+	// For computer_call type
+	Action              *responsesComputerAction `json:"action,omitempty"`
+	PendingSafetyChecks []responsesSafetyCheck   `json:"pending_safety_checks,omitempty"`
+}
+
+// responsesComputerAction is the action of a computer_call output item.
+type responsesComputerAction struct {
+	Type    string                 `json:"type"`
+	X       int                    `json:"x,omitempty"`
+	Y       int                    `json:"y,omitempty"`
+	Button  string                 `json:"button,omitempty"`
+	ScrollX int                    `json:"scroll_x,omitempty"`
+	ScrollY int                    `json:"scroll_y,omitempty"`
+	Text    string                 `json:"text,omitempty"`
+	Keys    []string               `json:"keys,omitempty"`
+	Path    []responsesScreenPoint `json:"path,omitempty"`
+}
+
+// responsesScreenPoint is a point on a drag path.
+type responsesScreenPoint struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// responsesSafetyCheck is a computer-use safety check.
+type responsesSafetyCheck struct {
+	ID      string `json:"id"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// responsesComputerCallOutput is a computer_call_output input item.
+type responsesComputerCallOutput struct {
+	Type                     string                      `json:"type"`
+	CallID                   string                      `json:"call_id"`
+	Output                   responsesComputerScreenshot `json:"output"`
+	AcknowledgedSafetyChecks []responsesSafetyCheck      `json:"acknowledged_safety_checks,omitempty"`
+	CurrentURL               string                      `json:"current_url,omitempty"`
+}
+
+// responsesComputerScreenshot is the screenshot of a computer_call_output.
+type responsesComputerScreenshot struct {
+	Type     string `json:"type"`
+	ImageURL string `json:"image_url"`
 }
 
 // responsesReasoningSummary contains a summary of reasoning.
@@ -177,8 +268,9 @@ type responsesReasoningSummary struct {
 
 // responsesMessageContent represents content in a message output.
 type responsesMessageContent struct {
-	Type string `json:"type"`
-	Text string `json:"text,omitempty"`
+	Type    string `json:"type"`
+	Text    string `json:"text,omitempty"`
+	Refusal string `json:"refusal,omitempty"` // For refusal type
 }
 
 // responsesUsage tracks token usage for a Responses API request.
@@ -195,6 +287,58 @@ type responsesError struct {
 	Message string `json:"message"`
 }
 
+// ResponseDeleteResponse contains the result of a response deletion.
+type ResponseDeleteResponse struct {
+	ID      string `json:"id"`
+	Object  string `json:"object"`
+	Deleted bool   `json:"deleted"`
+}
+
+// ResponseInputItem is one input item of a stored response: a message, a
+// tool call, or a tool call output. Raw holds the item as returned by the
+// API, including fields of item types not modeled here.
+type ResponseInputItem struct {
+	ID      string                 `json:"id"`
+	Type    string                 `json:"type"`
+	Role    string                 `json:"role,omitempty"`
+	Status  string                 `json:"status,omitempty"`
+	Content []ResponseInputContent `json:"content,omitempty"`
+
+	// Tool call fields
+	CallID    string `json:"call_id,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+
+	Raw json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the item and keeps a copy in Raw.
+func (i *ResponseInputItem) UnmarshalJSON(data []byte) error {
+	type plain ResponseInputItem
+	if err := json.Unmarshal(data, (*plain)(i)); err != nil {
+		return err
+	}
+	i.Raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// ResponseInputContent is one content part of a ResponseInputItem.
+type ResponseInputContent struct {
+	Type     string `json:"type"`
+	Text     string `json:"text,omitempty"`
+	ImageURL string `json:"image_url,omitempty"`
+	FileID   string `json:"file_id,omitempty"`
+}
+
+// responseInputItemList is a page of input items.
+type responseInputItemList struct {
+	Object  string              `json:"object"`
+	Data    []ResponseInputItem `json:"data"`
+	HasMore bool                `json:"has_more"`
+	FirstID string              `json:"first_id,omitempty"`
+	LastID  string              `json:"last_id,omitempty"`
+}
+
 // Streaming event types for the Responses API.
 
 // responsesStreamEvent represents a streaming event from the Responses API.
@@ -207,6 +351,10 @@ type responsesStreamEvent struct {
 	ContentIndex int    `json:"content_index,omitempty"`
 	OutputIndex  int    `json:"output_index,omitempty"`
 	ItemID       string `json:"item_id,omitempty"`
+	// SequenceNumber orders the events of a response, for resuming
+	SequenceNumber *int64 `json:"sequence_number,omitempty"`
+	// Refusal is the complete refusal text of response.refusal.done
+	Refusal string `json:"refusal,omitempty"`
 }
 
 // responsesContentDelta represents a content delta in streaming.
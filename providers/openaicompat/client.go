@@ -0,0 +1,102 @@
+package openaicompat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// API endpoint paths, relative to the base URL.
+const (
+	chatCompletionsPath = "/chat/completions"
+	modelsPath          = "/models"
+)
+
+// doChat performs a non-streaming chat completion request.
+func (p *Provider) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	body, err := json.Marshal(buildRequest(ctx, &p.config, req, false))
+	if err != nil {
+		return nil, p.newDecodeError(err)
+	}
+
+	resp, err := p.post(ctx, chatCompletionsPath, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	// Read response body
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, p.newNetworkError(err)
+	}
+
+	// Check for error status
+	if resp.StatusCode >= 400 {
+		return nil, p.normalizeError(resp.StatusCode, respBody, resp.Header)
+	}
+
+	// Parse response
+	var chatResp chatResponse
+	if err := json.Unmarshal(respBody, &chatResp); err != nil {
+		return nil, p.newDecodeError(err)
+	}
+
+	return mapResponse(&chatResp)
+}
+
+// post sends a JSON request body to path.
+func (p *Provider) post(ctx context.Context, path string, body []byte) (*http.Response, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, p.config.BaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, p.newNetworkError(err)
+	}
+	httpReq.Header = p.buildHeaders()
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, p.newNetworkError(err)
+	}
+	return resp, nil
+}
+
+// ListModels asks the server for the models it serves. Capabilities are
+// the features configured for the provider, since the models endpoint does
+// not report them.
+func (p *Provider) ListModels(ctx context.Context) ([]core.ModelInfo, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.BaseURL+modelsPath, nil)
+	if err != nil {
+		return nil, p.newNetworkError(err)
+	}
+	httpReq.Header = p.buildHeaders()
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, p.newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, p.normalizeError(resp.StatusCode, body, resp.Header)
+	}
+
+	var list modelList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, p.newDecodeError(err)
+	}
+
+	models := make([]core.ModelInfo, len(list.Data))
+	for i, m := range list.Data {
+		models[i] = core.ModelInfo{
+			ID:           core.ModelID(m.ID),
+			DisplayName:  m.ID,
+			Capabilities: p.config.Features,
+		}
+	}
+	return models, nil
+}
@@ -0,0 +1,121 @@
+package openaicompat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestChat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/chat/completions" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Errorf("Authorization = %q, want none", r.Header.Get("Authorization"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"id": "chatcmpl-1",
+			"model": "qwen3",
+			"choices": [{"index": 0, "message": {"role": "assistant", "content": "Hello!", "reasoning": "Greet back."}, "finish_reason": "stop"}],
+			"usage": {"prompt_tokens": 5, "completion_tokens": 2, "total_tokens": 7}
+		}`))
+	}))
+	defer server.Close()
+
+	p := New(server.URL+"/v1", "")
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:    "qwen3",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Output != "Hello!" || resp.Usage.TotalTokens != 7 {
+		t.Errorf("response = %+v", resp)
+	}
+	if resp.Reasoning == nil || resp.Reasoning.Summary[0] != "Greet back." {
+		t.Errorf("Reasoning = %+v", resp.Reasoning)
+	}
+}
+
+func TestChatErrorUsesID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": {"message": "Unauthorized", "type": "AuthenticationError"}}`))
+	}))
+	defer server.Close()
+
+	p := New(server.URL, "", WithID("vllm"))
+	_, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:    "qwen3",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+	})
+
+	var pe *core.ProviderError
+	if !errors.As(err, &pe) {
+		t.Fatalf("error = %v, want *core.ProviderError", err)
+	}
+	if pe.Provider != "vllm" || !errors.Is(err, core.ErrUnauthorized) {
+		t.Errorf("error = %+v, want a vllm unauthorized error", pe)
+	}
+}
+
+func TestChatRateLimitedRetryAfter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3")
+		w.Header().Set("X-Wait", "9")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error": {"message": "Too many requests"}}`))
+	}))
+	defer server.Close()
+
+	req := &core.ChatRequest{Model: "qwen3", Messages: []core.Message{{Role: core.RoleUser, Content: "Hi"}}}
+
+	_, err := New(server.URL, "").Chat(context.Background(), req)
+	var pe *core.ProviderError
+	if !errors.As(err, &pe) || !errors.Is(err, core.ErrRateLimited) {
+		t.Fatalf("error = %v, want a rate limit error", err)
+	}
+	if pe.RetryAfter != 3*time.Second {
+		t.Errorf("RetryAfter = %v, want 3s", pe.RetryAfter)
+	}
+
+	p := New(server.URL, "", WithRetryAfter(func(h http.Header) time.Duration {
+		seconds, _ := strconv.Atoi(h.Get("X-Wait"))
+		return time.Duration(seconds) * time.Second
+	}))
+	_, err = p.Chat(context.Background(), req)
+	if !errors.As(err, &pe) || pe.RetryAfter != 9*time.Second {
+		t.Errorf("RetryAfter = %v, want 9s from WithRetryAfter", pe.RetryAfter)
+	}
+}
+
+func TestListModels(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/models" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		w.Write([]byte(`{"object": "list", "data": [{"id": "Qwen/Qwen3-8B", "object": "model", "owned_by": "vllm"}]}`))
+	}))
+	defer server.Close()
+
+	p := New(server.URL+"/v1", "", WithFeatures(core.FeatureChat))
+	models, err := p.ListModels(context.Background())
+	if err != nil {
+		t.Fatalf("ListModels() error = %v", err)
+	}
+	if len(models) != 1 || models[0].ID != "Qwen/Qwen3-8B" {
+		t.Fatalf("models = %+v", models)
+	}
+	if len(models[0].Capabilities) != 1 || models[0].Capabilities[0] != core.FeatureChat {
+		t.Errorf("Capabilities = %v, want the configured features", models[0].Capabilities)
+	}
+}
@@ -0,0 +1,26 @@
+package openaicompat
+
+import (
+	"context"
+	"maps"
+)
+
+type extraBodyKey struct{}
+
+// ContextWithExtraBody returns a context that adds fields to the body of
+// chat requests made with it, on top of those set with WithExtraBody.
+// Fields already on ctx are kept unless fields replaces them.
+func ContextWithExtraBody(ctx context.Context, fields map[string]any) context.Context {
+	merged := maps.Clone(extraBodyFromContext(ctx))
+	if merged == nil {
+		merged = make(map[string]any, len(fields))
+	}
+	maps.Copy(merged, fields)
+	return context.WithValue(ctx, extraBodyKey{}, merged)
+}
+
+// extraBodyFromContext returns the fields set on ctx, or nil.
+func extraBodyFromContext(ctx context.Context) map[string]any {
+	fields, _ := ctx.Value(extraBodyKey{}).(map[string]any)
+	return fields
+}
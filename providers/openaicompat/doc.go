@@ -0,0 +1,55 @@
+// Package openaicompat provides an LLM provider for any server that
+// implements the OpenAI Chat Completions API, such as vLLM, the llama.cpp
+// server, LM Studio, text-generation-webui, or LocalAI.
+//
+// # Basic Usage
+//
+//	provider := openaicompat.New("http://localhost:8000/v1", "")
+//	resp, err := core.NewClient(provider).Chat("Qwen/Qwen3-8B").
+//	    User("Hello!").
+//	    GetResponse(ctx)
+//
+// The API key may be empty for servers that do not require one; no
+// Authorization header is sent then. WithID names the provider in errors
+// and telemetry:
+//
+//	provider := openaicompat.New("http://localhost:1234/v1", "", openaicompat.WithID("lmstudio"))
+//
+// # Compatibility Settings
+//
+// Servers differ in which parts of the API they accept. By default the
+// provider sends only the widely supported fields. Options adjust the
+// request for a particular server:
+//
+//   - WithStrictTools: mark tool definitions strict, for servers with
+//     guided decoding such as vLLM
+//   - WithStreamUsage: request token usage in streams
+//   - WithMaxCompletionTokens: send max_completion_tokens instead of max_tokens
+//   - WithoutToolChoice: omit tool_choice and parallel_tool_calls
+//   - WithoutJSONSchema: send JSON schema requests as plain JSON mode
+//   - WithoutSystemRole: merge system messages into the first user message
+//
+// WithExtraBody adds server-specific fields, such as sampling parameters
+// the OpenAI API does not define, to every request body.
+// ContextWithExtraBody adds fields to the requests made with a context.
+// The vllm package builds on this for vLLM's request fields.
+//
+// WithFeatures sets the features Supports reports, for example adding
+// core.FeatureVision when the server runs a vision model.
+//
+// Reasoning returned in reasoning_content or reasoning, as by vLLM and
+// llama.cpp with reasoning models, is mapped to ChatResponse.Reasoning.
+//
+// # Models
+//
+// Models returns the models set with WithModels. ListModels asks the server
+// which models it serves.
+//
+// # Registry
+//
+// Importing the package registers the "openaicompat" provider, which reads
+// its base URL and API key from ProviderConfig or the OPENAICOMPAT_BASE_URL
+// and OPENAICOMPAT_API_KEY environment variables:
+//
+//	provider, model, err := core.NewProviderFromString("openaicompat:Qwen3-8B@http://localhost:8000/v1")
+package openaicompat
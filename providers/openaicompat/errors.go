@@ -0,0 +1,38 @@
+package openaicompat
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
+)
+
+// ErrToolArgsInvalidJSON is returned when tool call arguments contain invalid JSON.
+var ErrToolArgsInvalidJSON = errors.New("tool args invalid json")
+
+// normalizeError converts an HTTP error response to a ProviderError with the
+// appropriate sentinel and, for 429 and 503 responses, the wait the server
+// requested.
+func (p *Provider) normalizeError(status int, body []byte, header http.Header) error {
+	err := normalize.OpenAIStyleProviderError(p.config.ID, status, body, header.Get("x-request-id"))
+	var pe *core.ProviderError
+	if (status == http.StatusTooManyRequests || status == http.StatusServiceUnavailable) && errors.As(err, &pe) {
+		retryAfter := normalize.RetryAfter
+		if p.config.RetryAfter != nil {
+			retryAfter = p.config.RetryAfter
+		}
+		pe.RetryAfter = retryAfter(header)
+	}
+	return err
+}
+
+// newNetworkError creates a ProviderError for network-related failures.
+func (p *Provider) newNetworkError(err error) error {
+	return normalize.NetworkError(p.config.ID, err)
+}
+
+// newDecodeError creates a ProviderError for JSON decode failures.
+func (p *Provider) newDecodeError(err error) error {
+	return normalize.DecodeError(p.config.ID, err)
+}
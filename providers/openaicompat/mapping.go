@@ -0,0 +1,304 @@
+package openaicompat
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"strings"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
+	"github.com/petal-labs/iris/providers/internal/toolchoice"
+	"github.com/petal-labs/iris/tools"
+)
+
+// schemaProvider is an interface for tools that provide a JSON schema.
+type schemaProvider interface {
+	Schema() tools.ToolSchema
+}
+
+// mapMessages converts Iris messages to chat completions messages.
+// Tool result content is serialized according to policy (nil uses the default).
+func mapMessages(msgs []core.Message, policy *core.ToolResultPolicy) []chatMessage {
+	result := make([]chatMessage, 0, len(msgs))
+
+	for _, msg := range msgs {
+		switch msg.Role {
+		case core.RoleTool:
+			// Tool result messages: expand into individual messages per result
+			for _, tr := range msg.ToolResults {
+				result = append(result, chatMessage{
+					Role:       "tool",
+					Content:    policy.Serialize(tr.Content),
+					ToolCallID: tr.CallID,
+				})
+			}
+
+		case core.RoleAssistant:
+			result = append(result, chatMessage{
+				Role:      "assistant",
+				Content:   msg.Content,
+				ToolCalls: mapToolCallsToWire(msg.ToolCalls),
+			})
+
+		default:
+			// System, User messages
+			result = append(result, chatMessage{
+				Role:    string(msg.Role),
+				Content: msg.Content,
+				Parts:   mapContentParts(msg),
+			})
+		}
+	}
+
+	return result
+}
+
+// mergeSystemMessages moves the content of system messages to the start of
+// the first user message, for models that reject the system role.
+func mergeSystemMessages(msgs []chatMessage) []chatMessage {
+	var system []string
+	result := make([]chatMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		if msg.Role == "system" {
+			system = append(system, msg.Content)
+			continue
+		}
+		result = append(result, msg)
+	}
+	if len(system) == 0 {
+		return msgs
+	}
+
+	prefix := strings.Join(system, "\n\n")
+	for i, msg := range result {
+		if msg.Role != "user" {
+			continue
+		}
+		if len(msg.Parts) > 0 {
+			msg.Parts = append([]contentPart{{Type: "text", Text: prefix}}, msg.Parts...)
+		} else {
+			msg.Content = prefix + "\n\n" + msg.Content
+		}
+		result[i] = msg
+		return result
+	}
+	// No user message: send the instructions as one
+	return append([]chatMessage{{Role: "user", Content: prefix}}, result...)
+}
+
+// mapContentParts converts multimodal message parts to content parts. It
+// returns nil for text-only messages. Parts other than text and images by
+// URL are not part of the common API and are dropped.
+func mapContentParts(msg core.Message) []contentPart {
+	if len(msg.Parts) == 0 {
+		return nil
+	}
+
+	parts := make([]contentPart, 0, len(msg.Parts)+1)
+	if msg.Content != "" {
+		parts = append(parts, contentPart{Type: "text", Text: msg.Content})
+	}
+	for _, part := range msg.Parts {
+		switch p := part.(type) {
+		case *core.InputText:
+			parts = append(parts, contentPart{Type: "text", Text: p.Text})
+		case *core.InputImage:
+			if p.ImageURL == "" {
+				continue
+			}
+			parts = append(parts, contentPart{
+				Type:     "image_url",
+				ImageURL: &imageURLPart{URL: p.ImageURL, Detail: string(p.Detail)},
+			})
+		}
+	}
+	return parts
+}
+
+// mapToolCallsToWire converts Iris ToolCalls to the wire format.
+func mapToolCallsToWire(calls []core.ToolCall) []toolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+	result := make([]toolCall, len(calls))
+	for i, tc := range calls {
+		result[i] = toolCall{
+			ID:   tc.ID,
+			Type: "function",
+			Function: functionCall{
+				Name:      tc.Name,
+				Arguments: string(tc.Arguments),
+			},
+		}
+	}
+	return result
+}
+
+// mapTools converts Iris tools to tool definitions.
+func mapTools(irisTools []core.Tool, strict bool) []chatTool {
+	if len(irisTools) == 0 {
+		return nil
+	}
+
+	result := make([]chatTool, len(irisTools))
+	for i, t := range irisTools {
+		var params json.RawMessage
+
+		// Check if the tool provides a schema
+		if sp, ok := t.(schemaProvider); ok {
+			params = sp.Schema().JSONSchema
+		}
+
+		// Default to empty object if no schema
+		if params == nil {
+			params = json.RawMessage(`{"type":"object","properties":{}}`)
+		}
+
+		result[i] = chatTool{
+			Type: "function",
+			Function: chatFunction{
+				Name:        t.Name(),
+				Description: t.Description(),
+				Parameters:  params,
+				Strict:      strict,
+			},
+		}
+	}
+	return result
+}
+
+// buildRequest creates a chat completions request from an Iris ChatRequest,
+// applying the compatibility settings in cfg and extra body fields from cfg
+// and ctx.
+func buildRequest(ctx context.Context, cfg *Config, req *core.ChatRequest, stream bool) *chatRequest {
+	out := &chatRequest{
+		Model:       string(req.Model),
+		Messages:    mapMessages(req.Messages, req.ToolResultPolicy),
+		Temperature: req.Temperature,
+		Stream:      stream,
+	}
+	if cfg.NoSystemRole {
+		out.Messages = mergeSystemMessages(out.Messages)
+	}
+
+	if cfg.MaxCompletionTokens {
+		out.MaxCompletionTokens = req.MaxTokens
+	} else {
+		out.MaxTokens = req.MaxTokens
+	}
+
+	if stream && cfg.StreamUsage {
+		out.StreamOptions = &streamOptions{IncludeUsage: true}
+	}
+
+	// Map tools if present
+	if len(req.Tools) > 0 {
+		out.Tools = mapTools(req.Tools, cfg.StrictTools)
+		if !cfg.NoToolChoice {
+			out.ToolChoice = toolchoice.OpenAI(req.ToolChoice)
+			out.ParallelToolCalls = req.ParallelToolCalls
+		}
+	}
+
+	out.ResponseFormat = mapResponseFormat(req, cfg.NoJSONSchema)
+
+	if extra := extraBodyFromContext(ctx); len(cfg.ExtraBody) > 0 || len(extra) > 0 {
+		out.Extra = make(map[string]any, len(cfg.ExtraBody)+len(extra))
+		maps.Copy(out.Extra, cfg.ExtraBody)
+		maps.Copy(out.Extra, extra)
+	}
+	return out
+}
+
+// mapResponseFormat converts the Iris response format to response_format.
+func mapResponseFormat(req *core.ChatRequest, noJSONSchema bool) *responseFormat {
+	switch req.ResponseFormat {
+	case core.ResponseFormatJSON:
+		return &responseFormat{Type: "json_object"}
+	case core.ResponseFormatJSONSchema:
+		if req.JSONSchema == nil {
+			return nil
+		}
+		if noJSONSchema {
+			return &responseFormat{Type: "json_object"}
+		}
+		return &responseFormat{
+			Type: "json_schema",
+			JSONSchema: &jsonSchema{
+				Name:        req.JSONSchema.Name,
+				Description: req.JSONSchema.Description,
+				Schema:      req.JSONSchema.Schema,
+				Strict:      req.JSONSchema.Strict,
+			},
+		}
+	default:
+		// ResponseFormatText or empty: no response_format constraint
+		return nil
+	}
+}
+
+// mapResponse converts a chat completions response to an Iris ChatResponse.
+func mapResponse(resp *chatResponse) (*core.ChatResponse, error) {
+	result := &core.ChatResponse{
+		ID:                resp.ID,
+		Model:             core.ModelID(resp.Model),
+		SystemFingerprint: resp.SystemFingerprint,
+		Usage: core.TokenUsage{
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+			TotalTokens:      resp.Usage.TotalTokens,
+		},
+	}
+
+	// Extract content from first choice
+	if len(resp.Choices) > 0 {
+		msg := resp.Choices[0].Message
+		result.Output = msg.Content
+		result.Reasoning = mapReasoning(msg.ReasoningContent + msg.Reasoning)
+		result.FinishReason = normalize.FinishReason(resp.Choices[0].FinishReason)
+		result.RawFinishReason = resp.Choices[0].FinishReason
+
+		if len(msg.ToolCalls) > 0 {
+			toolCalls, err := mapToolCalls(msg.ToolCalls)
+			if err != nil {
+				return nil, err
+			}
+			result.ToolCalls = toolCalls
+		}
+	}
+
+	return result, nil
+}
+
+// mapReasoning returns the reasoning output for text, or nil if it is empty.
+func mapReasoning(text string) *core.ReasoningOutput {
+	if text == "" {
+		return nil
+	}
+	return &core.ReasoningOutput{Summary: []string{text}}
+}
+
+// mapToolCalls converts wire tool calls to Iris ToolCalls.
+func mapToolCalls(calls []toolCall) ([]core.ToolCall, error) {
+	result := make([]core.ToolCall, len(calls))
+
+	for i, call := range calls {
+		args := call.Function.Arguments
+		// Some servers send an empty string for tools without parameters
+		if args == "" {
+			args = "{}"
+		}
+		if !json.Valid([]byte(args)) {
+			return nil, ErrToolArgsInvalidJSON
+		}
+
+		result[i] = core.ToolCall{
+			ID:        call.ID,
+			Name:      call.Function.Name,
+			Arguments: json.RawMessage(args),
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,217 @@
+package openaicompat
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/tools"
+)
+
+type weatherTool struct{}
+
+func (weatherTool) Name() string        { return "get_weather" }
+func (weatherTool) Description() string { return "Get the weather" }
+func (weatherTool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: json.RawMessage(`{"type":"object","properties":{"city":{"type":"string"}}}`)}
+}
+
+func marshal(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestBuildRequestDefaults(t *testing.T) {
+	maxTokens := 100
+	parallel := false
+	req := &core.ChatRequest{
+		Model:             "qwen3",
+		Messages:          []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+		MaxTokens:         &maxTokens,
+		Tools:             []core.Tool{weatherTool{}},
+		ToolChoice:        &core.ToolChoice{Mode: core.ToolChoiceRequired},
+		ParallelToolCalls: &parallel,
+	}
+
+	got := marshal(t, buildRequest(context.Background(), &Config{}, req, true))
+	for _, want := range []string{`"max_tokens":100`, `"tool_choice":"required"`, `"parallel_tool_calls":false`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("request %s missing %s", got, want)
+		}
+	}
+	for _, unwanted := range []string{"stream_options", "max_completion_tokens", `"strict"`} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("request %s contains %s by default", got, unwanted)
+		}
+	}
+}
+
+func TestBuildRequestCompatSettings(t *testing.T) {
+	maxTokens := 100
+	req := &core.ChatRequest{
+		Model:          "qwen3",
+		Messages:       []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+		MaxTokens:      &maxTokens,
+		Tools:          []core.Tool{weatherTool{}},
+		ToolChoice:     &core.ToolChoice{Mode: core.ToolChoiceRequired},
+		ResponseFormat: core.ResponseFormatJSONSchema,
+		JSONSchema:     &core.JSONSchemaDefinition{Name: "out", Schema: json.RawMessage(`{"type":"object"}`)},
+	}
+	cfg := &Config{
+		StrictTools:         true,
+		StreamUsage:         true,
+		MaxCompletionTokens: true,
+		NoToolChoice:        true,
+		NoJSONSchema:        true,
+	}
+
+	got := marshal(t, buildRequest(context.Background(), cfg, req, true))
+	for _, want := range []string{
+		`"max_completion_tokens":100`,
+		`"stream_options":{"include_usage":true}`,
+		`"strict":true`,
+		`"response_format":{"type":"json_object"}`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("request %s missing %s", got, want)
+		}
+	}
+	for _, unwanted := range []string{`"max_tokens"`, "tool_choice", "json_schema"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("request %s contains %s", got, unwanted)
+		}
+	}
+
+	// Stream options are only sent for streams
+	if got := marshal(t, buildRequest(context.Background(), cfg, req, false)); strings.Contains(got, "stream_options") {
+		t.Errorf("non-streaming request %s contains stream_options", got)
+	}
+}
+
+func TestBuildRequestExtraBody(t *testing.T) {
+	req := &core.ChatRequest{Model: "qwen3", Messages: []core.Message{{Role: core.RoleUser, Content: "Hi"}}}
+	cfg := &Config{}
+	WithExtraBody(map[string]any{"top_k": 20, "priority": 5})(cfg)
+
+	ctx := ContextWithExtraBody(context.Background(), map[string]any{"priority": 1})
+	ctx = ContextWithExtraBody(ctx, map[string]any{"guided_regex": "[0-9]+"})
+
+	got := marshal(t, buildRequest(ctx, cfg, req, false))
+	for _, want := range []string{`"top_k":20`, `"priority":1`, `"guided_regex":"[0-9]+"`, `"model":"qwen3"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("request %s missing %s", got, want)
+		}
+	}
+
+	// Without extra fields the request is unchanged
+	if got := marshal(t, buildRequest(context.Background(), &Config{}, req, false)); strings.Contains(got, "top_k") {
+		t.Errorf("request %s contains extra fields", got)
+	}
+}
+
+func TestMapMessagesToolRoundTrip(t *testing.T) {
+	msgs := mapMessages([]core.Message{
+		{Role: core.RoleUser, Content: "Weather in Paris?"},
+		{Role: core.RoleAssistant, ToolCalls: []core.ToolCall{
+			{ID: "call_1", Name: "get_weather", Arguments: json.RawMessage(`{"city":"Paris"}`)},
+		}},
+		{Role: core.RoleTool, ToolResults: []core.ToolResult{{CallID: "call_1", Content: "sunny"}}},
+	}, nil)
+
+	if len(msgs) != 3 {
+		t.Fatalf("len = %d, want 3", len(msgs))
+	}
+	if len(msgs[1].ToolCalls) != 1 || msgs[1].ToolCalls[0].Function.Arguments != `{"city":"Paris"}` {
+		t.Errorf("assistant tool calls = %+v", msgs[1].ToolCalls)
+	}
+	if msgs[2].Role != "tool" || msgs[2].ToolCallID != "call_1" || msgs[2].Content != "sunny" {
+		t.Errorf("tool message = %+v", msgs[2])
+	}
+}
+
+func TestMergeSystemMessages(t *testing.T) {
+	msgs := mergeSystemMessages([]chatMessage{
+		{Role: "system", Content: "Be brief."},
+		{Role: "user", Content: "Hi"},
+		{Role: "assistant", Content: "Hello"},
+		{Role: "user", Content: "Bye"},
+	})
+
+	if len(msgs) != 3 {
+		t.Fatalf("len = %d, want 3", len(msgs))
+	}
+	if msgs[0].Role != "user" || msgs[0].Content != "Be brief.\n\nHi" {
+		t.Errorf("first message = %+v", msgs[0])
+	}
+	if msgs[2].Content != "Bye" {
+		t.Errorf("later user message changed: %+v", msgs[2])
+	}
+
+	// Without a user message the instructions are sent as one
+	msgs = mergeSystemMessages([]chatMessage{{Role: "system", Content: "Be brief."}})
+	if len(msgs) != 1 || msgs[0].Role != "user" || msgs[0].Content != "Be brief." {
+		t.Errorf("messages = %+v", msgs)
+	}
+}
+
+func TestMapResponseReasoning(t *testing.T) {
+	resp, err := mapResponse(&chatResponse{
+		ID: "chatcmpl-1",
+		Choices: []chatChoice{{Message: responseMsg{
+			Content:          "42",
+			ReasoningContent: "Thinking it over.",
+			ToolCalls:        []toolCall{{ID: "call_1", Function: functionCall{Name: "noop"}}},
+		}}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Output != "42" {
+		t.Errorf("Output = %q", resp.Output)
+	}
+	if resp.Reasoning == nil || resp.Reasoning.Summary[0] != "Thinking it over." {
+		t.Errorf("Reasoning = %+v", resp.Reasoning)
+	}
+	// Empty arguments are treated as an empty object
+	if string(resp.ToolCalls[0].Arguments) != "{}" {
+		t.Errorf("Arguments = %s, want {}", resp.ToolCalls[0].Arguments)
+	}
+}
+
+func TestMapResponseFinishReason(t *testing.T) {
+	resp, err := mapResponse(&chatResponse{
+		Choices: []chatChoice{{Message: responseMsg{Content: "Once upon"}, FinishReason: "length"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.FinishReason != core.FinishLength || resp.RawFinishReason != "length" {
+		t.Errorf("FinishReason = %q, RawFinishReason = %q", resp.FinishReason, resp.RawFinishReason)
+	}
+}
+
+func TestMapToolCallsInvalidJSON(t *testing.T) {
+	_, err := mapToolCalls([]toolCall{{Function: functionCall{Name: "f", Arguments: "{bad"}}})
+	if err != ErrToolArgsInvalidJSON {
+		t.Errorf("error = %v, want ErrToolArgsInvalidJSON", err)
+	}
+}
+
+func TestMapMessagesToolResultPolicy(t *testing.T) {
+	msgs := []core.Message{{
+		Role:        core.RoleTool,
+		ToolResults: []core.ToolResult{{CallID: "call_1", Content: "plain text"}},
+	}}
+
+	policy := &core.ToolResultPolicy{Format: core.ToolResultFormatJSON}
+	result := mapMessages(msgs, policy)
+	if result[0].Content != `"plain text"` {
+		t.Errorf("Content = %q, want %q", result[0].Content, `"plain text"`)
+	}
+}
@@ -0,0 +1,292 @@
+package openaicompat
+
+import (
+	"maps"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// DefaultID is the provider ID used unless WithID is given.
+const DefaultID = "openaicompat"
+
+// DefaultFeatures are the features reported by Supports unless WithFeatures
+// is given.
+var DefaultFeatures = []core.Feature{
+	core.FeatureChat,
+	core.FeatureChatStreaming,
+	core.FeatureToolCalling,
+	core.FeatureStructuredOutput,
+}
+
+// Config holds configuration for an OpenAI-compatible provider.
+type Config struct {
+	// ID is the provider ID reported by ID and in errors. Defaults to
+	// DefaultID.
+	ID string
+
+	// APIKey is the API key. Local servers usually need none; when it is
+	// empty and Credentials is nil, no Authorization header is sent.
+	// Stored as Secret to prevent accidental logging.
+	APIKey core.Secret
+
+	// BaseURL is the API base URL including any version path, such as
+	// http://localhost:8000/v1 (required).
+	BaseURL string
+
+	// HTTPClient is the HTTP client to use. Defaults to a client with pooled
+	// connections shared by all providers. When set, Transport and Proxy are
+	// ignored.
+	HTTPClient *http.Client
+
+	// Transport replaces the default transport, for example to tune
+	// connection pooling or add instrumentation.
+	Transport http.RoundTripper
+
+	// Proxy routes requests through a proxy instead of the one configured by
+	// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY.
+	Proxy *url.URL
+
+	// Credentials, if set, supplies the API key for each request instead of
+	// APIKey, so keys can rotate without a restart.
+	Credentials core.CredentialProvider
+
+	// PathPrefix is inserted before the path of every request URL, for
+	// gateways that route by path.
+	PathPrefix string
+
+	// QueryParams are set on every request URL.
+	QueryParams url.Values
+
+	// RequestEditors are called on every request before it is sent.
+	RequestEditors []func(*http.Request)
+
+	// Headers contains optional extra headers to include in requests.
+	Headers http.Header
+
+	// Models is the list returned by Models. Servers usually serve whatever
+	// model they were started with, so it is empty unless WithModels is
+	// given; use ListModels to ask the server.
+	Models []core.ModelInfo
+
+	// Features are the features reported by Supports. Defaults to
+	// DefaultFeatures.
+	Features []core.Feature
+
+	// StrictTools sets "strict": true on tool definitions, so servers that
+	// support it constrain tool arguments to the schema.
+	StrictTools bool
+
+	// StreamUsage requests token usage in streams with stream_options.
+	StreamUsage bool
+
+	// MaxCompletionTokens sends the token limit as max_completion_tokens
+	// instead of max_tokens.
+	MaxCompletionTokens bool
+
+	// NoToolChoice omits tool_choice and parallel_tool_calls, which some
+	// servers reject.
+	NoToolChoice bool
+
+	// NoJSONSchema sends JSON schema requests as plain JSON mode
+	// (response_format type json_object), for servers without schema
+	// support.
+	NoJSONSchema bool
+
+	// NoSystemRole merges system messages into the first user message, for
+	// models whose chat templates reject the system role.
+	NoSystemRole bool
+
+	// ExtraBody holds server-specific fields added to every chat request
+	// body, such as sampling parameters the OpenAI API does not define.
+	ExtraBody map[string]any
+
+	// RetryAfter reads the wait a server requests from the headers of an
+	// error response, for ProviderError.RetryAfter. Defaults to reading
+	// Retry-After.
+	RetryAfter func(http.Header) time.Duration
+}
+
+// Option configures an OpenAI-compatible provider.
+type Option func(*Config)
+
+// WithID sets the provider ID, such as "vllm" or "lmstudio".
+func WithID(id string) Option {
+	return func(c *Config) {
+		c.ID = id
+	}
+}
+
+// WithBaseURL replaces the base URL given to New.
+func WithBaseURL(baseURL string) Option {
+	return func(c *Config) {
+		c.BaseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) {
+		c.HTTPClient = client
+	}
+}
+
+// WithTransport sets the transport used by the default HTTP client.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Config) {
+		c.Transport = rt
+	}
+}
+
+// WithProxy routes requests through the proxy at proxyURL.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Config) {
+		c.Proxy = proxyURL
+	}
+}
+
+// WithCredentials resolves the API key from creds for every request instead
+// of using a fixed key. A request rejected with 401 is retried once after
+// refreshing creds, if it implements core.CredentialRefresher.
+func WithCredentials(creds core.CredentialProvider) Option {
+	return func(c *Config) {
+		c.Credentials = creds
+	}
+}
+
+// WithPathPrefix inserts prefix before the path of every request URL, for
+// gateways such as LiteLLM or Portkey that route by path. With prefix
+// "/team-a", a request for https://gateway.example.com/v1/chat/completions
+// is sent to https://gateway.example.com/team-a/v1/chat/completions.
+func WithPathPrefix(prefix string) Option {
+	return func(c *Config) {
+		c.PathPrefix = prefix
+	}
+}
+
+// WithQueryParams sets query parameters on every request URL, such as a
+// version or routing parameter required by a gateway. Repeated calls add to
+// the parameters.
+func WithQueryParams(params url.Values) Option {
+	return func(c *Config) {
+		if c.QueryParams == nil {
+			c.QueryParams = make(url.Values)
+		}
+		for name, values := range params {
+			c.QueryParams[name] = append(c.QueryParams[name], values...)
+		}
+	}
+}
+
+// WithRequestEditor calls fn on every request before it is sent, after
+// authentication and other headers are set. fn may modify the request but
+// must not read or replace its body. Repeated calls add editors, which run
+// in order.
+func WithRequestEditor(fn func(*http.Request)) Option {
+	return func(c *Config) {
+		c.RequestEditors = append(c.RequestEditors, fn)
+	}
+}
+
+// WithHeader adds an extra header to include in requests.
+func WithHeader(key, value string) Option {
+	return func(c *Config) {
+		if c.Headers == nil {
+			c.Headers = make(http.Header)
+		}
+		c.Headers.Set(key, value)
+	}
+}
+
+// WithModels sets the models returned by Models.
+func WithModels(models ...core.ModelInfo) Option {
+	return func(c *Config) {
+		c.Models = models
+	}
+}
+
+// WithFeatures sets the features reported by Supports, replacing
+// DefaultFeatures. Add core.FeatureVision for servers running a vision
+// model, or leave out core.FeatureToolCalling for servers without tool
+// support.
+func WithFeatures(features ...core.Feature) Option {
+	return func(c *Config) {
+		c.Features = features
+	}
+}
+
+// WithStrictTools sets "strict": true on tool definitions. vLLM and other
+// servers with guided decoding then constrain tool arguments to the schema;
+// servers without support may reject the field.
+func WithStrictTools() Option {
+	return func(c *Config) {
+		c.StrictTools = true
+	}
+}
+
+// WithStreamUsage requests token usage in streams by sending
+// stream_options {"include_usage": true}. Without it most servers report no
+// usage for streamed responses.
+func WithStreamUsage() Option {
+	return func(c *Config) {
+		c.StreamUsage = true
+	}
+}
+
+// WithMaxCompletionTokens sends the token limit as max_completion_tokens
+// instead of the older max_tokens.
+func WithMaxCompletionTokens() Option {
+	return func(c *Config) {
+		c.MaxCompletionTokens = true
+	}
+}
+
+// WithoutToolChoice omits tool_choice and parallel_tool_calls from requests,
+// for servers that accept tools but reject these fields.
+func WithoutToolChoice() Option {
+	return func(c *Config) {
+		c.NoToolChoice = true
+	}
+}
+
+// WithoutJSONSchema sends JSON schema requests as plain JSON mode, for
+// servers that support response_format type json_object but not
+// json_schema. The output is then valid JSON but not checked against the
+// schema by the server.
+func WithoutJSONSchema() Option {
+	return func(c *Config) {
+		c.NoJSONSchema = true
+	}
+}
+
+// WithoutSystemRole merges system messages into the first user message,
+// for models whose chat templates reject the system role.
+func WithoutSystemRole() Option {
+	return func(c *Config) {
+		c.NoSystemRole = true
+	}
+}
+
+// WithExtraBody adds server-specific fields to every chat request body.
+// Fields override the standard fields of the same name. Repeated calls add
+// to the fields. Use ContextWithExtraBody to add fields to one request.
+func WithExtraBody(fields map[string]any) Option {
+	return func(c *Config) {
+		if c.ExtraBody == nil {
+			c.ExtraBody = make(map[string]any, len(fields))
+		}
+		maps.Copy(c.ExtraBody, fields)
+	}
+}
+
+// WithRetryAfter sets how the wait a server requests is read from the
+// headers of an error response, for servers that report it in headers other
+// than Retry-After.
+func WithRetryAfter(fn func(http.Header) time.Duration) Option {
+	return func(c *Config) {
+		c.RetryAfter = fn
+	}
+}
@@ -0,0 +1,124 @@
+package openaicompat
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
+)
+
+// Environment variable names read by NewFromEnv and the registry factory.
+const (
+	BaseURLEnvVar = "OPENAICOMPAT_BASE_URL"
+	APIKeyEnvVar  = "OPENAICOMPAT_API_KEY"
+)
+
+// ErrBaseURLNotFound is returned when no base URL is configured.
+var ErrBaseURLNotFound = errors.New("openaicompat: OPENAICOMPAT_BASE_URL environment variable not set")
+
+// NewFromEnv creates a provider for the server at OPENAICOMPAT_BASE_URL,
+// using OPENAICOMPAT_API_KEY as the API key if it is set.
+func NewFromEnv(opts ...Option) (*Provider, error) {
+	baseURL := os.Getenv(BaseURLEnvVar)
+	if baseURL == "" {
+		return nil, ErrBaseURLNotFound
+	}
+	return New(baseURL, os.Getenv(APIKeyEnvVar), opts...), nil
+}
+
+// Provider is an LLM provider for servers that implement the OpenAI Chat
+// Completions API. Provider is safe for concurrent use.
+type Provider struct {
+	config Config
+}
+
+// New creates a provider for the server at baseURL, such as
+// http://localhost:8000/v1. apiKey may be empty for servers that do not
+// require one.
+func New(baseURL, apiKey string, opts ...Option) *Provider {
+	cfg := Config{
+		ID:       DefaultID,
+		APIKey:   core.NewSecret(apiKey),
+		BaseURL:  strings.TrimSuffix(baseURL, "/"),
+		Features: DefaultFeatures,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+	}
+	cfg.HTTPClient = httpclient.WithRewrite(cfg.HTTPClient, httpclient.Rewrite{
+		PathPrefix: cfg.PathPrefix,
+		Query:      cfg.QueryParams,
+		Editors:    cfg.RequestEditors,
+	})
+	if cfg.Credentials != nil {
+		cfg.HTTPClient = httpclient.WithCredentials(cfg.HTTPClient, cfg.Credentials, httpclient.BearerAuth)
+	}
+
+	return &Provider{config: cfg}
+}
+
+// ID returns the provider identifier.
+func (p *Provider) ID() string {
+	return p.config.ID
+}
+
+// CloseIdleConnections closes the idle pooled connections of the provider's
+// HTTP client. core.Client.Close calls it during shutdown.
+func (p *Provider) CloseIdleConnections() {
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
+// Models returns the models set with WithModels.
+func (p *Provider) Models() []core.ModelInfo {
+	// Return a copy to prevent mutation
+	return slices.Clone(p.config.Models)
+}
+
+// Supports reports whether the provider supports the given feature.
+func (p *Provider) Supports(feature core.Feature) bool {
+	return slices.Contains(p.config.Features, feature)
+}
+
+// buildHeaders constructs the HTTP headers for an API request.
+func (p *Provider) buildHeaders() http.Header {
+	headers := make(http.Header)
+
+	headers.Set("Content-Type", "application/json")
+	if !p.config.APIKey.IsEmpty() {
+		headers.Set("Authorization", "Bearer "+p.config.APIKey.Expose())
+	}
+
+	// Copy any extra headers
+	for key, values := range p.config.Headers {
+		for _, v := range values {
+			headers.Add(key, v)
+		}
+	}
+
+	return headers
+}
+
+// Chat sends a non-streaming chat request.
+func (p *Provider) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	return p.doChat(ctx, req)
+}
+
+// StreamChat sends a streaming chat request.
+func (p *Provider) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	return p.doStreamChat(ctx, req)
+}
+
+// Compile-time check that Provider implements core.Provider.
+var _ core.Provider = (*Provider)(nil)
+
+// Compile-time check that Provider implements core.IdleConnectionCloser.
+var _ core.IdleConnectionCloser = (*Provider)(nil)
@@ -0,0 +1,86 @@
+package openaicompat
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestProviderImplementsProvider(t *testing.T) {
+	var _ core.Provider = New("http://localhost:8000/v1", "")
+}
+
+func TestNewDefaults(t *testing.T) {
+	p := New("http://localhost:8000/v1/", "")
+
+	if p.ID() != DefaultID {
+		t.Errorf("ID() = %q, want %q", p.ID(), DefaultID)
+	}
+	if p.config.BaseURL != "http://localhost:8000/v1" {
+		t.Errorf("BaseURL = %q, want trailing slash trimmed", p.config.BaseURL)
+	}
+	if len(p.Models()) != 0 {
+		t.Errorf("Models() = %v, want none", p.Models())
+	}
+	for _, f := range DefaultFeatures {
+		if !p.Supports(f) {
+			t.Errorf("Supports(%q) = false, want true", f)
+		}
+	}
+	if p.Supports(core.FeatureVision) {
+		t.Error("Supports(vision) = true by default")
+	}
+}
+
+func TestWithIDModelsAndFeatures(t *testing.T) {
+	p := New("http://localhost:1234/v1", "",
+		WithID("lmstudio"),
+		WithModels(core.ModelInfo{ID: "qwen3-8b"}),
+		WithFeatures(core.FeatureChat, core.FeatureVision),
+	)
+
+	if p.ID() != "lmstudio" {
+		t.Errorf("ID() = %q, want lmstudio", p.ID())
+	}
+	if models := p.Models(); len(models) != 1 || models[0].ID != "qwen3-8b" {
+		t.Errorf("Models() = %v", models)
+	}
+	if !p.Supports(core.FeatureVision) || p.Supports(core.FeatureToolCalling) {
+		t.Error("Supports does not reflect WithFeatures")
+	}
+}
+
+func TestBuildHeaders(t *testing.T) {
+	headers := New("http://localhost:8000/v1", "", WithHeader("X-Team", "research")).buildHeaders()
+	if headers.Get("Authorization") != "" {
+		t.Errorf("Authorization = %q, want none without an API key", headers.Get("Authorization"))
+	}
+	if headers.Get("X-Team") != "research" {
+		t.Errorf("X-Team = %q", headers.Get("X-Team"))
+	}
+
+	headers = New("http://localhost:8000/v1", "sk-local").buildHeaders()
+	if headers.Get("Authorization") != "Bearer sk-local" {
+		t.Errorf("Authorization = %q", headers.Get("Authorization"))
+	}
+}
+
+func TestRegisteredFactory(t *testing.T) {
+	t.Setenv(BaseURLEnvVar, "")
+
+	if _, err := core.NewProviderFromConfig(core.ProviderConfig{Name: "openaicompat"}); !errors.Is(err, ErrBaseURLNotFound) {
+		t.Errorf("error = %v, want ErrBaseURLNotFound", err)
+	}
+
+	p, model, err := core.NewProviderFromString("openaicompat:qwen3@http://localhost:8000/v1")
+	if err != nil {
+		t.Fatalf("NewProviderFromString() error = %v", err)
+	}
+	if model != "qwen3" {
+		t.Errorf("model = %q, want qwen3", model)
+	}
+	if got := p.(*Provider).config.BaseURL; got != "http://localhost:8000/v1" {
+		t.Errorf("BaseURL = %q", got)
+	}
+}
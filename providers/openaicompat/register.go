@@ -0,0 +1,24 @@
+package openaicompat
+
+import (
+	"cmp"
+	"os"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func init() {
+	// The base URL is required, so there is no registration with the
+	// key-only providers registry.
+	core.RegisterProvider("openaicompat", func(cfg core.ProviderConfig) (core.Provider, error) {
+		baseURL := cmp.Or(cfg.BaseURL, os.Getenv(BaseURLEnvVar))
+		if baseURL == "" {
+			return nil, ErrBaseURLNotFound
+		}
+		var opts []Option
+		if cfg.Credentials != nil {
+			opts = append(opts, WithCredentials(cfg.Credentials))
+		}
+		return New(baseURL, cfg.APIKeyOrEnv(APIKeyEnvVar), opts...), nil
+	})
+}
@@ -0,0 +1,189 @@
+package openaicompat
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
+	"github.com/petal-labs/iris/providers/internal/toolcalls"
+)
+
+// doStreamChat performs a streaming chat completion request.
+func (p *Provider) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	body, err := json.Marshal(buildRequest(ctx, &p.config, req, true))
+	if err != nil {
+		return nil, p.newDecodeError(err)
+	}
+
+	resp, err := p.post(ctx, chatCompletionsPath, body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check for error status
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		return nil, p.normalizeError(resp.StatusCode, respBody, resp.Header)
+	}
+
+	// Create channels
+	chunkCh := make(chan core.ChatChunk, 100)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+
+	// Start goroutine to process SSE stream
+	go p.processSSEStream(ctx, resp.Body, chunkCh, errCh, finalCh)
+
+	return &core.ChatStream{
+		Ch:    chunkCh,
+		Err:   errCh,
+		Final: finalCh,
+	}, nil
+}
+
+// processSSEStream reads the SSE stream and emits chunks.
+func (p *Provider) processSSEStream(
+	ctx context.Context,
+	body io.ReadCloser,
+	chunkCh chan<- core.ChatChunk,
+	errCh chan<- error,
+	finalCh chan<- *core.ChatResponse,
+) {
+	defer body.Close()
+	defer close(chunkCh)
+	defer close(errCh)
+	defer close(finalCh)
+
+	reader := bufio.NewReader(body)
+	// Some servers stream no argument fragments for tools without parameters
+	assembler := toolcalls.NewAssembler(toolcalls.Config{EmptyArgumentsJSON: "{}"})
+	var reasoning strings.Builder
+	var finishReason string
+
+	var responseID string
+	var responseModel string
+	var systemFingerprint string
+	var usage *chatUsage
+
+	for {
+		// Check for context cancellation
+		select {
+		case <-ctx.Done():
+			errCh <- ctx.Err()
+			return
+		default:
+		}
+
+		// Read line
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			errCh <- p.newNetworkError(err)
+			return
+		}
+
+		// Trim whitespace
+		line = strings.TrimSpace(line)
+
+		// Skip empty lines, comments, and non-data fields
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+
+		payload := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+
+		// Check for done signal
+		if payload == "[DONE]" {
+			break
+		}
+
+		// Parse chunk
+		var chunk streamChunk
+		if err := json.Unmarshal([]byte(payload), &chunk); err != nil {
+			errCh <- p.newDecodeError(err)
+			return
+		}
+
+		// Capture metadata
+		if chunk.ID != "" {
+			responseID = chunk.ID
+		}
+		if chunk.Model != "" {
+			responseModel = chunk.Model
+		}
+		if chunk.SystemFingerprint != "" {
+			systemFingerprint = chunk.SystemFingerprint
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+
+		// Process choices
+		for _, choice := range chunk.Choices {
+			reasoning.WriteString(choice.Delta.ReasoningContent)
+			reasoning.WriteString(choice.Delta.Reasoning)
+			if choice.FinishReason != nil && *choice.FinishReason != "" {
+				finishReason = *choice.FinishReason
+			}
+
+			// Emit content delta
+			if choice.Delta.Content != "" {
+				select {
+				case chunkCh <- core.ChatChunk{Delta: choice.Delta.Content}:
+				case <-ctx.Done():
+					errCh <- ctx.Err()
+					return
+				}
+			}
+
+			// Accumulate tool calls
+			for _, tc := range choice.Delta.ToolCalls {
+				assembler.AddFragment(toolcalls.Fragment{
+					Index:     tc.Index,
+					ID:        tc.ID,
+					Name:      tc.Function.Name,
+					Arguments: tc.Function.Arguments,
+				})
+			}
+		}
+	}
+
+	// Finalize tool calls
+	toolCalls, err := assembler.Finalize()
+	if err != nil {
+		if errors.Is(err, toolcalls.ErrInvalidJSON) {
+			err = ErrToolArgsInvalidJSON
+		}
+		errCh <- err
+		return
+	}
+
+	// Build final response
+	finalResp := &core.ChatResponse{
+		ID:                responseID,
+		Model:             core.ModelID(responseModel),
+		SystemFingerprint: systemFingerprint,
+		ToolCalls:         toolCalls,
+		Reasoning:         mapReasoning(reasoning.String()),
+		FinishReason:      normalize.FinishReason(finishReason),
+		RawFinishReason:   finishReason,
+	}
+
+	if usage != nil {
+		finalResp.Usage = core.TokenUsage{
+			PromptTokens:     usage.PromptTokens,
+			CompletionTokens: usage.CompletionTokens,
+			TotalTokens:      usage.TotalTokens,
+		}
+	}
+
+	finalCh <- finalResp
+}
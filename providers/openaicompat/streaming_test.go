@@ -0,0 +1,92 @@
+package openaicompat
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestStreamChat(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req map[string]any
+		json.Unmarshal(body, &req)
+		if req["stream"] != true || req["stream_options"] == nil {
+			t.Errorf("request = %s, want stream with stream_options", body)
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range []string{
+			`{"id":"chatcmpl-1","model":"qwen3","choices":[{"index":0,"delta":{"role":"assistant","reasoning_content":"Need "}}]}`,
+			`{"id":"chatcmpl-1","choices":[{"index":0,"delta":{"reasoning_content":"weather."}}]}`,
+			`{"id":"chatcmpl-1","choices":[{"index":0,"delta":{"content":"Checking"}}]}`,
+			`{"id":"chatcmpl-1","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"id":"call_1","type":"function","function":{"name":"get_weather","arguments":"{\"city\":"}}]}}]}`,
+			`{"id":"chatcmpl-1","choices":[{"index":0,"delta":{"tool_calls":[{"index":0,"function":{"arguments":"\"Paris\"}"}}]}}]}`,
+			`{"id":"chatcmpl-1","choices":[{"index":0,"delta":{"tool_calls":[{"index":1,"id":"call_2","type":"function","function":{"name":"get_time"}}]},"finish_reason":"tool_calls"}]}`,
+			`{"id":"chatcmpl-1","choices":[],"usage":{"prompt_tokens":10,"completion_tokens":5,"total_tokens":15}}`,
+		} {
+			io.WriteString(w, "data: "+event+"\n\n")
+		}
+		io.WriteString(w, "data: [DONE]\n\n")
+	}))
+	defer server.Close()
+
+	p := New(server.URL, "", WithStreamUsage())
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model:    "qwen3",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Weather in Paris?"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	var content strings.Builder
+	for chunk := range stream.Ch {
+		content.WriteString(chunk.Delta)
+	}
+	if err := <-stream.Err; err != nil {
+		t.Fatalf("stream error = %v", err)
+	}
+	final := <-stream.Final
+
+	if content.String() != "Checking" {
+		t.Errorf("content = %q", content.String())
+	}
+	if final.Usage.TotalTokens != 15 || final.Model != "qwen3" {
+		t.Errorf("final = %+v", final)
+	}
+	if final.Reasoning == nil || final.Reasoning.Summary[0] != "Need weather." {
+		t.Errorf("Reasoning = %+v", final.Reasoning)
+	}
+	if len(final.ToolCalls) != 2 {
+		t.Fatalf("ToolCalls = %+v", final.ToolCalls)
+	}
+	if string(final.ToolCalls[0].Arguments) != `{"city":"Paris"}` || string(final.ToolCalls[1].Arguments) != "{}" {
+		t.Errorf("arguments = %s, %s", final.ToolCalls[0].Arguments, final.ToolCalls[1].Arguments)
+	}
+	if final.FinishReason != core.FinishToolCalls || final.RawFinishReason != "tool_calls" {
+		t.Errorf("FinishReason = %q, RawFinishReason = %q", final.FinishReason, final.RawFinishReason)
+	}
+}
+
+func TestStreamChatError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": {"message": "tool_choice not supported"}}`))
+	}))
+	defer server.Close()
+
+	_, err := New(server.URL, "").StreamChat(context.Background(), &core.ChatRequest{
+		Model:    "qwen3",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "tool_choice not supported") {
+		t.Errorf("error = %v", err)
+	}
+}
@@ -0,0 +1,204 @@
+package openaicompat
+
+import (
+	"encoding/json"
+	"maps"
+)
+
+// chatRequest represents a request to the chat completions API.
+type chatRequest struct {
+	Model               string          `json:"model"`
+	Messages            []chatMessage   `json:"messages"`
+	Temperature         *float32        `json:"temperature,omitempty"`
+	MaxTokens           *int            `json:"max_tokens,omitempty"`
+	MaxCompletionTokens *int            `json:"max_completion_tokens,omitempty"`
+	Stream              bool            `json:"stream"`
+	StreamOptions       *streamOptions  `json:"stream_options,omitempty"`
+	Tools               []chatTool      `json:"tools,omitempty"`
+	ToolChoice          any             `json:"tool_choice,omitempty"`
+	ParallelToolCalls   *bool           `json:"parallel_tool_calls,omitempty"`
+	ResponseFormat      *responseFormat `json:"response_format,omitempty"`
+
+	// Extra holds server-specific fields merged into the body.
+	Extra map[string]any `json:"-"`
+}
+
+// MarshalJSON encodes the request with the Extra fields merged in.
+func (r chatRequest) MarshalJSON() ([]byte, error) {
+	type alias chatRequest
+	data, err := json.Marshal(alias(r))
+	if err != nil || len(r.Extra) == 0 {
+		return data, err
+	}
+
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	maps.Copy(fields, r.Extra)
+	return json.Marshal(fields)
+}
+
+// streamOptions represents the stream_options parameter.
+type streamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
+}
+
+// responseFormat represents the response_format parameter.
+type responseFormat struct {
+	Type       string      `json:"type"`
+	JSONSchema *jsonSchema `json:"json_schema,omitempty"`
+}
+
+// jsonSchema represents the JSON schema configuration for structured output.
+type jsonSchema struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Schema      json.RawMessage `json:"schema"`
+	Strict      bool            `json:"strict,omitempty"`
+}
+
+// chatMessage represents a message in the request.
+type chatMessage struct {
+	Role       string        `json:"role"`
+	Content    string        `json:"content"`
+	Parts      []contentPart `json:"-"`                      // Multimodal content; replaces Content when set
+	ToolCalls  []toolCall    `json:"tool_calls,omitempty"`   // For assistant messages requesting tools
+	ToolCallID string        `json:"tool_call_id,omitempty"` // For tool result messages
+}
+
+// MarshalJSON encodes content as an array of parts when Parts is set.
+func (m chatMessage) MarshalJSON() ([]byte, error) {
+	type alias chatMessage
+	if len(m.Parts) == 0 {
+		return json.Marshal(alias(m))
+	}
+	return json.Marshal(struct {
+		alias
+		Content []contentPart `json:"content"`
+	}{alias: alias(m), Content: m.Parts})
+}
+
+// contentPart is a multimodal content part. Compatible servers accept text
+// and images by URL or data URL.
+type contentPart struct {
+	Type     string        `json:"type"`
+	Text     string        `json:"text,omitempty"`
+	ImageURL *imageURLPart `json:"image_url,omitempty"`
+}
+
+// imageURLPart references an image by URL or data URL.
+type imageURLPart struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// chatTool represents a tool definition.
+type chatTool struct {
+	Type     string       `json:"type"`
+	Function chatFunction `json:"function"`
+}
+
+// chatFunction represents a function definition for tools.
+type chatFunction struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters"`
+	Strict      bool            `json:"strict,omitempty"`
+}
+
+// chatResponse represents a response from the chat completions API.
+type chatResponse struct {
+	ID                string       `json:"id"`
+	Model             string       `json:"model"`
+	SystemFingerprint string       `json:"system_fingerprint,omitempty"`
+	Choices           []chatChoice `json:"choices"`
+	Usage             chatUsage    `json:"usage"`
+}
+
+// chatChoice represents a single choice in a response.
+type chatChoice struct {
+	Index        int         `json:"index"`
+	Message      responseMsg `json:"message"`
+	FinishReason string      `json:"finish_reason"`
+}
+
+// responseMsg represents the assistant message in a response. Servers
+// running reasoning models return the reasoning in reasoning_content
+// (vLLM, llama.cpp, DeepSeek) or reasoning (newer vLLM, LM Studio).
+type responseMsg struct {
+	Role             string     `json:"role"`
+	Content          string     `json:"content"`
+	ReasoningContent string     `json:"reasoning_content,omitempty"`
+	Reasoning        string     `json:"reasoning,omitempty"`
+	ToolCalls        []toolCall `json:"tool_calls,omitempty"`
+}
+
+// toolCall represents a tool call.
+type toolCall struct {
+	ID       string       `json:"id"`
+	Type     string       `json:"type"`
+	Function functionCall `json:"function"`
+}
+
+// functionCall represents the function details in a tool call.
+type functionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// chatUsage represents token usage in a response.
+type chatUsage struct {
+	PromptTokens     int `json:"prompt_tokens"`
+	CompletionTokens int `json:"completion_tokens"`
+	TotalTokens      int `json:"total_tokens"`
+}
+
+// Streaming response types for the SSE protocol.
+
+// streamChunk represents a single chunk in a streaming response.
+type streamChunk struct {
+	ID                string         `json:"id"`
+	Model             string         `json:"model"`
+	SystemFingerprint string         `json:"system_fingerprint,omitempty"`
+	Choices           []streamChoice `json:"choices"`
+	Usage             *chatUsage     `json:"usage,omitempty"`
+}
+
+// streamChoice represents a single choice in a streaming chunk.
+type streamChoice struct {
+	Index        int         `json:"index"`
+	Delta        streamDelta `json:"delta"`
+	FinishReason *string     `json:"finish_reason,omitempty"`
+}
+
+// streamDelta represents the delta content in a streaming chunk.
+type streamDelta struct {
+	Role             string           `json:"role,omitempty"`
+	Content          string           `json:"content,omitempty"`
+	ReasoningContent string           `json:"reasoning_content,omitempty"`
+	Reasoning        string           `json:"reasoning,omitempty"`
+	ToolCalls        []streamToolCall `json:"tool_calls,omitempty"`
+}
+
+// streamToolCall represents a tool call fragment in a streaming chunk.
+type streamToolCall struct {
+	Index    int            `json:"index"`
+	ID       string         `json:"id,omitempty"`
+	Type     string         `json:"type,omitempty"`
+	Function streamFunction `json:"function,omitempty"`
+}
+
+// streamFunction represents a function fragment in a streaming tool call.
+type streamFunction struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// modelList represents the response from the models endpoint.
+type modelList struct {
+	Data []struct {
+		ID      string `json:"id"`
+		OwnedBy string `json:"owned_by,omitempty"`
+	} `json:"data"`
+}
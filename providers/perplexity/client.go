@@ -16,7 +16,7 @@ const chatCompletionsPath = "/chat/completions"
 // doChat performs a non-streaming chat completion request.
 func (p *Perplexity) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
 	// Build Perplexity request
-	pReq := buildRequest(req, false)
+	pReq := buildRequest(&p.config, req, false)
 
 	// Marshal request body
 	body, err := json.Marshal(pReq)
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
+	"github.com/petal-labs/iris/providers/internal/toolchoice"
 	"github.com/petal-labs/iris/tools"
 )
 
@@ -74,13 +76,18 @@ func mapReasoningEffort(effort core.ReasoningEffort) string {
 }
 
 // buildRequest creates a Perplexity API request from an Iris ChatRequest.
-func buildRequest(req *core.ChatRequest, stream bool) *perplexityRequest {
+func buildRequest(cfg *Config, req *core.ChatRequest, stream bool) *perplexityRequest {
 	pReq := &perplexityRequest{
 		Model:    string(req.Model),
 		Messages: mapMessages(req.Messages),
 		Stream:   stream,
 	}
 
+	if cfg.RelatedQuestions {
+		related := true
+		pReq.ReturnRelatedQuestions = &related
+	}
+
 	// Only set optional fields if provided
 	if req.Temperature != nil {
 		pReq.Temperature = req.Temperature
@@ -93,7 +100,7 @@ func buildRequest(req *core.ChatRequest, stream bool) *perplexityRequest {
 	// Map tools if present
 	if len(req.Tools) > 0 {
 		pReq.Tools = mapTools(req.Tools)
-		pReq.ToolChoice = "auto"
+		pReq.ToolChoice = toolchoice.OpenAI(req.ToolChoice)
 	}
 
 	// Map reasoning effort if set
@@ -107,8 +114,10 @@ func buildRequest(req *core.ChatRequest, stream bool) *perplexityRequest {
 // mapResponse converts a Perplexity response to an Iris ChatResponse.
 func mapResponse(resp *perplexityResponse) (*core.ChatResponse, error) {
 	result := &core.ChatResponse{
-		ID:    resp.ID,
-		Model: core.ModelID(resp.Model),
+		ID:        resp.ID,
+		Model:     core.ModelID(resp.Model),
+		Citations: mapCitations(resp.Citations, resp.SearchResults),
+		Metadata:  mapMetadata(resp.RelatedQuestions),
 	}
 
 	// Map usage if present
@@ -123,6 +132,8 @@ func mapResponse(resp *perplexityResponse) (*core.ChatResponse, error) {
 	// Extract content from first choice
 	if len(resp.Choices) > 0 {
 		choice := resp.Choices[0]
+		result.FinishReason = normalize.FinishReason(choice.FinishReason)
+		result.RawFinishReason = choice.FinishReason
 		if choice.Message != nil {
 			result.Output = choice.Message.Content
 
@@ -140,6 +151,40 @@ func mapResponse(resp *perplexityResponse) (*core.ChatResponse, error) {
 	return result, nil
 }
 
+// mapCitations converts the sources of a response to Iris Citations. Search
+// results carry titles and snippets and are listed in citation order, so
+// they are used when present; otherwise only the citation URLs are known.
+func mapCitations(urls []string, results []SearchResult) []core.Citation {
+	if len(results) > 0 {
+		citations := make([]core.Citation, len(results))
+		for i, r := range results {
+			citations[i] = core.Citation{
+				URL:     r.URL,
+				Title:   r.Title,
+				Snippet: r.Snippet,
+				Date:    r.Date,
+			}
+		}
+		return citations
+	}
+	if len(urls) == 0 {
+		return nil
+	}
+	citations := make([]core.Citation, len(urls))
+	for i, u := range urls {
+		citations[i] = core.Citation{URL: u}
+	}
+	return citations
+}
+
+// mapMetadata returns the response metadata, or nil if there is none.
+func mapMetadata(relatedQuestions []string) map[string]any {
+	if len(relatedQuestions) == 0 {
+		return nil
+	}
+	return map[string]any{MetadataRelatedQuestions: relatedQuestions}
+}
+
 // mapToolCalls converts Perplexity tool calls to Iris ToolCalls.
 func mapToolCalls(calls []perplexityToolCall) ([]core.ToolCall, error) {
 	result := make([]core.ToolCall, len(calls))
@@ -113,7 +113,7 @@ func TestBuildRequest(t *testing.T) {
 			},
 		}
 
-		result := buildRequest(req, false)
+		result := buildRequest(&Config{}, req, false)
 
 		if result.Model != "sonar" {
 			t.Errorf("Model = %q, want %q", result.Model, "sonar")
@@ -132,7 +132,7 @@ func TestBuildRequest(t *testing.T) {
 			Messages: []core.Message{{Role: core.RoleUser, Content: "Hello"}},
 		}
 
-		result := buildRequest(req, true)
+		result := buildRequest(&Config{}, req, true)
 
 		if !result.Stream {
 			t.Error("Stream should be true")
@@ -150,7 +150,7 @@ func TestBuildRequest(t *testing.T) {
 			MaxTokens:   &maxTokens,
 		}
 
-		result := buildRequest(req, false)
+		result := buildRequest(&Config{}, req, false)
 
 		if result.Temperature == nil || *result.Temperature != 0.7 {
 			t.Errorf("Temperature = %v, want 0.7", result.Temperature)
@@ -169,7 +169,7 @@ func TestBuildRequest(t *testing.T) {
 			},
 		}
 
-		result := buildRequest(req, false)
+		result := buildRequest(&Config{}, req, false)
 
 		if len(result.Tools) != 1 {
 			t.Fatalf("Tools count = %d, want 1", len(result.Tools))
@@ -186,13 +186,28 @@ func TestBuildRequest(t *testing.T) {
 			ReasoningEffort: core.ReasoningEffortHigh,
 		}
 
-		result := buildRequest(req, false)
+		result := buildRequest(&Config{}, req, false)
 
 		if result.ReasoningEffort != "high" {
 			t.Errorf("ReasoningEffort = %q, want %q", result.ReasoningEffort, "high")
 		}
 	})
 
+	t.Run("related questions", func(t *testing.T) {
+		req := &core.ChatRequest{
+			Model:    "sonar",
+			Messages: []core.Message{{Role: core.RoleUser, Content: "Hello"}},
+		}
+
+		if result := buildRequest(&Config{}, req, false); result.ReturnRelatedQuestions != nil {
+			t.Errorf("ReturnRelatedQuestions = %v, want nil by default", *result.ReturnRelatedQuestions)
+		}
+		result := buildRequest(&Config{RelatedQuestions: true}, req, false)
+		if result.ReturnRelatedQuestions == nil || !*result.ReturnRelatedQuestions {
+			t.Error("ReturnRelatedQuestions should be true with RelatedQuestions")
+		}
+	})
+
 	t.Run("reasoning effort none is omitted", func(t *testing.T) {
 		req := &core.ChatRequest{
 			Model:           "sonar",
@@ -200,7 +215,7 @@ func TestBuildRequest(t *testing.T) {
 			ReasoningEffort: core.ReasoningEffortNone,
 		}
 
-		result := buildRequest(req, false)
+		result := buildRequest(&Config{}, req, false)
 
 		if result.ReasoningEffort != "" {
 			t.Errorf("ReasoningEffort should be empty for None, got %q", result.ReasoningEffort)
@@ -253,6 +268,9 @@ func TestMapResponse(t *testing.T) {
 		if result.Usage.TotalTokens != 30 {
 			t.Errorf("TotalTokens = %d, want 30", result.Usage.TotalTokens)
 		}
+		if result.FinishReason != core.FinishStop || result.RawFinishReason != "stop" {
+			t.Errorf("FinishReason = %q, RawFinishReason = %q, want stop", result.FinishReason, result.RawFinishReason)
+		}
 	})
 
 	t.Run("with tool calls", func(t *testing.T) {
@@ -314,6 +332,51 @@ func TestMapResponse(t *testing.T) {
 		}
 	})
 
+	t.Run("with search results and related questions", func(t *testing.T) {
+		resp := &perplexityResponse{
+			ID:        "resp-def",
+			Model:     "sonar",
+			Choices:   []perplexityChoice{{Message: &perplexityRespMsg{Content: "Go 1.26 was released in February [1]."}}},
+			Citations: []string{"https://go.dev/blog/go1.26"},
+			SearchResults: []SearchResult{
+				{Title: "Go 1.26 is released", URL: "https://go.dev/blog/go1.26", Date: "2026-02-10", Snippet: "Today the Go team..."},
+			},
+			RelatedQuestions: []string{"What is new in Go 1.26?"},
+		}
+
+		result, err := mapResponse(resp)
+		if err != nil {
+			t.Fatalf("mapResponse() error = %v", err)
+		}
+
+		want := core.Citation{Title: "Go 1.26 is released", URL: "https://go.dev/blog/go1.26", Date: "2026-02-10", Snippet: "Today the Go team..."}
+		if len(result.Citations) != 1 || result.Citations[0] != want {
+			t.Errorf("Citations = %+v, want [%+v]", result.Citations, want)
+		}
+		if got := RelatedQuestions(result); len(got) != 1 || got[0] != "What is new in Go 1.26?" {
+			t.Errorf("RelatedQuestions() = %v", got)
+		}
+	})
+
+	t.Run("with citation URLs only", func(t *testing.T) {
+		resp := &perplexityResponse{
+			Choices:   []perplexityChoice{{Message: &perplexityRespMsg{Content: "See [1] and [2]."}}},
+			Citations: []string{"https://a.example", "https://b.example"},
+		}
+
+		result, err := mapResponse(resp)
+		if err != nil {
+			t.Fatalf("mapResponse() error = %v", err)
+		}
+
+		if len(result.Citations) != 2 || result.Citations[1].URL != "https://b.example" {
+			t.Errorf("Citations = %+v", result.Citations)
+		}
+		if result.Metadata != nil || RelatedQuestions(result) != nil {
+			t.Errorf("Metadata = %v, want nil", result.Metadata)
+		}
+	})
+
 	t.Run("no usage", func(t *testing.T) {
 		resp := &perplexityResponse{
 			ID:    "resp-abc",
@@ -0,0 +1,18 @@
+package perplexity
+
+import "github.com/petal-labs/iris/core"
+
+// MetadataRelatedQuestions is the ChatResponse.Metadata key of the related
+// questions returned when WithRelatedQuestions is set. Its value is a
+// []string.
+const MetadataRelatedQuestions = "related_questions"
+
+// RelatedQuestions returns the related questions of a Perplexity response,
+// or nil if it has none.
+func RelatedQuestions(resp *core.ChatResponse) []string {
+	if resp == nil {
+		return nil
+	}
+	questions, _ := resp.Metadata[MetadataRelatedQuestions].([]string)
+	return questions
+}
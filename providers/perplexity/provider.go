@@ -5,8 +5,10 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 // DefaultAPIKeyEnvVar is the environment variable name for the Perplexity API key.
@@ -40,14 +42,24 @@ type Perplexity struct {
 // New creates a new Perplexity provider with the given API key and options.
 func New(apiKey string, opts ...Option) *Perplexity {
 	cfg := Config{
-		APIKey:     core.NewSecret(apiKey),
-		BaseURL:    DefaultBaseURL,
-		HTTPClient: http.DefaultClient,
+		APIKey:  core.NewSecret(apiKey),
+		BaseURL: DefaultBaseURL,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+	}
+	cfg.HTTPClient = httpclient.WithRewrite(cfg.HTTPClient, httpclient.Rewrite{
+		PathPrefix: cfg.PathPrefix,
+		Query:      cfg.QueryParams,
+		Editors:    cfg.RequestEditors,
+	})
+	if cfg.Credentials != nil {
+		cfg.HTTPClient = httpclient.WithCredentials(cfg.HTTPClient, cfg.Credentials, httpclient.BearerAuth)
+	}
 
 	return &Perplexity{config: cfg}
 }
@@ -57,6 +69,12 @@ func (p *Perplexity) ID() string {
 	return "perplexity"
 }
 
+// CloseIdleConnections closes the idle pooled connections of the provider's
+// HTTP client. core.Client.Close calls it during shutdown.
+func (p *Perplexity) CloseIdleConnections() {
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
 // Models returns the list of available models.
 func (p *Perplexity) Models() []core.ModelInfo {
 	// Return a copy to prevent mutation
@@ -65,6 +83,12 @@ func (p *Perplexity) Models() []core.ModelInfo {
 	return result
 }
 
+// DefaultTimeout returns the timeout set with WithTimeout. core.Client
+// applies it to requests that have no deadline or Timeout of their own.
+func (p *Perplexity) DefaultTimeout() time.Duration {
+	return p.config.Timeout
+}
+
 // Supports reports whether the provider supports the given feature.
 func (p *Perplexity) Supports(feature core.Feature) bool {
 	switch feature {
@@ -105,3 +129,9 @@ func (p *Perplexity) StreamChat(ctx context.Context, req *core.ChatRequest) (*co
 
 // Compile-time check that Perplexity implements Provider.
 var _ core.Provider = (*Perplexity)(nil)
+
+// Compile-time check that Perplexity implements TimeoutProvider.
+var _ core.TimeoutProvider = (*Perplexity)(nil)
+
+// Compile-time check that Perplexity implements IdleConnectionCloser.
+var _ core.IdleConnectionCloser = (*Perplexity)(nil)
@@ -0,0 +1,26 @@
+package perplexity
+
+import (
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers"
+)
+
+func init() {
+	providers.Register("perplexity", func(apiKey string) core.Provider {
+		return New(apiKey)
+	})
+
+	core.RegisterProvider("perplexity", func(cfg core.ProviderConfig) (core.Provider, error) {
+		var opts []Option
+		apiKey := cfg.APIKeyOrEnv(DefaultAPIKeyEnvVar)
+		if cfg.Credentials != nil {
+			opts = append(opts, WithCredentials(cfg.Credentials))
+		} else if apiKey == "" {
+			return nil, ErrAPIKeyNotFound
+		}
+		if cfg.BaseURL != "" {
+			opts = append(opts, WithBaseURL(cfg.BaseURL))
+		}
+		return New(apiKey, opts...), nil
+	})
+}
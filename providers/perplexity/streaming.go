@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
 	"github.com/petal-labs/iris/providers/internal/toolcalls"
 )
 
@@ -50,7 +51,7 @@ func (a *toolCallAssembler) finalize() ([]core.ToolCall, error) {
 // doStreamChat performs a streaming chat completion request.
 func (p *Perplexity) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
 	// Build Perplexity request with stream=true
-	pReq := buildRequest(req, true)
+	pReq := buildRequest(&p.config, req, true)
 
 	// Marshal request body
 	body, err := json.Marshal(pReq)
@@ -122,6 +123,10 @@ func (p *Perplexity) processSSEStream(
 	var responseID string
 	var responseModel string
 	var usage *perplexityUsage
+	var finishReason string
+	var citations []string
+	var searchResults []SearchResult
+	var relatedQuestions []string
 
 	for {
 		// Check for context cancellation
@@ -179,9 +184,22 @@ func (p *Perplexity) processSSEStream(
 		if chunk.Usage != nil {
 			usage = chunk.Usage
 		}
+		if len(chunk.Citations) > 0 {
+			citations = chunk.Citations
+		}
+		if len(chunk.SearchResults) > 0 {
+			searchResults = chunk.SearchResults
+		}
+		if len(chunk.RelatedQuestions) > 0 {
+			relatedQuestions = chunk.RelatedQuestions
+		}
 
 		// Process choices
 		for _, choice := range chunk.Choices {
+			if choice.FinishReason != nil && *choice.FinishReason != "" {
+				finishReason = *choice.FinishReason
+			}
+
 			// Emit content delta
 			if choice.Delta.Content != "" {
 				select {
@@ -208,9 +226,13 @@ func (p *Perplexity) processSSEStream(
 
 	// Build final response
 	finalResp := &core.ChatResponse{
-		ID:        responseID,
-		Model:     core.ModelID(responseModel),
-		ToolCalls: toolCalls,
+		ID:              responseID,
+		Model:           core.ModelID(responseModel),
+		ToolCalls:       toolCalls,
+		Citations:       mapCitations(citations, searchResults),
+		Metadata:        mapMetadata(relatedQuestions),
+		FinishReason:    normalize.FinishReason(finishReason),
+		RawFinishReason: finishReason,
 	}
 
 	if usage != nil {
@@ -251,6 +251,46 @@ func TestDoStreamChat(t *testing.T) {
 		}
 	})
 
+	t.Run("stream with citations", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+
+			chunks := []string{
+				`data: {"id":"resp-3","model":"sonar","choices":[{"index":0,"delta":{"role":"assistant","content":"Answer [1]."}}]}`,
+				`data: {"id":"resp-3","model":"sonar","choices":[{"index":0,"delta":{},"finish_reason":"stop"}],"citations":["https://go.dev"],"search_results":[{"title":"The Go Programming Language","url":"https://go.dev","snippet":"Go is..."}],"related_questions":["Who created Go?"]}`,
+				`data: [DONE]`,
+			}
+
+			for _, chunk := range chunks {
+				fmt.Fprintln(w, chunk)
+				fmt.Fprintln(w, "")
+			}
+		}))
+		defer server.Close()
+
+		p := New("test-key", WithBaseURL(server.URL), WithRelatedQuestions())
+		stream, err := p.doStreamChat(context.Background(), &core.ChatRequest{
+			Model:    "sonar",
+			Messages: []core.Message{{Role: core.RoleUser, Content: "What is Go?"}},
+		})
+		if err != nil {
+			t.Fatalf("doStreamChat() error = %v", err)
+		}
+
+		for range stream.Ch {
+		}
+		finalResp := <-stream.Final
+		if finalResp == nil {
+			t.Fatal("Final response should not be nil")
+		}
+		if len(finalResp.Citations) != 1 || finalResp.Citations[0].Title != "The Go Programming Language" {
+			t.Errorf("Citations = %+v", finalResp.Citations)
+		}
+		if got := RelatedQuestions(finalResp); len(got) != 1 || got[0] != "Who created Go?" {
+			t.Errorf("RelatedQuestions() = %v", got)
+		}
+	})
+
 	t.Run("stream with tool calls", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.Header().Set("Content-Type", "text/event-stream")
@@ -94,8 +94,9 @@ type perplexityResponse struct {
 	Usage   *perplexityUsage   `json:"usage,omitempty"`
 
 	// Perplexity-specific
-	Citations     []string       `json:"citations,omitempty"`
-	SearchResults []SearchResult `json:"search_results,omitempty"`
+	Citations        []string       `json:"citations,omitempty"`
+	SearchResults    []SearchResult `json:"search_results,omitempty"`
+	RelatedQuestions []string       `json:"related_questions,omitempty"`
 }
 
 // perplexityChoice represents a single choice in a Perplexity response.
@@ -155,8 +156,9 @@ type perplexityStreamChunk struct {
 	Usage   *perplexityUsage         `json:"usage,omitempty"`
 
 	// Perplexity-specific - may appear in final chunk
-	Citations     []string       `json:"citations,omitempty"`
-	SearchResults []SearchResult `json:"search_results,omitempty"`
+	Citations        []string       `json:"citations,omitempty"`
+	SearchResults    []SearchResult `json:"search_results,omitempty"`
+	RelatedQuestions []string       `json:"related_questions,omitempty"`
 }
 
 // perplexityStreamChoice represents a single choice in a streaming chunk.
@@ -0,0 +1,206 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// conn multiplexes requests to a plugin over a pair of streams.
+type conn struct {
+	wmu sync.Mutex
+	w   io.WriteCloser
+	enc *json.Encoder
+
+	mu       sync.Mutex
+	provider string // ID used in errors
+	nextID   uint64
+	pending  map[uint64]*inbox
+	err      error // set when the plugin stopped answering
+}
+
+func newConn(r io.Reader, w io.WriteCloser) *conn {
+	c := &conn{
+		provider: "plugin",
+		w:        w,
+		enc:      json.NewEncoder(w),
+		pending:  make(map[uint64]*inbox),
+	}
+	go c.readLoop(r)
+	return c
+}
+
+// readLoop delivers plugin messages to their requests until r fails.
+func (c *conn) readLoop(r io.Reader) {
+	dec := json.NewDecoder(r)
+	for {
+		var msg message
+		if err := dec.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				err = errors.New("plugin closed its output")
+			}
+			c.fail(err)
+			return
+		}
+		c.mu.Lock()
+		in := c.pending[msg.ID]
+		c.mu.Unlock()
+		if in != nil {
+			in.push(msg)
+		}
+	}
+}
+
+// fail ends all pending requests with err.
+func (c *conn) fail(err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return
+	}
+	c.err = &core.ProviderError{Provider: c.provider, Message: err.Error(), Err: errors.Join(core.ErrNetwork, err)}
+	for id, in := range c.pending {
+		in.push(message{ID: id, Error: toWireError(c.err)})
+	}
+}
+
+// send starts a request and returns its ID and the inbox for its replies.
+func (c *conn) send(method string, params any) (uint64, *inbox, error) {
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return 0, nil, fmt.Errorf("plugin: encode %s params: %w", method, err)
+	}
+
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return 0, nil, c.err
+	}
+	c.nextID++
+	id := c.nextID
+	in := newInbox()
+	c.pending[id] = in
+	c.mu.Unlock()
+
+	if err := c.write(message{ID: id, Method: method, Params: raw}); err != nil {
+		c.forget(id)
+		return 0, nil, err
+	}
+	return id, in, nil
+}
+
+// cancel asks the plugin to stop request id. Errors are ignored: if the
+// plugin is gone the request is over anyway.
+func (c *conn) cancel(id uint64) {
+	raw, _ := json.Marshal(cancelParams{ID: id})
+	_ = c.write(message{Method: methodCancel, Params: raw})
+}
+
+func (c *conn) write(msg message) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	if err := c.enc.Encode(msg); err != nil {
+		return &core.ProviderError{Provider: c.name(), Message: err.Error(), Err: errors.Join(core.ErrNetwork, err)}
+	}
+	return nil
+}
+
+func (c *conn) name() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.provider
+}
+
+func (c *conn) setName(name string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.provider = name
+}
+
+func (c *conn) forget(id uint64) {
+	c.mu.Lock()
+	delete(c.pending, id)
+	c.mu.Unlock()
+}
+
+// call sends a request and waits for its result, ignoring chunks.
+func (c *conn) call(ctx context.Context, method string, params, result any) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	id, in, err := c.send(method, params)
+	if err != nil {
+		return err
+	}
+	defer c.forget(id)
+
+	for {
+		msg, err := in.next(ctx)
+		if err != nil {
+			c.cancel(id)
+			return err
+		}
+		if msg.Error != nil {
+			return msg.Error.err(c.name())
+		}
+		if msg.Result != nil {
+			if err := json.Unmarshal(msg.Result, result); err != nil {
+				return &core.ProviderError{Provider: c.name(), Message: err.Error(), Err: core.ErrDecode}
+			}
+			return nil
+		}
+	}
+}
+
+func (c *conn) close() error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	return c.w.Close()
+}
+
+// inbox queues the replies to one request without limit, so a slow stream
+// consumer never blocks replies to other requests.
+type inbox struct {
+	mu     sync.Mutex
+	msgs   []message
+	notify chan struct{}
+}
+
+func newInbox() *inbox {
+	return &inbox{notify: make(chan struct{}, 1)}
+}
+
+func (in *inbox) push(msg message) {
+	in.mu.Lock()
+	in.msgs = append(in.msgs, msg)
+	in.mu.Unlock()
+	select {
+	case in.notify <- struct{}{}:
+	default:
+	}
+}
+
+// next returns the oldest queued message, waiting for one if necessary.
+func (in *inbox) next(ctx context.Context) (message, error) {
+	for {
+		in.mu.Lock()
+		if len(in.msgs) > 0 {
+			msg := in.msgs[0]
+			in.msgs = in.msgs[1:]
+			in.mu.Unlock()
+			return msg, nil
+		}
+		in.mu.Unlock()
+
+		select {
+		case <-in.notify:
+		case <-ctx.Done():
+			return message{}, ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package plugin
+
+import (
+	"io"
+	"time"
+)
+
+// Config holds configuration for starting a plugin.
+type Config struct {
+	// Args are passed to the plugin executable.
+	Args []string
+
+	// Env holds extra KEY=value environment variables for the plugin, added
+	// to the host's environment.
+	Env []string
+
+	// Stderr receives the plugin's log output. Defaults to os.Stderr.
+	Stderr io.Writer
+
+	// StartTimeout bounds how long New waits for the plugin to describe
+	// itself. Defaults to DefaultStartTimeout.
+	StartTimeout time.Duration
+}
+
+// Option configures a plugin.
+type Option func(*Config)
+
+// WithArgs sets the arguments passed to the plugin executable.
+func WithArgs(args ...string) Option {
+	return func(c *Config) {
+		c.Args = args
+	}
+}
+
+// WithEnv adds KEY=value environment variables for the plugin.
+func WithEnv(env ...string) Option {
+	return func(c *Config) {
+		c.Env = append(c.Env, env...)
+	}
+}
+
+// WithStderr sets where the plugin's log output goes.
+func WithStderr(w io.Writer) Option {
+	return func(c *Config) {
+		c.Stderr = w
+	}
+}
+
+// WithStartTimeout sets how long New waits for the plugin to start.
+func WithStartTimeout(d time.Duration) Option {
+	return func(c *Config) {
+		c.StartTimeout = d
+	}
+}
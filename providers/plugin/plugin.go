@@ -0,0 +1,234 @@
+// Package plugin runs providers out of process, so providers can be written
+// in any language or shipped separately from the application.
+//
+// A plugin is an executable that speaks a line-delimited JSON protocol on
+// stdin and stdout. New starts it and returns a core.Provider that forwards
+// Chat and StreamChat to it. Go plugins call Serve with any core.Provider:
+//
+//	// cmd/iris-acme/main.go
+//	func main() {
+//		if err := plugin.Serve(acme.New(os.Getenv("ACME_API_KEY"))); err != nil {
+//			log.Fatal(err)
+//		}
+//	}
+//
+// The host registers the executable under a provider name:
+//
+//	plugin.Register("acme", "/usr/local/bin/iris-acme")
+//	provider, model, err := core.NewProviderFromString("acme:acme-large")
+//
+// # Protocol
+//
+// Each line is a JSON object. The host sends requests
+// {"id":1,"method":"chat","params":{...}} with the methods describe, chat,
+// stream, and cancel. The plugin answers each request except cancel with
+// the same id and either "result" or "error"; stream requests first receive
+// any number of {"id":2,"chunk":{"delta":"..."}} lines. Chat params are a
+// core.ChatRequest with tools as {"name","description","parameters"}
+// objects; results are core.ChatResponse. Errors are
+// {"kind","provider","status","code","message"} where kind names the core
+// sentinel error, such as "unauthorized" or "rate_limited". describe
+// returns {"protocol":1,"id":"acme","models":[...],"features":[...]}.
+// Plugins must write logs to stderr, never stdout.
+//
+// Multimodal message parts and output parts are not carried over the
+// protocol.
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// DefaultStartTimeout bounds how long New waits for a plugin to describe
+// itself.
+const DefaultStartTimeout = 10 * time.Second
+
+// closeTimeout bounds how long Close waits for the plugin to exit.
+const closeTimeout = 5 * time.Second
+
+// Provider is a core.Provider served by a plugin process. Provider is safe
+// for concurrent use; requests are multiplexed over one process.
+type Provider struct {
+	conn     *conn
+	id       string
+	models   []core.ModelInfo
+	features map[core.Feature]bool
+
+	cmd       *exec.Cmd
+	closeOnce sync.Once
+	closeErr  error
+}
+
+// New starts the plugin at path and returns a provider backed by it. Call
+// Close to stop the process.
+func New(path string, opts ...Option) (*Provider, error) {
+	cfg := Config{StartTimeout: DefaultStartTimeout, Stderr: os.Stderr}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	cmd := exec.Command(path, cfg.Args...)
+	cmd.Env = append(os.Environ(), cfg.Env...)
+	cmd.Stderr = cfg.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("plugin: start %s: %w", path, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.StartTimeout)
+	defer cancel()
+	p, err := newProvider(ctx, stdout, stdin)
+	if err != nil {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, fmt.Errorf("plugin: %s: %w", path, err)
+	}
+	p.cmd = cmd
+	return p, nil
+}
+
+// NewConn returns a provider that talks to a plugin over r and w, such as
+// a network connection. Closing the provider closes w.
+func NewConn(ctx context.Context, r io.Reader, w io.WriteCloser) (*Provider, error) {
+	return newProvider(ctx, r, w)
+}
+
+func newProvider(ctx context.Context, r io.Reader, w io.WriteCloser) (*Provider, error) {
+	c := newConn(r, w)
+
+	var desc describeResult
+	if err := c.call(ctx, methodDescribe, struct{}{}, &desc); err != nil {
+		c.close()
+		return nil, fmt.Errorf("describe: %w", err)
+	}
+	if desc.Protocol != ProtocolVersion {
+		c.close()
+		return nil, fmt.Errorf("protocol version %d, want %d", desc.Protocol, ProtocolVersion)
+	}
+	if desc.ID == "" {
+		c.close()
+		return nil, errors.New("describe returned no provider ID")
+	}
+	c.setName(desc.ID)
+
+	features := make(map[core.Feature]bool, len(desc.Features))
+	for _, f := range desc.Features {
+		features[f] = true
+	}
+	return &Provider{conn: c, id: desc.ID, models: desc.Models, features: features}, nil
+}
+
+// ID returns the ID reported by the plugin.
+func (p *Provider) ID() string { return p.id }
+
+// Models returns the models reported by the plugin.
+func (p *Provider) Models() []core.ModelInfo { return p.models }
+
+// Supports reports whether the plugin reported the feature.
+func (p *Provider) Supports(feature core.Feature) bool { return p.features[feature] }
+
+// Chat sends a chat request to the plugin.
+func (p *Provider) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	var resp core.ChatResponse
+	if err := p.conn.call(ctx, methodChat, newChatParams(req), &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// StreamChat sends a streaming chat request to the plugin.
+func (p *Provider) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	id, in, err := p.conn.send(methodStream, newChatParams(req))
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan core.ChatChunk)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+
+	go func() {
+		defer close(ch)
+		defer close(errCh)
+		defer close(finalCh)
+		defer p.conn.forget(id)
+
+		for {
+			msg, err := in.next(ctx)
+			if err != nil {
+				p.conn.cancel(id)
+				errCh <- err
+				return
+			}
+			switch {
+			case msg.Error != nil:
+				errCh <- msg.Error.err(p.id)
+				return
+			case msg.Chunk != nil:
+				select {
+				case ch <- *msg.Chunk:
+				case <-ctx.Done():
+					p.conn.cancel(id)
+					errCh <- ctx.Err()
+					return
+				}
+			case msg.Result != nil:
+				var resp core.ChatResponse
+				if err := json.Unmarshal(msg.Result, &resp); err != nil {
+					errCh <- &core.ProviderError{Provider: p.id, Message: err.Error(), Err: core.ErrDecode}
+					return
+				}
+				finalCh <- &resp
+				return
+			}
+		}
+	}()
+
+	return &core.ChatStream{Ch: ch, Err: errCh, Final: finalCh}, nil
+}
+
+// Close stops the plugin. It closes the plugin's stdin and waits for the
+// process to exit, killing it if it does not exit within five seconds.
+func (p *Provider) Close() error {
+	p.closeOnce.Do(func() {
+		p.closeErr = p.conn.close()
+		if p.cmd == nil {
+			return
+		}
+		exited := make(chan error, 1)
+		go func() { exited <- p.cmd.Wait() }()
+		select {
+		case err := <-exited:
+			if p.closeErr == nil {
+				p.closeErr = err
+			}
+		case <-time.After(closeTimeout):
+			_ = p.cmd.Process.Kill()
+			<-exited
+		}
+	})
+	return p.closeErr
+}
+
+// Compile-time check that Provider implements core.Provider.
+var _ core.Provider = (*Provider)(nil)
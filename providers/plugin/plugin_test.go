@@ -0,0 +1,341 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/testing/providertest"
+	"github.com/petal-labs/iris/tools"
+)
+
+// serveEnvVar makes the test binary act as a plugin.
+const serveEnvVar = "IRIS_PLUGIN_TEST_SERVE"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(serveEnvVar) == "1" {
+		if err := Serve(&fakeProvider{apiKey: "valid"}); err != nil {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// fakeProvider answers deterministically. It echoes the tools it received
+// and the plugin API key environment variable in its output.
+type fakeProvider struct {
+	apiKey string
+
+	lastTools []core.Tool
+}
+
+func (p *fakeProvider) ID() string { return "fake" }
+
+func (p *fakeProvider) Models() []core.ModelInfo {
+	return []core.ModelInfo{{ID: "fake-model", DisplayName: "Fake"}}
+}
+
+func (p *fakeProvider) Supports(f core.Feature) bool {
+	switch f {
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureEmbeddings:
+		return true
+	}
+	return false
+}
+
+func (p *fakeProvider) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	if err := p.check(ctx); err != nil {
+		return nil, err
+	}
+	p.lastTools = req.Tools
+	resp := &core.ChatResponse{
+		ID:    "resp-1",
+		Model: req.Model,
+		Usage: core.TokenUsage{PromptTokens: 10, CompletionTokens: 2, TotalTokens: 12},
+	}
+	last := req.Messages[len(req.Messages)-1]
+	switch {
+	case len(req.Tools) > 0 && last.Role == core.RoleUser:
+		resp.ToolCalls = []core.ToolCall{{ID: "call-1", Name: req.Tools[0].Name(), Arguments: json.RawMessage(`{"location":"Paris"}`)}}
+	case last.Content == "env":
+		resp.Output = os.Getenv(APIKeyEnvVar)
+	default:
+		resp.Output = "hello"
+	}
+	return resp, nil
+}
+
+func (p *fakeProvider) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	if err := p.check(ctx); err != nil {
+		return nil, err
+	}
+	ch := make(chan core.ChatChunk)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+
+	go func() {
+		defer close(ch)
+		defer close(errCh)
+		defer close(finalCh)
+
+		n := 5
+		if strings.Contains(req.Messages[0].Content, "300") {
+			n = 300
+		}
+		var out strings.Builder
+		for range n {
+			select {
+			case ch <- core.ChatChunk{Delta: "x\n"}:
+				out.WriteString("x\n")
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+		finalCh <- &core.ChatResponse{Output: out.String(), Usage: core.TokenUsage{PromptTokens: 10, CompletionTokens: n, TotalTokens: 10 + n}}
+	}()
+
+	return &core.ChatStream{Ch: ch, Err: errCh, Final: finalCh}, nil
+}
+
+func (p *fakeProvider) check(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if p.apiKey != "valid" {
+		return &core.ProviderError{Provider: p.ID(), Status: 401, Code: "invalid_api_key", Message: "invalid key", Err: core.ErrUnauthorized}
+	}
+	return nil
+}
+
+// pipeProvider serves fake in-process and returns a provider connected to it.
+func pipeProvider(t *testing.T, fake core.Provider) *Provider {
+	t.Helper()
+	hostR, pluginW := io.Pipe()
+	pluginR, hostW := io.Pipe()
+
+	served := make(chan error, 1)
+	go func() {
+		served <- ServeConn(context.Background(), fake, pluginR, pluginW)
+		pluginW.Close()
+	}()
+
+	p, err := NewConn(context.Background(), hostR, hostW)
+	if err != nil {
+		t.Fatalf("NewConn() error = %v", err)
+	}
+	t.Cleanup(func() {
+		p.Close()
+		if err := <-served; err != nil {
+			t.Errorf("ServeConn() error = %v", err)
+		}
+	})
+	return p
+}
+
+func TestConformance(t *testing.T) {
+	providertest.Run(t, providertest.Config{
+		NewProvider: func(t *testing.T) core.Provider {
+			return pipeProvider(t, &fakeProvider{apiKey: "valid"})
+		},
+		NewUnauthorizedProvider: func(t *testing.T) core.Provider {
+			return pipeProvider(t, &fakeProvider{apiKey: "invalid"})
+		},
+		Model:   "fake-model",
+		Timeout: 5 * time.Second,
+	})
+}
+
+func TestDescribe(t *testing.T) {
+	p := pipeProvider(t, &fakeProvider{apiKey: "valid"})
+
+	if p.ID() != "fake" {
+		t.Errorf("ID() = %q, want fake", p.ID())
+	}
+	if len(p.Models()) != 1 || p.Models()[0].ID != "fake-model" {
+		t.Errorf("Models() = %+v", p.Models())
+	}
+	if !p.Supports(core.FeatureToolCalling) {
+		t.Error("Supports(FeatureToolCalling) = false")
+	}
+	// Only chat features are proxied
+	if p.Supports(core.FeatureEmbeddings) {
+		t.Error("Supports(FeatureEmbeddings) = true")
+	}
+}
+
+type weatherTool struct{}
+
+func (weatherTool) Name() string        { return "get_weather" }
+func (weatherTool) Description() string { return "Get the weather." }
+func (weatherTool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: json.RawMessage(`{"type":"object","properties":{"location":{"type":"string"}}}`)}
+}
+func (weatherTool) Call(context.Context, json.RawMessage) (any, error) { return nil, nil }
+
+func TestChatForwardsTools(t *testing.T) {
+	fake := &fakeProvider{apiKey: "valid"}
+	p := pipeProvider(t, fake)
+
+	_, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:    "fake-model",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "weather?"}},
+		Tools:    []core.Tool{weatherTool{}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	if len(fake.lastTools) != 1 {
+		t.Fatalf("plugin received %d tools, want 1", len(fake.lastTools))
+	}
+	tool, ok := fake.lastTools[0].(tools.Tool)
+	if !ok {
+		t.Fatalf("plugin tool %T does not implement tools.Tool", fake.lastTools[0])
+	}
+	if tool.Name() != "get_weather" || tool.Description() != "Get the weather." {
+		t.Errorf("tool = %q %q", tool.Name(), tool.Description())
+	}
+	if got := string(tool.Schema().JSONSchema); got != string(weatherTool{}.Schema().JSONSchema) {
+		t.Errorf("Schema = %s", got)
+	}
+}
+
+func TestErrorMapping(t *testing.T) {
+	p := pipeProvider(t, &fakeProvider{apiKey: "invalid"})
+
+	_, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:    "fake-model",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "hi"}},
+	})
+	var pe *core.ProviderError
+	if !errors.As(err, &pe) {
+		t.Fatalf("error = %T %v, want *core.ProviderError", err, err)
+	}
+	if pe.Status != 401 || pe.Code != "invalid_api_key" || pe.Provider != "fake" {
+		t.Errorf("ProviderError = %+v", pe)
+	}
+	if !errors.Is(err, core.ErrUnauthorized) {
+		t.Error("error should wrap core.ErrUnauthorized")
+	}
+}
+
+func TestWireErrorKinds(t *testing.T) {
+	for _, k := range errorKinds {
+		we := toWireError(&core.ProviderError{Provider: "x", Message: "m", Err: k.err})
+		if we.Kind != k.kind {
+			t.Errorf("kind of %v = %q, want %q", k.err, we.Kind, k.kind)
+		}
+		if err := we.err("x"); !errors.Is(err, k.err) {
+			t.Errorf("round trip of %q = %v, want %v", k.kind, err, k.err)
+		}
+	}
+
+	if we := toWireError(errors.New("boom")); we.Kind != "" || we.Message != "boom" {
+		t.Errorf("plain error = %+v", we)
+	}
+}
+
+func TestPluginExit(t *testing.T) {
+	hostR, pluginW := io.Pipe()
+	pluginR, hostW := io.Pipe()
+
+	// A plugin that describes itself and then exits
+	go func() {
+		dec := json.NewDecoder(pluginR)
+		var msg message
+		dec.Decode(&msg)
+		raw, _ := json.Marshal(describeResult{Protocol: ProtocolVersion, ID: "dying"})
+		json.NewEncoder(pluginW).Encode(message{ID: msg.ID, Result: raw})
+		dec.Decode(&msg)
+		pluginW.Close()
+	}()
+
+	p, err := NewConn(context.Background(), hostR, hostW)
+	if err != nil {
+		t.Fatalf("NewConn() error = %v", err)
+	}
+	defer p.Close()
+
+	_, err = p.Chat(context.Background(), &core.ChatRequest{Model: "m", Messages: []core.Message{{Role: core.RoleUser, Content: "hi"}}})
+	if !errors.Is(err, core.ErrNetwork) {
+		t.Errorf("Chat() error = %v, want one wrapping core.ErrNetwork", err)
+	}
+
+	// Later requests fail immediately
+	_, err = p.Chat(context.Background(), &core.ChatRequest{Model: "m", Messages: []core.Message{{Role: core.RoleUser, Content: "hi"}}})
+	if !errors.Is(err, core.ErrNetwork) {
+		t.Errorf("Chat() error = %v, want one wrapping core.ErrNetwork", err)
+	}
+}
+
+func TestProtocolVersionMismatch(t *testing.T) {
+	hostR, pluginW := io.Pipe()
+	pluginR, hostW := io.Pipe()
+	go func() {
+		var msg message
+		json.NewDecoder(pluginR).Decode(&msg)
+		raw, _ := json.Marshal(describeResult{Protocol: ProtocolVersion + 1, ID: "future"})
+		json.NewEncoder(pluginW).Encode(message{ID: msg.ID, Result: raw})
+		io.Copy(io.Discard, pluginR)
+	}()
+
+	if _, err := NewConn(context.Background(), hostR, hostW); err == nil {
+		t.Error("NewConn() should reject a different protocol version")
+	}
+}
+
+func TestNewProcess(t *testing.T) {
+	p, err := New(os.Args[0], WithEnv(serveEnvVar+"=1"))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:    "fake-model",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "hi"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Output != "hello" {
+		t.Errorf("Output = %q, want hello", resp.Output)
+	}
+
+	if err := p.Close(); err != nil {
+		t.Errorf("Close() error = %v", err)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register("plugin-test", os.Args[0], WithEnv(serveEnvVar+"=1"))
+
+	provider, err := core.NewProviderFromConfig(core.ProviderConfig{
+		Name:   "plugin-test",
+		APIKey: core.NewSecret("sk-plugin"),
+		Model:  "fake-model",
+	})
+	if err != nil {
+		t.Fatalf("NewProviderFromConfig() error = %v", err)
+	}
+	defer provider.(*Provider).Close()
+
+	resp, err := provider.Chat(context.Background(), &core.ChatRequest{
+		Model:    "fake-model",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "env"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Output != "sk-plugin" {
+		t.Errorf("plugin saw API key %q, want sk-plugin", resp.Output)
+	}
+}
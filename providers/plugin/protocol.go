@@ -0,0 +1,193 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/tools"
+)
+
+// ProtocolVersion is the version of the wire protocol. A plugin reports it
+// in its describe result and New rejects plugins with a different version.
+const ProtocolVersion = 1
+
+// Methods sent by the host.
+const (
+	methodDescribe = "describe"
+	methodChat     = "chat"
+	methodStream   = "stream"
+	methodCancel   = "cancel"
+)
+
+// message is one line of the protocol. The host sends requests with Method
+// and Params; the plugin answers with the same ID and either Result or
+// Error. Stream requests receive any number of Chunk messages before the
+// Result.
+type message struct {
+	ID     uint64          `json:"id"`
+	Method string          `json:"method,omitempty"`
+	Params json.RawMessage `json:"params,omitempty"`
+	Chunk  *core.ChatChunk `json:"chunk,omitempty"`
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  *wireError      `json:"error,omitempty"`
+}
+
+// describeResult answers the describe method.
+type describeResult struct {
+	Protocol int              `json:"protocol"`
+	ID       string           `json:"id"`
+	Models   []core.ModelInfo `json:"models,omitempty"`
+	Features []core.Feature   `json:"features,omitempty"`
+}
+
+// describedFeatures are the features a plugin reports. Only chat is proxied,
+// so other features are left out.
+var describedFeatures = []core.Feature{
+	core.FeatureChat,
+	core.FeatureChatStreaming,
+	core.FeatureToolCalling,
+	core.FeatureReasoning,
+	core.FeatureStructuredOutput,
+	core.FeatureVision,
+	core.FeatureBuiltInTools,
+}
+
+// cancelParams names the request to cancel.
+type cancelParams struct {
+	ID uint64 `json:"id"`
+}
+
+// chatParams carries a ChatRequest together with the fields it does not
+// serialize itself.
+type chatParams struct {
+	*core.ChatRequest
+	Tools          []toolSpec `json:"tools,omitempty"`
+	IdempotencyKey string     `json:"idempotency_key,omitempty"`
+}
+
+// toolSpec describes a tool offered to the model.
+type toolSpec struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+}
+
+// remoteTool presents a toolSpec received by a plugin as a tools.Tool, so
+// the provider finds its schema like it would for a local tool.
+type remoteTool struct {
+	spec toolSpec
+}
+
+func (t remoteTool) Name() string        { return t.spec.Name }
+func (t remoteTool) Description() string { return t.spec.Description }
+
+func (t remoteTool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: t.spec.Parameters}
+}
+
+// Call is never used: tools run on the host, not in the plugin.
+func (t remoteTool) Call(context.Context, json.RawMessage) (any, error) {
+	return nil, core.ErrNotSupported
+}
+
+// Compile-time check that remoteTool implements tools.Tool.
+var _ tools.Tool = remoteTool{}
+
+// schemaProvider is implemented by tools.Tool.
+type schemaProvider interface {
+	Schema() tools.ToolSchema
+}
+
+func newChatParams(req *core.ChatRequest) chatParams {
+	params := chatParams{ChatRequest: req, IdempotencyKey: req.IdempotencyKey}
+	for _, t := range req.Tools {
+		spec := toolSpec{Name: t.Name(), Description: t.Description()}
+		if sp, ok := t.(schemaProvider); ok {
+			spec.Parameters = sp.Schema().JSONSchema
+		}
+		params.Tools = append(params.Tools, spec)
+	}
+	return params
+}
+
+func (p chatParams) request() *core.ChatRequest {
+	req := p.ChatRequest
+	if req == nil {
+		req = &core.ChatRequest{}
+	}
+	req.IdempotencyKey = p.IdempotencyKey
+	for _, t := range p.Tools {
+		req.Tools = append(req.Tools, remoteTool{spec: t})
+	}
+	return req
+}
+
+// wireError is a ProviderError on the wire. Kind names the sentinel error.
+type wireError struct {
+	Kind      string `json:"kind,omitempty"`
+	Provider  string `json:"provider,omitempty"`
+	Status    int    `json:"status,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+	Code      string `json:"code,omitempty"`
+	Message   string `json:"message"`
+}
+
+// errorKinds maps wire error kinds to sentinel errors, most specific first.
+var errorKinds = []struct {
+	kind string
+	err  error
+}{
+	{"canceled", context.Canceled},
+	{"deadline_exceeded", context.DeadlineExceeded},
+	{"unauthorized", core.ErrUnauthorized},
+	{"rate_limited", core.ErrRateLimited},
+	{"bad_request", core.ErrBadRequest},
+	{"not_found", core.ErrNotFound},
+	{"server", core.ErrServer},
+	{"network", core.ErrNetwork},
+	{"decode", core.ErrDecode},
+	{"not_supported", core.ErrNotSupported},
+}
+
+func toWireError(err error) *wireError {
+	we := &wireError{Message: err.Error()}
+	var pe *core.ProviderError
+	if errors.As(err, &pe) {
+		we.Provider = pe.Provider
+		we.Status = pe.Status
+		we.RequestID = pe.RequestID
+		we.Code = pe.Code
+		we.Message = pe.Message
+	}
+	for _, k := range errorKinds {
+		if errors.Is(err, k.err) {
+			we.Kind = k.kind
+			break
+		}
+	}
+	return we
+}
+
+// err converts the wire error back to a ProviderError. provider is used if
+// the plugin did not name one.
+func (e *wireError) err(provider string) error {
+	pe := &core.ProviderError{
+		Provider:  e.Provider,
+		Status:    e.Status,
+		RequestID: e.RequestID,
+		Code:      e.Code,
+		Message:   e.Message,
+	}
+	if pe.Provider == "" {
+		pe.Provider = provider
+	}
+	for _, k := range errorKinds {
+		if e.Kind == k.kind {
+			pe.Err = k.err
+			break
+		}
+	}
+	return pe
+}
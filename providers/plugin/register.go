@@ -0,0 +1,44 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// Environment variables a registered plugin receives.
+const (
+	APIKeyEnvVar  = "IRIS_PLUGIN_API_KEY"
+	BaseURLEnvVar = "IRIS_PLUGIN_BASE_URL"
+)
+
+// Register makes the plugin at path constructible by name through
+// core.NewProviderFromConfig and core.NewProviderFromString. Each
+// construction starts a new plugin process; the configured API key and base
+// URL are passed to it in APIKeyEnvVar and BaseURLEnvVar. Configured
+// credentials are resolved once, when the process starts.
+func Register(name, path string, opts ...Option) {
+	core.RegisterProvider(name, func(cfg core.ProviderConfig) (core.Provider, error) {
+		if cfg.Credentials != nil {
+			key, err := cfg.Credentials.Credential(context.Background())
+			if err != nil {
+				return nil, fmt.Errorf("plugin: %w", err)
+			}
+			cfg.APIKey = key
+		}
+		return New(path, append(slices.Clip(opts), withProviderConfig(cfg))...)
+	})
+}
+
+func withProviderConfig(cfg core.ProviderConfig) Option {
+	return func(c *Config) {
+		if key := cfg.APIKey.Expose(); key != "" {
+			c.Env = append(c.Env, APIKeyEnvVar+"="+key)
+		}
+		if cfg.BaseURL != "" {
+			c.Env = append(c.Env, BaseURLEnvVar+"="+cfg.BaseURL)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// Serve runs p as a plugin on stdin and stdout until the host closes stdin.
+func Serve(p core.Provider) error {
+	return ServeConn(context.Background(), p, os.Stdin, os.Stdout)
+}
+
+// ServeConn runs p as a plugin, reading requests from r and writing replies
+// to w, until r reaches EOF or ctx is cancelled. Requests run concurrently;
+// in-flight requests are cancelled and awaited before ServeConn returns.
+func ServeConn(ctx context.Context, p core.Provider, r io.Reader, w io.Writer) error {
+	ctx, cancelAll := context.WithCancel(ctx)
+	defer cancelAll()
+
+	s := &server{provider: p, enc: json.NewEncoder(w), running: make(map[uint64]context.CancelFunc)}
+	defer s.wg.Wait()
+
+	msgs := make(chan message)
+	readErr := make(chan error, 1)
+	go func() {
+		dec := json.NewDecoder(r)
+		for {
+			var msg message
+			if err := dec.Decode(&msg); err != nil {
+				readErr <- err
+				return
+			}
+			select {
+			case msgs <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-readErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case msg := <-msgs:
+			s.handle(ctx, msg)
+		}
+	}
+}
+
+// server answers requests for one connection.
+type server struct {
+	provider core.Provider
+
+	wmu sync.Mutex
+	enc *json.Encoder
+
+	mu      sync.Mutex
+	running map[uint64]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func (s *server) handle(ctx context.Context, msg message) {
+	switch msg.Method {
+	case methodDescribe:
+		s.reply(msg.ID, s.describe(), nil)
+
+	case methodCancel:
+		var params cancelParams
+		if json.Unmarshal(msg.Params, &params) == nil {
+			s.mu.Lock()
+			if cancel := s.running[params.ID]; cancel != nil {
+				cancel()
+			}
+			s.mu.Unlock()
+		}
+
+	case methodChat, methodStream:
+		var params chatParams
+		if err := json.Unmarshal(msg.Params, &params); err != nil {
+			s.reply(msg.ID, nil, &core.ProviderError{Provider: s.provider.ID(), Message: err.Error(), Err: core.ErrBadRequest})
+			return
+		}
+		reqCtx, cancel := context.WithCancel(ctx)
+		s.mu.Lock()
+		s.running[msg.ID] = cancel
+		s.mu.Unlock()
+
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer func() {
+				s.mu.Lock()
+				delete(s.running, msg.ID)
+				s.mu.Unlock()
+				cancel()
+			}()
+			if msg.Method == methodChat {
+				resp, err := s.provider.Chat(reqCtx, params.request())
+				s.reply(msg.ID, resp, err)
+			} else {
+				s.stream(reqCtx, msg.ID, params.request())
+			}
+		}()
+
+	default:
+		s.reply(msg.ID, nil, &core.ProviderError{Provider: s.provider.ID(), Message: "unknown method " + msg.Method, Err: core.ErrNotSupported})
+	}
+}
+
+func (s *server) describe() describeResult {
+	desc := describeResult{Protocol: ProtocolVersion, ID: s.provider.ID(), Models: s.provider.Models()}
+	for _, f := range describedFeatures {
+		if s.provider.Supports(f) {
+			desc.Features = append(desc.Features, f)
+		}
+	}
+	return desc
+}
+
+// stream relays a provider stream as chunk messages followed by the final
+// response or error.
+func (s *server) stream(ctx context.Context, id uint64, req *core.ChatRequest) {
+	stream, err := s.provider.StreamChat(ctx, req)
+	if err != nil {
+		s.reply(id, nil, err)
+		return
+	}
+
+	var streamErr error
+	var final *core.ChatResponse
+	ch, errCh, finalCh := stream.Ch, stream.Err, stream.Final
+	for ch != nil || errCh != nil || (finalCh != nil && final == nil && streamErr == nil) {
+		select {
+		case chunk, ok := <-ch:
+			if !ok {
+				ch = nil
+				continue
+			}
+			s.write(message{ID: id, Chunk: &chunk})
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+			} else if err != nil && streamErr == nil {
+				streamErr = err
+			}
+		case resp, ok := <-finalCh:
+			if !ok {
+				finalCh = nil
+			} else {
+				final = resp
+			}
+		}
+	}
+
+	switch {
+	case streamErr != nil:
+		s.reply(id, nil, streamErr)
+	case final == nil:
+		s.reply(id, &core.ChatResponse{}, nil)
+	default:
+		s.reply(id, final, nil)
+	}
+}
+
+// reply sends result, or err if it is not nil.
+func (s *server) reply(id uint64, result any, err error) {
+	if err != nil {
+		s.write(message{ID: id, Error: toWireError(err)})
+		return
+	}
+	raw, err := json.Marshal(result)
+	if err != nil {
+		s.write(message{ID: id, Error: toWireError(err)})
+		return
+	}
+	s.write(message{ID: id, Result: raw})
+}
+
+// write sends msg. Write errors mean the host is gone; the read loop ends
+// the connection when its input closes.
+func (s *server) write(msg message) {
+	s.wmu.Lock()
+	defer s.wmu.Unlock()
+	_ = s.enc.Encode(msg)
+}
@@ -0,0 +1,28 @@
+package replicate
+
+import (
+	"context"
+	"maps"
+)
+
+type inputKey struct{}
+
+// ContextWithInput returns a context that adds model inputs to the
+// predictions started by GenerateImage, EditImage, and StreamImage with it,
+// for inputs the core requests have no field for, such as "seed" or
+// "guidance". They override the inputs mapped from the request. Inputs
+// already on ctx are kept unless input replaces them.
+func ContextWithInput(ctx context.Context, input map[string]any) context.Context {
+	merged := maps.Clone(inputFromContext(ctx))
+	if merged == nil {
+		merged = make(map[string]any, len(input))
+	}
+	maps.Copy(merged, input)
+	return context.WithValue(ctx, inputKey{}, merged)
+}
+
+// inputFromContext returns the inputs set on ctx, or nil.
+func inputFromContext(ctx context.Context) map[string]any {
+	input, _ := ctx.Value(inputKey{}).(map[string]any)
+	return input
+}
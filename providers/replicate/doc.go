@@ -0,0 +1,62 @@
+// Package replicate provides a provider for models hosted on Replicate,
+// primarily the image, video, and audio models in its catalog.
+//
+// # Images
+//
+// Replicate implements core.ImageGenerator, so text-to-image and image
+// editing models work through the core image API:
+//
+//	provider, err := replicate.NewFromEnv()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	resp, err := provider.GenerateImage(ctx, &core.ImageGenerateRequest{
+//	    Model:  "black-forest-labs/flux-schnell",
+//	    Prompt: "a lighthouse at dusk, oil painting",
+//	    Size:   core.ImageSize1536x1024,
+//	})
+//
+// Requests are mapped to the input names most image models use.
+// ContextWithInput sets model-specific inputs:
+//
+//	ctx = replicate.ContextWithInput(ctx, map[string]any{"seed": 42, "go_fast": true})
+//
+// # Videos
+//
+// Replicate also implements core.VideoGenerator for text-to-video and
+// image-to-video models:
+//
+//	resp, err := provider.GenerateVideo(ctx, &core.VideoGenerateRequest{
+//	    Model:    "minimax/video-01",
+//	    Prompt:   "a paper boat drifting down a stream",
+//	    Duration: 6 * time.Second,
+//	})
+//
+// # Predictions
+//
+// Any model, including audio models, can be run with the prediction API.
+// Run starts a prediction and waits for it to finish; Files returns the
+// URLs of its file outputs, and DownloadFile fetches them:
+//
+//	pred, err := provider.Run(ctx, &replicate.PredictionRequest{
+//	    Model: "meta/musicgen",
+//	    Input: map[string]any{"prompt": "lo-fi beat with soft piano"},
+//	})
+//	audio, err := provider.DownloadFile(ctx, pred.Files()[0])
+//
+// CreatePrediction starts a prediction without waiting longer than
+// Config.Wait, and GetPrediction, Wait, and CancelPrediction manage it
+// afterwards.
+//
+// # Webhooks
+//
+// Long-running predictions can report to a webhook instead of being polled.
+// Set PredictionRequest.Webhook, and check deliveries with VerifyWebhook
+// using the secret from WebhookSecret.
+//
+// # Registry
+//
+// Importing the package registers the "replicate" provider, which reads its
+// API token from ProviderConfig or the REPLICATE_API_TOKEN environment
+// variable.
+package replicate
@@ -0,0 +1,50 @@
+package replicate
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
+)
+
+// ErrPredictionFailed is returned by Run and the image methods when a
+// prediction fails or is canceled.
+var ErrPredictionFailed = errors.New("replicate: prediction failed")
+
+// replicateErrorResponse represents a problem details error from the Replicate API.
+type replicateErrorResponse struct {
+	Title  string `json:"title"`
+	Detail string `json:"detail"`
+}
+
+// normalizeError converts an HTTP error response to a ProviderError with the appropriate sentinel.
+func normalizeError(status int, body []byte, header http.Header) error {
+	var errResp replicateErrorResponse
+	_ = json.Unmarshal(body, &errResp)
+
+	message := errResp.Detail
+	if message == "" {
+		message = errResp.Title
+	}
+
+	err := normalize.ProviderError("replicate", status, header.Get("x-request-id"), "", message, normalize.SentinelForStatus(status))
+	if status == http.StatusTooManyRequests {
+		var pe *core.ProviderError
+		if errors.As(err, &pe) {
+			pe.RetryAfter = normalize.RetryAfter(header)
+		}
+	}
+	return err
+}
+
+// newNetworkError creates a ProviderError for network-related failures.
+func newNetworkError(err error) error {
+	return normalize.NetworkError("replicate", err)
+}
+
+// newDecodeError creates a ProviderError for JSON decode failures.
+func newDecodeError(err error) error {
+	return normalize.DecodeError("replicate", err)
+}
@@ -0,0 +1,66 @@
+package replicate
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DownloadFile returns the contents of a file output, such as a URL from
+// Prediction.Files. The API token is only sent to the API host, since
+// output files are usually served from a separate delivery domain.
+func (p *Replicate) DownloadFile(ctx context.Context, fileURL string) ([]byte, error) {
+	if strings.HasPrefix(fileURL, "data:") {
+		return decodeDataURI(fileURL)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, fileURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if sameHost(fileURL, p.config.BaseURL) {
+		httpReq.Header = p.buildHeaders()
+		httpReq.Header.Del("Content-Type")
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, data, resp.Header)
+	}
+	return data, nil
+}
+
+// sameHost reports whether two URLs have the same host.
+func sameHost(a, b string) bool {
+	ua, errA := url.Parse(a)
+	ub, errB := url.Parse(b)
+	return errA == nil && errB == nil && ua.Host == ub.Host
+}
+
+// dataURI encodes data as a data URI, the form Replicate accepts for file
+// inputs sent inline.
+func dataURI(data []byte) string {
+	return "data:" + http.DetectContentType(data) + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// decodeDataURI returns the contents of a base64 data URI.
+func decodeDataURI(uri string) ([]byte, error) {
+	meta, payload, ok := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !ok || !strings.HasSuffix(meta, ";base64") {
+		return nil, fmt.Errorf("replicate: unsupported data URI %.40q", uri)
+	}
+	return base64.StdEncoding.DecodeString(payload)
+}
@@ -0,0 +1,164 @@
+package replicate
+
+import (
+	"context"
+	"encoding/base64"
+	"maps"
+	"strings"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// GenerateImage runs a text-to-image model such as
+// black-forest-labs/flux-schnell. The request is mapped to the input names
+// most Replicate image models use: prompt, num_outputs, aspect_ratio,
+// output_format, and output_quality; ContextWithInput sets others. Images
+// are returned as URLs, or downloaded and base64-encoded when
+// ResponseFormat is "b64_json".
+func (p *Replicate) GenerateImage(ctx context.Context, req *core.ImageGenerateRequest) (*core.ImageResponse, error) {
+	input := map[string]any{"prompt": req.Prompt}
+	if req.N > 1 {
+		input["num_outputs"] = req.N
+	}
+	if ratio := aspectRatio(req.Size); ratio != "" {
+		input["aspect_ratio"] = ratio
+	}
+	addOutputInputs(input, req.Format, req.Compression)
+	return p.runImage(ctx, req.Model, input, req.ResponseFormat == "b64_json")
+}
+
+// EditImage runs an image editing or inpainting model. The first image is
+// sent as the "image" input and the mask as "mask", inline unless given as
+// a URL. Images are returned as URLs.
+func (p *Replicate) EditImage(ctx context.Context, req *core.ImageEditRequest) (*core.ImageResponse, error) {
+	if len(req.Images) == 0 {
+		return nil, &core.ProviderError{
+			Provider: "replicate",
+			Message:  "at least one image is required",
+			Err:      core.ErrBadRequest,
+		}
+	}
+
+	input := map[string]any{"prompt": req.Prompt}
+	image, err := fileInput(req.Images[0])
+	if err != nil {
+		return nil, err
+	}
+	input["image"] = image
+	if req.Mask != nil {
+		mask, err := fileInput(*req.Mask)
+		if err != nil {
+			return nil, err
+		}
+		input["mask"] = mask
+	}
+	if req.N > 1 {
+		input["num_outputs"] = req.N
+	}
+	addOutputInputs(input, req.Format, req.Compression)
+	return p.runImage(ctx, req.Model, input, false)
+}
+
+// StreamImage generates images and delivers them as an ImageStream with no
+// partial images, since Replicate does not stream image generation.
+func (p *Replicate) StreamImage(ctx context.Context, req *core.ImageGenerateRequest) (*core.ImageStream, error) {
+	return core.ImageStreamFromFunc(ctx, func(ctx context.Context) (*core.ImageResponse, error) {
+		return p.GenerateImage(ctx, req)
+	}), nil
+}
+
+// runImage runs model with input and the inputs from ctx, and returns its
+// file outputs as images.
+func (p *Replicate) runImage(ctx context.Context, model core.ModelID, input map[string]any, inline bool) (*core.ImageResponse, error) {
+	maps.Copy(input, inputFromContext(ctx))
+	pred, err := p.Run(ctx, &PredictionRequest{Model: string(model), Input: input})
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := p.fileOutputs(ctx, pred, inline)
+	if err != nil {
+		return nil, err
+	}
+	resp := &core.ImageResponse{
+		Created: pred.CreatedAt.Unix(),
+		Data:    make([]core.ImageData, len(files)),
+	}
+	for i, file := range files {
+		resp.Data[i] = core.ImageData{URL: file.URL, B64JSON: file.B64JSON}
+	}
+	return resp, nil
+}
+
+// fileOutput is a file output of a prediction, as a URL or inline.
+type fileOutput struct {
+	URL     string
+	B64JSON string
+}
+
+// fileOutputs returns the file outputs of pred. Outputs are URLs unless
+// inline is set or the model returned a data URI, in which case they are
+// downloaded and base64-encoded.
+func (p *Replicate) fileOutputs(ctx context.Context, pred *Prediction, inline bool) ([]fileOutput, error) {
+	files := pred.Files()
+	out := make([]fileOutput, len(files))
+	for i, file := range files {
+		if !inline && !strings.HasPrefix(file, "data:") {
+			out[i] = fileOutput{URL: file}
+			continue
+		}
+		data, err := p.DownloadFile(ctx, file)
+		if err != nil {
+			return nil, err
+		}
+		out[i] = fileOutput{B64JSON: base64.StdEncoding.EncodeToString(data)}
+	}
+	return out, nil
+}
+
+// fileInput returns an image as a file input: its URL, or its contents as
+// a data URI.
+func fileInput(img core.ImageInput) (string, error) {
+	if img.URL != "" {
+		return img.URL, nil
+	}
+	data, err := img.GetBytes()
+	if err != nil {
+		return "", &core.ProviderError{Provider: "replicate", Message: err.Error(), Err: core.ErrBadRequest}
+	}
+	if len(data) == 0 {
+		return "", &core.ProviderError{
+			Provider: "replicate",
+			Message:  "image must have data or a URL; file IDs are not supported",
+			Err:      core.ErrBadRequest,
+		}
+	}
+	return dataURI(data), nil
+}
+
+// aspectRatio maps an image size to the aspect_ratio input.
+func aspectRatio(size core.ImageSize) string {
+	switch size {
+	case core.ImageSize1024x1024:
+		return "1:1"
+	case core.ImageSize1536x1024:
+		return "3:2"
+	case core.ImageSize1024x1536:
+		return "2:3"
+	default:
+		return ""
+	}
+}
+
+// addOutputInputs sets the output_format and output_quality inputs.
+func addOutputInputs(input map[string]any, format core.ImageFormat, compression *int) {
+	switch format {
+	case core.ImageFormatJPEG:
+		input["output_format"] = "jpg"
+	case core.ImageFormatPNG, core.ImageFormatWebP:
+		input["output_format"] = string(format)
+	}
+	if compression != nil {
+		input["output_quality"] = *compression
+	}
+}
@@ -0,0 +1,101 @@
+package replicate
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestGenerateImage(t *testing.T) {
+	var input map[string]any
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/models/black-forest-labs/flux-schnell/predictions":
+			var body predictionRequest
+			json.NewDecoder(r.Body).Decode(&body)
+			input = body.Input
+			w.Write([]byte(`{"id": "p1", "status": "succeeded", "created_at": "2026-01-02T03:04:05Z", "output": ["` + server.URL + `/files/1.webp"]}`))
+		case "/files/1.webp":
+			if r.Header.Get("Authorization") == "" {
+				t.Error("file on the API host fetched without Authorization")
+			}
+			w.Write([]byte("image-bytes"))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	p := New("test-token", WithBaseURL(server.URL))
+	ctx := ContextWithInput(context.Background(), map[string]any{"seed": 42})
+	resp, err := p.GenerateImage(ctx, &core.ImageGenerateRequest{
+		Model:          "black-forest-labs/flux-schnell",
+		Prompt:         "a lighthouse",
+		N:              2,
+		Size:           core.ImageSize1536x1024,
+		Format:         core.ImageFormatJPEG,
+		ResponseFormat: "b64_json",
+	})
+	if err != nil {
+		t.Fatalf("GenerateImage() error = %v", err)
+	}
+
+	want := map[string]any{"prompt": "a lighthouse", "num_outputs": float64(2), "aspect_ratio": "3:2", "output_format": "jpg", "seed": float64(42)}
+	for k, v := range want {
+		if input[k] != v {
+			t.Errorf("input[%q] = %v, want %v", k, input[k], v)
+		}
+	}
+	if len(resp.Data) != 1 || resp.Data[0].B64JSON != base64.StdEncoding.EncodeToString([]byte("image-bytes")) {
+		t.Errorf("Data = %+v, want the downloaded image", resp.Data)
+	}
+	if resp.Created != 1767323045 {
+		t.Errorf("Created = %d", resp.Created)
+	}
+}
+
+func TestEditImage(t *testing.T) {
+	var input map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body predictionRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		input = body.Input
+		w.Write([]byte(`{"id": "p1", "status": "succeeded", "output": "https://replicate.delivery/edited.png"}`))
+	}))
+	defer server.Close()
+
+	p := New("test-token", WithBaseURL(server.URL))
+	resp, err := p.EditImage(context.Background(), &core.ImageEditRequest{
+		Model:  "owner/inpaint",
+		Prompt: "add a boat",
+		Images: []core.ImageInput{{Data: []byte("\x89PNG\r\n\x1a\n")}},
+		Mask:   &core.ImageInput{URL: "https://example.com/mask.png"},
+	})
+	if err != nil {
+		t.Fatalf("EditImage() error = %v", err)
+	}
+
+	if image, _ := input["image"].(string); !strings.HasPrefix(image, "data:image/png;base64,") {
+		t.Errorf("image input = %q, want a PNG data URI", image)
+	}
+	if input["mask"] != "https://example.com/mask.png" {
+		t.Errorf("mask input = %v", input["mask"])
+	}
+	if len(resp.Data) != 1 || resp.Data[0].URL != "https://replicate.delivery/edited.png" {
+		t.Errorf("Data = %+v", resp.Data)
+	}
+}
+
+func TestEditImageRequiresImage(t *testing.T) {
+	_, err := New("test-token").EditImage(context.Background(), &core.ImageEditRequest{Model: "owner/inpaint"})
+	if err == nil || !strings.Contains(err.Error(), "at least one image") {
+		t.Errorf("EditImage() error = %v", err)
+	}
+}
@@ -0,0 +1,124 @@
+package replicate
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// Config holds configuration for the Replicate provider.
+type Config struct {
+	// APIToken is the Replicate API token (required).
+	// Stored as Secret to prevent accidental logging.
+	APIToken core.Secret
+
+	// BaseURL is the API base URL. Defaults to https://api.replicate.com/v1
+	BaseURL string
+
+	// HTTPClient is the HTTP client to use. Defaults to a client with pooled
+	// connections shared by all providers. When set, Transport and Proxy are
+	// ignored.
+	HTTPClient *http.Client
+
+	// Transport replaces the default transport, for example to tune
+	// connection pooling or add instrumentation.
+	Transport http.RoundTripper
+
+	// Proxy routes requests through a proxy instead of the one configured by
+	// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY.
+	Proxy *url.URL
+
+	// Credentials, if set, supplies the API token for each request instead
+	// of APIToken, so tokens can rotate without a restart.
+	Credentials core.CredentialProvider
+
+	// Headers contains optional extra headers to include in requests.
+	Headers http.Header
+
+	// Wait is how long CreatePrediction asks the API to hold the request
+	// open for the prediction to finish, up to 60 seconds. Zero returns as
+	// soon as the prediction is created. Defaults to DefaultWait.
+	Wait time.Duration
+
+	// PollInterval is the delay between status checks while waiting for a
+	// prediction. Defaults to DefaultPollInterval.
+	PollInterval time.Duration
+}
+
+// DefaultBaseURL is the default Replicate API base URL.
+const DefaultBaseURL = "https://api.replicate.com/v1"
+
+// Defaults for waiting on predictions.
+const (
+	DefaultWait         = 60 * time.Second
+	DefaultPollInterval = time.Second
+)
+
+// Option configures the Replicate provider.
+type Option func(*Config)
+
+// WithBaseURL sets the API base URL.
+func WithBaseURL(url string) Option {
+	return func(c *Config) {
+		c.BaseURL = url
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) {
+		c.HTTPClient = client
+	}
+}
+
+// WithTransport sets the transport used by the default HTTP client.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Config) {
+		c.Transport = rt
+	}
+}
+
+// WithProxy routes requests through the proxy at proxyURL.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Config) {
+		c.Proxy = proxyURL
+	}
+}
+
+// WithCredentials resolves the API token from creds for every request
+// instead of using a fixed token. A request rejected with 401 is retried
+// once after refreshing creds, if it implements core.CredentialRefresher.
+func WithCredentials(creds core.CredentialProvider) Option {
+	return func(c *Config) {
+		c.Credentials = creds
+	}
+}
+
+// WithHeader adds an extra header to include in requests.
+func WithHeader(key, value string) Option {
+	return func(c *Config) {
+		if c.Headers == nil {
+			c.Headers = make(http.Header)
+		}
+		c.Headers.Set(key, value)
+	}
+}
+
+// WithWait sets how long CreatePrediction holds the request open for the
+// prediction to finish. The API allows up to 60 seconds; zero disables
+// waiting, so predictions are only polled.
+func WithWait(d time.Duration) Option {
+	return func(c *Config) {
+		c.Wait = d
+	}
+}
+
+// WithPollInterval sets the delay between status checks while waiting for a
+// prediction.
+func WithPollInterval(d time.Duration) Option {
+	return func(c *Config) {
+		c.PollInterval = d
+	}
+}
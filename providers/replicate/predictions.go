@@ -0,0 +1,145 @@
+package replicate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+const predictionsPath = "/predictions"
+
+// CreatePrediction starts a prediction. It waits up to Config.Wait for the
+// prediction to finish, so fast models return with their output; use Wait
+// or Run for predictions that take longer.
+func (p *Replicate) CreatePrediction(ctx context.Context, req *PredictionRequest) (*Prediction, error) {
+	path, body := predictionsPath, predictionRequest{
+		Input:               req.Input,
+		Webhook:             req.Webhook,
+		WebhookEventsFilter: req.WebhookEvents,
+	}
+	if body.Input == nil {
+		body.Input = map[string]any{}
+	}
+	switch model, version, hasVersion := strings.Cut(req.Model, ":"); {
+	case hasVersion:
+		body.Version = version
+	case strings.Contains(model, "/"):
+		path = "/models/" + model + predictionsPath
+	default:
+		body.Version = model
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+	header := p.buildHeaders()
+	if wait := int(p.config.Wait / time.Second); wait > 0 {
+		header.Set("Prefer", "wait="+strconv.Itoa(min(wait, 60)))
+	}
+	return p.doPrediction(ctx, http.MethodPost, p.config.BaseURL+path, header, data)
+}
+
+// GetPrediction returns the current state of a prediction.
+func (p *Replicate) GetPrediction(ctx context.Context, id string) (*Prediction, error) {
+	return p.doPrediction(ctx, http.MethodGet, p.config.BaseURL+predictionsPath+"/"+id, p.buildHeaders(), nil)
+}
+
+// CancelPrediction cancels a prediction that has not finished.
+func (p *Replicate) CancelPrediction(ctx context.Context, id string) (*Prediction, error) {
+	return p.doPrediction(ctx, http.MethodPost, p.config.BaseURL+predictionsPath+"/"+id+"/cancel", p.buildHeaders(), nil)
+}
+
+// Wait polls a prediction every Config.PollInterval until it finishes or
+// ctx is done, and returns its final state.
+func (p *Replicate) Wait(ctx context.Context, id string) (*Prediction, error) {
+	ticker := time.NewTicker(p.config.PollInterval)
+	defer ticker.Stop()
+	for {
+		pred, err := p.GetPrediction(ctx, id)
+		if err != nil || pred.Status.Done() {
+			return pred, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// Run creates a prediction and waits for it to finish. If the prediction
+// fails or is canceled, Run returns it with an error wrapping
+// ErrPredictionFailed.
+func (p *Replicate) Run(ctx context.Context, req *PredictionRequest) (*Prediction, error) {
+	pred, err := p.CreatePrediction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !pred.Status.Done() {
+		if pred, err = p.Wait(ctx, pred.ID); err != nil {
+			return nil, err
+		}
+	}
+	if pred.Status != StatusSucceeded {
+		return pred, predictionError(pred)
+	}
+	return pred, nil
+}
+
+// predictionError describes a failed or canceled prediction.
+func predictionError(pred *Prediction) error {
+	message := pred.Error
+	if message == "" {
+		message = "prediction " + string(pred.Status)
+	}
+	return &core.ProviderError{
+		Provider:  "replicate",
+		RequestID: pred.ID,
+		Code:      string(pred.Status),
+		Message:   message,
+		Err:       ErrPredictionFailed,
+	}
+}
+
+// doPrediction sends a request that returns a prediction.
+func (p *Replicate) doPrediction(ctx context.Context, method, url string, header http.Header, body []byte) (*Prediction, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header = header
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, respBody, resp.Header)
+	}
+
+	var pred Prediction
+	if err := json.Unmarshal(respBody, &pred); err != nil {
+		return nil, newDecodeError(err)
+	}
+	return &pred, nil
+}
@@ -0,0 +1,165 @@
+package replicate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestCreatePredictionEndpoints(t *testing.T) {
+	tests := []struct {
+		model       string
+		wantPath    string
+		wantVersion string
+	}{
+		{"black-forest-labs/flux-schnell", "/models/black-forest-labs/flux-schnell/predictions", ""},
+		{"stability-ai/sdxl:7762fd07", "/predictions", "7762fd07"},
+		{"7762fd07", "/predictions", "7762fd07"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.model, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("path = %q, want %q", r.URL.Path, tt.wantPath)
+				}
+				if got := r.Header.Get("Prefer"); got != "wait=60" {
+					t.Errorf("Prefer = %q, want wait=60", got)
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+					t.Errorf("Authorization = %q", got)
+				}
+				var body predictionRequest
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("request body: %v", err)
+				}
+				if body.Version != tt.wantVersion || body.Input["prompt"] != "hi" {
+					t.Errorf("body = %+v", body)
+				}
+				w.Write([]byte(`{"id": "p1", "status": "starting"}`))
+			}))
+			defer server.Close()
+
+			p := New("test-token", WithBaseURL(server.URL))
+			pred, err := p.CreatePrediction(context.Background(), &PredictionRequest{
+				Model: tt.model,
+				Input: map[string]any{"prompt": "hi"},
+			})
+			if err != nil {
+				t.Fatalf("CreatePrediction() error = %v", err)
+			}
+			if pred.ID != "p1" || pred.Status != StatusStarting {
+				t.Errorf("prediction = %+v", pred)
+			}
+		})
+	}
+}
+
+func TestRunPollsUntilDone(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost:
+			if r.Header.Get("Prefer") != "" {
+				t.Errorf("Prefer = %q, want none with WithWait(0)", r.Header.Get("Prefer"))
+			}
+			w.Write([]byte(`{"id": "p1", "status": "starting"}`))
+		case r.URL.Path == "/predictions/p1":
+			if polls.Add(1) < 3 {
+				w.Write([]byte(`{"id": "p1", "status": "processing"}`))
+				return
+			}
+			w.Write([]byte(`{"id": "p1", "status": "succeeded", "output": {"video": "https://replicate.delivery/out.mp4"}, "metrics": {"predict_time": 12.5}}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	p := New("test-token", WithBaseURL(server.URL), WithWait(0), WithPollInterval(time.Millisecond))
+	pred, err := p.Run(context.Background(), &PredictionRequest{Model: "minimax/video-01"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if polls.Load() != 3 {
+		t.Errorf("polled %d times, want 3", polls.Load())
+	}
+	if files := pred.Files(); len(files) != 1 || files[0] != "https://replicate.delivery/out.mp4" {
+		t.Errorf("Files() = %v", files)
+	}
+	if pred.Metrics.PredictTime != 12.5 {
+		t.Errorf("PredictTime = %v", pred.Metrics.PredictTime)
+	}
+}
+
+func TestRunFailedPrediction(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": "p1", "status": "failed", "error": "CUDA out of memory"}`))
+	}))
+	defer server.Close()
+
+	p := New("test-token", WithBaseURL(server.URL))
+	pred, err := p.Run(context.Background(), &PredictionRequest{Model: "owner/model"})
+	if !errors.Is(err, ErrPredictionFailed) {
+		t.Fatalf("Run() error = %v, want ErrPredictionFailed", err)
+	}
+	var pe *core.ProviderError
+	if !errors.As(err, &pe) || pe.Message != "CUDA out of memory" || pe.RequestID != "p1" {
+		t.Errorf("error = %+v", pe)
+	}
+	if pred == nil || pred.Status != StatusFailed {
+		t.Errorf("prediction = %+v, want the failed prediction", pred)
+	}
+}
+
+func TestCreatePredictionError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"title": "Request was throttled", "detail": "Request was throttled. Expected available in 2 seconds.", "status": 429}`))
+	}))
+	defer server.Close()
+
+	p := New("test-token", WithBaseURL(server.URL))
+	_, err := p.CreatePrediction(context.Background(), &PredictionRequest{Model: "owner/model"})
+
+	var pe *core.ProviderError
+	if !errors.As(err, &pe) || !errors.Is(err, core.ErrRateLimited) {
+		t.Fatalf("error = %v, want a rate limit error", err)
+	}
+	if pe.RetryAfter != 2*time.Second || pe.Message != "Request was throttled. Expected available in 2 seconds." {
+		t.Errorf("error = %+v", pe)
+	}
+}
+
+func TestPredictionFiles(t *testing.T) {
+	tests := []struct {
+		output string
+		want   []string
+	}{
+		{``, nil},
+		{`"https://a.example/1.png"`, []string{"https://a.example/1.png"}},
+		{`["https://a.example/1.png", "https://a.example/2.png"]`, []string{"https://a.example/1.png", "https://a.example/2.png"}},
+		{`{"wav": "https://a.example/a.wav", "text": "not a file", "mp3": "https://a.example/a.mp3"}`, []string{"https://a.example/a.mp3", "https://a.example/a.wav"}},
+		{`"plain text output"`, nil},
+	}
+	for _, tt := range tests {
+		pred := &Prediction{Output: json.RawMessage(tt.output)}
+		got := pred.Files()
+		if len(got) != len(tt.want) {
+			t.Errorf("Files(%s) = %v, want %v", tt.output, got, tt.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("Files(%s) = %v, want %v", tt.output, got, tt.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package replicate
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
+)
+
+// DefaultAPIKeyEnvVar is the environment variable name for the Replicate API token.
+const DefaultAPIKeyEnvVar = "REPLICATE_API_TOKEN"
+
+// ErrAPIKeyNotFound is returned when the API token environment variable is not set.
+var ErrAPIKeyNotFound = errors.New("replicate: REPLICATE_API_TOKEN environment variable not set")
+
+// NewFromEnv creates a new Replicate provider using the REPLICATE_API_TOKEN environment variable.
+// This is a convenience factory for quick setup:
+//
+//	provider, err := replicate.NewFromEnv()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+func NewFromEnv(opts ...Option) (*Replicate, error) {
+	apiToken := os.Getenv(DefaultAPIKeyEnvVar)
+	if apiToken == "" {
+		return nil, ErrAPIKeyNotFound
+	}
+	return New(apiToken, opts...), nil
+}
+
+// Replicate is a provider for models hosted on Replicate. It implements
+// core.ImageGenerator and core.VideoGenerator, and runs any model through
+// the prediction API.
+// Replicate is safe for concurrent use.
+type Replicate struct {
+	config Config
+}
+
+// New creates a new Replicate provider with the given API token and options.
+func New(apiToken string, opts ...Option) *Replicate {
+	cfg := Config{
+		APIToken:     core.NewSecret(apiToken),
+		BaseURL:      DefaultBaseURL,
+		Wait:         DefaultWait,
+		PollInterval: DefaultPollInterval,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+	}
+	if cfg.Credentials != nil {
+		cfg.HTTPClient = httpclient.WithCredentials(cfg.HTTPClient, cfg.Credentials, httpclient.BearerAuth)
+	}
+
+	return &Replicate{config: cfg}
+}
+
+// ID returns the provider identifier.
+func (p *Replicate) ID() string {
+	return "replicate"
+}
+
+// CloseIdleConnections closes the idle pooled connections of the provider's
+// HTTP client. core.Client.Close calls it during shutdown.
+func (p *Replicate) CloseIdleConnections() {
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
+// Models returns no models: Replicate hosts thousands of models, named
+// "owner/name" or "owner/name:version".
+func (p *Replicate) Models() []core.ModelInfo {
+	return nil
+}
+
+// Supports reports whether the provider supports the given feature.
+func (p *Replicate) Supports(feature core.Feature) bool {
+	return feature == core.FeatureImageGeneration || feature == core.FeatureVideoGeneration
+}
+
+// Chat is not supported by Replicate. Returns ErrNotSupported.
+func (p *Replicate) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	return nil, &core.ProviderError{
+		Provider: "replicate",
+		Message:  "chat is not supported by the Replicate provider; use Run",
+		Err:      core.ErrNotSupported,
+	}
+}
+
+// StreamChat is not supported by Replicate. Returns ErrNotSupported.
+func (p *Replicate) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	return nil, &core.ProviderError{
+		Provider: "replicate",
+		Message:  "streaming chat is not supported by the Replicate provider; use Run",
+		Err:      core.ErrNotSupported,
+	}
+}
+
+// buildHeaders constructs the HTTP headers for an API request.
+func (p *Replicate) buildHeaders() http.Header {
+	headers := make(http.Header)
+
+	// Required headers
+	headers.Set("Authorization", "Bearer "+p.config.APIToken.Expose())
+	headers.Set("Content-Type", "application/json")
+
+	// Copy any extra headers
+	for key, values := range p.config.Headers {
+		for _, v := range values {
+			headers.Add(key, v)
+		}
+	}
+
+	return headers
+}
+
+// Compile-time checks that Replicate implements Provider, ImageGenerator,
+// and VideoGenerator.
+var (
+	_ core.Provider       = (*Replicate)(nil)
+	_ core.ImageGenerator = (*Replicate)(nil)
+	_ core.VideoGenerator = (*Replicate)(nil)
+)
+
+// Compile-time check that Replicate implements IdleConnectionCloser.
+var _ core.IdleConnectionCloser = (*Replicate)(nil)
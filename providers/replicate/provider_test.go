@@ -0,0 +1,49 @@
+package replicate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestNewDefaults(t *testing.T) {
+	p := New("test-token")
+
+	if p.ID() != "replicate" {
+		t.Errorf("ID() = %q, want replicate", p.ID())
+	}
+	if p.config.BaseURL != DefaultBaseURL || p.config.Wait != DefaultWait || p.config.PollInterval != DefaultPollInterval {
+		t.Errorf("config = %+v, want defaults", p.config)
+	}
+	if !p.Supports(core.FeatureImageGeneration) || !p.Supports(core.FeatureVideoGeneration) || p.Supports(core.FeatureChat) {
+		t.Error("Supports should report image and video generation only")
+	}
+}
+
+func TestChatNotSupported(t *testing.T) {
+	p := New("test-token")
+	if _, err := p.Chat(context.Background(), &core.ChatRequest{}); !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("Chat() error = %v, want ErrNotSupported", err)
+	}
+	if _, err := p.StreamChat(context.Background(), &core.ChatRequest{}); !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("StreamChat() error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestRegisteredFactory(t *testing.T) {
+	t.Setenv(DefaultAPIKeyEnvVar, "")
+	if _, err := core.NewProviderFromConfig(core.ProviderConfig{Name: "replicate"}); !errors.Is(err, ErrAPIKeyNotFound) {
+		t.Errorf("error = %v, want ErrAPIKeyNotFound", err)
+	}
+
+	t.Setenv(DefaultAPIKeyEnvVar, "test-token")
+	p, err := core.NewProviderFromConfig(core.ProviderConfig{Name: "replicate"})
+	if err != nil {
+		t.Fatalf("NewProviderFromConfig() error = %v", err)
+	}
+	if _, ok := p.(core.ImageGenerator); !ok {
+		t.Error("registered provider does not implement core.ImageGenerator")
+	}
+}
@@ -0,0 +1,141 @@
+package replicate
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Status is the state of a prediction.
+type Status string
+
+const (
+	StatusStarting   Status = "starting"
+	StatusProcessing Status = "processing"
+	StatusSucceeded  Status = "succeeded"
+	StatusFailed     Status = "failed"
+	StatusCanceled   Status = "canceled"
+)
+
+// Done reports whether the prediction has finished, successfully or not.
+func (s Status) Done() bool {
+	switch s {
+	case StatusSucceeded, StatusFailed, StatusCanceled:
+		return true
+	default:
+		return false
+	}
+}
+
+// WebhookEvent selects the prediction events sent to a webhook.
+type WebhookEvent string
+
+const (
+	WebhookEventStart     WebhookEvent = "start"
+	WebhookEventOutput    WebhookEvent = "output"
+	WebhookEventLogs      WebhookEvent = "logs"
+	WebhookEventCompleted WebhookEvent = "completed"
+)
+
+// PredictionRequest is a request to run a model.
+type PredictionRequest struct {
+	// Model is "owner/name" to run the latest version of a model,
+	// "owner/name:version" to run a specific version, or a version ID.
+	Model string
+
+	// Input holds the model inputs, as listed in the model's API schema.
+	// Files can be given as URLs or data URIs.
+	Input map[string]any
+
+	// Webhook, if set, receives the prediction as it changes. Use
+	// VerifyWebhook in the handler.
+	Webhook string
+
+	// WebhookEvents filters the events sent to Webhook. By default all
+	// events are sent.
+	WebhookEvents []WebhookEvent
+}
+
+// Prediction is a run of a model.
+type Prediction struct {
+	ID      string         `json:"id"`
+	Model   string         `json:"model"`
+	Version string         `json:"version"`
+	Status  Status         `json:"status"`
+	Input   map[string]any `json:"input,omitempty"`
+
+	// Output is the model output, whose shape depends on the model. Files
+	// returns the file URLs it contains.
+	Output json.RawMessage `json:"output,omitempty"`
+
+	Error       string         `json:"error,omitempty"`
+	Logs        string         `json:"logs,omitempty"`
+	Metrics     Metrics        `json:"metrics"`
+	URLs        PredictionURLs `json:"urls"`
+	CreatedAt   time.Time      `json:"created_at"`
+	StartedAt   *time.Time     `json:"started_at,omitempty"`
+	CompletedAt *time.Time     `json:"completed_at,omitempty"`
+}
+
+// Metrics are the timings of a prediction, in seconds.
+type Metrics struct {
+	PredictTime float64 `json:"predict_time,omitempty"`
+	TotalTime   float64 `json:"total_time,omitempty"`
+}
+
+// PredictionURLs are the API and web URLs of a prediction.
+type PredictionURLs struct {
+	Get    string `json:"get,omitempty"`
+	Cancel string `json:"cancel,omitempty"`
+	Stream string `json:"stream,omitempty"`
+	Web    string `json:"web,omitempty"`
+}
+
+// Files returns the file URLs in the output, in order. Models that produce
+// images, video, or audio return them as URLs, either alone, in a list, or
+// in an object.
+func (p *Prediction) Files() []string {
+	if len(p.Output) == 0 {
+		return nil
+	}
+	var output any
+	if err := json.Unmarshal(p.Output, &output); err != nil {
+		return nil
+	}
+	var files []string
+	collectFiles(output, &files)
+	return files
+}
+
+// collectFiles appends the URLs in v to files.
+func collectFiles(v any, files *[]string) {
+	switch v := v.(type) {
+	case string:
+		if strings.HasPrefix(v, "https://") || strings.HasPrefix(v, "http://") || strings.HasPrefix(v, "data:") {
+			*files = append(*files, v)
+		}
+	case []any:
+		for _, e := range v {
+			collectFiles(e, files)
+		}
+	case map[string]any:
+		// Sorted for a stable order
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			collectFiles(v[k], files)
+		}
+	}
+}
+
+// predictionRequest is the body of a create prediction request.
+type predictionRequest struct {
+	Version             string         `json:"version,omitempty"`
+	Input               map[string]any `json:"input"`
+	Webhook             string         `json:"webhook,omitempty"`
+	WebhookEventsFilter []WebhookEvent `json:"webhook_events_filter,omitempty"`
+}
@@ -0,0 +1,51 @@
+package replicate
+
+import (
+	"context"
+	"maps"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// GenerateVideo runs a text-to-video or image-to-video model such as
+// minimax/video-01. The request is mapped to the input names most Replicate
+// video models use: prompt, image for the first frame, duration in whole
+// seconds, and aspect_ratio; ContextWithInput sets others. Videos are
+// returned as URLs, or downloaded and base64-encoded when ResponseFormat is
+// "b64_json".
+func (p *Replicate) GenerateVideo(ctx context.Context, req *core.VideoGenerateRequest) (*core.VideoResponse, error) {
+	input := map[string]any{"prompt": req.Prompt}
+	if req.Image != nil {
+		image, err := fileInput(*req.Image)
+		if err != nil {
+			return nil, err
+		}
+		input["image"] = image
+	}
+	if req.Duration > 0 {
+		// Round up so short durations are not sent as zero
+		input["duration"] = int((req.Duration + time.Second - 1) / time.Second)
+	}
+	if req.AspectRatio != "" {
+		input["aspect_ratio"] = req.AspectRatio
+	}
+	maps.Copy(input, inputFromContext(ctx))
+
+	pred, err := p.Run(ctx, &PredictionRequest{Model: string(req.Model), Input: input})
+	if err != nil {
+		return nil, err
+	}
+	files, err := p.fileOutputs(ctx, pred, req.ResponseFormat == "b64_json")
+	if err != nil {
+		return nil, err
+	}
+	resp := &core.VideoResponse{
+		Created: pred.CreatedAt.Unix(),
+		Data:    make([]core.VideoData, len(files)),
+	}
+	for i, file := range files {
+		resp.Data[i] = core.VideoData{URL: file.URL, B64JSON: file.B64JSON}
+	}
+	return resp, nil
+}
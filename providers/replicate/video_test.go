@@ -0,0 +1,103 @@
+package replicate
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestGenerateVideo(t *testing.T) {
+	var input map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/models/minimax/video-01/predictions" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		var body predictionRequest
+		json.NewDecoder(r.Body).Decode(&body)
+		input = body.Input
+		w.Write([]byte(`{"id": "p1", "status": "succeeded", "created_at": "2026-01-02T03:04:05Z", "output": "https://replicate.delivery/boat.mp4"}`))
+	}))
+	defer server.Close()
+
+	p := New("test-token", WithBaseURL(server.URL))
+	ctx := ContextWithInput(context.Background(), map[string]any{"prompt_optimizer": false})
+	resp, err := p.GenerateVideo(ctx, &core.VideoGenerateRequest{
+		Model:       "minimax/video-01",
+		Prompt:      "a paper boat drifting down a stream",
+		Image:       &core.ImageInput{URL: "https://example.com/boat.png"},
+		Duration:    5500 * time.Millisecond,
+		AspectRatio: "16:9",
+	})
+	if err != nil {
+		t.Fatalf("GenerateVideo() error = %v", err)
+	}
+
+	want := map[string]any{
+		"prompt":           "a paper boat drifting down a stream",
+		"image":            "https://example.com/boat.png",
+		"duration":         float64(6),
+		"aspect_ratio":     "16:9",
+		"prompt_optimizer": false,
+	}
+	for k, v := range want {
+		if input[k] != v {
+			t.Errorf("input[%q] = %v, want %v", k, input[k], v)
+		}
+	}
+	if len(resp.Data) != 1 || resp.Data[0].URL != "https://replicate.delivery/boat.mp4" {
+		t.Errorf("Data = %+v, want the video URL", resp.Data)
+	}
+	if resp.Created != 1767323045 {
+		t.Errorf("Created = %d", resp.Created)
+	}
+}
+
+func TestGenerateVideoInline(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/models/owner/video/predictions":
+			w.Write([]byte(`{"id": "p1", "status": "succeeded", "output": ["` + server.URL + `/files/1.mp4"]}`))
+		case "/files/1.mp4":
+			w.Write([]byte("video-bytes"))
+		default:
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	p := New("test-token", WithBaseURL(server.URL))
+	resp, err := p.GenerateVideo(context.Background(), &core.VideoGenerateRequest{
+		Model:          "owner/video",
+		Prompt:         "a lighthouse",
+		ResponseFormat: "b64_json",
+	})
+	if err != nil {
+		t.Fatalf("GenerateVideo() error = %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].B64JSON != base64.StdEncoding.EncodeToString([]byte("video-bytes")) {
+		t.Fatalf("Data = %+v, want the downloaded video", resp.Data)
+	}
+	if data, _ := resp.Data[0].GetBytes(); string(data) != "video-bytes" {
+		t.Errorf("GetBytes() = %q", data)
+	}
+}
+
+func TestGenerateVideoInvalidImage(t *testing.T) {
+	p := New("test-token", WithBaseURL("http://unused"))
+	_, err := p.GenerateVideo(context.Background(), &core.VideoGenerateRequest{
+		Model:  "owner/video",
+		Prompt: "a lighthouse",
+		Image:  &core.ImageInput{FileID: "file_1"},
+	})
+	if err == nil || !strings.Contains(err.Error(), "file IDs are not supported") {
+		t.Errorf("GenerateVideo() error = %v, want unsupported file ID", err)
+	}
+}
@@ -0,0 +1,129 @@
+package replicate
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidWebhook is returned by VerifyWebhook when a request is not a
+// validly signed Replicate webhook.
+var ErrInvalidWebhook = errors.New("replicate: invalid webhook")
+
+// WebhookTolerance is how far the timestamp of a webhook may be from the
+// current time. Older webhooks are rejected to prevent replays.
+const WebhookTolerance = 5 * time.Minute
+
+// WebhookSecret returns the key webhooks sent to the account are signed
+// with, of the form "whsec_...". Fetch it once and keep it with the
+// handler's configuration.
+func (p *Replicate) WebhookSecret(ctx context.Context) (string, error) {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.BaseURL+"/webhooks/default/secret", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header = p.buildHeaders()
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return "", newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", newNetworkError(err)
+	}
+	if resp.StatusCode >= 400 {
+		return "", normalizeError(resp.StatusCode, body, resp.Header)
+	}
+
+	var secret struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(body, &secret); err != nil {
+		return "", newDecodeError(err)
+	}
+	return secret.Key, nil
+}
+
+// VerifyWebhook checks that r is a webhook signed with secret and sent
+// within WebhookTolerance, and returns the prediction it carries. It reads
+// the body of r. Errors wrap ErrInvalidWebhook.
+//
+//	func handle(w http.ResponseWriter, r *http.Request) {
+//	    pred, err := replicate.VerifyWebhook(r, secret)
+//	    if err != nil {
+//	        http.Error(w, "invalid webhook", http.StatusBadRequest)
+//	        return
+//	    }
+//	    if pred.Status.Done() {
+//	        // handle pred.Files()
+//	    }
+//	}
+func VerifyWebhook(r *http.Request, secret string) (*Prediction, error) {
+	id := r.Header.Get("webhook-id")
+	timestamp := r.Header.Get("webhook-timestamp")
+	signatures := r.Header.Get("webhook-signature")
+	if id == "" || timestamp == "" || signatures == "" {
+		return nil, fmt.Errorf("%w: missing webhook headers", ErrInvalidWebhook)
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad timestamp", ErrInvalidWebhook)
+	}
+	if age := time.Since(time.Unix(seconds, 0)); age > WebhookTolerance || age < -WebhookTolerance {
+		return nil, fmt.Errorf("%w: timestamp outside tolerance", ErrInvalidWebhook)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad secret: %v", ErrInvalidWebhook, err)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+
+	if !validSignature(key, id, timestamp, body, signatures) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidWebhook)
+	}
+
+	var pred Prediction
+	if err := json.Unmarshal(body, &pred); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebhook, err)
+	}
+	return &pred, nil
+}
+
+// validSignature reports whether one of the space-separated "v1,<base64>"
+// signatures is the HMAC-SHA256 of "id.timestamp.body".
+func validSignature(key []byte, id, timestamp string, body []byte, signatures string) bool {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	for _, sig := range strings.Fields(signatures) {
+		version, value, ok := strings.Cut(sig, ",")
+		if !ok || version != "v1" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err == nil && hmac.Equal(decoded, expected) {
+			return true
+		}
+	}
+	return false
+}
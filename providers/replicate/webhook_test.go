@@ -0,0 +1,73 @@
+package replicate
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func signedWebhook(t *testing.T, secret, body string, at time.Time) (id, timestamp, signature string) {
+	t.Helper()
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	id, timestamp = "msg_1", strconv.FormatInt(at.Unix(), 10)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + timestamp + "." + body))
+	return id, timestamp, "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhook(t *testing.T) {
+	secret := "whsec_" + base64.StdEncoding.EncodeToString([]byte("webhook-signing-key"))
+	body := `{"id": "p1", "status": "succeeded", "output": ["https://replicate.delivery/out.png"]}`
+
+	tests := []struct {
+		name    string
+		body    string
+		at      time.Time
+		secret  string
+		wantErr bool
+	}{
+		{"valid", body, time.Now(), secret, false},
+		{"tampered body", strings.Replace(body, "p1", "p2", 1), time.Now(), secret, true},
+		{"too old", body, time.Now().Add(-10 * time.Minute), secret, true},
+		{"wrong secret", body, time.Now(), "whsec_" + base64.StdEncoding.EncodeToString([]byte("other")), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, timestamp, signature := signedWebhook(t, tt.secret, body, tt.at)
+			r := httptest.NewRequest("POST", "/webhook", strings.NewReader(tt.body))
+			r.Header.Set("webhook-id", id)
+			r.Header.Set("webhook-timestamp", timestamp)
+			r.Header.Set("webhook-signature", "v1,bm90LXRoZS1zaWduYXR1cmU= "+signature)
+
+			pred, err := VerifyWebhook(r, secret)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidWebhook) {
+					t.Errorf("VerifyWebhook() error = %v, want ErrInvalidWebhook", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyWebhook() error = %v", err)
+			}
+			if pred.ID != "p1" || !pred.Status.Done() || len(pred.Files()) != 1 {
+				t.Errorf("prediction = %+v", pred)
+			}
+		})
+	}
+}
+
+func TestVerifyWebhookMissingHeaders(t *testing.T) {
+	r := httptest.NewRequest("POST", "/webhook", strings.NewReader(`{}`))
+	if _, err := VerifyWebhook(r, "whsec_a2V5"); !errors.Is(err, ErrInvalidWebhook) {
+		t.Errorf("VerifyWebhook() error = %v, want ErrInvalidWebhook", err)
+	}
+}
@@ -0,0 +1,43 @@
+// Package vllm provides an LLM provider for vLLM servers.
+//
+// The provider is an openaicompat provider configured for vLLM, with typed
+// access to the request fields vLLM adds to the OpenAI API: guided
+// decoding, beam search, extra sampling parameters, and priority
+// scheduling. These fields are dropped when a vLLM server is used through
+// the openai provider.
+//
+// # Basic Usage
+//
+//	provider := vllm.New("http://localhost:8000/v1", "")
+//	resp, err := core.NewClient(provider).Chat("Qwen/Qwen3-8B").
+//	    User("Hello!").
+//	    GetResponse(ctx)
+//
+// New takes the same options as openaicompat.New.
+//
+// # Request Parameters
+//
+// ContextWithParams sets vLLM parameters for the requests made with a
+// context:
+//
+//	ctx := vllm.ContextWithParams(ctx, vllm.Params{
+//	    GuidedChoice: []string{"positive", "negative", "neutral"},
+//	})
+//	resp, err := client.Chat("Qwen/Qwen3-8B").User("Classify: great product!").GetResponse(ctx)
+//
+// WithDefaultParams sets parameters for every request, for example a
+// scheduling priority for a batch workload:
+//
+//	provider := vllm.New(baseURL, "", vllm.WithDefaultParams(vllm.Params{Priority: 10}))
+//
+// Priority only takes effect when the server runs with
+// --scheduling-policy priority; lower values are scheduled first.
+//
+// # Registry
+//
+// Importing the package registers the "vllm" provider, which reads its base
+// URL and API key from ProviderConfig or the VLLM_BASE_URL and VLLM_API_KEY
+// environment variables, and otherwise uses http://localhost:8000/v1:
+//
+//	provider, model, err := core.NewProviderFromString("vllm:Qwen/Qwen3-8B")
+package vllm
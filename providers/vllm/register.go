@@ -0,0 +1,20 @@
+package vllm
+
+import (
+	"cmp"
+	"os"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/openaicompat"
+)
+
+func init() {
+	core.RegisterProvider("vllm", func(cfg core.ProviderConfig) (core.Provider, error) {
+		baseURL := cmp.Or(cfg.BaseURL, os.Getenv(BaseURLEnvVar), DefaultBaseURL)
+		var opts []openaicompat.Option
+		if cfg.Credentials != nil {
+			opts = append(opts, openaicompat.WithCredentials(cfg.Credentials))
+		}
+		return New(baseURL, cfg.APIKeyOrEnv(APIKeyEnvVar), opts...), nil
+	})
+}
@@ -0,0 +1,121 @@
+package vllm
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/openaicompat"
+)
+
+// DefaultID is the provider ID of providers created by New.
+const DefaultID = "vllm"
+
+// DefaultBaseURL is the address of a vLLM server started with vllm serve
+// on the local machine.
+const DefaultBaseURL = "http://localhost:8000/v1"
+
+// Environment variable names read by the registry factory.
+const (
+	BaseURLEnvVar = "VLLM_BASE_URL"
+	APIKeyEnvVar  = "VLLM_API_KEY"
+)
+
+// DefaultFeatures are the features reported by providers created by New.
+var DefaultFeatures = []core.Feature{
+	core.FeatureChat,
+	core.FeatureChatStreaming,
+	core.FeatureToolCalling,
+	core.FeatureStructuredOutput,
+	core.FeatureReasoning,
+}
+
+// New creates a provider for the vLLM server at baseURL, such as
+// http://localhost:8000/v1. apiKey may be empty unless the server was
+// started with --api-key. The provider requests token usage in streams;
+// opts are applied after these defaults.
+func New(baseURL, apiKey string, opts ...openaicompat.Option) *openaicompat.Provider {
+	defaults := []openaicompat.Option{
+		openaicompat.WithID(DefaultID),
+		openaicompat.WithFeatures(DefaultFeatures...),
+		openaicompat.WithStreamUsage(),
+	}
+	return openaicompat.New(baseURL, apiKey, append(defaults, opts...)...)
+}
+
+// Params are vLLM request fields that the OpenAI API does not define. Zero
+// fields are not sent, so the server defaults apply.
+type Params struct {
+	// GuidedJSON constrains the output to a JSON schema.
+	GuidedJSON json.RawMessage
+	// GuidedRegex constrains the output to a regular expression.
+	GuidedRegex string
+	// GuidedChoice constrains the output to one of the given strings.
+	GuidedChoice []string
+	// GuidedGrammar constrains the output to a context-free grammar.
+	GuidedGrammar string
+	// GuidedDecodingBackend selects the guided decoding backend, such as
+	// "xgrammar" or "outlines".
+	GuidedDecodingBackend string
+
+	// UseBeamSearch decodes with beam search instead of sampling.
+	UseBeamSearch bool
+	// BeamWidth is the number of beams. Only the best beam is returned.
+	BeamWidth int
+	// LengthPenalty scores beams by length; values above 1 favour longer
+	// sequences.
+	LengthPenalty float64
+
+	// TopK limits sampling to the K most likely tokens.
+	TopK int
+	// MinP is the minimum probability of a token relative to the most
+	// likely one.
+	MinP float64
+	// RepetitionPenalty penalizes tokens that already appear in the prompt
+	// or output; values above 1 discourage repetition.
+	RepetitionPenalty float64
+	// MinTokens is the minimum number of tokens to generate.
+	MinTokens int
+
+	// Priority is the scheduling priority of the request. Lower values are
+	// scheduled first; the server default is 0. The server must run with
+	// --scheduling-policy priority.
+	Priority int
+}
+
+// fields returns the non-zero parameters as request body fields.
+func (p Params) fields() map[string]any {
+	fields := make(map[string]any)
+	set := func(name string, v any, ok bool) {
+		if ok {
+			fields[name] = v
+		}
+	}
+	set("guided_json", p.GuidedJSON, len(p.GuidedJSON) > 0)
+	set("guided_regex", p.GuidedRegex, p.GuidedRegex != "")
+	set("guided_choice", p.GuidedChoice, len(p.GuidedChoice) > 0)
+	set("guided_grammar", p.GuidedGrammar, p.GuidedGrammar != "")
+	set("guided_decoding_backend", p.GuidedDecodingBackend, p.GuidedDecodingBackend != "")
+	set("use_beam_search", true, p.UseBeamSearch)
+	set("n", p.BeamWidth, p.BeamWidth != 0)
+	set("length_penalty", p.LengthPenalty, p.LengthPenalty != 0)
+	set("top_k", p.TopK, p.TopK != 0)
+	set("min_p", p.MinP, p.MinP != 0)
+	set("repetition_penalty", p.RepetitionPenalty, p.RepetitionPenalty != 0)
+	set("min_tokens", p.MinTokens, p.MinTokens != 0)
+	set("priority", p.Priority, p.Priority != 0)
+	return fields
+}
+
+// ContextWithParams returns a context that adds p to chat requests made
+// with it. Parameters set by an earlier call on ctx are kept unless p sets
+// them again.
+func ContextWithParams(ctx context.Context, p Params) context.Context {
+	return openaicompat.ContextWithExtraBody(ctx, p.fields())
+}
+
+// WithDefaultParams adds p to every chat request. Parameters set with
+// ContextWithParams take precedence.
+func WithDefaultParams(p Params) openaicompat.Option {
+	return openaicompat.WithExtraBody(p.fields())
+}
@@ -0,0 +1,100 @@
+package vllm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestNewDefaults(t *testing.T) {
+	p := New(DefaultBaseURL, "")
+
+	if p.ID() != DefaultID {
+		t.Errorf("ID() = %q, want %q", p.ID(), DefaultID)
+	}
+	if !p.Supports(core.FeatureReasoning) {
+		t.Error("Supports(reasoning) = false, want true")
+	}
+}
+
+func TestParamsFields(t *testing.T) {
+	if fields := (Params{}).fields(); len(fields) != 0 {
+		t.Errorf("fields() = %v, want none for zero Params", fields)
+	}
+
+	fields := Params{
+		GuidedChoice:  []string{"yes", "no"},
+		UseBeamSearch: true,
+		BeamWidth:     4,
+		LengthPenalty: 1.2,
+		Priority:      -1,
+	}.fields()
+	want := []string{"guided_choice", "use_beam_search", "n", "length_penalty", "priority"}
+	if len(fields) != len(want) {
+		t.Errorf("fields() = %v, want keys %v", fields, want)
+	}
+	for _, k := range want {
+		if _, ok := fields[k]; !ok {
+			t.Errorf("fields() missing %s", k)
+		}
+	}
+}
+
+func TestChatSendsParams(t *testing.T) {
+	var body map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Errorf("request body: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "chatcmpl-1", "model": "qwen3", "choices": [{"index": 0, "message": {"role": "assistant", "content": "42"}, "finish_reason": "stop"}]}`))
+	}))
+	defer server.Close()
+
+	p := New(server.URL+"/v1", "", WithDefaultParams(Params{Priority: 10, TopK: 20}))
+	ctx := ContextWithParams(context.Background(), Params{
+		GuidedJSON: json.RawMessage(`{"type":"integer"}`),
+		Priority:   1,
+	})
+	resp, err := p.Chat(ctx, &core.ChatRequest{
+		Model:    "qwen3",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Answer?"}},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp.Output != "42" {
+		t.Errorf("Output = %q", resp.Output)
+	}
+
+	if body["priority"] != float64(1) {
+		t.Errorf("priority = %v, want context value 1", body["priority"])
+	}
+	if body["top_k"] != float64(20) {
+		t.Errorf("top_k = %v, want default 20", body["top_k"])
+	}
+	if schema, ok := body["guided_json"].(map[string]any); !ok || schema["type"] != "integer" {
+		t.Errorf("guided_json = %v", body["guided_json"])
+	}
+}
+
+func TestRegisteredFactory(t *testing.T) {
+	t.Setenv(BaseURLEnvVar, "")
+
+	p, model, err := core.NewProviderFromString("vllm:Qwen/Qwen3-8B")
+	if err != nil {
+		t.Fatalf("NewProviderFromString() error = %v", err)
+	}
+	if p.ID() != DefaultID {
+		t.Errorf("ID() = %q, want %q", p.ID(), DefaultID)
+	}
+	if model != "Qwen/Qwen3-8B" {
+		t.Errorf("model = %q, want Qwen/Qwen3-8B", model)
+	}
+}
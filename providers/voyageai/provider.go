@@ -5,8 +5,10 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 // DefaultAPIKeyEnvVar is the environment variable name for the Voyage AI API key.
@@ -40,14 +42,19 @@ type VoyageAI struct {
 // New creates a new Voyage AI provider with the given API key and options.
 func New(apiKey string, opts ...Option) *VoyageAI {
 	cfg := Config{
-		APIKey:     core.NewSecret(apiKey),
-		BaseURL:    DefaultBaseURL,
-		HTTPClient: http.DefaultClient,
+		APIKey:  core.NewSecret(apiKey),
+		BaseURL: DefaultBaseURL,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+	}
+	if cfg.Credentials != nil {
+		cfg.HTTPClient = httpclient.WithCredentials(cfg.HTTPClient, cfg.Credentials, httpclient.BearerAuth)
+	}
 
 	return &VoyageAI{config: cfg}
 }
@@ -57,6 +64,12 @@ func (p *VoyageAI) ID() string {
 	return "voyageai"
 }
 
+// CloseIdleConnections closes the idle pooled connections of the provider's
+// HTTP client. core.Client.Close calls it during shutdown.
+func (p *VoyageAI) CloseIdleConnections() {
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
 // Models returns the list of available models.
 func (p *VoyageAI) Models() []core.ModelInfo {
 	// Return a copy to prevent mutation
@@ -65,6 +78,12 @@ func (p *VoyageAI) Models() []core.ModelInfo {
 	return result
 }
 
+// DefaultTimeout returns the timeout set with WithTimeout. core.Client
+// applies it to requests that have no deadline or Timeout of their own.
+func (p *VoyageAI) DefaultTimeout() time.Duration {
+	return p.config.Timeout
+}
+
 // Supports reports whether the provider supports the given feature.
 func (p *VoyageAI) Supports(feature core.Feature) bool {
 	switch feature {
@@ -113,3 +132,9 @@ func (p *VoyageAI) buildHeaders() http.Header {
 
 // Compile-time check that VoyageAI implements Provider.
 var _ core.Provider = (*VoyageAI)(nil)
+
+// Compile-time check that VoyageAI implements TimeoutProvider.
+var _ core.TimeoutProvider = (*VoyageAI)(nil)
+
+// Compile-time check that VoyageAI implements IdleConnectionCloser.
+var _ core.IdleConnectionCloser = (*VoyageAI)(nil)
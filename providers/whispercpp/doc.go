@@ -0,0 +1,31 @@
+// Package whispercpp provides a speech-to-text provider for the server
+// bundled with whisper.cpp, for transcription pipelines that run fully
+// offline next to a local Ollama chat model.
+//
+// Start the server with a model, for example:
+//
+//	whisper-server -m models/ggml-base.en.bin --port 8080
+//
+// and transcribe audio through core.Transcriber:
+//
+//	provider := whispercpp.NewLocal()
+//
+//	audio, _ := os.ReadFile("meeting.wav")
+//	resp, err := provider.Transcribe(ctx, &core.TranscriptionRequest{
+//		Audio:    audio,
+//		Filename: "meeting.wav",
+//		Language: "en",
+//	})
+//	fmt.Println(resp.Text)
+//
+// NewLocal reads the server URL from WHISPERCPP_BASE_URL and defaults to
+// http://127.0.0.1:8080. The server transcribes with the model it was
+// started with, so TranscriptionRequest.Model is ignored. Servers started
+// without --convert accept only 16 kHz WAV audio.
+//
+// Responses include the detected language, the audio duration, and timed
+// segments. Set Translate to translate the speech to English.
+//
+// Ollama does not expose a transcription endpoint, so whisper.cpp is the
+// local speech-to-text path. The provider does not support chat.
+package whispercpp
@@ -0,0 +1,38 @@
+package whispercpp
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/petal-labs/iris/providers/internal/normalize"
+)
+
+// errorResponse is the body the server sends when a request fails.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// normalizeError converts an HTTP error response to a ProviderError with the
+// appropriate sentinel.
+func normalizeError(status int, body []byte) error {
+	var errResp errorResponse
+	message := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &errResp) == nil && errResp.Error != "" {
+		message = errResp.Error
+	}
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	return normalize.ProviderError("whispercpp", status, "", "", message, normalize.SentinelForStatus(status))
+}
+
+// newNetworkError creates a ProviderError for network-related failures.
+func newNetworkError(err error) error {
+	return normalize.NetworkError("whispercpp", err)
+}
+
+// newDecodeError creates a ProviderError for JSON decode failures.
+func newDecodeError(err error) error {
+	return normalize.DecodeError("whispercpp", err)
+}
@@ -0,0 +1,89 @@
+package whispercpp
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// DefaultBaseURL is the address whisper.cpp's example server listens on by
+// default.
+const DefaultBaseURL = "http://127.0.0.1:8080"
+
+// DefaultInferencePath is the path of the server's transcription endpoint.
+const DefaultInferencePath = "/inference"
+
+// Config holds the configuration for the whisper.cpp provider.
+type Config struct {
+	// BaseURL is the server URL. Defaults to DefaultBaseURL.
+	BaseURL string
+
+	// InferencePath is the path of the transcription endpoint, relative to
+	// BaseURL. Defaults to DefaultInferencePath; servers started with
+	// --inference-path use a different one.
+	InferencePath string
+
+	// HTTPClient is the HTTP client to use. Defaults to a client with pooled
+	// connections shared by all providers. When set, Transport and Proxy are
+	// ignored.
+	HTTPClient *http.Client
+
+	// Transport replaces the default transport, for example to tune
+	// connection pooling or add instrumentation.
+	Transport http.RoundTripper
+
+	// Proxy routes requests through a proxy instead of the one configured by
+	// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY.
+	Proxy *url.URL
+
+	// Headers contains additional HTTP headers to include in requests, such
+	// as the credentials of an authenticating reverse proxy.
+	Headers http.Header
+}
+
+// Option is a function that configures the whisper.cpp provider.
+type Option func(*Config)
+
+// WithBaseURL sets the server URL.
+func WithBaseURL(url string) Option {
+	return func(c *Config) {
+		c.BaseURL = url
+	}
+}
+
+// WithInferencePath sets the path of the transcription endpoint.
+func WithInferencePath(path string) Option {
+	return func(c *Config) {
+		c.InferencePath = path
+	}
+}
+
+// WithHTTPClient sets a custom HTTP client.
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Config) {
+		c.HTTPClient = client
+	}
+}
+
+// WithTransport sets the transport used by the default HTTP client.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Config) {
+		c.Transport = rt
+	}
+}
+
+// WithProxy routes requests through the proxy at proxyURL.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Config) {
+		c.Proxy = proxyURL
+	}
+}
+
+// WithHeader adds an extra header to include in requests.
+func WithHeader(key, value string) Option {
+	return func(c *Config) {
+		if c.Headers == nil {
+			c.Headers = make(http.Header)
+		}
+		c.Headers.Set(key, value)
+	}
+}
@@ -0,0 +1,99 @@
+package whispercpp
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
+)
+
+// BaseURLEnvVar is the environment variable NewLocal and the registry
+// read the server URL from.
+const BaseURLEnvVar = "WHISPERCPP_BASE_URL"
+
+// WhisperCpp is a speech-to-text provider for a whisper.cpp server.
+// WhisperCpp is safe for concurrent use.
+type WhisperCpp struct {
+	config Config
+}
+
+// New creates a new whisper.cpp provider with the given options.
+func New(opts ...Option) *WhisperCpp {
+	cfg := Config{
+		BaseURL:       DefaultBaseURL,
+		InferencePath: DefaultInferencePath,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	cfg.BaseURL = strings.TrimSuffix(cfg.BaseURL, "/")
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+	}
+
+	return &WhisperCpp{config: cfg}
+}
+
+// NewLocal creates a new whisper.cpp provider for a local server. If
+// WHISPERCPP_BASE_URL is set, it uses that URL; otherwise it defaults to
+// DefaultBaseURL.
+func NewLocal(opts ...Option) *WhisperCpp {
+	baseOpts := make([]Option, 0, len(opts)+1)
+	if baseURL := os.Getenv(BaseURLEnvVar); baseURL != "" {
+		baseOpts = append(baseOpts, WithBaseURL(baseURL))
+	}
+	baseOpts = append(baseOpts, opts...)
+	return New(baseOpts...)
+}
+
+// ID returns the provider identifier.
+func (p *WhisperCpp) ID() string {
+	return "whispercpp"
+}
+
+// CloseIdleConnections closes the idle pooled connections of the provider's
+// HTTP client. core.Client.Close calls it during shutdown.
+func (p *WhisperCpp) CloseIdleConnections() {
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
+// Models returns nil: the server transcribes with whichever model it was
+// started with, so request models are ignored.
+func (p *WhisperCpp) Models() []core.ModelInfo {
+	return nil
+}
+
+// Supports reports whether the provider supports the given feature.
+func (p *WhisperCpp) Supports(feature core.Feature) bool {
+	return feature == core.FeatureTranscription
+}
+
+// Chat is not supported by whisper.cpp. Returns ErrNotSupported.
+func (p *WhisperCpp) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	return nil, &core.ProviderError{
+		Provider: "whispercpp",
+		Message:  "chat is not supported by whisper.cpp",
+		Err:      core.ErrNotSupported,
+	}
+}
+
+// StreamChat is not supported by whisper.cpp. Returns ErrNotSupported.
+func (p *WhisperCpp) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	return nil, &core.ProviderError{
+		Provider: "whispercpp",
+		Message:  "streaming chat is not supported by whisper.cpp",
+		Err:      core.ErrNotSupported,
+	}
+}
+
+// Compile-time checks that WhisperCpp implements Provider and Transcriber.
+var (
+	_ core.Provider    = (*WhisperCpp)(nil)
+	_ core.Transcriber = (*WhisperCpp)(nil)
+)
+
+// Compile-time check that WhisperCpp implements IdleConnectionCloser.
+var _ core.IdleConnectionCloser = (*WhisperCpp)(nil)
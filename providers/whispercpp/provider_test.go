@@ -0,0 +1,67 @@
+package whispercpp
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestNewDefaults(t *testing.T) {
+	p := New()
+	if p.config.BaseURL != DefaultBaseURL {
+		t.Errorf("BaseURL = %q, want %q", p.config.BaseURL, DefaultBaseURL)
+	}
+	if p.config.InferencePath != DefaultInferencePath {
+		t.Errorf("InferencePath = %q, want %q", p.config.InferencePath, DefaultInferencePath)
+	}
+	if p.config.HTTPClient == nil {
+		t.Error("HTTPClient is nil")
+	}
+}
+
+func TestNewLocalReadsEnv(t *testing.T) {
+	t.Setenv(BaseURLEnvVar, "http://gpu-box:9000/")
+
+	p := NewLocal()
+	if p.config.BaseURL != "http://gpu-box:9000" {
+		t.Errorf("BaseURL = %q, want http://gpu-box:9000", p.config.BaseURL)
+	}
+}
+
+func TestSupports(t *testing.T) {
+	p := New()
+	if !p.Supports(core.FeatureTranscription) {
+		t.Error("Supports(transcription) = false, want true")
+	}
+	if p.Supports(core.FeatureChat) {
+		t.Error("Supports(chat) = true, want false")
+	}
+}
+
+func TestChatNotSupported(t *testing.T) {
+	p := New()
+	if _, err := p.Chat(context.Background(), &core.ChatRequest{}); !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("Chat error = %v, want ErrNotSupported", err)
+	}
+	if _, err := p.StreamChat(context.Background(), &core.ChatRequest{}); !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("StreamChat error = %v, want ErrNotSupported", err)
+	}
+}
+
+func TestRegistry(t *testing.T) {
+	t.Setenv(BaseURLEnvVar, "")
+
+	p, err := core.NewProviderFromConfig(core.ProviderConfig{Name: "whispercpp", BaseURL: "http://localhost:9090"})
+	if err != nil {
+		t.Fatalf("NewProviderFromConfig() error = %v", err)
+	}
+	w, ok := p.(*WhisperCpp)
+	if !ok {
+		t.Fatalf("provider type = %T, want *WhisperCpp", p)
+	}
+	if w.config.BaseURL != "http://localhost:9090" {
+		t.Errorf("BaseURL = %q, want http://localhost:9090", w.config.BaseURL)
+	}
+}
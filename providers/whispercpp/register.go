@@ -0,0 +1,24 @@
+package whispercpp
+
+import (
+	"cmp"
+	"os"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers"
+)
+
+func init() {
+	// whisper.cpp doesn't require an API key, so we ignore the apiKey parameter.
+	providers.Register("whispercpp", func(apiKey string) core.Provider {
+		return NewLocal()
+	})
+
+	core.RegisterProvider("whispercpp", func(cfg core.ProviderConfig) (core.Provider, error) {
+		var opts []Option
+		if baseURL := cmp.Or(cfg.BaseURL, os.Getenv(BaseURLEnvVar)); baseURL != "" {
+			opts = append(opts, WithBaseURL(baseURL))
+		}
+		return New(opts...), nil
+	})
+}
@@ -0,0 +1,136 @@
+package whispercpp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// defaultFilename names the uploaded audio when the request does not.
+const defaultFilename = "audio.wav"
+
+// Transcribe sends the audio to the server's inference endpoint. The
+// request model is ignored: the server uses the model it was started with.
+func (p *WhisperCpp) Transcribe(ctx context.Context, req *core.TranscriptionRequest) (*core.TranscriptionResponse, error) {
+	if len(req.Audio) == 0 {
+		return nil, &core.ProviderError{
+			Provider: "whispercpp",
+			Message:  "transcription request has no audio",
+			Err:      core.ErrBadRequest,
+		}
+	}
+
+	body, contentType, err := buildForm(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build request: %w", err)
+	}
+
+	url := p.config.BaseURL + p.config.InferencePath
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range p.config.Headers {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+	httpReq.Header.Set("Content-Type", contentType)
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, respBody)
+	}
+
+	var result inferenceResponse
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		return nil, newDecodeError(err)
+	}
+	if result.Error != "" {
+		return nil, normalizeError(http.StatusBadRequest, respBody)
+	}
+	return mapResponse(&result), nil
+}
+
+// buildForm encodes req as the multipart form the inference endpoint
+// expects.
+func buildForm(req *core.TranscriptionRequest) (io.Reader, string, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	filename := req.Filename
+	if filename == "" {
+		filename = defaultFilename
+	}
+	part, err := w.CreateFormFile("file", filename)
+	if err != nil {
+		return nil, "", err
+	}
+	if _, err := part.Write(req.Audio); err != nil {
+		return nil, "", err
+	}
+
+	fields := [][2]string{{"response_format", "verbose_json"}}
+	if req.Language != "" {
+		fields = append(fields, [2]string{"language", req.Language})
+	}
+	if req.Prompt != "" {
+		fields = append(fields, [2]string{"prompt", req.Prompt})
+	}
+	if req.Temperature != nil {
+		fields = append(fields, [2]string{"temperature", strconv.FormatFloat(float64(*req.Temperature), 'f', -1, 32)})
+	}
+	if req.Translate {
+		fields = append(fields, [2]string{"translate", "true"})
+	}
+	for _, f := range fields {
+		if err := w.WriteField(f[0], f[1]); err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+	return &buf, w.FormDataContentType(), nil
+}
+
+// mapResponse converts an inference response to a core response.
+func mapResponse(resp *inferenceResponse) *core.TranscriptionResponse {
+	out := &core.TranscriptionResponse{
+		Text:     strings.TrimSpace(resp.Text),
+		Language: resp.Language,
+		Duration: seconds(resp.Duration),
+	}
+	for _, s := range resp.Segments {
+		out.Segments = append(out.Segments, core.TranscriptionSegment{
+			Start: seconds(s.Start),
+			End:   seconds(s.End),
+			Text:  strings.TrimSpace(s.Text),
+		})
+	}
+	return out
+}
+
+// seconds converts a duration in seconds to a time.Duration.
+func seconds(s float64) time.Duration {
+	return time.Duration(s * float64(time.Second))
+}
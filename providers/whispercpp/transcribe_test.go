@@ -0,0 +1,138 @@
+package whispercpp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestTranscribe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/inference" {
+			t.Errorf("Path = %q, want /inference", r.URL.Path)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatalf("ParseMultipartForm() error = %v", err)
+		}
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile() error = %v", err)
+		}
+		audio, _ := io.ReadAll(file)
+		if string(audio) != "RIFF" || header.Filename != "clip.wav" {
+			t.Errorf("file = %q (%s), want RIFF (clip.wav)", audio, header.Filename)
+		}
+		want := map[string]string{
+			"response_format": "verbose_json",
+			"language":        "de",
+			"prompt":          "Iris",
+			"temperature":     "0.2",
+			"translate":       "true",
+		}
+		for name, v := range want {
+			if got := r.FormValue(name); got != v {
+				t.Errorf("%s = %q, want %q", name, got, v)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{
+			"task": "translate",
+			"language": "german",
+			"duration": 3.5,
+			"text": " Hello from Iris.",
+			"segments": [
+				{"id": 0, "start": 0.0, "end": 1.5, "text": " Hello"},
+				{"id": 1, "start": 1.5, "end": 3.5, "text": " from Iris."}
+			]
+		}`)
+	}))
+	defer server.Close()
+
+	temp := float32(0.2)
+	resp, err := New(WithBaseURL(server.URL)).Transcribe(context.Background(), &core.TranscriptionRequest{
+		Audio:       []byte("RIFF"),
+		Filename:    "clip.wav",
+		Language:    "de",
+		Prompt:      "Iris",
+		Temperature: &temp,
+		Translate:   true,
+	})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if resp.Text != "Hello from Iris." {
+		t.Errorf("Text = %q, want %q", resp.Text, "Hello from Iris.")
+	}
+	if resp.Language != "german" || resp.Duration != 3500*time.Millisecond {
+		t.Errorf("Language, Duration = %q, %v, want german, 3.5s", resp.Language, resp.Duration)
+	}
+	if len(resp.Segments) != 2 {
+		t.Fatalf("len(Segments) = %d, want 2", len(resp.Segments))
+	}
+	if s := resp.Segments[1]; s.Start != 1500*time.Millisecond || s.End != 3500*time.Millisecond || s.Text != "from Iris." {
+		t.Errorf("Segments[1] = %+v", s)
+	}
+}
+
+func TestTranscribeInferencePath(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/audio" {
+			t.Errorf("Path = %q, want /v1/audio", r.URL.Path)
+		}
+		if r.FormValue("language") != "" {
+			t.Errorf("language = %q, want unset", r.FormValue("language"))
+		}
+		io.WriteString(w, `{"text": "hi"}`)
+	}))
+	defer server.Close()
+
+	p := New(WithBaseURL(server.URL), WithInferencePath("/v1/audio"))
+	resp, err := p.Transcribe(context.Background(), &core.TranscriptionRequest{Audio: []byte("RIFF")})
+	if err != nil {
+		t.Fatalf("Transcribe() error = %v", err)
+	}
+	if resp.Text != "hi" {
+		t.Errorf("Text = %q, want hi", resp.Text)
+	}
+}
+
+func TestTranscribeErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{"server error", http.StatusInternalServerError, `{"error": "failed to load model"}`, core.ErrServer},
+		{"error with status 200", http.StatusOK, `{"error": "failed to read WAV file"}`, core.ErrBadRequest},
+		{"plain text", http.StatusBadRequest, "bad request", core.ErrBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			defer server.Close()
+
+			_, err := New(WithBaseURL(server.URL)).Transcribe(context.Background(), &core.TranscriptionRequest{Audio: []byte("RIFF")})
+			if !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTranscribeNoAudio(t *testing.T) {
+	_, err := New().Transcribe(context.Background(), &core.TranscriptionRequest{})
+	if !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("error = %v, want ErrBadRequest", err)
+	}
+}
@@ -0,0 +1,20 @@
+package whispercpp
+
+// inferenceResponse is the verbose_json response of the inference endpoint.
+// Durations are in seconds.
+type inferenceResponse struct {
+	// Error is set, with status 200, by servers that fail to decode the
+	// audio.
+	Error    string    `json:"error,omitempty"`
+	Text     string    `json:"text"`
+	Language string    `json:"language,omitempty"`
+	Duration float64   `json:"duration,omitempty"`
+	Segments []segment `json:"segments,omitempty"`
+}
+
+// segment is a timed part of an inferenceResponse.
+type segment struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Text  string  `json:"text"`
+}
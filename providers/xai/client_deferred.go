@@ -0,0 +1,112 @@
+package xai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// deferredCompletionPath is the API endpoint for deferred completion results.
+const deferredCompletionPath = "/chat/deferred-completion/"
+
+// StartBackground submits a deferred chat completion and returns a queued
+// response whose ID is the xAI request ID. xAI keeps the result for 24
+// hours.
+func (p *Xai) StartBackground(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	xaiReq := buildRequest(req, false)
+	xaiReq.Deferred = true
+
+	body, err := json.Marshal(xaiReq)
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	_, respBody, err := p.doDeferredRequest(ctx, http.MethodPost, chatCompletionsPath, body)
+	if err != nil {
+		return nil, err
+	}
+
+	var deferred xaiDeferredResponse
+	if err := json.Unmarshal(respBody, &deferred); err != nil {
+		return nil, newDecodeError(err)
+	}
+	return &core.ChatResponse{
+		ID:     deferred.RequestID,
+		Model:  req.Model,
+		Status: core.ResponseStatusQueued,
+	}, nil
+}
+
+// RetrieveBackground returns the deferred completion for the request ID, or
+// an in-progress response while it is still running. The completed response
+// keeps the request ID as its ID.
+func (p *Xai) RetrieveBackground(ctx context.Context, id string) (*core.ChatResponse, error) {
+	status, respBody, err := p.doDeferredRequest(ctx, http.MethodGet, deferredCompletionPath+url.PathEscape(id), nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusAccepted {
+		return &core.ChatResponse{ID: id, Status: core.ResponseStatusInProgress}, nil
+	}
+
+	var xaiResp xaiResponse
+	if err := json.Unmarshal(respBody, &xaiResp); err != nil {
+		return nil, newDecodeError(err)
+	}
+	result, err := mapResponse(&xaiResp)
+	if err != nil {
+		return nil, err
+	}
+	result.ID = id
+	result.Status = core.ResponseStatusCompleted
+	return result, nil
+}
+
+// CancelBackground is not supported: xAI cannot cancel deferred completions.
+// Returns ErrNotSupported.
+func (p *Xai) CancelBackground(ctx context.Context, id string) (*core.ChatResponse, error) {
+	return nil, &core.ProviderError{
+		Provider: "xai",
+		Message:  "deferred completions cannot be cancelled",
+		Err:      core.ErrNotSupported,
+	}
+}
+
+// doDeferredRequest sends a request for a deferred completion and returns
+// the status code and body of a successful response.
+func (p *Xai) doDeferredRequest(ctx context.Context, method, path string, body []byte) (int, []byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, method, p.config.BaseURL+path, reader)
+	if err != nil {
+		return 0, nil, newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return 0, nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, newNetworkError(err)
+	}
+	if resp.StatusCode >= 400 {
+		return 0, nil, normalizeError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+	return resp.StatusCode, respBody, nil
+}
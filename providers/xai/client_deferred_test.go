@@ -0,0 +1,94 @@
+package xai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func TestBackgroundDeferredCompletion(t *testing.T) {
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/chat/completions":
+			var req map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			if req["deferred"] != true {
+				t.Errorf("deferred = %v, want true", req["deferred"])
+			}
+			json.NewEncoder(w).Encode(xaiDeferredResponse{RequestID: "req-42"})
+
+		case r.Method == http.MethodGet && r.URL.Path == "/chat/deferred-completion/req-42":
+			if polls.Add(1) == 1 {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			json.NewEncoder(w).Encode(xaiResponse{
+				ID:      "chatcmpl-1",
+				Model:   "grok-4",
+				Choices: []xaiChoice{{Message: xaiRespMsg{Role: "assistant", Content: "Done."}}},
+				Usage:   xaiUsage{PromptTokens: 5, CompletionTokens: 2, TotalTokens: 7},
+			})
+
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := core.NewClient(New("test-key", WithBaseURL(server.URL)))
+	h, err := client.Chat(ModelGrok4).User("Think hard.").Background(context.Background())
+	if err != nil {
+		t.Fatalf("Background() error = %v", err)
+	}
+	if h.ID != "req-42" {
+		t.Errorf("ID = %q, want req-42", h.ID)
+	}
+
+	h.PollInterval = time.Millisecond
+	resp, err := h.Wait(context.Background())
+	if err != nil {
+		t.Fatalf("Wait() error = %v", err)
+	}
+	if resp.Output != "Done." || resp.Status != core.ResponseStatusCompleted || resp.ID != "req-42" {
+		t.Errorf("resp = %+v", resp)
+	}
+	if resp.Usage.TotalTokens != 7 {
+		t.Errorf("TotalTokens = %d, want 7", resp.Usage.TotalTokens)
+	}
+	if polls.Load() != 2 {
+		t.Errorf("polls = %d, want 2", polls.Load())
+	}
+}
+
+func TestRetrieveBackgroundError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"deferred completion not found"}`))
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	_, err := p.RetrieveBackground(context.Background(), "expired")
+	var pe *core.ProviderError
+	if !errors.As(err, &pe) || pe.Status != http.StatusNotFound {
+		t.Errorf("error = %v, want ProviderError with status 404", err)
+	}
+}
+
+func TestCancelBackgroundNotSupported(t *testing.T) {
+	p := New("test-key")
+	if _, err := p.CancelBackground(context.Background(), "req-42"); !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("error = %v, want ErrNotSupported", err)
+	}
+}
@@ -0,0 +1,78 @@
+package xai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// imageGenerationsPath is the API endpoint for image generation.
+const imageGenerationsPath = "/images/generations"
+
+// GenerateImage generates images from a text prompt with a Grok image model.
+// Images are returned base64-encoded unless ResponseFormat is "url". The
+// xAI image API does not accept size, quality, format, or background
+// settings; they are ignored.
+func (p *Xai) GenerateImage(ctx context.Context, req *core.ImageGenerateRequest) (*core.ImageResponse, error) {
+	body, err := json.Marshal(mapImageRequest(req))
+	if err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	url := p.config.BaseURL + imageGenerationsPath
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	for key, values := range p.buildHeaders() {
+		for _, v := range values {
+			httpReq.Header.Add(key, v)
+		}
+	}
+
+	resp, err := p.config.HTTPClient.Do(httpReq)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, newNetworkError(err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return nil, normalizeError(resp.StatusCode, respBody, resp.Header.Get("x-request-id"))
+	}
+
+	var xaiResp xaiImageResponse
+	if err := json.Unmarshal(respBody, &xaiResp); err != nil {
+		return nil, newDecodeError(err)
+	}
+
+	return mapImageResponse(&xaiResp), nil
+}
+
+// EditImage is not supported by xAI.
+func (p *Xai) EditImage(ctx context.Context, req *core.ImageEditRequest) (*core.ImageResponse, error) {
+	return nil, &core.ProviderError{
+		Provider: "xai",
+		Code:     "not_supported",
+		Message:  "xAI does not support image editing",
+		Err:      core.ErrNotSupported,
+	}
+}
+
+// StreamImage generates images and delivers them as an ImageStream. The
+// xAI image API does not stream, so the stream carries no partial images,
+// only the final response.
+func (p *Xai) StreamImage(ctx context.Context, req *core.ImageGenerateRequest) (*core.ImageStream, error) {
+	return core.ImageStreamFromFunc(ctx, func(ctx context.Context) (*core.ImageResponse, error) {
+		return p.GenerateImage(ctx, req)
+	}), nil
+}
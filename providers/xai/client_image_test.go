@@ -0,0 +1,103 @@
+package xai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+)
+
+func newImageServer(t *testing.T, status int, body any) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/images/generations" {
+			t.Errorf("Path = %q, want /images/generations", r.URL.Path)
+		}
+		var req xaiImageRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		if req.Model != "grok-2-image" || req.Prompt != "A cat" || req.ResponseFormat != "b64_json" {
+			t.Errorf("request = %+v", req)
+		}
+		w.WriteHeader(status)
+		json.NewEncoder(w).Encode(body)
+	}))
+}
+
+func TestGenerateImage(t *testing.T) {
+	server := newImageServer(t, http.StatusOK, xaiImageResponse{
+		Data: []xaiImageData{{B64JSON: "aW1hZ2U=", RevisedPrompt: "A fluffy cat"}},
+	})
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	resp, err := p.GenerateImage(context.Background(), &core.ImageGenerateRequest{
+		Model:  ModelGrok2Image,
+		Prompt: "A cat",
+	})
+	if err != nil {
+		t.Fatalf("GenerateImage() error = %v", err)
+	}
+	if len(resp.Data) != 1 || resp.Data[0].RevisedPrompt != "A fluffy cat" {
+		t.Fatalf("Data = %+v", resp.Data)
+	}
+	if data, _ := resp.Data[0].GetBytes(); string(data) != "image" {
+		t.Errorf("GetBytes() = %q, want image", data)
+	}
+}
+
+func TestGenerateImageError(t *testing.T) {
+	server := newImageServer(t, http.StatusBadRequest, map[string]any{
+		"error": map[string]any{"message": "bad prompt"},
+	})
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	_, err := p.GenerateImage(context.Background(), &core.ImageGenerateRequest{
+		Model:  ModelGrok2Image,
+		Prompt: "A cat",
+	})
+	if !errors.Is(err, core.ErrBadRequest) {
+		t.Errorf("GenerateImage() error = %v, want ErrBadRequest", err)
+	}
+}
+
+func TestStreamImage(t *testing.T) {
+	server := newImageServer(t, http.StatusOK, xaiImageResponse{
+		Data: []xaiImageData{{B64JSON: "aW1hZ2U="}},
+	})
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	stream, err := p.StreamImage(context.Background(), &core.ImageGenerateRequest{
+		Model:  ModelGrok2Image,
+		Prompt: "A cat",
+	})
+	if err != nil {
+		t.Fatalf("StreamImage() error = %v", err)
+	}
+
+	for range stream.Ch {
+		t.Error("unexpected partial image")
+	}
+	if err := <-stream.Err; err != nil {
+		t.Fatalf("stream error = %v", err)
+	}
+	final := <-stream.Final
+	if final == nil || len(final.Data) != 1 {
+		t.Fatalf("Final = %+v", final)
+	}
+}
+
+func TestEditImageNotSupported(t *testing.T) {
+	p := New("test-key")
+	_, err := p.EditImage(context.Background(), &core.ImageEditRequest{Model: ModelGrok2Image, Prompt: "x"})
+	if !errors.Is(err, core.ErrNotSupported) {
+		t.Errorf("EditImage() error = %v, want ErrNotSupported", err)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
+	"github.com/petal-labs/iris/providers/internal/toolchoice"
 	"github.com/petal-labs/iris/tools"
 )
 
@@ -20,11 +22,41 @@ func mapMessages(msgs []core.Message) []xaiMessage {
 		result[i] = xaiMessage{
 			Role:    string(msg.Role),
 			Content: msg.Content,
+			Parts:   mapContentParts(msg),
 		}
 	}
 	return result
 }
 
+// mapContentParts converts the multimodal parts of a message to content
+// parts, with Content as leading text. xAI accepts images by URL or data
+// URL; images by file ID and other input types are skipped.
+func mapContentParts(msg core.Message) []xaiContentPart {
+	if len(msg.Parts) == 0 {
+		return nil
+	}
+
+	parts := make([]xaiContentPart, 0, len(msg.Parts)+1)
+	if msg.Content != "" {
+		parts = append(parts, xaiContentPart{Type: "text", Text: msg.Content})
+	}
+	for _, part := range msg.Parts {
+		switch p := part.(type) {
+		case *core.InputText:
+			parts = append(parts, xaiContentPart{Type: "text", Text: p.Text})
+		case *core.InputImage:
+			if p.ImageURL == "" {
+				continue
+			}
+			parts = append(parts, xaiContentPart{
+				Type:     "image_url",
+				ImageURL: &xaiImageURL{URL: p.ImageURL, Detail: string(p.Detail)},
+			})
+		}
+	}
+	return parts
+}
+
 // mapTools converts Iris tools to xAI tool format.
 // Tools that implement schemaProvider will have their schema included.
 func mapTools(irisTools []core.Tool) []xaiTool {
@@ -92,7 +124,8 @@ func buildRequest(req *core.ChatRequest, stream bool) *xaiRequest {
 	// Map tools if present
 	if len(req.Tools) > 0 {
 		xaiReq.Tools = mapTools(req.Tools)
-		xaiReq.ToolChoice = "auto"
+		xaiReq.ToolChoice = toolchoice.OpenAI(req.ToolChoice)
+		xaiReq.ParallelToolCalls = req.ParallelToolCalls
 	}
 
 	// Map reasoning effort if set
@@ -100,9 +133,73 @@ func buildRequest(req *core.ChatRequest, stream bool) *xaiRequest {
 		xaiReq.ReasoningEffort = mapReasoningEffort(req.ReasoningEffort)
 	}
 
+	xaiReq.ResponseFormat = mapResponseFormat(req)
+
+	// Map the web_search built-in tool to Live Search
+	for _, t := range req.BuiltInTools {
+		if t.Type == "web_search" {
+			xaiReq.SearchParameters = mapSearchParameters(t.WebSearch)
+			break
+		}
+	}
+
 	return xaiReq
 }
 
+// mapResponseFormat converts the Iris response format to response_format.
+func mapResponseFormat(req *core.ChatRequest) *xaiResponseFormat {
+	switch req.ResponseFormat {
+	case core.ResponseFormatJSON:
+		return &xaiResponseFormat{Type: "json_object"}
+	case core.ResponseFormatJSONSchema:
+		if req.JSONSchema == nil {
+			return nil
+		}
+		return &xaiResponseFormat{
+			Type: "json_schema",
+			JSONSchema: &xaiJSONSchema{
+				Name:        req.JSONSchema.Name,
+				Description: req.JSONSchema.Description,
+				Schema:      req.JSONSchema.Schema,
+				Strict:      req.JSONSchema.Strict,
+			},
+		}
+	default:
+		// ResponseFormatText or empty: no response_format constraint
+		return nil
+	}
+}
+
+// searchResultsByContextSize maps web search context sizes to Live Search
+// result limits. 20 is the API default.
+var searchResultsByContextSize = map[core.WebSearchContextSize]int{
+	core.WebSearchContextLow:    5,
+	core.WebSearchContextMedium: 10,
+	core.WebSearchContextHigh:   20,
+}
+
+// mapSearchParameters converts web search options to Live Search parameters.
+// Search is always on, since the caller asked for it. Only the user's
+// country is used from UserLocation.
+func mapSearchParameters(opts *core.WebSearchOptions) *xaiSearchParameters {
+	params := &xaiSearchParameters{Mode: "on"}
+	if opts == nil {
+		return params
+	}
+
+	web := xaiSearchSource{
+		Type:             "web",
+		AllowedWebsites:  opts.AllowedDomains,
+		ExcludedWebsites: opts.BlockedDomains,
+	}
+	if opts.UserLocation != nil {
+		web.Country = opts.UserLocation.Country
+	}
+	params.Sources = []xaiSearchSource{web}
+	params.MaxSearchResults = searchResultsByContextSize[opts.ContextSize]
+	return params
+}
+
 // mapResponse converts an xAI response to an Iris ChatResponse.
 func mapResponse(resp *xaiResponse) (*core.ChatResponse, error) {
 	result := &core.ChatResponse{
@@ -118,6 +215,8 @@ func mapResponse(resp *xaiResponse) (*core.ChatResponse, error) {
 	// Extract content from first choice
 	if len(resp.Choices) > 0 {
 		choice := resp.Choices[0]
+		result.FinishReason = normalize.FinishReason(choice.FinishReason)
+		result.RawFinishReason = choice.FinishReason
 		result.Output = choice.Message.Content
 
 		// Map reasoning content if present (grok-3-mini)
@@ -159,3 +258,34 @@ func mapToolCalls(calls []xaiToolCall) ([]core.ToolCall, error) {
 
 	return result, nil
 }
+
+// mapImageRequest converts a core image request to xAI format.
+func mapImageRequest(req *core.ImageGenerateRequest) *xaiImageRequest {
+	r := &xaiImageRequest{
+		Model:          string(req.Model),
+		Prompt:         req.Prompt,
+		N:              req.N,
+		ResponseFormat: req.ResponseFormat,
+		User:           req.User,
+	}
+	if r.ResponseFormat == "" {
+		r.ResponseFormat = "b64_json"
+	}
+	return r
+}
+
+// mapImageResponse converts an xAI image response to core format.
+func mapImageResponse(resp *xaiImageResponse) *core.ImageResponse {
+	r := &core.ImageResponse{
+		Created: resp.Created,
+		Data:    make([]core.ImageData, len(resp.Data)),
+	}
+	for i, d := range resp.Data {
+		r.Data[i] = core.ImageData{
+			B64JSON:       d.B64JSON,
+			URL:           d.URL,
+			RevisedPrompt: d.RevisedPrompt,
+		}
+	}
+	return r
+}
@@ -1,6 +1,7 @@
 package xai
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/petal-labs/iris/core"
@@ -198,3 +199,110 @@ func TestMapToolsBasic(t *testing.T) {
 		t.Errorf("Function.Parameters = %s, want {}", result[0].Function.Parameters)
 	}
 }
+
+func TestBuildRequestWebSearch(t *testing.T) {
+	req := &core.ChatRequest{
+		Model:        ModelGrok4,
+		Messages:     []core.Message{{Role: core.RoleUser, Content: "News?"}},
+		BuiltInTools: []core.BuiltInTool{{Type: "web_search"}},
+	}
+	params := buildRequest(req, false).SearchParameters
+	if params == nil || params.Mode != "on" || len(params.Sources) != 0 {
+		t.Errorf("SearchParameters = %+v, want mode on with default sources", params)
+	}
+
+	req.BuiltInTools = []core.BuiltInTool{{Type: "web_search", WebSearch: &core.WebSearchOptions{
+		BlockedDomains: []string{"example.com"},
+		UserLocation:   &core.WebSearchLocation{Country: "DE", City: "Berlin"},
+		ContextSize:    core.WebSearchContextLow,
+	}}}
+	params = buildRequest(req, false).SearchParameters
+	if params == nil || len(params.Sources) != 1 {
+		t.Fatalf("SearchParameters = %+v, want one source", params)
+	}
+	web := params.Sources[0]
+	if web.Type != "web" || web.Country != "DE" || len(web.ExcludedWebsites) != 1 || web.ExcludedWebsites[0] != "example.com" {
+		t.Errorf("source = %+v", web)
+	}
+	if params.MaxSearchResults != 5 {
+		t.Errorf("MaxSearchResults = %d, want 5", params.MaxSearchResults)
+	}
+}
+
+func TestBuildRequestWithoutWebSearch(t *testing.T) {
+	req := &core.ChatRequest{
+		Model:        ModelGrok4,
+		Messages:     []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+		BuiltInTools: []core.BuiltInTool{{Type: "code_interpreter"}},
+	}
+	if params := buildRequest(req, false).SearchParameters; params != nil {
+		t.Errorf("SearchParameters = %+v, want nil", params)
+	}
+}
+
+func TestBuildRequestResponseFormat(t *testing.T) {
+	schema := json.RawMessage(`{"type":"object","properties":{"name":{"type":"string"}}}`)
+	tests := []struct {
+		name string
+		req  *core.ChatRequest
+		want *xaiResponseFormat
+	}{
+		{"text", &core.ChatRequest{ResponseFormat: core.ResponseFormatText}, nil},
+		{"json", &core.ChatRequest{ResponseFormat: core.ResponseFormatJSON}, &xaiResponseFormat{Type: "json_object"}},
+		{"schema without definition", &core.ChatRequest{ResponseFormat: core.ResponseFormatJSONSchema}, nil},
+		{
+			"schema",
+			&core.ChatRequest{
+				ResponseFormat: core.ResponseFormatJSONSchema,
+				JSONSchema:     &core.JSONSchemaDefinition{Name: "person", Schema: schema, Strict: true},
+			},
+			&xaiResponseFormat{Type: "json_schema", JSONSchema: &xaiJSONSchema{Name: "person", Schema: schema, Strict: true}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := json.Marshal(buildRequest(tt.req, false).ResponseFormat)
+			want, _ := json.Marshal(tt.want)
+			if string(got) != string(want) {
+				t.Errorf("ResponseFormat = %s, want %s", got, want)
+			}
+		})
+	}
+}
+
+func TestMapMessagesImageParts(t *testing.T) {
+	msg := core.Message{
+		Role:    core.RoleUser,
+		Content: "What is in these images?",
+		Parts: []core.ContentPart{
+			&core.InputImage{ImageURL: "https://example.com/cat.jpg", Detail: core.ImageDetailHigh},
+			&core.InputImage{ImageURL: "data:image/png;base64,iVBORw0KGgo="},
+			&core.InputImage{FileID: "file-123"},
+			&core.InputText{Text: "Answer briefly."},
+		},
+	}
+
+	body, err := json.Marshal(mapMessages([]core.Message{msg})[0])
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	want := `{"role":"user","content":[` +
+		`{"type":"text","text":"What is in these images?"},` +
+		`{"type":"image_url","image_url":{"url":"https://example.com/cat.jpg","detail":"high"}},` +
+		`{"type":"image_url","image_url":{"url":"data:image/png;base64,iVBORw0KGgo="}},` +
+		`{"type":"text","text":"Answer briefly."}]}`
+	if string(body) != want {
+		t.Errorf("message =\n%s\nwant\n%s", body, want)
+	}
+}
+
+func TestMapMessagesTextOnlyContent(t *testing.T) {
+	body, err := json.Marshal(mapMessages([]core.Message{{Role: core.RoleUser, Content: "Hi"}})[0])
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if string(body) != `{"role":"user","content":"Hi"}` {
+		t.Errorf("message = %s", body)
+	}
+}
@@ -20,6 +20,12 @@ const (
 	// Grok 4.1 series
 	ModelGrok41FastNonReasoning core.ModelID = "grok-4-1-fast-non-reasoning"
 	ModelGrok41FastReasoning    core.ModelID = "grok-4-1-fast-reasoning"
+
+	// Vision
+	ModelGrok2Vision core.ModelID = "grok-2-vision-1212"
+
+	// Image generation
+	ModelGrok2Image core.ModelID = "grok-2-image"
 )
 
 // models is the static list of supported models.
@@ -33,6 +39,7 @@ var models = []core.ModelInfo{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
 			core.FeatureToolCalling,
+			core.FeatureBuiltInTools,
 			core.FeatureReasoning,
 		},
 	},
@@ -44,6 +51,7 @@ var models = []core.ModelInfo{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
 			core.FeatureToolCalling,
+			core.FeatureBuiltInTools,
 			core.FeatureReasoning,
 		},
 	},
@@ -56,7 +64,9 @@ var models = []core.ModelInfo{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
 			core.FeatureToolCalling,
+			core.FeatureBuiltInTools,
 			core.FeatureReasoning,
+			core.FeatureVision,
 		},
 	},
 	{
@@ -67,6 +77,8 @@ var models = []core.ModelInfo{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
 			core.FeatureToolCalling,
+			core.FeatureBuiltInTools,
+			core.FeatureVision,
 		},
 	},
 	{
@@ -77,7 +89,9 @@ var models = []core.ModelInfo{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
 			core.FeatureToolCalling,
+			core.FeatureBuiltInTools,
 			core.FeatureReasoning,
+			core.FeatureVision,
 		},
 	},
 	// Grok Code
@@ -89,6 +103,7 @@ var models = []core.ModelInfo{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
 			core.FeatureToolCalling,
+			core.FeatureBuiltInTools,
 		},
 	},
 	// Grok 4.1 series
@@ -100,6 +115,8 @@ var models = []core.ModelInfo{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
 			core.FeatureToolCalling,
+			core.FeatureBuiltInTools,
+			core.FeatureVision,
 		},
 	},
 	{
@@ -110,7 +127,29 @@ var models = []core.ModelInfo{
 			core.FeatureChat,
 			core.FeatureChatStreaming,
 			core.FeatureToolCalling,
+			core.FeatureBuiltInTools,
 			core.FeatureReasoning,
+			core.FeatureVision,
+		},
+	},
+	// Vision
+	{
+		ID:          ModelGrok2Vision,
+		DisplayName: "Grok 2 Vision",
+		APIEndpoint: core.APIEndpointCompletions,
+		Capabilities: []core.Feature{
+			core.FeatureChat,
+			core.FeatureChatStreaming,
+			core.FeatureToolCalling,
+			core.FeatureVision,
+		},
+	},
+	// Image generation
+	{
+		ID:          ModelGrok2Image,
+		DisplayName: "Grok 2 Image",
+		Capabilities: []core.Feature{
+			core.FeatureImageGeneration,
 		},
 	},
 }
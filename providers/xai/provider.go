@@ -5,8 +5,10 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 // DefaultAPIKeyEnvVar is the environment variable name for the xAI API key.
@@ -40,14 +42,24 @@ type Xai struct {
 // New creates a new xAI provider with the given API key and options.
 func New(apiKey string, opts ...Option) *Xai {
 	cfg := Config{
-		APIKey:     core.NewSecret(apiKey),
-		BaseURL:    DefaultBaseURL,
-		HTTPClient: http.DefaultClient,
+		APIKey:  core.NewSecret(apiKey),
+		BaseURL: DefaultBaseURL,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+	}
+	cfg.HTTPClient = httpclient.WithRewrite(cfg.HTTPClient, httpclient.Rewrite{
+		PathPrefix: cfg.PathPrefix,
+		Query:      cfg.QueryParams,
+		Editors:    cfg.RequestEditors,
+	})
+	if cfg.Credentials != nil {
+		cfg.HTTPClient = httpclient.WithCredentials(cfg.HTTPClient, cfg.Credentials, httpclient.BearerAuth)
+	}
 
 	return &Xai{config: cfg}
 }
@@ -57,6 +69,12 @@ func (p *Xai) ID() string {
 	return "xai"
 }
 
+// CloseIdleConnections closes the idle pooled connections of the provider's
+// HTTP client. core.Client.Close calls it during shutdown.
+func (p *Xai) CloseIdleConnections() {
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
 // Models returns the list of available models.
 func (p *Xai) Models() []core.ModelInfo {
 	// Return a copy to prevent mutation
@@ -65,10 +83,17 @@ func (p *Xai) Models() []core.ModelInfo {
 	return result
 }
 
+// DefaultTimeout returns the timeout set with WithTimeout. core.Client
+// applies it to requests that have no deadline or Timeout of their own.
+func (p *Xai) DefaultTimeout() time.Duration {
+	return p.config.Timeout
+}
+
 // Supports reports whether the provider supports the given feature.
 func (p *Xai) Supports(feature core.Feature) bool {
 	switch feature {
-	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning:
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning,
+		core.FeatureBuiltInTools, core.FeatureImageGeneration, core.FeatureStructuredOutput, core.FeatureVision:
 		return true
 	default:
 		return false
@@ -103,5 +128,16 @@ func (p *Xai) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.Chat
 	return p.doStreamChat(ctx, req)
 }
 
-// Compile-time check that Xai implements Provider.
-var _ core.Provider = (*Xai)(nil)
+// Compile-time checks that Xai implements Provider, ImageGenerator, and
+// BackgroundProvider.
+var (
+	_ core.Provider           = (*Xai)(nil)
+	_ core.ImageGenerator     = (*Xai)(nil)
+	_ core.BackgroundProvider = (*Xai)(nil)
+)
+
+// Compile-time check that Xai implements TimeoutProvider.
+var _ core.TimeoutProvider = (*Xai)(nil)
+
+// Compile-time check that Xai implements IdleConnectionCloser.
+var _ core.IdleConnectionCloser = (*Xai)(nil)
@@ -65,7 +65,10 @@ func TestModelsHaveCapabilities(t *testing.T) {
 			t.Errorf("Model %s has no capabilities", m.ID)
 		}
 
-		// All xAI models should support chat
+		// All xAI models except image models should support chat
+		if m.HasCapability(core.FeatureImageGeneration) {
+			continue
+		}
 		hasChat := false
 		for _, cap := range m.Capabilities {
 			if cap == core.FeatureChat {
@@ -111,6 +114,14 @@ func TestSupportsReasoning(t *testing.T) {
 	}
 }
 
+func TestSupportsImageGeneration(t *testing.T) {
+	p := New("test-key")
+
+	if !p.Supports(core.FeatureImageGeneration) {
+		t.Error("Supports(FeatureImageGeneration) = false, want true")
+	}
+}
+
 func TestSupportsUnknownFeature(t *testing.T) {
 	p := New("test-key")
 
@@ -11,6 +11,7 @@ import (
 	"strings"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
 	"github.com/petal-labs/iris/providers/internal/toolcalls"
 )
 
@@ -122,6 +123,7 @@ func (p *Xai) processSSEStream(
 	var responseID string
 	var responseModel string
 	var usage *xaiUsage
+	var finishReason string
 
 	for {
 		// Check for context cancellation
@@ -182,6 +184,10 @@ func (p *Xai) processSSEStream(
 
 		// Process choices
 		for _, choice := range chunk.Choices {
+			if choice.FinishReason != nil && *choice.FinishReason != "" {
+				finishReason = *choice.FinishReason
+			}
+
 			// Emit content delta
 			if choice.Delta.Content != "" {
 				select {
@@ -208,9 +214,11 @@ func (p *Xai) processSSEStream(
 
 	// Build final response
 	finalResp := &core.ChatResponse{
-		ID:        responseID,
-		Model:     core.ModelID(responseModel),
-		ToolCalls: toolCalls,
+		ID:              responseID,
+		Model:           core.ModelID(responseModel),
+		ToolCalls:       toolCalls,
+		FinishReason:    normalize.FinishReason(finishReason),
+		RawFinishReason: finishReason,
 	}
 
 	if usage != nil {
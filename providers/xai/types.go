@@ -4,21 +4,85 @@ import "encoding/json"
 
 // xaiRequest represents a request to the xAI chat completions API.
 type xaiRequest struct {
-	Model           string       `json:"model"`
-	Messages        []xaiMessage `json:"messages"`
-	Temperature     *float32     `json:"temperature,omitempty"`
-	MaxTokens       *int         `json:"max_tokens,omitempty"`
-	Stream          bool         `json:"stream"`
-	Tools           []xaiTool    `json:"tools,omitempty"`
-	ToolChoice      any          `json:"tool_choice,omitempty"`
-	ReasoningEffort string       `json:"reasoning_effort,omitempty"`
+	Model             string       `json:"model"`
+	Messages          []xaiMessage `json:"messages"`
+	Temperature       *float32     `json:"temperature,omitempty"`
+	MaxTokens         *int         `json:"max_tokens,omitempty"`
+	Stream            bool         `json:"stream"`
+	Tools             []xaiTool    `json:"tools,omitempty"`
+	ToolChoice        any          `json:"tool_choice,omitempty"`
+	ParallelToolCalls *bool        `json:"parallel_tool_calls,omitempty"`
+	ReasoningEffort   string       `json:"reasoning_effort,omitempty"`
+
+	ResponseFormat   *xaiResponseFormat   `json:"response_format,omitempty"`
+	SearchParameters *xaiSearchParameters `json:"search_parameters,omitempty"`
+
+	// Deferred queues the request and returns a request ID to poll for the
+	// completion instead of the completion itself.
+	Deferred bool `json:"deferred,omitempty"`
+}
+
+// xaiResponseFormat represents the response_format parameter.
+type xaiResponseFormat struct {
+	Type       string         `json:"type"` // "json_object" or "json_schema"
+	JSONSchema *xaiJSONSchema `json:"json_schema,omitempty"`
+}
+
+// xaiJSONSchema is the schema of a json_schema response format.
+type xaiJSONSchema struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	Schema      json.RawMessage `json:"schema"`
+	Strict      bool            `json:"strict,omitempty"`
+}
+
+// xaiSearchParameters configures Live Search.
+type xaiSearchParameters struct {
+	Mode             string            `json:"mode"` // "off", "auto", "on"
+	Sources          []xaiSearchSource `json:"sources,omitempty"`
+	MaxSearchResults int               `json:"max_search_results,omitempty"`
+}
+
+// xaiSearchSource is a Live Search data source.
+type xaiSearchSource struct {
+	Type             string   `json:"type"` // "web", "news", "x", "rss"
+	Country          string   `json:"country,omitempty"`
+	AllowedWebsites  []string `json:"allowed_websites,omitempty"`
+	ExcludedWebsites []string `json:"excluded_websites,omitempty"`
 }
 
 // xaiMessage represents a message in the xAI format.
 type xaiMessage struct {
-	Role       string `json:"role"`
-	Content    string `json:"content"`
-	ToolCallID string `json:"tool_call_id,omitempty"`
+	Role       string           `json:"role"`
+	Content    string           `json:"content"`
+	Parts      []xaiContentPart `json:"-"` // Multimodal content; replaces Content when set
+	ToolCallID string           `json:"tool_call_id,omitempty"`
+}
+
+// MarshalJSON encodes content as an array of parts when Parts is set.
+func (m xaiMessage) MarshalJSON() ([]byte, error) {
+	type alias xaiMessage
+	if len(m.Parts) == 0 {
+		return json.Marshal(alias(m))
+	}
+	return json.Marshal(struct {
+		alias
+		Content []xaiContentPart `json:"content"`
+	}{alias: alias(m), Content: m.Parts})
+}
+
+// xaiContentPart is a multimodal content part: text, or an image by URL or
+// data URL.
+type xaiContentPart struct {
+	Type     string       `json:"type"` // "text" or "image_url"
+	Text     string       `json:"text,omitempty"`
+	ImageURL *xaiImageURL `json:"image_url,omitempty"`
+}
+
+// xaiImageURL references an image by URL or data URL.
+type xaiImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"` // "low", "high", or "auto"
 }
 
 // xaiTool represents a tool definition in the xAI format.
@@ -34,6 +98,12 @@ type xaiFunction struct {
 	Parameters  json.RawMessage `json:"parameters"`
 }
 
+// xaiDeferredResponse is returned instead of a completion for deferred
+// requests.
+type xaiDeferredResponse struct {
+	RequestID string `json:"request_id"`
+}
+
 // xaiResponse represents a response from the xAI chat completions API.
 type xaiResponse struct {
 	ID      string      `json:"id"`
@@ -117,3 +187,25 @@ type xaiStreamFunction struct {
 	Name      string `json:"name,omitempty"`
 	Arguments string `json:"arguments,omitempty"`
 }
+
+// xaiImageRequest represents a request to the xAI image generation API.
+type xaiImageRequest struct {
+	Model          string `json:"model"`
+	Prompt         string `json:"prompt"`
+	N              int    `json:"n,omitempty"`
+	ResponseFormat string `json:"response_format,omitempty"`
+	User           string `json:"user,omitempty"`
+}
+
+// xaiImageResponse represents a response from the xAI image generation API.
+type xaiImageResponse struct {
+	Created int64          `json:"created"`
+	Data    []xaiImageData `json:"data"`
+}
+
+// xaiImageData represents a single generated image.
+type xaiImageData struct {
+	B64JSON       string `json:"b64_json,omitempty"`
+	URL           string `json:"url,omitempty"`
+	RevisedPrompt string `json:"revised_prompt,omitempty"`
+}
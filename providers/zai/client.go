@@ -16,7 +16,7 @@ const chatCompletionsPath = "/chat/completions"
 // doChat performs a non-streaming chat completion request.
 func (p *Zai) doChat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
 	// Build Z.ai request
-	zaiReq := buildRequest(req, false)
+	zaiReq := buildRequest(&p.config, req, false)
 
 	// Marshal request body
 	body, err := json.Marshal(zaiReq)
@@ -89,6 +89,10 @@ func TestChatSuccess(t *testing.T) {
 	if resp.Usage.TotalTokens != 18 {
 		t.Errorf("Usage.TotalTokens = %d, want 18", resp.Usage.TotalTokens)
 	}
+
+	if resp.FinishReason != core.FinishStop || resp.RawFinishReason != "stop" {
+		t.Errorf("FinishReason = %q, RawFinishReason = %q, want stop", resp.FinishReason, resp.RawFinishReason)
+	}
 }
 
 func TestChatWithToolCalls(t *testing.T) {
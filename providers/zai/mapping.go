@@ -1,9 +1,12 @@
 package zai
 
 import (
+	"cmp"
 	"encoding/json"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/normalize"
+	"github.com/petal-labs/iris/providers/internal/toolchoice"
 	"github.com/petal-labs/iris/tools"
 )
 
@@ -14,12 +17,53 @@ type schemaProvider interface {
 }
 
 // mapMessages converts Iris messages to Z.ai message format.
-func mapMessages(msgs []core.Message) []zaiMessage {
-	result := make([]zaiMessage, len(msgs))
-	for i, msg := range msgs {
-		result[i] = zaiMessage{
-			Role:    string(msg.Role),
-			Content: msg.Content,
+// Tool result content is serialized according to policy (nil uses the default).
+func mapMessages(msgs []core.Message, policy *core.ToolResultPolicy) []zaiMessage {
+	result := make([]zaiMessage, 0, len(msgs))
+	for _, msg := range msgs {
+		switch msg.Role {
+		case core.RoleTool:
+			// Z.ai expects one tool message per result
+			for _, tr := range msg.ToolResults {
+				result = append(result, zaiMessage{
+					Role:       "tool",
+					Content:    policy.Serialize(tr.Content),
+					ToolCallID: tr.CallID,
+				})
+			}
+
+		case core.RoleAssistant:
+			result = append(result, zaiMessage{
+				Role:      "assistant",
+				Content:   msg.Content,
+				ToolCalls: mapToolCallsToWire(msg.ToolCalls),
+			})
+
+		default:
+			result = append(result, zaiMessage{
+				Role:    string(msg.Role),
+				Content: msg.Content,
+			})
+		}
+	}
+	return result
+}
+
+// mapToolCallsToWire converts the tool calls of an assistant message to the
+// request format, which takes arguments as a JSON string.
+func mapToolCallsToWire(calls []core.ToolCall) []zaiToolCallReq {
+	if len(calls) == 0 {
+		return nil
+	}
+	result := make([]zaiToolCallReq, len(calls))
+	for i, tc := range calls {
+		result[i] = zaiToolCallReq{
+			ID:   tc.ID,
+			Type: "function",
+			Function: zaiToolCallFunc{
+				Name:      tc.Name,
+				Arguments: string(tc.Arguments),
+			},
 		}
 	}
 	return result
@@ -48,7 +92,7 @@ func mapTools(irisTools []core.Tool) []zaiTool {
 
 		result[i] = zaiTool{
 			Type: "function",
-			Function: zaiFunction{
+			Function: &zaiFunction{
 				Name:        t.Name(),
 				Description: t.Description(),
 				Parameters:  params,
@@ -74,11 +118,43 @@ func mapThinking(effort core.ReasoningEffort) *zaiThinking {
 	}
 }
 
+// searchCountByContextSize maps web search context sizes to the number of
+// results the web_search tool returns.
+var searchCountByContextSize = map[core.WebSearchContextSize]int{
+	core.WebSearchContextLow:    5,
+	core.WebSearchContextMedium: 10,
+	core.WebSearchContextHigh:   20,
+}
+
+// mapWebSearch converts web search options to the web_search tool. Z.ai
+// filters results by a single domain, so AllowedDomains is only used when
+// it names one. BlockedDomains and UserLocation are not supported.
+func mapWebSearch(engine string, opts *core.WebSearchOptions) zaiTool {
+	ws := &zaiWebSearch{
+		Enable:       true,
+		SearchEngine: cmp.Or(engine, DefaultWebSearchEngine),
+		SearchResult: true,
+	}
+	if opts != nil {
+		if len(opts.AllowedDomains) == 1 {
+			ws.SearchDomainFilter = opts.AllowedDomains[0]
+		}
+		ws.Count = searchCountByContextSize[opts.ContextSize]
+		switch opts.ContextSize {
+		case core.WebSearchContextHigh:
+			ws.ContentSize = "high"
+		case core.WebSearchContextLow, core.WebSearchContextMedium:
+			ws.ContentSize = "medium"
+		}
+	}
+	return zaiTool{Type: "web_search", WebSearch: ws}
+}
+
 // buildRequest creates a Z.ai API request from an Iris ChatRequest.
-func buildRequest(req *core.ChatRequest, stream bool) *zaiRequest {
+func buildRequest(cfg *Config, req *core.ChatRequest, stream bool) *zaiRequest {
 	zaiReq := &zaiRequest{
 		Model:    string(req.Model),
-		Messages: mapMessages(req.Messages),
+		Messages: mapMessages(req.Messages, req.ToolResultPolicy),
 		Stream:   stream,
 	}
 
@@ -94,7 +170,15 @@ func buildRequest(req *core.ChatRequest, stream bool) *zaiRequest {
 	// Map tools if present
 	if len(req.Tools) > 0 {
 		zaiReq.Tools = mapTools(req.Tools)
-		zaiReq.ToolChoice = "auto"
+		zaiReq.ToolChoice = toolchoice.OpenAI(req.ToolChoice)
+	}
+
+	// Map the web_search built-in tool
+	for _, t := range req.BuiltInTools {
+		if t.Type == "web_search" {
+			zaiReq.Tools = append(zaiReq.Tools, mapWebSearch(cfg.WebSearchEngine, t.WebSearch))
+			break
+		}
 	}
 
 	// Map reasoning effort to thinking parameter
@@ -115,11 +199,14 @@ func mapResponse(resp *zaiResponse) (*core.ChatResponse, error) {
 			CompletionTokens: resp.Usage.CompletionTokens,
 			TotalTokens:      resp.Usage.TotalTokens,
 		},
+		Citations: mapCitations(resp.WebSearch),
 	}
 
 	// Extract content from first choice
 	if len(resp.Choices) > 0 {
 		choice := resp.Choices[0]
+		result.FinishReason = mapFinishReason(choice.FinishReason)
+		result.RawFinishReason = choice.FinishReason
 		result.Output = choice.Message.Content
 
 		// Map reasoning content if present
@@ -168,3 +255,33 @@ func mapToolCalls(calls []zaiToolCall) ([]core.ToolCall, error) {
 
 	return result, nil
 }
+
+// mapFinishReason converts a Z.ai finish_reason to a finish reason. Z.ai
+// reports "sensitive" when its content moderation stopped the response.
+func mapFinishReason(reason string) core.FinishReason {
+	if reason == "sensitive" {
+		return core.FinishContentFilter
+	}
+	return normalize.FinishReason(reason)
+}
+
+// mapCitations converts web search results to citations, or returns nil if
+// there are none.
+func mapCitations(results []zaiSearch) []core.Citation {
+	if len(results) == 0 {
+		return nil
+	}
+	citations := make([]core.Citation, 0, len(results))
+	for _, r := range results {
+		if r.Link == "" {
+			continue
+		}
+		citations = append(citations, core.Citation{
+			URL:     r.Link,
+			Title:   r.Title,
+			Snippet: r.Content,
+			Date:    r.PublishDate,
+		})
+	}
+	return citations
+}
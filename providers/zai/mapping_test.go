@@ -1,6 +1,7 @@
 package zai
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/petal-labs/iris/core"
@@ -13,7 +14,7 @@ func TestMapMessages(t *testing.T) {
 		{Role: core.RoleAssistant, Content: "Hi there!"},
 	}
 
-	result := mapMessages(msgs)
+	result := mapMessages(msgs, nil)
 
 	if len(result) != 3 {
 		t.Fatalf("len(result) = %d, want 3", len(result))
@@ -36,7 +37,7 @@ func TestMapMessages(t *testing.T) {
 }
 
 func TestMapMessagesEmpty(t *testing.T) {
-	result := mapMessages([]core.Message{})
+	result := mapMessages([]core.Message{}, nil)
 
 	if len(result) != 0 {
 		t.Errorf("len(result) = %d, want 0", len(result))
@@ -90,7 +91,7 @@ func TestBuildRequest(t *testing.T) {
 		ReasoningEffort: core.ReasoningEffortHigh,
 	}
 
-	result := buildRequest(req, false)
+	result := buildRequest(&Config{}, req, false)
 
 	if result.Model != "glm-4.7" {
 		t.Errorf("Model = %q, want glm-4.7", result.Model)
@@ -127,7 +128,7 @@ func TestBuildRequestStream(t *testing.T) {
 		},
 	}
 
-	result := buildRequest(req, true)
+	result := buildRequest(&Config{}, req, true)
 
 	if !result.Stream {
 		t.Error("Stream = false, want true")
@@ -142,7 +143,7 @@ func TestBuildRequestNoOptionalFields(t *testing.T) {
 		},
 	}
 
-	result := buildRequest(req, false)
+	result := buildRequest(&Config{}, req, false)
 
 	if result.Temperature != nil {
 		t.Error("Temperature should be nil")
@@ -210,3 +211,128 @@ func TestMapToolsBasic(t *testing.T) {
 		t.Errorf("Function.Parameters = %s, want {}", result[0].Function.Parameters)
 	}
 }
+
+func TestMapMessagesToolRoundTrip(t *testing.T) {
+	msgs := []core.Message{
+		{Role: core.RoleUser, Content: "Weather in Paris and Rome?"},
+		{Role: core.RoleAssistant, ToolCalls: []core.ToolCall{
+			{ID: "call_1", Name: "get_weather", Arguments: json.RawMessage(`{"city":"Paris"}`)},
+			{ID: "call_2", Name: "get_weather", Arguments: json.RawMessage(`{"city":"Rome"}`)},
+		}},
+		{Role: core.RoleTool, ToolResults: []core.ToolResult{
+			{CallID: "call_1", Content: map[string]any{"temp": 18}},
+			{CallID: "call_2", Content: "sunny"},
+		}},
+	}
+
+	result := mapMessages(msgs, nil)
+
+	if len(result) != 4 {
+		t.Fatalf("len(result) = %d, want 4", len(result))
+	}
+	calls := result[1].ToolCalls
+	if len(calls) != 2 || calls[0].ID != "call_1" || calls[0].Type != "function" {
+		t.Fatalf("ToolCalls = %+v", calls)
+	}
+	if calls[0].Function.Name != "get_weather" || calls[0].Function.Arguments != `{"city":"Paris"}` {
+		t.Errorf("ToolCalls[0].Function = %+v", calls[0].Function)
+	}
+	if result[2].Role != "tool" || result[2].ToolCallID != "call_1" || result[2].Content != `{"temp":18}` {
+		t.Errorf("result[2] = %+v", result[2])
+	}
+	if result[3].ToolCallID != "call_2" || result[3].Content != "sunny" {
+		t.Errorf("result[3] = %+v", result[3])
+	}
+}
+
+func TestBuildRequestWebSearch(t *testing.T) {
+	req := &core.ChatRequest{
+		Model:    "glm-4.7",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "News?"}},
+		BuiltInTools: []core.BuiltInTool{{Type: "web_search", WebSearch: &core.WebSearchOptions{
+			AllowedDomains: []string{"go.dev"},
+			ContextSize:    core.WebSearchContextHigh,
+		}}},
+	}
+
+	result := buildRequest(&Config{}, req, false)
+
+	if len(result.Tools) != 1 || result.Tools[0].Type != "web_search" {
+		t.Fatalf("Tools = %+v, want one web_search tool", result.Tools)
+	}
+	ws := result.Tools[0].WebSearch
+	want := zaiWebSearch{
+		Enable:             true,
+		SearchEngine:       DefaultWebSearchEngine,
+		SearchResult:       true,
+		Count:              20,
+		SearchDomainFilter: "go.dev",
+		ContentSize:        "high",
+	}
+	if ws == nil || *ws != want {
+		t.Errorf("WebSearch = %+v, want %+v", ws, want)
+	}
+
+	result = buildRequest(&Config{WebSearchEngine: "search_pro"}, req, false)
+	if got := result.Tools[0].WebSearch.SearchEngine; got != "search_pro" {
+		t.Errorf("SearchEngine = %q, want search_pro", got)
+	}
+
+	body, _ := json.Marshal(result.Tools[0])
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(body, &fields); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if _, ok := fields["function"]; ok {
+		t.Errorf("web_search tool has a function field: %s", body)
+	}
+}
+
+func TestMapFinishReason(t *testing.T) {
+	tests := []struct {
+		reason string
+		want   core.FinishReason
+	}{
+		{"stop", core.FinishStop},
+		{"length", core.FinishLength},
+		{"tool_calls", core.FinishToolCalls},
+		{"sensitive", core.FinishContentFilter},
+		{"network_error", core.FinishUnknown},
+	}
+
+	for _, tt := range tests {
+		if got := mapFinishReason(tt.reason); got != tt.want {
+			t.Errorf("mapFinishReason(%q) = %q, want %q", tt.reason, got, tt.want)
+		}
+	}
+}
+
+func TestMapResponseCitations(t *testing.T) {
+	resp, err := mapResponse(&zaiResponse{
+		Choices: []zaiChoice{{Message: zaiRespMsg{Content: "Answer [1]"}}},
+		WebSearch: []zaiSearch{
+			{Title: "Go", Link: "https://go.dev", Content: "The Go language", PublishDate: "2025-08-12"},
+			{Title: "No link"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("mapResponse() error = %v", err)
+	}
+	want := core.Citation{URL: "https://go.dev", Title: "Go", Snippet: "The Go language", Date: "2025-08-12"}
+	if len(resp.Citations) != 1 || resp.Citations[0] != want {
+		t.Errorf("Citations = %+v, want [%+v]", resp.Citations, want)
+	}
+}
+
+func TestMapMessagesToolResultPolicy(t *testing.T) {
+	msgs := []core.Message{{
+		Role:        core.RoleTool,
+		ToolResults: []core.ToolResult{{CallID: "call_1", Content: "plain text"}},
+	}}
+
+	policy := &core.ToolResultPolicy{Format: core.ToolResultFormatJSON}
+	result := mapMessages(msgs, policy)
+	if result[0].Content != `"plain text"` {
+		t.Errorf("Content = %q, want %q", result[0].Content, `"plain text"`)
+	}
+}
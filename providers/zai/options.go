@@ -3,6 +3,7 @@ package zai
 
 import (
 	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/petal-labs/iris/core"
@@ -11,6 +12,11 @@ import (
 // DefaultBaseURL is the default base URL for the Z.ai API (coding endpoint).
 const DefaultBaseURL = "https://api.z.ai/api/coding/paas/v4"
 
+// DefaultWebSearchEngine is the search engine used by the web_search tool.
+// The mainland China endpoint (open.bigmodel.cn) uses engines such as
+// "search_std" and "search_pro" instead.
+const DefaultWebSearchEngine = "search-prime"
+
 // Config holds the configuration for the Z.ai provider.
 type Config struct {
 	// APIKey is the API key for authentication.
@@ -20,14 +26,42 @@ type Config struct {
 	// BaseURL is the base URL for the API. Defaults to DefaultBaseURL.
 	BaseURL string
 
-	// HTTPClient is the HTTP client to use for requests.
+	// HTTPClient is the HTTP client to use. Defaults to a client with pooled
+	// connections shared by all providers. When set, Transport and Proxy are
+	// ignored.
 	HTTPClient *http.Client
 
+	// Transport replaces the default transport, for example to tune
+	// connection pooling or add instrumentation.
+	Transport http.RoundTripper
+
+	// Proxy routes requests through a proxy instead of the one configured by
+	// HTTPS_PROXY, HTTP_PROXY, and NO_PROXY.
+	Proxy *url.URL
+
+	// Credentials, if set, supplies the API key for each request instead of
+	// APIKey, so keys can rotate without a restart.
+	Credentials core.CredentialProvider
+
+	// PathPrefix is inserted before the path of every request URL, for
+	// gateways that route by path.
+	PathPrefix string
+
+	// QueryParams are set on every request URL.
+	QueryParams url.Values
+
+	// RequestEditors are called on every request before it is sent.
+	RequestEditors []func(*http.Request)
+
 	// Headers are additional headers to include in requests.
 	Headers http.Header
 
 	// Timeout is the request timeout. Zero means no timeout.
 	Timeout time.Duration
+
+	// WebSearchEngine is the search engine used by the web_search tool.
+	// Defaults to DefaultWebSearchEngine.
+	WebSearchEngine string
 }
 
 // Option is a functional option for configuring the Z.ai provider.
@@ -47,6 +81,63 @@ func WithHTTPClient(client *http.Client) Option {
 	}
 }
 
+// WithTransport sets the transport used by the default HTTP client.
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Config) {
+		c.Transport = rt
+	}
+}
+
+// WithProxy routes requests through the proxy at proxyURL.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(c *Config) {
+		c.Proxy = proxyURL
+	}
+}
+
+// WithCredentials resolves the API key from creds for every request instead
+// of using a fixed key. A request rejected with 401 is retried once after
+// refreshing creds, if it implements core.CredentialRefresher.
+func WithCredentials(creds core.CredentialProvider) Option {
+	return func(c *Config) {
+		c.Credentials = creds
+	}
+}
+
+// WithPathPrefix inserts prefix before the path of every request URL, for
+// gateways such as LiteLLM or Portkey that route by path. With prefix
+// "/team-a", a request for https://gateway.example.com/v1/chat/completions
+// is sent to https://gateway.example.com/team-a/v1/chat/completions.
+func WithPathPrefix(prefix string) Option {
+	return func(c *Config) {
+		c.PathPrefix = prefix
+	}
+}
+
+// WithQueryParams sets query parameters on every request URL, such as a
+// version or routing parameter required by a gateway. Repeated calls add to
+// the parameters.
+func WithQueryParams(params url.Values) Option {
+	return func(c *Config) {
+		if c.QueryParams == nil {
+			c.QueryParams = make(url.Values)
+		}
+		for name, values := range params {
+			c.QueryParams[name] = append(c.QueryParams[name], values...)
+		}
+	}
+}
+
+// WithRequestEditor calls fn on every request before it is sent, after
+// authentication and other headers are set. fn may modify the request but
+// must not read or replace its body. Repeated calls add editors, which run
+// in order.
+func WithRequestEditor(fn func(*http.Request)) Option {
+	return func(c *Config) {
+		c.RequestEditors = append(c.RequestEditors, fn)
+	}
+}
+
 // WithHeaders sets additional headers to include in requests.
 func WithHeaders(headers http.Header) Option {
 	return func(c *Config) {
@@ -54,9 +145,17 @@ func WithHeaders(headers http.Header) Option {
 	}
 }
 
-// WithTimeout sets the request timeout.
+// WithTimeout sets the default request timeout, which core.Client applies
+// to requests that have no context deadline or Timeout of their own.
 func WithTimeout(timeout time.Duration) Option {
 	return func(c *Config) {
 		c.Timeout = timeout
 	}
 }
+
+// WithWebSearchEngine sets the search engine used by the web_search tool.
+func WithWebSearchEngine(engine string) Option {
+	return func(c *Config) {
+		c.WebSearchEngine = engine
+	}
+}
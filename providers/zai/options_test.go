@@ -4,6 +4,8 @@ import (
 	"net/http"
 	"testing"
 	"time"
+
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 func TestNewWithDefaults(t *testing.T) {
@@ -17,8 +19,8 @@ func TestNewWithDefaults(t *testing.T) {
 		t.Errorf("BaseURL = %q, want %q", p.config.BaseURL, DefaultBaseURL)
 	}
 
-	if p.config.HTTPClient != http.DefaultClient {
-		t.Error("HTTPClient should be http.DefaultClient")
+	if p.config.HTTPClient != httpclient.Default() {
+		t.Error("HTTPClient should be the shared default client")
 	}
 }
 
@@ -5,8 +5,10 @@ import (
 	"errors"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/internal/httpclient"
 )
 
 // DefaultAPIKeyEnvVar is the environment variable name for the Z.ai API key.
@@ -40,14 +42,24 @@ type Zai struct {
 // New creates a new Z.ai provider with the given API key and options.
 func New(apiKey string, opts ...Option) *Zai {
 	cfg := Config{
-		APIKey:     core.NewSecret(apiKey),
-		BaseURL:    DefaultBaseURL,
-		HTTPClient: http.DefaultClient,
+		APIKey:  core.NewSecret(apiKey),
+		BaseURL: DefaultBaseURL,
 	}
 
 	for _, opt := range opts {
 		opt(&cfg)
 	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = httpclient.New(cfg.Transport, cfg.Proxy)
+	}
+	cfg.HTTPClient = httpclient.WithRewrite(cfg.HTTPClient, httpclient.Rewrite{
+		PathPrefix: cfg.PathPrefix,
+		Query:      cfg.QueryParams,
+		Editors:    cfg.RequestEditors,
+	})
+	if cfg.Credentials != nil {
+		cfg.HTTPClient = httpclient.WithCredentials(cfg.HTTPClient, cfg.Credentials, httpclient.BearerAuth)
+	}
 
 	return &Zai{config: cfg}
 }
@@ -57,6 +69,12 @@ func (p *Zai) ID() string {
 	return "zai"
 }
 
+// CloseIdleConnections closes the idle pooled connections of the provider's
+// HTTP client. core.Client.Close calls it during shutdown.
+func (p *Zai) CloseIdleConnections() {
+	p.config.HTTPClient.CloseIdleConnections()
+}
+
 // Models returns the list of available models.
 func (p *Zai) Models() []core.ModelInfo {
 	// Return a copy to prevent mutation
@@ -65,10 +83,17 @@ func (p *Zai) Models() []core.ModelInfo {
 	return result
 }
 
+// DefaultTimeout returns the timeout set with WithTimeout. core.Client
+// applies it to requests that have no deadline or Timeout of their own.
+func (p *Zai) DefaultTimeout() time.Duration {
+	return p.config.Timeout
+}
+
 // Supports reports whether the provider supports the given feature.
 func (p *Zai) Supports(feature core.Feature) bool {
 	switch feature {
-	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning:
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling, core.FeatureReasoning,
+		core.FeatureBuiltInTools:
 		return true
 	default:
 		return false
@@ -106,3 +131,9 @@ func (p *Zai) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.Chat
 
 // Compile-time checks that Zai implements required interfaces.
 var _ core.Provider = (*Zai)(nil)
+
+// Compile-time check that Zai implements TimeoutProvider.
+var _ core.TimeoutProvider = (*Zai)(nil)
+
+// Compile-time check that Zai implements IdleConnectionCloser.
+var _ core.IdleConnectionCloser = (*Zai)(nil)
@@ -50,7 +50,7 @@ func (a *toolCallAssembler) finalize() ([]core.ToolCall, error) {
 // doStreamChat performs a streaming chat completion request.
 func (p *Zai) doStreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
 	// Build Z.ai request with stream=true
-	zaiReq := buildRequest(req, true)
+	zaiReq := buildRequest(&p.config, req, true)
 
 	// Marshal request body
 	body, err := json.Marshal(zaiReq)
@@ -126,7 +126,9 @@ func (p *Zai) processSSEStream(
 	var responseID string
 	var responseModel string
 	var usage *zaiUsage
+	var finishReason string
 	var reasoningContent strings.Builder
+	var webSearch []zaiSearch
 
 	for {
 		// Check for context cancellation
@@ -184,18 +186,23 @@ func (p *Zai) processSSEStream(
 		if chunk.Usage != nil {
 			usage = chunk.Usage
 		}
+		if len(chunk.WebSearch) > 0 {
+			webSearch = chunk.WebSearch
+		}
 
 		// Process choices
 		for _, choice := range chunk.Choices {
-			// Accumulate reasoning content
-			if choice.Delta.ReasoningContent != "" {
-				reasoningContent.WriteString(choice.Delta.ReasoningContent)
+			if choice.FinishReason != "" {
+				finishReason = choice.FinishReason
 			}
 
-			// Emit content delta
-			if choice.Delta.Content != "" {
+			// Accumulate reasoning content
+			reasoningContent.WriteString(choice.Delta.ReasoningContent)
+
+			// Emit content and reasoning deltas
+			if choice.Delta.Content != "" || choice.Delta.ReasoningContent != "" {
 				select {
-				case chunkCh <- core.ChatChunk{Delta: choice.Delta.Content}:
+				case chunkCh <- core.ChatChunk{Delta: choice.Delta.Content, Reasoning: choice.Delta.ReasoningContent}:
 				case <-ctx.Done():
 					errCh <- ctx.Err()
 					return
@@ -218,9 +225,12 @@ func (p *Zai) processSSEStream(
 
 	// Build final response
 	finalResp := &core.ChatResponse{
-		ID:        responseID,
-		Model:     core.ModelID(responseModel),
-		ToolCalls: toolCalls,
+		ID:              responseID,
+		Model:           core.ModelID(responseModel),
+		ToolCalls:       toolCalls,
+		Citations:       mapCitations(webSearch),
+		FinishReason:    mapFinishReason(finishReason),
+		RawFinishReason: finishReason,
 	}
 
 	// Add reasoning if present
@@ -180,15 +180,19 @@ func TestStreamChatWithReasoning(t *testing.T) {
 		t.Fatalf("StreamChat() error = %v", err)
 	}
 
-	// Collect content
-	var content strings.Builder
+	// Collect content and reasoning
+	var content, reasoning strings.Builder
 	for chunk := range stream.Ch {
 		content.WriteString(chunk.Delta)
+		reasoning.WriteString(chunk.Reasoning)
 	}
 
 	if content.String() != "The answer is 42." {
 		t.Errorf("content = %q, want %q", content.String(), "The answer is 42.")
 	}
+	if reasoning.String() != "Let me think..." {
+		t.Errorf("reasoning = %q, want %q", reasoning.String(), "Let me think...")
+	}
 
 	// Check final response
 	select {
@@ -303,3 +307,45 @@ func TestToolCallAssemblerEmpty(t *testing.T) {
 		t.Errorf("calls = %v, want nil", calls)
 	}
 }
+
+func TestStreamChatWithWebSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.WriteHeader(http.StatusOK)
+
+		chunks := []string{
+			`{"id":"task-search","model":"glm-4.7","web_search":[{"title":"Go 1.25","link":"https://go.dev/doc/go1.25","content":"Release notes"}],"choices":[{"index":0,"delta":{"content":"Go 1.25 "}}]}`,
+			`{"id":"task-search","model":"glm-4.7","choices":[{"index":0,"delta":{"content":"is out [1]."}}]}`,
+		}
+
+		flusher, _ := w.(http.Flusher)
+		for _, chunk := range chunks {
+			fmt.Fprintf(w, "data: %s\n\n", chunk)
+			flusher.Flush()
+		}
+		fmt.Fprintf(w, "data: [DONE]\n\n")
+		flusher.Flush()
+	}))
+	defer server.Close()
+
+	p := New("test-key", WithBaseURL(server.URL))
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model:        "glm-4.7",
+		Messages:     []core.Message{{Role: core.RoleUser, Content: "What's new in Go?"}},
+		BuiltInTools: []core.BuiltInTool{{Type: "web_search"}},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	resp, err := core.DrainStream(context.Background(), stream)
+	if err != nil {
+		t.Fatalf("DrainStream() error = %v", err)
+	}
+	if resp.Output != "Go 1.25 is out [1]." {
+		t.Errorf("Output = %q", resp.Output)
+	}
+	if len(resp.Citations) != 1 || resp.Citations[0].URL != "https://go.dev/doc/go1.25" {
+		t.Errorf("Citations = %+v, want go.dev citation", resp.Citations)
+	}
+}
@@ -16,7 +16,7 @@ type zaiRequest struct {
 	Stop           []string     `json:"stop,omitempty"`
 	Thinking       *zaiThinking `json:"thinking,omitempty"`
 	Tools          []zaiTool    `json:"tools,omitempty"`
-	ToolChoice     string       `json:"tool_choice,omitempty"`
+	ToolChoice     any          `json:"tool_choice,omitempty"`
 	ToolStream     *bool        `json:"tool_stream,omitempty"`
 	ResponseFormat *zaiRespFmt  `json:"response_format,omitempty"`
 	RequestID      string       `json:"request_id,omitempty"`
@@ -40,8 +40,19 @@ type zaiThinking struct {
 
 // zaiTool defines a tool that can be called.
 type zaiTool struct {
-	Type     string      `json:"type"` // "function"
-	Function zaiFunction `json:"function"`
+	Type      string        `json:"type"` // "function" or "web_search"
+	Function  *zaiFunction  `json:"function,omitempty"`
+	WebSearch *zaiWebSearch `json:"web_search,omitempty"`
+}
+
+// zaiWebSearch configures the built-in web_search tool.
+type zaiWebSearch struct {
+	Enable             bool   `json:"enable"`
+	SearchEngine       string `json:"search_engine"`
+	SearchResult       bool   `json:"search_result"`
+	Count              int    `json:"count,omitempty"`
+	SearchDomainFilter string `json:"search_domain_filter,omitempty"`
+	ContentSize        string `json:"content_size,omitempty"`
 }
 
 // zaiFunction defines a function tool.
@@ -79,6 +90,17 @@ type zaiResponse struct {
 	Model     string      `json:"model"`
 	Choices   []zaiChoice `json:"choices"`
 	Usage     zaiUsage    `json:"usage"`
+	WebSearch []zaiSearch `json:"web_search,omitempty"`
+}
+
+// zaiSearch is a web search result the response is based on.
+type zaiSearch struct {
+	Title       string `json:"title"`
+	Content     string `json:"content"`
+	Link        string `json:"link"`
+	Media       string `json:"media"`
+	Refer       string `json:"refer"`
+	PublishDate string `json:"publish_date"`
 }
 
 // zaiChoice is a choice in the response.
@@ -134,6 +156,7 @@ type zaiStreamChunk struct {
 	Model     string            `json:"model"`
 	Choices   []zaiStreamChoice `json:"choices"`
 	Usage     *zaiUsage         `json:"usage,omitempty"`
+	WebSearch []zaiSearch       `json:"web_search,omitempty"`
 }
 
 // zaiStreamChoice is a choice in a streaming chunk.
@@ -0,0 +1,234 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Coerce converts data toward schema, fixing the mistakes models commonly
+// make in tool arguments. It is a shortcut for Compile followed by
+// Validator.Coerce.
+func Coerce(schema, data json.RawMessage) (json.RawMessage, error) {
+	v, err := Compile(schema)
+	if err != nil {
+		return nil, err
+	}
+	return v.Coerce(data)
+}
+
+// Coerce returns data with values converted to the types the schema
+// declares and missing properties filled from their defaults:
+//   - numeric strings such as "42" become numbers for number and integer
+//   - "true" and "false" (in any case) become booleans for boolean
+//   - numbers and booleans become strings for string
+//   - strings holding a JSON object or array are decoded for object and
+//     array, and any other single value is wrapped in an array for array
+//   - missing properties with a default are set to the default
+//
+// Values that cannot be converted are left alone for Validate to report.
+// Properties, items, and $ref are followed; the branches of allOf, anyOf,
+// oneOf, and if are not. If nothing changes, data is returned as is. The
+// only error is for data that is not valid JSON.
+func (v *Validator) Coerce(data json.RawMessage) (json.RawMessage, error) {
+	value, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("schema: invalid JSON value: %w", err)
+	}
+	value, changed := v.coerce(v.root, value, 0)
+	if !changed {
+		return data, nil
+	}
+	return json.Marshal(value)
+}
+
+// coerce converts value toward schema and reports whether it changed
+// anything. Objects and arrays are updated in place.
+func (v *Validator) coerce(schema, value any, depth int) (any, bool) {
+	s, ok := schema.(map[string]any)
+	if !ok {
+		return value, false
+	}
+
+	changed := false
+	if ref, ok := s["$ref"].(string); ok && depth < maxRefDepth {
+		if target, err := v.resolve(ref); err == nil {
+			value, changed = v.coerce(target, value, depth+1)
+		}
+	}
+
+	if t, ok := s["type"]; ok && !matchesType(t, value) {
+		if converted, ok := convert(t, value); ok {
+			value, changed = converted, true
+		}
+	}
+
+	switch val := value.(type) {
+	case map[string]any:
+		if v.coerceProperties(s, val, depth) {
+			changed = true
+		}
+	case []any:
+		if v.coerceItems(s, val, depth) {
+			changed = true
+		}
+	}
+	return value, changed
+}
+
+func (v *Validator) coerceProperties(s map[string]any, obj map[string]any, depth int) bool {
+	changed := false
+	props, _ := s["properties"].(map[string]any)
+	for name, ps := range props {
+		value, present := obj[name]
+		if !present {
+			sub, _ := ps.(map[string]any)
+			if def, ok := sub["default"]; ok {
+				obj[name] = clone(def)
+				changed = true
+			}
+			continue
+		}
+		if value, ok := v.coerce(ps, value, depth); ok {
+			obj[name] = value
+			changed = true
+		}
+	}
+
+	if additional, ok := s["additionalProperties"].(map[string]any); ok {
+		for name, value := range obj {
+			if _, declared := props[name]; declared {
+				continue
+			}
+			if value, ok := v.coerce(additional, value, depth); ok {
+				obj[name] = value
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+func (v *Validator) coerceItems(s map[string]any, items []any, depth int) bool {
+	changed := false
+	prefix, _ := s["prefixItems"].([]any)
+	rest, hasRest := s["items"]
+	if tuple, ok := rest.([]any); ok {
+		prefix = tuple
+		rest, hasRest = s["additionalItems"]
+	}
+	for i, item := range items {
+		var sub any
+		switch {
+		case i < len(prefix):
+			sub = prefix[i]
+		case hasRest:
+			sub = rest
+		default:
+			continue
+		}
+		if item, ok := v.coerce(sub, item, depth); ok {
+			items[i] = item
+			changed = true
+		}
+	}
+	return changed
+}
+
+// convert converts value to the first of the types t (a type name or a
+// list of them) that it can be converted to.
+func convert(t, value any) (any, bool) {
+	switch t := t.(type) {
+	case string:
+		return convertTo(t, value)
+	case []any:
+		for _, name := range t {
+			if name, ok := name.(string); ok {
+				if converted, ok := convertTo(name, value); ok {
+					return converted, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+func convertTo(name string, value any) (any, bool) {
+	switch name {
+	case "number", "integer":
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		n, ok := parseJSON(strings.TrimSpace(s)).(json.Number)
+		if !ok || (name == "integer" && !rat(n).IsInt()) {
+			return nil, false
+		}
+		return n, true
+	case "boolean":
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		switch strings.ToLower(strings.TrimSpace(s)) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	case "string":
+		switch val := value.(type) {
+		case json.Number:
+			return val.String(), true
+		case bool:
+			return strconv.FormatBool(val), true
+		}
+	case "object":
+		if s, ok := value.(string); ok {
+			if obj, ok := parseJSON(s).(map[string]any); ok {
+				return obj, true
+			}
+		}
+	case "array":
+		if s, ok := value.(string); ok {
+			if arr, ok := parseJSON(s).([]any); ok {
+				return arr, true
+			}
+		}
+		if value != nil {
+			return []any{value}, true
+		}
+	}
+	return nil, false
+}
+
+// parseJSON decodes s, returning nil if it is not valid JSON.
+func parseJSON(s string) any {
+	v, err := decode([]byte(s))
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// clone deep-copies a decoded value, so defaults taken from the schema are
+// not shared with the output.
+func clone(value any) any {
+	switch val := value.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(val))
+		for k, v := range val {
+			out[k] = clone(v)
+		}
+		return out
+	case []any:
+		out := make([]any, len(val))
+		for i, v := range val {
+			out[i] = clone(v)
+		}
+		return out
+	default:
+		return value
+	}
+}
@@ -0,0 +1,68 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCoerce(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		data   string
+		want   string
+	}{
+		{"number", `{"type":"number"}`, `" 2.5 "`, `2.5`},
+		{"integer", `{"type":"integer"}`, `"42"`, `42`},
+		{"not an integer", `{"type":"integer"}`, `"4.2"`, `"4.2"`},
+		{"not a number", `{"type":"number"}`, `"abc"`, `"abc"`},
+		{"boolean", `{"type":"boolean"}`, `"TRUE"`, `true`},
+		{"string", `{"type":"string"}`, `12`, `"12"`},
+		{"type list", `{"type":["null","integer"]}`, `"7"`, `7`},
+		{"object from string", `{"type":"object"}`, `"{\"a\":1}"`, `{"a":1}`},
+		{"array from string", `{"type":"array"}`, `"[1,2]"`, `[1,2]`},
+		{"array wrap", `{"type":"array","items":{"type":"integer"}}`, `"3"`, `[3]`},
+		{"unchanged", `{"type":"object","properties":{"a":{"type":"integer"}}}`, `{ "a": 1 }`, `{ "a": 1 }`},
+		{
+			"properties and defaults",
+			`{"type":"object","properties":{"n":{"type":"integer"},"units":{"type":"string","default":"celsius"},"tags":{"type":"array","default":["x"]}},"additionalProperties":{"type":"number"}}`,
+			`{"n":"5","extra":"1.5"}`,
+			`{"extra":1.5,"n":5,"tags":["x"],"units":"celsius"}`,
+		},
+		{
+			"ref and items",
+			`{"$defs":{"n":{"type":"integer"}},"type":"array","items":{"$ref":"#/$defs/n"}}`,
+			`["1",2]`,
+			`[1,2]`,
+		},
+		{"prefixItems", `{"prefixItems":[{"type":"string"},{"type":"boolean"}]}`, `[1,"false"]`, `["1",false]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Coerce(json.RawMessage(tt.schema), json.RawMessage(tt.data))
+			if err != nil {
+				t.Fatalf("Coerce() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Coerce() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCoerceDefaultsAreCopied(t *testing.T) {
+	v, err := Compile(json.RawMessage(`{"properties":{"tags":{"default":["a"],"items":{"type":"string"}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 2 {
+		got, err := v.Coerce(json.RawMessage(`{}`))
+		if err != nil || string(got) != `{"tags":["a"]}` {
+			t.Fatalf("Coerce() = %s, %v", got, err)
+		}
+	}
+	if _, err := v.Coerce(json.RawMessage(`{`)); err == nil {
+		t.Error("Coerce() accepted invalid JSON")
+	}
+}
@@ -0,0 +1,61 @@
+// Package schema generates JSON Schema (draft 2020-12) from Go types.
+//
+// The same generator backs tool parameter schemas (tools.SchemaFor) and
+// structured output definitions (core.JSONSchemaFor), so a single Go struct
+// can describe both what a tool accepts and what a model must return.
+//
+//	type WeatherArgs struct {
+//	    City  string `json:"city" jsonschema:"description=City name"`
+//	    Units string `json:"units,omitempty" jsonschema:"enum=celsius|fahrenheit,default=celsius"`
+//	}
+//
+//	s, err := schema.SchemaFor[WeatherArgs]()
+//
+// # Mapping
+//
+// Field names follow encoding/json: the json tag name is used when present,
+// fields tagged "-" and unexported fields are omitted, and embedded structs
+// are flattened. Go types map as follows:
+//   - bool: boolean
+//   - integer kinds: integer (unsigned kinds with minimum 0)
+//   - float kinds: number
+//   - string: string; []byte: string with contentEncoding base64
+//   - time.Time: string with format date-time
+//   - slices and arrays: array with items
+//   - maps with string keys: object with additionalProperties
+//   - structs: object with properties and additionalProperties false
+//   - interfaces and json.RawMessage: any value
+//
+// Pointers are followed. Recursive types are emitted once under $defs and
+// referenced with $ref. Channels, functions, and complex numbers are not
+// supported.
+//
+// # Required fields
+//
+// A field is required unless its json tag has omitempty or omitzero, or it is
+// a pointer. The jsonschema tag options required and optional override this.
+//
+// # The jsonschema tag
+//
+// The jsonschema tag holds comma-separated options. Escape a literal comma
+// in a value with a backslash.
+//   - description=TEXT: the property description
+//   - enum=A|B|C: allowed values, parsed according to the field type
+//   - default=VALUE: the default value
+//   - format=NAME: a string format such as email or uri
+//   - pattern=REGEX: a string pattern
+//   - minimum=N, maximum=N: numeric bounds
+//   - minLength=N, maxLength=N, minItems=N, maxItems=N: length bounds
+//   - required, optional: override required detection
+//
+// Types can take full control of their schema by implementing [Provider].
+//
+// # Validation
+//
+// [Validate] and [Compile] check JSON values against a schema, whether
+// generated here or written by hand. Mismatches are reported as
+// [ValidationError] values with the JSON Pointer of the offending value:
+//
+//	err := schema.Validate(raw, json.RawMessage(`{"city": 42}`))
+//	// /city: expected string, got number
+package schema
@@ -0,0 +1,402 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect emitted by SchemaFor.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// ErrUnsupportedType is returned for Go types that have no JSON
+// representation, such as channels and functions.
+var ErrUnsupportedType = errors.New("schema: unsupported type")
+
+// Schema is a JSON Schema document or subschema.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Description string `json:"description,omitempty"`
+	Format      string `json:"format,omitempty"`
+
+	ContentEncoding string `json:"contentEncoding,omitempty"`
+	Pattern         string `json:"pattern,omitempty"`
+	Enum            []any  `json:"enum,omitempty"`
+	Default         any    `json:"default,omitempty"`
+
+	Minimum   *float64 `json:"minimum,omitempty"`
+	Maximum   *float64 `json:"maximum,omitempty"`
+	MinLength *int     `json:"minLength,omitempty"`
+	MaxLength *int     `json:"maxLength,omitempty"`
+	MinItems  *int     `json:"minItems,omitempty"`
+	MaxItems  *int     `json:"maxItems,omitempty"`
+
+	Items      *Schema            `json:"items,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+
+	// AdditionalProperties is nil, a bool, or a *Schema for map values.
+	AdditionalProperties any `json:"additionalProperties,omitempty"`
+
+	Defs map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// Provider is implemented by types that describe their own schema.
+// The returned schema is used as-is in place of the reflected one.
+type Provider interface {
+	JSONSchema() *Schema
+}
+
+// SchemaFor returns the JSON Schema for T.
+func SchemaFor[T any]() (*Schema, error) {
+	return Reflect(reflect.TypeFor[T]())
+}
+
+// MustSchemaFor is like SchemaFor but panics on error. It is intended for
+// package-level variables and tool Schema methods with fixed types.
+func MustSchemaFor[T any]() *Schema {
+	s, err := SchemaFor[T]()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// RawFor returns the JSON-encoded schema for T.
+func RawFor[T any]() (json.RawMessage, error) {
+	s, err := SchemaFor[T]()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(s)
+}
+
+// Reflect returns the JSON Schema for t.
+func Reflect(t reflect.Type) (*Schema, error) {
+	if t == nil {
+		return &Schema{Schema: Draft}, nil
+	}
+	r := &reflector{root: deref(t), visiting: make(map[reflect.Type]bool)}
+	s, err := r.reflect(t)
+	if err != nil {
+		return nil, err
+	}
+
+	// Copy so that a schema returned by a Provider is not modified.
+	root := *s
+	root.Schema = Draft
+	if len(r.defs) > 0 {
+		root.Defs = r.defs
+	}
+	return &root, nil
+}
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	rawType      = reflect.TypeFor[json.RawMessage]()
+	providerType = reflect.TypeFor[Provider]()
+)
+
+type reflector struct {
+	root     reflect.Type
+	visiting map[reflect.Type]bool
+	defs     map[string]*Schema
+	recursed map[reflect.Type]bool
+}
+
+func (r *reflector) reflect(t reflect.Type) (*Schema, error) {
+	if s, ok := provided(t); ok {
+		return s, nil
+	}
+	t = deref(t)
+
+	switch t {
+	case timeType:
+		return &Schema{Type: "string", Format: "date-time"}, nil
+	case rawType:
+		return &Schema{}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return &Schema{Type: "integer", Minimum: ptr(0.0)}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", ContentEncoding: "base64"}, nil
+		}
+		items, err := r.reflect(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		s := &Schema{Type: "array", Items: items}
+		if t.Kind() == reflect.Array {
+			s.MinItems, s.MaxItems = ptr(t.Len()), ptr(t.Len())
+		}
+		return s, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: %s (map keys must be strings)", ErrUnsupportedType, t)
+		}
+		values, err := r.reflect(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		return r.reflectStruct(t)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+	}
+}
+
+// reflectStruct builds an object schema. Recursive references to the root
+// become {"$ref": "#"}; other recursive types are moved to $defs.
+func (r *reflector) reflectStruct(t reflect.Type) (*Schema, error) {
+	if r.visiting[t] {
+		if t == r.root {
+			return &Schema{Ref: "#"}, nil
+		}
+		if r.recursed == nil {
+			r.recursed = make(map[reflect.Type]bool)
+		}
+		r.recursed[t] = true
+		return &Schema{Ref: "#/$defs/" + defName(t)}, nil
+	}
+
+	r.visiting[t] = true
+	defer delete(r.visiting, t)
+
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema), AdditionalProperties: false}
+	if err := r.addFields(s, t); err != nil {
+		return nil, err
+	}
+
+	if r.recursed[t] {
+		if r.defs == nil {
+			r.defs = make(map[string]*Schema)
+		}
+		r.defs[defName(t)] = s
+		return &Schema{Ref: "#/$defs/" + defName(t)}, nil
+	}
+	return s, nil
+}
+
+// addFields adds the fields of struct type t to s, flattening embedded
+// structs the way encoding/json does.
+func (r *reflector) addFields(s *Schema, t reflect.Type) error {
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, jsonOpts, tagged := parseJSONTag(f)
+		if name == "-" {
+			continue
+		}
+
+		if f.Anonymous && !tagged && deref(f.Type).Kind() == reflect.Struct {
+			if err := r.addFields(s, deref(f.Type)); err != nil {
+				return err
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		prop, err := r.reflect(f.Type)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", f.Name, err)
+		}
+		required := !jsonOpts["omitempty"] && !jsonOpts["omitzero"] && f.Type.Kind() != reflect.Pointer
+
+		if tag, ok := f.Tag.Lookup("jsonschema"); ok {
+			// Tag options must not alter a shared $defs or Provider schema.
+			cp := *prop
+			if cp.Items != nil {
+				items := *cp.Items
+				cp.Items = &items
+			}
+			prop = &cp
+			if required, err = applyTag(prop, tag, deref(f.Type), required); err != nil {
+				return fmt.Errorf("field %s: %w", f.Name, err)
+			}
+		}
+
+		if _, dup := s.Properties[name]; !dup && required {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+	return nil
+}
+
+// applyTag applies jsonschema tag options to s and returns whether the field
+// is required.
+func applyTag(s *Schema, tag string, t reflect.Type, required bool) (bool, error) {
+	for _, opt := range splitTag(tag) {
+		key, value, _ := strings.Cut(opt, "=")
+		var err error
+		switch strings.TrimSpace(key) {
+		case "":
+		case "required":
+			required = true
+		case "optional":
+			required = false
+		case "description":
+			s.Description = value
+		case "format":
+			s.Format = value
+		case "pattern":
+			s.Pattern = value
+		case "enum":
+			// Enums on slices constrain the items.
+			target, elem := s, t
+			if s.Items != nil && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) {
+				target, elem = s.Items, deref(t.Elem())
+			}
+			for _, v := range strings.Split(value, "|") {
+				parsed, perr := parseValue(v, elem)
+				if perr != nil {
+					return required, fmt.Errorf("schema: jsonschema option enum: %w", perr)
+				}
+				target.Enum = append(target.Enum, parsed)
+			}
+		case "default":
+			s.Default, err = parseValue(value, t)
+		case "minimum":
+			s.Minimum, err = parseFloat(value)
+		case "maximum":
+			s.Maximum, err = parseFloat(value)
+		case "minLength":
+			s.MinLength, err = parseInt(value)
+		case "maxLength":
+			s.MaxLength, err = parseInt(value)
+		case "minItems":
+			s.MinItems, err = parseInt(value)
+		case "maxItems":
+			s.MaxItems, err = parseInt(value)
+		default:
+			return required, fmt.Errorf("schema: unknown jsonschema option %q", key)
+		}
+		if err != nil {
+			return required, fmt.Errorf("schema: jsonschema option %s: %w", key, err)
+		}
+	}
+	return required, nil
+}
+
+// splitTag splits a jsonschema tag on commas not preceded by a backslash.
+func splitTag(tag string) []string {
+	var opts []string
+	var cur strings.Builder
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			cur.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			opts = append(opts, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(tag[i])
+		}
+	}
+	return append(opts, cur.String())
+}
+
+// parseValue converts an enum or default value to the field's JSON type.
+func parseValue(v string, t reflect.Type) (any, error) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return strconv.ParseBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(v, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.ParseUint(v, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return v, nil
+	}
+}
+
+func parseFloat(v string) (*float64, error) {
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return nil, err
+	}
+	return &f, nil
+}
+
+func parseInt(v string) (*int, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// parseJSONTag returns the JSON name and options of f, and whether the
+// json tag set a name.
+func parseJSONTag(f reflect.StructField) (string, map[string]bool, bool) {
+	tag, ok := f.Tag.Lookup("json")
+	if !ok {
+		return f.Name, nil, false
+	}
+	name, rest, _ := strings.Cut(tag, ",")
+	opts := make(map[string]bool)
+	for _, opt := range strings.Split(rest, ",") {
+		opts[opt] = true
+	}
+	if name == "" {
+		return f.Name, opts, false
+	}
+	return name, opts, true
+}
+
+// provided returns the schema of a type implementing Provider.
+func provided(t reflect.Type) (*Schema, bool) {
+	if t.Kind() == reflect.Interface {
+		return nil, false
+	}
+	if t.Implements(providerType) {
+		if t.Kind() == reflect.Pointer {
+			return reflect.New(t.Elem()).Interface().(Provider).JSONSchema(), true
+		}
+		return reflect.Zero(t).Interface().(Provider).JSONSchema(), true
+	}
+	if reflect.PointerTo(t).Implements(providerType) {
+		return reflect.New(t).Interface().(Provider).JSONSchema(), true
+	}
+	return nil, false
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t
+}
+
+func defName(t reflect.Type) string {
+	if t.Name() != "" {
+		return t.Name()
+	}
+	return strings.NewReplacer(" ", "", ".", "_").Replace(t.String())
+}
+
+func ptr[T any](v T) *T { return &v }
@@ -0,0 +1,211 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type address struct {
+	Street string `json:"street"`
+	City   string `json:"city,omitempty"`
+}
+
+type Base struct {
+	ID string `json:"id" jsonschema:"description=Unique ID\\, assigned by the server"`
+}
+
+type person struct {
+	Base
+	Name     string          `json:"name" jsonschema:"minLength=1"`
+	Age      int             `json:"age,omitempty" jsonschema:"minimum=0,maximum=150"`
+	Role     string          `json:"role" jsonschema:"enum=admin|user,default=user"`
+	Tags     []string        `json:"tags" jsonschema:"enum=a|b,optional"`
+	Address  *address        `json:"address"`
+	Nickname string          `json:"nickname,omitempty" jsonschema:"required"`
+	Born     time.Time       `json:"born"`
+	Avatar   []byte          `json:"avatar,omitempty"`
+	Labels   map[string]int  `json:"labels,omitempty"`
+	Extra    json.RawMessage `json:"extra,omitempty"`
+	Size     uint            `json:"size,omitempty"`
+	Ignored  string          `json:"-"`
+	internal string
+	Untagged bool
+	Scores   [2]float64          `json:"scores,omitempty"`
+	Meta     map[string]any      `json:"meta,omitempty"`
+	Nested   map[string]*address `json:"nested,omitempty"`
+}
+
+func schemaJSON(t *testing.T, s *Schema) map[string]any {
+	t.Helper()
+	raw, err := json.Marshal(s)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var out map[string]any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	return out
+}
+
+func TestSchemaFor(t *testing.T) {
+	s, err := SchemaFor[person]()
+	if err != nil {
+		t.Fatalf("SchemaFor() error = %v", err)
+	}
+
+	if s.Schema != Draft || s.Type != "object" || s.AdditionalProperties != false {
+		t.Errorf("root = %+v", s)
+	}
+	wantRequired := []string{"id", "name", "role", "nickname", "born", "Untagged"}
+	if !reflect.DeepEqual(s.Required, wantRequired) {
+		t.Errorf("Required = %v, want %v", s.Required, wantRequired)
+	}
+	for _, name := range []string{"Ignored", "internal", "Base"} {
+		if _, ok := s.Properties[name]; ok {
+			t.Errorf("property %q should be omitted", name)
+		}
+	}
+
+	p := s.Properties
+	if got := p["id"].Description; got != "Unique ID, assigned by the server" {
+		t.Errorf("id description = %q", got)
+	}
+	if p["name"].MinLength == nil || *p["name"].MinLength != 1 {
+		t.Errorf("name minLength = %v", p["name"].MinLength)
+	}
+	if p["age"].Type != "integer" || *p["age"].Minimum != 0 || *p["age"].Maximum != 150 {
+		t.Errorf("age = %+v", p["age"])
+	}
+	if !reflect.DeepEqual(p["role"].Enum, []any{"admin", "user"}) || p["role"].Default != "user" {
+		t.Errorf("role = %+v", p["role"])
+	}
+	if p["tags"].Type != "array" || !reflect.DeepEqual(p["tags"].Items.Enum, []any{"a", "b"}) {
+		t.Errorf("tags = %+v", p["tags"])
+	}
+	if p["address"].Type != "object" || !reflect.DeepEqual(p["address"].Required, []string{"street"}) {
+		t.Errorf("address = %+v", p["address"])
+	}
+	if p["born"].Format != "date-time" {
+		t.Errorf("born = %+v", p["born"])
+	}
+	if p["avatar"].Type != "string" || p["avatar"].ContentEncoding != "base64" {
+		t.Errorf("avatar = %+v", p["avatar"])
+	}
+	if v, ok := p["labels"].AdditionalProperties.(*Schema); !ok || v.Type != "integer" {
+		t.Errorf("labels = %+v", p["labels"])
+	}
+	if p["extra"].Type != "" {
+		t.Errorf("extra = %+v, want any", p["extra"])
+	}
+	if *p["size"].Minimum != 0 {
+		t.Errorf("size = %+v", p["size"])
+	}
+	if *p["scores"].MinItems != 2 || *p["scores"].MaxItems != 2 {
+		t.Errorf("scores = %+v", p["scores"])
+	}
+
+	out := schemaJSON(t, s)
+	if out["additionalProperties"] != false {
+		t.Errorf("additionalProperties = %v, want false", out["additionalProperties"])
+	}
+}
+
+type intEnum struct {
+	Level int     `json:"level" jsonschema:"enum=1|2|3"`
+	Ratio float64 `json:"ratio" jsonschema:"default=0.5"`
+	On    bool    `json:"on" jsonschema:"default=true"`
+}
+
+func TestTypedEnumsAndDefaults(t *testing.T) {
+	s := MustSchemaFor[intEnum]()
+	if !reflect.DeepEqual(s.Properties["level"].Enum, []any{int64(1), int64(2), int64(3)}) {
+		t.Errorf("level enum = %v", s.Properties["level"].Enum)
+	}
+	if s.Properties["ratio"].Default != 0.5 || s.Properties["on"].Default != true {
+		t.Errorf("defaults = %v, %v", s.Properties["ratio"].Default, s.Properties["on"].Default)
+	}
+}
+
+type tree struct {
+	Value    string  `json:"value"`
+	Children []*tree `json:"children,omitempty"`
+}
+
+type node struct {
+	Next *node `json:"next,omitempty"`
+}
+
+type list struct {
+	Head *node `json:"head"`
+}
+
+func TestRecursiveTypes(t *testing.T) {
+	s := MustSchemaFor[tree]()
+	if s.Properties["children"].Items.Ref != "#" {
+		t.Errorf("children items = %+v, want $ref #", s.Properties["children"].Items)
+	}
+
+	s = MustSchemaFor[list]()
+	if s.Properties["head"].Ref != "#/$defs/node" {
+		t.Errorf("head = %+v", s.Properties["head"])
+	}
+	def, ok := s.Defs["node"]
+	if !ok || def.Properties["next"].Ref != "#/$defs/node" {
+		t.Errorf("$defs = %+v", s.Defs)
+	}
+}
+
+type color string
+
+func (color) JSONSchema() *Schema {
+	return &Schema{Type: "string", Enum: []any{"red", "green"}}
+}
+
+type palette struct {
+	Primary color `json:"primary" jsonschema:"description=Main color"`
+}
+
+func TestProvider(t *testing.T) {
+	s := MustSchemaFor[palette]()
+	p := s.Properties["primary"]
+	if len(p.Enum) != 2 || p.Description != "Main color" {
+		t.Errorf("primary = %+v", p)
+	}
+	if color("").JSONSchema().Description != "" {
+		t.Error("tag options leaked into the provided schema")
+	}
+}
+
+func TestUnsupportedTypes(t *testing.T) {
+	type bad struct {
+		C chan int `json:"c"`
+	}
+	if _, err := SchemaFor[bad](); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("chan error = %v, want ErrUnsupportedType", err)
+	}
+	if _, err := SchemaFor[map[int]string](); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("int-keyed map error = %v, want ErrUnsupportedType", err)
+	}
+
+	type badTag struct {
+		N int `json:"n" jsonschema:"bogus=1"`
+	}
+	if _, err := SchemaFor[badTag](); err == nil {
+		t.Error("expected error for unknown jsonschema option")
+	}
+}
+
+func TestRawFor(t *testing.T) {
+	raw, err := RawFor[[]string]()
+	if err != nil {
+		t.Fatalf("RawFor() error = %v", err)
+	}
+	want := `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"array","items":{"type":"string"}}`
+	if string(raw) != want {
+		t.Errorf("RawFor() = %s, want %s", raw, want)
+	}
+}
@@ -0,0 +1,534 @@
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidSchema is returned by Compile for documents that are not valid
+// JSON Schema, such as malformed JSON or an invalid pattern.
+var ErrInvalidSchema = errors.New("schema: invalid schema")
+
+// ValidationError describes one way in which a value does not match a
+// schema. Validate joins all of them with errors.Join, so use errors.As to
+// inspect the first.
+type ValidationError struct {
+	// Path is the JSON Pointer (RFC 6901) of the offending value; empty for
+	// the value itself.
+	Path string
+
+	// Message describes the mismatch.
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Message
+	}
+	return e.Path + ": " + e.Message
+}
+
+// Validator checks JSON values against a compiled schema. It is safe for
+// concurrent use.
+//
+// The assertion keywords of draft 2020-12 are supported: type, enum, const,
+// the numeric, string, array, and object bounds, pattern,
+// patternProperties, additionalProperties, prefixItems, items,
+// uniqueItems, allOf, anyOf, oneOf, not, if/then/else, and $ref to the
+// document itself (such as "#" or "#/$defs/Node"). The older items array
+// and boolean exclusiveMinimum/exclusiveMaximum forms are accepted too.
+// format is treated as an annotation and not checked, as the specification
+// allows; unknown keywords are ignored.
+type Validator struct {
+	root     any
+	patterns map[string]*regexp.Regexp
+}
+
+// Compile parses a JSON Schema document for repeated validation.
+func Compile(schema json.RawMessage) (*Validator, error) {
+	root, err := decode(schema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	v := &Validator{root: root, patterns: make(map[string]*regexp.Regexp)}
+	if err := v.compilePatterns(root); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Validate reports whether data matches schema. It is a shortcut for
+// Compile followed by Validator.Validate; compile the schema once when
+// validating many values.
+func Validate(schema, data json.RawMessage) error {
+	v, err := Compile(schema)
+	if err != nil {
+		return err
+	}
+	return v.Validate(data)
+}
+
+// Validate checks data against the schema. It returns nil if data matches,
+// an error wrapping one ValidationError per mismatch if it does not, or a
+// plain error if data is not valid JSON.
+func (v *Validator) Validate(data json.RawMessage) error {
+	value, err := decode(data)
+	if err != nil {
+		return fmt.Errorf("schema: invalid JSON value: %w", err)
+	}
+	var errs []error
+	v.validate(v.root, value, "", 0, func(path, format string, args ...any) {
+		errs = append(errs, &ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	})
+	return errors.Join(errs...)
+}
+
+// maxRefDepth bounds $ref expansion so that a schema referring to itself
+// without consuming input cannot recurse forever.
+const maxRefDepth = 64
+
+// report records a validation error at path.
+type report func(path, format string, args ...any)
+
+// validate checks value against schema, calling fail for each mismatch.
+func (v *Validator) validate(schema, value any, path string, depth int, fail report) {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			fail(path, "no value is allowed here")
+		}
+		return
+	case map[string]any:
+		v.validateObject(s, value, path, depth, fail)
+	}
+}
+
+func (v *Validator) validateObject(s map[string]any, value any, path string, depth int, fail report) {
+	if ref, ok := s["$ref"].(string); ok {
+		if depth >= maxRefDepth {
+			fail(path, "$ref %q nests too deeply", ref)
+			return
+		}
+		target, err := v.resolve(ref)
+		if err != nil {
+			fail(path, "%v", err)
+			return
+		}
+		v.validate(target, value, path, depth+1, fail)
+	}
+
+	if t, ok := s["type"]; ok && !matchesType(t, value) {
+		fail(path, "expected %s, got %s", describeType(t), typeOf(value))
+		return
+	}
+	if enum, ok := s["enum"].([]any); ok && !slices.ContainsFunc(enum, func(e any) bool { return equal(e, value) }) {
+		fail(path, "value must be one of %s", encode(enum))
+	}
+	if c, ok := s["const"]; ok && !equal(c, value) {
+		fail(path, "value must be %s", encode(c))
+	}
+
+	switch val := value.(type) {
+	case json.Number:
+		v.validateNumber(s, val, path, fail)
+	case string:
+		v.validateString(s, val, path, fail)
+	case []any:
+		v.validateArray(s, val, path, depth, fail)
+	case map[string]any:
+		v.validateProperties(s, val, path, depth, fail)
+	}
+
+	v.validateCombinators(s, value, path, depth, fail)
+}
+
+func (v *Validator) validateNumber(s map[string]any, n json.Number, path string, fail report) {
+	x := rat(n)
+	if min, ok := s["minimum"].(json.Number); ok {
+		if exclusive, _ := s["exclusiveMinimum"].(bool); exclusive {
+			if x.Cmp(rat(min)) <= 0 {
+				fail(path, "must be greater than %s", min)
+			}
+		} else if x.Cmp(rat(min)) < 0 {
+			fail(path, "must be at least %s", min)
+		}
+	}
+	if max, ok := s["maximum"].(json.Number); ok {
+		if exclusive, _ := s["exclusiveMaximum"].(bool); exclusive {
+			if x.Cmp(rat(max)) >= 0 {
+				fail(path, "must be less than %s", max)
+			}
+		} else if x.Cmp(rat(max)) > 0 {
+			fail(path, "must be at most %s", max)
+		}
+	}
+	if min, ok := s["exclusiveMinimum"].(json.Number); ok && x.Cmp(rat(min)) <= 0 {
+		fail(path, "must be greater than %s", min)
+	}
+	if max, ok := s["exclusiveMaximum"].(json.Number); ok && x.Cmp(rat(max)) >= 0 {
+		fail(path, "must be less than %s", max)
+	}
+	if m, ok := s["multipleOf"].(json.Number); ok {
+		if d := rat(m); d.Sign() > 0 && !new(big.Rat).Quo(x, d).IsInt() {
+			fail(path, "must be a multiple of %s", m)
+		}
+	}
+}
+
+func (v *Validator) validateString(s map[string]any, str string, path string, fail report) {
+	n := utf8.RuneCountInString(str)
+	if min, ok := intKeyword(s, "minLength"); ok && n < min {
+		fail(path, "must be at least %d characters", min)
+	}
+	if max, ok := intKeyword(s, "maxLength"); ok && n > max {
+		fail(path, "must be at most %d characters", max)
+	}
+	if p, ok := s["pattern"].(string); ok && !v.patterns[p].MatchString(str) {
+		fail(path, "must match pattern %q", p)
+	}
+}
+
+func (v *Validator) validateArray(s map[string]any, items []any, path string, depth int, fail report) {
+	if min, ok := intKeyword(s, "minItems"); ok && len(items) < min {
+		fail(path, "must have at least %d items", min)
+	}
+	if max, ok := intKeyword(s, "maxItems"); ok && len(items) > max {
+		fail(path, "must have at most %d items", max)
+	}
+	if unique, _ := s["uniqueItems"].(bool); unique {
+	outer:
+		for i := range items {
+			for j := i + 1; j < len(items); j++ {
+				if equal(items[i], items[j]) {
+					fail(path, "items %d and %d are equal", i, j)
+					break outer
+				}
+			}
+		}
+	}
+
+	// prefixItems (or the older array form of items) covers the leading
+	// items; items applies to the rest.
+	prefix, _ := s["prefixItems"].([]any)
+	rest, hasRest := s["items"]
+	if tuple, ok := rest.([]any); ok {
+		prefix = tuple
+		rest, hasRest = s["additionalItems"]
+	}
+	for i, item := range items {
+		switch {
+		case i < len(prefix):
+			v.validate(prefix[i], item, path+"/"+strconv.Itoa(i), depth, fail)
+		case hasRest:
+			v.validate(rest, item, path+"/"+strconv.Itoa(i), depth, fail)
+		}
+	}
+}
+
+func (v *Validator) validateProperties(s map[string]any, obj map[string]any, path string, depth int, fail report) {
+	if required, ok := s["required"].([]any); ok {
+		for _, name := range required {
+			if name, ok := name.(string); ok {
+				if _, present := obj[name]; !present {
+					fail(path, "missing required property %q", name)
+				}
+			}
+		}
+	}
+	if min, ok := intKeyword(s, "minProperties"); ok && len(obj) < min {
+		fail(path, "must have at least %d properties", min)
+	}
+	if max, ok := intKeyword(s, "maxProperties"); ok && len(obj) > max {
+		fail(path, "must have at most %d properties", max)
+	}
+
+	props, _ := s["properties"].(map[string]any)
+	patterns, _ := s["patternProperties"].(map[string]any)
+	additional, hasAdditional := s["additionalProperties"]
+
+	// Sort the names so that errors are reported in a stable order.
+	names := make([]string, 0, len(obj))
+	for name := range obj {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value, child := obj[name], path+"/"+escapePointer(name)
+		matched := false
+		if ps, ok := props[name]; ok {
+			v.validate(ps, value, child, depth, fail)
+			matched = true
+		}
+		for p, ps := range patterns {
+			if v.patterns[p].MatchString(name) {
+				v.validate(ps, value, child, depth, fail)
+				matched = true
+			}
+		}
+		if matched || !hasAdditional {
+			continue
+		}
+		if allowed, ok := additional.(bool); ok && !allowed {
+			fail(path, "unexpected property %q", name)
+			continue
+		}
+		v.validate(additional, value, child, depth, fail)
+	}
+}
+
+func (v *Validator) validateCombinators(s map[string]any, value any, path string, depth int, fail report) {
+	if all, ok := s["allOf"].([]any); ok {
+		for _, sub := range all {
+			v.validate(sub, value, path, depth, fail)
+		}
+	}
+	if anyOf, ok := s["anyOf"].([]any); ok {
+		if !slices.ContainsFunc(anyOf, func(sub any) bool { return v.matches(sub, value, depth) }) {
+			fail(path, "does not match any schema in anyOf")
+		}
+	}
+	if oneOf, ok := s["oneOf"].([]any); ok {
+		n := 0
+		for _, sub := range oneOf {
+			if v.matches(sub, value, depth) {
+				n++
+			}
+		}
+		if n != 1 {
+			fail(path, "matches %d schemas in oneOf, want exactly 1", n)
+		}
+	}
+	if not, ok := s["not"]; ok && v.matches(not, value, depth) {
+		fail(path, "must not match the schema in not")
+	}
+	if cond, ok := s["if"]; ok {
+		branch := "else"
+		if v.matches(cond, value, depth) {
+			branch = "then"
+		}
+		if sub, ok := s[branch]; ok {
+			v.validate(sub, value, path, depth, fail)
+		}
+	}
+}
+
+// matches reports whether value matches schema without recording errors.
+func (v *Validator) matches(schema, value any, depth int) bool {
+	ok := true
+	v.validate(schema, value, "", depth, func(string, string, ...any) { ok = false })
+	return ok
+}
+
+// resolve returns the subschema a $ref points to. Only references within
+// the document are supported.
+func (v *Validator) resolve(ref string) (any, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported $ref %q: only references within the schema are resolved", ref)
+	}
+	pointer, err := url.PathUnescape(ref[1:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid $ref %q", ref)
+	}
+	node := v.root
+	if pointer == "" {
+		return node, nil
+	}
+	for _, tok := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		tok = strings.ReplaceAll(strings.ReplaceAll(tok, "~1", "/"), "~0", "~")
+		switch n := node.(type) {
+		case map[string]any:
+			node = n[tok]
+		case []any:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(n) {
+				return nil, fmt.Errorf("$ref %q not found", ref)
+			}
+			node = n[i]
+		default:
+			node = nil
+		}
+		if node == nil {
+			return nil, fmt.Errorf("$ref %q not found", ref)
+		}
+	}
+	return node, nil
+}
+
+// compilePatterns compiles every pattern and patternProperties key in the
+// schema.
+func (v *Validator) compilePatterns(node any) error {
+	switch n := node.(type) {
+	case []any:
+		for _, sub := range n {
+			if err := v.compilePatterns(sub); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		var patterns []string
+		if p, ok := n["pattern"].(string); ok {
+			patterns = append(patterns, p)
+		}
+		if pp, ok := n["patternProperties"].(map[string]any); ok {
+			for p := range pp {
+				patterns = append(patterns, p)
+			}
+		}
+		for _, p := range patterns {
+			if _, done := v.patterns[p]; done {
+				continue
+			}
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return fmt.Errorf("%w: pattern %q: %v", ErrInvalidSchema, p, err)
+			}
+			v.patterns[p] = re
+		}
+		for _, sub := range n {
+			if err := v.compilePatterns(sub); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// decode parses JSON, keeping numbers exact.
+func decode(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after JSON value")
+	}
+	return v, nil
+}
+
+// matchesType reports whether value has the type t, a type name or a list
+// of them.
+func matchesType(t, value any) bool {
+	switch t := t.(type) {
+	case string:
+		return hasType(t, value)
+	case []any:
+		return slices.ContainsFunc(t, func(name any) bool {
+			s, _ := name.(string)
+			return hasType(s, value)
+		})
+	}
+	return true
+}
+
+func hasType(name string, value any) bool {
+	switch name {
+	case "integer":
+		n, ok := value.(json.Number)
+		return ok && rat(n).IsInt()
+	case "number", "string", "boolean", "null", "array", "object":
+		return typeOf(value) == name
+	}
+	// Unknown type names are ignored.
+	return true
+}
+
+func describeType(t any) string {
+	if names, ok := t.([]any); ok {
+		parts := make([]string, len(names))
+		for i, n := range names {
+			parts[i] = fmt.Sprint(n)
+		}
+		return strings.Join(parts, " or ")
+	}
+	return fmt.Sprint(t)
+}
+
+// typeOf returns the JSON type name of a decoded value.
+func typeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// equal reports whether two decoded values are equal as JSON, comparing
+// numbers by value.
+func equal(a, b any) bool {
+	switch a := a.(type) {
+	case json.Number:
+		b, ok := b.(json.Number)
+		return ok && rat(a).Cmp(rat(b)) == 0
+	case []any:
+		b, ok := b.([]any)
+		return ok && slices.EqualFunc(a, b, equal)
+	case map[string]any:
+		b, ok := b.(map[string]any)
+		if !ok || len(a) != len(b) {
+			return false
+		}
+		for k, av := range a {
+			bv, ok := b[k]
+			if !ok || !equal(av, bv) {
+				return false
+			}
+		}
+		return true
+	default:
+		return a == b
+	}
+}
+
+// rat converts a JSON number to an exact rational.
+func rat(n json.Number) *big.Rat {
+	r, ok := new(big.Rat).SetString(n.String())
+	if !ok {
+		return new(big.Rat)
+	}
+	return r
+}
+
+// intKeyword returns a non-negative integer keyword.
+func intKeyword(s map[string]any, key string) (int, bool) {
+	n, ok := s[key].(json.Number)
+	if !ok {
+		return 0, false
+	}
+	i, err := strconv.Atoi(n.String())
+	return i, err == nil
+}
+
+func encode(v any) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
+
+// escapePointer escapes a property name for use in a JSON Pointer.
+func escapePointer(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,116 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		data   string
+		errs   []string // expected errors in order; nil means valid
+	}{
+		{"any", `{}`, `[1,"a"]`, nil},
+		{"false schema", `false`, `1`, []string{"no value is allowed here"}},
+		{"type", `{"type":"string"}`, `1`, []string{"expected string, got number"}},
+		{"type list", `{"type":["string","null"]}`, `null`, nil},
+		{"integer", `{"type":"integer"}`, `2.0`, nil},
+		{"not integer", `{"type":"integer"}`, `2.5`, []string{"expected integer, got number"}},
+		{"enum", `{"enum":["a","b"]}`, `"c"`, []string{`value must be one of ["a","b"]`}},
+		{"enum number", `{"enum":[1,2]}`, `1.0`, nil},
+		{"const", `{"const":{"a":1}}`, `{"a":1}`, nil},
+		{"minimum", `{"minimum":0,"maximum":10}`, `-1`, []string{"must be at least 0"}},
+		{"exclusive", `{"exclusiveMaximum":10}`, `10`, []string{"must be less than 10"}},
+		{"exclusive bool", `{"minimum":0,"exclusiveMinimum":true}`, `0`, []string{"must be greater than 0"}},
+		{"multipleOf", `{"multipleOf":0.1}`, `0.3`, nil},
+		{"length", `{"minLength":2,"maxLength":3}`, `"héllo"`, []string{"must be at most 3 characters"}},
+		{"pattern", `{"pattern":"^[a-z]+$"}`, `"abc1"`, []string{`must match pattern "^[a-z]+$"`}},
+		{
+			"object",
+			`{"type":"object","properties":{"a":{"type":"string"},"b/c":{"type":"integer"}},"required":["a","d"],"additionalProperties":false}`,
+			`{"a":1,"b/c":"x","e":true}`,
+			[]string{`missing required property "d"`, "/a: expected string, got number", "/b~1c: expected integer, got string", `unexpected property "e"`},
+		},
+		{
+			"additionalProperties schema",
+			`{"patternProperties":{"^x-":{}},"additionalProperties":{"type":"integer"}}`,
+			`{"x-a":"s","n":"s"}`,
+			[]string{"/n: expected integer, got string"},
+		},
+		{
+			"array",
+			`{"type":"array","items":{"type":"integer"},"minItems":1,"uniqueItems":true}`,
+			`[1,"a",1]`,
+			[]string{"items 0 and 2 are equal", "/1: expected integer, got string"},
+		},
+		{
+			"prefixItems",
+			`{"prefixItems":[{"type":"string"}],"items":false}`,
+			`["a",1]`,
+			[]string{"/1: no value is allowed here"},
+		},
+		{"anyOf", `{"anyOf":[{"type":"string"},{"type":"integer"}]}`, `true`, []string{"does not match any schema in anyOf"}},
+		{"oneOf", `{"oneOf":[{"type":"integer"},{"minimum":0}]}`, `1`, []string{"matches 2 schemas in oneOf, want exactly 1"}},
+		{"not", `{"not":{"type":"null"}}`, `null`, []string{"must not match the schema in not"}},
+		{"if then", `{"if":{"minimum":10},"then":{"multipleOf":10},"else":{"maximum":5}}`, `7`, []string{"must be at most 5"}},
+		{
+			"ref",
+			`{"$defs":{"node":{"type":"object","properties":{"next":{"$ref":"#/$defs/node"},"v":{"type":"integer"}}}},"$ref":"#/$defs/node"}`,
+			`{"next":{"next":{"v":"x"}}}`,
+			[]string{"/next/next/v: expected integer, got string"},
+		},
+		{"missing ref", `{"$ref":"#/$defs/nope"}`, `1`, []string{`$ref "#/$defs/nope" not found`}},
+		{"format ignored", `{"format":"email"}`, `"not an email"`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(json.RawMessage(tt.schema), json.RawMessage(tt.data))
+			var got []string
+			if err != nil {
+				for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+					got = append(got, e.Error())
+				}
+			}
+			if len(got) != len(tt.errs) {
+				t.Fatalf("Validate() errors = %q, want %q", got, tt.errs)
+			}
+			for i := range got {
+				if got[i] != tt.errs[i] {
+					t.Errorf("error %d = %q, want %q", i, got[i], tt.errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestValidateGeneratedSchema(t *testing.T) {
+	raw, err := RawFor[person]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := Compile(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = v.Validate(json.RawMessage(`{"id":"1","name":"","role":"root"}`))
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Validate() error = %v, want ValidationError", err)
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for _, s := range []string{`{`, `{"pattern":"["}`, `{"patternProperties":{"(":{}}}`} {
+		if _, err := Compile(json.RawMessage(s)); !errors.Is(err, ErrInvalidSchema) {
+			t.Errorf("Compile(%s) error = %v, want ErrInvalidSchema", s, err)
+		}
+	}
+	if err := Validate(json.RawMessage(`{}`), json.RawMessage(`{`)); err == nil {
+		t.Error("Validate() accepted invalid JSON")
+	}
+}
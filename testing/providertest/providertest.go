@@ -0,0 +1,435 @@
+// Package providertest checks that a core.Provider implementation behaves
+// the way the Iris client and its helpers expect.
+//
+// Run executes a table of conformance tests against a live provider: plain
+// chat, usage accounting, streaming, a tool-call round trip, error mapping,
+// and context cancellation. Third-party providers can run the same suite
+// the built-in providers are held to:
+//
+//	func TestConformance(t *testing.T) {
+//		key := os.Getenv("ACME_API_KEY")
+//		if key == "" {
+//			t.Skip("ACME_API_KEY not set")
+//		}
+//		providertest.Run(t, providertest.Config{
+//			NewProvider: func(t *testing.T) core.Provider { return acme.New(key) },
+//			NewUnauthorizedProvider: func(t *testing.T) core.Provider {
+//				return acme.New("invalid-key")
+//			},
+//			Model: "acme-chat-small",
+//		})
+//	}
+//
+// Tests for features the provider does not report through Supports are
+// skipped. The prompts are short, but each run makes about ten requests.
+package providertest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/tools"
+)
+
+// DefaultTimeout bounds each request when Config.Timeout is zero.
+const DefaultTimeout = 60 * time.Second
+
+// Config configures Run.
+type Config struct {
+	// NewProvider returns the provider under test. It is called once per
+	// test. Required.
+	NewProvider func(t *testing.T) core.Provider
+
+	// NewUnauthorizedProvider returns the provider configured with an invalid
+	// credential. Nil skips the ErrorMapping test.
+	NewUnauthorizedProvider func(t *testing.T) core.Provider
+
+	// Model is used for all requests. Required.
+	Model core.ModelID
+
+	// ToolModel is used for the tool-call test. Defaults to Model.
+	ToolModel core.ModelID
+
+	// Timeout bounds each request. Defaults to DefaultTimeout.
+	Timeout time.Duration
+
+	// Skip lists tests to skip by name: Metadata, Chat, Usage, Streaming,
+	// ToolCallRoundTrip, ErrorMapping, or ContextCancellation.
+	Skip []string
+}
+
+// conformanceTest is a single named test. feature, if set, must be
+// supported by the provider or the test is skipped.
+type conformanceTest struct {
+	name    string
+	feature core.Feature
+	run     func(t *testing.T, p core.Provider, cfg Config)
+}
+
+var conformanceTests = []conformanceTest{
+	{name: "Metadata", run: testMetadata},
+	{name: "Chat", feature: core.FeatureChat, run: testChat},
+	{name: "Usage", feature: core.FeatureChat, run: testUsage},
+	{name: "Streaming", feature: core.FeatureChatStreaming, run: testStreaming},
+	{name: "ToolCallRoundTrip", feature: core.FeatureToolCalling, run: testToolCallRoundTrip},
+	{name: "ErrorMapping", feature: core.FeatureChat, run: testErrorMapping},
+	{name: "ContextCancellation", feature: core.FeatureChat, run: testContextCancellation},
+}
+
+// Run runs the conformance tests as subtests of t.
+func Run(t *testing.T, cfg Config) {
+	t.Helper()
+	if cfg.NewProvider == nil {
+		t.Fatal("providertest: Config.NewProvider is required")
+	}
+	if cfg.Model == "" {
+		t.Fatal("providertest: Config.Model is required")
+	}
+	if cfg.ToolModel == "" {
+		cfg.ToolModel = cfg.Model
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	for _, tc := range conformanceTests {
+		t.Run(tc.name, func(t *testing.T) {
+			if slices.Contains(cfg.Skip, tc.name) {
+				t.Skip("skipped by Config.Skip")
+			}
+			p := cfg.NewProvider(t)
+			if p == nil {
+				t.Fatal("Config.NewProvider returned nil")
+			}
+			if tc.feature != "" && !p.Supports(tc.feature) {
+				t.Skipf("provider does not support %s", tc.feature)
+			}
+			tc.run(t, p, cfg)
+		})
+	}
+}
+
+func testMetadata(t *testing.T, p core.Provider, _ Config) {
+	if p.ID() == "" {
+		t.Error("ID() is empty")
+	}
+	if !p.Supports(core.FeatureChat) {
+		t.Error("Supports(FeatureChat) = false; every provider must support chat")
+	}
+
+	seen := make(map[core.ModelID]bool)
+	for _, m := range p.Models() {
+		if m.ID == "" {
+			t.Error("Models() contains a model without ID")
+			continue
+		}
+		if seen[m.ID] {
+			t.Errorf("Models() lists %s more than once", m.ID)
+		}
+		seen[m.ID] = true
+	}
+}
+
+func testChat(t *testing.T, p core.Provider, cfg Config) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	resp, err := p.Chat(ctx, userRequest(cfg.Model, "Reply with the single word: hello"))
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if resp == nil {
+		t.Fatal("Chat() returned nil response and nil error")
+	}
+	if strings.TrimSpace(resp.Output) == "" {
+		t.Error("Output is empty")
+	}
+	if len(resp.ToolCalls) > 0 {
+		t.Errorf("ToolCalls = %d, want 0 for a request without tools", len(resp.ToolCalls))
+	}
+}
+
+func testUsage(t *testing.T, p core.Provider, cfg Config) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	resp, err := p.Chat(ctx, userRequest(cfg.Model, "Reply with the single word: hello"))
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	u := resp.Usage
+	if u.PromptTokens <= 0 {
+		t.Errorf("PromptTokens = %d, want > 0", u.PromptTokens)
+	}
+	if u.CompletionTokens <= 0 {
+		t.Errorf("CompletionTokens = %d, want > 0", u.CompletionTokens)
+	}
+	if u.TotalTokens < u.PromptTokens+u.CompletionTokens {
+		t.Errorf("TotalTokens = %d, want >= %d (prompt + completion)", u.TotalTokens, u.PromptTokens+u.CompletionTokens)
+	}
+}
+
+func testStreaming(t *testing.T, p core.Provider, cfg Config) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	stream, err := p.StreamChat(ctx, userRequest(cfg.Model, "Count from 1 to 5, one number per line."))
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+	res := collect(t, stream, cfg.Timeout)
+	if res.err != nil {
+		t.Fatalf("stream error = %v", res.err)
+	}
+
+	text := strings.Join(res.deltas, "")
+	if strings.TrimSpace(text) == "" {
+		t.Error("stream sent no text deltas")
+	}
+	if res.final == nil {
+		t.Fatal("stream sent no final response")
+	}
+	if res.final.Output != "" && res.final.Output != text {
+		t.Errorf("Final.Output = %q, want the concatenated deltas %q", res.final.Output, text)
+	}
+	if res.final.Usage.TotalTokens == 0 {
+		t.Log("Note: final response has no usage")
+	}
+}
+
+func testToolCallRoundTrip(t *testing.T, p core.Provider, cfg Config) {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	req := userRequest(cfg.ToolModel, "What is the weather in Paris? Use the get_weather tool.")
+	req.Tools = []core.Tool{weatherTool{}}
+	req.ToolChoice = &core.ToolChoice{Mode: core.ToolChoiceRequired}
+
+	resp, err := p.Chat(ctx, req)
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if len(resp.ToolCalls) == 0 {
+		t.Fatalf("ToolCalls is empty; Output = %q", resp.Output)
+	}
+
+	results := make([]core.ToolResult, 0, len(resp.ToolCalls))
+	for _, call := range resp.ToolCalls {
+		if call.ID == "" {
+			t.Error("tool call ID is empty")
+		}
+		if call.Name != weatherToolName {
+			t.Errorf("tool call Name = %q, want %q", call.Name, weatherToolName)
+		}
+		var args struct {
+			Location string `json:"location"`
+		}
+		if err := json.Unmarshal(call.Arguments, &args); err != nil {
+			t.Errorf("tool call Arguments %s are not a JSON object: %v", call.Arguments, err)
+		} else if args.Location == "" {
+			t.Errorf("tool call Arguments %s have no location", call.Arguments)
+		}
+		results = append(results, core.ToolResult{
+			CallID:  call.ID,
+			Content: map[string]any{"temperature_c": 21, "conditions": "sunny"},
+		})
+	}
+	if t.Failed() {
+		return
+	}
+
+	req.ToolChoice = nil
+	req.Messages = append(req.Messages,
+		core.Message{Role: core.RoleAssistant, Content: resp.Output, ToolCalls: resp.ToolCalls},
+		core.Message{Role: core.RoleTool, ToolResults: results},
+	)
+
+	resp, err = p.Chat(ctx, req)
+	if err != nil {
+		t.Fatalf("Chat() with tool results error = %v", err)
+	}
+	if strings.TrimSpace(resp.Output) == "" {
+		t.Errorf("Output after tool results is empty; ToolCalls = %d", len(resp.ToolCalls))
+	}
+}
+
+func testErrorMapping(t *testing.T, _ core.Provider, cfg Config) {
+	if cfg.NewUnauthorizedProvider == nil {
+		t.Skip("Config.NewUnauthorizedProvider not set")
+	}
+	p := cfg.NewUnauthorizedProvider(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	_, err := p.Chat(ctx, userRequest(cfg.Model, "hello"))
+	checkUnauthorized(t, "Chat()", p, err)
+
+	if !p.Supports(core.FeatureChatStreaming) {
+		return
+	}
+	stream, err := p.StreamChat(ctx, userRequest(cfg.Model, "hello"))
+	if err == nil {
+		err = collect(t, stream, cfg.Timeout).err
+	}
+	checkUnauthorized(t, "StreamChat()", p, err)
+}
+
+func checkUnauthorized(t *testing.T, call string, p core.Provider, err error) {
+	t.Helper()
+	if err == nil {
+		t.Errorf("%s with an invalid credential succeeded", call)
+		return
+	}
+	if !errors.Is(err, core.ErrUnauthorized) {
+		t.Errorf("%s error = %v, want one wrapping core.ErrUnauthorized", call, err)
+	}
+	var pe *core.ProviderError
+	if !errors.As(err, &pe) {
+		t.Errorf("%s error = %T, want *core.ProviderError", call, err)
+	} else if pe.Provider != p.ID() {
+		t.Errorf("%s ProviderError.Provider = %q, want %q", call, pe.Provider, p.ID())
+	}
+}
+
+func testContextCancellation(t *testing.T, p core.Provider, cfg Config) {
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	t.Run("Chat", func(t *testing.T) {
+		_, err := p.Chat(cancelled, userRequest(cfg.Model, "hello"))
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Chat() error = %v, want one wrapping context.Canceled", err)
+		}
+	})
+
+	if !p.Supports(core.FeatureChatStreaming) {
+		return
+	}
+
+	t.Run("StreamChat", func(t *testing.T) {
+		stream, err := p.StreamChat(cancelled, userRequest(cfg.Model, "hello"))
+		if err == nil {
+			err = collect(t, stream, cfg.Timeout).err
+		}
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("StreamChat() error = %v, want one wrapping context.Canceled", err)
+		}
+	})
+
+	t.Run("StreamMidway", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		stream, err := p.StreamChat(ctx, userRequest(cfg.Model, "Count from 1 to 300, one number per line."))
+		if err != nil {
+			t.Fatalf("StreamChat() error = %v", err)
+		}
+		select {
+		case <-stream.Ch:
+		case <-time.After(cfg.Timeout):
+			t.Fatal("no chunk received")
+		}
+		cancel()
+
+		// The stream may have finished already; it only has to shut down
+		collect(t, stream, cfg.Timeout)
+	})
+}
+
+// streamResult is everything a stream sent.
+type streamResult struct {
+	deltas []string
+	final  *core.ChatResponse
+	err    error
+}
+
+// collect reads stream until Ch and Err are closed and a final response or
+// error arrived. It fails t if stream breaks the ChatStream contract or does
+// not finish within timeout.
+func collect(t *testing.T, stream *core.ChatStream, timeout time.Duration) streamResult {
+	t.Helper()
+	var res streamResult
+	if stream == nil || stream.Ch == nil || stream.Err == nil || stream.Final == nil {
+		t.Fatal("StreamChat() returned a stream with nil channels")
+	}
+
+	deadline := time.After(timeout)
+	ch, errCh, finalCh := stream.Ch, stream.Err, stream.Final
+	for ch != nil || errCh != nil || (finalCh != nil && res.final == nil && res.err == nil) {
+		select {
+		case chunk, ok := <-ch:
+			if !ok {
+				ch = nil
+				continue
+			}
+			if chunk.Delta != "" {
+				res.deltas = append(res.deltas, chunk.Delta)
+			}
+		case err, ok := <-errCh:
+			if !ok {
+				errCh = nil
+				continue
+			}
+			if err != nil && res.err == nil {
+				res.err = err
+			}
+		case resp, ok := <-finalCh:
+			if !ok {
+				finalCh = nil
+				continue
+			}
+			if res.final != nil {
+				t.Error("stream sent more than one final response")
+			}
+			res.final = resp
+		case <-deadline:
+			switch {
+			case ch != nil:
+				t.Fatal("stream did not close Ch")
+			case errCh != nil:
+				t.Fatal("stream did not close Err")
+			default:
+				t.Fatal("stream sent neither a final response nor an error")
+			}
+		}
+	}
+	return res
+}
+
+func userRequest(model core.ModelID, prompt string) *core.ChatRequest {
+	return &core.ChatRequest{
+		Model:    model,
+		Messages: []core.Message{{Role: core.RoleUser, Content: prompt}},
+	}
+}
+
+const weatherToolName = "get_weather"
+
+// weatherTool is the tool offered in the tool-call test. It is never called;
+// the suite answers with a fixed result.
+type weatherTool struct{}
+
+var _ tools.Tool = weatherTool{}
+
+func (weatherTool) Name() string        { return weatherToolName }
+func (weatherTool) Description() string { return "Get the current weather for a city." }
+
+func (weatherTool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {"location": {"type": "string", "description": "City name"}},
+		"required": ["location"]
+	}`)}
+}
+
+func (weatherTool) Call(context.Context, json.RawMessage) (any, error) {
+	return map[string]any{"temperature_c": 21, "conditions": "sunny"}, nil
+}
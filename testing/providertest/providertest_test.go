@@ -0,0 +1,140 @@
+package providertest
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// fakeProvider is a deterministic provider that follows the contract the
+// suite checks.
+type fakeProvider struct {
+	apiKey string
+}
+
+func (p *fakeProvider) ID() string { return "fake" }
+
+func (p *fakeProvider) Models() []core.ModelInfo {
+	return []core.ModelInfo{{ID: "fake-model"}}
+}
+
+func (p *fakeProvider) Supports(f core.Feature) bool {
+	switch f {
+	case core.FeatureChat, core.FeatureChatStreaming, core.FeatureToolCalling:
+		return true
+	}
+	return false
+}
+
+func (p *fakeProvider) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	if err := p.check(ctx); err != nil {
+		return nil, err
+	}
+	resp := &core.ChatResponse{
+		ID:    "resp-1",
+		Model: req.Model,
+		Usage: core.TokenUsage{PromptTokens: 10, CompletionTokens: 2, TotalTokens: 12},
+	}
+	last := req.Messages[len(req.Messages)-1]
+	if len(req.Tools) > 0 && last.Role == core.RoleUser {
+		resp.ToolCalls = []core.ToolCall{{ID: "call-1", Name: req.Tools[0].Name(), Arguments: json.RawMessage(`{"location":"Paris"}`)}}
+	} else {
+		resp.Output = "hello"
+	}
+	return resp, nil
+}
+
+func (p *fakeProvider) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	if err := p.check(ctx); err != nil {
+		return nil, err
+	}
+	ch := make(chan core.ChatChunk)
+	errCh := make(chan error, 1)
+	finalCh := make(chan *core.ChatResponse, 1)
+
+	go func() {
+		defer close(ch)
+		defer close(errCh)
+		defer close(finalCh)
+
+		n := 5
+		if strings.Contains(req.Messages[0].Content, "300") {
+			n = 300
+		}
+		var out strings.Builder
+		for i := 1; i <= n; i++ {
+			delta := strings.Repeat("x", i%3+1) + "\n"
+			select {
+			case ch <- core.ChatChunk{Delta: delta}:
+				out.WriteString(delta)
+			case <-ctx.Done():
+				errCh <- ctx.Err()
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+		finalCh <- &core.ChatResponse{Output: out.String(), Usage: core.TokenUsage{PromptTokens: 10, CompletionTokens: n, TotalTokens: 10 + n}}
+	}()
+
+	return &core.ChatStream{Ch: ch, Err: errCh, Final: finalCh}, nil
+}
+
+func (p *fakeProvider) check(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if p.apiKey != "valid" {
+		return &core.ProviderError{Provider: p.ID(), Status: 401, Message: "invalid key", Err: core.ErrUnauthorized}
+	}
+	return nil
+}
+
+func TestRun(t *testing.T) {
+	Run(t, Config{
+		NewProvider:             func(*testing.T) core.Provider { return &fakeProvider{apiKey: "valid"} },
+		NewUnauthorizedProvider: func(*testing.T) core.Provider { return &fakeProvider{apiKey: "invalid"} },
+		Model:                   "fake-model",
+		Timeout:                 5 * time.Second,
+	})
+}
+
+func TestRunSkip(t *testing.T) {
+	var ran bool
+	Run(t, Config{
+		NewProvider: func(*testing.T) core.Provider {
+			ran = true
+			return &fakeProvider{apiKey: "valid"}
+		},
+		Model: "fake-model",
+		Skip:  []string{"Metadata", "Chat", "Usage", "Streaming", "ToolCallRoundTrip", "ErrorMapping", "ContextCancellation"},
+	})
+	if ran {
+		t.Error("NewProvider should not be called for skipped tests")
+	}
+}
+
+func TestCollect(t *testing.T) {
+	ch := make(chan core.ChatChunk, 2)
+	errCh := make(chan error)
+	finalCh := make(chan *core.ChatResponse, 1)
+	ch <- core.ChatChunk{Delta: "a"}
+	ch <- core.ChatChunk{Delta: "b"}
+	finalCh <- &core.ChatResponse{Output: "ab"}
+	close(ch)
+	close(errCh)
+
+	res := collect(t, &core.ChatStream{Ch: ch, Err: errCh, Final: finalCh}, time.Second)
+	if got := strings.Join(res.deltas, ""); got != "ab" {
+		t.Errorf("deltas = %q, want %q", got, "ab")
+	}
+	if res.final == nil || res.final.Output != "ab" {
+		t.Errorf("final = %+v, want Output ab", res.final)
+	}
+	if res.err != nil {
+		t.Errorf("err = %v", res.err)
+	}
+}
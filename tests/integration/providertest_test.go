@@ -0,0 +1,43 @@
+//go:build integration
+
+package integration
+
+import (
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/providers/anthropic"
+	"github.com/petal-labs/iris/providers/gemini"
+	"github.com/petal-labs/iris/providers/openai"
+	"github.com/petal-labs/iris/testing/providertest"
+)
+
+func TestOpenAI_ProviderConformance(t *testing.T) {
+	skipIfNoAPIKey(t)
+	apiKey := getAPIKey(t)
+	providertest.Run(t, providertest.Config{
+		NewProvider:             func(*testing.T) core.Provider { return openai.New(apiKey) },
+		NewUnauthorizedProvider: func(*testing.T) core.Provider { return openai.New("sk-invalid") },
+		Model:                   openai.ModelGPT4oMini,
+	})
+}
+
+func TestAnthropic_ProviderConformance(t *testing.T) {
+	skipIfNoAnthropicKey(t)
+	apiKey := getAnthropicKey(t)
+	providertest.Run(t, providertest.Config{
+		NewProvider:             func(*testing.T) core.Provider { return anthropic.New(apiKey) },
+		NewUnauthorizedProvider: func(*testing.T) core.Provider { return anthropic.New("sk-ant-invalid") },
+		Model:                   anthropic.ModelClaudeHaiku45,
+	})
+}
+
+func TestGemini_ProviderConformance(t *testing.T) {
+	skipIfNoGeminiKey(t)
+	apiKey := getGeminiKey(t)
+	providertest.Run(t, providertest.Config{
+		NewProvider: func(*testing.T) core.Provider { return gemini.New(apiKey) },
+		// Gemini reports an invalid API key as 400 INVALID_ARGUMENT
+		Model: gemini.ModelGemini25FlashLite,
+	})
+}
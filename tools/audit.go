@@ -0,0 +1,228 @@
+package tools
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// ToolContextCallerMetadataKey is the ToolContext metadata key from which
+// audit logs read the caller identity by default.
+const ToolContextCallerMetadataKey = "caller"
+
+// ErrAuditChainBroken is returned by VerifyAuditLog when a record does not
+// match its hash or does not follow the previous record.
+var ErrAuditChainBroken = errors.New("audit log chain broken")
+
+// AuditRecord is one entry of an audit log. Records are chained: Hash
+// covers the record, including PrevHash, so changing, removing, or
+// reordering a record breaks every hash after it.
+//
+// # Security
+//
+// Arguments and results are stored only as SHA-256 digests, so the log can
+// prove what a tool was called with without holding the data. Error
+// messages are stored with secrets masked when the middleware runs inside
+// WithSecretScrubbing.
+type AuditRecord struct {
+	Seq          uint64        `json:"seq"`
+	Time         time.Time     `json:"time"`
+	Tool         string        `json:"tool"`
+	CallID       string        `json:"call_id,omitempty"`
+	Caller       string        `json:"caller,omitempty"`
+	ArgsDigest   string        `json:"args_sha256"`
+	ResultDigest string        `json:"result_sha256,omitempty"`
+	Error        string        `json:"error,omitempty"`
+	Duration     time.Duration `json:"duration_ns"`
+	PrevHash     string        `json:"prev_hash"`
+	Hash         string        `json:"hash"`
+}
+
+// computeHash returns the hash of the record with Hash cleared.
+func (r AuditRecord) computeHash() (string, error) {
+	r.Hash = ""
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// AuditOption configures an AuditLog.
+type AuditOption func(*AuditLog)
+
+// WithAuditCaller sets how the caller identity is derived. The default
+// reads ToolContext metadata under ToolContextCallerMetadataKey.
+func WithAuditCaller(fn KeyFunc) AuditOption {
+	return func(a *AuditLog) {
+		a.caller = fn
+	}
+}
+
+// AuditLog writes a tamper-evident, append-only log of tool invocations
+// as JSON Lines, one AuditRecord per call. Use VerifyAuditLog to check a
+// log. AuditLog is safe for concurrent use.
+type AuditLog struct {
+	caller KeyFunc
+
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+	seq    uint64
+	prev   string
+}
+
+// NewAuditLog creates an audit log that starts a new chain on w.
+func NewAuditLog(w io.Writer, opts ...AuditOption) *AuditLog {
+	a := &AuditLog{w: w, caller: KeyFromMetadata(ToolContextCallerMetadataKey)}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// OpenAuditLog opens the audit log file at path for appending, creating it
+// if needed. An existing log is verified first and new records continue
+// its chain; a broken chain is reported as ErrAuditChainBroken. Close the
+// log when done.
+func OpenAuditLog(path string, opts ...AuditOption) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	last, err := verifyAuditLog(f, nil)
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	a := NewAuditLog(f, opts...)
+	a.closer = f
+	if last != nil {
+		a.seq, a.prev = last.Seq, last.Hash
+	}
+	return a, nil
+}
+
+// Close closes the file opened by OpenAuditLog. It does nothing for logs
+// created with NewAuditLog.
+func (a *AuditLog) Close() error {
+	if a.closer == nil {
+		return nil
+	}
+	return a.closer.Close()
+}
+
+// Middleware returns middleware that records every call. A call whose
+// record cannot be written fails with the write error, so no invocation
+// goes unrecorded; the tool has already run by then.
+func (a *AuditLog) Middleware() Middleware {
+	return func(next ToolCallFunc) ToolCallFunc {
+		return func(ctx context.Context, args json.RawMessage) (any, error) {
+			start := time.Now()
+			result, err := next(ctx, args)
+
+			rec := AuditRecord{
+				Time:       start.UTC(),
+				Caller:     a.caller(ctx),
+				ArgsDigest: digest(args),
+				Duration:   time.Since(start),
+			}
+			if tc := ToolContextFromContext(ctx); tc != nil {
+				rec.Tool, rec.CallID = tc.ToolName, tc.CallID
+			}
+			if err != nil {
+				rec.Error = scrubError(ctx, err).Error()
+			} else if data, merr := json.Marshal(result); merr == nil {
+				rec.ResultDigest = digest(data)
+			}
+
+			if werr := a.write(rec); werr != nil {
+				return nil, errors.Join(err, fmt.Errorf("write audit record: %w", werr))
+			}
+			return result, err
+		}
+	}
+}
+
+// write chains rec onto the log and appends it.
+func (a *AuditLog) write(rec AuditRecord) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	rec.Seq = a.seq + 1
+	rec.PrevHash = a.prev
+	hash, err := rec.computeHash()
+	if err != nil {
+		return err
+	}
+	rec.Hash = hash
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if _, err := a.w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	a.seq, a.prev = rec.Seq, rec.Hash
+	return nil
+}
+
+// VerifyAuditLog reads an audit log and checks that every record matches
+// its hash and follows the previous one. It returns the number of valid
+// records; on a mismatch the error wraps ErrAuditChainBroken and names the
+// first bad line.
+func VerifyAuditLog(r io.Reader) (int, error) {
+	n := 0
+	_, err := verifyAuditLog(r, func() { n++ })
+	return n, err
+}
+
+// verifyAuditLog checks the log and returns its last record, or nil if it
+// is empty. each, if not nil, is called for every valid record.
+func verifyAuditLog(r io.Reader, each func()) (*AuditRecord, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var last *AuditRecord
+	for line := 1; scanner.Scan(); line++ {
+		var rec AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return last, fmt.Errorf("%w: line %d: %v", ErrAuditChainBroken, line, err)
+		}
+		want, err := rec.computeHash()
+		if err != nil {
+			return last, err
+		}
+		switch {
+		case rec.Hash != want:
+			return last, fmt.Errorf("%w: line %d: hash mismatch", ErrAuditChainBroken, line)
+		case last == nil && (rec.Seq != 1 || rec.PrevHash != ""):
+			return last, fmt.Errorf("%w: line %d: does not start a chain", ErrAuditChainBroken, line)
+		case last != nil && (rec.Seq != last.Seq+1 || rec.PrevHash != last.Hash):
+			return last, fmt.Errorf("%w: line %d: does not follow record %d", ErrAuditChainBroken, line, last.Seq)
+		}
+		last = &rec
+		if each != nil {
+			each()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return last, fmt.Errorf("read audit log: %w", err)
+	}
+	return last, nil
+}
+
+// digest returns the hex SHA-256 of data.
+func digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
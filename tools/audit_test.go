@@ -0,0 +1,120 @@
+package tools_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+func auditedCall(t *testing.T, tool tools.Tool, caller string, args string) error {
+	t.Helper()
+	ctx := tools.ContextWithToolContext(context.Background(), &tools.ToolContext{
+		CallID:   "call_1",
+		Metadata: map[string]any{tools.ToolContextCallerMetadataKey: caller},
+	})
+	_, err := tool.Call(ctx, json.RawMessage(args))
+	return err
+}
+
+func TestAuditLogRecordsCalls(t *testing.T) {
+	var buf bytes.Buffer
+	audit := tools.NewAuditLog(&buf)
+	ok := tools.ApplyMiddleware(newMockTool("lookup", "Lookup"), audit.Middleware())
+	failing := newMockTool("delete", "Delete")
+	failing.callFn = func(ctx context.Context, args json.RawMessage) (any, error) {
+		return nil, errors.New("permission denied")
+	}
+	bad := tools.ApplyMiddleware(failing, audit.Middleware())
+
+	if err := auditedCall(t, ok, "alice", `{"id":1}`); err != nil {
+		t.Fatal(err)
+	}
+	if err := auditedCall(t, bad, "bob", `{"id":2}`); err == nil {
+		t.Fatal("expected tool error")
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("log has %d lines, want 2:\n%s", len(lines), buf.String())
+	}
+	var first, second tools.AuditRecord
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatal(err)
+	}
+	if first.Seq != 1 || first.Tool != "lookup" || first.Caller != "alice" || first.CallID != "call_1" ||
+		first.ArgsDigest == "" || first.ResultDigest == "" || first.PrevHash != "" {
+		t.Errorf("first record = %+v", first)
+	}
+	if second.Seq != 2 || second.Caller != "bob" || second.Error != "permission denied" || second.PrevHash != first.Hash {
+		t.Errorf("second record = %+v", second)
+	}
+	if strings.Contains(buf.String(), `"id"`) {
+		t.Error("log contains raw arguments")
+	}
+
+	if n, err := tools.VerifyAuditLog(strings.NewReader(buf.String())); err != nil || n != 2 {
+		t.Errorf("VerifyAuditLog() = %d, %v", n, err)
+	}
+}
+
+func TestVerifyAuditLogDetectsTampering(t *testing.T) {
+	var buf bytes.Buffer
+	audit := tools.NewAuditLog(&buf)
+	tool := tools.ApplyMiddleware(newMockTool("lookup", "Lookup"), audit.Middleware())
+	for _, caller := range []string{"alice", "bob", "carol"} {
+		if err := auditedCall(t, tool, caller, `{}`); err != nil {
+			t.Fatal(err)
+		}
+	}
+	lines := strings.SplitAfter(buf.String(), "\n")
+
+	edited := strings.Replace(buf.String(), `"caller":"bob"`, `"caller":"mallory"`, 1)
+	removed := lines[0] + lines[2]
+	for name, log := range map[string]string{"edited": edited, "removed": removed} {
+		if _, err := tools.VerifyAuditLog(strings.NewReader(log)); !errors.Is(err, tools.ErrAuditChainBroken) {
+			t.Errorf("%s: VerifyAuditLog() error = %v, want ErrAuditChainBroken", name, err)
+		}
+	}
+}
+
+func TestOpenAuditLogContinuesChain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	for range 2 {
+		audit, err := tools.OpenAuditLog(path, tools.WithAuditCaller(func(context.Context) string { return "svc" }))
+		if err != nil {
+			t.Fatal(err)
+		}
+		tool := tools.ApplyMiddleware(newMockTool("lookup", "Lookup"), audit.Middleware())
+		if _, err := tool.Call(context.Background(), json.RawMessage(`{}`)); err != nil {
+			t.Fatal(err)
+		}
+		if err := audit.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, err := tools.VerifyAuditLog(bytes.NewReader(data)); err != nil || n != 2 {
+		t.Errorf("VerifyAuditLog() = %d, %v", n, err)
+	}
+
+	if err := os.WriteFile(path, bytes.Replace(data, []byte(`"svc"`), []byte(`"x"`), 1), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tools.OpenAuditLog(path); !errors.Is(err, tools.ErrAuditChainBroken) {
+		t.Errorf("OpenAuditLog() on tampered log error = %v", err)
+	}
+}
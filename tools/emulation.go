@@ -0,0 +1,350 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// Tags that delimit emulated tool calls and results in message text.
+const (
+	toolCallOpen    = "<tool_call>"
+	toolCallClose   = "</tool_call>"
+	toolResultOpen  = "<tool_result"
+	toolResultClose = "</tool_result>"
+)
+
+// toolCallRE matches one emulated tool call block.
+var toolCallRE = regexp.MustCompile(`(?s)<tool_call>\s*(.*?)\s*</tool_call>`)
+
+// EmulationOption configures EmulateToolCalling.
+type EmulationOption func(*EmulatedProvider)
+
+// EmulateAlways emulates tool calling for every model, even those with
+// native function calling. It is useful for testing prompts across
+// providers.
+func EmulateAlways() EmulationOption {
+	return func(p *EmulatedProvider) {
+		p.always = true
+	}
+}
+
+// EmulatedProvider wraps a provider to emulate tool calling for models
+// without native function calling. Create one with EmulateToolCalling.
+// EmulatedProvider is safe for concurrent use if the wrapped provider is.
+type EmulatedProvider struct {
+	provider core.Provider
+	always   bool
+}
+
+// EmulateToolCalling returns a provider that lets ChatRequest.Tools work
+// with models that lack native function calling, such as many models served
+// by Hugging Face or Ollama. Requests with tools are rewritten: the tool
+// schemas are described in a system message, earlier tool calls and results
+// become <tool_call> and <tool_result> blocks in the conversation, and
+// <tool_call> blocks in the model's output are parsed into
+// ChatResponse.ToolCalls.
+//
+// Models with native tool calling (by core.SupportsModel) are passed
+// through unchanged unless EmulateAlways is set. The returned provider
+// reports core.FeatureToolCallingEmulated for models it emulates.
+//
+// Emulation relies on the model following the prompt, so calls may be
+// missing or malformed more often than with native function calling.
+// Blocks that name an unknown tool or are not valid JSON are left in Output.
+func EmulateToolCalling(p core.Provider, opts ...EmulationOption) *EmulatedProvider {
+	e := &EmulatedProvider{provider: p}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// ID returns the wrapped provider's ID.
+func (e *EmulatedProvider) ID() string { return e.provider.ID() }
+
+// Models returns the wrapped provider's models.
+func (e *EmulatedProvider) Models() []core.ModelInfo { return e.provider.Models() }
+
+// Supports reports whether the wrapped provider supports the feature. Tool
+// calling is always supported; FeatureToolCallingEmulated is reported when
+// the wrapped provider has no native tool calling or EmulateAlways is set.
+func (e *EmulatedProvider) Supports(feature core.Feature) bool {
+	switch feature {
+	case core.FeatureToolCalling:
+		return true
+	case core.FeatureToolCallingEmulated:
+		return e.always || !e.provider.Supports(core.FeatureToolCalling)
+	default:
+		return e.provider.Supports(feature)
+	}
+}
+
+// SupportsModel is like Supports for a specific model.
+func (e *EmulatedProvider) SupportsModel(model core.ModelID, feature core.Feature) bool {
+	switch feature {
+	case core.FeatureToolCalling:
+		return true
+	case core.FeatureToolCallingEmulated:
+		return e.emulates(model)
+	default:
+		return core.SupportsModel(e.provider, model, feature)
+	}
+}
+
+// emulates reports whether tool calls for model are emulated.
+func (e *EmulatedProvider) emulates(model core.ModelID) bool {
+	return e.always || !core.SupportsModel(e.provider, model, core.FeatureToolCalling)
+}
+
+// Chat sends a chat request, emulating tool calling if needed.
+func (e *EmulatedProvider) Chat(ctx context.Context, req *core.ChatRequest) (*core.ChatResponse, error) {
+	if len(req.Tools) == 0 || !e.emulates(req.Model) {
+		return e.provider.Chat(ctx, req)
+	}
+	resp, err := e.provider.Chat(ctx, emulatedRequest(req))
+	if err != nil {
+		return nil, err
+	}
+	parseToolCalls(resp, req.Tools)
+	return resp, nil
+}
+
+// StreamChat sends a streaming chat request, emulating tool calling if
+// needed. Text deltas are forwarded until a <tool_call> block starts; the
+// tool calls are reported in the final response.
+func (e *EmulatedProvider) StreamChat(ctx context.Context, req *core.ChatRequest) (*core.ChatStream, error) {
+	if len(req.Tools) == 0 || !e.emulates(req.Model) {
+		return e.provider.StreamChat(ctx, req)
+	}
+	stream, err := e.provider.StreamChat(ctx, emulatedRequest(req))
+	if err != nil {
+		return nil, err
+	}
+
+	ch := make(chan core.ChatChunk)
+	finalCh := make(chan *core.ChatResponse, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		defer close(ch)
+		defer close(errCh)
+		defer close(finalCh)
+
+		var output strings.Builder
+		var pending string
+		inCall := false
+		send := func(chunk core.ChatChunk) bool {
+			select {
+			case ch <- chunk:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for chunk := range stream.Ch {
+			output.WriteString(chunk.Delta)
+			if !inCall {
+				pending += chunk.Delta
+				var text string
+				text, pending, inCall = splitBeforeToolCall(pending)
+				chunk.Delta = text
+			} else {
+				chunk.Delta = ""
+			}
+			if chunk.Delta == "" && chunk.Reasoning == "" && len(chunk.Parts) == 0 {
+				continue
+			}
+			if !send(chunk) {
+				errCh <- ctx.Err()
+				return
+			}
+		}
+		if !inCall && pending != "" && !send(core.ChatChunk{Delta: pending}) {
+			errCh <- ctx.Err()
+			return
+		}
+
+		// Wait for the final response; the chunks were consumed above
+		drained := make(chan core.ChatChunk)
+		close(drained)
+		resp, err := core.DrainStream(ctx, &core.ChatStream{Ch: drained, Err: stream.Err, Final: stream.Final})
+		if err != nil {
+			errCh <- err
+			return
+		}
+		if resp.Output == "" {
+			resp.Output = output.String()
+		}
+		parseToolCalls(resp, req.Tools)
+		finalCh <- resp
+	}()
+
+	return &core.ChatStream{Ch: ch, Err: errCh, Final: finalCh}, nil
+}
+
+// splitBeforeToolCall splits streamed text into the part that can be shown
+// now and the part held back because it is or may become a <tool_call> tag.
+// inCall reports whether a tool call has started.
+func splitBeforeToolCall(s string) (text, held string, inCall bool) {
+	if i := strings.Index(s, toolCallOpen); i >= 0 {
+		return s[:i], s[i:], true
+	}
+	for n := min(len(toolCallOpen)-1, len(s)); n > 0; n-- {
+		if strings.HasSuffix(s, toolCallOpen[:n]) {
+			return s[:len(s)-n], s[len(s)-n:], false
+		}
+	}
+	return s, "", false
+}
+
+// emulatedRequest returns a copy of req with the tools described in a
+// system message and earlier tool turns rendered as text.
+func emulatedRequest(req *core.ChatRequest) *core.ChatRequest {
+	out := *req
+	out.Tools = nil
+	out.ToolChoice = nil
+	out.ParallelToolCalls = nil
+
+	prompt := emulationPrompt(req.Tools, req.ToolChoice)
+	rest := req.Messages
+	msgs := make([]core.Message, 0, len(rest)+1)
+	if len(rest) > 0 && rest[0].Role == core.RoleSystem {
+		system := rest[0]
+		system.Content = strings.TrimSpace(system.Content + "\n\n" + prompt)
+		msgs = append(msgs, system)
+		rest = rest[1:]
+	} else {
+		msgs = append(msgs, core.Message{Role: core.RoleSystem, Content: prompt})
+	}
+
+	for _, msg := range rest {
+		switch {
+		case msg.Role == core.RoleAssistant && len(msg.ToolCalls) > 0:
+			msg.Content = renderToolCalls(msg.Content, msg.ToolCalls)
+			msg.ToolCalls = nil
+		case msg.Role == core.RoleTool:
+			msg = core.Message{Role: core.RoleUser, Content: renderToolResults(msg.ToolResults, req.ToolResultPolicy)}
+		}
+		msgs = append(msgs, msg)
+	}
+	out.Messages = msgs
+	return &out
+}
+
+// emulatedTool is the description of a tool in the emulation prompt.
+type emulatedTool struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Parameters  json.RawMessage `json:"parameters,omitempty"`
+}
+
+// emulationPrompt describes tools and the tool call format to the model.
+func emulationPrompt(tools []core.Tool, choice *core.ToolChoice) string {
+	defs := make([]emulatedTool, len(tools))
+	for i, t := range tools {
+		defs[i] = emulatedTool{Name: t.Name(), Description: t.Description()}
+		if st, ok := t.(interface{ Schema() ToolSchema }); ok {
+			defs[i].Parameters = st.Schema().JSONSchema
+		}
+	}
+	toolsJSON, _ := json.MarshalIndent(defs, "", "  ")
+
+	var b strings.Builder
+	b.WriteString("You can call the tools described below. To call a tool, reply with one block per call in exactly this format, with the arguments as a JSON object matching the tool's parameters:\n\n")
+	b.WriteString(toolCallOpen + "\n{\"name\": \"tool_name\", \"arguments\": {}}\n" + toolCallClose + "\n\n")
+	b.WriteString("Write nothing after the tool call blocks. Tool results are sent back in " + toolResultOpen + "> blocks. When you can answer without a tool, reply normally without any tool call blocks.\n\n")
+	if choice != nil {
+		switch choice.Mode {
+		case core.ToolChoiceRequired:
+			b.WriteString("You must call at least one tool in your reply.\n\n")
+		case core.ToolChoiceTool:
+			fmt.Fprintf(&b, "You must call the %s tool in your reply.\n\n", choice.Name)
+		case core.ToolChoiceNone:
+			b.WriteString("Do not call any tools in this reply.\n\n")
+		}
+	}
+	b.WriteString("Tools:\n")
+	b.Write(toolsJSON)
+	return b.String()
+}
+
+// emulatedCall is the JSON body of a <tool_call> block.
+type emulatedCall struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// renderToolCalls appends tool calls to assistant text as <tool_call>
+// blocks.
+func renderToolCalls(content string, calls []core.ToolCall) string {
+	var b strings.Builder
+	b.WriteString(content)
+	for _, c := range calls {
+		args := c.Arguments
+		if len(args) == 0 {
+			args = json.RawMessage("{}")
+		}
+		body, _ := json.Marshal(emulatedCall{Name: c.Name, Arguments: args})
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(toolCallOpen + "\n")
+		b.Write(body)
+		b.WriteString("\n" + toolCallClose)
+	}
+	return b.String()
+}
+
+// renderToolResults renders tool results as <tool_result> blocks.
+func renderToolResults(results []core.ToolResult, policy *core.ToolResultPolicy) string {
+	blocks := make([]string, len(results))
+	for i, r := range results {
+		content := policy.Serialize(r.Content)
+		if r.IsError {
+			content = "Error: " + content
+		}
+		blocks[i] = fmt.Sprintf("%s id=%q>\n%s\n%s", toolResultOpen, r.CallID, content, toolResultClose)
+	}
+	return strings.Join(blocks, "\n")
+}
+
+// parseToolCalls moves the <tool_call> blocks of resp.Output that name one
+// of tools into resp.ToolCalls. Calls are given IDs unique within the
+// response.
+func parseToolCalls(resp *core.ChatResponse, tools []core.Tool) {
+	known := make(map[string]bool, len(tools))
+	for _, t := range tools {
+		known[t.Name()] = true
+	}
+
+	n := 0
+	output := toolCallRE.ReplaceAllStringFunc(resp.Output, func(block string) string {
+		body := toolCallRE.FindStringSubmatch(block)[1]
+		var call emulatedCall
+		if err := json.Unmarshal([]byte(body), &call); err != nil || !known[call.Name] {
+			return block
+		}
+		args := call.Arguments
+		if len(args) == 0 || string(args) == "null" {
+			args = json.RawMessage("{}")
+		}
+		n++
+		resp.ToolCalls = append(resp.ToolCalls, core.ToolCall{
+			ID:        fmt.Sprintf("call_emulated_%d", n),
+			Name:      call.Name,
+			Arguments: args,
+		})
+		return ""
+	})
+	if n > 0 {
+		resp.Output = strings.TrimSpace(output)
+	}
+}
+
+// Compile-time check that EmulatedProvider implements core.Provider.
+var _ core.Provider = (*EmulatedProvider)(nil)
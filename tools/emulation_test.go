@@ -0,0 +1,190 @@
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/petal-labs/iris/core"
+	iristesting "github.com/petal-labs/iris/testing"
+	"github.com/petal-labs/iris/tools"
+)
+
+func weatherTool() *mockTool {
+	return &mockTool{
+		name:        "get_weather",
+		description: "Get the weather for a city",
+		schema: tools.ToolSchema{
+			JSONSchema: json.RawMessage(`{"type":"object","properties":{"city":{"type":"string"}}}`),
+		},
+	}
+}
+
+func TestEmulateToolCallingParsesCalls(t *testing.T) {
+	mock := iristesting.NewMockProvider(core.ChatResponse{
+		Output: "Let me check.\n<tool_call>\n{\"name\": \"get_weather\", \"arguments\": {\"city\": \"Paris\"}}\n</tool_call>",
+	})
+	p := tools.EmulateToolCalling(mock)
+
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:    "test-model",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Weather in Paris?"}},
+		Tools:    []core.Tool{weatherTool()},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+
+	if len(resp.ToolCalls) != 1 {
+		t.Fatalf("ToolCalls = %v, want 1", resp.ToolCalls)
+	}
+	call := resp.ToolCalls[0]
+	if call.Name != "get_weather" || string(call.Arguments) != `{"city": "Paris"}` || call.ID == "" {
+		t.Errorf("ToolCall = %+v, want get_weather with city Paris and an ID", call)
+	}
+	if resp.Output != "Let me check." {
+		t.Errorf("Output = %q, want text without the tool call block", resp.Output)
+	}
+
+	sent := mock.LastCall().Request
+	if len(sent.Tools) != 0 {
+		t.Errorf("sent Tools = %d, want none", len(sent.Tools))
+	}
+	if sent.Messages[0].Role != core.RoleSystem || !strings.Contains(sent.Messages[0].Content, `"get_weather"`) ||
+		!strings.Contains(sent.Messages[0].Content, `"city"`) {
+		t.Errorf("system message = %q, want tool name and schema", sent.Messages[0].Content)
+	}
+}
+
+func TestEmulateToolCallingRendersToolTurns(t *testing.T) {
+	mock := iristesting.NewMockProvider(core.ChatResponse{Output: "It is sunny."})
+	p := tools.EmulateToolCalling(mock)
+
+	msgs := []core.Message{
+		{Role: core.RoleSystem, Content: "Be brief."},
+		{Role: core.RoleUser, Content: "Weather in Paris?"},
+		{Role: core.RoleAssistant, ToolCalls: []core.ToolCall{{ID: "call_1", Name: "get_weather", Arguments: json.RawMessage(`{"city":"Paris"}`)}}},
+		{Role: core.RoleTool, ToolResults: []core.ToolResult{{CallID: "call_1", Content: map[string]any{"sky": "clear"}}}},
+	}
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:      "test-model",
+		Messages:   msgs,
+		Tools:      []core.Tool{weatherTool()},
+		ToolChoice: &core.ToolChoice{Mode: core.ToolChoiceAuto},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if len(resp.ToolCalls) != 0 || resp.Output != "It is sunny." {
+		t.Errorf("resp = %+v, want plain answer", resp)
+	}
+
+	sent := mock.LastCall().Request.Messages
+	if len(sent) != 4 {
+		t.Fatalf("sent %d messages, want 4", len(sent))
+	}
+	if !strings.HasPrefix(sent[0].Content, "Be brief.") || !strings.Contains(sent[0].Content, "<tool_call>") {
+		t.Errorf("system = %q, want original instructions followed by the tool prompt", sent[0].Content)
+	}
+	if len(sent[2].ToolCalls) != 0 || !strings.Contains(sent[2].Content, `"name":"get_weather"`) {
+		t.Errorf("assistant = %+v, want tool call rendered as text", sent[2])
+	}
+	if sent[3].Role != core.RoleUser || !strings.Contains(sent[3].Content, `id="call_1"`) || !strings.Contains(sent[3].Content, `"sky":"clear"`) {
+		t.Errorf("tool result = %+v, want user message with tool_result block", sent[3])
+	}
+	if msgs[0].Content != "Be brief." || len(msgs[2].ToolCalls) != 1 {
+		t.Error("caller's messages were modified")
+	}
+}
+
+func TestEmulateToolCallingIgnoresUnknownTools(t *testing.T) {
+	output := "<tool_call>{\"name\": \"send_email\", \"arguments\": {}}</tool_call>"
+	p := tools.EmulateToolCalling(iristesting.NewMockProvider(core.ChatResponse{Output: output}))
+
+	resp, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:    "test-model",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+		Tools:    []core.Tool{weatherTool()},
+	})
+	if err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if len(resp.ToolCalls) != 0 || resp.Output != output {
+		t.Errorf("resp = %+v, want the block left in Output", resp)
+	}
+}
+
+func TestEmulateToolCallingPassesThroughNativeModels(t *testing.T) {
+	mock := iristesting.NewMockProvider(core.ChatResponse{Output: "native"}).
+		WithFeatures(core.FeatureChat, core.FeatureToolCalling)
+	p := tools.EmulateToolCalling(mock)
+
+	if p.Supports(core.FeatureToolCallingEmulated) {
+		t.Error("Supports(FeatureToolCallingEmulated) = true for a native provider")
+	}
+	if _, err := p.Chat(context.Background(), &core.ChatRequest{
+		Model:    "test-model",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Hi"}},
+		Tools:    []core.Tool{weatherTool()},
+	}); err != nil {
+		t.Fatalf("Chat() error = %v", err)
+	}
+	if sent := mock.LastCall().Request; len(sent.Tools) != 1 {
+		t.Errorf("sent Tools = %d, want the native tools", len(sent.Tools))
+	}
+
+	always := tools.EmulateToolCalling(mock, tools.EmulateAlways())
+	if !always.Supports(core.FeatureToolCallingEmulated) || !always.SupportsModel("test-model", core.FeatureToolCallingEmulated) {
+		t.Error("EmulateAlways provider does not report FeatureToolCallingEmulated")
+	}
+}
+
+func TestEmulateToolCallingReportsFeatures(t *testing.T) {
+	mock := iristesting.NewMockProvider().WithFeatures(core.FeatureChat, core.FeatureChatStreaming)
+	p := tools.EmulateToolCalling(mock)
+
+	for _, f := range []core.Feature{core.FeatureChat, core.FeatureToolCalling, core.FeatureToolCallingEmulated} {
+		if !p.Supports(f) || !core.SupportsModel(p, "test-model", f) {
+			t.Errorf("Supports(%q) = false, want true", f)
+		}
+	}
+	if p.Supports(core.FeatureVision) {
+		t.Error("Supports(FeatureVision) = true, want the wrapped provider's answer")
+	}
+}
+
+func TestEmulateToolCallingStream(t *testing.T) {
+	mock := iristesting.NewMockProvider().WithStreamingResponse(
+		[]string{"Checking", " now.<tool", "_call>{\"name\": \"get_weather\",", " \"arguments\": {\"city\": \"Oslo\"}}</tool_call>"},
+		nil,
+	)
+	p := tools.EmulateToolCalling(mock)
+
+	stream, err := p.StreamChat(context.Background(), &core.ChatRequest{
+		Model:    "test-model",
+		Messages: []core.Message{{Role: core.RoleUser, Content: "Weather in Oslo?"}},
+		Tools:    []core.Tool{weatherTool()},
+	})
+	if err != nil {
+		t.Fatalf("StreamChat() error = %v", err)
+	}
+
+	var shown strings.Builder
+	for chunk := range stream.Ch {
+		shown.WriteString(chunk.Delta)
+	}
+	if shown.String() != "Checking now." {
+		t.Errorf("streamed text = %q, want text before the tool call", shown.String())
+	}
+	if err := <-stream.Err; err != nil {
+		t.Fatalf("stream error = %v", err)
+	}
+	final := <-stream.Final
+	if len(final.ToolCalls) != 1 || string(final.ToolCalls[0].Arguments) != `{"city": "Oslo"}` {
+		t.Errorf("final ToolCalls = %+v, want get_weather for Oslo", final.ToolCalls)
+	}
+	if final.Output != "Checking now." {
+		t.Errorf("final Output = %q, want text without the tool call", final.Output)
+	}
+}
@@ -0,0 +1,202 @@
+package tools
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultNamespaceSeparator joins a group namespace and a tool name, as in
+// "fs__read". OpenAI and Anthropic only accept letters, digits, '_' and '-'
+// in tool names, so the default avoids '.'; use WithNamespaceSeparator for
+// providers that accept other characters.
+const DefaultNamespaceSeparator = "__"
+
+// ConflictPolicy decides what happens when a tool is registered under a
+// name that is already taken.
+type ConflictPolicy int
+
+const (
+	// ConflictError rejects the tool with ErrDuplicateTool. It is the
+	// default.
+	ConflictError ConflictPolicy = iota
+
+	// ConflictReplace replaces the registered tool with the new one.
+	ConflictReplace
+
+	// ConflictKeepExisting keeps the registered tool and silently skips the
+	// new one.
+	ConflictKeepExisting
+)
+
+// WithConflictPolicy sets how Register and RegisterGroup handle names that
+// are already registered.
+func WithConflictPolicy(p ConflictPolicy) RegistryOption {
+	return func(r *Registry) {
+		r.conflicts = p
+	}
+}
+
+// WithNamespaceSeparator sets the separator between group namespaces and
+// tool names. The default is DefaultNamespaceSeparator.
+func WithNamespaceSeparator(sep string) RegistryOption {
+	return func(r *Registry) {
+		r.separator = sep
+	}
+}
+
+// Group is a set of tools registered together under a namespace, so large
+// tool libraries can be composed without name collisions:
+//
+//	fs := tools.Group{
+//	    Namespace:  "fs",
+//	    Tools:      []tools.Tool{readTool, writeTool},
+//	    Middleware: []tools.Middleware{tools.WithTimeout(2 * time.Second)},
+//	}
+//	web := tools.Group{Namespace: "web", Tools: []tools.Tool{searchTool}}
+//
+//	err := registry.RegisterGroup(tools.Group{Groups: []tools.Group{fs, web}})
+//	// registers fs__read, fs__write, and web__search
+type Group struct {
+	// Namespace prefixes the names of the group's tools. An empty
+	// namespace registers them under their own names.
+	Namespace string
+
+	// Tools are the group's tools.
+	Tools []Tool
+
+	// Groups are nested groups. Their namespaces are prefixed with this
+	// group's namespace.
+	Groups []Group
+
+	// Middleware wraps every tool of the group, including nested groups.
+	// It runs inside registry middleware and outside the middleware of
+	// nested groups.
+	Middleware []Middleware
+}
+
+// RegisterGroup registers the tools of g and its nested groups under their
+// namespaced names. Registration is all or nothing: if the conflict policy
+// rejects any name, including a name repeated within g, no tool is
+// registered and the error wraps ErrDuplicateTool.
+func (r *Registry) RegisterGroup(g Group) error {
+	r.mu.RLock()
+	sep := r.separator
+	r.mu.RUnlock()
+
+	var staged []Tool
+	if err := g.collect(sep, "", nil, &staged); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	pending := make(map[string]int, len(staged))
+	var accepted []Tool
+	for _, t := range staged {
+		name := t.Name()
+		add, err := r.resolveConflict(name, pending)
+		if err != nil {
+			r.mu.Unlock()
+			return fmt.Errorf("%w: %s", err, name)
+		}
+		if !add {
+			continue
+		}
+		if i, ok := pending[name]; ok {
+			accepted[i] = t
+			continue
+		}
+		pending[name] = len(accepted)
+		accepted = append(accepted, t)
+	}
+
+	changes := make([]RegistryChange, len(accepted))
+	for i, t := range accepted {
+		changes[i] = r.add(t)
+	}
+	r.mu.Unlock()
+
+	r.notify(changes...)
+	return nil
+}
+
+// ListNamespace returns the registered tools whose names start with
+// namespace and the separator, including those of nested groups.
+func (r *Registry) ListNamespace(namespace string) []Tool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	prefix := namespace + r.separator
+	var result []Tool
+	for name, t := range r.tools {
+		if strings.HasPrefix(name, prefix) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// resolveConflict reports whether a tool named name should be added,
+// applying the conflict policy if the name is registered or pending.
+// r.mu must be held.
+func (r *Registry) resolveConflict(name string, pending map[string]int) (bool, error) {
+	_, registered := r.tools[name]
+	_, staged := pending[name]
+	if !registered && !staged {
+		return true, nil
+	}
+	switch r.conflicts {
+	case ConflictReplace:
+		return true, nil
+	case ConflictKeepExisting:
+		return false, nil
+	default:
+		return false, ErrDuplicateTool
+	}
+}
+
+// collect appends the namespaced, wrapped tools of g and its nested groups
+// to out.
+func (g Group) collect(sep, prefix string, outer []Middleware, out *[]Tool) error {
+	ns := prefix
+	if g.Namespace != "" {
+		if ns != "" {
+			ns += sep
+		}
+		ns += g.Namespace
+	}
+	mws := append(append([]Middleware(nil), outer...), g.Middleware...)
+
+	for _, t := range g.Tools {
+		if t == nil {
+			return errors.New("tool cannot be nil")
+		}
+		if ns != "" {
+			t = &namespacedTool{Tool: t, name: ns + sep + t.Name()}
+		}
+		*out = append(*out, ApplyMiddleware(t, mws...))
+	}
+	for _, sub := range g.Groups {
+		if err := sub.collect(sep, ns, mws, out); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// namespacedTool registers a tool under a namespaced name.
+type namespacedTool struct {
+	Tool
+	name string
+}
+
+func (n *namespacedTool) Name() string { return n.name }
+
+// OutputSchema forwards the tool's output schema.
+func (n *namespacedTool) OutputSchema() ToolSchema {
+	schema, _ := OutputSchemaOf(n.Tool)
+	return schema
+}
+
+// Scopes forwards the tool's permission scopes.
+func (n *namespacedTool) Scopes() []string { return ScopesOf(n.Tool) }
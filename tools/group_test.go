@@ -0,0 +1,158 @@
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+func toolNames(ts []tools.Tool) []string {
+	names := make([]string, len(ts))
+	for i, t := range ts {
+		names[i] = t.Name()
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestRegisterGroupNamespaces(t *testing.T) {
+	r := tools.NewRegistry()
+	err := r.RegisterGroup(tools.Group{
+		Tools: []tools.Tool{newMockTool("echo", "")},
+		Groups: []tools.Group{
+			{Namespace: "fs", Tools: []tools.Tool{newMockTool("read", ""), newMockTool("write", "")}},
+			{Namespace: "web", Groups: []tools.Group{
+				{Namespace: "search", Tools: []tools.Tool{newMockTool("news", "")}},
+			}},
+		},
+	})
+	if err != nil {
+		t.Fatalf("RegisterGroup() error = %v", err)
+	}
+
+	want := []string{"echo", "fs__read", "fs__write", "web__search__news"}
+	if got := toolNames(r.List()); !slices.Equal(got, want) {
+		t.Errorf("names = %v, want %v", got, want)
+	}
+	if got := toolNames(r.ListNamespace("web")); !slices.Equal(got, []string{"web__search__news"}) {
+		t.Errorf("ListNamespace(web) = %v", got)
+	}
+	if _, err := r.Execute(context.Background(), "fs__read", json.RawMessage(`{}`)); err != nil {
+		t.Errorf("Execute(fs__read) error = %v", err)
+	}
+}
+
+func TestRegisterGroupSeparator(t *testing.T) {
+	r := tools.NewRegistry(tools.WithNamespaceSeparator("."))
+	if err := r.RegisterGroup(tools.Group{Namespace: "fs", Tools: []tools.Tool{newMockTool("read", "")}}); err != nil {
+		t.Fatalf("RegisterGroup() error = %v", err)
+	}
+	if _, ok := r.Get("fs.read"); !ok {
+		t.Error("fs.read not registered")
+	}
+}
+
+func TestRegisterGroupMiddlewareOrder(t *testing.T) {
+	var order []string
+	mark := func(name string) tools.Middleware {
+		return func(next tools.ToolCallFunc) tools.ToolCallFunc {
+			return func(ctx context.Context, args json.RawMessage) (any, error) {
+				order = append(order, name)
+				if tc := tools.ToolContextFromContext(ctx); tc == nil || tc.ToolName != "outer__inner__t" {
+					t.Errorf("ToolContext = %+v, want ToolName outer__inner__t", tc)
+				}
+				return next(ctx, args)
+			}
+		}
+	}
+
+	r := tools.NewRegistry(tools.WithRegistryMiddleware(mark("registry")))
+	err := r.RegisterGroup(tools.Group{
+		Namespace:  "outer",
+		Middleware: []tools.Middleware{mark("outer")},
+		Groups: []tools.Group{{
+			Namespace:  "inner",
+			Middleware: []tools.Middleware{mark("inner")},
+			Tools:      []tools.Tool{newMockTool("t", "")},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("RegisterGroup() error = %v", err)
+	}
+	if _, err := r.Execute(context.Background(), "outer__inner__t", nil); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	if want := []string{"registry", "outer", "inner"}; !slices.Equal(order, want) {
+		t.Errorf("order = %v, want %v", order, want)
+	}
+}
+
+func TestRegisterGroupScopesForwarded(t *testing.T) {
+	r := tools.NewRegistry()
+	scoped := tools.WithScopes(newMockTool("read", ""), "fs:read")
+	if err := r.RegisterGroup(tools.Group{Namespace: "fs", Tools: []tools.Tool{scoped}}); err != nil {
+		t.Fatalf("RegisterGroup() error = %v", err)
+	}
+	tool, _ := r.Get("fs__read")
+	if got := tools.ScopesOf(tool); !slices.Equal(got, []string{"fs:read"}) {
+		t.Errorf("ScopesOf() = %v, want [fs:read]", got)
+	}
+}
+
+func TestRegisterGroupConflicts(t *testing.T) {
+	group := tools.Group{Namespace: "fs", Tools: []tools.Tool{newMockTool("read", "new"), newMockTool("write", "new")}}
+
+	t.Run("error is all or nothing", func(t *testing.T) {
+		r := tools.NewRegistry()
+		_ = r.Register(newMockTool("fs__read", "old"))
+		err := r.RegisterGroup(group)
+		if !errors.Is(err, tools.ErrDuplicateTool) {
+			t.Fatalf("RegisterGroup() error = %v, want ErrDuplicateTool", err)
+		}
+		if _, ok := r.Get("fs__write"); ok {
+			t.Error("fs__write registered despite the conflict")
+		}
+	})
+
+	t.Run("duplicate within group", func(t *testing.T) {
+		r := tools.NewRegistry()
+		err := r.RegisterGroup(tools.Group{Tools: []tools.Tool{newMockTool("a", ""), newMockTool("a", "")}})
+		if !errors.Is(err, tools.ErrDuplicateTool) {
+			t.Errorf("RegisterGroup() error = %v, want ErrDuplicateTool", err)
+		}
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		r := tools.NewRegistry(tools.WithConflictPolicy(tools.ConflictReplace))
+		_ = r.Register(newMockTool("fs__read", "old"))
+		if err := r.RegisterGroup(group); err != nil {
+			t.Fatalf("RegisterGroup() error = %v", err)
+		}
+		if tool, _ := r.Get("fs__read"); tool.Description() != "new" {
+			t.Errorf("fs__read description = %q, want new", tool.Description())
+		}
+	})
+
+	t.Run("keep existing", func(t *testing.T) {
+		r := tools.NewRegistry(tools.WithConflictPolicy(tools.ConflictKeepExisting))
+		_ = r.Register(newMockTool("fs__read", "old"))
+		if err := r.RegisterGroup(group); err != nil {
+			t.Fatalf("RegisterGroup() error = %v", err)
+		}
+		if tool, _ := r.Get("fs__read"); tool.Description() != "old" {
+			t.Errorf("fs__read description = %q, want old", tool.Description())
+		}
+		if _, ok := r.Get("fs__write"); !ok {
+			t.Error("fs__write not registered")
+		}
+		if err := r.Register(newMockTool("fs__write", "newer")); err != nil {
+			t.Errorf("Register() error = %v, want nil", err)
+		}
+	})
+}
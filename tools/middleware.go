@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"maps"
 )
 
 // ToolCallFunc is the function signature for tool execution.
@@ -31,6 +32,9 @@ type ToolContext struct {
 	// Schema is the tool's JSON schema for this invocation.
 	Schema json.RawMessage
 
+	// OutputSchema is the tool's declared result schema, if it implements TypedTool.
+	OutputSchema json.RawMessage
+
 	// Metadata allows middleware to share data with each other.
 	Metadata map[string]any
 }
@@ -154,24 +158,45 @@ func (w *wrappedTool) Name() string        { return w.tool.Name() }
 func (w *wrappedTool) Description() string { return w.tool.Description() }
 func (w *wrappedTool) Schema() ToolSchema  { return w.tool.Schema() }
 
+// OutputSchema forwards the wrapped tool's output schema so middleware does not
+// hide it. Untyped tools report an empty schema.
+func (w *wrappedTool) OutputSchema() ToolSchema {
+	schema, _ := OutputSchemaOf(w.tool)
+	return schema
+}
+
+// Scopes forwards the wrapped tool's permission scopes.
+func (w *wrappedTool) Scopes() []string { return ScopesOf(w.tool) }
+
 func (w *wrappedTool) Call(ctx context.Context, args json.RawMessage) (any, error) {
-	// Ensure ToolContext exists.
-	tc := ToolContextFromContext(ctx)
-	if tc == nil {
-		tc = &ToolContext{
-			ToolName: w.tool.Name(),
-			Metadata: make(map[string]any),
-		}
-		ctx = ContextWithToolContext(ctx, tc)
-	} else {
-		if tc.ToolName == "" {
-			tc.ToolName = w.tool.Name()
-		}
-		if tc.Metadata == nil {
-			tc.Metadata = make(map[string]any)
-		}
+	return w.wrapped(withToolContext(ctx, w.tool), args)
+}
+
+// withToolContext returns ctx with a ToolContext for t: a copy of the one
+// already in ctx, if any, with t's name and input and output schemas. The
+// caller's ToolContext is never modified, so one can be shared by calls to
+// several tools, including concurrent ones.
+func withToolContext(ctx context.Context, t Tool) context.Context {
+	tc := cloneToolContext(ToolContextFromContext(ctx))
+	tc.ToolName = t.Name()
+	setToolSchema(tc, t.Schema().JSONSchema)
+	tc.OutputSchema = nil
+	if schema, ok := OutputSchemaOf(t); ok {
+		tc.OutputSchema = cloneRawMessage(schema.JSONSchema)
 	}
+	return ContextWithToolContext(ctx, tc)
+}
 
-	setToolSchema(tc, w.tool.Schema().JSONSchema)
-	return w.wrapped(ctx, args)
+// cloneToolContext returns a copy of tc with its own Metadata map, or a new
+// ToolContext if tc is nil.
+func cloneToolContext(tc *ToolContext) *ToolContext {
+	var out ToolContext
+	if tc != nil {
+		out = *tc
+	}
+	out.Metadata = maps.Clone(out.Metadata)
+	if out.Metadata == nil {
+		out.Metadata = make(map[string]any)
+	}
+	return &out
 }
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBulkheadFull is returned when a call cannot get an execution slot
+// because the queue is full or it waited longer than BulkheadConfig.MaxWait.
+var ErrBulkheadFull = errors.New("tool concurrency limit reached")
+
+// BulkheadConfig configures concurrency limiting.
+type BulkheadConfig struct {
+	MaxConcurrent int           // Simultaneous executions; at least 1.
+	MaxWaiting    int           // Calls that may queue for a slot; 0 rejects at once, negative means no limit.
+	MaxWait       time.Duration // How long a call may queue; 0 means until its context ends.
+}
+
+// WithConcurrencyLimit creates middleware that allows at most n
+// simultaneous executions, queuing further calls until a slot frees up or
+// their context ends. See WithBulkhead.
+func WithConcurrencyLimit(n int) Middleware {
+	return WithBulkhead(BulkheadConfig{MaxConcurrent: n, MaxWaiting: -1})
+}
+
+// WithBulkhead creates middleware that bounds simultaneous executions,
+// isolating scarce resources such as a pool of headless browsers. Calls
+// beyond MaxConcurrent queue for a slot, subject to MaxWaiting and
+// MaxWait, and fail with ErrBulkheadFull when they cannot get a slot.
+//
+// All tools wrapped with the same Middleware value share the limit. A slot
+// is held until the tool returns; place WithTimeout outside this
+// middleware so that abandoned calls keep their slot until they finish.
+func WithBulkhead(config BulkheadConfig) Middleware {
+	b := &bulkhead{
+		slots:      make(chan struct{}, max(config.MaxConcurrent, 1)),
+		maxWaiting: config.MaxWaiting,
+		maxWait:    config.MaxWait,
+	}
+	return func(next ToolCallFunc) ToolCallFunc {
+		return func(ctx context.Context, args json.RawMessage) (any, error) {
+			if err := b.acquire(ctx); err != nil {
+				return nil, err
+			}
+			defer b.release()
+			return next(ctx, args)
+		}
+	}
+}
+
+// bulkhead is a counting semaphore with a bounded queue.
+type bulkhead struct {
+	slots      chan struct{}
+	maxWaiting int
+	maxWait    time.Duration
+
+	mu      sync.Mutex
+	waiting int
+}
+
+func (b *bulkhead) acquire(ctx context.Context) error {
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	default:
+	}
+
+	b.mu.Lock()
+	if b.maxWaiting >= 0 && b.waiting >= b.maxWaiting {
+		b.mu.Unlock()
+		return ErrBulkheadFull
+	}
+	b.waiting++
+	b.mu.Unlock()
+	defer func() {
+		b.mu.Lock()
+		b.waiting--
+		b.mu.Unlock()
+	}()
+
+	var expired <-chan time.Time
+	if b.maxWait > 0 {
+		timer := time.NewTimer(b.maxWait)
+		defer timer.Stop()
+		expired = timer.C
+	}
+
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	case <-expired:
+		return fmt.Errorf("%w: waited %v", ErrBulkheadFull, b.maxWait)
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for tool concurrency slot: %w", ctx.Err())
+	}
+}
+
+func (b *bulkhead) release() {
+	<-b.slots
+}
@@ -36,6 +36,11 @@ type CircuitBreakerConfig struct {
 	FailureThreshold int           // Failures before opening.
 	SuccessThreshold int           // Successes in half-open to close.
 	OpenDuration     time.Duration // How long to stay open.
+
+	// OnStateChange, if set, is called after every state transition. It
+	// runs synchronously on the calling goroutine, outside the breaker's
+	// lock, so it must be fast.
+	OnStateChange func(CircuitStateChange)
 }
 
 // DefaultCircuitBreakerConfig returns sensible circuit breaker defaults.
@@ -50,69 +55,160 @@ func DefaultCircuitBreakerConfig() CircuitBreakerConfig {
 // ErrCircuitOpen is returned when the circuit breaker is open.
 var ErrCircuitOpen = errors.New("circuit breaker open: too many failures")
 
+// CircuitStateChange describes a circuit breaker state transition.
+type CircuitStateChange struct {
+	From     CircuitState
+	To       CircuitState
+	ToolName string    // Tool whose call caused the transition, if known.
+	At       time.Time // When the transition happened.
+	Failures int       // Consecutive failures at the time of the transition.
+}
+
+// CircuitStats is a snapshot of a circuit breaker's state.
+type CircuitStats struct {
+	State          CircuitState
+	Failures       int       // Consecutive failures counted toward FailureThreshold.
+	Successes      int       // Successes counted toward SuccessThreshold while half-open.
+	LastFailure    time.Time // Time of the most recent failure; zero if none.
+	LastTransition time.Time // When State was entered; zero if the breaker never changed state.
+}
+
+// CircuitBreaker implements the circuit breaker pattern and exposes its
+// state, for example so that dashboards can show which tools are tripped.
+// All tools wrapped with its Middleware share one breaker.
+// CircuitBreaker is safe for concurrent use.
+type CircuitBreaker struct {
+	config CircuitBreakerConfig
+
+	mu             sync.Mutex
+	state          CircuitState
+	failures       int
+	successes      int
+	lastFailure    time.Time
+	lastTransition time.Time
+}
+
+// NewCircuitBreaker creates a closed circuit breaker.
+func NewCircuitBreaker(config CircuitBreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{config: config}
+}
+
 // WithCircuitBreaker creates middleware that implements the circuit breaker pattern.
+// Use NewCircuitBreaker instead to observe the breaker's state.
 func WithCircuitBreaker(config CircuitBreakerConfig) Middleware {
-	var (
-		mu          sync.Mutex
-		state       CircuitState
-		failures    int
-		successes   int
-		lastFailure time.Time
-	)
+	return NewCircuitBreaker(config).Middleware()
+}
+
+// State returns the breaker's current state. An open breaker reports
+// CircuitOpen until the next call after OpenDuration moves it to half-open.
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.state
+}
+
+// Stats returns a snapshot of the breaker's state and counters.
+func (cb *CircuitBreaker) Stats() CircuitStats {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return CircuitStats{
+		State:          cb.state,
+		Failures:       cb.failures,
+		Successes:      cb.successes,
+		LastFailure:    cb.lastFailure,
+		LastTransition: cb.lastTransition,
+	}
+}
 
+// Middleware returns middleware that runs calls through the breaker.
+func (cb *CircuitBreaker) Middleware() Middleware {
 	return func(next ToolCallFunc) ToolCallFunc {
 		return func(ctx context.Context, args json.RawMessage) (any, error) {
-			mu.Lock()
+			toolName := ""
+			if tc := ToolContextFromContext(ctx); tc != nil {
+				toolName = tc.ToolName
+			}
+
+			cb.mu.Lock()
 
 			// Check if circuit should transition from open to half-open.
-			if state == CircuitOpen && time.Since(lastFailure) > config.OpenDuration {
-				state = CircuitHalfOpen
-				successes = 0
+			var changes []CircuitStateChange
+			if cb.state == CircuitOpen && time.Since(cb.lastFailure) > cb.config.OpenDuration {
+				changes = append(changes, cb.transition(CircuitHalfOpen, toolName))
+				cb.successes = 0
 			}
 
 			// Reject if circuit is open.
-			if state == CircuitOpen {
-				mu.Unlock()
+			if cb.state == CircuitOpen {
+				cb.mu.Unlock()
 				return nil, ErrCircuitOpen
 			}
 
-			mu.Unlock()
+			cb.mu.Unlock()
+			cb.notify(changes)
 
 			// Execute tool.
 			result, err := next(ctx, args)
 
-			mu.Lock()
-			defer mu.Unlock()
-
+			cb.mu.Lock()
+			changes = changes[:0]
 			if err != nil {
-				failures++
-				lastFailure = time.Now()
+				cb.failures++
+				cb.lastFailure = time.Now()
 
-				if state == CircuitHalfOpen {
+				if cb.state == CircuitHalfOpen {
 					// Failure in half-open returns to open.
-					state = CircuitOpen
-				} else if failures >= config.FailureThreshold {
+					changes = append(changes, cb.transition(CircuitOpen, toolName))
+				} else if cb.state == CircuitClosed && cb.failures >= cb.config.FailureThreshold {
 					// Too many failures, open circuit.
-					state = CircuitOpen
+					changes = append(changes, cb.transition(CircuitOpen, toolName))
 				}
-
+				cb.mu.Unlock()
+				cb.notify(changes)
 				return nil, err
 			}
 
 			// Success.
-			if state == CircuitHalfOpen {
-				successes++
-				if successes >= config.SuccessThreshold {
+			if cb.state == CircuitHalfOpen {
+				cb.successes++
+				if cb.successes >= cb.config.SuccessThreshold {
 					// Enough successes, close circuit.
-					state = CircuitClosed
-					failures = 0
+					changes = append(changes, cb.transition(CircuitClosed, toolName))
+					cb.failures = 0
 				}
 			} else {
 				// Reset failure count on success in closed state.
-				failures = 0
+				cb.failures = 0
 			}
+			cb.mu.Unlock()
+			cb.notify(changes)
 
 			return result, nil
 		}
 	}
 }
+
+// transition moves the breaker to state and returns the change.
+// cb.mu must be held.
+func (cb *CircuitBreaker) transition(to CircuitState, toolName string) CircuitStateChange {
+	change := CircuitStateChange{
+		From:     cb.state,
+		To:       to,
+		ToolName: toolName,
+		At:       time.Now(),
+		Failures: cb.failures,
+	}
+	cb.state = to
+	cb.lastTransition = change.At
+	return change
+}
+
+// notify reports changes to the state change listener, if any.
+func (cb *CircuitBreaker) notify(changes []CircuitStateChange) {
+	if cb.config.OnStateChange == nil {
+		return
+	}
+	for _, c := range changes {
+		cb.config.OnStateChange(c)
+	}
+}
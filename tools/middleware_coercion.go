@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// WithCoercion creates middleware that converts arguments toward a schema
+// before calling the tool, so that common model mistakes such as numbers
+// sent as strings or omitted defaults do not fail the call. See
+// schema.Validator.Coerce for the conversions. A nil schema means the
+// tool's own schema from the ToolContext.
+//
+// Arguments that are not valid JSON, or a schema that does not compile,
+// are passed through unchanged. Registry.Execute validates arguments
+// before calling the tool and its middleware, so registries should use
+// WithArgumentCoercion instead.
+func WithCoercion(s json.RawMessage) Middleware {
+	c := &coercer{}
+	return func(next ToolCallFunc) ToolCallFunc {
+		return func(ctx context.Context, args json.RawMessage) (any, error) {
+			target := s
+			if len(target) == 0 {
+				target, _ = ToolSchemaFromContext(ctx)
+			}
+			return next(ctx, c.coerce(target, args))
+		}
+	}
+}
+
+// coercer converts arguments toward schemas, caching compiled schemas.
+type coercer struct {
+	schemas JSONSchemaValidator
+}
+
+// coerce returns args converted toward s, or args unchanged if that fails.
+// Empty args are treated as an empty object so that defaults are filled.
+func (c *coercer) coerce(s json.RawMessage, args json.RawMessage) json.RawMessage {
+	if len(s) == 0 {
+		return args
+	}
+	compiled, err := c.schemas.compile(s)
+	if err != nil {
+		return args
+	}
+	data := args
+	if len(data) == 0 {
+		data = json.RawMessage(`{}`)
+	}
+	out, err := compiled.Coerce(data)
+	if err != nil || len(args) == 0 && string(out) == "{}" {
+		return args
+	}
+	return out
+}
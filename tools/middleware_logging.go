@@ -12,6 +12,7 @@ type Logger interface {
 }
 
 // WithLogging creates middleware that logs tool calls.
+// Inside WithSecretScrubbing, secrets in logged errors are masked.
 func WithLogging(logger Logger) Middleware {
 	return func(next ToolCallFunc) ToolCallFunc {
 		return func(ctx context.Context, args json.RawMessage) (any, error) {
@@ -28,7 +29,7 @@ func WithLogging(logger Logger) Middleware {
 
 			duration := time.Since(start)
 			if err != nil {
-				logger.Printf("tool call error: %s, duration=%v, error=%v", toolName, duration, err)
+				logger.Printf("tool call error: %s, duration=%v, error=%v", toolName, duration, scrubError(ctx, err))
 			} else {
 				logger.Printf("tool call success: %s, duration=%v", toolName, duration)
 			}
@@ -39,7 +40,8 @@ func WithLogging(logger Logger) Middleware {
 }
 
 // WithDetailedLogging creates middleware that logs tool calls with arguments.
-// WARNING: May log sensitive data. Use only in development.
+// WARNING: May log sensitive data. Use only in development, or inside
+// WithSecretScrubbing to mask secrets in the logged arguments and results.
 func WithDetailedLogging(logger Logger) Middleware {
 	return func(next ToolCallFunc) ToolCallFunc {
 		return func(ctx context.Context, args json.RawMessage) (any, error) {
@@ -49,17 +51,17 @@ func WithDetailedLogging(logger Logger) Middleware {
 				toolName = tc.ToolName
 			}
 
-			logger.Printf("tool call: %s, args=%s", toolName, string(args))
+			logger.Printf("tool call: %s, args=%s", toolName, Scrub(ctx, string(args)))
 			start := time.Now()
 
 			result, err := next(ctx, args)
 
 			duration := time.Since(start)
 			if err != nil {
-				logger.Printf("tool error: %s, duration=%v, error=%v", toolName, duration, err)
+				logger.Printf("tool error: %s, duration=%v, error=%v", toolName, duration, scrubError(ctx, err))
 			} else {
 				resultJSON, _ := json.Marshal(result)
-				logger.Printf("tool result: %s, duration=%v, result=%s", toolName, duration, string(resultJSON))
+				logger.Printf("tool result: %s, duration=%v, result=%s", toolName, duration, Scrub(ctx, string(resultJSON)))
 			}
 
 			return result, err
@@ -13,6 +13,7 @@ type MetricsCollector interface {
 }
 
 // WithMetrics creates middleware that records tool execution metrics.
+// Inside WithSecretScrubbing, secrets in recorded errors are masked.
 func WithMetrics(collector MetricsCollector) Middleware {
 	return func(next ToolCallFunc) ToolCallFunc {
 		return func(ctx context.Context, args json.RawMessage) (any, error) {
@@ -26,7 +27,7 @@ func WithMetrics(collector MetricsCollector) Middleware {
 			result, err := next(ctx, args)
 			duration := time.Since(start)
 
-			collector.RecordCall(toolName, duration, err)
+			collector.RecordCall(toolName, duration, scrubError(ctx, err))
 			return result, err
 		}
 	}
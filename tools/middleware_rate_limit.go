@@ -35,6 +35,91 @@ func WithRateLimiter(limiter RateLimiter) Middleware {
 	}
 }
 
+// KeyFunc derives a grouping key for a tool call, such as a tenant or user
+// ID, from its context.
+type KeyFunc func(ctx context.Context) string
+
+// KeyFromMetadata returns a KeyFunc that reads the ToolContext metadata
+// value stored under name, formatted with fmt.Sprint. Calls without the
+// value get the empty key.
+func KeyFromMetadata(name string) KeyFunc {
+	return func(ctx context.Context) string {
+		tc := ToolContextFromContext(ctx)
+		if tc == nil {
+			return ""
+		}
+		v, ok := tc.Metadata[name]
+		if !ok || v == nil {
+			return ""
+		}
+		return fmt.Sprint(v)
+	}
+}
+
+// WithKeyedRateLimit creates middleware that rate limits tool calls
+// separately for each key returned by keyFunc, so that one noisy tenant
+// cannot starve the others. Each key gets its own token bucket with the
+// same rate (calls per second) and burst as WithRateLimit; calls with the
+// empty key share one bucket. Idle buckets are discarded.
+func WithKeyedRateLimit(keyFunc KeyFunc, ratePerSecond float64) Middleware {
+	limiter := &keyedLimiter{
+		rate:      ratePerSecond,
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+	return func(next ToolCallFunc) ToolCallFunc {
+		return func(ctx context.Context, args json.RawMessage) (any, error) {
+			key := keyFunc(ctx)
+			if err := limiter.bucket(key).Wait(ctx); err != nil {
+				return nil, fmt.Errorf("rate limit exceeded for %q: %w", key, err)
+			}
+			return next(ctx, args)
+		}
+	}
+}
+
+// keyedSweepInterval is how often a keyedLimiter discards idle buckets.
+const keyedSweepInterval = time.Minute
+
+// keyedLimiter holds a token bucket per key.
+type keyedLimiter struct {
+	mu        sync.Mutex
+	rate      float64
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+// bucket returns the bucket for key, creating it if needed.
+func (k *keyedLimiter) bucket(key string) *tokenBucket {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+
+	if now := time.Now(); now.Sub(k.lastSweep) >= keyedSweepInterval {
+		k.sweep()
+		k.lastSweep = now
+	}
+	tb, ok := k.buckets[key]
+	if !ok {
+		tb = newTokenBucket(k.rate)
+		k.buckets[key] = tb
+	}
+	return tb
+}
+
+// sweep discards buckets holding at least as many tokens as a new bucket,
+// so dropping them never lets a key exceed its rate. k.mu must be held.
+func (k *keyedLimiter) sweep() {
+	for key, tb := range k.buckets {
+		tb.mu.Lock()
+		tb.refill()
+		idle := tb.tokens >= tb.refillRate
+		tb.mu.Unlock()
+		if idle {
+			delete(k.buckets, key)
+		}
+	}
+}
+
 // tokenBucket implements a simple token bucket rate limiter.
 type tokenBucket struct {
 	mu         sync.Mutex
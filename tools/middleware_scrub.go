@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"regexp"
+	"slices"
+
+	"github.com/petal-labs/iris/core"
+)
+
+// ToolContextScrubberMetadataKey is the metadata key under which
+// WithSecretScrubbing stores its scrubber for other middleware (see Scrub).
+const ToolContextScrubberMetadataKey = "secret_scrubber"
+
+// ScrubOption configures WithSecretScrubbing.
+type ScrubOption func(*scrubber)
+
+// ScrubAllowResults lets the named tools return secrets to the model, for
+// tools whose purpose is to hand out a credential. Their arguments are still
+// masked in logs and metrics.
+func ScrubAllowResults(toolNames ...string) ScrubOption {
+	return func(s *scrubber) {
+		s.allowed = append(s.allowed, toolNames...)
+	}
+}
+
+// WithSecretScrubbing creates middleware that keeps secrets out of logs,
+// metrics, and the model context. Text matching patterns, in addition to
+// core.DefaultRedactPatterns, is replaced with core.Redacted:
+//   - in the arguments and results that WithDetailedLogging logs, and in the
+//     errors that WithLogging logs and WithMetrics records, when those run
+//     inside this middleware
+//   - in tool results and errors, so that secrets a tool echoes are not fed
+//     back to the model, unless the tool is allowed by ScrubAllowResults
+//
+// The tool itself receives the arguments unchanged. Place this middleware
+// before logging and metrics middleware in the chain. Custom middleware can
+// mask text the same way with Scrub.
+//
+// String results are scrubbed in place. Other results are JSON-encoded and,
+// if they contained a secret, returned as the scrubbed json.RawMessage.
+func WithSecretScrubbing(patterns []*regexp.Regexp, opts ...ScrubOption) Middleware {
+	s := &scrubber{patterns: append(slices.Clone(core.DefaultRedactPatterns), patterns...)}
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return func(next ToolCallFunc) ToolCallFunc {
+		return func(ctx context.Context, args json.RawMessage) (any, error) {
+			// Install the scrubber on a copy, since the caller's ToolContext
+			// may be shared by concurrent calls
+			tc := cloneToolContext(ToolContextFromContext(ctx))
+			tc.Metadata[ToolContextScrubberMetadataKey] = s
+			ctx = ContextWithToolContext(ctx, tc)
+
+			result, err := next(ctx, args)
+			if slices.Contains(s.allowed, tc.ToolName) {
+				return result, err
+			}
+			return s.scrubResult(result), s.scrubError(err)
+		}
+	}
+}
+
+// Scrub masks the secrets in text using the scrubber installed by an
+// enclosing WithSecretScrubbing middleware. Without one, text is returned
+// unchanged.
+func Scrub(ctx context.Context, text string) string {
+	if s := scrubberFromContext(ctx); s != nil {
+		return s.scrub(text)
+	}
+	return text
+}
+
+func scrubberFromContext(ctx context.Context) *scrubber {
+	tc := ToolContextFromContext(ctx)
+	if tc == nil {
+		return nil
+	}
+	s, _ := tc.Metadata[ToolContextScrubberMetadataKey].(*scrubber)
+	return s
+}
+
+// scrubError returns err with secrets masked in its message, using the
+// scrubber in ctx if any.
+func scrubError(ctx context.Context, err error) error {
+	if s := scrubberFromContext(ctx); s != nil {
+		return s.scrubError(err)
+	}
+	return err
+}
+
+// scrubber masks text matching any of its patterns.
+type scrubber struct {
+	patterns []*regexp.Regexp
+	allowed  []string
+}
+
+func (s *scrubber) scrub(text string) string {
+	for _, re := range s.patterns {
+		text = re.ReplaceAllString(text, core.Redacted)
+	}
+	return text
+}
+
+// scrubJSON masks secrets in raw, keeping it valid JSON.
+func (s *scrubber) scrubJSON(raw []byte) []byte {
+	out := raw
+	for _, re := range s.patterns {
+		out = re.ReplaceAll(out, []byte(core.Redacted))
+	}
+	if !bytes.Equal(out, raw) && !json.Valid(out) {
+		return []byte(`"` + core.Redacted + `"`)
+	}
+	return out
+}
+
+func (s *scrubber) scrubResult(result any) any {
+	switch r := result.(type) {
+	case nil:
+		return nil
+	case string:
+		return s.scrub(r)
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return result
+	}
+	if out := s.scrubJSON(data); !bytes.Equal(out, data) {
+		return json.RawMessage(out)
+	}
+	return result
+}
+
+func (s *scrubber) scrubError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if msg := s.scrub(err.Error()); msg != err.Error() {
+		return &scrubbedError{msg: msg, err: err}
+	}
+	return err
+}
+
+// scrubbedError replaces the message of an error that contained a secret.
+// errors.Is and errors.As still see the original.
+type scrubbedError struct {
+	msg string
+	err error
+}
+
+func (e *scrubbedError) Error() string { return e.msg }
+func (e *scrubbedError) Unwrap() error { return e.err }
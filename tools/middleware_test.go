@@ -5,12 +5,16 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log"
+	"regexp"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/petal-labs/iris/schema"
 )
 
 // mockTool is a test implementation of Tool.
@@ -348,6 +352,133 @@ func TestWithRateLimitContextCancellation(t *testing.T) {
 	}
 }
 
+func TestWithKeyedRateLimit(t *testing.T) {
+	tool := &mockTool{name: "search"}
+	wrapped := ApplyMiddleware(tool, WithKeyedRateLimit(KeyFromMetadata("tenant"), 1))
+
+	call := func(tenant string) error {
+		ctx := ContextWithToolContext(context.Background(), &ToolContext{
+			Metadata: map[string]any{"tenant": tenant},
+		})
+		ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+		defer cancel()
+		_, err := wrapped.Call(ctx, json.RawMessage(`{}`))
+		return err
+	}
+
+	if err := call("a"); err != nil {
+		t.Fatalf("first call for a: %v", err)
+	}
+	if err := call("a"); err == nil || !strings.Contains(err.Error(), `"a"`) {
+		t.Errorf("second call for a error = %v, want rate limit", err)
+	}
+	if err := call("b"); err != nil {
+		t.Errorf("first call for b: %v", err)
+	}
+}
+
+func TestKeyFromMetadata(t *testing.T) {
+	key := KeyFromMetadata("user")
+	if got := key(context.Background()); got != "" {
+		t.Errorf("key without ToolContext = %q", got)
+	}
+	ctx := ContextWithToolContext(context.Background(), &ToolContext{Metadata: map[string]any{"user": 42}})
+	if got := key(ctx); got != "42" {
+		t.Errorf("key = %q, want 42", got)
+	}
+}
+
+func TestKeyedLimiterSweep(t *testing.T) {
+	k := &keyedLimiter{rate: 100, buckets: make(map[string]*tokenBucket)}
+	k.bucket("idle")
+	busy := k.bucket("busy")
+	drained := 0
+	for busy.Allow() {
+		drained++
+	}
+	k.sweep()
+	if _, ok := k.buckets["idle"]; ok {
+		t.Error("idle bucket not swept")
+	}
+	if _, ok := k.buckets["busy"]; !ok {
+		t.Errorf("bucket drained of %d tokens swept", drained)
+	}
+}
+
+func TestWithConcurrencyLimit(t *testing.T) {
+	var running, peak atomic.Int32
+	release := make(chan struct{})
+	tool := &mockTool{
+		name: "browser",
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			n := running.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			<-release
+			running.Add(-1)
+			return "ok", nil
+		},
+	}
+	wrapped := ApplyMiddleware(tool, WithConcurrencyLimit(2))
+
+	var wg sync.WaitGroup
+	for range 5 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := wrapped.Call(context.Background(), json.RawMessage(`{}`)); err != nil {
+				t.Errorf("Call() error = %v", err)
+			}
+		}()
+	}
+	for running.Load() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	wg.Wait()
+
+	if got := peak.Load(); got != 2 {
+		t.Errorf("peak concurrency = %d, want 2", got)
+	}
+}
+
+func TestWithBulkheadRejects(t *testing.T) {
+	block := make(chan struct{})
+	started := make(chan struct{})
+	tool := &mockTool{
+		name: "browser",
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			close(started)
+			<-block
+			return "ok", nil
+		},
+	}
+
+	for _, config := range []BulkheadConfig{
+		{MaxConcurrent: 1},
+		{MaxConcurrent: 1, MaxWaiting: -1, MaxWait: 10 * time.Millisecond},
+	} {
+		block, started = make(chan struct{}), make(chan struct{})
+		wrapped := ApplyMiddleware(tool, WithBulkhead(config))
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = wrapped.Call(context.Background(), json.RawMessage(`{}`))
+		}()
+		<-started
+
+		if _, err := wrapped.Call(context.Background(), json.RawMessage(`{}`)); !errors.Is(err, ErrBulkheadFull) {
+			t.Errorf("%+v: Call() error = %v, want ErrBulkheadFull", config, err)
+		}
+		close(block)
+		<-done
+	}
+}
+
 func TestTokenBucket(t *testing.T) {
 	tb := newTokenBucket(10.0) // 10 tokens/second, max 20
 
@@ -567,6 +698,359 @@ func TestWithValidationReturnsValidationError(t *testing.T) {
 	}
 }
 
+func TestJSONSchemaValidator(t *testing.T) {
+	v := NewJSONSchemaValidator()
+	s := json.RawMessage(`{"type":"object","properties":{"city":{"type":"string"}},"required":["city"]}`)
+
+	if err := v.Validate(s, json.RawMessage(`{"city":"SF"}`)); err != nil {
+		t.Errorf("Validate(valid) error = %v", err)
+	}
+	err := v.Validate(s, json.RawMessage(`{"city":42}`))
+	var verr *schema.ValidationError
+	if !errors.As(err, &verr) || verr.Path != "/city" {
+		t.Errorf("Validate(invalid) error = %v, want ValidationError at /city", err)
+	}
+	if err := v.Validate(s, nil); err == nil || !strings.Contains(err.Error(), `missing required property "city"`) {
+		t.Errorf("Validate(empty) error = %v", err)
+	}
+	if err := v.Validate(json.RawMessage(`{"pattern":"("}`), json.RawMessage(`"x"`)); !errors.Is(err, schema.ErrInvalidSchema) {
+		t.Errorf("Validate(bad schema) error = %v, want ErrInvalidSchema", err)
+	}
+}
+
+func TestRegistryExecuteValidatesArguments(t *testing.T) {
+	called := false
+	tool := &mockTool{
+		name:   "weather",
+		schema: ToolSchema{JSONSchema: json.RawMessage(`{"type":"object","properties":{"city":{"type":"string"}},"required":["city"]}`)},
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			called = true
+			return "sunny", nil
+		},
+	}
+	r := NewRegistry()
+	if err := r.Register(tool); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := r.Execute(context.Background(), "weather", json.RawMessage(`{"town":"SF"}`))
+	if !errors.Is(err, ErrInvalidArguments) {
+		t.Fatalf("Execute() error = %v, want ErrInvalidArguments", err)
+	}
+	if called {
+		t.Error("tool called with invalid arguments")
+	}
+
+	got, err := r.Execute(context.Background(), "weather", json.RawMessage(`{"city":"SF"}`))
+	if err != nil || got != "sunny" {
+		t.Errorf("Execute() = %v, %v; want sunny", got, err)
+	}
+}
+
+func TestRegistryExecuteWithoutArgumentValidation(t *testing.T) {
+	tool := &mockTool{
+		name:   "weather",
+		schema: ToolSchema{JSONSchema: json.RawMessage(`{"type":"object","required":["city"]}`)},
+	}
+	r := NewRegistry(WithoutArgumentValidation())
+	if err := r.Register(tool); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Execute(context.Background(), "weather", json.RawMessage(`{}`)); err != nil {
+		t.Errorf("Execute() error = %v", err)
+	}
+
+	validator := &mockSchemaValidator{err: errors.New("custom")}
+	r = NewRegistry(WithArgumentValidator(validator))
+	if err := r.Register(tool); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Execute(context.Background(), "weather", json.RawMessage(`{}`)); !errors.Is(err, ErrInvalidArguments) || validator.calls != 1 {
+		t.Errorf("Execute() error = %v, validator calls = %d", err, validator.calls)
+	}
+}
+
+func TestRegistryExecuteSetsToolContext(t *testing.T) {
+	const s = `{"type":"object"}`
+	var got json.RawMessage
+	var name string
+	tool := &mockTool{
+		name:   "plain",
+		schema: ToolSchema{JSONSchema: json.RawMessage(s)},
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			got, _ = ToolSchemaFromContext(ctx)
+			name = ToolContextFromContext(ctx).ToolName
+			return nil, nil
+		},
+	}
+	r := NewRegistry()
+	if err := r.Register(tool); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Execute(context.Background(), "plain", json.RawMessage(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != s || name != "plain" {
+		t.Errorf("ToolContext schema = %s, name = %q", got, name)
+	}
+}
+
+func TestRegistryExecuteSharedToolContext(t *testing.T) {
+	r := NewRegistry()
+	for _, name := range []string{"a", "b"} {
+		tool := &mockTool{
+			name:   name,
+			schema: ToolSchema{JSONSchema: json.RawMessage(`{"title":"` + name + `"}`)},
+			callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+				tc := ToolContextFromContext(ctx)
+				schema, _ := ToolSchemaFromContext(ctx)
+				return tc.ToolName + " " + string(schema) + " " + fmt.Sprint(tc.Metadata["tenant"]), nil
+			},
+		}
+		if err := r.Register(tool); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// One ToolContext carrying tenant metadata, shared by parallel calls
+	shared := &ToolContext{CallID: "call_1", Metadata: map[string]any{"tenant": "acme"}}
+	ctx := ContextWithToolContext(context.Background(), shared)
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		name := []string{"a", "b"}[i%2]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := r.Execute(ctx, name, json.RawMessage(`{}`))
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if want := name + ` {"title":"` + name + `"} acme`; result != want {
+				t.Errorf("Execute(%q) = %q, want %q", name, result, want)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if shared.ToolName != "" || shared.Schema != nil || len(shared.Metadata) != 1 {
+		t.Errorf("shared ToolContext modified: %+v", shared)
+	}
+}
+
+func TestWithSecretScrubbing(t *testing.T) {
+	const key = "sk-abcdefghijklmnopqrstuvwxyz"
+	var gotArgs json.RawMessage
+	tool := &mockTool{
+		name: "echo",
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			gotArgs = args
+			return map[string]string{"token": key, "pin": "1234"}, nil
+		},
+	}
+	var buf bytes.Buffer
+	logger := log.New(&buf, "", 0)
+	wrapped := ApplyMiddleware(tool,
+		WithSecretScrubbing([]*regexp.Regexp{regexp.MustCompile(`\b\d{4}\b`)}),
+		WithDetailedLogging(logger),
+	)
+
+	args := json.RawMessage(`{"key":"` + key + `"}`)
+	result, err := wrapped.Call(context.Background(), args)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(gotArgs) != string(args) {
+		t.Errorf("tool args = %s, want unchanged", gotArgs)
+	}
+	raw, ok := result.(json.RawMessage)
+	if !ok || string(raw) != `{"pin":"[REDACTED]","token":"[REDACTED]"}` {
+		t.Errorf("result = %#v", result)
+	}
+	if strings.Contains(buf.String(), key) || strings.Contains(buf.String(), "1234") {
+		t.Errorf("secret logged: %s", buf.String())
+	}
+}
+
+func TestWithSecretScrubbingErrors(t *testing.T) {
+	const key = "sk-abcdefghijklmnopqrstuvwxyz"
+	sentinel := errors.New("upstream failed")
+	tool := &mockTool{
+		name: "fail",
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			return nil, fmt.Errorf("%w with key %s", sentinel, key)
+		},
+	}
+	collector := &mockMetricsCollector{}
+	wrapped := ApplyMiddleware(tool, WithSecretScrubbing(nil), WithMetrics(collector))
+
+	_, err := wrapped.Call(context.Background(), nil)
+	if !errors.Is(err, sentinel) {
+		t.Errorf("error = %v, want to wrap sentinel", err)
+	}
+	if strings.Contains(err.Error(), key) {
+		t.Errorf("secret in error: %v", err)
+	}
+	if len(collector.calls) != 1 || strings.Contains(collector.calls[0].err.Error(), key) {
+		t.Errorf("metrics calls = %+v", collector.calls)
+	}
+}
+
+func TestWithSecretScrubbingSharedToolContext(t *testing.T) {
+	const key = "sk-abcdefghijklmnopqrstuvwxyz"
+	call := Chain(WithSecretScrubbing(nil))(func(ctx context.Context, args json.RawMessage) (any, error) {
+		return Scrub(ctx, string(args)), nil
+	})
+
+	// Chain skips the copy ApplyMiddleware makes, so the middleware sees the
+	// caller's ToolContext directly
+	shared := &ToolContext{ToolName: "echo", Metadata: map[string]any{"tenant": "acme"}}
+	ctx := ContextWithToolContext(context.Background(), shared)
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := call(ctx, json.RawMessage(`"`+key+`"`))
+			if err != nil || strings.Contains(result.(string), key) {
+				t.Errorf("call() = %v, %v, want scrubbed", result, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if _, ok := shared.Metadata[ToolContextScrubberMetadataKey]; ok || len(shared.Metadata) != 1 {
+		t.Errorf("shared ToolContext metadata modified: %v", shared.Metadata)
+	}
+}
+
+func TestScrubAllowResults(t *testing.T) {
+	const key = "sk-abcdefghijklmnopqrstuvwxyz"
+	tool := &mockTool{
+		name: "vault",
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			return key, nil
+		},
+	}
+	wrapped := ApplyMiddleware(tool, WithSecretScrubbing(nil, ScrubAllowResults("vault")))
+	result, err := wrapped.Call(context.Background(), nil)
+	if err != nil || result != key {
+		t.Errorf("Call() = %v, %v; want the key unchanged", result, err)
+	}
+
+	if got := Scrub(context.Background(), key); got != key {
+		t.Errorf("Scrub() without middleware = %q", got)
+	}
+}
+
+func TestWithCoercion(t *testing.T) {
+	const s = `{"type":"object","properties":{"days":{"type":"integer"},"units":{"type":"string","default":"celsius"}}}`
+	var got json.RawMessage
+	tool := &mockTool{
+		name:   "forecast",
+		schema: ToolSchema{JSONSchema: json.RawMessage(s)},
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			got = args
+			return nil, nil
+		},
+	}
+
+	for _, wrapped := range []Tool{
+		ApplyMiddleware(tool, WithCoercion(nil)),
+		ApplyMiddleware(&mockTool{name: "forecast", callFn: tool.callFn}, WithCoercion(json.RawMessage(s))),
+	} {
+		if _, err := wrapped.Call(context.Background(), json.RawMessage(`{"days":"3"}`)); err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != `{"days":3,"units":"celsius"}` {
+			t.Errorf("tool args = %s", got)
+		}
+	}
+
+	wrapped := ApplyMiddleware(tool, WithCoercion(nil))
+	if _, err := wrapped.Call(context.Background(), json.RawMessage(`not json`)); err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != `not json` {
+		t.Errorf("invalid args = %s, want unchanged", got)
+	}
+}
+
+func TestRegistryArgumentCoercion(t *testing.T) {
+	tool := &mockTool{
+		name:   "forecast",
+		schema: ToolSchema{JSONSchema: json.RawMessage(`{"type":"object","properties":{"days":{"type":"integer"}},"required":["days"]}`)},
+	}
+
+	r := NewRegistry()
+	_ = r.Register(tool)
+	if _, err := r.Execute(context.Background(), "forecast", json.RawMessage(`{"days":"3"}`)); !errors.Is(err, ErrInvalidArguments) {
+		t.Errorf("Execute() without coercion error = %v, want ErrInvalidArguments", err)
+	}
+
+	r = NewRegistry(WithArgumentCoercion())
+	_ = r.Register(tool)
+	if _, err := r.Execute(context.Background(), "forecast", json.RawMessage(`{"days":"3"}`)); err != nil {
+		t.Errorf("Execute() with coercion error = %v", err)
+	}
+}
+
+// outputTypedTool is a mockTool that declares an output schema.
+type outputTypedTool struct {
+	mockTool
+	output ToolSchema
+}
+
+func (t *outputTypedTool) OutputSchema() ToolSchema { return t.output }
+
+func TestWithOutputValidation(t *testing.T) {
+	validator := &mockSchemaValidator{}
+	tool := &outputTypedTool{
+		mockTool: mockTool{
+			name: "typed_tool",
+			callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+				return map[string]int{"count": 3}, nil
+			},
+		},
+		output: ToolSchema{JSONSchema: json.RawMessage(`{"type":"object"}`)},
+	}
+
+	wrapped := ApplyMiddleware(tool, WithOutputValidation(validator))
+	if _, err := wrapped.Call(context.Background(), json.RawMessage(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if validator.calls != 1 {
+		t.Fatalf("validator calls = %d, want 1", validator.calls)
+	}
+	if string(validator.lastSchema) != `{"type":"object"}` {
+		t.Errorf("validator schema = %s", validator.lastSchema)
+	}
+	if string(validator.lastData) != `{"count":3}` {
+		t.Errorf("validator data = %s", validator.lastData)
+	}
+
+	validator.err = errors.New("bad result")
+	_, err := wrapped.Call(context.Background(), json.RawMessage(`{}`))
+	if err == nil || !strings.Contains(err.Error(), "result validation failed") {
+		t.Errorf("expected result validation error, got: %v", err)
+	}
+}
+
+func TestWithOutputValidationSkipsUntypedTools(t *testing.T) {
+	validator := &mockSchemaValidator{err: errors.New("should not be called")}
+	tool := &mockTool{name: "plain_tool"}
+
+	wrapped := ApplyMiddleware(tool, WithOutputValidation(validator))
+	if _, err := wrapped.Call(context.Background(), json.RawMessage(`{}`)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if validator.calls != 0 {
+		t.Errorf("validator calls = %d, want 0", validator.calls)
+	}
+}
+
 // -----------------------------------------------------------------------------
 // Metrics Middleware Tests
 // -----------------------------------------------------------------------------
@@ -900,6 +1384,64 @@ func TestWithCircuitBreakerHalfOpen(t *testing.T) {
 	}
 }
 
+func TestCircuitBreakerObservability(t *testing.T) {
+	fail := true
+	tool := &mockTool{
+		name: "flaky",
+		callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+			if fail {
+				return nil, errors.New("unavailable")
+			}
+			return "ok", nil
+		},
+	}
+
+	var changes []CircuitStateChange
+	cb := NewCircuitBreaker(CircuitBreakerConfig{
+		FailureThreshold: 2,
+		SuccessThreshold: 1,
+		OpenDuration:     10 * time.Millisecond,
+		OnStateChange:    func(c CircuitStateChange) { changes = append(changes, c) },
+	})
+	wrapped := ApplyMiddleware(tool, cb.Middleware())
+
+	_, _ = wrapped.Call(context.Background(), nil)
+	if stats := cb.Stats(); stats.State != CircuitClosed || stats.Failures != 1 || stats.LastFailure.IsZero() {
+		t.Errorf("Stats() after one failure = %+v", stats)
+	}
+	_, _ = wrapped.Call(context.Background(), nil)
+	if cb.State() != CircuitOpen {
+		t.Fatalf("State() = %v, want open", cb.State())
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	fail = false
+	if _, err := wrapped.Call(context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	stats := cb.Stats()
+	if stats.State != CircuitClosed || stats.Failures != 0 || stats.LastTransition.IsZero() {
+		t.Errorf("Stats() after recovery = %+v", stats)
+	}
+
+	want := []struct{ from, to CircuitState }{
+		{CircuitClosed, CircuitOpen},
+		{CircuitOpen, CircuitHalfOpen},
+		{CircuitHalfOpen, CircuitClosed},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %+v", changes)
+	}
+	for i, w := range want {
+		if changes[i].From != w.from || changes[i].To != w.to || changes[i].ToolName != "flaky" {
+			t.Errorf("change %d = %+v, want %v -> %v", i, changes[i], w.from, w.to)
+		}
+	}
+	if changes[0].Failures != 2 {
+		t.Errorf("open transition Failures = %d, want 2", changes[0].Failures)
+	}
+}
+
 func TestCircuitStateString(t *testing.T) {
 	tests := []struct {
 		state CircuitState
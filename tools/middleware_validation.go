@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
+
+	"github.com/petal-labs/iris/schema"
 )
 
 // SchemaValidator validates arguments against a JSON schema.
@@ -12,7 +15,49 @@ type SchemaValidator interface {
 	Validate(schema json.RawMessage, data json.RawMessage) error
 }
 
+// JSONSchemaValidator is a SchemaValidator backed by package schema. It
+// compiles each distinct schema once and reuses it, so one validator can
+// serve every tool in a registry. Mismatches are reported as
+// schema.ValidationError values. It is safe for concurrent use.
+type JSONSchemaValidator struct {
+	compiled sync.Map // string(schema) -> *schema.Validator
+}
+
+// NewJSONSchemaValidator creates a JSONSchemaValidator.
+func NewJSONSchemaValidator() *JSONSchemaValidator {
+	return &JSONSchemaValidator{}
+}
+
+// Validate checks data against s. Empty data is validated as an empty
+// object, since models omit the arguments of tools that take none.
+func (v *JSONSchemaValidator) Validate(s json.RawMessage, data json.RawMessage) error {
+	compiled, err := v.compile(s)
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		data = json.RawMessage(`{}`)
+	}
+	return compiled.Validate(data)
+}
+
+// compile returns the compiled form of s, compiling it on first use.
+func (v *JSONSchemaValidator) compile(s json.RawMessage) (*schema.Validator, error) {
+	if compiled, ok := v.compiled.Load(string(s)); ok {
+		return compiled.(*schema.Validator), nil
+	}
+	c, err := schema.Compile(s)
+	if err != nil {
+		return nil, err
+	}
+	compiled, _ := v.compiled.LoadOrStore(string(s), c)
+	return compiled.(*schema.Validator), nil
+}
+
 // WithValidation creates middleware that validates arguments against the tool's schema.
+// Registry.Execute already validates with a JSONSchemaValidator by default;
+// this middleware suits tools called outside a registry, or registries
+// created with WithoutArgumentValidation.
 func WithValidation(validator SchemaValidator) Middleware {
 	return func(next ToolCallFunc) ToolCallFunc {
 		return func(ctx context.Context, args json.RawMessage) (any, error) {
@@ -30,6 +75,35 @@ func WithValidation(validator SchemaValidator) Middleware {
 	}
 }
 
+// WithOutputValidation creates middleware that validates tool results against
+// the tool's declared output schema (see TypedTool). Results are JSON-encoded
+// before validation. Tools without an output schema are passed through.
+func WithOutputValidation(validator SchemaValidator) Middleware {
+	return func(next ToolCallFunc) ToolCallFunc {
+		return func(ctx context.Context, args json.RawMessage) (any, error) {
+			result, err := next(ctx, args)
+			if err != nil {
+				return result, err
+			}
+
+			tc := ToolContextFromContext(ctx)
+			if tc == nil || len(tc.OutputSchema) == 0 {
+				return result, nil
+			}
+
+			data, err := json.Marshal(result)
+			if err != nil {
+				return nil, fmt.Errorf("result validation failed: %w", err)
+			}
+			if err := validator.Validate(tc.OutputSchema, data); err != nil {
+				return nil, fmt.Errorf("result validation failed: %w", err)
+			}
+
+			return result, nil
+		}
+	}
+}
+
 // WithBasicValidation creates middleware that performs basic JSON validation.
 func WithBasicValidation() Middleware {
 	return func(next ToolCallFunc) ToolCallFunc {
@@ -41,3 +115,6 @@ func WithBasicValidation() Middleware {
 		}
 	}
 }
+
+// Compile-time check that JSONSchemaValidator implements SchemaValidator.
+var _ SchemaValidator = (*JSONSchemaValidator)(nil)
@@ -0,0 +1,372 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ErrPermissionDenied is returned when a permission policy blocks a tool call.
+var ErrPermissionDenied = errors.New("tool permission denied")
+
+// PermissionAction is the outcome of evaluating a permission rule.
+type PermissionAction string
+
+const (
+	// PermissionAllow lets the call proceed.
+	PermissionAllow PermissionAction = "allow"
+	// PermissionDeny blocks the call.
+	PermissionDeny PermissionAction = "deny"
+	// PermissionAsk defers the decision to an Approver.
+	PermissionAsk PermissionAction = "ask"
+)
+
+// IsValid reports whether the action is a recognized value.
+func (a PermissionAction) IsValid() bool {
+	switch a {
+	case PermissionAllow, PermissionDeny, PermissionAsk:
+		return true
+	default:
+		return false
+	}
+}
+
+// ScopedTool is an optional interface for tools that declare capability scopes
+// such as "network" or "filesystem:write". Permission rules can match on scopes
+// instead of individual tool names.
+type ScopedTool interface {
+	Tool
+
+	// Scopes returns the capability labels for this tool.
+	Scopes() []string
+}
+
+// ScopesOf returns the scopes declared by t, or nil if t is not a ScopedTool.
+func ScopesOf(t Tool) []string {
+	st, ok := t.(ScopedTool)
+	if !ok {
+		return nil
+	}
+	return st.Scopes()
+}
+
+// WithScopes wraps a tool so it reports the given scopes.
+// Scopes already declared by the tool are kept.
+func WithScopes(t Tool, scopes ...string) Tool {
+	merged := append(append([]string(nil), ScopesOf(t)...), scopes...)
+	return &scopedTool{Tool: t, scopes: merged}
+}
+
+type scopedTool struct {
+	Tool
+	scopes []string
+}
+
+func (s *scopedTool) Scopes() []string {
+	return append([]string(nil), s.scopes...)
+}
+
+// OutputSchema forwards the wrapped tool's output schema.
+func (s *scopedTool) OutputSchema() ToolSchema {
+	schema, _ := OutputSchemaOf(s.Tool)
+	return schema
+}
+
+// ArgumentMatcher matches a single argument value of a tool call.
+type ArgumentMatcher struct {
+	// Path is a dot-separated path into the JSON arguments (e.g. "url" or "options.mode").
+	Path string `json:"path" yaml:"path"`
+
+	// Pattern is a regular expression matched against the argument value.
+	// Non-string values are matched against their JSON encoding.
+	Pattern string `json:"pattern" yaml:"pattern"`
+}
+
+// PermissionRule grants or restricts access to a set of tool calls.
+// All non-empty criteria must match for the rule to apply: the tool name must
+// match one of Tools, the tool must carry one of Scopes, and every Args
+// matcher must match.
+type PermissionRule struct {
+	// Action is applied when the rule matches.
+	Action PermissionAction `json:"action" yaml:"action"`
+
+	// Tools lists tool names or glob patterns (e.g. "fs_*").
+	Tools []string `json:"tools,omitempty" yaml:"tools,omitempty"`
+
+	// Scopes lists scope labels. A rule scope matches a tool scope that is
+	// equal to it or nested under it ("filesystem" matches "filesystem:write").
+	Scopes []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+
+	// Args restricts the rule to calls whose arguments match.
+	Args []ArgumentMatcher `json:"args,omitempty" yaml:"args,omitempty"`
+
+	// Reason is reported to callers when the rule blocks a call.
+	Reason string `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// PermissionPolicy decides whether tool calls may run.
+// Rules are evaluated in order and the first matching rule wins; Default
+// applies when no rule matches. The zero value allows everything.
+//
+// Policies are plain data and can be loaded from JSON or YAML configuration:
+//
+//	{
+//	  "default": "deny",
+//	  "rules": [
+//	    {"action": "allow", "scopes": ["network"], "args": [{"path": "url", "pattern": "^https://api\\.example\\.com/"}]},
+//	    {"action": "ask", "scopes": ["filesystem:write"]}
+//	  ]
+//	}
+type PermissionPolicy struct {
+	// Default is applied when no rule matches. Empty means PermissionAllow.
+	Default PermissionAction `json:"default,omitempty" yaml:"default,omitempty"`
+
+	// Rules are evaluated in order.
+	Rules []PermissionRule `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// ParsePermissionPolicy decodes a JSON permission policy and validates it.
+func ParsePermissionPolicy(data []byte) (*PermissionPolicy, error) {
+	var p PermissionPolicy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parse permission policy: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks that all actions are recognized and all patterns compile.
+func (p *PermissionPolicy) Validate() error {
+	if p.Default != "" && !p.Default.IsValid() {
+		return fmt.Errorf("permission policy: invalid default action %q", p.Default)
+	}
+	for i, rule := range p.Rules {
+		if !rule.Action.IsValid() {
+			return fmt.Errorf("permission policy: rule %d: invalid action %q", i, rule.Action)
+		}
+		for _, pattern := range rule.Tools {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return fmt.Errorf("permission policy: rule %d: invalid tool pattern %q: %w", i, pattern, err)
+			}
+		}
+		for _, m := range rule.Args {
+			if m.Path == "" {
+				return fmt.Errorf("permission policy: rule %d: argument matcher requires a path", i)
+			}
+			if _, err := regexp.Compile(m.Pattern); err != nil {
+				return fmt.Errorf("permission policy: rule %d: invalid argument pattern %q: %w", i, m.Pattern, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Merge returns a policy that evaluates p's rules followed by other's rules.
+// The default action of other is used when it is set; otherwise p's default is kept.
+func (p *PermissionPolicy) Merge(other *PermissionPolicy) *PermissionPolicy {
+	merged := &PermissionPolicy{}
+	if p != nil {
+		merged.Default = p.Default
+		merged.Rules = append(merged.Rules, p.Rules...)
+	}
+	if other != nil {
+		if other.Default != "" {
+			merged.Default = other.Default
+		}
+		merged.Rules = append(merged.Rules, other.Rules...)
+	}
+	return merged
+}
+
+// PermissionRequest describes a tool call being checked against a policy.
+type PermissionRequest struct {
+	ToolName  string
+	Scopes    []string
+	Arguments json.RawMessage
+}
+
+// PermissionDecision is the result of evaluating a policy.
+type PermissionDecision struct {
+	// Action is the resolved action.
+	Action PermissionAction
+
+	// Rule is the matching rule, or nil if the default action applied.
+	Rule *PermissionRule
+}
+
+// Evaluate resolves the action for a tool call.
+// A nil policy allows everything.
+func (p *PermissionPolicy) Evaluate(req PermissionRequest) PermissionDecision {
+	if p == nil {
+		return PermissionDecision{Action: PermissionAllow}
+	}
+
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		if rule.matches(req) {
+			return PermissionDecision{Action: rule.Action, Rule: rule}
+		}
+	}
+
+	action := p.Default
+	if action == "" {
+		action = PermissionAllow
+	}
+	return PermissionDecision{Action: action}
+}
+
+func (r *PermissionRule) matches(req PermissionRequest) bool {
+	if len(r.Tools) > 0 && !matchesAnyName(r.Tools, req.ToolName) {
+		return false
+	}
+	if len(r.Scopes) > 0 && !matchesAnyScope(r.Scopes, req.Scopes) {
+		return false
+	}
+	for _, m := range r.Args {
+		if !m.matches(req.Arguments) {
+			return false
+		}
+	}
+	return true
+}
+
+// mayPermit reports whether some call to a tool with the given name and scopes
+// could be allowed. Rules with argument matchers are treated as possibly
+// applying, so a tool is only excluded when it is unconditionally denied.
+func (p *PermissionPolicy) mayPermit(name string, scopes []string) bool {
+	if p == nil {
+		return true
+	}
+	for _, rule := range p.Rules {
+		if len(rule.Tools) > 0 && !matchesAnyName(rule.Tools, name) {
+			continue
+		}
+		if len(rule.Scopes) > 0 && !matchesAnyScope(rule.Scopes, scopes) {
+			continue
+		}
+		if len(rule.Args) > 0 {
+			if rule.Action != PermissionDeny {
+				return true
+			}
+			continue
+		}
+		return rule.Action != PermissionDeny
+	}
+	return p.Default != PermissionDeny
+}
+
+func matchesAnyName(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, err := path.Match(pattern, name); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesAnyScope(ruleScopes, toolScopes []string) bool {
+	for _, rs := range ruleScopes {
+		for _, ts := range toolScopes {
+			if ts == rs || strings.HasPrefix(ts, rs+":") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (m ArgumentMatcher) matches(args json.RawMessage) bool {
+	re, err := regexp.Compile(m.Pattern)
+	if err != nil {
+		return false
+	}
+
+	var value any
+	if err := json.Unmarshal(args, &value); err != nil {
+		return false
+	}
+	for _, key := range strings.Split(m.Path, ".") {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+		if value, ok = obj[key]; !ok {
+			return false
+		}
+	}
+
+	if s, ok := value.(string); ok {
+		return re.MatchString(s)
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	return re.Match(encoded)
+}
+
+// Approver resolves PermissionAsk decisions, typically by prompting a human.
+// Returning false or an error blocks the call.
+type Approver func(ctx context.Context, req PermissionRequest) (bool, error)
+
+// PermissionError reports a tool call blocked by a permission policy.
+// It unwraps to ErrPermissionDenied.
+type PermissionError struct {
+	ToolName string
+	Reason   string
+}
+
+// Error implements the error interface.
+func (e *PermissionError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("tool %q: permission denied: %s", e.ToolName, e.Reason)
+	}
+	return fmt.Sprintf("tool %q: permission denied", e.ToolName)
+}
+
+// Unwrap returns ErrPermissionDenied.
+func (e *PermissionError) Unwrap() error {
+	return ErrPermissionDenied
+}
+
+// checkPermission enforces policy for a single call, consulting approver for
+// PermissionAsk decisions.
+func checkPermission(ctx context.Context, policy *PermissionPolicy, approver Approver, req PermissionRequest) error {
+	decision := policy.Evaluate(req)
+
+	reason := ""
+	if decision.Rule != nil {
+		reason = decision.Rule.Reason
+	}
+
+	switch decision.Action {
+	case PermissionAllow:
+		return nil
+	case PermissionAsk:
+		if approver == nil {
+			if reason == "" {
+				reason = "approval required but no approver configured"
+			}
+			return &PermissionError{ToolName: req.ToolName, Reason: reason}
+		}
+		ok, err := approver(ctx, req)
+		if err != nil {
+			return fmt.Errorf("tool %q: approval failed: %w", req.ToolName, err)
+		}
+		if !ok {
+			if reason == "" {
+				reason = "approval rejected"
+			}
+			return &PermissionError{ToolName: req.ToolName, Reason: reason}
+		}
+		return nil
+	default:
+		return &PermissionError{ToolName: req.ToolName, Reason: reason}
+	}
+}
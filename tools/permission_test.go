@@ -0,0 +1,234 @@
+package tools_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+func TestPermissionPolicyZeroValueAllows(t *testing.T) {
+	var nilPolicy *tools.PermissionPolicy
+	if got := nilPolicy.Evaluate(tools.PermissionRequest{ToolName: "x"}); got.Action != tools.PermissionAllow {
+		t.Errorf("nil policy action = %q, want allow", got.Action)
+	}
+
+	p := &tools.PermissionPolicy{}
+	if got := p.Evaluate(tools.PermissionRequest{ToolName: "x"}); got.Action != tools.PermissionAllow {
+		t.Errorf("zero policy action = %q, want allow", got.Action)
+	}
+}
+
+func TestPermissionPolicyEvaluate(t *testing.T) {
+	p := &tools.PermissionPolicy{
+		Default: tools.PermissionDeny,
+		Rules: []tools.PermissionRule{
+			{Action: tools.PermissionDeny, Tools: []string{"fs_delete"}, Reason: "destructive"},
+			{Action: tools.PermissionAsk, Scopes: []string{"filesystem:write"}},
+			{Action: tools.PermissionAllow, Tools: []string{"fs_*"}},
+			{
+				Action: tools.PermissionAllow,
+				Scopes: []string{"network"},
+				Args:   []tools.ArgumentMatcher{{Path: "url", Pattern: `^https://api\.example\.com/`}},
+			},
+			{
+				Action: tools.PermissionAllow,
+				Tools:  []string{"shell"},
+				Args:   []tools.ArgumentMatcher{{Path: "opts.dry_run", Pattern: `^true$`}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		req  tools.PermissionRequest
+		want tools.PermissionAction
+	}{
+		{"exact name deny", tools.PermissionRequest{ToolName: "fs_delete"}, tools.PermissionDeny},
+		{"scope ask", tools.PermissionRequest{ToolName: "fs_write", Scopes: []string{"filesystem:write"}}, tools.PermissionAsk},
+		{"glob allow", tools.PermissionRequest{ToolName: "fs_read", Scopes: []string{"filesystem:read"}}, tools.PermissionAllow},
+		{"arg match allow", tools.PermissionRequest{ToolName: "http_get", Scopes: []string{"network"}, Arguments: json.RawMessage(`{"url":"https://api.example.com/v1"}`)}, tools.PermissionAllow},
+		{"arg mismatch default", tools.PermissionRequest{ToolName: "http_get", Scopes: []string{"network"}, Arguments: json.RawMessage(`{"url":"https://evil.test/"}`)}, tools.PermissionDeny},
+		{"nested non-string arg", tools.PermissionRequest{ToolName: "shell", Arguments: json.RawMessage(`{"opts":{"dry_run":true}}`)}, tools.PermissionAllow},
+		{"missing arg", tools.PermissionRequest{ToolName: "shell", Arguments: json.RawMessage(`{}`)}, tools.PermissionDeny},
+		{"no match default", tools.PermissionRequest{ToolName: "other"}, tools.PermissionDeny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.Evaluate(tt.req); got.Action != tt.want {
+				t.Errorf("Evaluate() = %q, want %q", got.Action, tt.want)
+			}
+		})
+	}
+}
+
+func TestPermissionScopePrefixDoesNotMatchSubstring(t *testing.T) {
+	p := &tools.PermissionPolicy{
+		Rules: []tools.PermissionRule{{Action: tools.PermissionDeny, Scopes: []string{"net"}}},
+	}
+	got := p.Evaluate(tools.PermissionRequest{ToolName: "x", Scopes: []string{"network"}})
+	if got.Action != tools.PermissionAllow {
+		t.Errorf("Evaluate() = %q, want allow", got.Action)
+	}
+}
+
+func TestParsePermissionPolicy(t *testing.T) {
+	p, err := tools.ParsePermissionPolicy([]byte(`{
+		"default": "deny",
+		"rules": [{"action": "allow", "tools": ["calc"]}]
+	}`))
+	if err != nil {
+		t.Fatalf("ParsePermissionPolicy() error = %v", err)
+	}
+	if p.Default != tools.PermissionDeny || len(p.Rules) != 1 {
+		t.Errorf("unexpected policy: %+v", p)
+	}
+
+	invalid := []string{
+		`{"default": "maybe"}`,
+		`{"rules": [{"action": "sometimes"}]}`,
+		`{"rules": [{"action": "allow", "tools": ["["]}]}`,
+		`{"rules": [{"action": "allow", "args": [{"path": "x", "pattern": "("}]}]}`,
+		`{"rules": [{"action": "allow", "args": [{"pattern": ".*"}]}]}`,
+		`not json`,
+	}
+	for _, data := range invalid {
+		if _, err := tools.ParsePermissionPolicy([]byte(data)); err == nil {
+			t.Errorf("ParsePermissionPolicy(%s) expected error", data)
+		}
+	}
+}
+
+func TestPermissionPolicyMerge(t *testing.T) {
+	base := &tools.PermissionPolicy{
+		Default: tools.PermissionDeny,
+		Rules:   []tools.PermissionRule{{Action: tools.PermissionAllow, Tools: []string{"a"}}},
+	}
+	override := &tools.PermissionPolicy{
+		Rules: []tools.PermissionRule{{Action: tools.PermissionAllow, Tools: []string{"b"}}},
+	}
+
+	merged := base.Merge(override)
+	if merged.Default != tools.PermissionDeny {
+		t.Errorf("Default = %q, want deny", merged.Default)
+	}
+	if len(merged.Rules) != 2 || merged.Rules[0].Tools[0] != "a" || merged.Rules[1].Tools[0] != "b" {
+		t.Errorf("unexpected rules: %+v", merged.Rules)
+	}
+
+	merged = base.Merge(&tools.PermissionPolicy{Default: tools.PermissionAsk})
+	if merged.Default != tools.PermissionAsk {
+		t.Errorf("Default = %q, want ask", merged.Default)
+	}
+}
+
+func TestWithScopes(t *testing.T) {
+	tool := tools.WithScopes(newMockTool("fetch", "Fetch"), "network")
+	tool = tools.WithScopes(tool, "filesystem:write")
+
+	scopes := tools.ScopesOf(tool)
+	if len(scopes) != 2 || scopes[0] != "network" || scopes[1] != "filesystem:write" {
+		t.Errorf("ScopesOf() = %v", scopes)
+	}
+	if tools.ScopesOf(newMockTool("plain", "Plain")) != nil {
+		t.Error("expected nil scopes for plain tool")
+	}
+
+	wrapped := tools.ApplyMiddleware(tool, tools.WithLogging(nil))
+	if got := tools.ScopesOf(wrapped); len(got) != 2 {
+		t.Errorf("scopes lost through middleware: %v", got)
+	}
+}
+
+func TestRegistryExecuteEnforcesPermissionPolicy(t *testing.T) {
+	policy := &tools.PermissionPolicy{
+		Rules: []tools.PermissionRule{
+			{Action: tools.PermissionDeny, Scopes: []string{"network"}, Reason: "offline mode"},
+			{Action: tools.PermissionAsk, Tools: []string{"write"}},
+		},
+	}
+
+	var asked []string
+	approver := func(ctx context.Context, req tools.PermissionRequest) (bool, error) {
+		asked = append(asked, req.ToolName)
+		return string(req.Arguments) == `{"ok":true}`, nil
+	}
+
+	called := 0
+	newTool := func(name string) *mockTool {
+		m := newMockTool(name, name)
+		m.callFn = func(ctx context.Context, args json.RawMessage) (any, error) {
+			called++
+			return "done", nil
+		}
+		return m
+	}
+
+	r := tools.NewRegistry(tools.WithPermissionPolicy(policy, approver))
+	_ = r.Register(tools.WithScopes(newTool("fetch"), "network"))
+	_ = r.Register(newTool("write"))
+	_ = r.Register(newTool("read"))
+
+	_, err := r.Execute(context.Background(), "fetch", nil)
+	var permErr *tools.PermissionError
+	if !errors.As(err, &permErr) || !errors.Is(err, tools.ErrPermissionDenied) {
+		t.Fatalf("expected PermissionError, got %v", err)
+	}
+	if permErr.Reason != "offline mode" {
+		t.Errorf("Reason = %q", permErr.Reason)
+	}
+
+	if _, err := r.Execute(context.Background(), "write", json.RawMessage(`{"ok":false}`)); !errors.Is(err, tools.ErrPermissionDenied) {
+		t.Errorf("expected rejected approval to deny, got %v", err)
+	}
+	if _, err := r.Execute(context.Background(), "write", json.RawMessage(`{"ok":true}`)); err != nil {
+		t.Errorf("approved call failed: %v", err)
+	}
+	if _, err := r.Execute(context.Background(), "read", nil); err != nil {
+		t.Errorf("allowed call failed: %v", err)
+	}
+
+	if called != 2 {
+		t.Errorf("tool called %d times, want 2", called)
+	}
+	if len(asked) != 2 {
+		t.Errorf("approver asked %d times, want 2", len(asked))
+	}
+}
+
+func TestRegistryAskWithoutApproverDenies(t *testing.T) {
+	policy := &tools.PermissionPolicy{Default: tools.PermissionAsk}
+	r := tools.NewRegistry(tools.WithPermissionPolicy(policy, nil))
+	_ = r.Register(newMockTool("calc", "Calc"))
+
+	if _, err := r.Execute(context.Background(), "calc", nil); !errors.Is(err, tools.ErrPermissionDenied) {
+		t.Errorf("expected ErrPermissionDenied, got %v", err)
+	}
+}
+
+func TestRegistryListPermitted(t *testing.T) {
+	policy := &tools.PermissionPolicy{
+		Default: tools.PermissionDeny,
+		Rules: []tools.PermissionRule{
+			{Action: tools.PermissionAllow, Tools: []string{"calc"}},
+			{Action: tools.PermissionAsk, Scopes: []string{"filesystem"}},
+			{Action: tools.PermissionAllow, Tools: []string{"fetch"}, Args: []tools.ArgumentMatcher{{Path: "url", Pattern: "^https://"}}},
+		},
+	}
+	r := tools.NewRegistry(tools.WithPermissionPolicy(policy, nil))
+	_ = r.Register(newMockTool("calc", "Calc"))
+	_ = r.Register(tools.WithScopes(newMockTool("save", "Save"), "filesystem:write"))
+	_ = r.Register(newMockTool("fetch", "Fetch"))
+	_ = r.Register(newMockTool("shell", "Shell"))
+
+	names := map[string]bool{}
+	for _, tool := range r.ListPermitted() {
+		names[tool.Name()] = true
+	}
+	if len(names) != 3 || !names["calc"] || !names["save"] || !names["fetch"] {
+		t.Errorf("ListPermitted() = %v", names)
+	}
+}
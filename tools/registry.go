@@ -12,6 +12,10 @@ import (
 // that is already registered.
 var ErrDuplicateTool = errors.New("tool already registered")
 
+// ErrInvalidArguments is returned by Registry.Execute when the arguments do
+// not match the tool's schema.
+var ErrInvalidArguments = errors.New("invalid tool arguments")
+
 // RegistryOption configures a Registry.
 type RegistryOption func(*Registry)
 
@@ -23,12 +27,57 @@ func WithRegistryMiddleware(middlewares ...Middleware) RegistryOption {
 	}
 }
 
+// WithPermissionPolicy enforces a permission policy in Execute.
+// Calls resolved to PermissionAsk are sent to approver; with a nil approver
+// they are denied.
+func WithPermissionPolicy(policy *PermissionPolicy, approver Approver) RegistryOption {
+	return func(r *Registry) {
+		r.policy = policy
+		r.approver = approver
+	}
+}
+
+// WithArgumentValidator sets the validator Execute checks arguments with.
+// The default is a JSONSchemaValidator.
+func WithArgumentValidator(v SchemaValidator) RegistryOption {
+	return func(r *Registry) {
+		r.validator = v
+	}
+}
+
+// WithoutArgumentValidation disables argument validation in Execute, for
+// tools whose schemas are advisory or that validate arguments themselves.
+func WithoutArgumentValidation() RegistryOption {
+	return func(r *Registry) {
+		r.validator = nil
+	}
+}
+
+// WithArgumentCoercion makes Execute convert arguments toward the tool's
+// schema before validating them, so that numbers sent as strings and
+// omitted defaults do not fail the call. See WithCoercion.
+func WithArgumentCoercion() RegistryOption {
+	return func(r *Registry) {
+		r.coercer = &coercer{}
+	}
+}
+
 // Registry manages a collection of tools indexed by name.
 // Registry is safe for concurrent use.
 type Registry struct {
 	mu          sync.RWMutex
 	tools       map[string]Tool
 	middlewares []Middleware
+	policy      *PermissionPolicy
+	approver    Approver
+	conflicts   ConflictPolicy
+	separator   string
+	validator   SchemaValidator
+	coercer     *coercer
+
+	version   uint64
+	watchers  map[int]func(RegistryChange)
+	nextWatch int
 }
 
 // NewRegistry creates a new tool registry with optional configuration.
@@ -36,6 +85,8 @@ func NewRegistry(opts ...RegistryOption) *Registry {
 	r := &Registry{
 		tools:       make(map[string]Tool),
 		middlewares: nil,
+		separator:   DefaultNamespaceSeparator,
+		validator:   NewJSONSchemaValidator(),
 	}
 	for _, opt := range opts {
 		opt(r)
@@ -45,28 +96,40 @@ func NewRegistry(opts ...RegistryOption) *Registry {
 
 // Register adds a tool to the registry.
 // If registry middleware is configured, it's automatically applied.
-// Returns ErrDuplicateTool if a tool with the same name is already registered.
+// A name that is already registered is resolved by the conflict policy;
+// by default Register returns ErrDuplicateTool.
 func (r *Registry) Register(t Tool) error {
 	if t == nil {
 		return errors.New("tool cannot be nil")
 	}
 
-	name := t.Name()
-
 	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	if _, exists := r.tools[name]; exists {
-		return ErrDuplicateTool
+	add, err := r.resolveConflict(t.Name(), nil)
+	if err != nil || !add {
+		r.mu.Unlock()
+		return err
 	}
+	change := r.add(t)
+	r.mu.Unlock()
+
+	r.notify(change)
+	return nil
+}
 
-	// Apply registry-level middleware
+// add stores t with registry middleware applied and returns the change.
+// r.mu must be held.
+func (r *Registry) add(t Tool) RegistryChange {
 	if len(r.middlewares) > 0 {
 		t = ApplyMiddleware(t, r.middlewares...)
 	}
-
+	name := t.Name()
+	kind := ToolAdded
+	if _, exists := r.tools[name]; exists {
+		kind = ToolReplaced
+	}
 	r.tools[name] = t
-	return nil
+	r.version++
+	return RegistryChange{Kind: kind, Name: name, Version: r.version}
 }
 
 // RegisterWithMiddleware adds a tool with additional per-tool middleware.
@@ -102,12 +165,59 @@ func (r *Registry) List() []Tool {
 	return result
 }
 
+// ListPermitted returns the registered tools that the permission policy could
+// allow. Tools that are unconditionally denied are omitted, so they are never
+// advertised to the model. Without a policy this is equivalent to List.
+func (r *Registry) ListPermitted() []Tool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make([]Tool, 0, len(r.tools))
+	for name, t := range r.tools {
+		if r.policy.mayPermit(name, ScopesOf(t)) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
 // Execute finds a tool by name and calls it with the given arguments.
-// Returns an error if the tool is not found or if execution fails.
+// The tool's name and schema are placed in a copy of the ToolContext in ctx
+// for middleware, so one ToolContext can be shared by concurrent calls. The
+// arguments are coerced toward the schema if the registry was created with
+// WithArgumentCoercion, then validated against it unless the registry was
+// created with WithoutArgumentValidation.
+// Returns an error if the tool is not found, if the arguments do not match
+// the schema (see ErrInvalidArguments), if the permission policy blocks the
+// call (see ErrPermissionDenied), or if execution fails.
 func (r *Registry) Execute(ctx context.Context, name string, args json.RawMessage) (any, error) {
 	tool, ok := r.Get(name)
 	if !ok {
 		return nil, fmt.Errorf("tool %q not found", name)
 	}
+
+	r.mu.RLock()
+	policy, approver, validator, coercer := r.policy, r.approver, r.validator, r.coercer
+	r.mu.RUnlock()
+
+	ctx = withToolContext(ctx, tool)
+	s := tool.Schema().JSONSchema
+	if coercer != nil {
+		args = coercer.coerce(s, args)
+	}
+	if validator != nil && len(s) > 0 {
+		// Validate before asking for permission, so approvers never see
+		// calls that would be rejected anyway.
+		if err := validator.Validate(s, args); err != nil {
+			return nil, fmt.Errorf("%w for %q: %w", ErrInvalidArguments, name, err)
+		}
+	}
+
+	if policy != nil {
+		req := PermissionRequest{ToolName: name, Scopes: ScopesOf(tool), Arguments: args}
+		if err := checkPermission(ctx, policy, approver, req); err != nil {
+			return nil, err
+		}
+	}
 	return tool.Call(ctx, args)
 }
@@ -0,0 +1,90 @@
+package tools
+
+// ChangeKind describes how a registry changed.
+type ChangeKind string
+
+const (
+	// ToolAdded reports a tool registered under a new name.
+	ToolAdded ChangeKind = "added"
+	// ToolReplaced reports a tool that replaced one of the same name.
+	ToolReplaced ChangeKind = "replaced"
+	// ToolRemoved reports a tool removed with Unregister.
+	ToolRemoved ChangeKind = "removed"
+)
+
+// RegistryChange describes one change to a Registry.
+type RegistryChange struct {
+	Kind ChangeKind
+	Name string
+
+	// Version is the registry version after the change.
+	Version uint64
+}
+
+// Unregister removes the tool registered under name and reports whether it
+// was registered.
+func (r *Registry) Unregister(name string) bool {
+	r.mu.Lock()
+	if _, ok := r.tools[name]; !ok {
+		r.mu.Unlock()
+		return false
+	}
+	delete(r.tools, name)
+	r.version++
+	change := RegistryChange{Kind: ToolRemoved, Name: name, Version: r.version}
+	r.mu.Unlock()
+
+	r.notify(change)
+	return true
+}
+
+// Version returns a counter that increases with every change to the
+// registry. Code that advertises the tool list to a model, such as a loop
+// that re-sends ListPermitted on each turn, can compare versions to notice
+// tools added or removed mid-conversation, for example privileged tools
+// unlocked after an authentication tool succeeds.
+func (r *Registry) Version() uint64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.version
+}
+
+// Watch calls fn after every change to the registry, with the registry
+// unlocked, until the returned stop function is called. Changes made by one
+// call, such as a RegisterGroup, are delivered in order before the call
+// returns. fn must be safe for concurrent use when the registry is changed
+// from several goroutines.
+func (r *Registry) Watch(fn func(RegistryChange)) (stop func()) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.watchers == nil {
+		r.watchers = make(map[int]func(RegistryChange))
+	}
+	id := r.nextWatch
+	r.nextWatch++
+	r.watchers[id] = fn
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		delete(r.watchers, id)
+	}
+}
+
+// notify delivers changes to the watchers. r.mu must not be held.
+func (r *Registry) notify(changes ...RegistryChange) {
+	if len(changes) == 0 {
+		return
+	}
+	r.mu.RLock()
+	watchers := make([]func(RegistryChange), 0, len(r.watchers))
+	for _, fn := range r.watchers {
+		watchers = append(watchers, fn)
+	}
+	r.mu.RUnlock()
+
+	for _, fn := range watchers {
+		for _, c := range changes {
+			fn(c)
+		}
+	}
+}
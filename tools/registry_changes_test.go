@@ -0,0 +1,62 @@
+package tools_test
+
+import (
+	"testing"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+func TestRegistryWatch(t *testing.T) {
+	r := tools.NewRegistry(tools.WithConflictPolicy(tools.ConflictReplace))
+	var changes []tools.RegistryChange
+	stop := r.Watch(func(c tools.RegistryChange) { changes = append(changes, c) })
+
+	_ = r.Register(newMockTool("auth", ""))
+	_ = r.RegisterGroup(tools.Group{Namespace: "admin", Tools: []tools.Tool{newMockTool("delete", "")}})
+	_ = r.Register(newMockTool("auth", "v2"))
+	if !r.Unregister("auth") {
+		t.Error("Unregister(auth) = false, want true")
+	}
+	if r.Unregister("auth") {
+		t.Error("second Unregister(auth) = true, want false")
+	}
+
+	want := []tools.RegistryChange{
+		{Kind: tools.ToolAdded, Name: "auth", Version: 1},
+		{Kind: tools.ToolAdded, Name: "admin__delete", Version: 2},
+		{Kind: tools.ToolReplaced, Name: "auth", Version: 3},
+		{Kind: tools.ToolRemoved, Name: "auth", Version: 4},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("changes = %+v, want %+v", changes, want)
+	}
+	for i := range want {
+		if changes[i] != want[i] {
+			t.Errorf("changes[%d] = %+v, want %+v", i, changes[i], want[i])
+		}
+	}
+	if r.Version() != 4 {
+		t.Errorf("Version() = %d, want 4", r.Version())
+	}
+
+	stop()
+	_ = r.Register(newMockTool("other", ""))
+	if len(changes) != len(want) {
+		t.Errorf("change delivered after stop: %+v", changes[len(changes)-1])
+	}
+}
+
+func TestRegistryWatchCanModifyRegistry(t *testing.T) {
+	r := tools.NewRegistry()
+	// Unlock privileged tools once the auth tool is registered
+	r.Watch(func(c tools.RegistryChange) {
+		if c.Kind == tools.ToolAdded && c.Name == "auth" {
+			_ = r.Register(newMockTool("privileged", ""))
+		}
+	})
+
+	_ = r.Register(newMockTool("auth", ""))
+	if _, ok := r.Get("privileged"); !ok {
+		t.Error("privileged tool not registered from watcher")
+	}
+}
@@ -0,0 +1,18 @@
+// Package sandbox executes model-generated code in an isolated environment.
+//
+// A Backend runs a single program and reports its exit code, output, and any
+// files it produced. Two backends are provided:
+//
+//   - ProcessBackend runs the interpreter as a local subprocess in a fresh
+//     temporary directory with CPU, memory, file size, and process rlimits and
+//     a minimal environment. It is suitable for development and trusted hosts.
+//   - DockerBackend runs each program in a throwaway container with networking
+//     disabled and resource limits enforced by the container runtime. Set
+//     Runtime to "runsc" to run under gVisor.
+//
+// NewTool exposes a backend as an execute_code tool:
+//
+//	backend := sandbox.NewDockerBackend(sandbox.DockerConfig{Runtime: "runsc"})
+//	reg := tools.NewRegistry()
+//	reg.Register(sandbox.NewTool(backend))
+package sandbox
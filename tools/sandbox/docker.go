@@ -0,0 +1,149 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DockerConfig configures a DockerBackend.
+type DockerConfig struct {
+	// Binary is the container CLI. Defaults to "docker"; "podman" also works.
+	Binary string
+
+	// Runtime selects an OCI runtime such as "runsc" (gVisor).
+	Runtime string
+
+	// Runtimes maps languages to interpreters and images. Defaults to DefaultRuntimes.
+	Runtimes map[Language]Runtime
+
+	// Limits bounds each execution. Defaults to DefaultLimits.
+	Limits Limits
+
+	// Network is passed to --network. Defaults to "none".
+	Network string
+
+	// ExtraArgs are appended to "docker run" before the image name.
+	ExtraArgs []string
+}
+
+// DockerBackend runs each program in a throwaway container.
+type DockerBackend struct {
+	config DockerConfig
+}
+
+// NewDockerBackend creates a DockerBackend.
+func NewDockerBackend(cfg DockerConfig) *DockerBackend {
+	if cfg.Binary == "" {
+		cfg.Binary = "docker"
+	}
+	if cfg.Runtimes == nil {
+		cfg.Runtimes = DefaultRuntimes()
+	}
+	if cfg.Limits == (Limits{}) {
+		cfg.Limits = DefaultLimits()
+	}
+	cfg.Limits = cfg.Limits.withDefaults()
+	if cfg.Network == "" {
+		cfg.Network = "none"
+	}
+	return &DockerBackend{config: cfg}
+}
+
+const containerWorkdir = "/workspace"
+
+// args builds the container run command line.
+func (b *DockerBackend) args(rt Runtime, hostDir string) []string {
+	l := b.config.Limits
+	args := []string{
+		"run", "--rm", "-i",
+		"--network", b.config.Network,
+		"--read-only",
+		"--tmpfs", "/tmp",
+		"--cap-drop", "ALL",
+		"--security-opt", "no-new-privileges",
+		"-v", hostDir + ":" + containerWorkdir,
+		"-w", containerWorkdir,
+		"-e", "HOME=" + containerWorkdir,
+	}
+	if b.config.Runtime != "" {
+		args = append(args, "--runtime", b.config.Runtime)
+	}
+	if l.MemoryBytes > 0 {
+		args = append(args, "--memory", strconv.FormatInt(l.MemoryBytes, 10))
+	}
+	if l.MaxProcesses > 0 {
+		args = append(args, "--pids-limit", strconv.Itoa(l.MaxProcesses))
+	}
+	if l.CPUTime > 0 {
+		secs := max(1, int64(l.CPUTime.Seconds()))
+		args = append(args, "--ulimit", fmt.Sprintf("cpu=%d:%d", secs, secs))
+	}
+	if l.MaxFileBytes > 0 {
+		args = append(args, "--ulimit", fmt.Sprintf("fsize=%d:%d", l.MaxFileBytes, l.MaxFileBytes))
+	}
+	args = append(args, b.config.ExtraArgs...)
+	args = append(args, rt.Image)
+	args = append(args, rt.Command...)
+	args = append(args, path.Join(containerWorkdir, rt.Filename))
+	return args
+}
+
+// Run implements Backend.
+func (b *DockerBackend) Run(ctx context.Context, req Request) (*Result, error) {
+	rt, ok := b.config.Runtimes[req.Language]
+	if !ok || len(rt.Command) == 0 || rt.Image == "" {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLanguage, req.Language)
+	}
+
+	ws, err := prepareWorkspace(rt, req)
+	if err != nil {
+		return nil, err
+	}
+	defer ws.cleanup()
+
+	limits := b.config.Limits
+	ctx, cancel := context.WithTimeout(ctx, limits.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, b.config.Binary, b.args(rt, ws.dir)...)
+	cmd.Stdin = strings.NewReader(req.Stdin)
+	stdout := &limitedBuffer{max: limits.MaxOutputBytes}
+	stderr := &limitedBuffer{max: limits.MaxOutputBytes}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	start := time.Now()
+	err = cmd.Run()
+	result := &Result{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Duration:  time.Since(start),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		result.TimedOut = true
+		result.ExitCode = -1
+	case errors.As(err, &exitErr):
+		// Exit code 125 means the container CLI failed before the program ran.
+		if exitErr.ExitCode() == 125 {
+			return nil, fmt.Errorf("sandbox: %s run failed: %s", b.config.Binary, strings.TrimSpace(result.Stderr))
+		}
+		result.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		return nil, fmt.Errorf("sandbox: run: %w", err)
+	}
+
+	artifacts, truncated := ws.artifacts(limits.MaxArtifactBytes)
+	result.Artifacts = artifacts
+	result.Truncated = result.Truncated || truncated
+	return result, nil
+}
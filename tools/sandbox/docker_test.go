@@ -0,0 +1,59 @@
+package sandbox
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDockerBackendArgs(t *testing.T) {
+	b := NewDockerBackend(DockerConfig{
+		Runtime: "runsc",
+		Limits: Limits{
+			Timeout:      time.Second,
+			CPUTime:      5 * time.Second,
+			MemoryBytes:  256 << 20,
+			MaxProcesses: 32,
+			MaxFileBytes: 1 << 20,
+		},
+	})
+
+	rt := DefaultRuntimes()[LanguagePython]
+	args := b.args(rt, "/tmp/ws")
+	joined := strings.Join(args, " ")
+
+	for _, want := range []string{
+		"run --rm -i",
+		"--network none",
+		"--runtime runsc",
+		"--memory 268435456",
+		"--pids-limit 32",
+		"--ulimit cpu=5:5",
+		"--ulimit fsize=1048576:1048576",
+		"-v /tmp/ws:/workspace",
+		"--cap-drop ALL",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("args missing %q: %s", want, joined)
+		}
+	}
+
+	tail := args[len(args)-3:]
+	if !slices.Equal(tail, []string{rt.Image, "python3", "/workspace/main.py"}) {
+		t.Errorf("unexpected command tail: %v", tail)
+	}
+}
+
+func TestDockerBackendDefaults(t *testing.T) {
+	b := NewDockerBackend(DockerConfig{})
+	if b.config.Binary != "docker" || b.config.Network != "none" {
+		t.Errorf("unexpected defaults: %+v", b.config)
+	}
+	if b.config.Limits != DefaultLimits() {
+		t.Errorf("Limits = %+v, want DefaultLimits", b.config.Limits)
+	}
+	if slices.Contains(b.args(DefaultRuntimes()[LanguageShell], "/x"), "--runtime") {
+		t.Error("--runtime should be omitted by default")
+	}
+}
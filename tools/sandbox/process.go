@@ -0,0 +1,105 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ProcessConfig configures a ProcessBackend.
+type ProcessConfig struct {
+	// Runtimes maps languages to interpreters. Defaults to DefaultRuntimes.
+	Runtimes map[Language]Runtime
+
+	// Limits bounds each execution. Defaults to DefaultLimits. MaxProcesses is
+	// not enforced by this backend because the process rlimit is per user.
+	Limits Limits
+
+	// Env holds additional environment variables. Programs otherwise see only
+	// PATH, HOME (the workspace), and TMPDIR (the workspace).
+	Env []string
+}
+
+// ProcessBackend runs code as a local subprocess with rlimits applied.
+// It isolates the working directory and environment but not the network or
+// the rest of the filesystem; use DockerBackend for untrusted code.
+type ProcessBackend struct {
+	config ProcessConfig
+}
+
+// NewProcessBackend creates a ProcessBackend.
+func NewProcessBackend(cfg ProcessConfig) *ProcessBackend {
+	if cfg.Runtimes == nil {
+		cfg.Runtimes = DefaultRuntimes()
+	}
+	if cfg.Limits == (Limits{}) {
+		cfg.Limits = DefaultLimits()
+	}
+	cfg.Limits = cfg.Limits.withDefaults()
+	return &ProcessBackend{config: cfg}
+}
+
+// Run implements Backend.
+func (b *ProcessBackend) Run(ctx context.Context, req Request) (*Result, error) {
+	rt, ok := b.config.Runtimes[req.Language]
+	if !ok || len(rt.Command) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedLanguage, req.Language)
+	}
+
+	ws, err := prepareWorkspace(rt, req)
+	if err != nil {
+		return nil, err
+	}
+	defer ws.cleanup()
+
+	limits := b.config.Limits
+	ctx, cancel := context.WithTimeout(ctx, limits.Timeout)
+	defer cancel()
+
+	argv := append(append([]string{}, rt.Command...), filepath.Join(ws.dir, rt.Filename))
+	argv = limitCommand(limits, argv)
+
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Dir = ws.dir
+	cmd.Env = append([]string{
+		"PATH=" + os.Getenv("PATH"),
+		"HOME=" + ws.dir,
+		"TMPDIR=" + ws.dir,
+	}, b.config.Env...)
+	cmd.Stdin = strings.NewReader(req.Stdin)
+	stdout := &limitedBuffer{max: limits.MaxOutputBytes}
+	stderr := &limitedBuffer{max: limits.MaxOutputBytes}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	isolateProcess(cmd)
+
+	start := time.Now()
+	err = cmd.Run()
+	result := &Result{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Duration:  time.Since(start),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() != nil:
+		result.TimedOut = true
+		result.ExitCode = -1
+	case errors.As(err, &exitErr):
+		result.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		return nil, fmt.Errorf("sandbox: run: %w", err)
+	}
+
+	artifacts, truncated := ws.artifacts(limits.MaxArtifactBytes)
+	result.Artifacts = artifacts
+	result.Truncated = result.Truncated || truncated
+	return result, nil
+}
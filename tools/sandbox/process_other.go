@@ -0,0 +1,13 @@
+//go:build !unix
+
+package sandbox
+
+import "os/exec"
+
+// limitCommand returns argv unchanged; rlimits are not available on this platform.
+func limitCommand(_ Limits, argv []string) []string {
+	return argv
+}
+
+// isolateProcess is a no-op on this platform.
+func isolateProcess(*exec.Cmd) {}
@@ -0,0 +1,98 @@
+//go:build unix
+
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProcessBackendRun(t *testing.T) {
+	b := NewProcessBackend(ProcessConfig{})
+
+	res, err := b.Run(context.Background(), Request{
+		Language: LanguageShell,
+		Code:     "read name; echo \"hello $name\"; echo oops >&2; echo data > out.txt; cat input.txt; exit 4",
+		Stdin:    "iris\n",
+		Files:    map[string][]byte{"input.txt": []byte("from input\n")},
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if res.ExitCode != 4 {
+		t.Errorf("ExitCode = %d, want 4", res.ExitCode)
+	}
+	if res.Stdout != "hello iris\nfrom input\n" {
+		t.Errorf("Stdout = %q", res.Stdout)
+	}
+	if res.Stderr != "oops\n" {
+		t.Errorf("Stderr = %q", res.Stderr)
+	}
+	if len(res.Artifacts) != 1 || res.Artifacts[0].Path != "out.txt" || string(res.Artifacts[0].Data) != "data\n" {
+		t.Errorf("Artifacts = %+v", res.Artifacts)
+	}
+}
+
+func TestProcessBackendTimeout(t *testing.T) {
+	limits := DefaultLimits()
+	limits.Timeout = 100 * time.Millisecond
+	b := NewProcessBackend(ProcessConfig{Limits: limits})
+
+	start := time.Now()
+	res, err := b.Run(context.Background(), Request{Language: LanguageShell, Code: "sleep 5 & sleep 5"})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !res.TimedOut {
+		t.Error("expected TimedOut")
+	}
+	if time.Since(start) > 3*time.Second {
+		t.Errorf("timeout not enforced, took %v", time.Since(start))
+	}
+}
+
+func TestProcessBackendLimits(t *testing.T) {
+	limits := DefaultLimits()
+	limits.MaxOutputBytes = 10
+	limits.MaxFileBytes = 1024
+	b := NewProcessBackend(ProcessConfig{Limits: limits})
+
+	res, err := b.Run(context.Background(), Request{
+		Language: LanguageShell,
+		Code:     "echo 0123456789abcdef; head -c 4096 /dev/zero > big.bin",
+	})
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if res.Stdout != "0123456789" || !res.Truncated {
+		t.Errorf("Stdout = %q, Truncated = %v", res.Stdout, res.Truncated)
+	}
+	if res.ExitCode == 0 {
+		t.Error("expected file size limit to fail the program")
+	}
+	for _, a := range res.Artifacts {
+		if a.Size > 1024 {
+			t.Errorf("artifact %s exceeds file limit: %d bytes", a.Path, a.Size)
+		}
+	}
+}
+
+func TestProcessBackendErrors(t *testing.T) {
+	b := NewProcessBackend(ProcessConfig{})
+
+	if _, err := b.Run(context.Background(), Request{Language: "cobol", Code: "x"}); !errors.Is(err, ErrUnsupportedLanguage) {
+		t.Errorf("expected ErrUnsupportedLanguage, got %v", err)
+	}
+
+	_, err := b.Run(context.Background(), Request{
+		Language: LanguageShell,
+		Code:     "true",
+		Files:    map[string][]byte{"../escape.txt": nil},
+	})
+	if err == nil || !strings.Contains(err.Error(), "invalid file path") {
+		t.Errorf("expected invalid path error, got %v", err)
+	}
+}
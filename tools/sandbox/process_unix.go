@@ -0,0 +1,44 @@
+//go:build unix
+
+package sandbox
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"syscall"
+)
+
+// limitCommand wraps argv in a shell that applies rlimits before exec.
+func limitCommand(l Limits, argv []string) []string {
+	var script []string
+	if l.CPUTime > 0 {
+		script = append(script, fmt.Sprintf("ulimit -t %d", max(1, int64(l.CPUTime.Seconds()))))
+	}
+	if l.MemoryBytes > 0 {
+		// Limit the data segment rather than the address space: runtimes such
+		// as V8 reserve large virtual ranges up front and fail under ulimit -v.
+		script = append(script, fmt.Sprintf("ulimit -d %d", max(1, l.MemoryBytes/1024)))
+	}
+	if l.MaxFileBytes > 0 {
+		// POSIX specifies ulimit -f in 512-byte blocks.
+		script = append(script, fmt.Sprintf("ulimit -f %d", max(1, l.MaxFileBytes/512)))
+	}
+	if len(script) == 0 {
+		return argv
+	}
+	script = append(script, `exec "$@"`)
+	return append([]string{"/bin/sh", "-c", strings.Join(script, " && "), "sandbox"}, argv...)
+}
+
+// isolateProcess runs the command in its own process group so the whole
+// group is killed on timeout.
+func isolateProcess(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		if cmd.Process == nil {
+			return nil
+		}
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
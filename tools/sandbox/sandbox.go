@@ -0,0 +1,245 @@
+package sandbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedLanguage is returned when a backend has no runner for a language.
+var ErrUnsupportedLanguage = errors.New("sandbox: unsupported language")
+
+// Language identifies the interpreter used to run code.
+type Language string
+
+const (
+	LanguagePython     Language = "python"
+	LanguageJavaScript Language = "javascript"
+	LanguageShell      Language = "shell"
+)
+
+// Runtime describes how to execute code for a language.
+type Runtime struct {
+	// Command is the interpreter and any leading arguments.
+	Command []string
+
+	// Filename is the name the source file is written to inside the workspace.
+	Filename string
+
+	// Image is the container image used by DockerBackend.
+	Image string
+}
+
+// DefaultRuntimes returns the built-in language runtimes.
+func DefaultRuntimes() map[Language]Runtime {
+	return map[Language]Runtime{
+		LanguagePython:     {Command: []string{"python3"}, Filename: "main.py", Image: "python:3.12-slim"},
+		LanguageJavaScript: {Command: []string{"node"}, Filename: "main.js", Image: "node:22-slim"},
+		LanguageShell:      {Command: []string{"sh"}, Filename: "main.sh", Image: "busybox:stable"},
+	}
+}
+
+// Limits bounds the resources available to a single execution.
+// Zero values disable the corresponding limit unless noted.
+type Limits struct {
+	// Timeout is the wall-clock limit. Defaults to 30 seconds.
+	Timeout time.Duration
+
+	// CPUTime is the CPU time limit.
+	CPUTime time.Duration
+
+	// MemoryBytes caps the data segment (process) or memory (container).
+	MemoryBytes int64
+
+	// MaxProcesses caps the number of processes or threads.
+	MaxProcesses int
+
+	// MaxFileBytes caps the size of any file the program writes.
+	MaxFileBytes int64
+
+	// MaxOutputBytes caps captured stdout and stderr, each. Defaults to 64 KiB.
+	MaxOutputBytes int
+
+	// MaxArtifactBytes caps the total size of returned artifacts. Defaults to 1 MiB.
+	MaxArtifactBytes int64
+}
+
+// DefaultLimits returns conservative limits for untrusted code.
+func DefaultLimits() Limits {
+	return Limits{
+		Timeout:          30 * time.Second,
+		CPUTime:          10 * time.Second,
+		MemoryBytes:      512 << 20,
+		MaxProcesses:     64,
+		MaxFileBytes:     10 << 20,
+		MaxOutputBytes:   64 << 10,
+		MaxArtifactBytes: 1 << 20,
+	}
+}
+
+func (l Limits) withDefaults() Limits {
+	if l.Timeout <= 0 {
+		l.Timeout = 30 * time.Second
+	}
+	if l.MaxOutputBytes <= 0 {
+		l.MaxOutputBytes = 64 << 10
+	}
+	if l.MaxArtifactBytes <= 0 {
+		l.MaxArtifactBytes = 1 << 20
+	}
+	return l
+}
+
+// Request is a program to execute.
+type Request struct {
+	// Language selects the runtime.
+	Language Language
+
+	// Code is the program source.
+	Code string
+
+	// Stdin is passed to the program's standard input.
+	Stdin string
+
+	// Files are written into the workspace before the program runs.
+	// Keys are slash-separated relative paths.
+	Files map[string][]byte
+}
+
+// Artifact is a file the program created or modified in its workspace.
+type Artifact struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	Data []byte `json:"data,omitempty"`
+}
+
+// Result is the outcome of an execution.
+type Result struct {
+	ExitCode  int           `json:"exit_code"`
+	Stdout    string        `json:"stdout"`
+	Stderr    string        `json:"stderr,omitempty"`
+	Artifacts []Artifact    `json:"artifacts,omitempty"`
+	Duration  time.Duration `json:"duration"`
+	TimedOut  bool          `json:"timed_out,omitempty"`
+	Truncated bool          `json:"truncated,omitempty"`
+}
+
+// Backend executes code in an isolated environment.
+type Backend interface {
+	Run(ctx context.Context, req Request) (*Result, error)
+}
+
+// workspace is a temporary directory holding a program and its inputs.
+type workspace struct {
+	dir    string
+	inputs map[string]time.Time
+}
+
+// prepareWorkspace writes the program and input files to a new temp directory.
+func prepareWorkspace(rt Runtime, req Request) (*workspace, error) {
+	dir, err := os.MkdirTemp("", "iris-sandbox-")
+	if err != nil {
+		return nil, fmt.Errorf("sandbox: create workspace: %w", err)
+	}
+	ws := &workspace{dir: dir, inputs: map[string]time.Time{}}
+
+	files := map[string][]byte{rt.Filename: []byte(req.Code)}
+	for name, data := range req.Files {
+		files[name] = data
+	}
+
+	for name, data := range files {
+		rel := filepath.Clean(filepath.FromSlash(name))
+		if !filepath.IsLocal(rel) {
+			ws.cleanup()
+			return nil, fmt.Errorf("sandbox: invalid file path %q", name)
+		}
+		full := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			ws.cleanup()
+			return nil, fmt.Errorf("sandbox: write %s: %w", name, err)
+		}
+		if err := os.WriteFile(full, data, 0o644); err != nil {
+			ws.cleanup()
+			return nil, fmt.Errorf("sandbox: write %s: %w", name, err)
+		}
+		info, err := os.Stat(full)
+		if err == nil {
+			ws.inputs[filepath.ToSlash(rel)] = info.ModTime()
+		}
+	}
+	return ws, nil
+}
+
+// artifacts collects regular files created or modified by the program,
+// up to maxBytes of content in total. Files beyond the budget are listed
+// without data.
+func (ws *workspace) artifacts(maxBytes int64) ([]Artifact, bool) {
+	var out []Artifact
+	_ = filepath.WalkDir(ws.dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		rel, err := filepath.Rel(ws.dir, p)
+		if err != nil {
+			return nil
+		}
+		rel = filepath.ToSlash(rel)
+		if mod, ok := ws.inputs[rel]; ok && info.ModTime().Equal(mod) {
+			return nil
+		}
+		out = append(out, Artifact{Path: rel, Size: info.Size()})
+		return nil
+	})
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+
+	truncated := false
+	remaining := maxBytes
+	for i := range out {
+		if out[i].Size > remaining {
+			truncated = true
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(ws.dir, filepath.FromSlash(out[i].Path)))
+		if err != nil {
+			continue
+		}
+		out[i].Data = data
+		remaining -= int64(len(data))
+	}
+	return out, truncated
+}
+
+func (ws *workspace) cleanup() {
+	_ = os.RemoveAll(ws.dir)
+}
+
+// limitedBuffer keeps at most max bytes and discards the rest.
+type limitedBuffer struct {
+	buf       strings.Builder
+	max       int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.buf.Len(); room < len(p) {
+		b.truncated = true
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string { return b.buf.String() }
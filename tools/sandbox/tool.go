@@ -0,0 +1,70 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+// ScopeProcessExec is the permission scope declared by the execute_code tool.
+const ScopeProcessExec = "process:exec"
+
+// Tool exposes a Backend as the execute_code tool.
+type Tool struct {
+	backend Backend
+}
+
+// NewTool creates an execute_code tool that runs programs on backend.
+func NewTool(backend Backend) *Tool {
+	return &Tool{backend: backend}
+}
+
+var _ tools.ScopedTool = (*Tool)(nil)
+
+// Name implements tools.Tool.
+func (t *Tool) Name() string { return "execute_code" }
+
+// Description implements tools.Tool.
+func (t *Tool) Description() string {
+	return "Execute a short program in an isolated sandbox and return its exit code, stdout, stderr, and any files it wrote."
+}
+
+// Schema implements tools.Tool.
+func (t *Tool) Schema() tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: json.RawMessage(`{
+		"type": "object",
+		"properties": {
+			"language": {"type": "string", "enum": ["python", "javascript", "shell"], "description": "Programming language of the code"},
+			"code": {"type": "string", "description": "Complete program source"},
+			"stdin": {"type": "string", "description": "Optional standard input"}
+		},
+		"required": ["language", "code"]
+	}`)}
+}
+
+// Scopes implements tools.ScopedTool.
+func (t *Tool) Scopes() []string { return []string{ScopeProcessExec} }
+
+// Call implements tools.Tool.
+func (t *Tool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	var params struct {
+		Language Language `json:"language"`
+		Code     string   `json:"code"`
+		Stdin    string   `json:"stdin"`
+	}
+	if err := json.Unmarshal(args, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+	if params.Code == "" {
+		return nil, errors.New("code is required")
+	}
+
+	return t.backend.Run(ctx, Request{
+		Language: params.Language,
+		Code:     params.Code,
+		Stdin:    params.Stdin,
+	})
+}
@@ -0,0 +1,41 @@
+package sandbox
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+type fakeBackend struct {
+	got Request
+}
+
+func (f *fakeBackend) Run(ctx context.Context, req Request) (*Result, error) {
+	f.got = req
+	return &Result{Stdout: "ok"}, nil
+}
+
+func TestTool(t *testing.T) {
+	backend := &fakeBackend{}
+	tool := NewTool(backend)
+
+	out, err := tool.Call(context.Background(), json.RawMessage(`{"language":"python","code":"print(1)","stdin":"x"}`))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if out.(*Result).Stdout != "ok" {
+		t.Errorf("unexpected result %+v", out)
+	}
+	if backend.got.Language != LanguagePython || backend.got.Code != "print(1)" || backend.got.Stdin != "x" {
+		t.Errorf("backend got %+v", backend.got)
+	}
+
+	if _, err := tool.Call(context.Background(), json.RawMessage(`{"language":"python"}`)); err == nil {
+		t.Error("expected error for missing code")
+	}
+	if scopes := tools.ScopesOf(tool); len(scopes) != 1 || scopes[0] != ScopeProcessExec {
+		t.Errorf("Scopes = %v", scopes)
+	}
+}
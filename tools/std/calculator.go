@@ -0,0 +1,346 @@
+package std
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+// Calculator evaluates arithmetic expressions.
+//
+// Supported syntax: + - * / % ^, parentheses, unary minus, the constants pi
+// and e, and the functions abs, ceil, cos, exp, floor, ln, log, log10, max,
+// min, pow, round, sin, sqrt, and tan.
+type Calculator struct{}
+
+// NewCalculator creates a calculator tool.
+func NewCalculator() *Calculator {
+	return &Calculator{}
+}
+
+// Name implements tools.Tool.
+func (t *Calculator) Name() string { return "calculator" }
+
+// Description implements tools.Tool.
+func (t *Calculator) Description() string {
+	return "Evaluate an arithmetic expression such as \"(2 + 3) * sqrt(16) / 4\"."
+}
+
+// Schema implements tools.Tool.
+func (t *Calculator) Schema() tools.ToolSchema {
+	return schema(`{
+		"type": "object",
+		"properties": {
+			"expression": {"type": "string", "description": "Arithmetic expression to evaluate"}
+		},
+		"required": ["expression"]
+	}`)
+}
+
+// Call implements tools.Tool.
+func (t *Calculator) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	params, err := parseArgs[struct {
+		Expression string `json:"expression"`
+	}](args)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := Evaluate(params.Expression)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"expression": params.Expression, "result": value}, nil
+}
+
+// Evaluate computes the value of an arithmetic expression using the syntax
+// accepted by Calculator.
+func Evaluate(expr string) (float64, error) {
+	p := &exprParser{src: expr}
+	p.next()
+	v, err := p.parseExpr()
+	if err != nil {
+		return 0, err
+	}
+	if p.tok.kind != tokEOF {
+		return 0, fmt.Errorf("unexpected %q at position %d", p.tok.text, p.tok.pos)
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, errors.New("result is not a finite number")
+	}
+	return v, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokNumber
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	num  float64
+	pos  int
+}
+
+type exprParser struct {
+	src string
+	pos int
+	tok token
+	err error
+}
+
+func (p *exprParser) next() {
+	for p.pos < len(p.src) && unicode.IsSpace(rune(p.src[p.pos])) {
+		p.pos++
+	}
+	start := p.pos
+	if p.pos >= len(p.src) {
+		p.tok = token{kind: tokEOF, pos: start}
+		return
+	}
+
+	c := p.src[p.pos]
+	switch {
+	case c >= '0' && c <= '9' || c == '.':
+		for p.pos < len(p.src) && (isDigit(p.src[p.pos]) || p.src[p.pos] == '.') {
+			p.pos++
+		}
+		if p.pos < len(p.src) && (p.src[p.pos] == 'e' || p.src[p.pos] == 'E') {
+			save := p.pos
+			p.pos++
+			if p.pos < len(p.src) && (p.src[p.pos] == '+' || p.src[p.pos] == '-') {
+				p.pos++
+			}
+			if p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+				for p.pos < len(p.src) && isDigit(p.src[p.pos]) {
+					p.pos++
+				}
+			} else {
+				p.pos = save
+			}
+		}
+		text := p.src[start:p.pos]
+		n, err := strconv.ParseFloat(text, 64)
+		if err != nil && p.err == nil {
+			p.err = fmt.Errorf("invalid number %q", text)
+		}
+		p.tok = token{kind: tokNumber, text: text, num: n, pos: start}
+	case unicode.IsLetter(rune(c)):
+		for p.pos < len(p.src) && (unicode.IsLetter(rune(p.src[p.pos])) || isDigit(p.src[p.pos])) {
+			p.pos++
+		}
+		p.tok = token{kind: tokIdent, text: strings.ToLower(p.src[start:p.pos]), pos: start}
+	default:
+		p.pos++
+		p.tok = token{kind: tokOp, text: string(c), pos: start}
+	}
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func (p *exprParser) isOp(op string) bool {
+	return p.tok.kind == tokOp && p.tok.text == op
+}
+
+// parseExpr handles addition and subtraction.
+func (p *exprParser) parseExpr() (float64, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+	for p.isOp("+") || p.isOp("-") {
+		op := p.tok.text
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return 0, err
+		}
+		if op == "+" {
+			left += right
+		} else {
+			left -= right
+		}
+	}
+	return left, nil
+}
+
+// parseTerm handles multiplication, division, and modulo.
+func (p *exprParser) parseTerm() (float64, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	for p.isOp("*") || p.isOp("/") || p.isOp("%") {
+		op := p.tok.text
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		switch op {
+		case "*":
+			left *= right
+		case "/":
+			if right == 0 {
+				return 0, errors.New("division by zero")
+			}
+			left /= right
+		case "%":
+			if right == 0 {
+				return 0, errors.New("division by zero")
+			}
+			left = math.Mod(left, right)
+		}
+	}
+	return left, nil
+}
+
+// parseUnary handles leading signs.
+func (p *exprParser) parseUnary() (float64, error) {
+	if p.isOp("-") || p.isOp("+") {
+		neg := p.isOp("-")
+		p.next()
+		v, err := p.parseUnary()
+		if neg {
+			v = -v
+		}
+		return v, err
+	}
+	return p.parsePower()
+}
+
+// parsePower handles right-associative exponentiation.
+func (p *exprParser) parsePower() (float64, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return 0, err
+	}
+	if p.isOp("^") {
+		p.next()
+		exp, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		return math.Pow(base, exp), nil
+	}
+	return base, nil
+}
+
+func (p *exprParser) parsePrimary() (float64, error) {
+	if p.err != nil {
+		return 0, p.err
+	}
+
+	switch p.tok.kind {
+	case tokNumber:
+		v := p.tok.num
+		p.next()
+		return v, p.err
+	case tokIdent:
+		name := p.tok.text
+		p.next()
+		if !p.isOp("(") {
+			switch name {
+			case "pi":
+				return math.Pi, nil
+			case "e":
+				return math.E, nil
+			}
+			return 0, fmt.Errorf("unknown identifier %q", name)
+		}
+		p.next()
+		var args []float64
+		if !p.isOp(")") {
+			for {
+				v, err := p.parseExpr()
+				if err != nil {
+					return 0, err
+				}
+				args = append(args, v)
+				if !p.isOp(",") {
+					break
+				}
+				p.next()
+			}
+		}
+		if !p.isOp(")") {
+			return 0, errors.New("expected ')'")
+		}
+		p.next()
+		return callFunc(name, args)
+	case tokOp:
+		if p.isOp("(") {
+			p.next()
+			v, err := p.parseExpr()
+			if err != nil {
+				return 0, err
+			}
+			if !p.isOp(")") {
+				return 0, errors.New("expected ')'")
+			}
+			p.next()
+			return v, nil
+		}
+		return 0, fmt.Errorf("unexpected %q at position %d", p.tok.text, p.tok.pos)
+	default:
+		return 0, errors.New("unexpected end of expression")
+	}
+}
+
+var unaryFuncs = map[string]func(float64) float64{
+	"abs":   math.Abs,
+	"ceil":  math.Ceil,
+	"cos":   math.Cos,
+	"exp":   math.Exp,
+	"floor": math.Floor,
+	"ln":    math.Log,
+	"log":   math.Log,
+	"log10": math.Log10,
+	"round": math.Round,
+	"sin":   math.Sin,
+	"sqrt":  math.Sqrt,
+	"tan":   math.Tan,
+}
+
+func callFunc(name string, args []float64) (float64, error) {
+	if fn, ok := unaryFuncs[name]; ok {
+		if len(args) != 1 {
+			return 0, fmt.Errorf("%s expects 1 argument, got %d", name, len(args))
+		}
+		return fn(args[0]), nil
+	}
+
+	switch name {
+	case "pow":
+		if len(args) != 2 {
+			return 0, fmt.Errorf("pow expects 2 arguments, got %d", len(args))
+		}
+		return math.Pow(args[0], args[1]), nil
+	case "min", "max":
+		if len(args) == 0 {
+			return 0, fmt.Errorf("%s expects at least 1 argument", name)
+		}
+		result := args[0]
+		for _, v := range args[1:] {
+			if name == "min" {
+				result = math.Min(result, v)
+			} else {
+				result = math.Max(result, v)
+			}
+		}
+		return result, nil
+	}
+	return 0, fmt.Errorf("unknown function %q", name)
+}
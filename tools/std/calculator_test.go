@@ -0,0 +1,56 @@
+package std_test
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/petal-labs/iris/tools/std"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"-2 ^ 2", -4},
+		{"2 ^ 3 ^ 2", 512},
+		{"10 % 4", 2},
+		{"sqrt(16) / 4", 1},
+		{"max(1, 5, 3) - min(4, 2)", 3},
+		{"pow(2, 10)", 1024},
+		{"1.5e3 + .5", 1500.5},
+		{"round(pi * 100)", 314},
+	}
+	for _, tt := range tests {
+		got, err := std.Evaluate(tt.expr)
+		if err != nil {
+			t.Errorf("Evaluate(%q) error = %v", tt.expr, err)
+			continue
+		}
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Evaluate(%q) = %v, want %v", tt.expr, got, tt.want)
+		}
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	for _, expr := range []string{"", "1 +", "(1 + 2", "1 / 0", "foo(1)", "x + 1", "sqrt(1, 2)", "1 2", "sqrt(-1)", "1..2"} {
+		if _, err := std.Evaluate(expr); err == nil {
+			t.Errorf("Evaluate(%q) expected error", expr)
+		}
+	}
+}
+
+func TestCalculatorTool(t *testing.T) {
+	out, err := std.NewCalculator().Call(context.Background(), json.RawMessage(`{"expression":"6 * 7"}`))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if got := out.(map[string]any)["result"]; got != 42.0 {
+		t.Errorf("result = %v, want 42", got)
+	}
+}
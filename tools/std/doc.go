@@ -0,0 +1,15 @@
+// Package std provides a library of ready-to-use tools for common tasks.
+//
+// Every tool is conservative by default: network access is limited to an
+// explicit host allowlist, filesystem tools are confined to a sandbox root,
+// and shell execution is disabled until it is enabled with an allowlist of
+// commands. Tools declare permission scopes (see tools.ScopedTool) so they
+// can be governed by a tools.PermissionPolicy.
+//
+//	reg := tools.NewRegistry()
+//	reg.Register(std.NewCurrentTime())
+//	reg.Register(std.NewCalculator())
+//	reg.Register(std.NewHTTPFetch(std.HTTPFetchConfig{
+//	    AllowedHosts: []string{"api.example.com", "*.wikipedia.org"},
+//	}))
+package std
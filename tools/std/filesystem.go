@@ -0,0 +1,258 @@
+package std
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+const defaultFileMaxBytes = 1 << 20
+
+// FilesystemConfig configures the filesystem tools.
+type FilesystemConfig struct {
+	// Root is the sandbox directory. Paths supplied by the model are resolved
+	// relative to Root and may not escape it, including through symlinks.
+	Root string
+
+	// MaxBytes caps the size of files read or written. Defaults to 1 MiB.
+	MaxBytes int64
+}
+
+func (c FilesystemConfig) withDefaults() FilesystemConfig {
+	if c.MaxBytes <= 0 {
+		c.MaxBytes = defaultFileMaxBytes
+	}
+	return c
+}
+
+// openRoot opens the sandbox root for a single call.
+func (c FilesystemConfig) openRoot() (*os.Root, error) {
+	if c.Root == "" {
+		return nil, errors.New("filesystem sandbox root is not configured")
+	}
+	return os.OpenRoot(c.Root)
+}
+
+// sandboxPath converts a model-supplied path into a path relative to the root.
+func sandboxPath(p string) string {
+	p = filepath.Clean("/" + filepath.FromSlash(p))
+	rel := p[1:]
+	if rel == "" {
+		return "."
+	}
+	return rel
+}
+
+// ReadFile reads a text file from the sandbox.
+type ReadFile struct {
+	cfg FilesystemConfig
+}
+
+// NewReadFile creates a read_file tool confined to cfg.Root.
+func NewReadFile(cfg FilesystemConfig) *ReadFile {
+	return &ReadFile{cfg: cfg.withDefaults()}
+}
+
+// Name implements tools.Tool.
+func (t *ReadFile) Name() string { return "read_file" }
+
+// Description implements tools.Tool.
+func (t *ReadFile) Description() string {
+	return "Read the contents of a file from the workspace."
+}
+
+// Schema implements tools.Tool.
+func (t *ReadFile) Schema() tools.ToolSchema {
+	return schema(`{
+		"type": "object",
+		"properties": {
+			"path": {"type": "string", "description": "Path relative to the workspace root"}
+		},
+		"required": ["path"]
+	}`)
+}
+
+// Scopes implements tools.ScopedTool.
+func (t *ReadFile) Scopes() []string { return []string{ScopeFilesystemRead} }
+
+// Call implements tools.Tool.
+func (t *ReadFile) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	params, err := parseArgs[struct {
+		Path string `json:"path"`
+	}](args)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := t.cfg.openRoot()
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+
+	f, err := root.Open(sandboxPath(params.Path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, t.cfg.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > t.cfg.MaxBytes {
+		return nil, fmt.Errorf("file %q exceeds %d byte limit", params.Path, t.cfg.MaxBytes)
+	}
+	return string(data), nil
+}
+
+// WriteFile writes a text file inside the sandbox.
+type WriteFile struct {
+	cfg FilesystemConfig
+}
+
+// NewWriteFile creates a write_file tool confined to cfg.Root.
+// Missing parent directories are not created.
+func NewWriteFile(cfg FilesystemConfig) *WriteFile {
+	return &WriteFile{cfg: cfg.withDefaults()}
+}
+
+// Name implements tools.Tool.
+func (t *WriteFile) Name() string { return "write_file" }
+
+// Description implements tools.Tool.
+func (t *WriteFile) Description() string {
+	return "Write text to a file in the workspace, replacing any existing content."
+}
+
+// Schema implements tools.Tool.
+func (t *WriteFile) Schema() tools.ToolSchema {
+	return schema(`{
+		"type": "object",
+		"properties": {
+			"path": {"type": "string", "description": "Path relative to the workspace root"},
+			"content": {"type": "string", "description": "Text to write"}
+		},
+		"required": ["path", "content"]
+	}`)
+}
+
+// Scopes implements tools.ScopedTool.
+func (t *WriteFile) Scopes() []string { return []string{ScopeFilesystemWrite} }
+
+// Call implements tools.Tool.
+func (t *WriteFile) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	params, err := parseArgs[struct {
+		Path    string `json:"path"`
+		Content string `json:"content"`
+	}](args)
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(params.Content)) > t.cfg.MaxBytes {
+		return nil, fmt.Errorf("content exceeds %d byte limit", t.cfg.MaxBytes)
+	}
+
+	root, err := t.cfg.openRoot()
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+
+	f, err := root.OpenFile(sandboxPath(params.Path), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.WriteString(params.Content); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	return map[string]any{"path": params.Path, "bytes_written": len(params.Content)}, nil
+}
+
+// ListDir lists the entries of a directory inside the sandbox.
+type ListDir struct {
+	cfg FilesystemConfig
+}
+
+// NewListDir creates a list_dir tool confined to cfg.Root.
+func NewListDir(cfg FilesystemConfig) *ListDir {
+	return &ListDir{cfg: cfg.withDefaults()}
+}
+
+// Name implements tools.Tool.
+func (t *ListDir) Name() string { return "list_dir" }
+
+// Description implements tools.Tool.
+func (t *ListDir) Description() string {
+	return "List files and directories in a workspace directory."
+}
+
+// Schema implements tools.Tool.
+func (t *ListDir) Schema() tools.ToolSchema {
+	return schema(`{
+		"type": "object",
+		"properties": {
+			"path": {"type": "string", "description": "Directory relative to the workspace root. Defaults to the root."}
+		}
+	}`)
+}
+
+// Scopes implements tools.ScopedTool.
+func (t *ListDir) Scopes() []string { return []string{ScopeFilesystemRead} }
+
+// DirEntry describes a single entry returned by list_dir.
+type DirEntry struct {
+	Name  string `json:"name"`
+	IsDir bool   `json:"is_dir"`
+	Size  int64  `json:"size,omitempty"`
+}
+
+// Call implements tools.Tool.
+func (t *ListDir) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	params, err := parseArgs[struct {
+		Path string `json:"path"`
+	}](args)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := t.cfg.openRoot()
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+
+	dir, err := root.Open(sandboxPath(params.Path))
+	if err != nil {
+		return nil, err
+	}
+	defer dir.Close()
+
+	infos, err := dir.Readdir(-1)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name() < infos[j].Name() })
+
+	entries := make([]DirEntry, 0, len(infos))
+	for _, info := range infos {
+		entry := DirEntry{Name: info.Name(), IsDir: info.IsDir()}
+		if !info.IsDir() {
+			entry.Size = info.Size()
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
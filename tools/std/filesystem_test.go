@@ -0,0 +1,89 @@
+package std_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/petal-labs/iris/tools/std"
+)
+
+func TestFilesystemReadWriteList(t *testing.T) {
+	root := t.TempDir()
+	cfg := std.FilesystemConfig{Root: root}
+	ctx := context.Background()
+
+	write := std.NewWriteFile(cfg)
+	if _, err := write.Call(ctx, json.RawMessage(`{"path":"notes.txt","content":"hi there"}`)); err != nil {
+		t.Fatalf("write_file error = %v", err)
+	}
+
+	read := std.NewReadFile(cfg)
+	out, err := read.Call(ctx, json.RawMessage(`{"path":"/notes.txt"}`))
+	if err != nil {
+		t.Fatalf("read_file error = %v", err)
+	}
+	if out != "hi there" {
+		t.Errorf("read_file = %v", out)
+	}
+
+	if err := os.Mkdir(filepath.Join(root, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	list := std.NewListDir(cfg)
+	out, err = list.Call(ctx, json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("list_dir error = %v", err)
+	}
+	entries := out.([]std.DirEntry)
+	if len(entries) != 2 || entries[0].Name != "notes.txt" || entries[0].Size != 8 || !entries[1].IsDir {
+		t.Errorf("list_dir = %+v", entries)
+	}
+}
+
+func TestFilesystemSandbox(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "root")
+	if err := os.Mkdir(root, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(parent, "secret.txt"), []byte("secret"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(parent, "secret.txt"), filepath.Join(root, "link.txt")); err != nil {
+		t.Skipf("symlinks unsupported: %v", err)
+	}
+
+	read := std.NewReadFile(std.FilesystemConfig{Root: root})
+	ctx := context.Background()
+
+	if _, err := read.Call(ctx, json.RawMessage(`{"path":"../secret.txt"}`)); err == nil {
+		t.Error("expected traversal outside root to fail")
+	}
+	if _, err := read.Call(ctx, json.RawMessage(`{"path":"link.txt"}`)); err == nil {
+		t.Error("expected symlink escape to fail")
+	}
+}
+
+func TestFilesystemLimits(t *testing.T) {
+	root := t.TempDir()
+	cfg := std.FilesystemConfig{Root: root, MaxBytes: 4}
+	ctx := context.Background()
+
+	if _, err := std.NewWriteFile(cfg).Call(ctx, json.RawMessage(`{"path":"a.txt","content":"too long"}`)); err == nil {
+		t.Error("expected write over limit to fail")
+	}
+
+	if err := os.WriteFile(filepath.Join(root, "big.txt"), []byte("0123456789"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := std.NewReadFile(cfg).Call(ctx, json.RawMessage(`{"path":"big.txt"}`)); err == nil {
+		t.Error("expected read over limit to fail")
+	}
+
+	if _, err := std.NewReadFile(std.FilesystemConfig{}).Call(ctx, json.RawMessage(`{"path":"a"}`)); err == nil {
+		t.Error("expected error without root")
+	}
+}
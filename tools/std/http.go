@@ -0,0 +1,170 @@
+package std
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+// ErrHostNotAllowed is returned when a URL's host is not on the allowlist.
+var ErrHostNotAllowed = errors.New("host not allowed")
+
+const (
+	defaultHTTPMaxBytes = 1 << 20
+	defaultHTTPTimeout  = 30 * time.Second
+)
+
+// HTTPFetchConfig configures the http_fetch tool.
+type HTTPFetchConfig struct {
+	// AllowedHosts lists host names the tool may contact. Entries may use glob
+	// patterns such as "*.example.com". An empty list blocks every request.
+	AllowedHosts []string
+
+	// MaxBytes caps the response body returned to the model. Defaults to 1 MiB.
+	MaxBytes int64
+
+	// Timeout bounds each request. Defaults to 30 seconds.
+	Timeout time.Duration
+
+	// HTTPClient is used for requests. Defaults to http.DefaultClient.
+	// Redirects are always checked against AllowedHosts.
+	HTTPClient *http.Client
+
+	// UserAgent is sent with each request when set.
+	UserAgent string
+}
+
+// HTTPFetch fetches a URL over HTTP(S) from an allowlisted host.
+type HTTPFetch struct {
+	cfg    HTTPFetchConfig
+	client *http.Client
+}
+
+// NewHTTPFetch creates an http_fetch tool.
+func NewHTTPFetch(cfg HTTPFetchConfig) *HTTPFetch {
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = defaultHTTPMaxBytes
+	}
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultHTTPTimeout
+	}
+
+	base := cfg.HTTPClient
+	if base == nil {
+		base = http.DefaultClient
+	}
+	client := *base
+	t := &HTTPFetch{cfg: cfg, client: &client}
+	client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 10 {
+			return errors.New("stopped after 10 redirects")
+		}
+		return t.checkURL(req.URL)
+	}
+	return t
+}
+
+// Name implements tools.Tool.
+func (t *HTTPFetch) Name() string { return "http_fetch" }
+
+// Description implements tools.Tool.
+func (t *HTTPFetch) Description() string {
+	return "Fetch the contents of a web page or API endpoint with an HTTP GET request."
+}
+
+// Schema implements tools.Tool.
+func (t *HTTPFetch) Schema() tools.ToolSchema {
+	return schema(`{
+		"type": "object",
+		"properties": {
+			"url": {"type": "string", "description": "Absolute http or https URL to fetch"}
+		},
+		"required": ["url"]
+	}`)
+}
+
+// Scopes implements tools.ScopedTool.
+func (t *HTTPFetch) Scopes() []string { return []string{ScopeNetwork} }
+
+// HTTPFetchResult is returned by the http_fetch tool.
+type HTTPFetchResult struct {
+	URL         string `json:"url"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body"`
+	Truncated   bool   `json:"truncated,omitempty"`
+}
+
+// Call implements tools.Tool.
+func (t *HTTPFetch) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	params, err := parseArgs[struct {
+		URL string `json:"url"`
+	}](args)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(params.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if err := t.checkURL(u); err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.cfg.Timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if t.cfg.UserAgent != "" {
+		req.Header.Set("User-Agent", t.cfg.UserAgent)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, t.cfg.MaxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read response: %w", err)
+	}
+
+	result := &HTTPFetchResult{
+		URL:         resp.Request.URL.String(),
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if int64(len(body)) > t.cfg.MaxBytes {
+		body = body[:t.cfg.MaxBytes]
+		result.Truncated = true
+	}
+	result.Body = string(body)
+	return result, nil
+}
+
+func (t *HTTPFetch) checkURL(u *url.URL) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported url scheme %q", u.Scheme)
+	}
+	host := strings.ToLower(u.Hostname())
+	for _, pattern := range t.cfg.AllowedHosts {
+		if ok, _ := path.Match(strings.ToLower(pattern), host); ok {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrHostNotAllowed, host)
+}
@@ -0,0 +1,110 @@
+package std_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/petal-labs/iris/tools/std"
+)
+
+func TestHTTPFetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		fmt.Fprint(w, "hello world")
+	}))
+	defer srv.Close()
+
+	host := mustHost(t, srv.URL)
+	tool := std.NewHTTPFetch(std.HTTPFetchConfig{AllowedHosts: []string{host}})
+
+	out, err := tool.Call(context.Background(), json.RawMessage(fmt.Sprintf(`{"url":%q}`, srv.URL)))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	result := out.(*std.HTTPFetchResult)
+	if result.Status != http.StatusOK || result.Body != "hello world" || result.Truncated {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if result.ContentType != "text/plain" {
+		t.Errorf("ContentType = %q", result.ContentType)
+	}
+}
+
+func TestHTTPFetchTruncates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, strings.Repeat("x", 100))
+	}))
+	defer srv.Close()
+
+	tool := std.NewHTTPFetch(std.HTTPFetchConfig{AllowedHosts: []string{mustHost(t, srv.URL)}, MaxBytes: 10})
+	out, err := tool.Call(context.Background(), json.RawMessage(fmt.Sprintf(`{"url":%q}`, srv.URL)))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	result := out.(*std.HTTPFetchResult)
+	if len(result.Body) != 10 || !result.Truncated {
+		t.Errorf("expected 10 truncated bytes, got %d (truncated=%v)", len(result.Body), result.Truncated)
+	}
+}
+
+func TestHTTPFetchAllowlist(t *testing.T) {
+	tool := std.NewHTTPFetch(std.HTTPFetchConfig{AllowedHosts: []string{"*.example.com"}})
+
+	tests := []struct {
+		url     string
+		wantErr error
+	}{
+		{"https://evil.test/", std.ErrHostNotAllowed},
+		{"https://example.com.evil.test/", std.ErrHostNotAllowed},
+		{"file:///etc/passwd", nil},
+	}
+	for _, tt := range tests {
+		_, err := tool.Call(context.Background(), json.RawMessage(fmt.Sprintf(`{"url":%q}`, tt.url)))
+		if err == nil {
+			t.Errorf("%s: expected error", tt.url)
+			continue
+		}
+		if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: error = %v, want %v", tt.url, err, tt.wantErr)
+		}
+	}
+
+	empty := std.NewHTTPFetch(std.HTTPFetchConfig{})
+	if _, err := empty.Call(context.Background(), json.RawMessage(`{"url":"https://example.com"}`)); !errors.Is(err, std.ErrHostNotAllowed) {
+		t.Errorf("empty allowlist should block, got %v", err)
+	}
+}
+
+func TestHTTPFetchBlocksRedirectToDisallowedHost(t *testing.T) {
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "secret")
+	}))
+	defer target.Close()
+
+	redirector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, strings.Replace(target.URL, "127.0.0.1", "localhost", 1), http.StatusFound)
+	}))
+	defer redirector.Close()
+
+	tool := std.NewHTTPFetch(std.HTTPFetchConfig{AllowedHosts: []string{mustHost(t, redirector.URL)}})
+	_, err := tool.Call(context.Background(), json.RawMessage(fmt.Sprintf(`{"url":%q}`, redirector.URL)))
+	if !errors.Is(err, std.ErrHostNotAllowed) {
+		t.Errorf("expected redirect to be blocked, got %v", err)
+	}
+}
+
+func mustHost(t *testing.T, raw string) string {
+	t.Helper()
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u.Hostname()
+}
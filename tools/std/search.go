@@ -0,0 +1,101 @@
+package std
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+const (
+	defaultSearchResults = 5
+	maxSearchResults     = 20
+)
+
+// SearchResult is a single web search hit.
+type SearchResult struct {
+	Title   string `json:"title"`
+	URL     string `json:"url"`
+	Snippet string `json:"snippet,omitempty"`
+}
+
+// SearchEngine performs web searches for the web_search tool.
+// Implementations wrap a search API such as Brave, Bing, or SerpAPI.
+type SearchEngine interface {
+	Search(ctx context.Context, query string, limit int) ([]SearchResult, error)
+}
+
+// SearchFunc adapts a function to the SearchEngine interface.
+type SearchFunc func(ctx context.Context, query string, limit int) ([]SearchResult, error)
+
+// Search implements SearchEngine.
+func (f SearchFunc) Search(ctx context.Context, query string, limit int) ([]SearchResult, error) {
+	return f(ctx, query, limit)
+}
+
+// WebSearch searches the web through a pluggable SearchEngine.
+type WebSearch struct {
+	engine SearchEngine
+}
+
+// NewWebSearch creates a web_search tool backed by engine.
+func NewWebSearch(engine SearchEngine) *WebSearch {
+	return &WebSearch{engine: engine}
+}
+
+// Name implements tools.Tool.
+func (t *WebSearch) Name() string { return "web_search" }
+
+// Description implements tools.Tool.
+func (t *WebSearch) Description() string {
+	return "Search the web and return matching page titles, URLs, and snippets."
+}
+
+// Schema implements tools.Tool.
+func (t *WebSearch) Schema() tools.ToolSchema {
+	return schema(`{
+		"type": "object",
+		"properties": {
+			"query": {"type": "string", "description": "Search query"},
+			"limit": {"type": "integer", "minimum": 1, "maximum": 20, "description": "Maximum number of results (default 5)"}
+		},
+		"required": ["query"]
+	}`)
+}
+
+// Scopes implements tools.ScopedTool.
+func (t *WebSearch) Scopes() []string { return []string{ScopeNetwork} }
+
+// Call implements tools.Tool.
+func (t *WebSearch) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	if t.engine == nil {
+		return nil, errors.New("no search engine configured")
+	}
+
+	params, err := parseArgs[struct {
+		Query string `json:"query"`
+		Limit int    `json:"limit"`
+	}](args)
+	if err != nil {
+		return nil, err
+	}
+	if params.Query == "" {
+		return nil, errors.New("query is required")
+	}
+
+	limit := params.Limit
+	if limit <= 0 {
+		limit = defaultSearchResults
+	}
+	limit = min(limit, maxSearchResults)
+
+	results, err := t.engine.Search(ctx, params.Query, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
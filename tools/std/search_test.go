@@ -0,0 +1,59 @@
+package std_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/petal-labs/iris/tools/std"
+)
+
+func TestWebSearch(t *testing.T) {
+	var gotQuery string
+	var gotLimit int
+	engine := std.SearchFunc(func(ctx context.Context, query string, limit int) ([]std.SearchResult, error) {
+		gotQuery, gotLimit = query, limit
+		return []std.SearchResult{
+			{Title: "A", URL: "https://a.test"},
+			{Title: "B", URL: "https://b.test"},
+			{Title: "C", URL: "https://c.test"},
+		}, nil
+	})
+
+	tool := std.NewWebSearch(engine)
+	out, err := tool.Call(context.Background(), json.RawMessage(`{"query":"iris sdk","limit":2}`))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if gotQuery != "iris sdk" || gotLimit != 2 {
+		t.Errorf("engine got query=%q limit=%d", gotQuery, gotLimit)
+	}
+	if results := out.([]std.SearchResult); len(results) != 2 {
+		t.Errorf("expected results capped at 2, got %d", len(results))
+	}
+
+	if _, err := tool.Call(context.Background(), json.RawMessage(`{"limit":100,"query":"x"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if gotLimit != 20 {
+		t.Errorf("limit = %d, want clamp to 20", gotLimit)
+	}
+}
+
+func TestWebSearchErrors(t *testing.T) {
+	if _, err := std.NewWebSearch(nil).Call(context.Background(), json.RawMessage(`{"query":"x"}`)); err == nil {
+		t.Error("expected error without engine")
+	}
+
+	boom := errors.New("boom")
+	tool := std.NewWebSearch(std.SearchFunc(func(context.Context, string, int) ([]std.SearchResult, error) {
+		return nil, boom
+	}))
+	if _, err := tool.Call(context.Background(), json.RawMessage(`{"query":""}`)); err == nil {
+		t.Error("expected error for empty query")
+	}
+	if _, err := tool.Call(context.Background(), json.RawMessage(`{"query":"x"}`)); !errors.Is(err, boom) {
+		t.Errorf("expected engine error, got %v", err)
+	}
+}
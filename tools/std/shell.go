@@ -0,0 +1,170 @@
+package std
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"time"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+// ErrShellDisabled is returned when shell_exec is called without being enabled.
+var ErrShellDisabled = errors.New("shell execution is disabled")
+
+// ErrCommandNotAllowed is returned when a command is not on the allowlist.
+var ErrCommandNotAllowed = errors.New("command not allowed")
+
+const (
+	defaultShellTimeout  = 30 * time.Second
+	defaultShellMaxBytes = 64 << 10
+)
+
+// ShellConfig configures the shell_exec tool.
+type ShellConfig struct {
+	// Enabled must be set for the tool to run anything. The tool can be
+	// registered while disabled; calls then fail with ErrShellDisabled.
+	Enabled bool
+
+	// AllowedCommands restricts which executables may run. Empty allows any
+	// command once Enabled is set.
+	AllowedCommands []string
+
+	// Dir is the working directory for commands.
+	Dir string
+
+	// Env replaces the process environment when non-nil.
+	Env []string
+
+	// Timeout bounds each command. Defaults to 30 seconds.
+	Timeout time.Duration
+
+	// MaxOutputBytes caps captured stdout and stderr. Defaults to 64 KiB each.
+	MaxOutputBytes int
+}
+
+// Shell runs a command without a shell interpreter. Arguments are passed
+// directly to the executable, so pipes, globbing, and substitutions are not
+// interpreted.
+type Shell struct {
+	cfg ShellConfig
+}
+
+// NewShell creates a shell_exec tool. It is inert unless cfg.Enabled is set.
+func NewShell(cfg ShellConfig) *Shell {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = defaultShellTimeout
+	}
+	if cfg.MaxOutputBytes <= 0 {
+		cfg.MaxOutputBytes = defaultShellMaxBytes
+	}
+	return &Shell{cfg: cfg}
+}
+
+// Name implements tools.Tool.
+func (t *Shell) Name() string { return "shell_exec" }
+
+// Description implements tools.Tool.
+func (t *Shell) Description() string {
+	return "Run a command with arguments and return its exit code and output."
+}
+
+// Schema implements tools.Tool.
+func (t *Shell) Schema() tools.ToolSchema {
+	return schema(`{
+		"type": "object",
+		"properties": {
+			"command": {"type": "string", "description": "Executable to run"},
+			"args": {"type": "array", "items": {"type": "string"}, "description": "Command arguments"}
+		},
+		"required": ["command"]
+	}`)
+}
+
+// Scopes implements tools.ScopedTool.
+func (t *Shell) Scopes() []string { return []string{ScopeProcessExec} }
+
+// ShellResult is returned by the shell_exec tool.
+type ShellResult struct {
+	ExitCode  int    `json:"exit_code"`
+	Stdout    string `json:"stdout"`
+	Stderr    string `json:"stderr,omitempty"`
+	Truncated bool   `json:"truncated,omitempty"`
+}
+
+// Call implements tools.Tool.
+func (t *Shell) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	if !t.cfg.Enabled {
+		return nil, ErrShellDisabled
+	}
+
+	params, err := parseArgs[struct {
+		Command string   `json:"command"`
+		Args    []string `json:"args"`
+	}](args)
+	if err != nil {
+		return nil, err
+	}
+	if params.Command == "" {
+		return nil, errors.New("command is required")
+	}
+	if len(t.cfg.AllowedCommands) > 0 && !slices.Contains(t.cfg.AllowedCommands, params.Command) {
+		return nil, fmt.Errorf("%w: %s", ErrCommandNotAllowed, params.Command)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, t.cfg.Timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, params.Command, params.Args...)
+	cmd.Dir = t.cfg.Dir
+	if t.cfg.Env != nil {
+		cmd.Env = t.cfg.Env
+	}
+	stdout := &limitedBuffer{max: t.cfg.MaxOutputBytes}
+	stderr := &limitedBuffer{max: t.cfg.MaxOutputBytes}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	err = cmd.Run()
+	result := &ShellResult{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr) && ctx.Err() == nil:
+		result.ExitCode = exitErr.ExitCode()
+	case ctx.Err() != nil:
+		return nil, fmt.Errorf("command timed out: %w", ctx.Err())
+	default:
+		return nil, err
+	}
+	return result, nil
+}
+
+// limitedBuffer keeps at most max bytes and discards the rest.
+type limitedBuffer struct {
+	buf       bytes.Buffer
+	max       int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.buf.Len(); room < len(p) {
+		b.truncated = true
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *limitedBuffer) String() string { return b.buf.String() }
@@ -0,0 +1,46 @@
+package std_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os/exec"
+	"testing"
+
+	"github.com/petal-labs/iris/tools/std"
+)
+
+func TestShellDisabledByDefault(t *testing.T) {
+	tool := std.NewShell(std.ShellConfig{})
+	_, err := tool.Call(context.Background(), json.RawMessage(`{"command":"echo","args":["hi"]}`))
+	if !errors.Is(err, std.ErrShellDisabled) {
+		t.Errorf("expected ErrShellDisabled, got %v", err)
+	}
+}
+
+func TestShellAllowlist(t *testing.T) {
+	tool := std.NewShell(std.ShellConfig{Enabled: true, AllowedCommands: []string{"echo"}})
+	_, err := tool.Call(context.Background(), json.RawMessage(`{"command":"rm","args":["-rf","/"]}`))
+	if !errors.Is(err, std.ErrCommandNotAllowed) {
+		t.Errorf("expected ErrCommandNotAllowed, got %v", err)
+	}
+}
+
+func TestShellRun(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+
+	tool := std.NewShell(std.ShellConfig{Enabled: true, MaxOutputBytes: 5})
+	out, err := tool.Call(context.Background(), json.RawMessage(`{"command":"sh","args":["-c","echo hello world; exit 3"]}`))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	result := out.(*std.ShellResult)
+	if result.ExitCode != 3 {
+		t.Errorf("ExitCode = %d, want 3", result.ExitCode)
+	}
+	if result.Stdout != "hello" || !result.Truncated {
+		t.Errorf("Stdout = %q, Truncated = %v", result.Stdout, result.Truncated)
+	}
+}
@@ -0,0 +1,46 @@
+package std
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+// Scope labels declared by the standard tools.
+const (
+	ScopeNetwork         = "network"
+	ScopeFilesystemRead  = "filesystem:read"
+	ScopeFilesystemWrite = "filesystem:write"
+	ScopeProcessExec     = "process:exec"
+)
+
+// schema wraps a JSON Schema literal.
+func schema(s string) tools.ToolSchema {
+	return tools.ToolSchema{JSONSchema: json.RawMessage(s)}
+}
+
+// parseArgs decodes tool arguments into T.
+func parseArgs[T any](args json.RawMessage) (T, error) {
+	var v T
+	if len(args) == 0 {
+		return v, nil
+	}
+	if err := json.Unmarshal(args, &v); err != nil {
+		return v, fmt.Errorf("invalid arguments: %w", err)
+	}
+	return v, nil
+}
+
+// Compile-time checks that the standard tools declare scopes where they
+// touch external resources.
+var (
+	_ tools.ScopedTool = (*HTTPFetch)(nil)
+	_ tools.ScopedTool = (*ReadFile)(nil)
+	_ tools.ScopedTool = (*WriteFile)(nil)
+	_ tools.ScopedTool = (*ListDir)(nil)
+	_ tools.ScopedTool = (*Shell)(nil)
+	_ tools.ScopedTool = (*WebSearch)(nil)
+	_ tools.Tool       = (*CurrentTime)(nil)
+	_ tools.Tool       = (*Calculator)(nil)
+)
@@ -0,0 +1,72 @@
+package std
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/petal-labs/iris/tools"
+)
+
+// CurrentTime reports the current date and time.
+type CurrentTime struct {
+	now func() time.Time
+}
+
+// NewCurrentTime creates a current_time tool.
+func NewCurrentTime() *CurrentTime {
+	return &CurrentTime{now: time.Now}
+}
+
+// Name implements tools.Tool.
+func (t *CurrentTime) Name() string { return "current_time" }
+
+// Description implements tools.Tool.
+func (t *CurrentTime) Description() string {
+	return "Get the current date and time, optionally in a specific IANA time zone."
+}
+
+// Schema implements tools.Tool.
+func (t *CurrentTime) Schema() tools.ToolSchema {
+	return schema(`{
+		"type": "object",
+		"properties": {
+			"timezone": {"type": "string", "description": "IANA time zone such as America/New_York. Defaults to UTC."}
+		}
+	}`)
+}
+
+// CurrentTimeResult is returned by the current_time tool.
+type CurrentTimeResult struct {
+	Time     string `json:"time"`
+	Timezone string `json:"timezone"`
+	Weekday  string `json:"weekday"`
+	Unix     int64  `json:"unix"`
+}
+
+// Call implements tools.Tool.
+func (t *CurrentTime) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	params, err := parseArgs[struct {
+		Timezone string `json:"timezone"`
+	}](args)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := time.UTC
+	if params.Timezone != "" {
+		loc, err = time.LoadLocation(params.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("unknown timezone %q", params.Timezone)
+		}
+	}
+
+	now := t.now().In(loc)
+	return &CurrentTimeResult{
+		Time:     now.Format(time.RFC3339),
+		Timezone: loc.String(),
+		Weekday:  now.Weekday().String(),
+		Unix:     now.Unix(),
+	}, nil
+}
@@ -0,0 +1,42 @@
+package std_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/tools/std"
+)
+
+func TestCurrentTime(t *testing.T) {
+	tool := std.NewCurrentTime()
+
+	out, err := tool.Call(context.Background(), json.RawMessage(`{"timezone":"Asia/Tokyo"}`))
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	result := out.(*std.CurrentTimeResult)
+	if result.Timezone != "Asia/Tokyo" {
+		t.Errorf("Timezone = %q", result.Timezone)
+	}
+	parsed, err := time.Parse(time.RFC3339, result.Time)
+	if err != nil {
+		t.Fatalf("Time not RFC3339: %v", err)
+	}
+	if _, offset := parsed.Zone(); offset != 9*3600 {
+		t.Errorf("offset = %d, want +9h", offset)
+	}
+
+	out, err = tool.Call(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+	if got := out.(*std.CurrentTimeResult).Timezone; got != "UTC" {
+		t.Errorf("default Timezone = %q, want UTC", got)
+	}
+
+	if _, err := tool.Call(context.Background(), json.RawMessage(`{"timezone":"Mars/Olympus"}`)); err == nil {
+		t.Error("expected error for unknown timezone")
+	}
+}
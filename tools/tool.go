@@ -3,6 +3,8 @@ package tools
 import (
 	"context"
 	"encoding/json"
+
+	"github.com/petal-labs/iris/schema"
 )
 
 // Tool defines the interface for AI-callable tools.
@@ -27,6 +29,30 @@ type Tool interface {
 	Call(ctx context.Context, args json.RawMessage) (any, error)
 }
 
+// TypedTool is an optional interface for tools that declare the shape of their
+// result. The output schema documents what Call returns and lets middleware
+// such as WithOutputValidation check results before they reach the model.
+type TypedTool interface {
+	Tool
+
+	// OutputSchema returns the JSON Schema describing the tool's result.
+	OutputSchema() ToolSchema
+}
+
+// OutputSchemaOf returns the output schema declared by t.
+// It reports false if t does not implement TypedTool or declares an empty schema.
+func OutputSchemaOf(t Tool) (ToolSchema, bool) {
+	tt, ok := t.(TypedTool)
+	if !ok {
+		return ToolSchema{}, false
+	}
+	schema := tt.OutputSchema()
+	if len(schema.JSONSchema) == 0 {
+		return ToolSchema{}, false
+	}
+	return schema, true
+}
+
 // ToolSchema describes the parameters a tool accepts.
 // JSONSchema must be a valid JSON Schema object.
 type ToolSchema struct {
@@ -34,3 +60,32 @@ type ToolSchema struct {
 	// Example: {"type": "object", "properties": {"location": {"type": "string"}}}
 	JSONSchema json.RawMessage `json:"json_schema"`
 }
+
+// SchemaFor returns a ToolSchema generated from the Go type T, typically the
+// struct the tool decodes its arguments into. See package schema for the
+// supported json and jsonschema struct tags.
+//
+//	type weatherArgs struct {
+//	    City string `json:"city" jsonschema:"description=City name"`
+//	}
+//
+//	func (weatherTool) Schema() tools.ToolSchema {
+//	    return tools.MustSchemaFor[weatherArgs]()
+//	}
+func SchemaFor[T any]() (ToolSchema, error) {
+	raw, err := schema.RawFor[T]()
+	if err != nil {
+		return ToolSchema{}, err
+	}
+	return ToolSchema{JSONSchema: raw}, nil
+}
+
+// MustSchemaFor is like SchemaFor but panics if T has no JSON Schema
+// representation.
+func MustSchemaFor[T any]() ToolSchema {
+	s, err := SchemaFor[T]()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
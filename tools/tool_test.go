@@ -90,3 +90,87 @@ func TestToolSchemaJSONSerialization(t *testing.T) {
 		t.Errorf("Round-trip failed: got %q, want %q", string(parsed.JSONSchema), string(schema.JSONSchema))
 	}
 }
+
+// typedMockTool is a mockTool that also declares an output schema.
+type typedMockTool struct {
+	mockTool
+	output tools.ToolSchema
+}
+
+func (m *typedMockTool) OutputSchema() tools.ToolSchema { return m.output }
+
+func TestOutputSchemaOf(t *testing.T) {
+	plain := &mockTool{name: "plain"}
+	if _, ok := tools.OutputSchemaOf(plain); ok {
+		t.Error("OutputSchemaOf(untyped tool) should report false")
+	}
+
+	typed := &typedMockTool{
+		mockTool: mockTool{name: "typed"},
+		output:   tools.ToolSchema{JSONSchema: json.RawMessage(`{"type":"string"}`)},
+	}
+	schema, ok := tools.OutputSchemaOf(typed)
+	if !ok {
+		t.Fatal("OutputSchemaOf(typed tool) should report true")
+	}
+	if string(schema.JSONSchema) != `{"type":"string"}` {
+		t.Errorf("OutputSchema = %s, want %s", schema.JSONSchema, `{"type":"string"}`)
+	}
+
+	empty := &typedMockTool{mockTool: mockTool{name: "empty"}}
+	if _, ok := tools.OutputSchemaOf(empty); ok {
+		t.Error("OutputSchemaOf(empty schema) should report false")
+	}
+}
+
+func TestOutputSchemaSurvivesMiddleware(t *testing.T) {
+	typed := &typedMockTool{
+		mockTool: mockTool{
+			name: "typed",
+			callFn: func(ctx context.Context, args json.RawMessage) (any, error) {
+				tc := tools.ToolContextFromContext(ctx)
+				if tc == nil || string(tc.OutputSchema) != `{"type":"string"}` {
+					t.Errorf("ToolContext.OutputSchema not propagated: %+v", tc)
+				}
+				return "ok", nil
+			},
+		},
+		output: tools.ToolSchema{JSONSchema: json.RawMessage(`{"type":"string"}`)},
+	}
+
+	wrapped := tools.ApplyMiddleware(typed, tools.WithBasicValidation())
+	if _, ok := tools.OutputSchemaOf(wrapped); !ok {
+		t.Fatal("wrapped tool lost its output schema")
+	}
+	if _, err := wrapped.Call(context.Background(), json.RawMessage(`{}`)); err != nil {
+		t.Fatalf("Call() error = %v", err)
+	}
+}
+
+func TestSchemaFor(t *testing.T) {
+	type args struct {
+		City  string `json:"city" jsonschema:"description=City name"`
+		Units string `json:"units,omitempty" jsonschema:"enum=c|f"`
+	}
+
+	s := tools.MustSchemaFor[args]()
+
+	var got struct {
+		Type       string                     `json:"type"`
+		Properties map[string]json.RawMessage `json:"properties"`
+		Required   []string                   `json:"required"`
+	}
+	if err := json.Unmarshal(s.JSONSchema, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if got.Type != "object" || len(got.Properties) != 2 {
+		t.Errorf("schema = %s", s.JSONSchema)
+	}
+	if len(got.Required) != 1 || got.Required[0] != "city" {
+		t.Errorf("required = %v, want [city]", got.Required)
+	}
+
+	if _, err := tools.SchemaFor[func()](); err == nil {
+		t.Error("SchemaFor[func()]() error = nil, want error")
+	}
+}
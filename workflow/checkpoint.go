@@ -0,0 +1,69 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// Checkpoint is the saved progress of a run.
+type Checkpoint struct {
+	// Outputs holds the JSON-encoded output of each completed node.
+	Outputs map[string]json.RawMessage `json:"outputs"`
+}
+
+func (c *Checkpoint) clone() *Checkpoint {
+	out := &Checkpoint{Outputs: make(map[string]json.RawMessage, len(c.Outputs))}
+	for name, raw := range c.Outputs {
+		out.Outputs[name] = append(json.RawMessage(nil), raw...)
+	}
+	return out
+}
+
+// Checkpointer persists run progress. Implementations must be safe for
+// concurrent use across runs; saves within one run are sequential.
+type Checkpointer interface {
+	// Load returns the checkpoint for runID, or nil if there is none.
+	Load(ctx context.Context, runID string) (*Checkpoint, error)
+
+	// Save stores the checkpoint for runID, replacing any previous one.
+	Save(ctx context.Context, runID string, cp *Checkpoint) error
+}
+
+// MemoryCheckpointer keeps checkpoints in memory. It is useful for tests and
+// for resuming within a single process, for example after a human gate.
+type MemoryCheckpointer struct {
+	mu   sync.Mutex
+	runs map[string]*Checkpoint
+}
+
+// NewMemoryCheckpointer returns an empty in-memory checkpointer.
+func NewMemoryCheckpointer() *MemoryCheckpointer {
+	return &MemoryCheckpointer{runs: make(map[string]*Checkpoint)}
+}
+
+// Load returns a copy of the checkpoint for runID, or nil.
+func (m *MemoryCheckpointer) Load(_ context.Context, runID string) (*Checkpoint, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	cp, ok := m.runs[runID]
+	if !ok {
+		return nil, nil
+	}
+	return cp.clone(), nil
+}
+
+// Save stores a copy of cp under runID.
+func (m *MemoryCheckpointer) Save(_ context.Context, runID string, cp *Checkpoint) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.runs[runID] = cp.clone()
+	return nil
+}
+
+// Delete removes the checkpoint for runID.
+func (m *MemoryCheckpointer) Delete(runID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.runs, runID)
+}
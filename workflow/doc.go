@@ -0,0 +1,45 @@
+// Package workflow runs deterministic pipelines of LLM calls, tool calls, and
+// plain Go functions as a directed acyclic graph.
+//
+// Where a free-running agent loop lets the model decide what happens next, a
+// workflow fixes the steps up front. Each node names the nodes it depends on;
+// nodes whose dependencies have completed run concurrently.
+//
+//	wf, err := workflow.NewBuilder().
+//	    Node("outline", workflow.LLM(client, model, func(in *workflow.Results) (string, error) {
+//	        return "Outline a post about " + topic, nil
+//	    })).
+//	    Node("review", workflow.Gate(askEditor), workflow.After("outline")).
+//	    Node("draft", workflow.LLM(client, model, func(in *workflow.Results) (string, error) {
+//	        outline, err := workflow.Value[*core.ChatResponse](in, "outline")
+//	        if err != nil {
+//	            return "", err
+//	        }
+//	        return "Write the post from this outline:\n" + outline.Output, nil
+//	    }), workflow.After("review"), workflow.Retry(3, time.Second)).
+//	    Build()
+//
+//	results, err := wf.Run(ctx)
+//	draft, err := workflow.Value[*core.ChatResponse](results, "draft")
+//
+// # Nodes
+//
+// A node is a [NodeFunc] that reads upstream outputs from [Results] and returns
+// its own output. Helpers build the common kinds:
+//   - [LLM] and [LLMRequest]: a chat request through a core.Client
+//   - [Tool]: a call to a tools.Tool
+//   - [Branch]: selects a label; downstream nodes opt in with [When]
+//   - [Map] and [Reduce]: fan out over items and fold the results
+//   - [Gate]: a human approval step that fails the run with ErrRejected
+//
+// Nodes excluded by a branch are skipped, as are nodes that depend on a
+// skipped node.
+//
+// # Checkpointing
+//
+// With [WithCheckpointer], the output of every completed node is saved as JSON
+// under a run ID. Running the workflow again with the same run ID restores
+// those outputs and only executes the nodes that had not completed, so a run
+// that failed or was stopped at a human gate can be resumed. Node outputs must
+// therefore be JSON-serializable when checkpointing is used.
+package workflow
@@ -0,0 +1,156 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/petal-labs/iris/core"
+	"github.com/petal-labs/iris/tools"
+)
+
+// LLM returns a node that sends a single user prompt to model and outputs the
+// *core.ChatResponse. The prompt is built from upstream results.
+func LLM(client *core.Client, model core.ModelID, prompt func(in *Results) (string, error)) NodeFunc {
+	return LLMRequest(client, model, func(in *Results, b *core.ChatBuilder) error {
+		p, err := prompt(in)
+		if err != nil {
+			return err
+		}
+		b.User(p)
+		return nil
+	})
+}
+
+// LLMRequest returns a node that lets build configure the whole chat request
+// (system prompt, messages, response format, and so on) and outputs the
+// *core.ChatResponse.
+func LLMRequest(client *core.Client, model core.ModelID, build func(in *Results, b *core.ChatBuilder) error) NodeFunc {
+	return func(ctx context.Context, in *Results) (any, error) {
+		b := client.Chat(model)
+		if err := build(in, b); err != nil {
+			return nil, err
+		}
+		return b.GetResponse(ctx)
+	}
+}
+
+// Tool returns a node that calls t with arguments built from upstream
+// results and outputs the tool's result. Arguments are JSON-encoded unless
+// args returns a json.RawMessage.
+func Tool(t tools.Tool, args func(in *Results) (any, error)) NodeFunc {
+	return func(ctx context.Context, in *Results) (any, error) {
+		a, err := args(in)
+		if err != nil {
+			return nil, err
+		}
+		raw, ok := a.(json.RawMessage)
+		if !ok {
+			if raw, err = json.Marshal(a); err != nil {
+				return nil, fmt.Errorf("encode %s arguments: %w", t.Name(), err)
+			}
+		}
+		return t.Call(ctx, raw)
+	}
+}
+
+// Branch returns a node that outputs the label chosen by choose. Downstream
+// nodes declared with When(branch, label) run only for the chosen label; the
+// others are skipped.
+func Branch(choose func(ctx context.Context, in *Results) (string, error)) NodeFunc {
+	return func(ctx context.Context, in *Results) (any, error) {
+		return choose(ctx, in)
+	}
+}
+
+// Map returns a node that applies fn to every item and outputs the results as
+// []R in item order. At most concurrency items run at once; zero or less runs
+// all items concurrently. The first error cancels the remaining items.
+func Map[T, R any](items func(in *Results) ([]T, error), fn func(ctx context.Context, item T) (R, error), concurrency int) NodeFunc {
+	return func(ctx context.Context, in *Results) (any, error) {
+		list, err := items(in)
+		if err != nil {
+			return nil, err
+		}
+		if concurrency <= 0 || concurrency > len(list) {
+			concurrency = len(list)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		out := make([]R, len(list))
+		sem := make(chan struct{}, max(concurrency, 1))
+		var wg sync.WaitGroup
+		var once sync.Once
+		var firstErr error
+
+		for i, item := range list {
+			wg.Add(1)
+			go func(i int, item T) {
+				defer wg.Done()
+				select {
+				case sem <- struct{}{}:
+					defer func() { <-sem }()
+				case <-ctx.Done():
+					return
+				}
+				r, err := fn(ctx, item)
+				if err != nil {
+					once.Do(func() {
+						firstErr = fmt.Errorf("item %d: %w", i, err)
+						cancel()
+					})
+					return
+				}
+				out[i] = r
+			}(i, item)
+		}
+		wg.Wait()
+
+		if firstErr != nil {
+			return nil, firstErr
+		}
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+}
+
+// Reduce returns a node that folds the []R output of the node named from into
+// a single value, starting with initial. The node must also be declared
+// After(from).
+func Reduce[R, A any](from string, initial A, fn func(acc A, item R) (A, error)) NodeFunc {
+	return func(ctx context.Context, in *Results) (any, error) {
+		items, err := Value[[]R](in, from)
+		if err != nil {
+			return nil, err
+		}
+		acc := initial
+		for _, item := range items {
+			if acc, err = fn(acc, item); err != nil {
+				return nil, err
+			}
+		}
+		return acc, nil
+	}
+}
+
+// Gate returns a human approval node. approve typically notifies a reviewer
+// and waits for a decision. A rejection fails the run with ErrRejected; with
+// a checkpointer the run can be resumed later and only the gate and the nodes
+// after it execute again.
+func Gate(approve func(ctx context.Context, in *Results) (bool, error)) NodeFunc {
+	return func(ctx context.Context, in *Results) (any, error) {
+		ok, err := approve(ctx, in)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrRejected
+		}
+		return true, nil
+	}
+}
@@ -0,0 +1,441 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCycle is returned by Build when node dependencies form a cycle.
+	ErrCycle = errors.New("workflow: dependency cycle")
+
+	// ErrUnknownNode is returned by Build when a node depends on a node that
+	// does not exist.
+	ErrUnknownNode = errors.New("workflow: unknown node")
+
+	// ErrDuplicateNode is returned by Build when two nodes share a name.
+	ErrDuplicateNode = errors.New("workflow: duplicate node")
+
+	// ErrSkipped is returned by Value for a node that was skipped by a branch.
+	ErrSkipped = errors.New("workflow: node skipped")
+
+	// ErrNotRun is returned by Value for a node that has not produced output.
+	ErrNotRun = errors.New("workflow: node has not run")
+
+	// ErrRejected is returned when a human gate rejects the run.
+	ErrRejected = errors.New("workflow: rejected at gate")
+)
+
+// NodeFunc executes a node. It reads upstream outputs from in and returns the
+// node's output, which downstream nodes retrieve with Value.
+type NodeFunc func(ctx context.Context, in *Results) (any, error)
+
+// NodeOption configures a node.
+type NodeOption func(*node)
+
+// After makes the node run only after the named nodes have completed.
+func After(names ...string) NodeOption {
+	return func(n *node) {
+		n.deps = append(n.deps, names...)
+	}
+}
+
+// When makes the node run only if the branch node selected label.
+// The branch node is added as a dependency.
+func When(branch, label string) NodeOption {
+	return func(n *node) {
+		n.deps = append(n.deps, branch)
+		n.conds = append(n.conds, condition{branch: branch, label: label})
+	}
+}
+
+// Retry retries a failing node up to attempts times in total, waiting backoff
+// between attempts. Gate rejections and context cancellation are not retried.
+func Retry(attempts int, backoff time.Duration) NodeOption {
+	return func(n *node) {
+		n.attempts = attempts
+		n.backoff = backoff
+	}
+}
+
+type condition struct {
+	branch string
+	label  string
+}
+
+type node struct {
+	name     string
+	fn       NodeFunc
+	deps     []string
+	conds    []condition
+	attempts int
+	backoff  time.Duration
+}
+
+// Builder assembles a Workflow. Errors are reported by Build.
+type Builder struct {
+	nodes []*node
+	index map[string]*node
+	err   error
+}
+
+// NewBuilder returns an empty workflow builder.
+func NewBuilder() *Builder {
+	return &Builder{index: make(map[string]*node)}
+}
+
+// Node adds a named node.
+func (b *Builder) Node(name string, fn NodeFunc, opts ...NodeOption) *Builder {
+	if b.err != nil {
+		return b
+	}
+	if _, ok := b.index[name]; ok {
+		b.err = fmt.Errorf("%w: %q", ErrDuplicateNode, name)
+		return b
+	}
+	if fn == nil {
+		b.err = fmt.Errorf("workflow: node %q has no function", name)
+		return b
+	}
+
+	n := &node{name: name, fn: fn, attempts: 1}
+	for _, opt := range opts {
+		opt(n)
+	}
+	b.nodes = append(b.nodes, n)
+	b.index[name] = n
+	return b
+}
+
+// Build validates the graph and returns a Workflow.
+func (b *Builder) Build() (*Workflow, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	for _, n := range b.nodes {
+		for _, dep := range n.deps {
+			if _, ok := b.index[dep]; !ok {
+				return nil, fmt.Errorf("%w: %q (dependency of %q)", ErrUnknownNode, dep, n.name)
+			}
+		}
+	}
+
+	order, err := topoSort(b.nodes, b.index)
+	if err != nil {
+		return nil, err
+	}
+	return &Workflow{nodes: order}, nil
+}
+
+// topoSort orders nodes so that every node follows its dependencies,
+// keeping insertion order where dependencies allow.
+func topoSort(nodes []*node, index map[string]*node) ([]*node, error) {
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(nodes))
+	order := make([]*node, 0, len(nodes))
+
+	var visit func(n *node) error
+	visit = func(n *node) error {
+		switch state[n.name] {
+		case visiting:
+			return fmt.Errorf("%w at %q", ErrCycle, n.name)
+		case visited:
+			return nil
+		}
+		state[n.name] = visiting
+		for _, dep := range n.deps {
+			if err := visit(index[dep]); err != nil {
+				return err
+			}
+		}
+		state[n.name] = visited
+		order = append(order, n)
+		return nil
+	}
+
+	for _, n := range nodes {
+		if err := visit(n); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// Workflow is a validated graph of nodes. It is safe to run concurrently.
+type Workflow struct {
+	nodes []*node // in dependency order
+}
+
+// RunOption configures a single run.
+type RunOption func(*runConfig)
+
+type runConfig struct {
+	checkpointer Checkpointer
+	runID        string
+}
+
+// WithCheckpointer saves node outputs under runID as they complete and
+// restores them at the start of the run, so only unfinished nodes execute.
+func WithCheckpointer(c Checkpointer, runID string) RunOption {
+	return func(cfg *runConfig) {
+		cfg.checkpointer = c
+		cfg.runID = runID
+	}
+}
+
+type nodeStatus int
+
+const (
+	statusPending nodeStatus = iota
+	statusRunning
+	statusDone
+	statusSkipped
+)
+
+type outcome struct {
+	name  string
+	value any
+	err   error
+}
+
+// Run executes the workflow. Nodes start as soon as their dependencies have
+// completed. The first node error cancels the remaining nodes and is
+// returned, together with the results of the nodes that completed.
+func (w *Workflow) Run(ctx context.Context, opts ...RunOption) (*Results, error) {
+	var cfg runConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	results := newResults()
+	status := make(map[string]nodeStatus, len(w.nodes))
+
+	var checkpoint *Checkpoint
+	if cfg.checkpointer != nil {
+		cp, err := cfg.checkpointer.Load(ctx, cfg.runID)
+		if err != nil {
+			return nil, fmt.Errorf("workflow: load checkpoint: %w", err)
+		}
+		if cp == nil {
+			cp = &Checkpoint{}
+		}
+		checkpoint = cp.clone()
+		for name, raw := range checkpoint.Outputs {
+			results.restore(name, raw)
+			status[name] = statusDone
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	done := make(chan outcome)
+	running := 0
+	var runErr error
+
+	for {
+		if runErr == nil {
+			running += w.schedule(ctx, status, results, done)
+		}
+		if running == 0 {
+			break
+		}
+
+		o := <-done
+		running--
+		if o.err != nil {
+			if runErr == nil {
+				runErr = fmt.Errorf("workflow: node %q: %w", o.name, o.err)
+				cancel()
+			}
+			continue
+		}
+
+		status[o.name] = statusDone
+		results.set(o.name, o.value)
+		if checkpoint != nil && runErr == nil {
+			if err := saveOutput(ctx, cfg, checkpoint, o); err != nil {
+				runErr = err
+				cancel()
+			}
+		}
+	}
+
+	return results, runErr
+}
+
+// schedule starts every pending node whose dependencies are resolved and marks
+// nodes excluded by a branch as skipped. It returns the number started.
+func (w *Workflow) schedule(ctx context.Context, status map[string]nodeStatus, results *Results, done chan<- outcome) int {
+	started := 0
+	// Nodes are in dependency order, so skips propagate in a single pass.
+	for _, n := range w.nodes {
+		if status[n.name] != statusPending {
+			continue
+		}
+
+		ready, skip := true, false
+		for _, dep := range n.deps {
+			switch status[dep] {
+			case statusSkipped:
+				skip = true
+			case statusDone:
+			default:
+				ready = false
+			}
+		}
+		if !ready {
+			continue
+		}
+		if !skip {
+			for _, c := range n.conds {
+				if label, err := Value[string](results, c.branch); err != nil || label != c.label {
+					skip = true
+				}
+			}
+		}
+		if skip {
+			status[n.name] = statusSkipped
+			results.skip(n.name)
+			continue
+		}
+
+		status[n.name] = statusRunning
+		started++
+		go func(n *node) {
+			value, err := n.run(ctx, results)
+			done <- outcome{name: n.name, value: value, err: err}
+		}(n)
+	}
+	return started
+}
+
+// run calls the node function, retrying as configured.
+func (n *node) run(ctx context.Context, in *Results) (any, error) {
+	var lastErr error
+	for attempt := 0; attempt < max(n.attempts, 1); attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(n.backoff):
+			}
+		}
+
+		value, err := n.fn(ctx, in)
+		if err == nil {
+			return value, nil
+		}
+		lastErr = err
+		if errors.Is(err, ErrRejected) || ctx.Err() != nil {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// saveOutput records a completed node in the checkpoint and persists it.
+func saveOutput(ctx context.Context, cfg runConfig, cp *Checkpoint, o outcome) error {
+	raw, err := json.Marshal(o.value)
+	if err != nil {
+		return fmt.Errorf("workflow: checkpoint node %q: %w", o.name, err)
+	}
+	if cp.Outputs == nil {
+		cp.Outputs = make(map[string]json.RawMessage)
+	}
+	cp.Outputs[o.name] = raw
+	if err := cfg.checkpointer.Save(ctx, cfg.runID, cp.clone()); err != nil {
+		return fmt.Errorf("workflow: save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// Results holds node outputs during and after a run.
+// It is safe for concurrent use.
+type Results struct {
+	mu      sync.RWMutex
+	values  map[string]any
+	raw     map[string]json.RawMessage // outputs restored from a checkpoint
+	skipped map[string]bool
+}
+
+func newResults() *Results {
+	return &Results{
+		values:  make(map[string]any),
+		raw:     make(map[string]json.RawMessage),
+		skipped: make(map[string]bool),
+	}
+}
+
+func (r *Results) set(name string, value any) {
+	r.mu.Lock()
+	r.values[name] = value
+	r.mu.Unlock()
+}
+
+func (r *Results) restore(name string, raw json.RawMessage) {
+	r.mu.Lock()
+	r.raw[name] = raw
+	r.mu.Unlock()
+}
+
+func (r *Results) skip(name string) {
+	r.mu.Lock()
+	r.skipped[name] = true
+	r.mu.Unlock()
+}
+
+// Has reports whether the node has produced output.
+func (r *Results) Has(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.values[name]
+	_, restored := r.raw[name]
+	return ok || restored
+}
+
+// Skipped reports whether the node was skipped by a branch.
+func (r *Results) Skipped(name string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.skipped[name]
+}
+
+// Value returns the output of the named node as T. Outputs restored from a
+// checkpoint are decoded from JSON into T.
+func Value[T any](r *Results, name string) (T, error) {
+	var zero T
+
+	r.mu.RLock()
+	v, ok := r.values[name]
+	raw, restored := r.raw[name]
+	skipped := r.skipped[name]
+	r.mu.RUnlock()
+
+	switch {
+	case skipped:
+		return zero, fmt.Errorf("%w: %q", ErrSkipped, name)
+	case ok:
+		t, ok := v.(T)
+		if !ok && v != nil {
+			return zero, fmt.Errorf("workflow: node %q output is %T, not %T", name, v, zero)
+		}
+		return t, nil
+	case restored:
+		var t T
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return zero, fmt.Errorf("workflow: decode checkpointed output of %q: %w", name, err)
+		}
+		return t, nil
+	default:
+		return zero, fmt.Errorf("%w: %q", ErrNotRun, name)
+	}
+}
@@ -0,0 +1,296 @@
+package workflow_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/petal-labs/iris/core"
+	iristesting "github.com/petal-labs/iris/testing"
+	"github.com/petal-labs/iris/tools"
+	"github.com/petal-labs/iris/workflow"
+)
+
+// constant returns a node that outputs v.
+func constant(v any) workflow.NodeFunc {
+	return func(ctx context.Context, in *workflow.Results) (any, error) {
+		return v, nil
+	}
+}
+
+func TestRunDependencies(t *testing.T) {
+	wf, err := workflow.NewBuilder().
+		Node("sum", func(ctx context.Context, in *workflow.Results) (any, error) {
+			a, err := workflow.Value[int](in, "a")
+			if err != nil {
+				return nil, err
+			}
+			b, err := workflow.Value[int](in, "b")
+			if err != nil {
+				return nil, err
+			}
+			return a + b, nil
+		}, workflow.After("a", "b")).
+		Node("a", constant(2)).
+		Node("b", constant(3)).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	results, err := wf.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if sum, err := workflow.Value[int](results, "sum"); err != nil || sum != 5 {
+		t.Errorf("sum = %d, %v, want 5", sum, err)
+	}
+}
+
+func TestBuildErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *workflow.Builder
+		want    error
+	}{
+		{
+			name:    "unknown dependency",
+			builder: workflow.NewBuilder().Node("a", constant(1), workflow.After("missing")),
+			want:    workflow.ErrUnknownNode,
+		},
+		{
+			name:    "duplicate",
+			builder: workflow.NewBuilder().Node("a", constant(1)).Node("a", constant(2)),
+			want:    workflow.ErrDuplicateNode,
+		},
+		{
+			name: "cycle",
+			builder: workflow.NewBuilder().
+				Node("a", constant(1), workflow.After("b")).
+				Node("b", constant(2), workflow.After("a")),
+			want: workflow.ErrCycle,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.builder.Build(); !errors.Is(err, tt.want) {
+				t.Errorf("Build() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBranch(t *testing.T) {
+	wf, err := workflow.NewBuilder().
+		Node("route", workflow.Branch(func(ctx context.Context, in *workflow.Results) (string, error) {
+			return "refund", nil
+		})).
+		Node("refund", constant("refunded"), workflow.When("route", "refund")).
+		Node("escalate", constant("escalated"), workflow.When("route", "escalate")).
+		Node("notify", constant("sent"), workflow.After("escalate")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	results, err := wf.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if v, _ := workflow.Value[string](results, "refund"); v != "refunded" {
+		t.Errorf("refund = %q", v)
+	}
+	if !results.Skipped("escalate") || !results.Skipped("notify") {
+		t.Error("escalate and its dependents should be skipped")
+	}
+	if _, err := workflow.Value[string](results, "notify"); !errors.Is(err, workflow.ErrSkipped) {
+		t.Errorf("Value(notify) error = %v, want ErrSkipped", err)
+	}
+}
+
+func TestMapReduce(t *testing.T) {
+	wf, err := workflow.NewBuilder().
+		Node("words", constant([]string{"a", "bb", "ccc"})).
+		Node("lengths", workflow.Map(
+			func(in *workflow.Results) ([]string, error) {
+				return workflow.Value[[]string](in, "words")
+			},
+			func(ctx context.Context, w string) (int, error) {
+				return len(w), nil
+			}, 2), workflow.After("words")).
+		Node("total", workflow.Reduce("lengths", 0, func(acc, n int) (int, error) {
+			return acc + n, nil
+		}), workflow.After("lengths")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	results, err := wf.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if lengths, _ := workflow.Value[[]int](results, "lengths"); len(lengths) != 3 || lengths[2] != 3 {
+		t.Errorf("lengths = %v, want [1 2 3]", lengths)
+	}
+	if total, _ := workflow.Value[int](results, "total"); total != 6 {
+		t.Errorf("total = %d, want 6", total)
+	}
+}
+
+func TestRetry(t *testing.T) {
+	var calls atomic.Int32
+	flaky := func(ctx context.Context, in *workflow.Results) (any, error) {
+		if calls.Add(1) < 3 {
+			return nil, errors.New("transient")
+		}
+		return "ok", nil
+	}
+
+	wf, err := workflow.NewBuilder().
+		Node("flaky", flaky, workflow.Retry(3, time.Millisecond)).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	if _, err := wf.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+}
+
+func TestNodeErrorStopsRun(t *testing.T) {
+	var downstream atomic.Bool
+	wf, err := workflow.NewBuilder().
+		Node("fail", func(ctx context.Context, in *workflow.Results) (any, error) {
+			return nil, errors.New("boom")
+		}).
+		Node("after", func(ctx context.Context, in *workflow.Results) (any, error) {
+			downstream.Store(true)
+			return nil, nil
+		}, workflow.After("fail")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	_, err = wf.Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), `"fail"`) {
+		t.Fatalf("Run() error = %v, want error naming the failed node", err)
+	}
+	if downstream.Load() {
+		t.Error("downstream node ran after failure")
+	}
+}
+
+func TestGateResumeFromCheckpoint(t *testing.T) {
+	var draftCalls atomic.Int32
+	var approved atomic.Bool
+
+	wf, err := workflow.NewBuilder().
+		Node("draft", func(ctx context.Context, in *workflow.Results) (any, error) {
+			draftCalls.Add(1)
+			return map[string]string{"title": "Hello"}, nil
+		}).
+		Node("review", workflow.Gate(func(ctx context.Context, in *workflow.Results) (bool, error) {
+			return approved.Load(), nil
+		}), workflow.After("draft")).
+		Node("publish", func(ctx context.Context, in *workflow.Results) (any, error) {
+			draft, err := workflow.Value[map[string]string](in, "draft")
+			if err != nil {
+				return nil, err
+			}
+			return "published " + draft["title"], nil
+		}, workflow.After("review")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	cp := workflow.NewMemoryCheckpointer()
+
+	_, err = wf.Run(context.Background(), workflow.WithCheckpointer(cp, "run-1"))
+	if !errors.Is(err, workflow.ErrRejected) {
+		t.Fatalf("first Run() error = %v, want ErrRejected", err)
+	}
+
+	approved.Store(true)
+	results, err := wf.Run(context.Background(), workflow.WithCheckpointer(cp, "run-1"))
+	if err != nil {
+		t.Fatalf("resumed Run() error = %v", err)
+	}
+	if draftCalls.Load() != 1 {
+		t.Errorf("draft ran %d times, want 1 (restored from checkpoint)", draftCalls.Load())
+	}
+	if v, _ := workflow.Value[string](results, "publish"); v != "published Hello" {
+		t.Errorf("publish = %q", v)
+	}
+}
+
+func TestLLMNode(t *testing.T) {
+	provider := iristesting.NewMockProvider(core.ChatResponse{Output: "Paris"})
+	client := core.NewClient(provider)
+
+	wf, err := workflow.NewBuilder().
+		Node("country", constant("France")).
+		Node("capital", workflow.LLM(client, "mock-model", func(in *workflow.Results) (string, error) {
+			country, err := workflow.Value[string](in, "country")
+			return "Capital of " + country + "?", err
+		}), workflow.After("country")).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	results, err := wf.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	resp, err := workflow.Value[*core.ChatResponse](results, "capital")
+	if err != nil || resp.Output != "Paris" {
+		t.Errorf("capital = %+v, %v", resp, err)
+	}
+	if got := provider.LastCall().Request.Messages[0].Content; got != "Capital of France?" {
+		t.Errorf("prompt = %q", got)
+	}
+}
+
+type upperTool struct{}
+
+func (upperTool) Name() string             { return "upper" }
+func (upperTool) Description() string      { return "Uppercases text" }
+func (upperTool) Schema() tools.ToolSchema { return tools.ToolSchema{} }
+func (upperTool) Call(ctx context.Context, args json.RawMessage) (any, error) {
+	var in struct{ Text string }
+	if err := json.Unmarshal(args, &in); err != nil {
+		return nil, err
+	}
+	return strings.ToUpper(in.Text), nil
+}
+
+func TestToolNode(t *testing.T) {
+	wf, err := workflow.NewBuilder().
+		Node("upper", workflow.Tool(upperTool{}, func(in *workflow.Results) (any, error) {
+			return map[string]string{"Text": "hi"}, nil
+		})).
+		Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	results, err := wf.Run(context.Background())
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if v, _ := workflow.Value[string](results, "upper"); v != "HI" {
+		t.Errorf("upper = %q, want HI", v)
+	}
+}